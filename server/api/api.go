@@ -7,8 +7,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"runtime/debug"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/mattermost/mattermost-plugin-boards/server/app"
@@ -23,6 +25,8 @@ const (
 	HeaderRequestedWith    = "X-Requested-With"
 	HeaderRequestedWithXML = "XMLHttpRequest"
 	UploadFormFileKey      = "file"
+	HeaderSharePassword    = "X-Share-Password"
+	HeaderHookToken        = "X-Hook-Token"
 	True                   = "true"
 
 	ErrorNoTeamCode    = 1000
@@ -95,6 +99,26 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 
 	// V3 routes
 	a.registerCardsRoutes(apiv2)
+	a.registerCardDependenciesRoutes(apiv2)
+	a.registerCardRecurrencesRoutes(apiv2)
+	a.registerBoardRulesRoutes(apiv2)
+	a.registerBoardWebhooksRoutes(apiv2)
+	a.registerIncomingWebhooksRoutes(apiv2)
+	a.registerRetentionPoliciesRoutes(apiv2)
+	a.registerShareLinksRoutes(apiv2)
+	a.registerGuestCommentsRoutes(apiv2)
+	a.registerTimeEntriesRoutes(apiv2)
+	a.registerIterationsRoutes(apiv2)
+	a.registerBoardAnalyticsRoutes(apiv2)
+	a.registerCardRemindersRoutes(apiv2)
+	a.registerChannelFeedsRoutes(apiv2)
+	a.registerBoardCustomRolesRoutes(apiv2)
+
+	// Incoming webhooks are called by external systems, without a session
+	// nor the CSRF header of the web app
+	hooks := r.PathPrefix("/hooks").Subrouter()
+	hooks.Use(a.panicHandler)
+	hooks.HandleFunc("/{webhookID}", a.handleExecuteIncomingWebhook).Methods("POST")
 
 	// System routes are outside the /api/v2 path
 	a.registerSystemRoutes(r)
@@ -143,21 +167,76 @@ func (a *API) checkCSRFToken(r *http.Request) bool {
 	return token == HeaderRequestedWithXML
 }
 
-func (a *API) hasValidReadTokenForBoard(r *http.Request, boardID string) bool {
-	query := r.URL.Query()
-	readToken := query.Get("read_token")
+// getReadTokenAccess returns the access the read token of a request gives to
+// a board, nil if the request has no valid read token. The password of a
+// share link is read from the X-Share-Password header, and an unauthorized
+// error is returned if it is missing or wrong. Every use of a share link is
+// recorded in its access log, including the requests rejected because of a
+// wrong password.
+func (a *API) getReadTokenAccess(r *http.Request, boardID string) (*model.ReadTokenAccess, error) {
+	readToken := r.URL.Query().Get("read_token")
 
 	if len(readToken) < 1 {
-		return false
+		return nil, nil
 	}
 
-	isValid, err := a.app.IsValidReadToken(boardID, readToken)
+	password := r.Header.Get(HeaderSharePassword)
+
+	access, err := a.app.GetReadTokenAccess(boardID, readToken, password, a.getClientIP(r))
+	var passwordErr model.ErrWrongShareLinkPassword
+	if errors.As(err, &passwordErr) {
+		if password != "" {
+			a.recordShareLinkAccess(r, boardID, passwordErr.ShareLinkID, true)
+		}
+		return nil, model.NewErrUnauthorized(err.Error())
+	}
+	if model.IsErrTooManyRequests(err) {
+		return nil, err
+	}
 	if err != nil {
 		a.logger.Error("IsValidReadTokenForBoard ERROR", mlog.Err(err))
-		return false
+		return nil, nil
+	}
+
+	if access != nil && access.ShareLinkID != "" {
+		a.recordShareLinkAccess(r, boardID, access.ShareLinkID, false)
+	}
+
+	return access, nil
+}
+
+func (a *API) recordShareLinkAccess(r *http.Request, boardID, shareLinkID string, failed bool) {
+	shareLinkAccess := &model.ShareLinkAccess{
+		ShareLinkID: shareLinkID,
+		BoardID:     boardID,
+		RemoteAddr:  a.getClientIP(r),
+		UserAgent:   r.UserAgent(),
+		Failed:      failed,
+	}
+	if err := a.app.RecordShareLinkAccess(shareLinkAccess); err != nil {
+		a.logger.Warn("Cannot record share link access",
+			mlog.String("shareLinkID", shareLinkID),
+			mlog.Err(err),
+		)
+	}
+}
+
+// getClientIP returns the address of the client of a request. As in the
+// Mattermost server, it is read from the first of the trusted proxy headers
+// of the configuration that is set, and otherwise from the remote address of
+// the request, without its port.
+func (a *API) getClientIP(r *http.Request) string {
+	for _, header := range a.app.GetConfig().TrustedProxyIPHeader {
+		// X-Forwarded-For lists the client first, followed by the proxies
+		if ip := strings.TrimSpace(strings.Split(r.Header.Get(header), ",")[0]); ip != "" {
+			return ip
+		}
 	}
 
-	return isValid
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
 }
 
 func (a *API) userIsGuest(userID string) (bool, error) {
@@ -180,6 +259,8 @@ func (a *API) errorResponse(w http.ResponseWriter, r *http.Request, err error) {
 		errorResponse.ErrorCode = http.StatusNotFound
 	case model.IsErrRequestEntityTooLarge(err):
 		errorResponse.ErrorCode = http.StatusRequestEntityTooLarge
+	case model.IsErrTooManyRequests(err):
+		errorResponse.ErrorCode = http.StatusTooManyRequests
 	case model.IsErrNotImplemented(err):
 		errorResponse.ErrorCode = http.StatusNotImplemented
 	default:
@@ -4,6 +4,8 @@
 package api
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
@@ -11,6 +13,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/mattermost/mattermost-plugin-boards/server/model"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/xlsx"
 
 	mmModel "github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
@@ -25,6 +28,8 @@ func (a *API) registerAchivesRoutes(r *mux.Router) {
 	r.HandleFunc("/boards/{boardID}/archive/export", a.sessionRequired(a.handleArchiveExportBoard)).Methods("GET")
 	r.HandleFunc("/teams/{teamID}/archive/import", a.sessionRequired(a.handleArchiveImport)).Methods("POST")
 	r.HandleFunc("/teams/{teamID}/archive/export", a.sessionRequired(a.handleArchiveExportTeam)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/import/csv", a.sessionRequired(a.handleImportBoardCSV)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/export/cards", a.sessionRequired(a.handleExportBoardCards)).Methods("GET")
 }
 
 func (a *API) handleArchiveExportBoard(w http.ResponseWriter, r *http.Request) {
@@ -180,6 +185,194 @@ func (a *API) handleArchiveImport(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+func (a *API) handleImportBoardCSV(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/import/csv importBoardCSV
+	//
+	// Imports the rows of a CSV file as cards of a board. The columns are
+	// matched with the card properties by name, and nothing is imported if
+	// any row has an error.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// consumes:
+	// - multipart/form-data
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: dry_run
+	//   in: query
+	//   description: Only validate the rows, without importing them
+	//   required: false
+	//   type: boolean
+	// - name: file
+	//   in: formData
+	//   description: CSV file to import
+	//   required: true
+	//   type: file
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CSVImportResult"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	dryRun := r.URL.Query().Get("dry_run") == True
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to import cards"))
+		return
+	}
+
+	file, handle, err := r.FormFile(UploadFormFileKey)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	defer file.Close()
+
+	auditRec := a.makeAuditRecord(r, "importBoardCSV", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("filename", handle.Filename)
+	auditRec.AddMeta("size", handle.Size)
+	auditRec.AddMeta("dryRun", dryRun)
+
+	opt := model.ImportCSVOptions{
+		BoardID:    boardID,
+		ModifiedBy: userID,
+		DryRun:     dryRun,
+		// adding the options that are missing from the board changes its properties
+		AllowNewOptions: a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties),
+	}
+
+	result, err := a.app.ImportBoardCSV(file, opt)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("ImportBoardCSV",
+		mlog.String("boardID", boardID),
+		mlog.Bool("dryRun", dryRun),
+		mlog.Int("rowCount", result.RowCount),
+		mlog.Int("errorCount", len(result.Errors)),
+	)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("cardCount", len(result.CardIDs))
+	auditRec.Success()
+}
+
+func (a *API) handleExportBoardCards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/export/cards exportBoardCards
+	//
+	// Exports the cards of a board, or of one of its views, as a CSV or XLSX
+	// spreadsheet.
+	//
+	// ---
+	// produces:
+	// - text/csv
+	// - application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: format
+	//   in: query
+	//   description: The format of the export, csv (default) or xlsx
+	//   required: false
+	//   type: string
+	// - name: view_id
+	//   in: query
+	//   description: The view whose filter, sort and visible properties are applied
+	//   required: false
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     content:
+	//       application-octet-stream:
+	//         type: string
+	//         format: binary
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	query := r.URL.Query()
+
+	opt := model.CardExportOptions{
+		BoardID: boardID,
+		ViewID:  query.Get("view_id"),
+		Format:  query.Get("format"),
+	}
+	if opt.Format == "" {
+		opt.Format = model.CardExportFormatCSV
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "exportBoardCards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("viewID", opt.ViewID)
+	auditRec.AddMeta("format", opt.Format)
+
+	// the export is buffered so that an error can still be responded
+	var buf bytes.Buffer
+	if err := a.app.ExportBoardCards(&buf, opt); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("ExportBoardCards",
+		mlog.String("boardID", boardID),
+		mlog.String("viewID", opt.ViewID),
+		mlog.String("format", opt.Format),
+	)
+
+	contentType := "text/csv"
+	if opt.Format == model.CardExportFormatXLSX {
+		contentType = xlsx.ContentType
+	}
+
+	filename := fmt.Sprintf("cards-%s.%s", time.Now().Format("2006-01-02"), opt.Format)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+
+	auditRec.Success()
+}
+
 func (a *API) handleArchiveExportTeam(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /teams/{teamID}/archive/export archiveExportTeam
 	//
@@ -77,8 +77,13 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 
 	userID := getUserID(r)
 
-	hasValidReadToken := a.hasValidReadTokenForBoard(r, boardID)
+	readTokenAccess, err := a.getReadTokenAccess(r, boardID)
+	hasValidReadToken := readTokenAccess != nil
 	if userID == "" && !hasValidReadToken {
+		if err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
 		a.errorResponse(w, r, model.NewErrUnauthorized("access denied to board"))
 		return
 	}
@@ -154,6 +159,14 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if hasValidReadToken && readTokenAccess.ViewID != "" {
+		blocks, err = a.app.FilterBlocksForView(boardID, readTokenAccess.ViewID, blocks)
+		if err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+	}
+
 	a.logger.Debug("GetBlocks",
 		mlog.String("boardID", boardID),
 		mlog.String("parentID", parentID),
@@ -546,7 +559,9 @@ func (a *API) handlePatchBlock(w http.ResponseWriter, r *http.Request) {
 	val := r.URL.Query().Get("disable_notify")
 	disableNotify := val == True
 
-	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+	// members of a custom role may be allowed to edit card properties only
+	canManageCards := a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards)
+	if !canManageCards && !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionEditBoardCardProperties) {
 		a.errorResponse(w, r, model.NewErrPermission("access denied to make board changes"))
 		return
 	}
@@ -575,6 +590,18 @@ func (a *API) handlePatchBlock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !canManageCards {
+		if patch == nil {
+			a.errorResponse(w, r, model.NewErrPermission("access denied to make board changes"))
+			return
+		}
+		propertyIDs, ok := patch.ChangedCardPropertyIDs(block)
+		if !ok || !a.canEditCardProperties(userID, boardID, propertyIDs) {
+			a.errorResponse(w, r, model.NewErrPermission("access denied to make board changes"))
+			return
+		}
+	}
+
 	auditRec := a.makeAuditRecord(r, "patchBlock", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 	auditRec.AddMeta("boardID", boardID)
@@ -0,0 +1,153 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerBoardAnalyticsRoutes(r *mux.Router) {
+	// Board analytics APIs
+	r.HandleFunc("/boards/{boardID}/analytics", a.sessionRequired(a.handleGetBoardAnalytics)).Methods("GET")
+}
+
+func (a *API) handleGetBoardAnalytics(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/analytics getBoardAnalytics
+	//
+	// Returns the time in state of each card, the cycle and lead time
+	// distributions, the weekly throughput and the cumulative flow of a board
+	// for the options of a select property, computed from the history of the
+	// cards.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: property_id
+	//   in: query
+	//   description: ID of the select property whose options are the states of the cards
+	//   required: true
+	//   type: string
+	// - name: from
+	//   in: query
+	//   description: Start of the date range in milliseconds, defaults to 90 days before its end
+	//   required: false
+	//   type: integer
+	// - name: to
+	//   in: query
+	//   description: End of the date range in milliseconds, defaults to now
+	//   required: false
+	//   type: integer
+	// - name: start_options
+	//   in: query
+	//   description: Comma separated IDs of the options where work on a card starts, defaults to all options but the first
+	//   required: false
+	//   type: string
+	// - name: done_options
+	//   in: query
+	//   description: Comma separated IDs of the options where a card is done, defaults to the last option
+	//   required: false
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardAnalytics"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	query := r.URL.Query()
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	opts := model.BoardAnalyticsOptions{
+		PropertyID:     query.Get("property_id"),
+		StartOptionIDs: splitQueryList(query.Get("start_options")),
+		DoneOptionIDs:  splitQueryList(query.Get("done_options")),
+	}
+	if opts.PropertyID == "" {
+		a.errorResponse(w, r, model.NewErrBadRequest("missing `property_id` parameter"))
+		return
+	}
+
+	var err error
+	if strFrom := query.Get("from"); strFrom != "" {
+		if opts.From, err = strconv.ParseInt(strFrom, 10, 64); err != nil {
+			message := fmt.Sprintf("invalid `from` parameter: %s", err)
+			a.errorResponse(w, r, model.NewErrBadRequest(message))
+			return
+		}
+	}
+	if strTo := query.Get("to"); strTo != "" {
+		if opts.To, err = strconv.ParseInt(strTo, 10, 64); err != nil {
+			message := fmt.Sprintf("invalid `to` parameter: %s", err)
+			a.errorResponse(w, r, model.NewErrBadRequest(message))
+			return
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardAnalytics", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("propertyID", opts.PropertyID)
+
+	analytics, err := a.app.GetBoardAnalytics(boardID, opts)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetBoardAnalytics",
+		mlog.String("boardID", boardID),
+		mlog.String("propertyID", opts.PropertyID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(analytics)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+// splitQueryList splits a comma separated query parameter, ignoring empty
+// items.
+func splitQueryList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
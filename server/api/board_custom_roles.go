@@ -0,0 +1,366 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerBoardCustomRolesRoutes(r *mux.Router) {
+	// Board custom role APIs
+	r.HandleFunc("/boards/{boardID}/custom-roles", a.sessionRequired(a.handleGetBoardCustomRoles)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/custom-roles", a.sessionRequired(a.handleCreateBoardCustomRole)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/custom-roles/{roleID}", a.sessionRequired(a.handleUpdateBoardCustomRole)).Methods("PUT")
+	r.HandleFunc("/boards/{boardID}/custom-roles/{roleID}", a.sessionRequired(a.handleDeleteBoardCustomRole)).Methods("DELETE")
+}
+
+func (a *API) handleGetBoardCustomRoles(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/custom-roles getBoardCustomRoles
+	//
+	// Returns the custom roles of a board, sorted by name.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BoardCustomRole"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardCustomRoles", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	roles, err := a.app.GetBoardCustomRolesForBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetBoardCustomRoles",
+		mlog.String("boardID", boardID),
+		mlog.Int("roleCount", len(roles)),
+	)
+
+	data, err := json.Marshal(roles)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("roleCount", len(roles))
+	auditRec.Success()
+}
+
+func (a *API) handleCreateBoardCustomRole(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/custom-roles createBoardCustomRole
+	//
+	// Creates a custom role for a board, granting a set of board permissions
+	// to the members it is assigned to. A custom role is assigned to a member
+	// through its customRoleId, and replaces the member's scheme roles.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the name, permissions and editable properties of the role
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardCustomRole"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardCustomRole"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to create board custom role"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var role model.BoardCustomRole
+	if err = json.Unmarshal(requestBody, &role); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	role.BoardID = boardID
+
+	auditRec := a.makeAuditRecord(r, "createBoardCustomRole", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	newRole, err := a.app.CreateBoardCustomRole(&role, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateBoardCustomRole",
+		mlog.String("boardID", boardID),
+		mlog.String("roleID", newRole.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(newRole)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("roleID", newRole.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleUpdateBoardCustomRole(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/custom-roles/{roleID} updateBoardCustomRole
+	//
+	// Replaces the name, description, permissions and editable properties of a
+	// custom role of a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: roleID
+	//   in: path
+	//   description: Custom role ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the updated role
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardCustomRole"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardCustomRole"
+	//   '404':
+	//     description: custom role not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	roleID := vars["roleID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to update board custom role"))
+		return
+	}
+
+	if _, err := a.getBoardCustomRole(boardID, roleID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var role model.BoardCustomRole
+	if err = json.Unmarshal(requestBody, &role); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	role.ID = roleID
+	role.BoardID = boardID
+
+	auditRec := a.makeAuditRecord(r, "updateBoardCustomRole", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("roleID", roleID)
+
+	updatedRole, err := a.app.UpdateBoardCustomRole(&role, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("UpdateBoardCustomRole",
+		mlog.String("boardID", boardID),
+		mlog.String("roleID", roleID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(updatedRole)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteBoardCustomRole(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/custom-roles/{roleID} deleteBoardCustomRole
+	//
+	// Deletes a custom role of a board. The members of the role are left with
+	// the viewer role.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: roleID
+	//   in: path
+	//   description: Custom role ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: custom role not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	roleID := vars["roleID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to delete board custom role"))
+		return
+	}
+
+	if _, err := a.getBoardCustomRole(boardID, roleID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteBoardCustomRole", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("roleID", roleID)
+
+	if err := a.app.DeleteBoardCustomRole(roleID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteBoardCustomRole",
+		mlog.String("boardID", boardID),
+		mlog.String("roleID", roleID),
+		mlog.String("userID", userID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
+
+// getBoardCustomRole returns a custom role, if it is a role of the board.
+func (a *API) getBoardCustomRole(boardID, roleID string) (*model.BoardCustomRole, error) {
+	role, err := a.app.GetBoardCustomRole(roleID)
+	if err != nil {
+		return nil, err
+	}
+	if role.BoardID != boardID {
+		return nil, model.NewErrNotFound("board custom role ID=" + roleID)
+	}
+	return role, nil
+}
+
+// canEditCardProperties returns true if a user who cannot manage the cards
+// of a board may still change the given properties of its cards, through the
+// edit_board_card_properties permission of its custom role.
+func (a *API) canEditCardProperties(userID, boardID string, propertyIDs []string) bool {
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionEditBoardCardProperties) {
+		return false
+	}
+
+	role, err := a.app.GetCustomRoleForMember(boardID, userID)
+	if err != nil {
+		return false
+	}
+	return role.CanEditCardProperties(propertyIDs)
+}
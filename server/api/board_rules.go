@@ -0,0 +1,442 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerBoardRulesRoutes(r *mux.Router) {
+	// Board automation rule APIs
+	r.HandleFunc("/boards/{boardID}/rules", a.sessionRequired(a.handleGetBoardRules)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/rules", a.sessionRequired(a.handleCreateBoardRule)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/rules/{ruleID}", a.sessionRequired(a.handleGetBoardRule)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/rules/{ruleID}", a.sessionRequired(a.handleUpdateBoardRule)).Methods("PUT")
+	r.HandleFunc("/boards/{boardID}/rules/{ruleID}", a.sessionRequired(a.handleDeleteBoardRule)).Methods("DELETE")
+}
+
+func (a *API) handleGetBoardRules(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/rules getBoardRules
+	//
+	// Returns the automation rules of a board, in the order they are evaluated.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BoardRule"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardRules", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	rules, err := a.app.GetBoardRulesForBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetBoardRules",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int("count", len(rules)),
+	)
+
+	data, err := json.Marshal(rules)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleCreateBoardRule(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/rules createBoardRule
+	//
+	// Creates an automation rule for a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the rule to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardRule"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardRule"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to create board rule"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var rule model.BoardRule
+	if err = json.Unmarshal(requestBody, &rule); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	rule.BoardID = boardID
+
+	if err = a.checkBoardRuleActionsPermissions(userID, &rule); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createBoardRule", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	newRule, err := a.app.CreateBoardRule(&rule, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateBoardRule",
+		mlog.String("boardID", boardID),
+		mlog.String("ruleID", newRule.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(newRule)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("ruleID", newRule.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleGetBoardRule(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/rules/{ruleID} getBoardRule
+	//
+	// Returns an automation rule of a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: ruleID
+	//   in: path
+	//   description: Rule ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardRule"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	ruleID := vars["ruleID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardRule", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("ruleID", ruleID)
+
+	rule, err := a.getBoardRule(boardID, ruleID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetBoardRule",
+		mlog.String("boardID", boardID),
+		mlog.String("ruleID", ruleID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(rule)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleUpdateBoardRule(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/rules/{ruleID} updateBoardRule
+	//
+	// Replaces the title, state, trigger and actions of an automation rule.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: ruleID
+	//   in: path
+	//   description: Rule ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the updated rule
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardRule"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardRule"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	ruleID := vars["ruleID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to update board rule"))
+		return
+	}
+
+	if _, err := a.getBoardRule(boardID, ruleID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var rule model.BoardRule
+	if err = json.Unmarshal(requestBody, &rule); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	rule.ID = ruleID
+	rule.BoardID = boardID
+
+	if err = a.checkBoardRuleActionsPermissions(userID, &rule); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "updateBoardRule", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("ruleID", ruleID)
+
+	updatedRule, err := a.app.UpdateBoardRule(&rule, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("UpdateBoardRule",
+		mlog.String("boardID", boardID),
+		mlog.String("ruleID", ruleID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(updatedRule)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteBoardRule(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/rules/{ruleID} deleteBoardRule
+	//
+	// Deletes an automation rule of a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: ruleID
+	//   in: path
+	//   description: Rule ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	ruleID := vars["ruleID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to delete board rule"))
+		return
+	}
+
+	if _, err := a.getBoardRule(boardID, ruleID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteBoardRule", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("ruleID", ruleID)
+
+	if err := a.app.DeleteBoardRule(ruleID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteBoardRule",
+		mlog.String("boardID", boardID),
+		mlog.String("ruleID", ruleID),
+		mlog.String("userID", userID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
+
+// getBoardRule returns a rule only if it belongs to the board.
+func (a *API) getBoardRule(boardID, ruleID string) (*model.BoardRule, error) {
+	rule, err := a.app.GetBoardRule(ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if rule.BoardID != boardID {
+		return nil, model.NewErrNotFound("board rule ID=" + ruleID)
+	}
+	return rule, nil
+}
+
+// checkBoardRuleActionsPermissions checks that the user can perform the actions
+// of a rule that reach outside of its board.
+func (a *API) checkBoardRuleActionsPermissions(userID string, rule *model.BoardRule) error {
+	for _, action := range rule.Actions {
+		switch action.Type {
+		case model.RuleActionMoveCard:
+			if !a.permissions.HasPermissionToBoard(userID, action.BoardID, model.PermissionManageBoardCards) {
+				return model.NewErrPermission("access denied to the destination board of the rule")
+			}
+		case model.RuleActionPostToChannel:
+			if !a.permissions.HasPermissionToChannel(userID, action.ChannelID, model.PermissionCreatePost) {
+				return model.NewErrPermission("access denied to the channel of the rule")
+			}
+		}
+	}
+	return nil
+}
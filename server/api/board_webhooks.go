@@ -0,0 +1,535 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerBoardWebhooksRoutes(r *mux.Router) {
+	// Board outgoing webhook APIs
+	r.HandleFunc("/boards/{boardID}/webhooks", a.sessionRequired(a.handleGetBoardWebhooks)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/webhooks", a.sessionRequired(a.handleCreateBoardWebhook)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/webhooks/{webhookID}", a.sessionRequired(a.handleGetBoardWebhook)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/webhooks/{webhookID}", a.sessionRequired(a.handleUpdateBoardWebhook)).Methods("PUT")
+	r.HandleFunc("/boards/{boardID}/webhooks/{webhookID}", a.sessionRequired(a.handleDeleteBoardWebhook)).Methods("DELETE")
+	r.HandleFunc("/boards/{boardID}/webhooks/{webhookID}/deliveries", a.sessionRequired(a.handleGetWebhookDeliveries)).Methods("GET")
+}
+
+func (a *API) handleGetBoardWebhooks(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/webhooks getBoardWebhooks
+	//
+	// Returns the outgoing webhooks of a board. Their secrets are not returned.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BoardWebhook"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board webhooks"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardWebhooks", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	webhooks, err := a.app.GetBoardWebhooksForBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetBoardWebhooks",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int("count", len(webhooks)),
+	)
+
+	data, err := json.Marshal(webhooks)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleCreateBoardWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/webhooks createBoardWebhook
+	//
+	// Creates an outgoing webhook for a board. The response contains the
+	// generated secret the payloads are signed with, which is not returned
+	// afterwards.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the webhook to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardWebhook"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardWebhook"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to create board webhook"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var webhook model.BoardWebhook
+	if err = json.Unmarshal(requestBody, &webhook); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	webhook.BoardID = boardID
+
+	auditRec := a.makeAuditRecord(r, "createBoardWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	newWebhook, err := a.app.CreateBoardWebhook(&webhook, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateBoardWebhook",
+		mlog.String("boardID", boardID),
+		mlog.String("webhookID", newWebhook.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(newWebhook)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("webhookID", newWebhook.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleGetBoardWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/webhooks/{webhookID} getBoardWebhook
+	//
+	// Returns an outgoing webhook of a board. Its secret is not returned.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: webhookID
+	//   in: path
+	//   description: Webhook ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardWebhook"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	webhookID := vars["webhookID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board webhooks"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("webhookID", webhookID)
+
+	webhook, err := a.getBoardWebhook(boardID, webhookID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetBoardWebhook",
+		mlog.String("boardID", boardID),
+		mlog.String("webhookID", webhookID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(webhook)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleUpdateBoardWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/webhooks/{webhookID} updateBoardWebhook
+	//
+	// Replaces the URL, events and state of an outgoing webhook. Its secret
+	// cannot be changed.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: webhookID
+	//   in: path
+	//   description: Webhook ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the updated webhook
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardWebhook"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardWebhook"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	webhookID := vars["webhookID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to update board webhook"))
+		return
+	}
+
+	if _, err := a.getBoardWebhook(boardID, webhookID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var webhook model.BoardWebhook
+	if err = json.Unmarshal(requestBody, &webhook); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	webhook.ID = webhookID
+	webhook.BoardID = boardID
+
+	auditRec := a.makeAuditRecord(r, "updateBoardWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("webhookID", webhookID)
+
+	updatedWebhook, err := a.app.UpdateBoardWebhook(&webhook)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("UpdateBoardWebhook",
+		mlog.String("boardID", boardID),
+		mlog.String("webhookID", webhookID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(updatedWebhook)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteBoardWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/webhooks/{webhookID} deleteBoardWebhook
+	//
+	// Deletes an outgoing webhook of a board, along with its delivery log.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: webhookID
+	//   in: path
+	//   description: Webhook ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	webhookID := vars["webhookID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to delete board webhook"))
+		return
+	}
+
+	if _, err := a.getBoardWebhook(boardID, webhookID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteBoardWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("webhookID", webhookID)
+
+	if err := a.app.DeleteBoardWebhook(webhookID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteBoardWebhook",
+		mlog.String("boardID", boardID),
+		mlog.String("webhookID", webhookID),
+		mlog.String("userID", userID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/webhooks/{webhookID}/deliveries getWebhookDeliveries
+	//
+	// Returns the delivery log of an outgoing webhook, newest first.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: webhookID
+	//   in: path
+	//   description: Webhook ID
+	//   required: true
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: The page to select (default=0)
+	//   required: false
+	//   type: integer
+	// - name: per_page
+	//   in: query
+	//   description: Number of deliveries to return per page(default=100)
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/WebhookDelivery"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	webhookID := vars["webhookID"]
+
+	query := r.URL.Query()
+	strPage := query.Get("page")
+	strPerPage := query.Get("per_page")
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board webhooks"))
+		return
+	}
+
+	if strPage == "" {
+		strPage = defaultPage
+	}
+	if strPerPage == "" {
+		strPerPage = defaultPerPage
+	}
+
+	page, err := strconv.Atoi(strPage)
+	if err != nil {
+		message := fmt.Sprintf("invalid `page` parameter: %s", err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	perPage, err := strconv.Atoi(strPerPage)
+	if err != nil {
+		message := fmt.Sprintf("invalid `per_page` parameter: %s", err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if _, err = a.getBoardWebhook(boardID, webhookID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getWebhookDeliveries", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("webhookID", webhookID)
+	auditRec.AddMeta("page", page)
+	auditRec.AddMeta("per_page", perPage)
+
+	deliveries, err := a.app.GetWebhookDeliveries(webhookID, page, perPage)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetWebhookDeliveries",
+		mlog.String("boardID", boardID),
+		mlog.String("webhookID", webhookID),
+		mlog.String("userID", userID),
+		mlog.Int("count", len(deliveries)),
+	)
+
+	data, err := json.Marshal(deliveries)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+// getBoardWebhook returns a webhook only if it belongs to the board.
+func (a *API) getBoardWebhook(boardID, webhookID string) (*model.BoardWebhook, error) {
+	webhook, err := a.app.GetBoardWebhook(webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if webhook.BoardID != boardID {
+		return nil, model.NewErrNotFound("board webhook ID=" + webhookID)
+	}
+	return webhook, nil
+}
@@ -232,8 +232,13 @@ func (a *API) handleGetBoard(w http.ResponseWriter, r *http.Request) {
 	boardID := mux.Vars(r)["boardID"]
 	userID := getUserID(r)
 
-	hasValidReadToken := a.hasValidReadTokenForBoard(r, boardID)
+	readTokenAccess, err := a.getReadTokenAccess(r, boardID)
+	hasValidReadToken := readTokenAccess != nil
 	if userID == "" && !hasValidReadToken {
+		if err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
 		a.errorResponse(w, r, model.NewErrUnauthorized("access denied to board"))
 		return
 	}
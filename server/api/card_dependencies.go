@@ -0,0 +1,369 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerCardDependenciesRoutes(r *mux.Router) {
+	// Card dependency APIs
+	r.HandleFunc("/boards/{boardID}/dependencies", a.sessionRequired(a.handleGetBoardCardDependencies)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/dependencies", a.sessionRequired(a.handleGetCardDependencies)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/dependencies", a.sessionRequired(a.handleCreateCardDependency)).Methods("POST")
+	r.HandleFunc("/cards/{cardID}/dependencies/{dependencyID}", a.sessionRequired(a.handleDeleteCardDependency)).Methods("DELETE")
+}
+
+func (a *API) handleGetBoardCardDependencies(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/dependencies getBoardCardDependencies
+	//
+	// Returns the dependencies of the cards of a board, including the
+	// dependencies with cards of other boards.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/CardDependency"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardCardDependencies", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	dependencies, err := a.app.GetCardDependenciesForBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetBoardCardDependencies",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int("count", len(dependencies)),
+	)
+
+	data, err := json.Marshal(dependencies)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetCardDependencies(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /cards/{cardID}/dependencies getCardDependencies
+	//
+	// Returns the dependencies of a card, both the cards blocking it and
+	// the cards it blocks.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/CardDependency"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		message := fmt.Sprintf("could not fetch card %s: %s", cardID, err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch card dependencies"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardDependencies", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", card.ID)
+
+	dependencies, err := a.app.GetCardDependenciesForCard(card.ID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetCardDependencies",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", card.ID),
+		mlog.String("userID", userID),
+		mlog.Int("count", len(dependencies)),
+	)
+
+	data, err := json.Marshal(dependencies)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleCreateCardDependency(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/dependencies createCardDependency
+	//
+	// Creates a dependency between the card and another card of the same
+	// team. The card must be either the blocking or the blocked card of the
+	// dependency; when one of them is omitted, the card takes its place.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the dependency to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardDependency"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CardDependency"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var dependency model.CardDependency
+	if err = json.Unmarshal(requestBody, &dependency); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if dependency.BlockingCardID == "" {
+		dependency.BlockingCardID = cardID
+	}
+	if dependency.BlockedCardID == "" {
+		dependency.BlockedCardID = cardID
+	}
+
+	if !dependency.Involves(cardID) {
+		a.errorResponse(w, r, model.NewErrBadRequest("the card must be part of the dependency"))
+		return
+	}
+
+	if err = dependency.IsValid(); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		message := fmt.Sprintf("could not fetch card %s: %s", cardID, err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to create card dependency"))
+		return
+	}
+
+	otherCardID := dependency.BlockedCardID
+	if otherCardID == cardID {
+		otherCardID = dependency.BlockingCardID
+	}
+
+	otherCard, err := a.app.GetCardByID(otherCardID)
+	if err != nil {
+		message := fmt.Sprintf("could not fetch card %s: %s", otherCardID, err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, otherCard.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to create card dependency"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createCardDependency", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("blockingCardID", dependency.BlockingCardID)
+	auditRec.AddMeta("blockedCardID", dependency.BlockedCardID)
+
+	newDependency, err := a.app.CreateCardDependency(&dependency, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateCardDependency",
+		mlog.String("dependencyID", newDependency.ID),
+		mlog.String("blockingCardID", newDependency.BlockingCardID),
+		mlog.String("blockedCardID", newDependency.BlockedCardID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(newDependency)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("dependencyID", newDependency.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteCardDependency(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /cards/{cardID}/dependencies/{dependencyID} deleteCardDependency
+	//
+	// Deletes a dependency of a card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: dependencyID
+	//   in: path
+	//   description: ID of the dependency to delete
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	cardID := vars["cardID"]
+	dependencyID := vars["dependencyID"]
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		message := fmt.Sprintf("could not fetch card %s: %s", cardID, err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to delete card dependency"))
+		return
+	}
+
+	dependency, err := a.app.GetCardDependency(dependencyID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !dependency.Involves(card.ID) {
+		a.errorResponse(w, r, model.NewErrNotFound("card dependency ID="+dependencyID))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteCardDependency", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("cardID", card.ID)
+	auditRec.AddMeta("dependencyID", dependencyID)
+
+	if err = a.app.DeleteCardDependency(dependencyID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteCardDependency",
+		mlog.String("cardID", card.ID),
+		mlog.String("dependencyID", dependencyID),
+		mlog.String("userID", userID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerCardRemindersRoutes(r *mux.Router) {
+	// Card reminder APIs
+	r.HandleFunc("/boards/{boardID}/reminder-settings", a.sessionRequired(a.handleGetCardReminderSettings)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/reminder-settings", a.sessionRequired(a.handleSetCardReminderSettings)).Methods("PUT")
+	r.HandleFunc("/boards/{boardID}/reminder-settings", a.sessionRequired(a.handleDeleteCardReminderSettings)).Methods("DELETE")
+}
+
+func (a *API) handleGetCardReminderSettings(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/reminder-settings getCardReminderSettings
+	//
+	// Returns the due date reminder settings of a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CardReminderSettings"
+	//   '404':
+	//     description: the board has no reminder settings
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardReminderSettings", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	settings, err := a.app.GetCardReminderSettings(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetCardReminderSettings",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(settings)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleSetCardReminderSettings(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/reminder-settings setCardReminderSettings
+	//
+	// Sets the due date reminder settings of a board, replacing any existing
+	// ones. The users assigned to a card through its person properties are
+	// sent a direct message before the due date of the card and when it
+	// becomes overdue.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the due date property and how many hours before the due date to send reminders
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardReminderSettings"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CardReminderSettings"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to set card reminder settings"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var settings model.CardReminderSettings
+	if err = json.Unmarshal(requestBody, &settings); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	settings.BoardID = boardID
+
+	auditRec := a.makeAuditRecord(r, "setCardReminderSettings", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("dueDatePropertyID", settings.DueDatePropertyID)
+
+	newSettings, err := a.app.SetCardReminderSettings(&settings, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SetCardReminderSettings",
+		mlog.String("boardID", boardID),
+		mlog.String("dueDatePropertyID", newSettings.DueDatePropertyID),
+		mlog.Int("hoursBefore", newSettings.HoursBefore),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(newSettings)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteCardReminderSettings(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/reminder-settings deleteCardReminderSettings
+	//
+	// Stops the due date reminders of a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to delete card reminder settings"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteCardReminderSettings", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	if err := a.app.DeleteCardReminderSettings(boardID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteCardReminderSettings",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
@@ -26,8 +26,12 @@ func (a *API) registerCardsRoutes(r *mux.Router) {
 	// Cards APIs
 	r.HandleFunc("/boards/{boardID}/cards", a.sessionRequired(a.handleCreateCard)).Methods("POST")
 	r.HandleFunc("/boards/{boardID}/cards", a.sessionRequired(a.handleGetCards)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/cards/query", a.sessionRequired(a.handleQueryCards)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/cards/from-post/{postID}", a.sessionRequired(a.handleCreateCardFromPost)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/views/{viewID}/cards", a.attachSession(a.handleGetViewCards)).Methods("GET")
 	r.HandleFunc("/cards/{cardID}", a.sessionRequired(a.handlePatchCard)).Methods("PATCH")
 	r.HandleFunc("/cards/{cardID}", a.sessionRequired(a.handleGetCard)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/preview", a.sessionRequired(a.handleGetCardPreview)).Methods("GET")
 }
 
 func (a *API) handleCreateCard(w http.ResponseWriter, r *http.Request) {
@@ -130,6 +134,118 @@ func (a *API) handleCreateCard(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+func (a *API) handleCreateCardFromPost(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/cards/from-post/{postID} createCardFromPost
+	//
+	// Creates a new card for the specified board from a post. The message of the post
+	// becomes the content of the card, its files are copied to the board and, optionally,
+	// the replies of its thread become comments.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: postID
+	//   in: path
+	//   description: Post ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the options for the card
+	//   required: false
+	//   schema:
+	//     "$ref": "#/definitions/CardFromPostOptions"
+	// - name: disable_notify
+	//   in: query
+	//   description: Disables notifications (for bulk data inserting)
+	//   required: false
+	//   type: bool
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Card'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	postID := vars["postID"]
+
+	val := r.URL.Query().Get("disable_notify")
+	disableNotify := val == True
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var opts model.CardFromPostOptions
+	if len(requestBody) != 0 {
+		if err = json.Unmarshal(requestBody, &opts); err != nil {
+			a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+			return
+		}
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to create card"))
+		return
+	}
+
+	post, err := a.app.GetPost(postID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToChannel(userID, post.ChannelId, model.PermissionReadChannel) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to post"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createCardFromPost", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("postID", postID)
+
+	card, err := a.app.CreateCardFromPost(boardID, postID, userID, opts, disableNotify)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateCardFromPost",
+		mlog.String("boardID", boardID),
+		mlog.String("postID", postID),
+		mlog.String("cardID", card.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(card)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
 func (a *API) handleGetCards(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /boards/{boardID}/cards getCards
 	//
@@ -230,6 +346,199 @@ func (a *API) handleGetCards(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+func (a *API) handleQueryCards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/cards/query queryCards
+	//
+	// Fetches the cards of the specified board that match a filter tree, sorted
+	// by the given sort options.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the card query
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardQuery"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Card"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch cards"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var query *model.CardQuery
+	if err = json.Unmarshal(requestBody, &query); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	if query == nil {
+		query = &model.CardQuery{}
+	}
+
+	if err = query.IsValid(); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "queryCards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("page", query.Page)
+	auditRec.AddMeta("per_page", query.PerPage)
+
+	cards, err := a.app.QueryCards(boardID, query)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("QueryCards",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int("page", query.Page),
+		mlog.Int("per_page", query.PerPage),
+		mlog.Int("count", len(cards)),
+	)
+
+	data, err := json.Marshal(cards)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetViewCards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/views/{viewID}/cards getViewCards
+	//
+	// Fetches the cards shown by a view, filtered, sorted and grouped with the
+	// view settings.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: viewID
+	//   in: path
+	//   description: View ID
+	//   required: true
+	//   type: string
+	// - name: read_token
+	//   in: query
+	//   description: Share token for read only access
+	//   required: false
+	//   type: string
+	// - name: X-Share-Password
+	//   in: header
+	//   description: Password of the share link of the read token
+	//   required: false
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/ViewCards"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	viewID := vars["viewID"]
+
+	readTokenAccess, err := a.getReadTokenAccess(r, boardID)
+	hasValidReadToken := readTokenAccess != nil
+	if userID == "" && !hasValidReadToken {
+		if err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+		a.errorResponse(w, r, model.NewErrUnauthorized("access denied to board"))
+		return
+	}
+
+	if !hasValidReadToken && !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch cards"))
+		return
+	}
+
+	if hasValidReadToken && readTokenAccess.ViewID != "" && readTokenAccess.ViewID != viewID {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to view"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getViewCards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("viewID", viewID)
+
+	viewCards, err := a.app.GetCardsForView(boardID, viewID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetViewCards",
+		mlog.String("boardID", boardID),
+		mlog.String("viewID", viewID),
+		mlog.String("userID", userID),
+		mlog.Int("count", len(viewCards.Cards)),
+	)
+
+	data, err := json.Marshal(viewCards)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
 func (a *API) handlePatchCard(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation PATCH /cards/{cardID}/cards patchCard
 	//
@@ -286,7 +595,9 @@ func (a *API) handlePatchCard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+	// members of a custom role may be allowed to edit card properties only
+	canManageCards := a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards)
+	if !canManageCards && !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionEditBoardCardProperties) {
 		a.errorResponse(w, r, model.NewErrPermission("access denied to patch card"))
 		return
 	}
@@ -297,6 +608,18 @@ func (a *API) handlePatchCard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !canManageCards {
+		if patch == nil {
+			a.errorResponse(w, r, model.NewErrPermission("access denied to patch card"))
+			return
+		}
+		propertyIDs, ok := patch.ChangedPropertyIDs()
+		if !ok || !a.canEditCardProperties(userID, card.BoardID, propertyIDs) {
+			a.errorResponse(w, r, model.NewErrPermission("access denied to patch card"))
+			return
+		}
+	}
+
 	auditRec := a.makeAuditRecord(r, "patchCard", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 	auditRec.AddMeta("boardID", card.BoardID)
@@ -390,3 +713,66 @@ func (a *API) handleGetCard(w http.ResponseWriter, r *http.Request) {
 
 	auditRec.Success()
 }
+
+func (a *API) handleGetCardPreview(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /cards/{cardID}/preview getCardPreview
+	//
+	// Fetches the preview of the specified card, as shown in the link previews of posts.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardPreview'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	preview, err := a.app.GetCardPreview(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, preview.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch card preview"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardPreview", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", preview.BoardID)
+	auditRec.AddMeta("cardID", preview.CardID)
+
+	a.logger.Debug("GetCardPreview",
+		mlog.String("boardID", preview.BoardID),
+		mlog.String("cardID", preview.CardID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(preview)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerChannelFeedsRoutes(r *mux.Router) {
+	// Channel feed APIs
+	r.HandleFunc("/boards/{boardID}/channel-feed", a.sessionRequired(a.handleGetChannelFeedSettings)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/channel-feed", a.sessionRequired(a.handleSetChannelFeedSettings)).Methods("PUT")
+	r.HandleFunc("/boards/{boardID}/channel-feed", a.sessionRequired(a.handleDeleteChannelFeedSettings)).Methods("DELETE")
+}
+
+func (a *API) handleGetChannelFeedSettings(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/channel-feed getChannelFeedSettings
+	//
+	// Returns the channel feed settings of a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/ChannelFeedSettings"
+	//   '404':
+	//     description: the board has no channel feed
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getChannelFeedSettings", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	settings, err := a.app.GetChannelFeedSettings(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetChannelFeedSettings",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(settings)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleSetChannelFeedSettings(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/channel-feed setChannelFeedSettings
+	//
+	// Sets the channel feed settings of a board, replacing any existing ones.
+	// The new cards, status changes and completed cards of the board are
+	// batched and posted to the channel linked to the board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the events to post, the status property and how many minutes to batch changes
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/ChannelFeedSettings"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/ChannelFeedSettings"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to set channel feed settings"))
+		return
+	}
+
+	board, err := a.app.GetBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	if board.ChannelID != "" && !a.permissions.HasPermissionToChannel(userID, board.ChannelID, model.PermissionCreatePost) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to post to the channel of the board"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var settings model.ChannelFeedSettings
+	if err = json.Unmarshal(requestBody, &settings); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	settings.BoardID = boardID
+
+	auditRec := a.makeAuditRecord(r, "setChannelFeedSettings", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("channelID", board.ChannelID)
+
+	newSettings, err := a.app.SetChannelFeedSettings(&settings, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SetChannelFeedSettings",
+		mlog.String("boardID", boardID),
+		mlog.String("channelID", board.ChannelID),
+		mlog.Int("windowMinutes", newSettings.WindowMinutes),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(newSettings)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteChannelFeedSettings(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/channel-feed deleteChannelFeedSettings
+	//
+	// Stops the channel feed of a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to delete channel feed settings"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteChannelFeedSettings", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	if err := a.app.DeleteChannelFeedSettings(boardID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteChannelFeedSettings",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
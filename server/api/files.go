@@ -124,8 +124,13 @@ func (a *API) handleServeFile(w http.ResponseWriter, r *http.Request) {
 	filename := vars["filename"]
 	userID := getUserID(r)
 
-	hasValidReadToken := a.hasValidReadTokenForBoard(r, boardID)
+	readTokenAccess, err := a.getReadTokenAccess(r, boardID)
+	hasValidReadToken := readTokenAccess != nil
 	if userID == "" && !hasValidReadToken {
+		if err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
 		a.errorResponse(w, r, model.NewErrUnauthorized("access denied to board"))
 		return
 	}
@@ -135,6 +140,13 @@ func (a *API) handleServeFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if hasValidReadToken && readTokenAccess.ViewID != "" {
+		if err = a.app.ValidateFileInView(boardID, readTokenAccess.ViewID, filename); err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+	}
+
 	board, err := a.app.GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r, err)
@@ -286,8 +298,13 @@ func (a *API) getFileInfo(w http.ResponseWriter, r *http.Request) {
 	filename := vars["filename"]
 	userID := getUserID(r)
 
-	hasValidReadToken := a.hasValidReadTokenForBoard(r, boardID)
+	readTokenAccess, err := a.getReadTokenAccess(r, boardID)
+	hasValidReadToken := readTokenAccess != nil
 	if userID == "" && !hasValidReadToken {
+		if err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
 		a.errorResponse(w, r, model.NewErrUnauthorized("access denied to board"))
 		return
 	}
@@ -304,11 +321,18 @@ func (a *API) getFileInfo(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("filename", filename)
 
 	// Validate that the file belongs to the specified board and team
-	if err := a.app.ValidateFileOwnership(teamID, boardID, filename); err != nil {
+	if err = a.app.ValidateFileOwnership(teamID, boardID, filename); err != nil {
 		a.errorResponse(w, r, model.NewErrPermission("access denied to file"))
 		return
 	}
 
+	if hasValidReadToken && readTokenAccess.ViewID != "" {
+		if err = a.app.ValidateFileInView(boardID, readTokenAccess.ViewID, filename); err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+	}
+
 	fileInfo, err := a.app.GetFileInfo(filename)
 	if err != nil && !model.IsErrNotFound(err) {
 		a.errorResponse(w, r, err)
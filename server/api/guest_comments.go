@@ -0,0 +1,336 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// guestCommentMaxBodySize is the maximum size of the body of a request
+// creating a guest comment, which is sent without a session.
+const guestCommentMaxBodySize = 64 << 10 // 64KB
+
+func (a *API) registerGuestCommentsRoutes(r *mux.Router) {
+	// Guest comment APIs
+	r.HandleFunc("/boards/{boardID}/cards/{cardID}/guest-comments", a.attachSession(a.handleCreateGuestComment)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/guest-comments", a.sessionRequired(a.handleGetGuestComments)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/guest-comments/{commentID}/approve", a.sessionRequired(a.handleApproveGuestComment)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/guest-comments/{commentID}/reject", a.sessionRequired(a.handleRejectGuestComment)).Methods("POST")
+}
+
+func (a *API) handleCreateGuestComment(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/cards/{cardID}/guest-comments createGuestComment
+	//
+	// Submits a comment on a card through a commenter share link. The comment
+	// is shown on the card once approved by a board admin.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: read_token
+	//   in: query
+	//   description: Token of a commenter share link
+	//   required: true
+	//   type: string
+	// - name: X-Share-Password
+	//   in: header
+	//   description: Password of the share link
+	//   required: false
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the display name of the author and the text of the comment
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/GuestComment"
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/GuestComment"
+	//   '413':
+	//     description: request body too large
+	//   '429':
+	//     description: too many comments submitted from the same address
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	cardID := vars["cardID"]
+
+	readTokenAccess, err := a.getReadTokenAccess(r, boardID)
+	if readTokenAccess == nil {
+		if err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+		a.errorResponse(w, r, model.NewErrUnauthorized("access denied to board"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(http.MaxBytesReader(w, r.Body, guestCommentMaxBodySize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			a.errorResponse(w, r, model.ErrRequestEntityTooLarge)
+			return
+		}
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var comment model.GuestComment
+	if err = json.Unmarshal(requestBody, &comment); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	comment.CardID = cardID
+	comment.RemoteAddr = a.getClientIP(r)
+
+	auditRec := a.makeAuditRecord(r, "createGuestComment", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("shareLinkID", readTokenAccess.ShareLinkID)
+
+	newComment, err := a.app.CreateGuestComment(readTokenAccess, &comment)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateGuestComment",
+		mlog.String("boardID", boardID),
+		mlog.String("cardID", cardID),
+		mlog.String("shareLinkID", readTokenAccess.ShareLinkID),
+		mlog.String("guestCommentID", newComment.ID),
+	)
+
+	data, err := json.Marshal(newComment)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("guestCommentID", newComment.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleGetGuestComments(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/guest-comments getGuestComments
+	//
+	// Returns the guest comments of a board, oldest first.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: status
+	//   in: query
+	//   description: Only return the comments with this status (pending, approved or rejected)
+	//   required: false
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/GuestComment"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	status := r.URL.Query().Get("status")
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board guest comments"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getGuestComments", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("status", status)
+
+	comments, err := a.app.GetGuestCommentsForBoard(boardID, status)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetGuestComments",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int("count", len(comments)),
+	)
+
+	data, err := json.Marshal(comments)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleApproveGuestComment(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/guest-comments/{commentID}/approve approveGuestComment
+	//
+	// Approves a pending guest comment, which is added to its card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: commentID
+	//   in: path
+	//   description: Guest comment ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/GuestComment"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	a.moderateGuestComment(w, r, "approveGuestComment", a.app.ApproveGuestComment)
+}
+
+func (a *API) handleRejectGuestComment(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/guest-comments/{commentID}/reject rejectGuestComment
+	//
+	// Rejects a pending guest comment, which is never shown on its card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: commentID
+	//   in: path
+	//   description: Guest comment ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/GuestComment"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	a.moderateGuestComment(w, r, "rejectGuestComment", a.app.RejectGuestComment)
+}
+
+// moderateGuestComment handles the approval or rejection of a guest comment
+// of a board by a board admin.
+func (a *API) moderateGuestComment(w http.ResponseWriter, r *http.Request, action string, moderate func(id, userID string) (*model.GuestComment, error)) {
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	commentID := vars["commentID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to moderate guest comments"))
+		return
+	}
+
+	comment, err := a.app.GetGuestComment(commentID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	if comment.BoardID != boardID {
+		a.errorResponse(w, r, model.NewErrNotFound("guest comment ID="+commentID))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, action, audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("guestCommentID", commentID)
+
+	moderatedComment, err := moderate(commentID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("ModerateGuestComment",
+		mlog.String("action", action),
+		mlog.String("boardID", boardID),
+		mlog.String("guestCommentID", commentID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(moderatedComment)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
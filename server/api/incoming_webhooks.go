@@ -0,0 +1,608 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// incomingWebhookMaxBodySize is the maximum size of the body of a request
+// executing an incoming webhook.
+const incomingWebhookMaxBodySize = 1 << 20 // 1MB
+
+func (a *API) registerIncomingWebhooksRoutes(r *mux.Router) {
+	// Incoming webhook APIs
+	r.HandleFunc("/boards/{boardID}/incoming-webhooks", a.sessionRequired(a.handleGetIncomingWebhooks)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/incoming-webhooks", a.sessionRequired(a.handleCreateIncomingWebhook)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/incoming-webhooks/{webhookID}", a.sessionRequired(a.handleGetIncomingWebhook)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/incoming-webhooks/{webhookID}", a.sessionRequired(a.handleUpdateIncomingWebhook)).Methods("PUT")
+	r.HandleFunc("/boards/{boardID}/incoming-webhooks/{webhookID}", a.sessionRequired(a.handleDeleteIncomingWebhook)).Methods("DELETE")
+	r.HandleFunc("/boards/{boardID}/incoming-webhooks/{webhookID}/token", a.sessionRequired(a.handleRegenerateIncomingWebhookToken)).Methods("POST")
+}
+
+func (a *API) handleGetIncomingWebhooks(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/incoming-webhooks getIncomingWebhooks
+	//
+	// Returns the incoming webhooks of a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/IncomingWebhook"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to incoming webhooks"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getIncomingWebhooks", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	webhooks, err := a.app.GetIncomingWebhooksForBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetIncomingWebhooks",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int("count", len(webhooks)),
+	)
+
+	data, err := json.Marshal(webhooks)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleCreateIncomingWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/incoming-webhooks createIncomingWebhook
+	//
+	// Creates an incoming webhook for a board. The response contains the
+	// generated token the requests to the webhook are authenticated with.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the webhook to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/IncomingWebhook"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/IncomingWebhook"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to create incoming webhook"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var webhook model.IncomingWebhook
+	if err = json.Unmarshal(requestBody, &webhook); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	webhook.BoardID = boardID
+
+	auditRec := a.makeAuditRecord(r, "createIncomingWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	newWebhook, err := a.app.CreateIncomingWebhook(&webhook, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateIncomingWebhook",
+		mlog.String("boardID", boardID),
+		mlog.String("webhookID", newWebhook.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(newWebhook)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("webhookID", newWebhook.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleGetIncomingWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/incoming-webhooks/{webhookID} getIncomingWebhook
+	//
+	// Returns an incoming webhook of a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: webhookID
+	//   in: path
+	//   description: Webhook ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/IncomingWebhook"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	webhookID := vars["webhookID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to incoming webhooks"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getIncomingWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("webhookID", webhookID)
+
+	webhook, err := a.getIncomingWebhook(boardID, webhookID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetIncomingWebhook",
+		mlog.String("boardID", boardID),
+		mlog.String("webhookID", webhookID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(webhook)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleUpdateIncomingWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/incoming-webhooks/{webhookID} updateIncomingWebhook
+	//
+	// Replaces the title and state of an incoming webhook.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: webhookID
+	//   in: path
+	//   description: Webhook ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the updated webhook
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/IncomingWebhook"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/IncomingWebhook"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	webhookID := vars["webhookID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to update incoming webhook"))
+		return
+	}
+
+	if _, err := a.getIncomingWebhook(boardID, webhookID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var webhook model.IncomingWebhook
+	if err = json.Unmarshal(requestBody, &webhook); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	webhook.ID = webhookID
+	webhook.BoardID = boardID
+
+	auditRec := a.makeAuditRecord(r, "updateIncomingWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("webhookID", webhookID)
+
+	updatedWebhook, err := a.app.UpdateIncomingWebhook(&webhook)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("UpdateIncomingWebhook",
+		mlog.String("boardID", boardID),
+		mlog.String("webhookID", webhookID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(updatedWebhook)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteIncomingWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/incoming-webhooks/{webhookID} deleteIncomingWebhook
+	//
+	// Deletes an incoming webhook of a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: webhookID
+	//   in: path
+	//   description: Webhook ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	webhookID := vars["webhookID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to delete incoming webhook"))
+		return
+	}
+
+	if _, err := a.getIncomingWebhook(boardID, webhookID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteIncomingWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("webhookID", webhookID)
+
+	if err := a.app.DeleteIncomingWebhook(webhookID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteIncomingWebhook",
+		mlog.String("boardID", boardID),
+		mlog.String("webhookID", webhookID),
+		mlog.String("userID", userID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
+
+func (a *API) handleRegenerateIncomingWebhookToken(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/incoming-webhooks/{webhookID}/token regenerateIncomingWebhookToken
+	//
+	// Replaces the token of an incoming webhook. Requests made with the
+	// previous token are rejected.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: webhookID
+	//   in: path
+	//   description: Webhook ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/IncomingWebhook"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	webhookID := vars["webhookID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to update incoming webhook"))
+		return
+	}
+
+	if _, err := a.getIncomingWebhook(boardID, webhookID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "regenerateIncomingWebhookToken", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("webhookID", webhookID)
+
+	webhook, err := a.app.RegenerateIncomingWebhookToken(webhookID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("RegenerateIncomingWebhookToken",
+		mlog.String("boardID", boardID),
+		mlog.String("webhookID", webhookID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(webhook)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleExecuteIncomingWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /hooks/{webhookID} executeIncomingWebhook
+	//
+	// Creates a card in the board of an incoming webhook or, if a card id is
+	// given, patches the card. The request does not need a session: it is
+	// authenticated with the token of the webhook and made on behalf of the
+	// user who created it.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: webhookID
+	//   in: path
+	//   description: Webhook ID
+	//   required: true
+	//   type: string
+	// - name: X-Hook-Token
+	//   in: header
+	//   description: The token of the webhook
+	//   required: true
+	//   type: string
+	// - name: token
+	//   in: query
+	//   description: The token of the webhook, if not sent in the X-Hook-Token header
+	//   required: false
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the card title and properties, keyed by property name
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/IncomingWebhookRequest"
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Card"
+	//   '413':
+	//     description: request body too large
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	webhookID := mux.Vars(r)["webhookID"]
+	token := r.Header.Get(HeaderHookToken)
+	if token == "" {
+		token = r.URL.Query().Get("token")
+	}
+
+	webhook, err := a.app.GetIncomingWebhookForToken(webhookID, token)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// the creator of the webhook can have lost access to the board since
+	if !a.permissions.HasPermissionToBoard(webhook.CreatedBy, webhook.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to modify board cards"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(http.MaxBytesReader(w, r.Body, incomingWebhookMaxBodySize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			a.errorResponse(w, r, model.ErrRequestEntityTooLarge)
+			return
+		}
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var request model.IncomingWebhookRequest
+	if err = json.Unmarshal(requestBody, &request); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "executeIncomingWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", webhook.BoardID)
+	auditRec.AddMeta("webhookID", webhook.ID)
+	auditRec.AddMeta("userID", webhook.CreatedBy)
+
+	card, err := a.app.ExecuteIncomingWebhook(webhook, &request)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("ExecuteIncomingWebhook",
+		mlog.String("boardID", webhook.BoardID),
+		mlog.String("webhookID", webhook.ID),
+		mlog.String("cardID", card.ID),
+	)
+
+	data, err := json.Marshal(card)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("cardID", card.ID)
+	auditRec.Success()
+}
+
+// getIncomingWebhook returns an incoming webhook only if it belongs to the board.
+func (a *API) getIncomingWebhook(boardID, webhookID string) (*model.IncomingWebhook, error) {
+	webhook, err := a.app.GetIncomingWebhook(webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if webhook.BoardID != boardID {
+		return nil, model.NewErrNotFound("incoming webhook ID=" + webhookID)
+	}
+	return webhook, nil
+}
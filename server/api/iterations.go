@@ -0,0 +1,776 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerIterationsRoutes(r *mux.Router) {
+	// Iteration APIs
+	r.HandleFunc("/boards/{boardID}/iterations", a.sessionRequired(a.handleGetIterations)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/iterations", a.sessionRequired(a.handleCreateIteration)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/iterations/{iterationID}", a.sessionRequired(a.handleGetIteration)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/iterations/{iterationID}", a.sessionRequired(a.handleUpdateIteration)).Methods("PUT")
+	r.HandleFunc("/boards/{boardID}/iterations/{iterationID}", a.sessionRequired(a.handleDeleteIteration)).Methods("DELETE")
+	r.HandleFunc("/boards/{boardID}/iterations/{iterationID}/start", a.sessionRequired(a.handleStartIteration)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/iterations/{iterationID}/close", a.sessionRequired(a.handleCloseIteration)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/iterations/{iterationID}/move-unfinished", a.sessionRequired(a.handleMoveUnfinishedIterationCards)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/iterations/{iterationID}/burndown", a.sessionRequired(a.handleGetIterationBurndown)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/velocity", a.sessionRequired(a.handleGetBoardVelocity)).Methods("GET")
+}
+
+func (a *API) handleGetIterations(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/iterations getIterations
+	//
+	// Returns the iterations of a board, in the order they were created.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Iteration"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getIterations", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	iterations, err := a.app.GetIterationsForBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetIterations",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int("count", len(iterations)),
+	)
+
+	data, err := json.Marshal(iterations)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleCreateIteration(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/iterations createIteration
+	//
+	// Plans a new iteration on a board. Cards are added to the iteration by
+	// setting their iterationId field.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the iteration to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/Iteration"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Iteration"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to create iteration"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var iteration model.Iteration
+	if err = json.Unmarshal(requestBody, &iteration); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	iteration.BoardID = boardID
+
+	auditRec := a.makeAuditRecord(r, "createIteration", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	newIteration, err := a.app.CreateIteration(&iteration, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateIteration",
+		mlog.String("boardID", boardID),
+		mlog.String("iterationID", newIteration.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(newIteration)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("iterationID", newIteration.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleGetIteration(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/iterations/{iterationID} getIteration
+	//
+	// Returns an iteration of a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: iterationID
+	//   in: path
+	//   description: Iteration ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Iteration"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	iterationID := vars["iterationID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getIteration", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("iterationID", iterationID)
+
+	iteration, err := a.getIteration(boardID, iterationID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetIteration",
+		mlog.String("boardID", boardID),
+		mlog.String("iterationID", iterationID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(iteration)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleUpdateIteration(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/iterations/{iterationID} updateIteration
+	//
+	// Replaces the name, goal, dates and report settings of an iteration. Its
+	// state is changed by starting or closing it.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: iterationID
+	//   in: path
+	//   description: Iteration ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the updated iteration
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/Iteration"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Iteration"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	iterationID := vars["iterationID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to update iteration"))
+		return
+	}
+
+	if _, err := a.getIteration(boardID, iterationID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var iteration model.Iteration
+	if err = json.Unmarshal(requestBody, &iteration); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	iteration.ID = iterationID
+
+	auditRec := a.makeAuditRecord(r, "updateIteration", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("iterationID", iterationID)
+
+	updatedIteration, err := a.app.UpdateIteration(&iteration)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("UpdateIteration",
+		mlog.String("boardID", boardID),
+		mlog.String("iterationID", iterationID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(updatedIteration)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteIteration(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/iterations/{iterationID} deleteIteration
+	//
+	// Deletes an iteration of a board. Its cards are taken out of it.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: iterationID
+	//   in: path
+	//   description: Iteration ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	iterationID := vars["iterationID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to delete iteration"))
+		return
+	}
+
+	if _, err := a.getIteration(boardID, iterationID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteIteration", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("iterationID", iterationID)
+
+	if err := a.app.DeleteIteration(iterationID, userID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteIteration",
+		mlog.String("boardID", boardID),
+		mlog.String("iterationID", iterationID),
+		mlog.String("userID", userID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
+
+func (a *API) handleStartIteration(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/iterations/{iterationID}/start startIteration
+	//
+	// Starts a planned iteration. A board has at most one active iteration.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: iterationID
+	//   in: path
+	//   description: Iteration ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Iteration"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	a.changeIterationState(w, r, "startIteration", a.app.StartIteration)
+}
+
+func (a *API) handleCloseIteration(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/iterations/{iterationID}/close closeIteration
+	//
+	// Closes the active iteration of a board. Its unfinished cards stay in it
+	// until they are moved to another iteration.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: iterationID
+	//   in: path
+	//   description: Iteration ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Iteration"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	a.changeIterationState(w, r, "closeIteration", a.app.CloseIteration)
+}
+
+// changeIterationState starts or closes the iteration of the request.
+func (a *API) changeIterationState(w http.ResponseWriter, r *http.Request, action string, change func(id string) (*model.Iteration, error)) {
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	iterationID := vars["iterationID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to change iteration"))
+		return
+	}
+
+	if _, err := a.getIteration(boardID, iterationID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, action, audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("iterationID", iterationID)
+
+	iteration, err := change(iterationID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug(action,
+		mlog.String("boardID", boardID),
+		mlog.String("iterationID", iterationID),
+		mlog.String("state", iteration.State),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(iteration)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleMoveUnfinishedIterationCards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/iterations/{iterationID}/move-unfinished moveUnfinishedIterationCards
+	//
+	// Moves the cards of an iteration that are not done to another iteration
+	// of the board that is not closed, typically the next one.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: iterationID
+	//   in: path
+	//   description: Iteration ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the iteration to move the cards to
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/IterationCardsMove"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/IterationCardsMove"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	iterationID := vars["iterationID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to move cards"))
+		return
+	}
+
+	if _, err := a.getIteration(boardID, iterationID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var move model.IterationCardsMove
+	if err = json.Unmarshal(requestBody, &move); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "moveUnfinishedIterationCards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("iterationID", iterationID)
+	auditRec.AddMeta("targetIterationID", move.IterationID)
+
+	cardIDs, err := a.app.MoveUnfinishedIterationCards(iterationID, move.IterationID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	move.CardIDs = cardIDs
+
+	a.logger.Debug("MoveUnfinishedIterationCards",
+		mlog.String("boardID", boardID),
+		mlog.String("iterationID", iterationID),
+		mlog.String("targetIterationID", move.IterationID),
+		mlog.Int("count", len(cardIDs)),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(move)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetIterationBurndown(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/iterations/{iterationID}/burndown getIterationBurndown
+	//
+	// Returns the daily scope, completed and remaining work of an iteration,
+	// computed from the history of the cards, for burndown and burnup charts.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: iterationID
+	//   in: path
+	//   description: Iteration ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/IterationBurndown"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	iterationID := vars["iterationID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	if _, err := a.getIteration(boardID, iterationID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getIterationBurndown", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("iterationID", iterationID)
+
+	burndown, err := a.app.GetIterationBurndown(iterationID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetIterationBurndown",
+		mlog.String("boardID", boardID),
+		mlog.String("iterationID", iterationID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(burndown)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetBoardVelocity(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/velocity getBoardVelocity
+	//
+	// Returns the work committed and completed in the closed iterations of a
+	// board, oldest first, computed from the history of the cards.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/IterationVelocity"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardVelocity", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	velocity, err := a.app.GetBoardVelocity(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetBoardVelocity",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int("count", len(velocity)),
+	)
+
+	data, err := json.Marshal(velocity)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+// getIteration returns an iteration of a board, and a not found error if the
+// iteration belongs to another board.
+func (a *API) getIteration(boardID, iterationID string) (*model.Iteration, error) {
+	iteration, err := a.app.GetIteration(iterationID)
+	if err != nil {
+		return nil, err
+	}
+	if iteration.BoardID != boardID {
+		return nil, model.NewErrNotFound("iteration ID=" + iterationID)
+	}
+	return iteration, nil
+}
@@ -396,7 +396,7 @@ func (a *API) handleUpdateMember(w http.ResponseWriter, r *http.Request) {
 	//   type: string
 	// - name: Body
 	//   in: body
-	//   description: membership to replace the current one with
+	//   description: membership to replace the current one with, keeping the custom role of the member if customRoleId is not set
 	//   required: true
 	//   schema:
 	//     "$ref": "#/definitions/BoardMember"
@@ -428,6 +428,16 @@ func (a *API) handleUpdateMember(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the custom role is only changed if the request sets it, so that the
+	// clients unaware of custom roles do not unassign them
+	var reqCustomRole struct {
+		CustomRoleID *string `json:"customRoleId"`
+	}
+	if err = json.Unmarshal(requestBody, &reqCustomRole); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
 	newBoardMember := &model.BoardMember{
 		UserID:          paramsUserID,
 		BoardID:         boardID,
@@ -452,6 +462,17 @@ func (a *API) handleUpdateMember(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if reqCustomRole.CustomRoleID != nil {
+		newBoardMember.CustomRoleID = *reqCustomRole.CustomRoleID
+	} else {
+		oldMember, oErr := a.app.GetMemberForBoard(boardID, paramsUserID)
+		if oErr != nil {
+			a.errorResponse(w, r, oErr)
+			return
+		}
+		newBoardMember.CustomRoleID = oldMember.CustomRoleID
+	}
+
 	auditRec := a.makeAuditRecord(r, "patchMember", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 	auditRec.AddMeta("boardID", boardID)
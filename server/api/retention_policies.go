@@ -0,0 +1,408 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerRetentionPoliciesRoutes(r *mux.Router) {
+	// Data retention policy APIs
+	r.HandleFunc("/retention-policies", a.sessionRequired(a.handleGetRetentionPolicies)).Methods("GET")
+	r.HandleFunc("/retention-policies", a.sessionRequired(a.handleCreateRetentionPolicy)).Methods("POST")
+	r.HandleFunc("/retention-policies/preview", a.sessionRequired(a.handlePreviewDataRetention)).Methods("GET")
+	r.HandleFunc("/retention-policies/{policyID}", a.sessionRequired(a.handleGetRetentionPolicy)).Methods("GET")
+	r.HandleFunc("/retention-policies/{policyID}", a.sessionRequired(a.handleUpdateRetentionPolicy)).Methods("PUT")
+	r.HandleFunc("/retention-policies/{policyID}", a.sessionRequired(a.handleDeleteRetentionPolicy)).Methods("DELETE")
+}
+
+func (a *API) handleGetRetentionPolicies(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /retention-policies getRetentionPolicies
+	//
+	// Returns the team and board data retention policies.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/RetentionPolicy"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionTo(userID, model.PermissionManageSystem) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to retention policies"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getRetentionPolicies", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+
+	policies, err := a.app.GetRetentionPolicies()
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetRetentionPolicies",
+		mlog.String("userID", userID),
+		mlog.Int("count", len(policies)),
+	)
+
+	data, err := json.Marshal(policies)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleCreateRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /retention-policies createRetentionPolicy
+	//
+	// Creates a data retention policy for a team or a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: Body
+	//   in: body
+	//   description: the policy to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/RetentionPolicy"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/RetentionPolicy"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionTo(userID, model.PermissionManageSystem) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to create retention policy"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var policy model.RetentionPolicy
+	if err = json.Unmarshal(requestBody, &policy); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createRetentionPolicy", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", policy.TeamID)
+	auditRec.AddMeta("boardID", policy.BoardID)
+	auditRec.AddMeta("retentionDays", policy.RetentionDays)
+
+	newPolicy, err := a.app.CreateRetentionPolicy(&policy, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateRetentionPolicy",
+		mlog.String("policyID", newPolicy.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(newPolicy)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("policyID", newPolicy.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleGetRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /retention-policies/{policyID} getRetentionPolicy
+	//
+	// Returns a data retention policy.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: policyID
+	//   in: path
+	//   description: Retention policy ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/RetentionPolicy"
+	//   '404':
+	//     description: policy not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	policyID := mux.Vars(r)["policyID"]
+
+	if !a.permissions.HasPermissionTo(userID, model.PermissionManageSystem) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to retention policies"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getRetentionPolicy", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("policyID", policyID)
+
+	policy, err := a.app.GetRetentionPolicy(policyID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(policy)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleUpdateRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /retention-policies/{policyID} updateRetentionPolicy
+	//
+	// Updates the retention days and exempt boards of a data retention policy.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: policyID
+	//   in: path
+	//   description: Retention policy ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the updated policy
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/RetentionPolicy"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/RetentionPolicy"
+	//   '404':
+	//     description: policy not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	policyID := mux.Vars(r)["policyID"]
+
+	if !a.permissions.HasPermissionTo(userID, model.PermissionManageSystem) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to update retention policy"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var policy model.RetentionPolicy
+	if err = json.Unmarshal(requestBody, &policy); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	policy.ID = policyID
+
+	auditRec := a.makeAuditRecord(r, "updateRetentionPolicy", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("policyID", policyID)
+	auditRec.AddMeta("retentionDays", policy.RetentionDays)
+
+	updatedPolicy, err := a.app.UpdateRetentionPolicy(&policy)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("UpdateRetentionPolicy",
+		mlog.String("policyID", policyID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(updatedPolicy)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /retention-policies/{policyID} deleteRetentionPolicy
+	//
+	// Deletes a data retention policy. Its boards fall back to the team policy or
+	// the global data retention setting.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: policyID
+	//   in: path
+	//   description: Retention policy ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: policy not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	policyID := mux.Vars(r)["policyID"]
+
+	if !a.permissions.HasPermissionTo(userID, model.PermissionManageSystem) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to delete retention policy"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteRetentionPolicy", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("policyID", policyID)
+
+	if err := a.app.DeleteRetentionPolicy(policyID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteRetentionPolicy",
+		mlog.String("policyID", policyID),
+		mlog.String("userID", userID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
+
+func (a *API) handlePreviewDataRetention(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /retention-policies/preview previewDataRetention
+	//
+	// Reports how many boards, blocks and history rows a data retention run
+	// would delete now, with the current policies and global setting.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/RetentionPreview"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionTo(userID, model.PermissionManageSystem) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to data retention preview"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "previewDataRetention", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+
+	preview, err := a.app.PreviewDataRetention(time.Now())
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("PreviewDataRetention",
+		mlog.String("userID", userID),
+		mlog.Int("boardCount", preview.BoardCount),
+	)
+
+	data, err := json.Marshal(preview)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
@@ -5,7 +5,10 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/mattermost/mattermost-plugin-boards/server/model"
@@ -19,6 +22,7 @@ func (a *API) registerSearchRoutes(r *mux.Router) {
 	r.HandleFunc("/teams/{teamID}/boards/search", a.sessionRequired(a.handleSearchBoards)).Methods("GET")
 	r.HandleFunc("/teams/{teamID}/boards/search/linkable", a.sessionRequired(a.handleSearchLinkableBoards)).Methods("GET")
 	r.HandleFunc("/boards/search", a.sessionRequired(a.handleSearchAllBoards)).Methods("GET")
+	r.HandleFunc("/teams/{teamID}/cards/search", a.sessionRequired(a.handleSearchCards)).Methods("GET")
 }
 
 func (a *API) handleSearchMyChannels(w http.ResponseWriter, r *http.Request) {
@@ -342,3 +346,131 @@ func (a *API) handleSearchAllBoards(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardsCount", len(boards))
 	auditRec.Success()
 }
+
+func (a *API) handleSearchCards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/cards/search searchCards
+	//
+	// Returns the cards that match with a search term in the titles, text
+	// properties, content and comments of the cards of the team boards the
+	// user can see, ranked by relevance
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: q
+	//   in: query
+	//   description: The search term. Must have at least one character
+	//   required: true
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: The page to select (default=0)
+	//   required: false
+	//   type: integer
+	// - name: per_page
+	//   in: query
+	//   description: Number of results to return per page (default=20, max=100)
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/CardSearchResult"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	teamID := mux.Vars(r)["teamID"]
+	query := r.URL.Query()
+	term := strings.TrimSpace(query.Get("q"))
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to team"))
+		return
+	}
+
+	page := 0
+	if strPage := query.Get("page"); strPage != "" {
+		var err error
+		if page, err = strconv.Atoi(strPage); err != nil {
+			message := fmt.Sprintf("invalid `page` parameter: %s", err)
+			a.errorResponse(w, r, model.NewErrBadRequest(message))
+			return
+		}
+	}
+
+	perPage := model.CardSearchDefaultPerPage
+	if strPerPage := query.Get("per_page"); strPerPage != "" {
+		var err error
+		if perPage, err = strconv.Atoi(strPerPage); err != nil {
+			message := fmt.Sprintf("invalid `per_page` parameter: %s", err)
+			a.errorResponse(w, r, model.NewErrBadRequest(message))
+			return
+		}
+	}
+
+	if len(term) == 0 {
+		jsonStringResponse(w, http.StatusOK, "[]")
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "searchCards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	// only the boards the user can see are searched
+	boards, err := a.app.SearchBoardsForUserInTeam(teamID, "", userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	boardIDs := []string{}
+	for _, board := range boards {
+		if a.permissions.HasPermissionToBoard(userID, board.ID, model.PermissionViewBoard) {
+			boardIDs = append(boardIDs, board.ID)
+		}
+	}
+
+	results, err := a.app.SearchCards(model.CardSearchOptions{
+		BoardIDs: boardIDs,
+		Term:     term,
+		Page:     page,
+		PerPage:  perPage,
+	})
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SearchCards",
+		mlog.String("teamID", teamID),
+		mlog.Int("boardsCount", len(boardIDs)),
+		mlog.Int("resultsCount", len(results)),
+	)
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("resultsCount", len(results))
+	auditRec.Success()
+}
@@ -0,0 +1,625 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerShareLinksRoutes(r *mux.Router) {
+	// Share link APIs
+	r.HandleFunc("/boards/{boardID}/share-links", a.sessionRequired(a.handleGetShareLinks)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/share-links", a.sessionRequired(a.handleCreateShareLink)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/share-links/{linkID}", a.sessionRequired(a.handleGetShareLink)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/share-links/{linkID}", a.sessionRequired(a.handleUpdateShareLink)).Methods("PUT")
+	r.HandleFunc("/boards/{boardID}/share-links/{linkID}", a.sessionRequired(a.handleDeleteShareLink)).Methods("DELETE")
+	r.HandleFunc("/boards/{boardID}/share-links/{linkID}/revoke", a.sessionRequired(a.handleRevokeShareLink)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/share-links/{linkID}/accesses", a.sessionRequired(a.handleGetShareLinkAccesses)).Methods("GET")
+}
+
+func (a *API) handleGetShareLinks(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/share-links getShareLinks
+	//
+	// Returns the share links of a board, including the revoked and expired
+	// ones.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/ShareLink"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board share links"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getShareLinks", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	links, err := a.app.GetShareLinksForBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetShareLinks",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int("count", len(links)),
+	)
+
+	data, err := json.Marshal(links)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleCreateShareLink(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/share-links createShareLink
+	//
+	// Creates a share link for a board. The read token of the link is
+	// generated.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the share link to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/ShareLink"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/ShareLink"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to sharing the board"))
+		return
+	}
+
+	if !a.app.GetClientConfig().EnablePublicSharedBoards {
+		a.errorResponse(w, r, model.NewErrBadRequest("public shared boards are disabled"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var link model.ShareLink
+	if err = json.Unmarshal(requestBody, &link); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	link.BoardID = boardID
+
+	auditRec := a.makeAuditRecord(r, "createShareLink", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("viewID", link.ViewID)
+	auditRec.AddMeta("expiresAt", link.ExpiresAt)
+
+	newLink, err := a.app.CreateShareLink(&link, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateShareLink",
+		mlog.String("boardID", boardID),
+		mlog.String("linkID", newLink.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(newLink)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("linkID", newLink.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleGetShareLink(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/share-links/{linkID} getShareLink
+	//
+	// Returns a share link of a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: linkID
+	//   in: path
+	//   description: Share link ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/ShareLink"
+	//   '404':
+	//     description: share link not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	linkID := vars["linkID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board share links"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getShareLink", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("linkID", linkID)
+
+	link, err := a.getBoardShareLink(boardID, linkID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetShareLink",
+		mlog.String("boardID", boardID),
+		mlog.String("linkID", linkID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(link)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleUpdateShareLink(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/share-links/{linkID} updateShareLink
+	//
+	// Replaces the name, mode, view, expiration and password of a share
+	// link. The password is kept if none is sent and hasPassword is true, and
+	// removed if hasPassword is false. The token of the link cannot be
+	// changed.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: linkID
+	//   in: path
+	//   description: Share link ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the updated share link
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/ShareLink"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/ShareLink"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	linkID := vars["linkID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to update share link"))
+		return
+	}
+
+	if _, err := a.getBoardShareLink(boardID, linkID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var link model.ShareLink
+	if err = json.Unmarshal(requestBody, &link); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	link.ID = linkID
+	link.BoardID = boardID
+
+	auditRec := a.makeAuditRecord(r, "updateShareLink", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("linkID", linkID)
+	auditRec.AddMeta("viewID", link.ViewID)
+	auditRec.AddMeta("expiresAt", link.ExpiresAt)
+
+	updatedLink, err := a.app.UpdateShareLink(&link)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("UpdateShareLink",
+		mlog.String("boardID", boardID),
+		mlog.String("linkID", linkID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(updatedLink)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleRevokeShareLink(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/share-links/{linkID}/revoke revokeShareLink
+	//
+	// Revokes a share link. A revoked link cannot be used anymore, but is
+	// kept along with its access log.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: linkID
+	//   in: path
+	//   description: Share link ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/ShareLink"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	linkID := vars["linkID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to revoke share link"))
+		return
+	}
+
+	if _, err := a.getBoardShareLink(boardID, linkID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "revokeShareLink", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("linkID", linkID)
+
+	link, err := a.app.RevokeShareLink(linkID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("RevokeShareLink",
+		mlog.String("boardID", boardID),
+		mlog.String("linkID", linkID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(link)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteShareLink(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/share-links/{linkID} deleteShareLink
+	//
+	// Deletes a share link of a board, along with its access log.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: linkID
+	//   in: path
+	//   description: Share link ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	linkID := vars["linkID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to delete share link"))
+		return
+	}
+
+	if _, err := a.getBoardShareLink(boardID, linkID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteShareLink", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("linkID", linkID)
+
+	if err := a.app.DeleteShareLink(linkID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteShareLink",
+		mlog.String("boardID", boardID),
+		mlog.String("linkID", linkID),
+		mlog.String("userID", userID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetShareLinkAccesses(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/share-links/{linkID}/accesses getShareLinkAccesses
+	//
+	// Returns the access log of a share link, newest first.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: linkID
+	//   in: path
+	//   description: Share link ID
+	//   required: true
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: The page to select (default=0)
+	//   required: false
+	//   type: integer
+	// - name: per_page
+	//   in: query
+	//   description: Number of accesses to return per page(default=100)
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/ShareLinkAccess"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	linkID := vars["linkID"]
+
+	query := r.URL.Query()
+	strPage := query.Get("page")
+	strPerPage := query.Get("per_page")
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board share links"))
+		return
+	}
+
+	if strPage == "" {
+		strPage = defaultPage
+	}
+	if strPerPage == "" {
+		strPerPage = defaultPerPage
+	}
+
+	page, err := strconv.Atoi(strPage)
+	if err != nil {
+		message := fmt.Sprintf("invalid `page` parameter: %s", err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	perPage, err := strconv.Atoi(strPerPage)
+	if err != nil {
+		message := fmt.Sprintf("invalid `per_page` parameter: %s", err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if _, err = a.getBoardShareLink(boardID, linkID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getShareLinkAccesses", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("linkID", linkID)
+	auditRec.AddMeta("page", page)
+	auditRec.AddMeta("per_page", perPage)
+
+	accesses, err := a.app.GetShareLinkAccesses(linkID, page, perPage)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetShareLinkAccesses",
+		mlog.String("boardID", boardID),
+		mlog.String("linkID", linkID),
+		mlog.String("userID", userID),
+		mlog.Int("count", len(accesses)),
+	)
+
+	data, err := json.Marshal(accesses)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+// getBoardShareLink returns a share link only if it belongs to the board.
+func (a *API) getBoardShareLink(boardID, linkID string) (*model.ShareLink, error) {
+	link, err := a.app.GetShareLink(linkID)
+	if err != nil {
+		return nil, err
+	}
+	if link.BoardID != boardID {
+		return nil, model.NewErrNotFound("share link ID=" + linkID)
+	}
+	return link, nil
+}
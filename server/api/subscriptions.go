@@ -20,6 +20,7 @@ func (a *API) registerSubscriptionsRoutes(r *mux.Router) {
 	// Subscription APIs
 	r.HandleFunc("/subscriptions", a.sessionRequired(a.handleCreateSubscription)).Methods("POST")
 	r.HandleFunc("/subscriptions/{blockID}/{subscriberID}", a.sessionRequired(a.handleDeleteSubscription)).Methods("DELETE")
+	r.HandleFunc("/subscriptions/{blockID}/{subscriberID}/filter", a.sessionRequired(a.handleUpdateSubscriptionFilter)).Methods("PUT")
 	r.HandleFunc("/subscriptions/{subscriberID}", a.sessionRequired(a.handleGetSubscriptions)).Methods("GET")
 }
 
@@ -174,6 +175,89 @@ func (a *API) handleDeleteSubscription(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+func (a *API) handleUpdateSubscriptionFilter(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /subscriptions/{blockID}/{subscriberID}/filter updateSubscriptionFilter
+	//
+	// Sets the types of changes a user is notified of for a subscribed block. A null filter notifies
+	// the user of every change.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: blockID
+	//   in: path
+	//   description: Block ID
+	//   required: true
+	//   type: string
+	// - name: subscriberID
+	//   in: path
+	//   description: Subscriber ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: subscription filter
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/SubscriptionFilter"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Subscription"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+
+	vars := mux.Vars(r)
+	blockID := vars["blockID"]
+	subscriberID := vars["subscriberID"]
+
+	filter, err := model.SubscriptionFilterFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "updateSubscriptionFilter", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("block_id", blockID)
+	auditRec.AddMeta("subscriber_id", subscriberID)
+
+	// User can only change subscriptions for themselves
+	if session.UserID != subscriberID {
+		a.errorResponse(w, r, model.NewErrPermission("access denied"))
+		return
+	}
+
+	sub, err := a.app.UpdateSubscriptionFilter(blockID, subscriberID, filter)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("UPDATE subscription filter",
+		mlog.String("blockID", blockID),
+		mlog.String("subscriberID", subscriberID),
+	)
+
+	json, err := json.Marshal(sub)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	jsonBytesResponse(w, http.StatusOK, json)
+
+	auditRec.Success()
+}
+
 func (a *API) handleGetSubscriptions(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /subscriptions/{subscriberID} getSubscriptions
 	//
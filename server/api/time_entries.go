@@ -0,0 +1,774 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerTimeEntriesRoutes(r *mux.Router) {
+	// Time tracking APIs
+	r.HandleFunc("/boards/{boardID}/time-entries", a.sessionRequired(a.handleGetBoardTimeEntries)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/time-summary", a.sessionRequired(a.handleGetBoardTimeSummary)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/time-entries", a.sessionRequired(a.handleGetCardTimeEntries)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/time-entries", a.sessionRequired(a.handleCreateTimeEntry)).Methods("POST")
+	r.HandleFunc("/cards/{cardID}/time-entries/{entryID}", a.sessionRequired(a.handleUpdateTimeEntry)).Methods("PUT")
+	r.HandleFunc("/cards/{cardID}/time-entries/{entryID}", a.sessionRequired(a.handleDeleteTimeEntry)).Methods("DELETE")
+	r.HandleFunc("/cards/{cardID}/time-summary", a.sessionRequired(a.handleGetCardTimeSummary)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/timer", a.sessionRequired(a.handleStartTimer)).Methods("POST")
+	r.HandleFunc("/cards/{cardID}/timer", a.sessionRequired(a.handleStopTimer)).Methods("DELETE")
+	r.HandleFunc("/users/me/timer", a.sessionRequired(a.handleGetMyTimer)).Methods("GET")
+}
+
+func (a *API) handleGetBoardTimeEntries(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/time-entries getBoardTimeEntries
+	//
+	// Returns the time entries of the cards of a board, oldest first.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/TimeEntry"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardTimeEntries", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	entries, err := a.app.GetTimeEntriesForBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetBoardTimeEntries",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int("count", len(entries)),
+	)
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetBoardTimeSummary(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/time-summary getBoardTimeSummary
+	//
+	// Returns the time spent on the cards of a board, per user and per card.
+	// Running timers are counted up to now.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/TimeSummary"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardTimeSummary", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	summary, err := a.app.GetBoardTimeSummary(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetBoardTimeSummary",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(summary)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetCardTimeEntries(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /cards/{cardID}/time-entries getCardTimeEntries
+	//
+	// Returns the time entries of a card, oldest first.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/TimeEntry"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		message := fmt.Sprintf("could not fetch card %s: %s", cardID, err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch time entries"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardTimeEntries", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", card.ID)
+
+	entries, err := a.app.GetTimeEntriesForCard(card.ID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetCardTimeEntries",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", card.ID),
+		mlog.String("userID", userID),
+		mlog.Int("count", len(entries)),
+	)
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleCreateTimeEntry(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/time-entries createTimeEntry
+	//
+	// Logs time spent by the current user on a card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the start time, duration and note of the entry
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/TimeEntry"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/TimeEntry"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var entry model.TimeEntry
+	if err = json.Unmarshal(requestBody, &entry); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	entry.CardID = cardID
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		message := fmt.Sprintf("could not fetch card %s: %s", cardID, err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to log time"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createTimeEntry", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", card.ID)
+
+	newEntry, err := a.app.CreateTimeEntry(&entry, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateTimeEntry",
+		mlog.String("cardID", card.ID),
+		mlog.String("entryID", newEntry.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(newEntry)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("entryID", newEntry.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleUpdateTimeEntry(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /cards/{cardID}/time-entries/{entryID} updateTimeEntry
+	//
+	// Replaces the start time, duration and note of a time entry. Users can
+	// edit their own entries, and board admins the entries of everyone.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: entryID
+	//   in: path
+	//   description: ID of the time entry to update
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the start time, duration and note of the entry
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/TimeEntry"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/TimeEntry"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	cardID := vars["cardID"]
+	entryID := vars["entryID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var entry model.TimeEntry
+	if err = json.Unmarshal(requestBody, &entry); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	entry.ID = entryID
+
+	if _, err = a.getEditableTimeEntry(userID, cardID, entryID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "updateTimeEntry", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("entryID", entryID)
+
+	updatedEntry, err := a.app.UpdateTimeEntry(&entry)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("UpdateTimeEntry",
+		mlog.String("cardID", cardID),
+		mlog.String("entryID", entryID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(updatedEntry)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteTimeEntry(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /cards/{cardID}/time-entries/{entryID} deleteTimeEntry
+	//
+	// Deletes a time entry. Users can delete their own entries, and board
+	// admins the entries of everyone.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: entryID
+	//   in: path
+	//   description: ID of the time entry to delete
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	cardID := vars["cardID"]
+	entryID := vars["entryID"]
+
+	if _, err := a.getEditableTimeEntry(userID, cardID, entryID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteTimeEntry", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("entryID", entryID)
+
+	if err := a.app.DeleteTimeEntry(entryID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteTimeEntry",
+		mlog.String("cardID", cardID),
+		mlog.String("entryID", entryID),
+		mlog.String("userID", userID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetCardTimeSummary(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /cards/{cardID}/time-summary getCardTimeSummary
+	//
+	// Returns the time spent on a card, per user. Running timers are counted
+	// up to now.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/TimeSummary"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		message := fmt.Sprintf("could not fetch card %s: %s", cardID, err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch time summary"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardTimeSummary", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", card.ID)
+
+	summary, err := a.app.GetCardTimeSummary(card.ID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetCardTimeSummary",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", card.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(summary)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleStartTimer(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/timer startTimer
+	//
+	// Starts a timer for the current user on a card. The timer already
+	// running for the user, if any, is stopped first.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the note of the entry
+	//   required: false
+	//   schema:
+	//     "$ref": "#/definitions/TimeEntry"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/TimeEntry"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var entry model.TimeEntry
+	if len(requestBody) != 0 {
+		if err = json.Unmarshal(requestBody, &entry); err != nil {
+			a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+			return
+		}
+	}
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		message := fmt.Sprintf("could not fetch card %s: %s", cardID, err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to log time"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "startTimer", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", card.ID)
+
+	newEntry, err := a.app.StartTimer(card.ID, entry.Note, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("StartTimer",
+		mlog.String("cardID", card.ID),
+		mlog.String("entryID", newEntry.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(newEntry)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("entryID", newEntry.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleStopTimer(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /cards/{cardID}/timer stopTimer
+	//
+	// Stops the running timer of the current user on a card, and returns the
+	// completed time entry.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/TimeEntry"
+	//   '404':
+	//     description: the user has no running timer on the card
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	runningEntry, err := a.app.GetRunningTimeEntry(userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if runningEntry.CardID != cardID {
+		a.errorResponse(w, r, model.NewErrNotFound("running time entry CardID="+cardID))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "stopTimer", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("entryID", runningEntry.ID)
+
+	entry, err := a.app.StopTimer(userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("StopTimer",
+		mlog.String("cardID", cardID),
+		mlog.String("entryID", entry.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetMyTimer(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/me/timer getMyTimer
+	//
+	// Returns the running timer of the current user.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/TimeEntry"
+	//   '404':
+	//     description: the user has no running timer
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+
+	entry, err := a.app.GetRunningTimeEntry(userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetMyTimer",
+		mlog.String("entryID", entry.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+// getEditableTimeEntry returns a time entry of a card if the user can edit
+// it, as the user who logged it or as a board admin.
+func (a *API) getEditableTimeEntry(userID, cardID, entryID string) (*model.TimeEntry, error) {
+	entry, err := a.app.GetTimeEntry(entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.CardID != cardID {
+		return nil, model.NewErrNotFound("time entry ID=" + entryID)
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, entry.BoardID, model.PermissionManageBoardCards) {
+		return nil, model.NewErrPermission("access denied to edit time entry")
+	}
+
+	if entry.UserID != userID && !a.permissions.HasPermissionToBoard(userID, entry.BoardID, model.PermissionManageBoardRoles) {
+		return nil, model.NewErrPermission("access denied to edit the time entry of another user")
+	}
+	return entry, nil
+}
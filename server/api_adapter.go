@@ -84,6 +84,21 @@ func (a *pluginAPIAdapter) CreatePost(post *mm_model.Post) (*mm_model.Post, erro
 	return post, normalizeAppErr(appErr)
 }
 
+func (a *pluginAPIAdapter) GetPost(postID string) (*mm_model.Post, error) {
+	post, appErr := a.api.GetPost(postID)
+	return post, normalizeAppErr(appErr)
+}
+
+func (a *pluginAPIAdapter) GetPostThread(postID string) (*mm_model.PostList, error) {
+	postList, appErr := a.api.GetPostThread(postID)
+	return postList, normalizeAppErr(appErr)
+}
+
+func (a *pluginAPIAdapter) UpdatePost(post *mm_model.Post) (*mm_model.Post, error) {
+	post, appErr := a.api.UpdatePost(post)
+	return post, normalizeAppErr(appErr)
+}
+
 //
 // User service.
 //
@@ -168,6 +183,11 @@ func (a *pluginAPIAdapter) GetFileInfo(fileID string) (*mm_model.FileInfo, error
 	return fi, normalizeAppErr(appErr)
 }
 
+func (a *pluginAPIAdapter) GetFile(fileID string) ([]byte, error) {
+	data, appErr := a.api.GetFile(fileID)
+	return data, normalizeAppErr(appErr)
+}
+
 //
 // Cluster store.
 //
@@ -229,6 +249,14 @@ func (a *pluginAPIAdapter) RegisterRouter(sub *mux.Router) {
 	// NOOP for plugin
 }
 
+//
+// Command service.
+//
+
+func (a *pluginAPIAdapter) RegisterCommand(command *mm_model.Command) error {
+	return a.api.RegisterCommand(command)
+}
+
 //
 // Preferences service.
 //
@@ -27,10 +27,17 @@ const (
 	blockChangeNotifierQueueSize       = 1000
 	blockChangeNotifierPoolSize        = 10
 	blockChangeNotifierShutdownTimeout = time.Second * 10
+
+	webhookDelivererQueueSize = 1000
+	webhookDelivererPoolSize  = 5
 )
 
 type servicesAPI interface {
 	GetUsersFromProfiles(options *mm_model.UserGetOptions) ([]*mm_model.User, error)
+	GetPost(postID string) (*mm_model.Post, error)
+	GetPostThread(postID string) (*mm_model.PostList, error)
+	GetFileInfo(fileID string) (*mm_model.FileInfo, error)
+	GetFile(fileID string) ([]byte, error)
 }
 
 type ReadCloseSeeker = filestore.ReadCloseSeeker
@@ -69,6 +76,7 @@ type App struct {
 	logger              mlog.LoggerIFace
 	permissions         permissions.PermissionsService
 	blockChangeNotifier *utils.CallbackQueue
+	webhookDeliverer    *utils.CallbackQueue
 	servicesAPI         servicesAPI
 
 	cardLimitMux sync.RWMutex
@@ -96,6 +104,7 @@ func New(config *config.Configuration, wsAdapter ws.Adapter, services Services)
 		logger:              services.Logger,
 		permissions:         services.Permissions,
 		blockChangeNotifier: utils.NewCallbackQueue("blockChangeNotifier", blockChangeNotifierQueueSize, blockChangeNotifierPoolSize, services.Logger),
+		webhookDeliverer:    utils.NewCallbackQueue("webhookDeliverer", webhookDelivererQueueSize, webhookDelivererPoolSize, services.Logger),
 		servicesAPI:         services.ServicesAPI,
 	}
 	app.initialize(services.SkipTemplateInit)
@@ -79,6 +79,11 @@ func (a *App) PatchBlockAndNotify(blockID string, blockPatch *model.BlockPatch,
 		return nil, err
 	}
 
+	blockPatch, err = a.applyBoardRules(board, oldBlock, blockPatch)
+	if err != nil {
+		return nil, err
+	}
+
 	err = a.store.PatchBlock(blockID, blockPatch, modifiedByID)
 	if err != nil {
 		return nil, err
@@ -95,11 +100,17 @@ func (a *App) PatchBlockAndNotify(blockID string, blockPatch *model.BlockPatch,
 
 		// broadcast on webhooks
 		a.webhook.NotifyUpdate(block)
+		a.notifyBoardWebhooks(board.TeamID, block, oldBlock)
 
 		// send notifications
 		if !disableNotify {
 			a.notifyBlockChanged(notify.Update, block, oldBlock, modifiedByID)
+		} else {
+			a.notifyBlockChangedSilently(notify.Update, block, oldBlock, modifiedByID)
 		}
+
+		// the old block is included for the rollups of its former parent
+		a.updateComputedProperties(board, []*model.Block{oldBlock, block})
 		return nil
 	})
 	return block, nil
@@ -121,12 +132,18 @@ func (a *App) PatchBlocksAndNotify(teamID string, blockPatches *model.BlockPatch
 		return err
 	}
 
+	blockPatches, err = a.applyBoardRulesToBatch(oldBlocks, blockPatches)
+	if err != nil {
+		return err
+	}
+
 	if err := a.store.PatchBlocks(blockPatches, modifiedByID); err != nil {
 		return err
 	}
 
 	a.blockChangeNotifier.Enqueue(func() error {
 		a.metrics.IncrementBlocksPatched(len(oldBlocks))
+		changedBlocks := append([]*model.Block{}, oldBlocks...)
 		for i, blockID := range blockPatches.BlockIDs {
 			newBlock, err := a.store.GetBlock(blockID)
 			if err != nil {
@@ -134,10 +151,15 @@ func (a *App) PatchBlocksAndNotify(teamID string, blockPatches *model.BlockPatch
 			}
 			a.wsAdapter.BroadcastBlockChange(teamID, newBlock)
 			a.webhook.NotifyUpdate(newBlock)
+			a.notifyBoardWebhooks(teamID, newBlock, oldBlocks[i])
 			if !disableNotify {
 				a.notifyBlockChanged(notify.Update, newBlock, oldBlocks[i], modifiedByID)
+			} else {
+				a.notifyBlockChangedSilently(notify.Update, newBlock, oldBlocks[i], modifiedByID)
 			}
+			changedBlocks = append(changedBlocks, newBlock)
 		}
+		a.updateComputedPropertiesForBlocks(changedBlocks)
 		return nil
 	})
 	return nil
@@ -159,9 +181,11 @@ func (a *App) InsertBlockAndNotify(block *model.Block, modifiedByID string, disa
 			a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
 			a.metrics.IncrementBlocksInserted(1)
 			a.webhook.NotifyUpdate(block)
+			a.notifyBoardWebhooks(board.TeamID, block, nil)
 			if !disableNotify {
 				a.notifyBlockChanged(notify.Add, block, nil, modifiedByID)
 			}
+			a.updateComputedProperties(board, []*model.Block{block})
 			return nil
 		})
 	}
@@ -207,10 +231,12 @@ func (a *App) InsertBlocksAndNotify(blocks []*model.Block, modifiedByID string,
 		for _, b := range needsNotify {
 			block := b
 			a.webhook.NotifyUpdate(block)
+			a.notifyBoardWebhooks(board.TeamID, block, nil)
 			if !disableNotify {
 				a.notifyBlockChanged(notify.Add, block, nil, modifiedByID)
 			}
 		}
+		a.updateComputedProperties(board, needsNotify)
 		return nil
 	})
 
@@ -252,6 +278,7 @@ func (a *App) DeleteBlockAndNotify(blockID string, modifiedBy string, disableNot
 		if !disableNotify {
 			a.notifyBlockChanged(notify.Delete, block, block, modifiedBy)
 		}
+		a.updateComputedProperties(board, []*model.Block{block})
 		return nil
 	})
 
@@ -304,6 +331,7 @@ func (a *App) UndeleteBlock(blockID string, modifiedBy string) (*model.Block, er
 		a.metrics.IncrementBlocksInserted(1)
 		a.webhook.NotifyUpdate(block)
 		a.notifyBlockChanged(notify.Add, block, nil, modifiedBy)
+		a.updateComputedProperties(board, []*model.Block{block})
 
 		return nil
 	})
@@ -320,16 +348,33 @@ func (a *App) GetBlocksForBoard(boardID string) ([]*model.Block, error) {
 }
 
 func (a *App) notifyBlockChanged(action notify.Action, block *model.Block, oldBlock *model.Block, modifiedByID string) {
+	if evt, ok := a.getBlockChangeEvent(action, block, oldBlock, modifiedByID); ok {
+		a.notifications.BlockChanged(evt)
+	}
+}
+
+// notifyBlockChangedSilently informs the backends that act on the block
+// changes saved with notifications disabled, such as the board rules, so the
+// changes are handled the same way with and without notifications.
+func (a *App) notifyBlockChangedSilently(action notify.Action, block *model.Block, oldBlock *model.Block, modifiedByID string) {
+	if evt, ok := a.getBlockChangeEvent(action, block, oldBlock, modifiedByID); ok {
+		a.notifications.BlockChangedSilently(evt)
+	}
+}
+
+// getBlockChangeEvent returns the notification event of a block change, and
+// false if no notification is sent for it.
+func (a *App) getBlockChangeEvent(action notify.Action, block *model.Block, oldBlock *model.Block, modifiedByID string) (notify.BlockChangeEvent, bool) {
 	// don't notify if notifications service disabled, or block change is generated via system user.
 	if a.notifications == nil || modifiedByID == model.SystemUserID {
-		return
+		return notify.BlockChangeEvent{}, false
 	}
 
 	// find card and board for the changed block.
 	board, card, err := a.getBoardAndCard(block)
 	if err != nil {
 		a.logger.Error("Error notifying for block change; cannot determine board or card", mlog.Err(err))
-		return
+		return notify.BlockChangeEvent{}, false
 	}
 
 	boardMember, _ := a.GetMemberForBoard(board.ID, modifiedByID)
@@ -350,7 +395,7 @@ func (a *App) notifyBlockChanged(action notify.Action, block *model.Block, oldBl
 		BlockOld:     oldBlock,
 		ModifiedBy:   boardMember,
 	}
-	a.notifications.BlockChanged(evt)
+	return evt, true
 }
 
 const (
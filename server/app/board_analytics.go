@@ -0,0 +1,32 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+// GetBoardAnalytics computes the flow of the cards of a board through the
+// options of a select property from the history of the cards.
+func (a *App) GetBoardAnalytics(boardID string, opts model.BoardAnalyticsOptions) (*model.BoardAnalytics, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+	if err = opts.Resolve(schema, utils.GetMillis()); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	history, err := a.store.GetBlockHistoryDescendants(boardID, model.QueryBlockHistoryOptions{BeforeUpdateAt: opts.To + 1})
+	if err != nil {
+		return nil, err
+	}
+	return model.BuildBoardAnalytics(model.NewBlockTimeline(history), opts), nil
+}
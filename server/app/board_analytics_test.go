@@ -0,0 +1,68 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBoardAnalytics(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status",
+				"name": "Status",
+				"type": model.PropTypeSelect,
+				"options": []interface{}{
+					map[string]interface{}{"id": "todo", "value": "To Do"},
+					map[string]interface{}{"id": "done", "value": "Done"},
+				},
+			},
+			{"id": "points", "name": "Points", "type": model.PropTypeNumber},
+		},
+	}
+
+	makeCardVersion := func(updateAt int64, status string) *model.Block {
+		return &model.Block{
+			ID:       "card",
+			BoardID:  board.ID,
+			Type:     model.TypeCard,
+			CreateAt: 1000,
+			UpdateAt: updateAt,
+			Fields:   map[string]interface{}{"properties": map[string]interface{}{"status": status}},
+		}
+	}
+
+	t.Run("compute the analytics from the history", func(t *testing.T) {
+		opts := model.BoardAnalyticsOptions{PropertyID: "status", From: 500, To: 5000}
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetBlockHistoryDescendants(board.ID, model.QueryBlockHistoryOptions{BeforeUpdateAt: 5001}).Return(
+			[]*model.Block{makeCardVersion(1000, "todo"), makeCardVersion(3000, "done")}, nil)
+
+		analytics, err := th.App.GetBoardAnalytics(board.ID, opts)
+		require.NoError(t, err)
+		require.Equal(t, []string{"done"}, analytics.DoneOptionIDs)
+		require.Equal(t, []string{"done"}, analytics.StartOptionIDs)
+		require.Equal(t, map[string]int64{"todo": 2000, "done": 2000}, analytics.TimeInState[0].Durations)
+		require.Equal(t, 1, analytics.LeadTime.Count)
+		require.Equal(t, int64(2000), analytics.LeadTime.Max)
+		require.Equal(t, int64(0), analytics.CycleTime.Max)
+	})
+
+	t.Run("not a select property", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+		_, err := th.App.GetBoardAnalytics(board.ID, model.BoardAnalyticsOptions{PropertyID: "points"})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+}
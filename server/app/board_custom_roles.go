@@ -0,0 +1,147 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+// CreateBoardCustomRole defines a new custom role for a board. The name of
+// the role must be unique within the board.
+func (a *App) CreateBoardCustomRole(role *model.BoardCustomRole, userID string) (*model.BoardCustomRole, error) {
+	newRole := &model.BoardCustomRole{
+		ID:          utils.NewID(utils.IDTypeNone),
+		BoardID:     role.BoardID,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: role.Permissions,
+		PropertyIDs: role.PropertyIDs,
+		CreatedBy:   userID,
+		ModifiedBy:  userID,
+	}
+
+	if err := a.validateBoardCustomRole(newRole); err != nil {
+		return nil, err
+	}
+
+	return a.store.CreateBoardCustomRole(newRole)
+}
+
+// UpdateBoardCustomRole replaces the name, description, permissions and
+// properties of a custom role. The members of the role are granted the new
+// permissions right away.
+func (a *App) UpdateBoardCustomRole(role *model.BoardCustomRole, userID string) (*model.BoardCustomRole, error) {
+	existingRole, err := a.store.GetBoardCustomRole(role.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedRole := *existingRole
+	updatedRole.Name = role.Name
+	updatedRole.Description = role.Description
+	updatedRole.Permissions = role.Permissions
+	updatedRole.PropertyIDs = role.PropertyIDs
+	updatedRole.ModifiedBy = userID
+
+	if err = a.validateBoardCustomRole(&updatedRole); err != nil {
+		return nil, err
+	}
+
+	return a.store.UpdateBoardCustomRole(&updatedRole)
+}
+
+// validateBoardCustomRole checks a custom role against the board it is
+// defined for and the other roles of the board.
+func (a *App) validateBoardCustomRole(role *model.BoardCustomRole) error {
+	if err := role.IsValid(); err != nil {
+		return model.NewErrBadRequest(err.Error())
+	}
+
+	board, err := a.store.GetBoard(role.BoardID)
+	if err != nil {
+		return err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return err
+	}
+	if err = role.IsValidForSchema(schema); err != nil {
+		return model.NewErrBadRequest(err.Error())
+	}
+
+	roles, err := a.store.GetBoardCustomRolesForBoard(board.ID)
+	if err != nil {
+		return err
+	}
+	for _, r := range roles {
+		if r.ID != role.ID && r.Name == role.Name {
+			return model.NewErrBadRequest("a custom role with this name already exists: " + role.Name)
+		}
+	}
+	return nil
+}
+
+func (a *App) GetBoardCustomRole(id string) (*model.BoardCustomRole, error) {
+	return a.store.GetBoardCustomRole(id)
+}
+
+func (a *App) GetBoardCustomRolesForBoard(boardID string) ([]*model.BoardCustomRole, error) {
+	return a.store.GetBoardCustomRolesForBoard(boardID)
+}
+
+// DeleteBoardCustomRole deletes a custom role and unassigns it from its
+// members in the same transaction. Its members are left with the viewer role
+// they were given when the custom role was assigned to them.
+func (a *App) DeleteBoardCustomRole(id string) error {
+	return a.store.DeleteBoardCustomRole(id)
+}
+
+// GetCustomRoleForMember returns the custom role of a member of a board.
+func (a *App) GetCustomRoleForMember(boardID, userID string) (*model.BoardCustomRole, error) {
+	member, err := a.store.GetMemberForBoard(boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member.CustomRoleID == "" {
+		return nil, model.NewErrNotFound("custom role for board member BoardID=" + boardID + " UserID=" + userID)
+	}
+
+	role, err := a.store.GetBoardCustomRole(member.CustomRoleID)
+	if err != nil {
+		return nil, err
+	}
+	if role.BoardID != boardID {
+		return nil, model.NewErrNotFound("board custom role ID=" + member.CustomRoleID)
+	}
+	return role, nil
+}
+
+// applyBoardCustomRole checks that the custom role assigned to a member is a
+// role of its board. A custom role replaces the scheme roles of the member,
+// which is kept a viewer so that it can still view the board if the role is
+// deleted.
+func (a *App) applyBoardCustomRole(member *model.BoardMember) error {
+	if member.CustomRoleID == "" {
+		return nil
+	}
+
+	role, err := a.store.GetBoardCustomRole(member.CustomRoleID)
+	if model.IsErrNotFound(err) {
+		return model.NewErrBadRequest("unknown custom role: " + member.CustomRoleID)
+	}
+	if err != nil {
+		return err
+	}
+	if role.BoardID != member.BoardID {
+		return model.NewErrBadRequest("the custom role is not a role of the board: " + member.CustomRoleID)
+	}
+
+	member.SchemeAdmin = false
+	member.SchemeEditor = false
+	member.SchemeCommenter = false
+	member.SchemeViewer = true
+	return nil
+}
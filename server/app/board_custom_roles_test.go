@@ -0,0 +1,140 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBoardCustomRole(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID: utils.NewID(utils.IDTypeBoard),
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": model.PropTypeSelect},
+		},
+	}
+	existing := &model.BoardCustomRole{ID: "existing", BoardID: board.ID, Name: "Reviewer"}
+
+	t.Run("create the role", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetBoardCustomRolesForBoard(board.ID).Return([]*model.BoardCustomRole{existing}, nil)
+		th.Store.EXPECT().CreateBoardCustomRole(gomock.Any()).DoAndReturn(
+			func(role *model.BoardCustomRole) (*model.BoardCustomRole, error) {
+				return role, nil
+			})
+
+		role, err := th.App.CreateBoardCustomRole(&model.BoardCustomRole{
+			BoardID:     board.ID,
+			Name:        "Contractor",
+			Permissions: []string{model.PermissionCommentBoardCards.Id, model.PermissionEditBoardCardProperties.Id},
+			PropertyIDs: []string{"status"},
+		}, "user-id")
+		require.NoError(t, err)
+		require.NotEmpty(t, role.ID)
+		require.Equal(t, "user-id", role.CreatedBy)
+		require.Equal(t, "user-id", role.ModifiedBy)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetBoardCustomRolesForBoard(board.ID).Return([]*model.BoardCustomRole{existing}, nil)
+
+		_, err := th.App.CreateBoardCustomRole(&model.BoardCustomRole{
+			BoardID: board.ID,
+			Name:    "Reviewer",
+		}, "user-id")
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("unknown property", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+		_, err := th.App.CreateBoardCustomRole(&model.BoardCustomRole{
+			BoardID:     board.ID,
+			Name:        "Mover",
+			Permissions: []string{model.PermissionEditBoardCardProperties.Id},
+			PropertyIDs: []string{"priority"},
+		}, "user-id")
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("admin permission", func(t *testing.T) {
+		_, err := th.App.CreateBoardCustomRole(&model.BoardCustomRole{
+			BoardID:     board.ID,
+			Name:        "Owner",
+			Permissions: []string{model.PermissionManageBoardRoles.Id},
+		}, "user-id")
+		require.True(t, model.IsErrBadRequest(err))
+	})
+}
+
+func TestApplyBoardCustomRole(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	role := &model.BoardCustomRole{ID: "role-id", BoardID: boardID, Name: "Contractor"}
+
+	t.Run("the custom role replaces the scheme roles", func(t *testing.T) {
+		th.Store.EXPECT().GetBoardCustomRole(role.ID).Return(role, nil)
+
+		member := &model.BoardMember{BoardID: boardID, UserID: "user-id", SchemeEditor: true, CustomRoleID: role.ID}
+		require.NoError(t, th.App.applyBoardCustomRole(member))
+		require.False(t, member.SchemeEditor)
+		require.True(t, member.SchemeViewer)
+	})
+
+	t.Run("role of another board", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("other-board-id").Return(&model.Board{ID: "other-board-id"}, nil)
+		th.Store.EXPECT().GetMemberForBoard("other-board-id", "user-id").Return(&model.BoardMember{BoardID: "other-board-id", UserID: "user-id", SchemeViewer: true}, nil)
+		th.Store.EXPECT().GetBoardCustomRole(role.ID).Return(role, nil)
+
+		_, err := th.App.UpdateBoardMember(&model.BoardMember{BoardID: "other-board-id", UserID: "user-id", CustomRoleID: role.ID})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("role of another board for a new member", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("other-board-id").Return(&model.Board{ID: "other-board-id"}, nil)
+		th.Store.EXPECT().GetMemberForBoard("other-board-id", "user-id").Return(nil, model.NewErrNotFound("board member"))
+		th.Store.EXPECT().GetBoardCustomRole(role.ID).Return(role, nil)
+
+		_, err := th.App.AddMemberToBoard(&model.BoardMember{BoardID: "other-board-id", UserID: "user-id", CustomRoleID: role.ID})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		th.Store.EXPECT().GetBoardCustomRole("unknown").Return(nil, model.NewErrNotFound("board custom role ID=unknown"))
+
+		err := th.App.applyBoardCustomRole(&model.BoardMember{BoardID: boardID, UserID: "user-id", CustomRoleID: "unknown"})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+}
+
+func TestGetCustomRoleForMember(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	role := &model.BoardCustomRole{ID: "role-id", BoardID: boardID, Name: "Contractor"}
+
+	th.Store.EXPECT().GetMemberForBoard(boardID, "user-id").Return(&model.BoardMember{BoardID: boardID, UserID: "user-id", CustomRoleID: role.ID}, nil)
+	th.Store.EXPECT().GetBoardCustomRole(role.ID).Return(role, nil)
+
+	memberRole, err := th.App.GetCustomRoleForMember(boardID, "user-id")
+	require.NoError(t, err)
+	require.Equal(t, role, memberRole)
+
+	th.Store.EXPECT().GetMemberForBoard(boardID, "other-user-id").Return(&model.BoardMember{BoardID: boardID, UserID: "other-user-id", SchemeEditor: true}, nil)
+
+	_, err = th.App.GetCustomRoleForMember(boardID, "other-user-id")
+	require.True(t, model.IsErrNotFound(err))
+}
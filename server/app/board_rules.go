@@ -0,0 +1,173 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *App) CreateBoardRule(rule *model.BoardRule, userID string) (*model.BoardRule, error) {
+	newRule := *rule
+	newRule.ID = utils.NewID(utils.IDTypeBlock)
+	newRule.CreatedBy = userID
+	newRule.ModifiedBy = userID
+
+	if err := newRule.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	return a.store.CreateBoardRule(&newRule)
+}
+
+func (a *App) UpdateBoardRule(rule *model.BoardRule, userID string) (*model.BoardRule, error) {
+	existingRule, err := a.store.GetBoardRule(rule.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedRule := *rule
+	updatedRule.BoardID = existingRule.BoardID
+	updatedRule.CreatedBy = existingRule.CreatedBy
+	updatedRule.CreateAt = existingRule.CreateAt
+	updatedRule.ModifiedBy = userID
+
+	if err = updatedRule.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	return a.store.UpdateBoardRule(&updatedRule)
+}
+
+func (a *App) GetBoardRule(id string) (*model.BoardRule, error) {
+	return a.store.GetBoardRule(id)
+}
+
+func (a *App) GetBoardRulesForBoard(boardID string) ([]*model.BoardRule, error) {
+	return a.store.GetBoardRulesForBoard(boardID)
+}
+
+func (a *App) DeleteBoardRule(id string) error {
+	return a.store.DeleteBoardRule(id)
+}
+
+// applyBoardRules returns the patch of a card extended with the property
+// changes of the board rules it fires, so they are saved along with it. The rest
+// of the actions of the fired rules are executed by the rules notification
+// backend once the change is saved, whether notifications are disabled or not.
+func (a *App) applyBoardRules(board *model.Board, oldBlock *model.Block, blockPatch *model.BlockPatch) (*model.BlockPatch, error) {
+	if oldBlock.Type != model.TypeCard {
+		return blockPatch, nil
+	}
+
+	newProperties, ok := blockPatch.UpdatedFields["properties"].(map[string]interface{})
+	if !ok {
+		return blockPatch, nil
+	}
+
+	rules, err := a.store.GetBoardRulesForBoard(board.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return blockPatch, nil
+	}
+
+	oldProperties, _ := oldBlock.Fields["properties"].(map[string]interface{})
+	properties, fired := model.EvaluateBoardRules(board, rules, oldProperties, newProperties)
+	if len(fired) == 0 {
+		return blockPatch, nil
+	}
+
+	a.logger.Debug("Board rules fired",
+		mlog.String("board_id", board.ID),
+		mlog.String("card_id", oldBlock.ID),
+		mlog.Int("count", len(fired)),
+	)
+
+	updatedFields := make(map[string]interface{}, len(blockPatch.UpdatedFields))
+	for key, value := range blockPatch.UpdatedFields {
+		updatedFields[key] = value
+	}
+	updatedFields["properties"] = properties
+
+	patch := *blockPatch
+	patch.UpdatedFields = updatedFields
+	return &patch, nil
+}
+
+// applyBoardRulesToBatch applies the board rules to every card patch of a batch.
+func (a *App) applyBoardRulesToBatch(oldBlocks []*model.Block, blockPatches *model.BlockPatchBatch) (*model.BlockPatchBatch, error) {
+	oldBlocksByID := make(map[string]*model.Block, len(oldBlocks))
+	for _, block := range oldBlocks {
+		oldBlocksByID[block.ID] = block
+	}
+
+	boards := map[string]*model.Board{}
+	patches := make([]model.BlockPatch, len(blockPatches.BlockPatches))
+	for i, blockPatch := range blockPatches.BlockPatches {
+		patches[i] = blockPatch
+
+		oldBlock, ok := oldBlocksByID[blockPatches.BlockIDs[i]]
+		if !ok || oldBlock.Type != model.TypeCard {
+			continue
+		}
+
+		board, ok := boards[oldBlock.BoardID]
+		if !ok {
+			var err error
+			if board, err = a.store.GetBoard(oldBlock.BoardID); err != nil {
+				return nil, err
+			}
+			boards[oldBlock.BoardID] = board
+		}
+
+		patch, err := a.applyBoardRules(board, oldBlock, &blockPatch)
+		if err != nil {
+			return nil, err
+		}
+		patches[i] = *patch
+	}
+
+	return &model.BlockPatchBatch{BlockIDs: blockPatches.BlockIDs, BlockPatches: patches}, nil
+}
+
+// MoveCardToBoard moves a card and its content to another board of the same
+// team, without sending notifications.
+func (a *App) MoveCardToBoard(cardID string, boardID string, userID string) error {
+	card, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return err
+	}
+
+	board, err := a.store.GetBoard(card.BoardID)
+	if err != nil {
+		return err
+	}
+
+	destinationBoard, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return err
+	}
+
+	if board.TeamID != destinationBoard.TeamID {
+		return model.NewErrBadRequest("cards can only be moved to boards of the same team")
+	}
+
+	blocks, err := a.store.MoveCardToBoard(cardID, boardID, userID)
+	if err != nil {
+		return err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		for _, block := range blocks {
+			a.wsAdapter.BroadcastBlockDelete(board.TeamID, block.ID, board.ID)
+			a.wsAdapter.BroadcastBlockChange(destinationBoard.TeamID, block)
+		}
+		return nil
+	})
+	return nil
+}
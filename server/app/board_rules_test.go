@@ -0,0 +1,189 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+// silentTestBackend records the block change events it is informed of.
+type silentTestBackend struct {
+	changed  chan notify.BlockChangeEvent
+	silenced chan notify.BlockChangeEvent
+}
+
+func (b *silentTestBackend) Start() error    { return nil }
+func (b *silentTestBackend) ShutDown() error { return nil }
+func (b *silentTestBackend) Name() string    { return "silentTest" }
+
+func (b *silentTestBackend) BlockChanged(evt notify.BlockChangeEvent) error {
+	b.changed <- evt
+	return nil
+}
+
+func (b *silentTestBackend) BlockChangedSilently(evt notify.BlockChangeEvent) error {
+	b.silenced <- evt
+	return nil
+}
+
+func TestCreateBoardRule(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	userID := utils.NewID(utils.IDTypeUser)
+	boardID := utils.NewID(utils.IDTypeBoard)
+
+	t.Run("create rule", func(t *testing.T) {
+		th.Store.EXPECT().CreateBoardRule(gomock.Any()).DoAndReturn(
+			func(rule *model.BoardRule) (*model.BoardRule, error) {
+				return rule, nil
+			})
+
+		rule, err := th.App.CreateBoardRule(&model.BoardRule{
+			BoardID: boardID,
+			Trigger: model.RuleTrigger{PropertyID: "status", Value: "done"},
+			Actions: []model.RuleAction{{Type: model.RuleActionAddComment, Message: "Done!"}},
+		}, userID)
+		require.NoError(t, err)
+		require.NotEmpty(t, rule.ID)
+		require.Equal(t, userID, rule.CreatedBy)
+		require.Equal(t, userID, rule.ModifiedBy)
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		rule, err := th.App.CreateBoardRule(&model.BoardRule{
+			BoardID: boardID,
+			Trigger: model.RuleTrigger{PropertyID: "status", Value: "done"},
+		}, userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, rule)
+	})
+}
+
+func TestApplyBoardRules(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID: utils.NewID(utils.IDTypeBoard),
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": "select"},
+			{"id": "priority", "name": "Priority", "type": "select"},
+		},
+	}
+	card := &model.Block{
+		ID:      utils.NewID(utils.IDTypeCard),
+		BoardID: board.ID,
+		Type:    model.TypeCard,
+		Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "todo"},
+		},
+	}
+	rule := &model.BoardRule{
+		ID:      utils.NewID(utils.IDTypeBlock),
+		BoardID: board.ID,
+		Enabled: true,
+		Trigger: model.RuleTrigger{PropertyID: "status", Value: "done"},
+		Actions: []model.RuleAction{{Type: model.RuleActionSetProperty, PropertyID: "priority", Value: "low"}},
+	}
+
+	t.Run("rule fired", func(t *testing.T) {
+		th.Store.EXPECT().GetBoardRulesForBoard(board.ID).Return([]*model.BoardRule{rule}, nil)
+
+		blockPatch := &model.BlockPatch{
+			UpdatedFields: map[string]interface{}{
+				"properties": map[string]interface{}{"status": "done"},
+			},
+		}
+		patch, err := th.App.applyBoardRules(board, card, blockPatch)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"status": "done", "priority": "low"}, patch.UpdatedFields["properties"])
+		require.Equal(t, map[string]interface{}{"status": "done"}, blockPatch.UpdatedFields["properties"], "the original patch is not modified")
+	})
+
+	t.Run("rule not fired", func(t *testing.T) {
+		th.Store.EXPECT().GetBoardRulesForBoard(board.ID).Return([]*model.BoardRule{rule}, nil)
+
+		blockPatch := &model.BlockPatch{
+			UpdatedFields: map[string]interface{}{
+				"properties": map[string]interface{}{"status": "doing"},
+			},
+		}
+		patch, err := th.App.applyBoardRules(board, card, blockPatch)
+		require.NoError(t, err)
+		require.Equal(t, blockPatch, patch)
+	})
+
+	t.Run("properties not patched", func(t *testing.T) {
+		title := "new title"
+		blockPatch := &model.BlockPatch{Title: &title}
+		patch, err := th.App.applyBoardRules(board, card, blockPatch)
+		require.NoError(t, err)
+		require.Equal(t, blockPatch, patch)
+	})
+	t.Run("rule fired with notifications disabled", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(card.ID).Return(card, nil).Times(2)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetBoardRulesForBoard(board.ID).Return([]*model.BoardRule{rule}, nil)
+		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+		th.Store.EXPECT().GetBoardWebhooksForBoard(board.ID).Return([]*model.BoardWebhook{}, nil).AnyTimes()
+		th.Store.EXPECT().PatchBlock(card.ID, gomock.Any(), "user-id").DoAndReturn(
+			func(blockID string, patch *model.BlockPatch, userID string) error {
+				require.Equal(t, map[string]interface{}{"status": "done", "priority": "low"}, patch.UpdatedFields["properties"])
+				return nil
+			})
+
+		blockPatch := &model.BlockPatch{
+			UpdatedFields: map[string]interface{}{
+				"properties": map[string]interface{}{"status": "done"},
+			},
+		}
+		_, err := th.App.PatchBlockAndNotify(card.ID, blockPatch, "user-id", true)
+		require.NoError(t, err)
+	})
+
+	t.Run("rule actions executed with notifications disabled", func(t *testing.T) {
+		backend := &silentTestBackend{
+			changed:  make(chan notify.BlockChangeEvent, 1),
+			silenced: make(chan notify.BlockChangeEvent, 2),
+		}
+		notifications, err := notify.New(th.logger, backend)
+		require.NoError(t, err)
+		th.App.notifications = notifications
+		defer func() { th.App.notifications = nil }()
+
+		th.Store.EXPECT().GetBlock(card.ID).Return(card, nil).AnyTimes()
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+		th.Store.EXPECT().GetBoardRulesForBoard(board.ID).Return([]*model.BoardRule{rule}, nil)
+		th.Store.EXPECT().GetMemberForBoard(board.ID, "user-id").Return(&model.BoardMember{BoardID: board.ID, UserID: "user-id"}, nil).AnyTimes()
+		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+		th.Store.EXPECT().GetBoardWebhooksForBoard(board.ID).Return([]*model.BoardWebhook{}, nil).AnyTimes()
+		th.Store.EXPECT().PatchBlock(card.ID, gomock.Any(), "user-id").Return(nil)
+
+		blockPatch := &model.BlockPatch{
+			UpdatedFields: map[string]interface{}{
+				"properties": map[string]interface{}{"status": "done"},
+			},
+		}
+		_, err = th.App.PatchBlockAndNotify(card.ID, blockPatch, "user-id", true)
+		require.NoError(t, err)
+
+		// the rules backend is informed of the change, the other backends are not
+		select {
+		case evt := <-backend.silenced:
+			require.Equal(t, notify.Update, evt.Action)
+			require.Equal(t, card.ID, evt.BlockChanged.ID)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "the silent backend was not informed of the change")
+		}
+		require.Empty(t, backend.changed)
+	})
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	// webhookDeliveryClaimTime is how long an attempt of a delivery can take
+	// before another server considers it abandoned and attempts it again.
+	webhookDeliveryClaimTime = int64(5 * 60 * 1000)
+
+	// webhookDeliveryBatchSize is the number of due deliveries attempted each
+	// time the delivery queue is processed.
+	webhookDeliveryBatchSize = 100
+)
+
+func (a *App) CreateBoardWebhook(webhook *model.BoardWebhook, userID string) (*model.BoardWebhook, error) {
+	newWebhook := *webhook
+	newWebhook.ID = utils.NewID(utils.IDTypeBlock)
+	newWebhook.Secret = utils.NewID(utils.IDTypeToken)
+	newWebhook.CreatedBy = userID
+
+	if err := newWebhook.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+	if err := a.webhook.CheckURL(newWebhook.URL); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	return a.store.CreateBoardWebhook(&newWebhook)
+}
+
+func (a *App) UpdateBoardWebhook(webhook *model.BoardWebhook) (*model.BoardWebhook, error) {
+	existingWebhook, err := a.store.GetBoardWebhook(webhook.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedWebhook := *webhook
+	updatedWebhook.BoardID = existingWebhook.BoardID
+	updatedWebhook.Secret = existingWebhook.Secret
+	updatedWebhook.CreatedBy = existingWebhook.CreatedBy
+	updatedWebhook.CreateAt = existingWebhook.CreateAt
+
+	if err = updatedWebhook.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+	if err = a.webhook.CheckURL(updatedWebhook.URL); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	newWebhook, err := a.store.UpdateBoardWebhook(&updatedWebhook)
+	if err != nil {
+		return nil, err
+	}
+	newWebhook.Sanitize()
+	return newWebhook, nil
+}
+
+func (a *App) GetBoardWebhook(id string) (*model.BoardWebhook, error) {
+	webhook, err := a.store.GetBoardWebhook(id)
+	if err != nil {
+		return nil, err
+	}
+	webhook.Sanitize()
+	return webhook, nil
+}
+
+func (a *App) GetBoardWebhooksForBoard(boardID string) ([]*model.BoardWebhook, error) {
+	webhooks, err := a.store.GetBoardWebhooksForBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	for _, webhook := range webhooks {
+		webhook.Sanitize()
+	}
+	return webhooks, nil
+}
+
+func (a *App) DeleteBoardWebhook(id string) error {
+	return a.store.DeleteBoardWebhook(id)
+}
+
+func (a *App) GetWebhookDeliveries(webhookID string, page, perPage int) ([]*model.WebhookDelivery, error) {
+	return a.store.GetWebhookDeliveries(webhookID, page, perPage)
+}
+
+// notifyBoardWebhooks queues the webhook events of a block change. The old
+// block is nil for inserted blocks.
+func (a *App) notifyBoardWebhooks(teamID string, block *model.Block, oldBlock *model.Block) {
+	payload := &model.WebhookPayload{
+		TeamID:    teamID,
+		BoardID:   block.BoardID,
+		Timestamp: utils.GetMillis(),
+	}
+
+	switch {
+	case oldBlock == nil && block.Type == model.TypeCard:
+		payload.Event = model.WebhookEventCardCreated
+		payload.Card = block
+	case oldBlock == nil && block.Type == model.TypeComment:
+		card, err := a.store.GetBlock(block.ParentID)
+		if err != nil {
+			a.logger.Error("Cannot fetch the card of a comment for webhooks", mlog.String("block_id", block.ID), mlog.Err(err))
+			return
+		}
+		payload.Event = model.WebhookEventCommentAdded
+		payload.Card = card
+		payload.Comment = block
+	case oldBlock != nil && block.Type == model.TypeCard:
+		changed := model.ChangedCardProperties(oldBlock, block)
+		if len(changed) == 0 {
+			return
+		}
+		payload.Event = model.WebhookEventPropertyChanged
+		payload.Card = block
+		payload.ChangedProperties = changed
+	default:
+		return
+	}
+
+	a.queueWebhookEvent(payload)
+}
+
+// queueWebhookEvent saves a delivery of the event for every webhook of the
+// board subscribed to it, and attempts them in the background.
+func (a *App) queueWebhookEvent(payload *model.WebhookPayload) {
+	webhooks, err := a.store.GetBoardWebhooksForBoard(payload.BoardID)
+	if err != nil {
+		a.logger.Error("Cannot fetch board webhooks", mlog.String("board_id", payload.BoardID), mlog.Err(err))
+		return
+	}
+
+	var data []byte
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(payload.Event) {
+			continue
+		}
+
+		if data == nil {
+			if data, err = json.Marshal(payload); err != nil {
+				a.logger.Error("Cannot marshal webhook payload", mlog.String("event", payload.Event), mlog.Err(err))
+				return
+			}
+		}
+
+		// the delivery is created claimed so it is only attempted by this
+		// server, unless the attempt is abandoned
+		delivery := &model.WebhookDelivery{
+			ID:            utils.NewID(utils.IDTypeNone),
+			WebhookID:     webhook.ID,
+			BoardID:       webhook.BoardID,
+			Event:         payload.Event,
+			Payload:       string(data),
+			Status:        model.WebhookDeliveryPending,
+			NextAttemptAt: utils.GetMillis() + webhookDeliveryClaimTime,
+		}
+		if err := a.store.InsertWebhookDelivery(delivery); err != nil {
+			a.logger.Error("Cannot queue webhook delivery", mlog.String("webhook_id", webhook.ID), mlog.Err(err))
+			continue
+		}
+
+		wh := webhook
+		a.webhookDeliverer.Enqueue(func() error {
+			a.attemptWebhookDelivery(wh, delivery)
+			return nil
+		})
+	}
+}
+
+// ProcessWebhookDeliveries attempts the deliveries whose retry is due.
+func (a *App) ProcessWebhookDeliveries() {
+	now := utils.GetMillis()
+
+	deliveries, err := a.store.GetDueWebhookDeliveries(now, webhookDeliveryBatchSize)
+	if err != nil {
+		a.logger.Error("Cannot fetch due webhook deliveries", mlog.Err(err))
+		return
+	}
+
+	webhooks := map[string]*model.BoardWebhook{}
+	for _, delivery := range deliveries {
+		claimed, err := a.store.ClaimWebhookDelivery(delivery, now+webhookDeliveryClaimTime)
+		if err != nil {
+			a.logger.Error("Cannot claim webhook delivery", mlog.String("delivery_id", delivery.ID), mlog.Err(err))
+			continue
+		}
+		if !claimed {
+			// another server is attempting the delivery
+			continue
+		}
+
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			webhook, err = a.store.GetBoardWebhook(delivery.WebhookID)
+			if err != nil && !model.IsErrNotFound(err) {
+				a.logger.Error("Cannot fetch the webhook of a delivery", mlog.String("delivery_id", delivery.ID), mlog.Err(err))
+				continue
+			}
+			// a deleted webhook is cached as nil
+			webhooks[delivery.WebhookID] = webhook
+		}
+
+		// the deliveries of deleted and disabled webhooks are not retried
+		switch {
+		case webhook == nil:
+			a.abandonWebhookDelivery(delivery, "webhook deleted")
+		case !webhook.Enabled:
+			a.abandonWebhookDelivery(delivery, "webhook disabled")
+		default:
+			a.attemptWebhookDelivery(webhook, delivery)
+		}
+	}
+}
+
+func (a *App) abandonWebhookDelivery(delivery *model.WebhookDelivery, reason string) {
+	delivery.Abandon(reason)
+	if err := a.store.UpdateWebhookDelivery(delivery); err != nil {
+		a.logger.Error("Cannot save webhook delivery", mlog.String("delivery_id", delivery.ID), mlog.Err(err))
+	}
+}
+
+func (a *App) attemptWebhookDelivery(webhook *model.BoardWebhook, delivery *model.WebhookDelivery) {
+	responseCode, err := a.webhook.Deliver(webhook, delivery)
+	delivery.RecordAttempt(utils.GetMillis(), responseCode, err)
+
+	if err != nil {
+		a.logger.Warn("Webhook delivery attempt failed",
+			mlog.String("webhook_id", webhook.ID),
+			mlog.String("delivery_id", delivery.ID),
+			mlog.Int("attempts", delivery.Attempts),
+			mlog.String("status", delivery.Status),
+			mlog.Err(err),
+		)
+	}
+
+	if err := a.store.UpdateWebhookDelivery(delivery); err != nil {
+		a.logger.Error("Cannot save webhook delivery", mlog.String("delivery_id", delivery.ID), mlog.Err(err))
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/webhook"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBoardWebhook(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	userID := utils.NewID(utils.IDTypeUser)
+	boardID := utils.NewID(utils.IDTypeBoard)
+
+	t.Run("create webhook", func(t *testing.T) {
+		th.Store.EXPECT().CreateBoardWebhook(gomock.Any()).DoAndReturn(
+			func(webhook *model.BoardWebhook) (*model.BoardWebhook, error) {
+				return webhook, nil
+			})
+
+		webhook, err := th.App.CreateBoardWebhook(&model.BoardWebhook{
+			BoardID: boardID,
+			URL:     "https://example.com/hook",
+			Events:  []string{model.WebhookEventCardCreated},
+			Secret:  "ignored",
+		}, userID)
+		require.NoError(t, err)
+		require.NotEmpty(t, webhook.ID)
+		require.NotEmpty(t, webhook.Secret)
+		require.NotEqual(t, "ignored", webhook.Secret)
+		require.Equal(t, userID, webhook.CreatedBy)
+	})
+
+	t.Run("invalid webhook", func(t *testing.T) {
+		webhook, err := th.App.CreateBoardWebhook(&model.BoardWebhook{
+			BoardID: boardID,
+			URL:     "https://example.com/hook",
+		}, userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, webhook)
+	})
+
+	t.Run("internal address", func(t *testing.T) {
+		webhook, err := th.App.CreateBoardWebhook(&model.BoardWebhook{
+			BoardID: boardID,
+			URL:     "http://169.254.169.254/latest/meta-data",
+			Events:  []string{model.WebhookEventCardCreated},
+		}, userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, webhook)
+	})
+}
+
+func TestProcessWebhookDeliveries(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+	th.App.config.AllowedInternalHosts = "127.0.0.1"
+
+	var signature string
+	var payload []byte
+	status := http.StatusOK
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(webhook.HeaderSignature)
+		payload, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer ts.Close()
+
+	boardWebhook := &model.BoardWebhook{
+		ID:      utils.NewID(utils.IDTypeBlock),
+		BoardID: utils.NewID(utils.IDTypeBoard),
+		URL:     ts.URL,
+		Secret:  "secret",
+		Events:  []string{model.WebhookEventCardCreated},
+		Enabled: true,
+	}
+	newDelivery := func() *model.WebhookDelivery {
+		return &model.WebhookDelivery{
+			ID:        utils.NewID(utils.IDTypeNone),
+			WebhookID: boardWebhook.ID,
+			BoardID:   boardWebhook.BoardID,
+			Event:     model.WebhookEventCardCreated,
+			Payload:   `{"event":"card.created"}`,
+			Status:    model.WebhookDeliveryPending,
+		}
+	}
+
+	t.Run("successful delivery", func(t *testing.T) {
+		status = http.StatusOK
+		delivery := newDelivery()
+
+		th.Store.EXPECT().GetDueWebhookDeliveries(gomock.Any(), webhookDeliveryBatchSize).Return([]*model.WebhookDelivery{delivery}, nil)
+		th.Store.EXPECT().ClaimWebhookDelivery(delivery, gomock.Any()).Return(true, nil)
+		th.Store.EXPECT().GetBoardWebhook(boardWebhook.ID).Return(boardWebhook, nil)
+		th.Store.EXPECT().UpdateWebhookDelivery(delivery).Return(nil)
+
+		th.App.ProcessWebhookDeliveries()
+
+		require.Equal(t, model.WebhookDeliverySuccess, delivery.Status)
+		require.Equal(t, 1, delivery.Attempts)
+		require.Equal(t, http.StatusOK, delivery.ResponseCode)
+		require.Equal(t, delivery.Payload, string(payload))
+		require.Equal(t, webhook.Sign("secret", payload), signature)
+	})
+
+	t.Run("failed delivery is retried", func(t *testing.T) {
+		status = http.StatusServiceUnavailable
+		delivery := newDelivery()
+
+		th.Store.EXPECT().GetDueWebhookDeliveries(gomock.Any(), webhookDeliveryBatchSize).Return([]*model.WebhookDelivery{delivery}, nil)
+		th.Store.EXPECT().ClaimWebhookDelivery(delivery, gomock.Any()).Return(true, nil)
+		th.Store.EXPECT().GetBoardWebhook(boardWebhook.ID).Return(boardWebhook, nil)
+		th.Store.EXPECT().UpdateWebhookDelivery(delivery).Return(nil)
+
+		th.App.ProcessWebhookDeliveries()
+
+		require.Equal(t, model.WebhookDeliveryPending, delivery.Status)
+		require.Equal(t, http.StatusServiceUnavailable, delivery.ResponseCode)
+		require.Greater(t, delivery.NextAttemptAt, delivery.LastAttemptAt)
+		require.NotEmpty(t, delivery.Error)
+	})
+
+	t.Run("delivery of a disabled webhook", func(t *testing.T) {
+		delivery := newDelivery()
+		disabledWebhook := *boardWebhook
+		disabledWebhook.Enabled = false
+
+		th.Store.EXPECT().GetDueWebhookDeliveries(gomock.Any(), webhookDeliveryBatchSize).Return([]*model.WebhookDelivery{delivery}, nil)
+		th.Store.EXPECT().ClaimWebhookDelivery(delivery, gomock.Any()).Return(true, nil)
+		th.Store.EXPECT().GetBoardWebhook(boardWebhook.ID).Return(&disabledWebhook, nil)
+		th.Store.EXPECT().UpdateWebhookDelivery(delivery).Return(nil)
+
+		th.App.ProcessWebhookDeliveries()
+
+		require.Equal(t, model.WebhookDeliveryFailed, delivery.Status)
+		require.Equal(t, 0, delivery.Attempts)
+		require.Zero(t, delivery.NextAttemptAt)
+	})
+
+	t.Run("delivery of a deleted webhook", func(t *testing.T) {
+		delivery := newDelivery()
+		otherDelivery := newDelivery()
+
+		th.Store.EXPECT().GetDueWebhookDeliveries(gomock.Any(), webhookDeliveryBatchSize).Return([]*model.WebhookDelivery{delivery, otherDelivery}, nil)
+		th.Store.EXPECT().ClaimWebhookDelivery(gomock.Any(), gomock.Any()).Return(true, nil).Times(2)
+		th.Store.EXPECT().GetBoardWebhook(boardWebhook.ID).Return(nil, model.NewErrNotFound("board webhook ID="+boardWebhook.ID))
+		th.Store.EXPECT().UpdateWebhookDelivery(gomock.Any()).Return(nil).Times(2)
+
+		th.App.ProcessWebhookDeliveries()
+
+		require.Equal(t, model.WebhookDeliveryFailed, delivery.Status)
+		require.Equal(t, model.WebhookDeliveryFailed, otherDelivery.Status)
+		require.Zero(t, delivery.NextAttemptAt)
+	})
+
+	t.Run("delivery claimed by another server", func(t *testing.T) {
+		delivery := newDelivery()
+
+		th.Store.EXPECT().GetDueWebhookDeliveries(gomock.Any(), webhookDeliveryBatchSize).Return([]*model.WebhookDelivery{delivery}, nil)
+		th.Store.EXPECT().ClaimWebhookDelivery(delivery, gomock.Any()).Return(false, nil)
+
+		th.App.ProcessWebhookDeliveries()
+
+		require.Equal(t, 0, delivery.Attempts)
+	})
+}
+
+func TestNotifyBoardWebhooks(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	boardWebhook := &model.BoardWebhook{
+		ID:      utils.NewID(utils.IDTypeBlock),
+		BoardID: boardID,
+		URL:     "http://localhost:0/hook",
+		Events:  []string{model.WebhookEventPropertyChanged},
+		Enabled: true,
+	}
+	oldCard := &model.Block{
+		ID:      utils.NewID(utils.IDTypeCard),
+		BoardID: boardID,
+		Type:    model.TypeCard,
+		Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "todo"},
+		},
+	}
+
+	t.Run("property changed", func(t *testing.T) {
+		card := *oldCard
+		card.Fields = map[string]interface{}{
+			"properties": map[string]interface{}{"status": "done"},
+		}
+
+		var queued *model.WebhookDelivery
+		th.Store.EXPECT().GetBoardWebhooksForBoard(boardID).Return([]*model.BoardWebhook{boardWebhook}, nil)
+		th.Store.EXPECT().InsertWebhookDelivery(gomock.Any()).DoAndReturn(
+			func(delivery *model.WebhookDelivery) error {
+				queued = delivery
+				return nil
+			})
+		th.Store.EXPECT().UpdateWebhookDelivery(gomock.Any()).Return(nil).AnyTimes()
+
+		th.App.notifyBoardWebhooks("team-id", &card, oldCard)
+
+		require.NotNil(t, queued)
+		require.Equal(t, model.WebhookEventPropertyChanged, queued.Event)
+
+		var payload model.WebhookPayload
+		require.NoError(t, json.Unmarshal([]byte(queued.Payload), &payload))
+		require.Equal(t, []string{"status"}, payload.ChangedProperties)
+		require.Equal(t, card.ID, payload.Card.ID)
+	})
+
+	t.Run("event not subscribed", func(t *testing.T) {
+		card := &model.Block{
+			ID:      utils.NewID(utils.IDTypeCard),
+			BoardID: boardID,
+			Type:    model.TypeCard,
+		}
+		th.Store.EXPECT().GetBoardWebhooksForBoard(boardID).Return([]*model.BoardWebhook{boardWebhook}, nil)
+
+		th.App.notifyBoardWebhooks("team-id", card, nil)
+	})
+
+	t.Run("properties unchanged", func(t *testing.T) {
+		th.App.notifyBoardWebhooks("team-id", oldCard, oldCard)
+	})
+}
@@ -342,6 +342,17 @@ func (a *App) PatchBoard(patch *model.BoardPatch, boardID, userID string) (*mode
 		}
 	}
 
+	// the schema before the patch tells whether the computed properties of
+	// the cards need to be recomputed
+	var oldSchema model.PropSchema
+	if len(patch.UpdatedCardProperties) != 0 || len(patch.DeletedCardProperties) != 0 {
+		board, err := a.store.GetBoard(boardID)
+		if err != nil {
+			return nil, err
+		}
+		oldSchema, _ = model.ParsePropertySchema(board)
+	}
+
 	updatedBoard, err := a.store.PatchBoard(boardID, patch, userID)
 	if err != nil {
 		return nil, err
@@ -374,6 +385,7 @@ func (a *App) PatchBoard(patch *model.BoardPatch, boardID, userID string) (*mode
 	// Broadcast Messages to affected users
 	a.blockChangeNotifier.Enqueue(func() error {
 		a.wsAdapter.BroadcastBoardChange(updatedBoard.TeamID, updatedBoard)
+		a.notifyBoardChanged(updatedBoard)
 
 		if patch.ChannelID != nil {
 			if *patch.ChannelID != "" {
@@ -402,6 +414,13 @@ func (a *App) PatchBoard(patch *model.BoardPatch, boardID, userID string) (*mode
 			}
 			a.broadcastTeamUsers(updatedBoard.TeamID, updatedBoard.ID, *patch.Type, members)
 		}
+
+		if len(patch.UpdatedCardProperties) != 0 || len(patch.DeletedCardProperties) != 0 {
+			newSchema, _ := model.ParsePropertySchema(updatedBoard)
+			if model.ComputedPropertiesChanged(oldSchema, newSchema) {
+				a.updateComputedProperties(updatedBoard, nil)
+			}
+		}
 		return nil
 	})
 
@@ -455,6 +474,13 @@ func (a *App) DeleteBoard(boardID, userID string) error {
 
 	a.blockChangeNotifier.Enqueue(func() error {
 		a.wsAdapter.BroadcastBoardDelete(board.TeamID, boardID)
+		a.queueWebhookEvent(&model.WebhookPayload{
+			Event:     model.WebhookEventBoardDeleted,
+			TeamID:    board.TeamID,
+			BoardID:   boardID,
+			Timestamp: utils.GetMillis(),
+			Board:     board,
+		})
 		return nil
 	})
 
@@ -528,6 +554,10 @@ func (a *App) AddMemberToBoard(member *model.BoardMember) (*model.BoardMember, e
 		return existingMembership, nil
 	}
 
+	if err = a.applyBoardCustomRole(member); err != nil {
+		return nil, err
+	}
+
 	newMember, err := a.store.SaveMember(member)
 	if err != nil {
 		return nil, err
@@ -549,6 +579,7 @@ func (a *App) AddMemberToBoard(member *model.BoardMember) (*model.BoardMember, e
 
 	a.blockChangeNotifier.Enqueue(func() error {
 		a.wsAdapter.BroadcastMemberChange(board.TeamID, member.BoardID, member)
+		a.notifyBoardChanged(board)
 		return nil
 	})
 
@@ -572,6 +603,10 @@ func (a *App) UpdateBoardMember(member *model.BoardMember) (*model.BoardMember,
 		return nil, err
 	}
 
+	if err = a.applyBoardCustomRole(member); err != nil {
+		return nil, err
+	}
+
 	// if we're updating an admin, we need to check that there is at
 	// least still another admin on the board
 	if oldMember.SchemeAdmin && !member.SchemeAdmin {
@@ -591,12 +626,25 @@ func (a *App) UpdateBoardMember(member *model.BoardMember) (*model.BoardMember,
 
 	a.blockChangeNotifier.Enqueue(func() error {
 		a.wsAdapter.BroadcastMemberChange(board.TeamID, member.BoardID, member)
+		a.notifyBoardChanged(board)
 		return nil
 	})
 
 	return newMember, nil
 }
 
+// notifyBoardChanged informs the notification backends of a change of a board
+// or of its members.
+func (a *App) notifyBoardChanged(board *model.Board) {
+	if a.notifications == nil {
+		return
+	}
+	a.notifications.BoardChanged(notify.BoardChangeEvent{
+		TeamID: board.TeamID,
+		Board:  board,
+	})
+}
+
 func (a *App) isLastAdmin(userID, boardID string) (bool, error) {
 	members, err := a.store.GetMembersForBoard(boardID)
 	if err != nil {
@@ -650,6 +698,7 @@ func (a *App) DeleteBoardMember(boardID, userID string) error {
 		} else {
 			a.wsAdapter.BroadcastMemberDelete(board.TeamID, boardID, userID)
 		}
+		a.notifyBoardChanged(board)
 		return nil
 	})
 
@@ -0,0 +1,145 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+// CreateCardDependency creates a relationship where the blocking card blocks the
+// blocked card. Both cards must belong to boards of the same team, and the new
+// dependency cannot close a cycle of blocking cards.
+func (a *App) CreateCardDependency(dependency *model.CardDependency, userID string) (*model.CardDependency, error) {
+	blockingCard, err := a.GetCardByID(dependency.BlockingCardID)
+	if err != nil {
+		return nil, err
+	}
+
+	blockedCard, err := a.GetCardByID(dependency.BlockedCardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if blockingCard.BoardID != blockedCard.BoardID {
+		blockingBoard, err := a.store.GetBoard(blockingCard.BoardID)
+		if err != nil {
+			return nil, err
+		}
+		blockedBoard, err := a.store.GetBoard(blockedCard.BoardID)
+		if err != nil {
+			return nil, err
+		}
+		if blockingBoard.TeamID != blockedBoard.TeamID {
+			return nil, model.NewErrBadRequest("cards of different teams cannot depend on each other")
+		}
+	}
+
+	dependencies, err := a.store.GetCardDependenciesForCard(blockingCard.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range dependencies {
+		if d.BlockingCardID == blockingCard.ID && d.BlockedCardID == blockedCard.ID {
+			return nil, model.NewErrBadRequest("card dependency already exists")
+		}
+	}
+
+	// the new dependency closes a cycle if the blocked card already
+	// blocks the blocking card, directly or through other cards
+	isCycle, err := a.cardBlocks(blockedCard.ID, blockingCard.ID)
+	if err != nil {
+		return nil, err
+	}
+	if isCycle {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("card %s already depends on card %s", blockingCard.ID, blockedCard.ID))
+	}
+
+	newDependency := &model.CardDependency{
+		ID:              utils.NewID(utils.IDTypeBlock),
+		BlockingCardID:  blockingCard.ID,
+		BlockingBoardID: blockingCard.BoardID,
+		BlockedCardID:   blockedCard.ID,
+		BlockedBoardID:  blockedCard.BoardID,
+		CreatedBy:       userID,
+		CreateAt:        utils.GetMillis(),
+	}
+
+	createdDependency, err := a.store.CreateCardDependency(newDependency)
+	if err != nil {
+		return nil, err
+	}
+
+	if blockingCard.BoardID == blockedCard.BoardID {
+		a.blockChangeNotifier.Enqueue(func() error {
+			a.updateComputedPropertiesForBlocks(createdDependency.Cards())
+			return nil
+		})
+	}
+	return createdDependency, nil
+}
+
+// cardBlocks returns true if the blocking card blocks the blocked card, either
+// directly or through a chain of dependencies.
+func (a *App) cardBlocks(blockingCardID, blockedCardID string) (bool, error) {
+	visited := map[string]bool{blockingCardID: true}
+	pending := []string{blockingCardID}
+
+	for len(pending) > 0 {
+		cardID := pending[0]
+		pending = pending[1:]
+
+		dependencies, err := a.store.GetCardDependenciesForCard(cardID)
+		if err != nil {
+			return false, err
+		}
+
+		for _, dependency := range dependencies {
+			if dependency.BlockingCardID != cardID {
+				continue
+			}
+			if dependency.BlockedCardID == blockedCardID {
+				return true, nil
+			}
+			if !visited[dependency.BlockedCardID] {
+				visited[dependency.BlockedCardID] = true
+				pending = append(pending, dependency.BlockedCardID)
+			}
+		}
+	}
+	return false, nil
+}
+
+func (a *App) GetCardDependency(id string) (*model.CardDependency, error) {
+	return a.store.GetCardDependency(id)
+}
+
+func (a *App) GetCardDependenciesForCard(cardID string) ([]*model.CardDependency, error) {
+	return a.store.GetCardDependenciesForCard(cardID)
+}
+
+func (a *App) GetCardDependenciesForBoard(boardID string) ([]*model.CardDependency, error) {
+	return a.store.GetCardDependenciesForBoard(boardID)
+}
+
+func (a *App) DeleteCardDependency(id string) error {
+	dependency, err := a.store.GetCardDependency(id)
+	if err != nil {
+		return err
+	}
+
+	if err = a.store.DeleteCardDependency(id); err != nil {
+		return err
+	}
+
+	if dependency.BlockingBoardID == dependency.BlockedBoardID {
+		a.blockChangeNotifier.Enqueue(func() error {
+			a.updateComputedPropertiesForBlocks(dependency.Cards())
+			return nil
+		})
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCardDependency(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	userID := utils.NewID(utils.IDTypeUser)
+	board := &model.Board{ID: utils.NewID(utils.IDTypeBoard), TeamID: "team-id"}
+
+	makeCardBlock := func(boardID string) *model.Block {
+		return &model.Block{
+			ID:      utils.NewID(utils.IDTypeCard),
+			BoardID: boardID,
+			Type:    model.TypeCard,
+		}
+	}
+	blockingCard := makeCardBlock(board.ID)
+	blockedCard := makeCardBlock(board.ID)
+
+	t.Run("create dependency", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(blockingCard.ID).Return(blockingCard, nil)
+		th.Store.EXPECT().GetBlock(blockedCard.ID).Return(blockedCard, nil)
+		th.Store.EXPECT().GetCardDependenciesForCard(blockingCard.ID).Return([]*model.CardDependency{}, nil)
+		th.Store.EXPECT().GetCardDependenciesForCard(blockedCard.ID).Return([]*model.CardDependency{}, nil)
+		th.Store.EXPECT().CreateCardDependency(gomock.Any()).DoAndReturn(
+			func(dependency *model.CardDependency) (*model.CardDependency, error) {
+				return dependency, nil
+			})
+		// this call comes from the computed properties update
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).MaxTimes(1)
+
+		dependency, err := th.App.CreateCardDependency(&model.CardDependency{
+			BlockingCardID: blockingCard.ID,
+			BlockedCardID:  blockedCard.ID,
+		}, userID)
+		require.NoError(t, err)
+		require.NotEmpty(t, dependency.ID)
+		require.Equal(t, board.ID, dependency.BlockingBoardID)
+		require.Equal(t, board.ID, dependency.BlockedBoardID)
+		require.Equal(t, userID, dependency.CreatedBy)
+	})
+
+	t.Run("cards of different teams", func(t *testing.T) {
+		otherBoard := &model.Board{ID: utils.NewID(utils.IDTypeBoard), TeamID: "other-team-id"}
+		otherCard := makeCardBlock(otherBoard.ID)
+
+		th.Store.EXPECT().GetBlock(blockingCard.ID).Return(blockingCard, nil)
+		th.Store.EXPECT().GetBlock(otherCard.ID).Return(otherCard, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetBoard(otherBoard.ID).Return(otherBoard, nil)
+
+		dependency, err := th.App.CreateCardDependency(&model.CardDependency{
+			BlockingCardID: blockingCard.ID,
+			BlockedCardID:  otherCard.ID,
+		}, userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, dependency)
+	})
+
+	t.Run("existing dependency", func(t *testing.T) {
+		existing := &model.CardDependency{
+			ID:             utils.NewID(utils.IDTypeBlock),
+			BlockingCardID: blockingCard.ID,
+			BlockedCardID:  blockedCard.ID,
+		}
+
+		th.Store.EXPECT().GetBlock(blockingCard.ID).Return(blockingCard, nil)
+		th.Store.EXPECT().GetBlock(blockedCard.ID).Return(blockedCard, nil)
+		th.Store.EXPECT().GetCardDependenciesForCard(blockingCard.ID).Return([]*model.CardDependency{existing}, nil)
+
+		dependency, err := th.App.CreateCardDependency(&model.CardDependency{
+			BlockingCardID: blockingCard.ID,
+			BlockedCardID:  blockedCard.ID,
+		}, userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, dependency)
+	})
+
+	t.Run("dependency closing a cycle", func(t *testing.T) {
+		// blockedCard blocks middleCard, which blocks blockingCard
+		middleCard := makeCardBlock(board.ID)
+		dependency1 := &model.CardDependency{BlockingCardID: blockedCard.ID, BlockedCardID: middleCard.ID}
+		dependency2 := &model.CardDependency{BlockingCardID: middleCard.ID, BlockedCardID: blockingCard.ID}
+
+		th.Store.EXPECT().GetBlock(blockingCard.ID).Return(blockingCard, nil)
+		th.Store.EXPECT().GetBlock(blockedCard.ID).Return(blockedCard, nil)
+		th.Store.EXPECT().GetCardDependenciesForCard(blockingCard.ID).Return([]*model.CardDependency{dependency2}, nil)
+		th.Store.EXPECT().GetCardDependenciesForCard(blockedCard.ID).Return([]*model.CardDependency{dependency1}, nil)
+		th.Store.EXPECT().GetCardDependenciesForCard(middleCard.ID).Return([]*model.CardDependency{dependency1, dependency2}, nil)
+
+		dependency, err := th.App.CreateCardDependency(&model.CardDependency{
+			BlockingCardID: blockingCard.ID,
+			BlockedCardID:  blockedCard.ID,
+		}, userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, dependency)
+	})
+
+	t.Run("missing card", func(t *testing.T) {
+		missingCardID := utils.NewID(utils.IDTypeCard)
+		th.Store.EXPECT().GetBlock(blockingCard.ID).Return(blockingCard, nil)
+		th.Store.EXPECT().GetBlock(missingCardID).Return(nil, model.NewErrNotFound(missingCardID))
+
+		dependency, err := th.App.CreateCardDependency(&model.CardDependency{
+			BlockingCardID: blockingCard.ID,
+			BlockedCardID:  missingCardID,
+		}, userID)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, dependency)
+	})
+}
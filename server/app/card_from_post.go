@@ -0,0 +1,228 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	// cardFromPostTitleMaxRunes limits the length of a title taken from a post.
+	cardFromPostTitleMaxRunes = 80
+
+	// TODO: localize these when i18n is available.
+	defCardFromPostTitle     = "Untitled post"
+	defCardFromPostBacklink  = "[View the original post](%s)"
+	defCardFromPostReplyText = "**%s** replied:\n%s"
+)
+
+var errNoServicesAPI = errors.New("posts are not available without the Mattermost services API")
+
+// GetPost returns a post that is not deleted.
+func (a *App) GetPost(postID string) (*mm_model.Post, error) {
+	if a.servicesAPI == nil {
+		return nil, errNoServicesAPI
+	}
+
+	post, err := a.servicesAPI.GetPost(postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.DeleteAt != 0 {
+		return nil, model.NewErrNotFound("post ID=" + postID)
+	}
+	return post, nil
+}
+
+// CreateCardFromPost creates a card on a board from a post. The message of the
+// post becomes a text block of the card, the files of the post are copied to
+// the board and, optionally, the replies of its thread become comments. The
+// card keeps the ID of the post as a backlink.
+func (a *App) CreateCardFromPost(boardID, postID, userID string, opts model.CardFromPostOptions, disableNotify bool) (*model.Card, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := a.GetPost(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	var replies []*mm_model.Post
+	if opts.IncludeThread {
+		if replies, err = a.getPostReplies(post); err != nil {
+			return nil, err
+		}
+	}
+
+	title := opts.Title
+	if title == "" {
+		title = titleFromPostMessage(post.Message)
+	}
+
+	now := utils.GetMillis()
+	card := &model.Card{
+		ID:           utils.NewID(utils.IDTypeCard),
+		BoardID:      board.ID,
+		CreatedBy:    userID,
+		ModifiedBy:   userID,
+		Title:        title,
+		SourcePostID: post.Id,
+		CreateAt:     now,
+		UpdateAt:     now,
+	}
+	card.Populate()
+
+	text := newCardContentBlock(card, model.TypeText, userID, now)
+	text.Title = fmt.Sprintf(defCardFromPostBacklink, postPermalink(post.Id))
+	if post.Message != "" {
+		text.Title = post.Message + "\n\n" + text.Title
+	}
+	blocks := []*model.Block{text}
+	card.ContentOrder = append(card.ContentOrder, text.ID)
+
+	files, err := a.copyPostFiles(board, card, post, userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, reply := range replies {
+		replyFiles, err := a.copyPostFiles(board, card, reply, userID, now)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, replyFiles...)
+
+		if reply.Message == "" {
+			continue
+		}
+		comment := newCardContentBlock(card, model.TypeComment, userID, now)
+		comment.Title = fmt.Sprintf(defCardFromPostReplyText, a.postAuthorName(reply), reply.Message)
+		comment.Fields["sourcePostId"] = reply.Id
+		blocks = append(blocks, comment)
+	}
+
+	// images are shown in the content of the card, other files as its attachments.
+	for _, file := range files {
+		if file.Type == model.TypeImage {
+			card.ContentOrder = append(card.ContentOrder, file.ID)
+		}
+	}
+	blocks = append(blocks, files...)
+
+	blocks = append([]*model.Block{model.Card2Block(card)}, blocks...)
+	newBlocks, err := a.InsertBlocksAndNotify(blocks, userID, disableNotify)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create card from post: %w", err)
+	}
+
+	return model.Block2Card(newBlocks[0])
+}
+
+// getPostReplies returns the other posts of the thread of a post, oldest first.
+func (a *App) getPostReplies(post *mm_model.Post) ([]*mm_model.Post, error) {
+	thread, err := a.servicesAPI.GetPostThread(post.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	replies := make([]*mm_model.Post, 0, len(thread.Posts))
+	for _, reply := range thread.Posts {
+		if reply.Id == post.Id || reply.DeleteAt != 0 || reply.IsSystemMessage() {
+			continue
+		}
+		replies = append(replies, reply)
+	}
+	sort.Slice(replies, func(i, j int) bool {
+		return replies[i].CreateAt < replies[j].CreateAt
+	})
+	return replies, nil
+}
+
+// copyPostFiles copies the files attached to a post into the board, and
+// returns the image or attachment blocks referencing the copies.
+func (a *App) copyPostFiles(board *model.Board, card *model.Card, post *mm_model.Post, userID string, now int64) ([]*model.Block, error) {
+	blocks := make([]*model.Block, 0, len(post.FileIds))
+	for _, fileID := range post.FileIds {
+		info, err := a.servicesAPI.GetFileInfo(fileID)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get file info %s of post %s: %w", fileID, post.Id, err)
+		}
+		data, err := a.servicesAPI.GetFile(fileID)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get file %s of post %s: %w", fileID, post.Id, err)
+		}
+
+		filename, err := a.SaveFile(bytes.NewReader(data), board.TeamID, board.ID, info.Name, false)
+		if err != nil {
+			return nil, err
+		}
+
+		var blockType model.BlockType = model.TypeAttachment
+		if info.IsImage() {
+			blockType = model.TypeImage
+		}
+		block := newCardContentBlock(card, blockType, userID, now)
+		block.Title = info.Name
+		block.Fields[model.BlockFieldFileId] = filename
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// postAuthorName returns the username of the author of a post, without the @
+// so that copying the post does not mention its author.
+func (a *App) postAuthorName(post *mm_model.Post) string {
+	user, err := a.store.GetUserByID(post.UserId)
+	if err != nil || user == nil {
+		return "unknown user"
+	}
+	return user.Username
+}
+
+func newCardContentBlock(card *model.Card, blockType model.BlockType, userID string, now int64) *model.Block {
+	return &model.Block{
+		ID:         utils.NewID(model.BlockType2IDType(blockType)),
+		ParentID:   card.ID,
+		BoardID:    card.BoardID,
+		Type:       blockType,
+		CreatedBy:  userID,
+		ModifiedBy: userID,
+		Schema:     1,
+		Fields:     map[string]interface{}{},
+		CreateAt:   now,
+		UpdateAt:   now,
+	}
+}
+
+// titleFromPostMessage returns the first line of a post message, shortened
+// to a card title.
+func titleFromPostMessage(message string) string {
+	title, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return defCardFromPostTitle
+	}
+
+	runes := []rune(title)
+	if len(runes) > cardFromPostTitleMaxRunes {
+		title = strings.TrimSpace(string(runes[:cardFromPostTitleMaxRunes-1])) + "…"
+	}
+	return title
+}
+
+// postPermalink returns a link to a post that works from any team.
+func postPermalink(postID string) string {
+	return "/_redirect/pl/" + postID
+}
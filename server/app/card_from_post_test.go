@@ -0,0 +1,104 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	servicesMocks "github.com/mattermost/mattermost-plugin-boards/server/model/mocks"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestCreateCardFromPost(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	ctrl := gomock.NewController(t)
+	servicesAPI := servicesMocks.NewMockServicesAPI(ctrl)
+	th.App.servicesAPI = servicesAPI
+
+	board := &model.Board{ID: utils.NewID(utils.IDTypeBoard), TeamID: mm_model.NewId()}
+	userID := utils.NewID(utils.IDTypeUser)
+	author := &model.User{ID: utils.NewID(utils.IDTypeUser), Username: "bob"}
+
+	post := &mm_model.Post{
+		Id:       "post-id",
+		UserId:   userID,
+		Message:  "Login fails\nThe login page shows an error.",
+		FileIds:  []string{"file-id"},
+		CreateAt: 1,
+	}
+	reply := &mm_model.Post{Id: "reply-id", RootId: post.Id, UserId: author.ID, Message: "Same for me", CreateAt: 2}
+	joined := &mm_model.Post{Id: "joined-id", RootId: post.Id, Type: mm_model.PostTypeJoinChannel, CreateAt: 3}
+	thread := &mm_model.PostList{
+		Order: []string{joined.Id, reply.Id, post.Id},
+		Posts: map[string]*mm_model.Post{post.Id: post, reply.Id: reply, joined.Id: joined},
+	}
+
+	t.Run("with thread", func(t *testing.T) {
+		servicesAPI.EXPECT().GetPost(post.Id).Return(post, nil)
+		servicesAPI.EXPECT().GetPostThread(post.Id).Return(thread, nil)
+		servicesAPI.EXPECT().GetFileInfo("file-id").Return(&mm_model.FileInfo{Id: "file-id", Name: "screenshot.png", MimeType: "image/png"}, nil)
+		servicesAPI.EXPECT().GetFile("file-id").Return([]byte("png"), nil)
+		th.FilesBackend.On("WriteFile", mock.Anything, mock.Anything).Return(int64(3), nil).Once()
+		th.Store.EXPECT().SaveFileInfo(gomock.Any()).Return(nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).Times(2)
+		th.Store.EXPECT().GetUserByID(author.ID).Return(author, nil)
+		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+		th.Store.EXPECT().GetBoardWebhooksForBoard(board.ID).Return([]*model.BoardWebhook{}, nil).AnyTimes()
+		th.Store.EXPECT().GetBlock(gomock.Any()).Return(&model.Block{}, nil).AnyTimes()
+
+		var inserted []*model.Block
+		th.Store.EXPECT().InsertBlock(gomock.Any(), userID).DoAndReturn(func(block *model.Block, userID string) error {
+			inserted = append(inserted, block)
+			return nil
+		}).Times(4)
+
+		card, err := th.App.CreateCardFromPost(board.ID, post.Id, userID, model.CardFromPostOptions{IncludeThread: true}, true)
+		require.NoError(t, err)
+		require.Equal(t, "Login fails", card.Title)
+		require.Equal(t, post.Id, card.SourcePostID)
+
+		require.Len(t, inserted, 4)
+		text, comment, image := inserted[1], inserted[2], inserted[3]
+		require.Equal(t, model.BlockType(model.TypeText), text.Type)
+		require.True(t, strings.HasPrefix(text.Title, post.Message))
+		require.Contains(t, text.Title, "/_redirect/pl/post-id")
+		require.Equal(t, model.BlockType(model.TypeComment), comment.Type)
+		require.Equal(t, "**bob** replied:\nSame for me", comment.Title)
+		require.Equal(t, model.BlockType(model.TypeImage), image.Type)
+		require.NotEmpty(t, image.Fields[model.BlockFieldFileId])
+		require.Equal(t, []string{text.ID, image.ID}, card.ContentOrder)
+		for _, block := range inserted[1:] {
+			require.Equal(t, card.ID, block.ParentID)
+			require.Equal(t, board.ID, block.BoardID)
+		}
+	})
+
+	t.Run("deleted post", func(t *testing.T) {
+		deleted := &mm_model.Post{Id: "deleted-id", DeleteAt: 1}
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		servicesAPI.EXPECT().GetPost(deleted.Id).Return(deleted, nil)
+
+		_, err := th.App.CreateCardFromPost(board.ID, deleted.Id, userID, model.CardFromPostOptions{}, true)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func TestTitleFromPostMessage(t *testing.T) {
+	require.Equal(t, "Fix login", titleFromPostMessage("  Fix login  \nmore details"))
+	require.Equal(t, defCardFromPostTitle, titleFromPostMessage(" \n "))
+
+	title := titleFromPostMessage(strings.Repeat("a", 100))
+	require.Equal(t, cardFromPostTitleMaxRunes, len([]rune(title)))
+	require.True(t, strings.HasSuffix(title, "…"))
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"sort"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// GetCardPreview returns the summary of a card shown in the link previews of
+// posts: its title, its key properties, its assignees and its last update.
+func (a *App) GetCardPreview(cardID string) (*model.CardPreview, error) {
+	block, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if block.Type != model.TypeCard || block.DeleteAt != 0 {
+		return nil, model.NewErrNotFound("card ID=" + cardID)
+	}
+
+	card, err := model.Block2Card(block)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.GetBoard(card.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &model.CardPreview{
+		CardID:     card.ID,
+		BoardID:    board.ID,
+		TeamID:     board.TeamID,
+		Title:      card.Title,
+		Icon:       card.Icon,
+		BoardTitle: board.Title,
+		Properties: []model.CardPreviewProperty{},
+		Assignees:  []string{},
+		UpdateAt:   card.UpdateAt,
+		UpdatedBy:  a.cardExportUsername(card.ModifiedBy),
+	}
+
+	for _, propDef := range cardPreviewPropDefs(schema) {
+		value, ok := card.Properties[propDef.ID]
+		if !ok || isEmptyPropertyValue(value) {
+			continue
+		}
+		if len(preview.Properties) == model.MaxCardPreviewProperties {
+			preview.MoreProperties++
+			continue
+		}
+
+		text, err := propDef.GetValue(value, a.store)
+		if err != nil {
+			a.logger.Debug("Cannot get card property value for preview",
+				mlog.String("card_id", card.ID),
+				mlog.String("property_id", propDef.ID),
+				mlog.Err(err),
+			)
+			continue
+		}
+
+		property := model.CardPreviewProperty{
+			ID:    propDef.ID,
+			Name:  propDef.Name,
+			Value: text,
+		}
+		if optionID, ok := value.(string); ok && propDef.Type == model.PropTypeSelect {
+			property.Color = propDef.Options[optionID].Color
+		}
+		preview.Properties = append(preview.Properties, property)
+	}
+
+	for _, userID := range model.CardAssignees(block, schema) {
+		preview.Assignees = append(preview.Assignees, a.cardExportUsername(userID))
+	}
+
+	return preview, nil
+}
+
+// cardPreviewPropDefs returns the properties of a board that can be shown in
+// card previews, in the order of the board. People are shown as assignees and
+// the creation and last update as the card's own fields.
+func cardPreviewPropDefs(schema model.PropSchema) []model.PropDef {
+	propDefs := make([]model.PropDef, 0, len(schema))
+	for _, propDef := range schema {
+		switch {
+		case isReadOnlyPropType(propDef.Type),
+			propDef.Type == model.PropTypePerson,
+			propDef.Type == model.PropTypeMultiPerson,
+			propDef.Type == model.PropTypeCheckbox:
+			continue
+		}
+		propDefs = append(propDefs, propDef)
+	}
+	sort.Slice(propDefs, func(i, j int) bool {
+		return propDefs[i].Index < propDefs[j].Index
+	})
+	return propDefs
+}
+
+func isEmptyPropertyValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	}
+	return false
+}
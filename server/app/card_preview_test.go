@@ -0,0 +1,111 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+func TestGetCardPreview(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: "team-id",
+		Title:  "Roadmap",
+		CardProperties: []map[string]interface{}{
+			{"id": "owner", "name": "Owner", "type": model.PropTypePerson},
+			{
+				"id":   "status",
+				"name": "Status",
+				"type": model.PropTypeSelect,
+				"options": []interface{}{
+					map[string]interface{}{"id": "doing", "value": "Doing", "color": "propColorBlue"},
+				},
+			},
+			{"id": "notes", "name": "Notes", "type": model.PropTypeText},
+			{"id": "points", "name": "Points", "type": model.PropTypeNumber},
+			{"id": "url", "name": "Link", "type": model.PropTypeURL},
+			{"id": "done", "name": "Done", "type": model.PropTypeCheckbox},
+			{"id": "estimate", "name": "Estimate", "type": model.PropTypeText},
+		},
+	}
+	cardID := utils.NewID(utils.IDTypeCard)
+	makeCard := func(properties map[string]interface{}) *model.Block {
+		return &model.Block{
+			ID:         cardID,
+			BoardID:    board.ID,
+			Type:       model.TypeCard,
+			Title:      "Fix login",
+			ModifiedBy: "user-2",
+			UpdateAt:   1000,
+			Fields:     map[string]interface{}{"icon": "🐞", "properties": properties},
+		}
+	}
+	card := makeCard(map[string]interface{}{
+		"owner":  "user-1",
+		"status": "doing",
+		"notes":  "",
+		"points": "3",
+		"url":    "https://example.com",
+		"done":   "true",
+	})
+
+	t.Run("preview of a card", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(card.ID).Return(card, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetUserByID("user-1").Return(&model.User{ID: "user-1", Username: "alice"}, nil)
+		th.Store.EXPECT().GetUserByID("user-2").Return(&model.User{ID: "user-2", Username: "bob"}, nil)
+
+		preview, err := th.App.GetCardPreview(card.ID)
+		require.NoError(t, err)
+		require.Equal(t, "Fix login", preview.Title)
+		require.Equal(t, "🐞", preview.Icon)
+		require.Equal(t, "Roadmap", preview.BoardTitle)
+		require.Equal(t, []string{"alice"}, preview.Assignees)
+		require.Equal(t, "bob", preview.UpdatedBy)
+		require.Equal(t, int64(1000), preview.UpdateAt)
+		require.Equal(t, []model.CardPreviewProperty{
+			{ID: "status", Name: "Status", Value: "DOING", Color: "propColorBlue"},
+			{ID: "points", Name: "Points", Value: "3"},
+			{ID: "url", Name: "Link", Value: "https://example.com"},
+		}, preview.Properties)
+		require.Zero(t, preview.MoreProperties)
+	})
+
+	t.Run("properties over the limit are counted", func(t *testing.T) {
+		withMore := makeCard(map[string]interface{}{
+			"owner":    "user-1",
+			"status":   "doing",
+			"points":   "3",
+			"url":      "https://example.com",
+			"estimate": "2 days",
+		})
+		th.Store.EXPECT().GetBlock(card.ID).Return(withMore, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetUserByID(gomock.Any()).Return(nil, model.NewErrNotFound("user")).Times(2)
+
+		preview, err := th.App.GetCardPreview(card.ID)
+		require.NoError(t, err)
+		require.Len(t, preview.Properties, model.MaxCardPreviewProperties)
+		require.Equal(t, 1, preview.MoreProperties)
+		require.Equal(t, []string{"user-1"}, preview.Assignees)
+	})
+
+	t.Run("deleted card", func(t *testing.T) {
+		deleted := makeCard(nil)
+		deleted.DeleteAt = 1
+		th.Store.EXPECT().GetBlock(card.ID).Return(deleted, nil)
+
+		_, err := th.App.GetCardPreview(card.ID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
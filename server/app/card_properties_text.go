@@ -0,0 +1,61 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+)
+
+// CardPropertiesFromText converts card property values entered as text and
+// keyed by property name into the properties of a card of the board. Values
+// are parsed like the cells of a CSV import: options are matched by label,
+// users by username or email, and several values are separated by commas.
+func (a *App) CardPropertiesFromText(boardID string, values map[string]string) (map[string]any, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	importer := &csvCardImporter{
+		app:        a,
+		newOptions: map[string][]model.PropDefOption{},
+		userIDs:    map[string]string{},
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	properties := map[string]any{}
+	for _, name := range names {
+		propDef, ok := findPropDefByName(schema, name)
+		if !ok {
+			return nil, model.NewErrBadRequest(fmt.Sprintf("unknown property %q", name))
+		}
+		if isReadOnlyPropType(propDef.Type) || propDef.IsComputed() {
+			return nil, model.NewErrBadRequest(fmt.Sprintf("property %q cannot be changed", propDef.Name))
+		}
+
+		value, err := importer.propertyValue(&propDef, values[name])
+		if errors.Is(err, model.ErrInvalidPropertyValue) {
+			return nil, model.NewErrBadRequest(fmt.Sprintf("%s: %s", propDef.Name, err.Error()))
+		}
+		if err != nil {
+			return nil, err
+		}
+		properties[propDef.ID] = value
+	}
+	return properties, nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardPropertiesFromText(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{
+				"id": "status", "name": "Status", "type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "doing", "value": "Doing", "color": "propColorGray"},
+				},
+			},
+			{"id": "assignee", "name": "Assignee", "type": "person"},
+			{"id": "created", "name": "Created", "type": "createdTime"},
+		},
+	}
+	user := &model.User{ID: utils.NewID(utils.IDTypeUser), Username: "alice"}
+
+	t.Run("resolves properties by name", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetUserByUsername("alice").Return(user, nil)
+
+		properties, err := th.App.CardPropertiesFromText(board.ID, map[string]string{
+			"status":   "doing",
+			"Assignee": "@alice",
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"status": "doing", "assignee": user.ID}, properties)
+	})
+
+	t.Run("unknown property", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+		_, err := th.App.CardPropertiesFromText(board.ID, map[string]string{"Priority": "High"})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("read-only property", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+		_, err := th.App.CardPropertiesFromText(board.ID, map[string]string{"Created": "2024-01-01"})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("unknown option", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+		_, err := th.App.CardPropertiesFromText(board.ID, map[string]string{"Status": "Done"})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+}
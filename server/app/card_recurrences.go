@@ -0,0 +1,145 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// SetCardRecurrence creates or replaces the recurrence of a card. When no start
+// time is provided the recurrence starts now.
+func (a *App) SetCardRecurrence(recurrence *model.CardRecurrence, userID string) (*model.CardRecurrence, error) {
+	card, err := a.GetCardByID(recurrence.CardID)
+	if err != nil {
+		return nil, err
+	}
+
+	newRecurrence := &model.CardRecurrence{
+		CardID:    card.ID,
+		BoardID:   card.BoardID,
+		Rule:      recurrence.Rule,
+		StartAt:   recurrence.StartAt,
+		CreatedBy: userID,
+	}
+	if newRecurrence.StartAt == 0 {
+		newRecurrence.StartAt = utils.GetMillis()
+	}
+
+	if err = newRecurrence.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	newRecurrence.NextRunAt, err = newRecurrence.NextOccurrence(max(newRecurrence.StartAt, utils.GetMillis()))
+	if err != nil {
+		return nil, err
+	}
+
+	return a.store.SaveCardRecurrence(newRecurrence)
+}
+
+func (a *App) GetCardRecurrence(cardID string) (*model.CardRecurrence, error) {
+	return a.store.GetCardRecurrence(cardID)
+}
+
+func (a *App) GetCardRecurrencesForBoard(boardID string) ([]*model.CardRecurrence, error) {
+	return a.store.GetCardRecurrencesForBoard(boardID)
+}
+
+func (a *App) DeleteCardRecurrence(cardID string) error {
+	return a.store.DeleteCardRecurrence(cardID)
+}
+
+// ProcessCardRecurrences duplicates the cards whose recurrence is due. It is run
+// periodically by the server.
+func (a *App) ProcessCardRecurrences() {
+	now := utils.GetMillis()
+
+	recurrences, err := a.store.GetDueCardRecurrences(now)
+	if err != nil {
+		a.logger.Error("Cannot fetch due card recurrences", mlog.Err(err))
+		return
+	}
+
+	for _, recurrence := range recurrences {
+		if err := a.runCardRecurrence(recurrence, now); err != nil {
+			a.logger.Error("Cannot run card recurrence",
+				mlog.String("card_id", recurrence.CardID),
+				mlog.String("board_id", recurrence.BoardID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
+
+// runCardRecurrence duplicates the card of a due recurrence and schedules its next
+// run. When several runs were missed, e.g. while the server was down, only the
+// card of the latest occurrence is created.
+func (a *App) runCardRecurrence(recurrence *model.CardRecurrence, now int64) error {
+	card, err := a.store.GetBlock(recurrence.CardID)
+	if model.IsErrNotFound(err) {
+		// the recurring card has been deleted
+		return a.store.DeleteCardRecurrence(recurrence.CardID)
+	}
+	if err != nil {
+		return err
+	}
+
+	occurrence := recurrence.NextRunAt
+	nextRunAt, err := recurrence.NextOccurrence(occurrence)
+	for err == nil && nextRunAt <= now {
+		occurrence = nextRunAt
+		nextRunAt, err = recurrence.NextOccurrence(occurrence)
+	}
+	if err != nil {
+		return err
+	}
+
+	advanced, err := a.store.AdvanceCardRecurrence(recurrence, nextRunAt)
+	if err != nil {
+		return err
+	}
+	if !advanced {
+		// another server already took care of this run
+		return nil
+	}
+
+	blocks, err := a.DuplicateBlock(card.BoardID, card.ID, recurrence.CreatedBy, false)
+	if err != nil {
+		return err
+	}
+	if len(blocks) == 0 {
+		return fmt.Errorf("duplicating card %s returned no blocks", card.ID)
+	}
+	newCard := blocks[0]
+
+	board, err := a.store.GetBoard(newCard.BoardID)
+	if err != nil {
+		return err
+	}
+
+	properties, ok := newCard.Fields["properties"].(map[string]interface{})
+	if !ok || len(properties) == 0 {
+		return nil
+	}
+
+	// the new card represents the occurrence of the run, so its dates are moved
+	// forward by the time elapsed since the occurrence of the original card
+	properties, err = model.ShiftDateProperties(board, properties, occurrence-recurrence.StartAt)
+	if err != nil {
+		return err
+	}
+
+	patch := &model.BlockPatch{
+		UpdatedFields: map[string]interface{}{
+			"properties": properties,
+		},
+	}
+	_, err = a.PatchBlockAndNotify(newCard.ID, patch, recurrence.CreatedBy, true)
+	return err
+}
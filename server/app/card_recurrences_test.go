@@ -0,0 +1,145 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetCardRecurrence(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	userID := utils.NewID(utils.IDTypeUser)
+	card := &model.Block{
+		ID:      utils.NewID(utils.IDTypeCard),
+		BoardID: utils.NewID(utils.IDTypeBoard),
+		Type:    model.TypeCard,
+	}
+
+	t.Run("set recurrence", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(card.ID).Return(card, nil)
+		th.Store.EXPECT().SaveCardRecurrence(gomock.Any()).DoAndReturn(
+			func(recurrence *model.CardRecurrence) (*model.CardRecurrence, error) {
+				return recurrence, nil
+			})
+
+		recurrence, err := th.App.SetCardRecurrence(&model.CardRecurrence{
+			CardID: card.ID,
+			Rule:   "FREQ=DAILY",
+		}, userID)
+		require.NoError(t, err)
+		require.Equal(t, card.BoardID, recurrence.BoardID)
+		require.Equal(t, userID, recurrence.CreatedBy)
+		require.NotZero(t, recurrence.StartAt)
+		require.Greater(t, recurrence.NextRunAt, recurrence.StartAt)
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(card.ID).Return(card, nil)
+
+		recurrence, err := th.App.SetCardRecurrence(&model.CardRecurrence{
+			CardID: card.ID,
+			Rule:   "FREQ=HOURLY",
+		}, userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, recurrence)
+	})
+}
+
+func TestRunCardRecurrence(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	const day = int64(24 * 60 * 60 * 1000)
+
+	board := &model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{"id": "due", "name": "Due", "type": model.PropTypeDate},
+		},
+	}
+	card := &model.Block{
+		ID:      utils.NewID(utils.IDTypeCard),
+		BoardID: board.ID,
+		Type:    model.TypeCard,
+	}
+
+	t.Run("duplicate the card and move its dates", func(t *testing.T) {
+		startAt := utils.GetMillis() - day - 1000
+		recurrence := &model.CardRecurrence{
+			CardID:    card.ID,
+			BoardID:   board.ID,
+			Rule:      "FREQ=DAILY",
+			StartAt:   startAt,
+			NextRunAt: startAt + day,
+			CreatedBy: "user-id",
+		}
+
+		newCard := &model.Block{
+			ID:      utils.NewID(utils.IDTypeCard),
+			BoardID: board.ID,
+			Type:    model.TypeCard,
+			Fields: map[string]interface{}{
+				"properties": map[string]interface{}{"due": `{"from":1000}`},
+			},
+		}
+
+		th.Store.EXPECT().GetBlock(card.ID).Return(card, nil)
+		th.Store.EXPECT().AdvanceCardRecurrence(recurrence, startAt+2*day).Return(true, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+		th.Store.EXPECT().DuplicateBlock(board.ID, card.ID, "user-id", false).Return([]*model.Block{newCard}, nil)
+		th.Store.EXPECT().GetBlock(newCard.ID).Return(newCard, nil).AnyTimes()
+		th.Store.EXPECT().GetBoardRulesForBoard(board.ID).Return([]*model.BoardRule{}, nil)
+		th.Store.EXPECT().PatchBlock(newCard.ID, gomock.Any(), "user-id").DoAndReturn(
+			func(blockID string, patch *model.BlockPatch, userID string) error {
+				properties := patch.UpdatedFields["properties"].(map[string]interface{})
+				require.JSONEq(t, `{"from":86401000}`, properties["due"].(string))
+				return nil
+			})
+		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		err := th.App.runCardRecurrence(recurrence, utils.GetMillis())
+		require.NoError(t, err)
+	})
+
+	t.Run("run already done by another server", func(t *testing.T) {
+		startAt := utils.GetMillis() - 1000
+		recurrence := &model.CardRecurrence{
+			CardID:    card.ID,
+			BoardID:   board.ID,
+			Rule:      "FREQ=DAILY",
+			StartAt:   startAt - day,
+			NextRunAt: startAt,
+			CreatedBy: "user-id",
+		}
+
+		th.Store.EXPECT().GetBlock(card.ID).Return(card, nil)
+		th.Store.EXPECT().AdvanceCardRecurrence(recurrence, startAt+day).Return(false, nil)
+
+		err := th.App.runCardRecurrence(recurrence, utils.GetMillis())
+		require.NoError(t, err)
+	})
+
+	t.Run("deleted card", func(t *testing.T) {
+		recurrence := &model.CardRecurrence{
+			CardID:    card.ID,
+			BoardID:   board.ID,
+			Rule:      "FREQ=DAILY",
+			NextRunAt: 1000,
+		}
+
+		th.Store.EXPECT().GetBlock(card.ID).Return(nil, model.NewErrNotFound(card.ID))
+		th.Store.EXPECT().DeleteCardRecurrence(card.ID).Return(nil)
+
+		err := th.App.runCardRecurrence(recurrence, utils.GetMillis())
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+)
+
+// SetCardReminderSettings creates or replaces the due date reminder settings
+// of a board.
+func (a *App) SetCardReminderSettings(settings *model.CardReminderSettings, userID string) (*model.CardReminderSettings, error) {
+	board, err := a.store.GetBoard(settings.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	newSettings := &model.CardReminderSettings{
+		BoardID:           board.ID,
+		DueDatePropertyID: settings.DueDatePropertyID,
+		HoursBefore:       settings.HoursBefore,
+		ModifiedBy:        userID,
+	}
+	if err = newSettings.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+	if err = newSettings.IsValidForSchema(schema); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	return a.store.SaveCardReminderSettings(newSettings)
+}
+
+func (a *App) GetCardReminderSettings(boardID string) (*model.CardReminderSettings, error) {
+	return a.store.GetCardReminderSettings(boardID)
+}
+
+func (a *App) DeleteCardReminderSettings(boardID string) error {
+	return a.store.DeleteCardReminderSettings(boardID)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetCardReminderSettings(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID: utils.NewID(utils.IDTypeBoard),
+		CardProperties: []map[string]interface{}{
+			{"id": "due", "name": "Due", "type": model.PropTypeDate},
+			{"id": "owner", "name": "Owner", "type": model.PropTypePerson},
+		},
+	}
+
+	t.Run("save the settings", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().SaveCardReminderSettings(gomock.Any()).DoAndReturn(
+			func(settings *model.CardReminderSettings) (*model.CardReminderSettings, error) {
+				return settings, nil
+			})
+
+		settings, err := th.App.SetCardReminderSettings(&model.CardReminderSettings{BoardID: board.ID, DueDatePropertyID: "due", HoursBefore: 24}, "user-id")
+		require.NoError(t, err)
+		require.Equal(t, "user-id", settings.ModifiedBy)
+		require.Equal(t, 24, settings.HoursBefore)
+	})
+
+	t.Run("not a date property", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+		_, err := th.App.SetCardReminderSettings(&model.CardReminderSettings{BoardID: board.ID, DueDatePropertyID: "owner"}, "user-id")
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("invalid hours before", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+		_, err := th.App.SetCardReminderSettings(&model.CardReminderSettings{BoardID: board.ID, DueDatePropertyID: "due", HoursBefore: -1}, "user-id")
+		require.True(t, model.IsErrBadRequest(err))
+	})
+}
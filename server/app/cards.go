@@ -62,6 +62,133 @@ func (a *App) GetCardsForBoard(boardID string, page int, perPage int) ([]*model.
 	return cards, nil
 }
 
+// QueryCards returns the non template cards of a board that match the query filter,
+// sorted by the query sort options and paginated.
+func (a *App) QueryCards(boardID string, query *model.CardQuery) ([]*model.Card, error) {
+	cards, schema, err := a.getFilteredCards(boardID, query.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	model.SortCards(cards, query.SortOptions, schema, a.store)
+
+	return paginateCards(cards, query.Page, query.PerPage), nil
+}
+
+// GetCardsForView returns the cards shown by a view block, filtered, sorted and
+// grouped with the view settings.
+func (a *App) GetCardsForView(boardID string, viewID string) (*model.ViewCards, error) {
+	view, err := a.store.GetBlock(viewID)
+	if err != nil {
+		return nil, err
+	}
+	if view.BoardID != boardID || view.Type != model.TypeView {
+		return nil, model.NewErrNotFound("view ID=" + viewID)
+	}
+
+	settings, err := model.ParseViewSettings(view)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, schema, err := a.getFilteredCards(boardID, settings.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(settings.SortOptions) == 0 {
+		model.SortCardsByOrder(cards, settings.CardOrder)
+	} else {
+		model.SortCards(cards, settings.SortOptions, schema, a.store)
+	}
+
+	viewCards := &model.ViewCards{
+		ViewID:             view.ID,
+		ViewType:           settings.ViewType,
+		VisiblePropertyIDs: settings.VisiblePropertyIDs,
+		Cards:              cards,
+	}
+	if viewCards.VisiblePropertyIDs == nil {
+		viewCards.VisiblePropertyIDs = []string{}
+	}
+
+	if settings.IsGrouped() {
+		viewCards.GroupByPropertyID = settings.GroupByID
+		viewCards.Groups = settings.GroupCards(cards, schema, a.store)
+	}
+
+	return viewCards, nil
+}
+
+// getFilteredCards returns the non template cards of a board that match a filter,
+// along with the board's property schema.
+func (a *App) getFilteredCards(boardID string, filter *model.FilterGroup) ([]*model.Card, model.PropSchema, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blocks, err := a.store.GetBlocksWithType(boardID, model.TypeCard)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cards := make([]*model.Card, 0, len(blocks))
+	for _, block := range blocks {
+		card, err := model.Block2Card(block)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Block2Card fail: %w", err)
+		}
+		if card.IsTemplate {
+			continue
+		}
+		if filter != nil && !filter.Matches(card, schema) {
+			continue
+		}
+		cards = append(cards, card)
+	}
+	return cards, schema, nil
+}
+
+// SearchCards returns the cards of a set of boards that match a search term,
+// ranked by relevance.
+func (a *App) SearchCards(opts model.CardSearchOptions) ([]*model.CardSearchResult, error) {
+	if opts.PerPage <= 0 {
+		opts.PerPage = model.CardSearchDefaultPerPage
+	}
+	if opts.PerPage > model.CardSearchMaxPerPage {
+		opts.PerPage = model.CardSearchMaxPerPage
+	}
+	if opts.Page < 0 {
+		opts.Page = 0
+	}
+	return a.store.SearchCards(opts)
+}
+
+func paginateCards(cards []*model.Card, page int, perPage int) []*model.Card {
+	if perPage < 0 {
+		return cards
+	}
+	if perPage == 0 {
+		perPage = model.CardQueryDefaultPerPage
+	}
+
+	start := page * perPage
+	if start >= len(cards) {
+		return []*model.Card{}
+	}
+	end := start + perPage
+	if end > len(cards) {
+		end = len(cards)
+	}
+	return cards[start:end]
+}
+
 func (a *App) PatchCard(cardPatch *model.CardPatch, cardID string, userID string, disableNotify bool) (*model.Card, error) {
 	blockPatch, err := model.CardPatch2BlockPatch(cardPatch)
 	if err != nil {
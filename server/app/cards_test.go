@@ -40,6 +40,7 @@ func TestCreateCard(t *testing.T) {
 		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
 		th.Store.EXPECT().InsertBlock(gomock.AssignableToTypeOf(reflect.TypeOf(block)), userID).Return(nil)
 		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil)
+		th.Store.EXPECT().GetBoardWebhooksForBoard(board.ID).Return([]*model.BoardWebhook{}, nil)
 
 		newCard, err := th.App.CreateCard(card, board.ID, userID, false)
 
@@ -112,6 +113,222 @@ func TestGetCards(t *testing.T) {
 	})
 }
 
+func TestQueryCards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	statusPropID := utils.NewID(utils.IDTypeBlock)
+	doneOptionID := utils.NewID(utils.IDTypeBlock)
+	todoOptionID := utils.NewID(utils.IDTypeBlock)
+
+	board := &model.Board{
+		ID: utils.NewID(utils.IDTypeBoard),
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   statusPropID,
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": doneOptionID, "value": "Done"},
+					map[string]interface{}{"id": todoOptionID, "value": "To Do"},
+				},
+			},
+		},
+	}
+
+	makeCardBlock := func(title string, status string, isTemplate bool) *model.Block {
+		return &model.Block{
+			ID:       utils.NewID(utils.IDTypeCard),
+			ParentID: board.ID,
+			BoardID:  board.ID,
+			Type:     model.TypeCard,
+			Title:    title,
+			Fields: map[string]interface{}{
+				"isTemplate": isTemplate,
+				"properties": map[string]interface{}{statusPropID: status},
+			},
+		}
+	}
+
+	blocks := []*model.Block{
+		makeCardBlock("c", todoOptionID, false),
+		makeCardBlock("a", doneOptionID, false),
+		makeCardBlock("b", todoOptionID, false),
+		makeCardBlock("template", todoOptionID, true),
+	}
+
+	t.Run("filter, sort and paginate", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).Times(2)
+		th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeCard).Return(blocks, nil).Times(2)
+
+		query := &model.CardQuery{
+			Filter: &model.FilterGroup{
+				Operation: model.FilterOperationAnd,
+				Filters: []model.FilterItem{
+					{Clause: &model.FilterClause{PropertyID: statusPropID, Condition: model.FilterConditionIncludes, Values: []string{todoOptionID}}},
+				},
+			},
+			SortOptions: []model.SortOption{{PropertyID: model.TitleSortPropertyID, Reversed: true}},
+			PerPage:     1,
+		}
+
+		cards, err := th.App.QueryCards(board.ID, query)
+		require.NoError(t, err)
+		require.Len(t, cards, 1)
+		require.Equal(t, "c", cards[0].Title)
+
+		query.Page = 1
+		cards, err = th.App.QueryCards(board.ID, query)
+		require.NoError(t, err)
+		require.Len(t, cards, 1)
+		require.Equal(t, "b", cards[0].Title)
+	})
+
+	t.Run("without filter returns all non template cards", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeCard).Return(blocks, nil)
+
+		cards, err := th.App.QueryCards(board.ID, &model.CardQuery{PerPage: -1})
+		require.NoError(t, err)
+		require.Len(t, cards, 3)
+		require.Equal(t, "a", cards[0].Title)
+	})
+
+	t.Run("error scenario", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(nil, blockError{"error"})
+
+		cards, err := th.App.QueryCards(board.ID, &model.CardQuery{})
+		require.Error(t, err)
+		require.Nil(t, cards)
+	})
+}
+
+func TestGetCardsForView(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	statusPropID := utils.NewID(utils.IDTypeBlock)
+	doneOptionID := utils.NewID(utils.IDTypeBlock)
+	todoOptionID := utils.NewID(utils.IDTypeBlock)
+
+	board := &model.Board{
+		ID: utils.NewID(utils.IDTypeBoard),
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   statusPropID,
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": todoOptionID, "value": "To Do"},
+					map[string]interface{}{"id": doneOptionID, "value": "Done"},
+				},
+			},
+		},
+	}
+
+	makeCardBlock := func(title string, status string) *model.Block {
+		return &model.Block{
+			ID:       utils.NewID(utils.IDTypeCard),
+			ParentID: board.ID,
+			BoardID:  board.ID,
+			Type:     model.TypeCard,
+			Title:    title,
+			Fields: map[string]interface{}{
+				"properties": map[string]interface{}{statusPropID: status},
+			},
+		}
+	}
+
+	cardA := makeCardBlock("a", doneOptionID)
+	cardB := makeCardBlock("b", todoOptionID)
+	cardC := makeCardBlock("c", "")
+	blocks := []*model.Block{cardA, cardB, cardC}
+
+	view := &model.Block{
+		ID:       utils.NewID(utils.IDTypeView),
+		ParentID: board.ID,
+		BoardID:  board.ID,
+		Type:     model.TypeView,
+		Fields: map[string]interface{}{
+			"viewType":  "board",
+			"groupById": statusPropID,
+			"cardOrder": []interface{}{cardB.ID, cardC.ID, cardA.ID},
+			"filter":    map[string]interface{}{"operation": "and", "filters": []interface{}{}},
+		},
+	}
+
+	t.Run("manual order and groups", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(view.ID).Return(view, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeCard).Return(blocks, nil)
+
+		viewCards, err := th.App.GetCardsForView(board.ID, view.ID)
+		require.NoError(t, err)
+		require.Equal(t, view.ID, viewCards.ViewID)
+		require.Equal(t, statusPropID, viewCards.GroupByPropertyID)
+		require.Len(t, viewCards.Cards, 3)
+		require.Equal(t, []string{"b", "c", "a"}, []string{viewCards.Cards[0].Title, viewCards.Cards[1].Title, viewCards.Cards[2].Title})
+
+		require.Len(t, viewCards.Groups, 3)
+		require.Equal(t, "", viewCards.Groups[0].ID)
+		require.Len(t, viewCards.Groups[0].Cards, 1)
+		require.Equal(t, "c", viewCards.Groups[0].Cards[0].Title)
+		require.Equal(t, todoOptionID, viewCards.Groups[1].ID)
+		require.Equal(t, doneOptionID, viewCards.Groups[2].ID)
+	})
+
+	t.Run("view of another board", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(view.ID).Return(view, nil)
+
+		viewCards, err := th.App.GetCardsForView(utils.NewID(utils.IDTypeBoard), view.ID)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, viewCards)
+	})
+
+	t.Run("block is not a view", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(cardA.ID).Return(cardA, nil)
+
+		viewCards, err := th.App.GetCardsForView(board.ID, cardA.ID)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, viewCards)
+	})
+}
+
+func TestSearchCards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	results := []*model.CardSearchResult{
+		{Card: &model.Card{ID: utils.NewID(utils.IDTypeCard), BoardID: boardID}, Rank: 4, Matches: []string{model.CardSearchMatchTitle}},
+	}
+
+	t.Run("applies the default page size", func(t *testing.T) {
+		th.Store.EXPECT().SearchCards(model.CardSearchOptions{
+			BoardIDs: []string{boardID},
+			Term:     "term",
+			PerPage:  model.CardSearchDefaultPerPage,
+		}).Return(results, nil)
+
+		found, err := th.App.SearchCards(model.CardSearchOptions{BoardIDs: []string{boardID}, Term: "term"})
+		require.NoError(t, err)
+		require.Equal(t, results, found)
+	})
+
+	t.Run("limits the page size", func(t *testing.T) {
+		th.Store.EXPECT().SearchCards(model.CardSearchOptions{
+			BoardIDs: []string{boardID},
+			Term:     "term",
+			Page:     2,
+			PerPage:  model.CardSearchMaxPerPage,
+		}).Return(results, nil)
+
+		found, err := th.App.SearchCards(model.CardSearchOptions{BoardIDs: []string{boardID}, Term: "term", Page: 2, PerPage: 1000})
+		require.NoError(t, err)
+		require.Equal(t, results, found)
+	})
+}
+
 func TestPatchCard(t *testing.T) {
 	th, tearDown := SetupTestHelper(t)
 	defer tearDown()
@@ -149,6 +366,7 @@ func TestPatchCard(t *testing.T) {
 
 		var blockPatch *model.BlockPatch
 		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetBoardRulesForBoard(board.ID).Return([]*model.BoardRule{}, nil)
 		th.Store.EXPECT().PatchBlock(card.ID, gomock.AssignableToTypeOf(reflect.TypeOf(blockPatch)), userID).Return(nil)
 		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil)
 		th.Store.EXPECT().GetBlock(card.ID).Return(expectedPatchedBlock, nil).AnyTimes()
@@ -166,6 +384,7 @@ func TestPatchCard(t *testing.T) {
 	t.Run("error scenario", func(t *testing.T) {
 		var blockPatch *model.BlockPatch
 		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetBoardRulesForBoard(board.ID).Return([]*model.BoardRule{}, nil)
 		th.Store.EXPECT().PatchBlock(card.ID, gomock.AssignableToTypeOf(reflect.TypeOf(blockPatch)), userID).Return(blockError{"error"})
 
 		patchedCard, err := th.App.PatchCard(cardPatch, card.ID, userID, false)
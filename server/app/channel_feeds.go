@@ -0,0 +1,53 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+)
+
+// SetChannelFeedSettings creates or replaces the channel feed settings of a
+// board. The board must be linked to a channel, where its activity is posted.
+func (a *App) SetChannelFeedSettings(settings *model.ChannelFeedSettings, userID string) (*model.ChannelFeedSettings, error) {
+	board, err := a.store.GetBoard(settings.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if board.ChannelID == "" {
+		return nil, model.NewErrBadRequest("the board is not linked to a channel")
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	newSettings := &model.ChannelFeedSettings{
+		BoardID:          board.ID,
+		Events:           settings.Events,
+		StatusPropertyID: settings.StatusPropertyID,
+		DoneOptionIDs:    settings.DoneOptionIDs,
+		WindowMinutes:    settings.WindowMinutes,
+		ModifiedBy:       userID,
+	}
+	if newSettings.WindowMinutes == 0 {
+		newSettings.WindowMinutes = model.ChannelFeedDefaultWindowMinutes
+	}
+	if err = newSettings.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+	if err = newSettings.IsValidForSchema(schema); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	return a.store.SaveChannelFeedSettings(newSettings)
+}
+
+func (a *App) GetChannelFeedSettings(boardID string) (*model.ChannelFeedSettings, error) {
+	return a.store.GetChannelFeedSettings(boardID)
+}
+
+func (a *App) DeleteChannelFeedSettings(boardID string) error {
+	return a.store.DeleteChannelFeedSettings(boardID)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetChannelFeedSettings(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:        utils.NewID(utils.IDTypeBoard),
+		ChannelID: "channel-id",
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status",
+				"name": "Status",
+				"type": model.PropTypeSelect,
+				"options": []interface{}{
+					map[string]interface{}{"id": "todo", "value": "To Do"},
+					map[string]interface{}{"id": "done", "value": "Done"},
+				},
+			},
+		},
+	}
+
+	t.Run("save the settings", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().SaveChannelFeedSettings(gomock.Any()).DoAndReturn(
+			func(settings *model.ChannelFeedSettings) (*model.ChannelFeedSettings, error) {
+				return settings, nil
+			})
+
+		settings, err := th.App.SetChannelFeedSettings(&model.ChannelFeedSettings{
+			BoardID:          board.ID,
+			Events:           []string{model.ChannelFeedEventCardCreated, model.ChannelFeedEventCardCompleted},
+			StatusPropertyID: "status",
+		}, "user-id")
+		require.NoError(t, err)
+		require.Equal(t, "user-id", settings.ModifiedBy)
+		require.Equal(t, model.ChannelFeedDefaultWindowMinutes, settings.WindowMinutes)
+		require.Equal(t, []string{"done"}, settings.DoneOptionIDs)
+	})
+
+	t.Run("board not linked to a channel", func(t *testing.T) {
+		unlinked := &model.Board{ID: board.ID}
+		th.Store.EXPECT().GetBoard(board.ID).Return(unlinked, nil)
+
+		_, err := th.App.SetChannelFeedSettings(&model.ChannelFeedSettings{
+			BoardID: board.ID,
+			Events:  []string{model.ChannelFeedEventCardCreated},
+		}, "user-id")
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("unknown status property", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+		_, err := th.App.SetChannelFeedSettings(&model.ChannelFeedSettings{
+			BoardID:          board.ID,
+			Events:           []string{model.ChannelFeedEventStatusChanged},
+			StatusPropertyID: "priority",
+		}, "user-id")
+		require.True(t, model.IsErrBadRequest(err))
+	})
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// maxComputedPropertyPasses bounds how many times the computed properties of
+// a card are updated for one change, so that cards aggregating each other do
+// not update forever.
+const maxComputedPropertyPasses = 3
+
+// updateComputedProperties recomputes the rollup and formula properties of the
+// changed cards of a board and of the cards aggregating them, saves the values
+// that changed and broadcasts the updated cards. The saved values are not
+// recorded as an edit of the cards. If changedCards is nil, all the cards of
+// the board are recomputed, which is only needed when the schema changes. It
+// runs on the block change notifier queue, after the change is saved.
+func (a *App) updateComputedProperties(board *model.Board, changedCards []*model.Block) {
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		a.logger.Error("Cannot parse the property schema to compute properties", mlog.String("board_id", board.ID), mlog.Err(err))
+		return
+	}
+	if !schema.HasComputedProperties() {
+		return
+	}
+
+	cards, err := a.store.GetBlocksWithType(board.ID, model.TypeCard)
+	if err != nil {
+		a.logger.Error("Cannot get the cards to compute properties", mlog.String("board_id", board.ID), mlog.Err(err))
+		return
+	}
+	dependencies, err := a.store.GetCardDependenciesForBoard(board.ID)
+	if err != nil {
+		a.logger.Error("Cannot get the card dependencies to compute properties", mlog.String("board_id", board.ID), mlog.Err(err))
+		return
+	}
+
+	cardsByID := make(map[string]*model.Block, len(cards))
+	for _, card := range cards {
+		cardsByID[card.ID] = card
+	}
+	children := map[string][]*model.Block{}
+	for _, card := range cards {
+		if parentID := model.GetParentCardID(card); parentID != "" {
+			children[parentID] = append(children[parentID], card)
+		}
+	}
+	blocking := map[string][]*model.Block{}
+	blockedBy := map[string][]*model.Block{}
+	for _, dependency := range dependencies {
+		if !dependency.IsWithinBoard(board.ID) {
+			continue
+		}
+		blockingCard, ok := cardsByID[dependency.BlockingCardID]
+		if !ok {
+			continue
+		}
+		blockedCard, ok := cardsByID[dependency.BlockedCardID]
+		if !ok {
+			continue
+		}
+		blocking[blockingCard.ID] = append(blocking[blockingCard.ID], blockedCard)
+		blockedBy[blockedCard.ID] = append(blockedBy[blockedCard.ID], blockingCard)
+	}
+
+	// the cards whose rollups aggregate the given card
+	aggregators := func(card *model.Block) []string {
+		ids := []string{}
+		if parentID := model.GetParentCardID(card); parentID != "" {
+			ids = append(ids, parentID)
+		}
+		for _, blockedCard := range blocking[card.ID] {
+			ids = append(ids, blockedCard.ID)
+		}
+		for _, blockingCard := range blockedBy[card.ID] {
+			ids = append(ids, blockingCard.ID)
+		}
+		return ids
+	}
+
+	if changedCards == nil {
+		changedCards = cards
+	}
+	pending := []string{}
+	queued := map[string]bool{}
+	for _, card := range changedCards {
+		if card == nil || card.Type != model.TypeCard {
+			continue
+		}
+		for _, id := range append([]string{card.ID}, aggregators(card)...) {
+			if !queued[id] {
+				queued[id] = true
+				pending = append(pending, id)
+			}
+		}
+	}
+
+	passes := map[string]int{}
+	for len(pending) > 0 {
+		id := pending[0]
+		pending = pending[1:]
+
+		card, ok := cardsByID[id]
+		if !ok || passes[id] >= maxComputedPropertyPasses {
+			continue
+		}
+		passes[id]++
+
+		related := model.CardRelations{
+			model.RollupRelationChildren:  children[id],
+			model.RollupRelationBlocking:  blocking[id],
+			model.RollupRelationBlockedBy: blockedBy[id],
+		}
+		properties, changed := model.ApplyComputedProperties(schema, card, related)
+		if !changed {
+			continue
+		}
+
+		if err := a.store.UpdateCardComputedProperties(id, properties); err != nil {
+			a.logger.Error("Cannot save the computed properties of a card", mlog.String("card_id", id), mlog.Err(err))
+			continue
+		}
+		// the cards aggregating this one use its new values
+		if card.Fields == nil {
+			card.Fields = map[string]interface{}{}
+		}
+		card.Fields["properties"] = properties
+
+		updatedCard, err := a.store.GetBlock(id)
+		if err != nil {
+			a.logger.Error("Cannot get a card after computing its properties", mlog.String("card_id", id), mlog.Err(err))
+			continue
+		}
+		a.wsAdapter.BroadcastBlockChange(board.TeamID, updatedCard)
+
+		pending = append(pending, aggregators(card)...)
+	}
+}
+
+// updateComputedPropertiesForBlocks recomputes the computed properties
+// affected by changes to the given blocks, which may belong to several boards.
+func (a *App) updateComputedPropertiesForBlocks(blocks []*model.Block) {
+	cardsByBoard := map[string][]*model.Block{}
+	for _, block := range blocks {
+		if block != nil && block.Type == model.TypeCard {
+			cardsByBoard[block.BoardID] = append(cardsByBoard[block.BoardID], block)
+		}
+	}
+
+	for boardID, cards := range cardsByBoard {
+		board, err := a.store.GetBoard(boardID)
+		if err != nil {
+			a.logger.Error("Cannot get the board to compute properties", mlog.String("board_id", boardID), mlog.Err(err))
+			continue
+		}
+		a.updateComputedProperties(board, cards)
+	}
+}
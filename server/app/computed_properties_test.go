@@ -0,0 +1,104 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateComputedProperties(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{"id": "points", "name": "Points", "type": model.PropTypeNumber},
+			{
+				"id":     "total",
+				"name":   "Total",
+				"type":   model.PropTypeRollup,
+				"rollup": map[string]interface{}{"relation": model.RollupRelationChildren, "propertyId": "points", "function": model.RollupFunctionSum},
+			},
+			{
+				"id":     "blockers",
+				"name":   "Blockers",
+				"type":   model.PropTypeRollup,
+				"rollup": map[string]interface{}{"relation": model.RollupRelationBlockedBy, "function": model.RollupFunctionCount},
+			},
+		},
+	}
+
+	makeCard := func(id string, fields map[string]interface{}) *model.Block {
+		return &model.Block{ID: id, BoardID: board.ID, Type: model.TypeCard, Fields: fields}
+	}
+
+	t.Run("board without computed properties", func(t *testing.T) {
+		// no store call is expected
+		th.App.updateComputedProperties(&model.Board{ID: board.ID}, []*model.Block{makeCard("card", nil)})
+	})
+
+	t.Run("update the aggregating cards", func(t *testing.T) {
+		epic := makeCard("epic", map[string]interface{}{
+			"properties": map[string]interface{}{"total": "0", "blockers": "1"},
+		})
+		story := makeCard("story", map[string]interface{}{
+			"parentCardId": "epic",
+			"properties":   map[string]interface{}{"points": "3", "total": "0", "blockers": "0"},
+		})
+		otherStory := makeCard("other-story", map[string]interface{}{
+			"parentCardId": "epic",
+			"properties":   map[string]interface{}{"points": "5", "total": "0", "blockers": "0"},
+		})
+		blocker := makeCard("blocker", map[string]interface{}{
+			"properties": map[string]interface{}{"total": "0", "blockers": "0"},
+		})
+		dependency := &model.CardDependency{
+			BlockingCardID:  blocker.ID,
+			BlockingBoardID: board.ID,
+			BlockedCardID:   epic.ID,
+			BlockedBoardID:  board.ID,
+		}
+
+		th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeCard).Return([]*model.Block{epic, story, otherStory, blocker}, nil)
+		th.Store.EXPECT().GetCardDependenciesForBoard(board.ID).Return([]*model.CardDependency{dependency}, nil)
+
+		patched := map[string]interface{}{}
+		th.Store.EXPECT().UpdateCardComputedProperties(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(blockID string, properties map[string]interface{}) error {
+				patched[blockID] = properties
+				return nil
+			}).Times(1)
+		th.Store.EXPECT().GetBlock(epic.ID).Return(epic, nil)
+		// this call comes from the WS server notification
+		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		th.App.updateComputedProperties(board, []*model.Block{story})
+
+		require.Equal(t, map[string]interface{}{
+			"epic": map[string]interface{}{"total": "8", "blockers": "1"},
+		}, patched)
+	})
+
+	t.Run("recompute all cards", func(t *testing.T) {
+		card := makeCard("card", nil)
+
+		th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeCard).Return([]*model.Block{card}, nil)
+		th.Store.EXPECT().GetCardDependenciesForBoard(board.ID).Return([]*model.CardDependency{}, nil)
+		th.Store.EXPECT().UpdateCardComputedProperties(card.ID, gomock.Any()).DoAndReturn(
+			func(blockID string, properties map[string]interface{}) error {
+				require.Equal(t, map[string]interface{}{"total": "0", "blockers": "0"}, properties)
+				return nil
+			})
+		th.Store.EXPECT().GetBlock(card.ID).Return(card, nil)
+
+		th.App.updateComputedProperties(board, nil)
+	})
+}
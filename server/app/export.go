@@ -103,6 +103,44 @@ func (a *App) writeArchiveBoard(zw *zip.Writer, board model.Board, opt model.Exp
 		}
 	}
 
+	// only the dependencies between cards of the board can be restored on import
+	dependencies, err := a.GetCardDependenciesForBoard(board.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, dependency := range dependencies {
+		if !dependency.IsWithinBoard(board.ID) {
+			continue
+		}
+		if err = a.writeArchiveCardDependencyLine(w, dependency); err != nil {
+			return err
+		}
+	}
+
+	timeEntries, err := a.GetTimeEntriesForBoard(board.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range timeEntries {
+		if err = a.writeArchiveTimeEntryLine(w, entry); err != nil {
+			return err
+		}
+	}
+
+	// the custom roles come before the members they are assigned to
+	customRoles, err := a.GetBoardCustomRolesForBoard(board.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, role := range customRoles {
+		if err = a.writeArchiveBoardCustomRoleLine(w, role); err != nil {
+			return err
+		}
+	}
+
 	boardMembers, err := a.GetMembersForBoard(board.ID)
 	if err != nil {
 		return err
@@ -148,6 +186,81 @@ func (a *App) writeArchiveBoardMemberLine(w io.Writer, boardMember *model.BoardM
 	return err
 }
 
+// writeArchiveBoardCustomRoleLine writes a single board custom role to the archive.
+func (a *App) writeArchiveBoardCustomRoleLine(w io.Writer, role *model.BoardCustomRole) error {
+	r, err := json.Marshal(&role)
+	if err != nil {
+		return err
+	}
+	line := model.ArchiveLine{
+		Type: "boardCustomRole",
+		Data: r,
+	}
+
+	r, err = json.Marshal(&line)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(r)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(newline)
+	return err
+}
+
+// writeArchiveCardDependencyLine writes a single card dependency to the archive.
+func (a *App) writeArchiveCardDependencyLine(w io.Writer, dependency *model.CardDependency) error {
+	d, err := json.Marshal(&dependency)
+	if err != nil {
+		return err
+	}
+	line := model.ArchiveLine{
+		Type: "cardDependency",
+		Data: d,
+	}
+
+	d, err = json.Marshal(&line)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(newline)
+	return err
+}
+
+// writeArchiveTimeEntryLine writes a single time entry to the archive.
+func (a *App) writeArchiveTimeEntryLine(w io.Writer, entry *model.TimeEntry) error {
+	te, err := json.Marshal(&entry)
+	if err != nil {
+		return err
+	}
+	line := model.ArchiveLine{
+		Type: "timeEntry",
+		Data: te,
+	}
+
+	te, err = json.Marshal(&line)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(te)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(newline)
+	return err
+}
+
 // writeArchiveBlockLine writes a single block to the archive.
 func (a *App) writeArchiveBlockLine(w io.Writer, block *model.Block) error {
 	b, err := json.Marshal(&block)
@@ -0,0 +1,159 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/xlsx"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	cardExportTitleColumn = "Title"
+	cardExportTimeFormat  = "January 02, 2006 15:04"
+)
+
+// ExportBoardCards writes the cards of a board, or of one of its views, as a
+// CSV or XLSX spreadsheet.
+func (a *App) ExportBoardCards(w io.Writer, opt model.CardExportOptions) error {
+	if err := opt.IsValid(); err != nil {
+		return model.NewErrBadRequest(err.Error())
+	}
+
+	table, err := a.GetCardExportTable(opt.BoardID, opt.ViewID)
+	if err != nil {
+		return err
+	}
+
+	if opt.Format == model.CardExportFormatXLSX {
+		return xlsx.Write(w, xlsx.Sheet{
+			Name:           table.Title,
+			Rows:           table.Rows,
+			NumericColumns: table.NumericColumns,
+		})
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(table.Rows); err != nil {
+		return fmt.Errorf("cannot write cards CSV: %w", err)
+	}
+	return nil
+}
+
+// GetCardExportTable returns the rows of a cards export: the card titles and
+// the values of the card properties, resolved into the option labels, dates
+// and usernames shown to the users.
+func (a *App) GetCardExportTable(boardID, viewID string) (*model.CardExportTable, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	var cards []*model.Card
+	propDefs := make([]model.PropDef, 0, len(schema))
+	if viewID == "" {
+		cards, _, err = a.getFilteredCards(boardID, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, propDef := range schema {
+			propDefs = append(propDefs, propDef)
+		}
+	} else {
+		viewCards, err := a.GetCardsForView(boardID, viewID)
+		if err != nil {
+			return nil, err
+		}
+		cards = viewCards.Cards
+		for _, propertyID := range viewCards.VisiblePropertyIDs {
+			if propDef, ok := schema[propertyID]; ok {
+				propDefs = append(propDefs, propDef)
+			}
+		}
+	}
+
+	// properties are exported in the order of the board, whatever the order of
+	// the visible properties of the view
+	sort.Slice(propDefs, func(i, j int) bool {
+		return propDefs[i].Index < propDefs[j].Index
+	})
+
+	header := []string{cardExportTitleColumn}
+	numericColumns := map[int]bool{}
+	for i, propDef := range propDefs {
+		header = append(header, propDef.Name)
+		if propDef.Type == model.PropTypeNumber {
+			numericColumns[i+1] = true
+		}
+	}
+
+	rows := [][]string{header}
+	for _, card := range cards {
+		row := []string{card.Title}
+		for _, propDef := range propDefs {
+			row = append(row, a.cardExportValue(card, propDef))
+		}
+		rows = append(rows, row)
+	}
+
+	return &model.CardExportTable{
+		Title:          board.Title,
+		Rows:           rows,
+		NumericColumns: numericColumns,
+	}, nil
+}
+
+// cardExportValue returns the value of a card property as shown to the
+// users. Values that cannot be resolved are exported as they are stored.
+func (a *App) cardExportValue(card *model.Card, propDef model.PropDef) string {
+	switch propDef.Type {
+	case model.PropTypeCreatedTime:
+		return utils.GetTimeForMillis(card.CreateAt).Format(cardExportTimeFormat)
+	case model.PropTypeUpdatedTime:
+		return utils.GetTimeForMillis(card.UpdateAt).Format(cardExportTimeFormat)
+	case model.PropTypeCreatedBy:
+		return a.cardExportUsername(card.CreatedBy)
+	case model.PropTypeUpdatedBy:
+		return a.cardExportUsername(card.ModifiedBy)
+	}
+
+	value, ok := card.Properties[propDef.ID]
+	if !ok || value == nil {
+		return ""
+	}
+
+	resolved, err := propDef.GetValue(value, a.store)
+	if err != nil {
+		a.logger.Debug("Cannot resolve card property value for export",
+			mlog.String("card_id", card.ID),
+			mlog.String("property_id", propDef.ID),
+			mlog.Err(err),
+		)
+		return fmt.Sprintf("%v", value)
+	}
+	return resolved
+}
+
+func (a *App) cardExportUsername(userID string) string {
+	if userID == "" {
+		return ""
+	}
+	user, err := a.store.GetUserByID(userID)
+	if err != nil || user == nil {
+		return userID
+	}
+	return user.Username
+}
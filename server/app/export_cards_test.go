@@ -0,0 +1,136 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCardExportTable(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	user := &model.User{ID: utils.NewID(utils.IDTypeUser), Username: "john"}
+	board := &model.Board{
+		ID:    utils.NewID(utils.IDTypeBoard),
+		Title: "Roadmap",
+		CardProperties: []map[string]interface{}{
+			{
+				"id": "status", "name": "Status", "type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "todo", "value": "To Do"},
+					map[string]interface{}{"id": "done", "value": "Done"},
+				},
+			},
+			{"id": "owner", "name": "Owner", "type": "person"},
+			{"id": "estimate", "name": "Estimate", "type": "number"},
+			{"id": "creator", "name": "Creator", "type": "createdBy"},
+		},
+	}
+
+	makeCardBlock := func(title string, properties map[string]interface{}) *model.Block {
+		return &model.Block{
+			ID:        utils.NewID(utils.IDTypeCard),
+			ParentID:  board.ID,
+			BoardID:   board.ID,
+			Type:      model.TypeCard,
+			Title:     title,
+			CreatedBy: user.ID,
+			Fields:    map[string]interface{}{"properties": properties},
+		}
+	}
+
+	cardA := makeCardBlock("a", map[string]interface{}{"status": "done", "owner": user.ID, "estimate": "3"})
+	cardB := makeCardBlock("b", map[string]interface{}{"status": "todo"})
+	blocks := []*model.Block{cardA, cardB}
+
+	t.Run("whole board", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).Times(2)
+		th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeCard).Return(blocks, nil)
+		th.Store.EXPECT().GetUserByID(user.ID).Return(user, nil).Times(3)
+
+		table, err := th.App.GetCardExportTable(board.ID, "")
+		require.NoError(t, err)
+		require.Equal(t, "Roadmap", table.Title)
+		require.Equal(t, [][]string{
+			{"Title", "Status", "Owner", "Estimate", "Creator"},
+			{"a", "DONE", "john", "3", "john"},
+			{"b", "TO DO", "", "", "john"},
+		}, table.Rows)
+		require.Equal(t, map[int]bool{3: true}, table.NumericColumns)
+	})
+
+	t.Run("view", func(t *testing.T) {
+		view := &model.Block{
+			ID:       utils.NewID(utils.IDTypeView),
+			ParentID: board.ID,
+			BoardID:  board.ID,
+			Type:     model.TypeView,
+			Fields: map[string]interface{}{
+				"viewType":           "table",
+				"visiblePropertyIds": []interface{}{"estimate", "status"},
+				"filter": map[string]interface{}{
+					"operation": "and",
+					"filters": []interface{}{
+						map[string]interface{}{"propertyId": "status", "condition": "includes", "values": []interface{}{"todo"}},
+					},
+				},
+			},
+		}
+
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).Times(2)
+		th.Store.EXPECT().GetBlock(view.ID).Return(view, nil)
+		th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeCard).Return(blocks, nil)
+
+		table, err := th.App.GetCardExportTable(board.ID, view.ID)
+		require.NoError(t, err)
+		require.Equal(t, [][]string{
+			{"Title", "Status", "Estimate"},
+			{"b", "TO DO", ""},
+		}, table.Rows)
+		require.Equal(t, map[int]bool{2: true}, table.NumericColumns)
+	})
+}
+
+func TestExportBoardCards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID: utils.NewID(utils.IDTypeBoard),
+		CardProperties: []map[string]interface{}{
+			{"id": "notes", "name": "Notes", "type": "text"},
+		},
+	}
+	card := &model.Block{
+		ID:      utils.NewID(utils.IDTypeCard),
+		BoardID: board.ID,
+		Type:    model.TypeCard,
+		Title:   "a",
+		Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"notes": "one, two"},
+		},
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).Times(2)
+		th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeCard).Return([]*model.Block{card}, nil)
+
+		var buf bytes.Buffer
+		err := th.App.ExportBoardCards(&buf, model.CardExportOptions{BoardID: board.ID, Format: model.CardExportFormatCSV})
+		require.NoError(t, err)
+		require.Equal(t, "Title,Notes\na,\"one, two\"\n", buf.String())
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := th.App.ExportBoardCards(&buf, model.CardExportOptions{BoardID: board.ID, Format: "pdf"})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+}
@@ -123,22 +123,8 @@ func (a *App) validateFileReferencedByBoard(boardID, filename string) error {
 		return err
 	}
 
-	// Check image blocks
-	for _, block := range imageBlocks {
-		if fileID, ok := block.Fields[model.BlockFieldFileId].(string); ok && fileID == filename {
-			return nil
-		}
-		if attachmentID, ok := block.Fields[model.BlockFieldAttachmentId].(string); ok && attachmentID == filename {
-			return nil
-		}
-	}
-
-	// Check attachment blocks
-	for _, block := range attachmentBlocks {
-		if fileID, ok := block.Fields[model.BlockFieldFileId].(string); ok && fileID == filename {
-			return nil
-		}
-		if attachmentID, ok := block.Fields[model.BlockFieldAttachmentId].(string); ok && attachmentID == filename {
+	for _, block := range append(imageBlocks, attachmentBlocks...) {
+		if blockReferencesFile(block, filename) {
 			return nil
 		}
 	}
@@ -146,6 +132,18 @@ func (a *App) validateFileReferencedByBoard(boardID, filename string) error {
 	return fmt.Errorf("%w: file %s is not referenced by any block in board %s", ErrFileNotReferencedByBoard, filename, boardID)
 }
 
+// blockReferencesFile returns true if an image or attachment block holds a
+// file.
+func blockReferencesFile(block *model.Block, filename string) bool {
+	if fileID, ok := block.Fields[model.BlockFieldFileId].(string); ok && fileID == filename {
+		return true
+	}
+	if attachmentID, ok := block.Fields[model.BlockFieldAttachmentId].(string); ok && attachmentID == filename {
+		return true
+	}
+	return false
+}
+
 func (a *App) GetFile(teamID, boardID, fileName string) (*mm_model.FileInfo, filestore.ReadCloseSeeker, error) {
 	if err := a.ValidateFileOwnership(teamID, boardID, fileName); err != nil {
 		a.logger.Error("GetFile: File ownership validation failed",
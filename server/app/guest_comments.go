@@ -0,0 +1,177 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	// guestCommentAuthorField and guestCommentIDField are the fields of an
+	// approved comment block holding the display name of its author and the
+	// id of the guest comment.
+	guestCommentAuthorField = "guestAuthorName"
+	guestCommentIDField     = "guestCommentId"
+)
+
+// CreateGuestComment submits a comment on a card through a commenter share
+// link. The comment is pending until a board admin approves it, and a
+// limited number of comments can be submitted from the same address.
+func (a *App) CreateGuestComment(access *model.ReadTokenAccess, comment *model.GuestComment) (*model.GuestComment, error) {
+	if access == nil || !access.CanComment {
+		return nil, model.NewErrPermission("the share link does not allow comments")
+	}
+
+	now := utils.GetMillis()
+
+	newComment := *comment
+	newComment.ID = utils.NewID(utils.IDTypeNone)
+	newComment.BoardID = access.BoardID
+	newComment.ShareLinkID = access.ShareLinkID
+	newComment.AuthorName = strings.TrimSpace(newComment.AuthorName)
+	newComment.Text = strings.TrimSpace(newComment.Text)
+	newComment.Status = model.GuestCommentPending
+	newComment.RemoteAddr = truncateString(newComment.RemoteAddr, shareLinkAccessFieldMaxBytes)
+	newComment.CommentID = ""
+	newComment.ModeratedBy = ""
+
+	if err := newComment.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	if err := a.checkGuestCommentCard(access, newComment.CardID); err != nil {
+		return nil, err
+	}
+
+	return a.store.CreateGuestComment(&newComment, model.GuestCommentRateLimit, now-model.GuestCommentRateLimitWindow)
+}
+
+func (a *App) GetGuestComment(id string) (*model.GuestComment, error) {
+	return a.store.GetGuestComment(id)
+}
+
+// GetGuestCommentsForBoard returns the guest comments of a board with the
+// given status, or with any status if empty.
+func (a *App) GetGuestCommentsForBoard(boardID, status string) ([]*model.GuestComment, error) {
+	switch status {
+	case "", model.GuestCommentPending, model.GuestCommentApproved, model.GuestCommentRejected:
+	default:
+		return nil, model.NewErrBadRequest("invalid guest comment status: " + status)
+	}
+	return a.store.GetGuestCommentsForBoard(boardID, status)
+}
+
+// ApproveGuestComment adds a pending guest comment to its card, as a comment
+// block holding the display name of its author. The comment is marked as
+// approved first, so that it is added only once, and set back to pending if
+// its block cannot be inserted.
+func (a *App) ApproveGuestComment(id, userID string) (*model.GuestComment, error) {
+	comment, err := a.store.GetGuestComment(id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := utils.GetMillis()
+	block := &model.Block{
+		ID:         utils.NewID(utils.IDTypeBlock),
+		ParentID:   comment.CardID,
+		BoardID:    comment.BoardID,
+		Type:       model.TypeComment,
+		Title:      comment.Text,
+		CreatedBy:  userID,
+		ModifiedBy: userID,
+		CreateAt:   now,
+		UpdateAt:   now,
+		Fields: map[string]interface{}{
+			guestCommentAuthorField: comment.AuthorName,
+			guestCommentIDField:     comment.ID,
+		},
+	}
+
+	comment.Status = model.GuestCommentApproved
+	comment.CommentID = block.ID
+	comment.ModeratedBy = userID
+	if err = a.moderateGuestComment(comment); err != nil {
+		return nil, err
+	}
+
+	if err = a.InsertBlock(block, userID); err != nil {
+		a.logger.Error("Cannot insert approved guest comment",
+			mlog.String("guest_comment_id", comment.ID),
+			mlog.String("card_id", comment.CardID),
+			mlog.Err(err),
+		)
+		// the comment is set back to pending so that it can be approved again
+		if resetErr := a.store.ResetGuestCommentApproval(comment); resetErr != nil {
+			a.logger.Error("Cannot reset guest comment approval",
+				mlog.String("guest_comment_id", comment.ID),
+				mlog.Err(resetErr),
+			)
+		}
+		return nil, err
+	}
+
+	return a.store.GetGuestComment(id)
+}
+
+// RejectGuestComment rejects a pending guest comment, which is never shown
+// on its card.
+func (a *App) RejectGuestComment(id, userID string) (*model.GuestComment, error) {
+	comment, err := a.store.GetGuestComment(id)
+	if err != nil {
+		return nil, err
+	}
+
+	comment.Status = model.GuestCommentRejected
+	comment.ModeratedBy = userID
+	if err = a.moderateGuestComment(comment); err != nil {
+		return nil, err
+	}
+
+	return a.store.GetGuestComment(id)
+}
+
+// moderateGuestComment records the new status of a pending guest comment,
+// and returns an error if the comment was already moderated.
+func (a *App) moderateGuestComment(comment *model.GuestComment) error {
+	updated, err := a.store.UpdateGuestCommentStatus(comment)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return model.NewErrBadRequest("the guest comment was already moderated")
+	}
+	return nil
+}
+
+// checkGuestCommentCard returns an error if the card cannot be commented
+// with a read token access.
+func (a *App) checkGuestCommentCard(access *model.ReadTokenAccess, cardID string) error {
+	card, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return err
+	}
+
+	if card.BoardID != access.BoardID || card.Type != model.TypeCard {
+		return model.NewErrNotFound("card ID=" + cardID)
+	}
+
+	if access.ViewID == "" {
+		return nil
+	}
+
+	blocks, err := a.FilterBlocksForView(access.BoardID, access.ViewID, []*model.Block{card})
+	if err != nil {
+		return err
+	}
+	if len(blocks) == 0 {
+		return model.NewErrNotFound("card ID=" + cardID)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateGuestComment(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	card := &model.Block{ID: utils.NewID(utils.IDTypeCard), BoardID: boardID, ParentID: boardID, Type: model.TypeCard}
+	access := &model.ReadTokenAccess{BoardID: boardID, ShareLinkID: utils.NewID(utils.IDTypeNone), CanComment: true}
+
+	newComment := func() *model.GuestComment {
+		return &model.GuestComment{
+			CardID:     card.ID,
+			AuthorName: "  Jane  ",
+			Text:       "Looks good",
+			RemoteAddr: "10.0.0.1",
+		}
+	}
+
+	t.Run("create comment", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(card.ID).Return(card, nil)
+		th.Store.EXPECT().CreateGuestComment(gomock.Any(), model.GuestCommentRateLimit, gomock.Any()).DoAndReturn(
+			func(comment *model.GuestComment, _ int, _ int64) (*model.GuestComment, error) {
+				return comment, nil
+			})
+
+		comment, err := th.App.CreateGuestComment(access, newComment())
+		require.NoError(t, err)
+		require.NotEmpty(t, comment.ID)
+		require.Equal(t, boardID, comment.BoardID)
+		require.Equal(t, access.ShareLinkID, comment.ShareLinkID)
+		require.Equal(t, "Jane", comment.AuthorName)
+		require.Equal(t, model.GuestCommentPending, comment.Status)
+	})
+
+	t.Run("viewer share link", func(t *testing.T) {
+		viewerAccess := &model.ReadTokenAccess{BoardID: boardID, ShareLinkID: access.ShareLinkID}
+
+		comment, err := th.App.CreateGuestComment(viewerAccess, newComment())
+		require.True(t, model.IsErrForbidden(err))
+		require.Nil(t, comment)
+	})
+
+	t.Run("card of another board", func(t *testing.T) {
+		otherCard := &model.Block{ID: utils.NewID(utils.IDTypeCard), BoardID: "other-board", Type: model.TypeCard}
+		th.Store.EXPECT().GetBlock(otherCard.ID).Return(otherCard, nil)
+
+		comment := newComment()
+		comment.CardID = otherCard.ID
+		comment, err := th.App.CreateGuestComment(access, comment)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, comment)
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(card.ID).Return(card, nil)
+		th.Store.EXPECT().CreateGuestComment(gomock.Any(), model.GuestCommentRateLimit, gomock.Any()).Return(nil, model.ErrTooManyRequests)
+
+		comment, err := th.App.CreateGuestComment(access, newComment())
+		require.True(t, model.IsErrTooManyRequests(err))
+		require.Nil(t, comment)
+	})
+
+	t.Run("invalid comment", func(t *testing.T) {
+		comment := newComment()
+		comment.Text = " "
+		comment, err := th.App.CreateGuestComment(access, comment)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, comment)
+	})
+}
+
+func TestModerateGuestComment(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	userID := utils.NewID(utils.IDTypeUser)
+	board := &model.Board{ID: utils.NewID(utils.IDTypeBoard), TeamID: "team-id"}
+	comment := &model.GuestComment{
+		ID:          utils.NewID(utils.IDTypeNone),
+		BoardID:     board.ID,
+		CardID:      utils.NewID(utils.IDTypeCard),
+		ShareLinkID: utils.NewID(utils.IDTypeNone),
+		AuthorName:  "Jane",
+		Text:        "Looks good",
+		Status:      model.GuestCommentPending,
+	}
+
+	card := &model.Block{ID: comment.CardID, BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard}
+
+	th.Store.EXPECT().GetBlock(card.ID).Return(card, nil).AnyTimes()
+	th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+	th.Store.EXPECT().GetBoardWebhooksForBoard(board.ID).Return([]*model.BoardWebhook{}, nil).AnyTimes()
+
+	t.Run("approve comment", func(t *testing.T) {
+		var moderated *model.GuestComment
+		pending := *comment
+		th.Store.EXPECT().GetGuestComment(comment.ID).Return(&pending, nil)
+		th.Store.EXPECT().UpdateGuestCommentStatus(gomock.Any()).DoAndReturn(
+			func(c *model.GuestComment) (bool, error) {
+				moderated = c
+				return true, nil
+			})
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), userID).DoAndReturn(
+			func(block *model.Block, _ string) error {
+				require.EqualValues(t, model.TypeComment, block.Type)
+				require.Equal(t, comment.CardID, block.ParentID)
+				require.Equal(t, comment.Text, block.Title)
+				require.Equal(t, comment.AuthorName, block.Fields["guestAuthorName"])
+				require.Equal(t, moderated.CommentID, block.ID)
+				return nil
+			})
+		th.Store.EXPECT().GetGuestComment(comment.ID).DoAndReturn(
+			func(string) (*model.GuestComment, error) {
+				return moderated, nil
+			})
+
+		approved, err := th.App.ApproveGuestComment(comment.ID, userID)
+		require.NoError(t, err)
+		require.Equal(t, model.GuestCommentApproved, approved.Status)
+		require.Equal(t, userID, approved.ModeratedBy)
+		require.NotEmpty(t, approved.CommentID)
+	})
+
+	t.Run("approval reset when the comment block cannot be inserted", func(t *testing.T) {
+		pending := *comment
+		th.Store.EXPECT().GetGuestComment(comment.ID).Return(&pending, nil)
+		th.Store.EXPECT().UpdateGuestCommentStatus(gomock.Any()).Return(true, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), userID).Return(errors.New("insert failed"))
+		th.Store.EXPECT().ResetGuestCommentApproval(gomock.Any()).DoAndReturn(
+			func(c *model.GuestComment) error {
+				require.Equal(t, comment.ID, c.ID)
+				require.NotEmpty(t, c.CommentID)
+				return nil
+			})
+
+		approved, err := th.App.ApproveGuestComment(comment.ID, userID)
+		require.Error(t, err)
+		require.Nil(t, approved)
+	})
+
+	t.Run("reject comment", func(t *testing.T) {
+		pending := *comment
+		th.Store.EXPECT().GetGuestComment(comment.ID).Return(&pending, nil).Times(2)
+		th.Store.EXPECT().UpdateGuestCommentStatus(gomock.Any()).Return(true, nil)
+
+		rejected, err := th.App.RejectGuestComment(comment.ID, userID)
+		require.NoError(t, err)
+		require.Equal(t, model.GuestCommentRejected, rejected.Status)
+		require.Empty(t, rejected.CommentID)
+	})
+
+	t.Run("comment already moderated", func(t *testing.T) {
+		pending := *comment
+		th.Store.EXPECT().GetGuestComment(comment.ID).Return(&pending, nil)
+		th.Store.EXPECT().UpdateGuestCommentStatus(gomock.Any()).Return(false, nil)
+
+		approved, err := th.App.ApproveGuestComment(comment.ID, userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, approved)
+	})
+}
@@ -166,6 +166,9 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (*mo
 	now := utils.GetMillis()
 	var boardID string
 	var boardMembers []*model.BoardMember
+	var cardDependencies []*model.CardDependency
+	var timeEntries []*model.TimeEntry
+	var customRoles []*model.BoardCustomRole
 
 	lineNum := 1
 	firstLine := true
@@ -241,6 +244,24 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (*mo
 						return nil, fmt.Errorf("invalid board Member in archive line %d: %w", lineNum, err2)
 					}
 					boardMembers = append(boardMembers, boardMember)
+				case "cardDependency":
+					var cardDependency *model.CardDependency
+					if err2 := json.Unmarshal(archiveLine.Data, &cardDependency); err2 != nil {
+						return nil, fmt.Errorf("invalid card dependency in archive line %d: %w", lineNum, err2)
+					}
+					cardDependencies = append(cardDependencies, cardDependency)
+				case "timeEntry":
+					var timeEntry *model.TimeEntry
+					if err2 := json.Unmarshal(archiveLine.Data, &timeEntry); err2 != nil {
+						return nil, fmt.Errorf("invalid time entry in archive line %d: %w", lineNum, err2)
+					}
+					timeEntries = append(timeEntries, timeEntry)
+				case "boardCustomRole":
+					var customRole *model.BoardCustomRole
+					if err2 := json.Unmarshal(archiveLine.Data, &customRole); err2 != nil {
+						return nil, fmt.Errorf("invalid board custom role in archive line %d: %w", lineNum, err2)
+					}
+					customRoles = append(customRoles, customRole)
 				default:
 					return nil, model.NewErrUnsupportedArchiveLineType(lineNum, archiveLine.Type)
 				}
@@ -262,18 +283,45 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (*mo
 
 	a.fixBoardsandBlocks(boardsAndBlocks, opt)
 
+	// the blocks keep their identity while their ids are regenerated, so
+	// the old ids are kept to restore the card dependencies
+	oldIDs := make(map[*model.Block]string, len(boardsAndBlocks.Blocks))
+	for _, block := range boardsAndBlocks.Blocks {
+		oldIDs[block] = block.ID
+	}
+
 	var err error
 	boardsAndBlocks, err = model.GenerateBoardsAndBlocksIDs(boardsAndBlocks, a.logger)
 	if err != nil {
 		return nil, fmt.Errorf("error generating archive block IDs: %w", err)
 	}
 
+	cardIDs := make(map[string]string)
+	for _, block := range boardsAndBlocks.Blocks {
+		if block.Type == model.TypeCard {
+			cardIDs[oldIDs[block]] = block.ID
+		}
+	}
+
 	boardsAndBlocks, err = a.CreateBoardsAndBlocks(boardsAndBlocks, opt.ModifiedBy, false)
 	if err != nil {
 		return nil, fmt.Errorf("error inserting archive blocks: %w", err)
 	}
 
-	if err := a.addUserToNewBoard(boardsAndBlocks, opt, boardMembers); err != nil {
+	if err := a.importCardDependencies(boardsAndBlocks, cardDependencies, cardIDs); err != nil {
+		return nil, err
+	}
+
+	if err := a.importTimeEntries(boardsAndBlocks, timeEntries, cardIDs); err != nil {
+		return nil, err
+	}
+
+	roleIDs, err := a.importBoardCustomRoles(boardsAndBlocks, customRoles, opt.ModifiedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.addUserToNewBoard(boardsAndBlocks, opt, boardMembers, roleIDs); err != nil {
 		return nil, err
 	}
 
@@ -284,7 +332,53 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (*mo
 	return nil, fmt.Errorf("missing board in archive: %w", model.ErrInvalidBoardBlock)
 }
 
-func (a *App) addUserToNewBoard(boardsAndBlocks *model.BoardsAndBlocks, opt model.ImportArchiveOptions, boardMembers []*model.BoardMember) error {
+// importCardDependencies creates the dependencies of an imported board between the
+// cards with their new ids.
+func (a *App) importCardDependencies(boardsAndBlocks *model.BoardsAndBlocks, dependencies []*model.CardDependency, cardIDs map[string]string) error {
+	if len(dependencies) == 0 || len(boardsAndBlocks.Boards) == 0 {
+		return nil
+	}
+
+	for _, dependency := range model.RemapCardDependencies(dependencies, cardIDs, boardsAndBlocks.Boards[0].ID) {
+		if _, err := a.store.CreateCardDependency(dependency); err != nil {
+			return fmt.Errorf("cannot import card dependency: %w", err)
+		}
+	}
+	return nil
+}
+
+// importTimeEntries creates the completed time entries of an imported board
+// on the cards with their new ids.
+func (a *App) importTimeEntries(boardsAndBlocks *model.BoardsAndBlocks, entries []*model.TimeEntry, cardIDs map[string]string) error {
+	if len(entries) == 0 || len(boardsAndBlocks.Boards) == 0 {
+		return nil
+	}
+
+	for _, entry := range model.RemapTimeEntries(entries, cardIDs, boardsAndBlocks.Boards[0].ID) {
+		if _, err := a.store.CreateTimeEntry(entry); err != nil {
+			return fmt.Errorf("cannot import time entry: %w", err)
+		}
+	}
+	return nil
+}
+
+// importBoardCustomRoles creates the custom roles of an imported board, and
+// returns their new ids by their archived ids.
+func (a *App) importBoardCustomRoles(boardsAndBlocks *model.BoardsAndBlocks, roles []*model.BoardCustomRole, userID string) (map[string]string, error) {
+	if len(roles) == 0 || len(boardsAndBlocks.Boards) == 0 {
+		return map[string]string{}, nil
+	}
+
+	newRoles, roleIDs := model.RemapBoardCustomRoles(roles, boardsAndBlocks.Boards[0].ID, userID)
+	for _, role := range newRoles {
+		if _, err := a.store.CreateBoardCustomRole(role); err != nil {
+			return nil, fmt.Errorf("cannot import board custom role: %w", err)
+		}
+	}
+	return roleIDs, nil
+}
+
+func (a *App) addUserToNewBoard(boardsAndBlocks *model.BoardsAndBlocks, opt model.ImportArchiveOptions, boardMembers []*model.BoardMember, roleIDs map[string]string) error {
 	// add users to all the new boards (if not the fake system user).
 	for _, board := range boardsAndBlocks.Boards {
 		// make sure an admin user gets added
@@ -307,6 +401,7 @@ func (a *App) addUserToNewBoard(boardsAndBlocks *model.BoardsAndBlocks, opt mode
 				SchemeCommenter: boardMember.SchemeCommenter,
 				SchemeViewer:    boardMember.SchemeViewer,
 				Synthetic:       boardMember.Synthetic,
+				CustomRoleID:    roleIDs[boardMember.CustomRoleID],
 			}
 			if _, err2 := a.AddMemberToBoard(bm); err2 != nil {
 				return fmt.Errorf("cannot add member to board: %w", err2)
@@ -0,0 +1,436 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	maxCSVImportRows = 5000
+
+	csvTitleColumn       = "title"
+	csvValueSeparator    = ","
+	csvNewOptionColor    = "propColorDefault"
+	csvUTF8ByteOrderMark = "\ufeff"
+)
+
+// csvDateLayouts are the date formats accepted in date columns, tried in order.
+var csvDateLayouts = []string{
+	"2006-01-02",
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+	"1/2/2006",
+	"1/2/2006 15:04",
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+}
+
+// csvColumn is a column of the file mapped onto a card property.
+type csvColumn struct {
+	index   int
+	name    string
+	propDef model.PropDef
+}
+
+// csvCardImporter converts the values of CSV rows into card properties,
+// keeping track of the options it adds to the board.
+type csvCardImporter struct {
+	app             *App
+	allowNewOptions bool
+	result          *model.CSVImportResult
+
+	// newOptions are the options added to each property, keyed by property id
+	newOptions map[string][]model.PropDefOption
+	userIDs    map[string]string
+}
+
+// ImportBoardCSV creates a card for each row of a CSV file in an existing
+// board. The first row names the columns: the "Title" column, or the first
+// column if there is none, holds the card titles, and the other columns are
+// matched with the card properties by name. Nothing is changed if any row has
+// an error, nor in a dry run.
+func (a *App) ImportBoardCSV(r io.Reader, opt model.ImportCSVOptions) (*model.CSVImportResult, error) {
+	board, err := a.store.GetBoard(opt.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, model.NewErrBadRequest("the CSV file is empty")
+	}
+	if err != nil {
+		return nil, model.NewErrBadRequest("invalid CSV file: " + err.Error())
+	}
+	header[0] = strings.TrimPrefix(header[0], csvUTF8ByteOrderMark)
+
+	result := model.NewCSVImportResult(opt.DryRun)
+	titleIndex, columns := mapCSVColumns(header, schema, result)
+
+	importer := &csvCardImporter{
+		app:             a,
+		allowNewOptions: opt.AllowNewOptions,
+		result:          result,
+		newOptions:      map[string][]model.PropDefOption{},
+		userIDs:         map[string]string{},
+	}
+
+	cards := []*model.Card{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, model.NewErrBadRequest("invalid CSV file: " + err.Error())
+		}
+		if isBlankCSVRecord(record) {
+			continue
+		}
+
+		result.RowCount++
+		if result.RowCount > maxCSVImportRows {
+			return nil, model.NewErrBadRequest(fmt.Sprintf("the CSV file cannot have more than %d rows", maxCSVImportRows))
+		}
+
+		line, _ := reader.FieldPos(0)
+		card, err := importer.rowToCard(line, record, titleIndex, columns)
+		if err != nil {
+			return nil, err
+		}
+		if card != nil {
+			cards = append(cards, card)
+		}
+	}
+
+	for propID, options := range importer.newOptions {
+		labels := make([]string, 0, len(options))
+		for _, option := range options {
+			labels = append(labels, option.Value)
+		}
+		result.NewOptions[schema[propID].Name] = labels
+	}
+
+	if opt.DryRun || len(result.Errors) != 0 || len(cards) == 0 {
+		return result, nil
+	}
+
+	if len(importer.newOptions) != 0 {
+		patch := &model.BoardPatch{
+			UpdatedCardProperties: importer.updatedCardProperties(board),
+		}
+		if _, err = a.PatchBoard(patch, board.ID, opt.ModifiedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	now := utils.GetMillis()
+	blocks := make([]*model.Block, 0, len(cards))
+	for _, card := range cards {
+		card.CreatedBy = opt.ModifiedBy
+		card.ModifiedBy = opt.ModifiedBy
+		card.CreateAt = now
+		card.UpdateAt = now
+		card.PopulateWithBoardID(board.ID)
+		blocks = append(blocks, model.Card2Block(card))
+	}
+
+	// a card notification per imported row would flood the board subscribers
+	if _, err = a.InsertBlocksAndNotify(blocks, opt.ModifiedBy, true); err != nil {
+		return nil, fmt.Errorf("cannot import cards: %w", err)
+	}
+
+	for _, card := range cards {
+		result.CardIDs = append(result.CardIDs, card.ID)
+	}
+	result.Imported = true
+
+	a.logger.Debug("Cards imported from CSV",
+		mlog.String("board_id", board.ID),
+		mlog.Int("card_count", len(cards)),
+	)
+	return result, nil
+}
+
+// mapCSVColumns returns the index of the title column and the columns that
+// match a writable card property. The other columns are reported as ignored.
+func mapCSVColumns(header []string, schema model.PropSchema, result *model.CSVImportResult) (int, []csvColumn) {
+	titleIndex := 0
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), csvTitleColumn) {
+			titleIndex = i
+			break
+		}
+	}
+	result.TitleColumn = strings.TrimSpace(header[titleIndex])
+
+	mappedProps := map[string]bool{}
+	columns := []csvColumn{}
+	for i, name := range header {
+		if i == titleIndex {
+			continue
+		}
+
+		name = strings.TrimSpace(name)
+		propDef, ok := findPropDefByName(schema, name)
+		if !ok || mappedProps[propDef.ID] || isReadOnlyPropType(propDef.Type) {
+			result.IgnoredColumns = append(result.IgnoredColumns, name)
+			continue
+		}
+
+		mappedProps[propDef.ID] = true
+		columns = append(columns, csvColumn{index: i, name: name, propDef: propDef})
+		result.MappedColumns[name] = propDef.Name
+	}
+	return titleIndex, columns
+}
+
+func findPropDefByName(schema model.PropSchema, name string) (model.PropDef, bool) {
+	for _, propDef := range schema {
+		if strings.EqualFold(propDef.Name, name) {
+			return propDef, true
+		}
+	}
+	return model.PropDef{}, false
+}
+
+func isReadOnlyPropType(propType string) bool {
+	switch propType {
+	case model.PropTypeCreatedTime, model.PropTypeCreatedBy, model.PropTypeUpdatedTime, model.PropTypeUpdatedBy:
+		return true
+	}
+	return false
+}
+
+func isBlankCSVRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// rowToCard converts a row into a card. Invalid values are added to the
+// errors of the result, in which case no card is returned.
+func (ci *csvCardImporter) rowToCard(line int, record []string, titleIndex int, columns []csvColumn) (*model.Card, error) {
+	card := &model.Card{
+		Properties: map[string]any{},
+	}
+	if titleIndex < len(record) {
+		card.Title = strings.TrimSpace(record[titleIndex])
+	}
+
+	valid := true
+	for _, column := range columns {
+		if column.index >= len(record) {
+			continue
+		}
+		value := strings.TrimSpace(record[column.index])
+		if value == "" {
+			continue
+		}
+
+		propValue, err := ci.propertyValue(&column.propDef, value)
+		if errors.Is(err, model.ErrInvalidPropertyValue) {
+			ci.result.AddError(line, column.name, err.Error())
+			valid = false
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		card.Properties[column.propDef.ID] = propValue
+	}
+
+	if !valid {
+		return nil, nil
+	}
+	return card, nil
+}
+
+// propertyValue converts the value of a cell into the value stored in the
+// card for the property.
+func (ci *csvCardImporter) propertyValue(propDef *model.PropDef, value string) (any, error) {
+	switch propDef.Type {
+	case model.PropTypeSelect:
+		return ci.optionID(propDef, value)
+
+	case model.PropTypeMultiSelect:
+		ids := []string{}
+		for _, label := range splitCSVValues(value) {
+			id, err := ci.optionID(propDef, label)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+		}
+		return ids, nil
+
+	case model.PropTypePerson:
+		return ci.userID(value)
+
+	case model.PropTypeMultiPerson:
+		userIDs := []string{}
+		for _, user := range splitCSVValues(value) {
+			userID, err := ci.userID(user)
+			if err != nil {
+				return nil, err
+			}
+			userIDs = append(userIDs, userID)
+		}
+		return userIDs, nil
+
+	case model.PropTypeCheckbox:
+		switch strings.ToLower(value) {
+		case "true", "yes", "y", "x", "1", "checked":
+			return "true", nil
+		case "false", "no", "n", "0", "unchecked":
+			return "false", nil
+		}
+		return nil, fmt.Errorf("%w: %q is not a checkbox value", model.ErrInvalidPropertyValue, value)
+
+	case model.PropTypeNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("%w: %q is not a number", model.ErrInvalidPropertyValue, value)
+		}
+		return value, nil
+
+	case model.PropTypeDate:
+		millis, err := parseCSVDate(value)
+		if err != nil {
+			return nil, err
+		}
+		return fmt.Sprintf(`{"from":%d}`, millis), nil
+	}
+	return value, nil
+}
+
+// optionID returns the id of the option with the given label, adding the
+// option to the property if it does not exist and new options are allowed.
+func (ci *csvCardImporter) optionID(propDef *model.PropDef, label string) (string, error) {
+	for _, option := range propDef.Options {
+		if strings.EqualFold(option.Value, label) {
+			return option.ID, nil
+		}
+	}
+
+	if !ci.allowNewOptions {
+		return "", fmt.Errorf("%w: unknown option %q", model.ErrInvalidPropertyValue, label)
+	}
+
+	option := model.PropDefOption{
+		ID:    utils.NewID(utils.IDTypeBlock),
+		Index: len(propDef.Options),
+		Color: csvNewOptionColor,
+		Value: label,
+	}
+	propDef.Options[option.ID] = option
+	ci.newOptions[propDef.ID] = append(ci.newOptions[propDef.ID], option)
+	return option.ID, nil
+}
+
+// userID resolves a username, with or without a leading @, or an email into
+// a user id.
+func (ci *csvCardImporter) userID(value string) (string, error) {
+	name := strings.TrimPrefix(value, "@")
+	if userID, ok := ci.userIDs[name]; ok {
+		return userID, nil
+	}
+
+	var user *model.User
+	var err error
+	if strings.Contains(name, "@") {
+		user, err = ci.app.store.GetUserByEmail(name)
+	} else {
+		user, err = ci.app.store.GetUserByUsername(name)
+	}
+	if model.IsErrNotFound(err) || (err == nil && user == nil) {
+		return "", fmt.Errorf("%w: unknown user %q", model.ErrInvalidPropertyValue, value)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	ci.userIDs[name] = user.ID
+	return user.ID, nil
+}
+
+// updatedCardProperties returns the card properties of the board that have
+// new options, with the options appended.
+func (ci *csvCardImporter) updatedCardProperties(board *model.Board) []map[string]interface{} {
+	updated := []map[string]interface{}{}
+	for _, prop := range board.CardProperties {
+		propID, _ := prop["id"].(string)
+		newOptions, ok := ci.newOptions[propID]
+		if !ok {
+			continue
+		}
+
+		options := []interface{}{}
+		if existing, ok := prop["options"].([]interface{}); ok {
+			options = append(options, existing...)
+		}
+		for _, option := range newOptions {
+			options = append(options, map[string]interface{}{
+				"id":    option.ID,
+				"color": option.Color,
+				"value": option.Value,
+			})
+		}
+
+		updatedProp := make(map[string]interface{}, len(prop))
+		for key, value := range prop {
+			updatedProp[key] = value
+		}
+		updatedProp["options"] = options
+		updated = append(updated, updatedProp)
+	}
+	return updated
+}
+
+func splitCSVValues(value string) []string {
+	values := []string{}
+	for _, v := range strings.Split(value, csvValueSeparator) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// parseCSVDate parses a date in one of the accepted layouts and returns it in
+// milliseconds since the epoch. Dates without a time zone are taken as UTC.
+func parseCSVDate(value string) (int64, error) {
+	for _, layout := range csvDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return utils.GetMillisForTime(t), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q is not a date", model.ErrInvalidPropertyValue, value)
+}
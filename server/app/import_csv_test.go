@@ -0,0 +1,143 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportBoardCSV(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{
+				"id": "status", "name": "Status", "type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "todo", "value": "To Do", "color": "propColorGray"},
+				},
+			},
+			{"id": "tags", "name": "Tags", "type": "multiSelect", "options": []interface{}{}},
+			{"id": "owner", "name": "Owner", "type": "person"},
+			{"id": "due", "name": "Due", "type": "date"},
+			{"id": "estimate", "name": "Estimate", "type": "number"},
+			{"id": "created", "name": "Created", "type": "createdTime"},
+		},
+	}
+	user := &model.User{ID: utils.NewID(utils.IDTypeUser), Username: "john"}
+
+	csvFile := "Estimate,Title,Status,Owner,Due,Tags,Created,Notes\n" +
+		"3,Write docs,to do,@john,2024-01-15,\"docs, writing\",2023-01-01,first\n" +
+		"\n" +
+		"5,Ship it,Done,john@example.com,1/31/2024,,,\n"
+
+	t.Run("dry run", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetUserByUsername("john").Return(user, nil)
+		th.Store.EXPECT().GetUserByEmail("john@example.com").Return(user, nil)
+
+		result, err := th.App.ImportBoardCSV(strings.NewReader(csvFile), model.ImportCSVOptions{
+			BoardID:         board.ID,
+			ModifiedBy:      user.ID,
+			DryRun:          true,
+			AllowNewOptions: true,
+		})
+		require.NoError(t, err)
+		require.False(t, result.Imported)
+		require.Equal(t, 2, result.RowCount)
+		require.Empty(t, result.Errors)
+		require.Empty(t, result.CardIDs)
+		require.Equal(t, "Title", result.TitleColumn)
+		require.Equal(t, []string{"Created", "Notes"}, result.IgnoredColumns)
+		require.Len(t, result.MappedColumns, 5)
+		require.Equal(t, []string{"Done"}, result.NewOptions["Status"])
+		require.Equal(t, []string{"docs", "writing"}, result.NewOptions["Tags"])
+	})
+
+	t.Run("import", func(t *testing.T) {
+		var updatedProperties []map[string]interface{}
+		inserted := []*model.Block{}
+
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).Times(3)
+		th.Store.EXPECT().GetUserByUsername("john").Return(user, nil)
+		th.Store.EXPECT().GetUserByEmail("john@example.com").Return(user, nil)
+		th.Store.EXPECT().PatchBoard(board.ID, gomock.Any(), user.ID).DoAndReturn(
+			func(boardID string, patch *model.BoardPatch, userID string) (*model.Board, error) {
+				updatedProperties = patch.UpdatedCardProperties
+				return board, nil
+			})
+		th.Store.EXPECT().InsertBlock(gomock.Any(), user.ID).DoAndReturn(
+			func(block *model.Block, userID string) error {
+				inserted = append(inserted, block)
+				return nil
+			}).Times(2)
+		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+		th.Store.EXPECT().GetBoardWebhooksForBoard(board.ID).Return([]*model.BoardWebhook{}, nil).AnyTimes()
+
+		result, err := th.App.ImportBoardCSV(strings.NewReader(csvFile), model.ImportCSVOptions{
+			BoardID:         board.ID,
+			ModifiedBy:      user.ID,
+			AllowNewOptions: true,
+		})
+		require.NoError(t, err)
+		require.True(t, result.Imported)
+		require.Len(t, result.CardIDs, 2)
+
+		require.Len(t, updatedProperties, 2)
+		require.Equal(t, "status", updatedProperties[0]["id"])
+		require.Len(t, updatedProperties[0]["options"], 2)
+		require.Equal(t, "tags", updatedProperties[1]["id"])
+		require.Len(t, updatedProperties[1]["options"], 2)
+
+		require.Len(t, inserted, 2)
+		require.Equal(t, "Write docs", inserted[0].Title)
+		properties := inserted[0].Fields["properties"].(map[string]interface{})
+		require.Equal(t, "todo", properties["status"])
+		require.Equal(t, user.ID, properties["owner"])
+		require.Equal(t, `{"from":1705276800000}`, properties["due"])
+		require.Equal(t, "3", properties["estimate"])
+		require.Len(t, properties["tags"], 2)
+		require.NotContains(t, properties, "created")
+	})
+
+	t.Run("row errors", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetUserByUsername("jane").Return(nil, model.NewErrNotFound("user jane"))
+
+		csvFile := "Title,Status,Owner,Estimate,Due\n" +
+			"Write docs,Done,jane,many,someday\n"
+
+		result, err := th.App.ImportBoardCSV(strings.NewReader(csvFile), model.ImportCSVOptions{
+			BoardID:    board.ID,
+			ModifiedBy: user.ID,
+		})
+		require.NoError(t, err)
+		require.False(t, result.Imported)
+		require.Len(t, result.Errors, 4)
+		for _, rowErr := range result.Errors {
+			require.Equal(t, 2, rowErr.Row)
+		}
+		require.Empty(t, result.NewOptions)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+		result, err := th.App.ImportBoardCSV(strings.NewReader(""), model.ImportCSVOptions{
+			BoardID:    board.ID,
+			ModifiedBy: user.ID,
+		})
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, result)
+	})
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/subtle"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+func (a *App) CreateIncomingWebhook(webhook *model.IncomingWebhook, userID string) (*model.IncomingWebhook, error) {
+	newWebhook := *webhook
+	newWebhook.ID = utils.NewID(utils.IDTypeBlock)
+	newWebhook.Token = utils.NewID(utils.IDTypeToken)
+	newWebhook.CreatedBy = userID
+
+	if err := newWebhook.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	return a.store.CreateIncomingWebhook(&newWebhook)
+}
+
+// UpdateIncomingWebhook replaces the title and state of an incoming webhook.
+// Its token can only be changed with RegenerateIncomingWebhookToken.
+func (a *App) UpdateIncomingWebhook(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	existingWebhook, err := a.store.GetIncomingWebhook(webhook.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedWebhook := *existingWebhook
+	updatedWebhook.Title = webhook.Title
+	updatedWebhook.Enabled = webhook.Enabled
+
+	if err = updatedWebhook.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	return a.store.UpdateIncomingWebhook(&updatedWebhook)
+}
+
+// RegenerateIncomingWebhookToken replaces the token of an incoming webhook,
+// so that requests made with the previous token are rejected.
+func (a *App) RegenerateIncomingWebhookToken(id string) (*model.IncomingWebhook, error) {
+	webhook, err := a.store.GetIncomingWebhook(id)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.Token = utils.NewID(utils.IDTypeToken)
+	return a.store.UpdateIncomingWebhook(webhook)
+}
+
+func (a *App) GetIncomingWebhook(id string) (*model.IncomingWebhook, error) {
+	return a.store.GetIncomingWebhook(id)
+}
+
+func (a *App) GetIncomingWebhooksForBoard(boardID string) ([]*model.IncomingWebhook, error) {
+	return a.store.GetIncomingWebhooksForBoard(boardID)
+}
+
+func (a *App) DeleteIncomingWebhook(id string) error {
+	return a.store.DeleteIncomingWebhook(id)
+}
+
+// GetIncomingWebhookForToken returns an enabled incoming webhook if the token
+// is its token. An unauthorized error is returned otherwise, without telling
+// whether the webhook exists.
+func (a *App) GetIncomingWebhookForToken(id, token string) (*model.IncomingWebhook, error) {
+	webhook, err := a.store.GetIncomingWebhook(id)
+	if model.IsErrNotFound(err) {
+		return nil, model.NewErrUnauthorized("invalid incoming webhook token")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if token == "" || subtle.ConstantTimeCompare([]byte(webhook.Token), []byte(token)) != 1 {
+		return nil, model.NewErrUnauthorized("invalid incoming webhook token")
+	}
+
+	if !webhook.Enabled {
+		return nil, model.NewErrUnauthorized("incoming webhook is disabled")
+	}
+	return webhook, nil
+}
+
+// ExecuteIncomingWebhook creates a card in the board of the webhook or, if
+// the request has a card id, patches the card, on behalf of the user who
+// created the webhook.
+func (a *App) ExecuteIncomingWebhook(webhook *model.IncomingWebhook, request *model.IncomingWebhookRequest) (*model.Card, error) {
+	board, err := a.store.GetBoard(webhook.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	properties, err := request.CardProperties(board)
+	if err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	if request.CardID == "" {
+		card := &model.Card{
+			Properties: model.MergeCardProperties(nil, properties),
+		}
+		if request.Title != nil {
+			card.Title = *request.Title
+		}
+		if request.Icon != nil {
+			card.Icon = *request.Icon
+		}
+
+		card.PopulateWithBoardID(board.ID)
+		if err = card.CheckValid(); err != nil {
+			return nil, model.NewErrBadRequest(err.Error())
+		}
+
+		return a.CreateCard(card, board.ID, webhook.CreatedBy, false)
+	}
+
+	card, err := a.GetCardByID(request.CardID)
+	if err != nil {
+		return nil, err
+	}
+	if card.BoardID != board.ID {
+		return nil, model.NewErrNotFound("card ID=" + request.CardID)
+	}
+
+	cardPatch := &model.CardPatch{
+		Title: request.Title,
+		Icon:  request.Icon,
+	}
+	if len(properties) != 0 {
+		// the properties of a card are replaced as a whole when patched
+		cardPatch.UpdatedProperties = model.MergeCardProperties(card.Properties, properties)
+	}
+	if err = cardPatch.CheckValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	return a.PatchCard(cardPatch, card.ID, webhook.CreatedBy, false)
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetIncomingWebhookForToken(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	webhook := &model.IncomingWebhook{
+		ID:      utils.NewID(utils.IDTypeBlock),
+		BoardID: utils.NewID(utils.IDTypeBoard),
+		Token:   utils.NewID(utils.IDTypeToken),
+		Enabled: true,
+	}
+
+	t.Run("valid token", func(t *testing.T) {
+		th.Store.EXPECT().GetIncomingWebhook(webhook.ID).Return(webhook, nil)
+
+		result, err := th.App.GetIncomingWebhookForToken(webhook.ID, webhook.Token)
+		require.NoError(t, err)
+		require.Equal(t, webhook, result)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		th.Store.EXPECT().GetIncomingWebhook(webhook.ID).Return(webhook, nil)
+
+		result, err := th.App.GetIncomingWebhookForToken(webhook.ID, "invalid")
+		require.True(t, model.IsErrUnauthorized(err))
+		require.Nil(t, result)
+	})
+
+	t.Run("disabled webhook", func(t *testing.T) {
+		disabled := *webhook
+		disabled.Enabled = false
+		th.Store.EXPECT().GetIncomingWebhook(webhook.ID).Return(&disabled, nil)
+
+		result, err := th.App.GetIncomingWebhookForToken(webhook.ID, webhook.Token)
+		require.True(t, model.IsErrUnauthorized(err))
+		require.Nil(t, result)
+	})
+
+	t.Run("nonexistent webhook", func(t *testing.T) {
+		th.Store.EXPECT().GetIncomingWebhook("missing").Return(nil, model.NewErrNotFound("incoming webhook ID=missing"))
+
+		result, err := th.App.GetIncomingWebhookForToken("missing", webhook.Token)
+		require.True(t, model.IsErrUnauthorized(err))
+		require.Nil(t, result)
+	})
+}
+
+func TestExecuteIncomingWebhook(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID: utils.NewID(utils.IDTypeBoard),
+		CardProperties: []map[string]interface{}{
+			{
+				"id": "status", "name": "Status", "type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "todo", "value": "To Do"},
+					map[string]interface{}{"id": "done", "value": "Done"},
+				},
+			},
+			{"id": "link", "name": "Link", "type": "url"},
+		},
+	}
+	webhook := &model.IncomingWebhook{
+		ID:        utils.NewID(utils.IDTypeBlock),
+		BoardID:   board.ID,
+		CreatedBy: utils.NewID(utils.IDTypeUser),
+		Enabled:   true,
+	}
+	title := "Build failed"
+
+	t.Run("create card", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).Times(2)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), webhook.CreatedBy).DoAndReturn(
+			func(block *model.Block, userID string) error {
+				require.Equal(t, title, block.Title)
+				require.Equal(t, map[string]interface{}{"status": "todo"}, block.Fields["properties"])
+				return nil
+			})
+		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+		th.Store.EXPECT().GetBoardWebhooksForBoard(board.ID).Return([]*model.BoardWebhook{}, nil).AnyTimes()
+
+		card, err := th.App.ExecuteIncomingWebhook(webhook, &model.IncomingWebhookRequest{
+			Title:      &title,
+			Properties: map[string]interface{}{"Status": "To Do", "Link": nil},
+		})
+		require.NoError(t, err)
+		require.Equal(t, board.ID, card.BoardID)
+		require.Equal(t, webhook.CreatedBy, card.CreatedBy)
+	})
+
+	t.Run("patch card", func(t *testing.T) {
+		cardBlock := &model.Block{
+			ID:      utils.NewID(utils.IDTypeCard),
+			BoardID: board.ID,
+			Type:    model.TypeCard,
+			Fields: map[string]interface{}{
+				"properties": map[string]interface{}{"status": "todo", "link": "https://ci.example.com/1"},
+			},
+		}
+
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).Times(2)
+		th.Store.EXPECT().GetBlock(cardBlock.ID).Return(cardBlock, nil).AnyTimes()
+		th.Store.EXPECT().GetBoardRulesForBoard(board.ID).Return([]*model.BoardRule{}, nil)
+		th.Store.EXPECT().PatchBlock(cardBlock.ID, gomock.Any(), webhook.CreatedBy).DoAndReturn(
+			func(blockID string, patch *model.BlockPatch, userID string) error {
+				require.Equal(t, map[string]interface{}{"status": "done", "link": "https://ci.example.com/1"}, patch.UpdatedFields["properties"])
+				return nil
+			})
+		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+		th.Store.EXPECT().GetBoardWebhooksForBoard(board.ID).Return([]*model.BoardWebhook{}, nil).AnyTimes()
+
+		_, err := th.App.ExecuteIncomingWebhook(webhook, &model.IncomingWebhookRequest{
+			CardID:     cardBlock.ID,
+			Properties: map[string]interface{}{"status": "done"},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("card of another board", func(t *testing.T) {
+		cardBlock := &model.Block{
+			ID:      utils.NewID(utils.IDTypeCard),
+			BoardID: utils.NewID(utils.IDTypeBoard),
+			Type:    model.TypeCard,
+		}
+
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetBlock(cardBlock.ID).Return(cardBlock, nil)
+
+		card, err := th.App.ExecuteIncomingWebhook(webhook, &model.IncomingWebhookRequest{
+			CardID: cardBlock.ID,
+			Title:  &title,
+		})
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, card)
+	})
+
+	t.Run("unknown option", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+		card, err := th.App.ExecuteIncomingWebhook(webhook, &model.IncomingWebhookRequest{
+			Title:      &title,
+			Properties: map[string]interface{}{"Status": "Doing"},
+		})
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, card)
+	})
+}
@@ -26,4 +26,14 @@ func (a *App) Shutdown() {
 			a.logger.Warn("blockChangeNotifier shutdown timed out")
 		}
 	}
+
+	// undelivered webhook events stay pending and are retried by
+	// ProcessWebhookDeliveries once their claim expires
+	if a.webhookDeliverer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), blockChangeNotifierShutdownTimeout)
+		defer cancel()
+		if !a.webhookDeliverer.Shutdown(ctx) {
+			a.logger.Warn("webhookDeliverer shutdown timed out")
+		}
+	}
 }
@@ -0,0 +1,242 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+// CreateIteration plans a new iteration on a board.
+func (a *App) CreateIteration(iteration *model.Iteration, userID string) (*model.Iteration, error) {
+	newIteration := *iteration
+	newIteration.ID = utils.NewID(utils.IDTypeNone)
+	newIteration.State = model.IterationStatePlanned
+	newIteration.CreatedBy = userID
+	newIteration.StartedAt = 0
+	newIteration.ClosedAt = 0
+
+	if err := newIteration.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	return a.store.CreateIteration(&newIteration)
+}
+
+// UpdateIteration replaces the name, goal, dates and report settings of an
+// iteration. Its state only changes when it is started or closed.
+func (a *App) UpdateIteration(iteration *model.Iteration) (*model.Iteration, error) {
+	existingIteration, err := a.store.GetIteration(iteration.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedIteration := *existingIteration
+	updatedIteration.Name = iteration.Name
+	updatedIteration.Goal = iteration.Goal
+	updatedIteration.StartAt = iteration.StartAt
+	updatedIteration.EndAt = iteration.EndAt
+	updatedIteration.StatusPropertyID = iteration.StatusPropertyID
+	updatedIteration.DoneOptionIDs = iteration.DoneOptionIDs
+	updatedIteration.EstimatePropertyID = iteration.EstimatePropertyID
+
+	if err = updatedIteration.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	return a.store.UpdateIteration(&updatedIteration)
+}
+
+func (a *App) GetIteration(id string) (*model.Iteration, error) {
+	return a.store.GetIteration(id)
+}
+
+func (a *App) GetIterationsForBoard(boardID string) ([]*model.Iteration, error) {
+	return a.store.GetIterationsForBoard(boardID)
+}
+
+// DeleteIteration deletes an iteration and takes its cards out of it.
+func (a *App) DeleteIteration(id, userID string) error {
+	iteration, err := a.store.GetIteration(id)
+	if err != nil {
+		return err
+	}
+
+	cards, err := a.getIterationCards(iteration)
+	if err != nil {
+		return err
+	}
+	if err = a.patchIterationCards(iteration.BoardID, cards, model.BlockPatch{DeletedFields: []string{model.CardFieldIterationID}}, userID); err != nil {
+		return err
+	}
+
+	return a.store.DeleteIteration(id)
+}
+
+// StartIteration makes a planned iteration the active iteration of its board.
+// The planned start date defaults to now.
+func (a *App) StartIteration(id string) (*model.Iteration, error) {
+	iteration, err := a.store.GetIteration(id)
+	if err != nil {
+		return nil, err
+	}
+	if iteration.State != model.IterationStatePlanned {
+		return nil, model.NewErrBadRequest("only a planned iteration can be started")
+	}
+
+	iterations, err := a.store.GetIterationsForBoard(iteration.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range iterations {
+		if other.State == model.IterationStateActive {
+			return nil, model.NewErrBadRequest("the board already has an active iteration: " + other.Name)
+		}
+	}
+
+	now := utils.GetMillis()
+	iteration.State = model.IterationStateActive
+	iteration.StartedAt = now
+	if iteration.StartAt == 0 {
+		iteration.StartAt = now
+	}
+	if iteration.EndAt != 0 && iteration.EndAt <= iteration.StartAt {
+		return nil, model.NewErrBadRequest("the iteration ends before it starts")
+	}
+
+	return a.store.UpdateIteration(iteration)
+}
+
+// CloseIteration closes the active iteration of a board. Its unfinished
+// cards stay in it until they are moved with MoveUnfinishedIterationCards.
+func (a *App) CloseIteration(id string) (*model.Iteration, error) {
+	iteration, err := a.store.GetIteration(id)
+	if err != nil {
+		return nil, err
+	}
+	if iteration.State != model.IterationStateActive {
+		return nil, model.NewErrBadRequest("only an active iteration can be closed")
+	}
+
+	iteration.State = model.IterationStateClosed
+	iteration.ClosedAt = utils.GetMillis()
+
+	return a.store.UpdateIteration(iteration)
+}
+
+// MoveUnfinishedIterationCards moves the cards of an iteration that are not
+// done to another iteration of the same board that is not closed, and returns
+// the ids of the moved cards.
+func (a *App) MoveUnfinishedIterationCards(id, targetID, userID string) ([]string, error) {
+	iteration, err := a.store.GetIteration(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if targetID == id {
+		return nil, model.NewErrBadRequest("the cards are already in this iteration")
+	}
+	target, err := a.store.GetIteration(targetID)
+	if model.IsErrNotFound(err) || (err == nil && target.BoardID != iteration.BoardID) {
+		return nil, model.NewErrBadRequest("the target iteration does not belong to the board")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if target.State == model.IterationStateClosed {
+		return nil, model.NewErrBadRequest("cards cannot be moved to a closed iteration")
+	}
+
+	cards, err := a.getIterationCards(iteration)
+	if err != nil {
+		return nil, err
+	}
+
+	unfinished := []*model.Block{}
+	cardIDs := []string{}
+	for _, card := range cards {
+		if !iteration.IsCardDone(card) {
+			unfinished = append(unfinished, card)
+			cardIDs = append(cardIDs, card.ID)
+		}
+	}
+
+	patch := model.BlockPatch{UpdatedFields: map[string]interface{}{model.CardFieldIterationID: target.ID}}
+	if err = a.patchIterationCards(iteration.BoardID, unfinished, patch, userID); err != nil {
+		return nil, err
+	}
+	return cardIDs, nil
+}
+
+// GetIterationBurndown computes the daily scope and completed work of an
+// iteration from the history of the cards of its board.
+func (a *App) GetIterationBurndown(id string) (*model.IterationBurndown, error) {
+	iteration, err := a.store.GetIteration(id)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline, err := a.getBoardTimeline(iteration.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	return model.BuildIterationBurndown(iteration, timeline, utils.GetMillis()), nil
+}
+
+// GetBoardVelocity computes the committed and completed work of the closed
+// iterations of a board from the history of its cards.
+func (a *App) GetBoardVelocity(boardID string) ([]*model.IterationVelocity, error) {
+	iterations, err := a.store.GetIterationsForBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline, err := a.getBoardTimeline(boardID)
+	if err != nil {
+		return nil, err
+	}
+	return model.BuildIterationVelocity(iterations, timeline), nil
+}
+
+func (a *App) getBoardTimeline(boardID string) (*model.BlockTimeline, error) {
+	history, err := a.store.GetBlockHistoryDescendants(boardID, model.QueryBlockHistoryOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return model.NewBlockTimeline(history), nil
+}
+
+func (a *App) getIterationCards(iteration *model.Iteration) ([]*model.Block, error) {
+	blocks, err := a.store.GetBlocksWithType(iteration.BoardID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := []*model.Block{}
+	for _, block := range blocks {
+		if iteration.HasCard(block) {
+			cards = append(cards, block)
+		}
+	}
+	return cards, nil
+}
+
+// patchIterationCards applies the same patch to cards of a board.
+func (a *App) patchIterationCards(boardID string, cards []*model.Block, patch model.BlockPatch, userID string) error {
+	if len(cards) == 0 {
+		return nil
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return err
+	}
+
+	blockPatches := &model.BlockPatchBatch{}
+	for _, card := range cards {
+		blockPatches.BlockIDs = append(blockPatches.BlockIDs, card.ID)
+		blockPatches.BlockPatches = append(blockPatches.BlockPatches, patch)
+	}
+	return a.PatchBlocksAndNotify(board.TeamID, blockPatches, userID, false)
+}
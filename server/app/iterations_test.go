@@ -0,0 +1,141 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartIteration(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	makeIteration := func(state string) *model.Iteration {
+		return &model.Iteration{
+			ID:      utils.NewID(utils.IDTypeNone),
+			BoardID: boardID,
+			Name:    "Sprint",
+			State:   state,
+		}
+	}
+
+	t.Run("start an iteration", func(t *testing.T) {
+		iteration := makeIteration(model.IterationStatePlanned)
+		th.Store.EXPECT().GetIteration(iteration.ID).Return(iteration, nil)
+		th.Store.EXPECT().GetIterationsForBoard(boardID).Return([]*model.Iteration{iteration, makeIteration(model.IterationStateClosed)}, nil)
+		th.Store.EXPECT().UpdateIteration(gomock.Any()).DoAndReturn(
+			func(iteration *model.Iteration) (*model.Iteration, error) {
+				return iteration, nil
+			})
+
+		started, err := th.App.StartIteration(iteration.ID)
+		require.NoError(t, err)
+		require.Equal(t, model.IterationStateActive, started.State)
+		require.NotZero(t, started.StartedAt)
+		require.Equal(t, started.StartedAt, started.StartAt)
+	})
+
+	t.Run("board with an active iteration", func(t *testing.T) {
+		iteration := makeIteration(model.IterationStatePlanned)
+		th.Store.EXPECT().GetIteration(iteration.ID).Return(iteration, nil)
+		th.Store.EXPECT().GetIterationsForBoard(boardID).Return([]*model.Iteration{iteration, makeIteration(model.IterationStateActive)}, nil)
+
+		_, err := th.App.StartIteration(iteration.ID)
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("iteration already closed", func(t *testing.T) {
+		iteration := makeIteration(model.IterationStateClosed)
+		th.Store.EXPECT().GetIteration(iteration.ID).Return(iteration, nil)
+
+		_, err := th.App.StartIteration(iteration.ID)
+		require.True(t, model.IsErrBadRequest(err))
+
+		th.Store.EXPECT().GetIteration(iteration.ID).Return(iteration, nil)
+		_, err = th.App.CloseIteration(iteration.ID)
+		require.True(t, model.IsErrBadRequest(err))
+	})
+}
+
+func TestMoveUnfinishedIterationCards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: utils.NewID(utils.IDTypeBoard), TeamID: "team-id"}
+	iteration := &model.Iteration{
+		ID:               utils.NewID(utils.IDTypeNone),
+		BoardID:          board.ID,
+		State:            model.IterationStateClosed,
+		StatusPropertyID: "status",
+		DoneOptionIDs:    []string{"done"},
+	}
+	nextIteration := &model.Iteration{
+		ID:      utils.NewID(utils.IDTypeNone),
+		BoardID: board.ID,
+		State:   model.IterationStatePlanned,
+	}
+
+	makeCard := func(iterationID, status string) *model.Block {
+		return &model.Block{
+			ID:      utils.NewID(utils.IDTypeCard),
+			BoardID: board.ID,
+			Type:    model.TypeCard,
+			Fields: map[string]interface{}{
+				model.CardFieldIterationID: iterationID,
+				"properties":               map[string]interface{}{"status": status},
+			},
+		}
+	}
+	doneCard := makeCard(iteration.ID, "done")
+	unfinishedCard := makeCard(iteration.ID, "todo")
+	otherCard := makeCard(nextIteration.ID, "todo")
+
+	t.Run("move the unfinished cards", func(t *testing.T) {
+		th.Store.EXPECT().GetIteration(iteration.ID).Return(iteration, nil)
+		th.Store.EXPECT().GetIteration(nextIteration.ID).Return(nextIteration, nil)
+		th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeCard).Return([]*model.Block{doneCard, unfinishedCard, otherCard}, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+		th.Store.EXPECT().GetBlocksByIDs([]string{unfinishedCard.ID}).Return([]*model.Block{unfinishedCard}, nil)
+		th.Store.EXPECT().GetBoardRulesForBoard(board.ID).Return([]*model.BoardRule{}, nil).AnyTimes()
+		th.Store.EXPECT().PatchBlocks(gomock.Any(), "user-id").DoAndReturn(
+			func(blockPatches *model.BlockPatchBatch, userID string) error {
+				require.Equal(t, []string{unfinishedCard.ID}, blockPatches.BlockIDs)
+				require.Equal(t, nextIteration.ID, blockPatches.BlockPatches[0].UpdatedFields[model.CardFieldIterationID])
+				return nil
+			})
+		// these calls come from the notifications of the patched cards
+		th.Store.EXPECT().GetBlock(unfinishedCard.ID).Return(unfinishedCard, nil).AnyTimes()
+		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+		th.Store.EXPECT().GetBoardWebhooksForBoard(board.ID).Return([]*model.BoardWebhook{}, nil).AnyTimes()
+
+		cardIDs, err := th.App.MoveUnfinishedIterationCards(iteration.ID, nextIteration.ID, "user-id")
+		require.NoError(t, err)
+		require.Equal(t, []string{unfinishedCard.ID}, cardIDs)
+	})
+
+	t.Run("target iteration of another board", func(t *testing.T) {
+		otherIteration := &model.Iteration{ID: utils.NewID(utils.IDTypeNone), BoardID: utils.NewID(utils.IDTypeBoard)}
+		th.Store.EXPECT().GetIteration(iteration.ID).Return(iteration, nil)
+		th.Store.EXPECT().GetIteration(otherIteration.ID).Return(otherIteration, nil)
+
+		_, err := th.App.MoveUnfinishedIterationCards(iteration.ID, otherIteration.ID, "user-id")
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("closed target iteration", func(t *testing.T) {
+		th.Store.EXPECT().GetIteration(nextIteration.ID).Return(nextIteration, nil)
+		closedIteration := *iteration
+		closedIteration.ID = utils.NewID(utils.IDTypeNone)
+		th.Store.EXPECT().GetIteration(closedIteration.ID).Return(&closedIteration, nil)
+
+		_, err := th.App.MoveUnfinishedIterationCards(nextIteration.ID, closedIteration.ID, "user-id")
+		require.True(t, model.IsErrBadRequest(err))
+	})
+}
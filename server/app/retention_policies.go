@@ -0,0 +1,129 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *App) CreateRetentionPolicy(policy *model.RetentionPolicy, userID string) (*model.RetentionPolicy, error) {
+	newPolicy := *policy
+	newPolicy.ID = utils.NewID(utils.IDTypeNone)
+	newPolicy.CreatedBy = userID
+
+	if err := newPolicy.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	if err := a.checkRetentionPolicyScope(&newPolicy); err != nil {
+		return nil, err
+	}
+
+	return a.store.CreateRetentionPolicy(&newPolicy)
+}
+
+// UpdateRetentionPolicy replaces the retention days and exempt boards of a
+// policy. Its team or board cannot be changed.
+func (a *App) UpdateRetentionPolicy(policy *model.RetentionPolicy) (*model.RetentionPolicy, error) {
+	existingPolicy, err := a.store.GetRetentionPolicy(policy.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedPolicy := *existingPolicy
+	updatedPolicy.RetentionDays = policy.RetentionDays
+	updatedPolicy.ExemptBoardIDs = policy.ExemptBoardIDs
+
+	if err = updatedPolicy.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	return a.store.UpdateRetentionPolicy(&updatedPolicy)
+}
+
+func (a *App) GetRetentionPolicy(id string) (*model.RetentionPolicy, error) {
+	return a.store.GetRetentionPolicy(id)
+}
+
+func (a *App) GetRetentionPolicies() ([]*model.RetentionPolicy, error) {
+	return a.store.GetRetentionPolicies()
+}
+
+func (a *App) DeleteRetentionPolicy(id string) error {
+	return a.store.DeleteRetentionPolicy(id)
+}
+
+// checkRetentionPolicyScope returns an error if the team or board of a new
+// policy already has one.
+func (a *App) checkRetentionPolicyScope(policy *model.RetentionPolicy) error {
+	policies, err := a.store.GetRetentionPolicies()
+	if err != nil {
+		return err
+	}
+
+	for _, existing := range policies {
+		if policy.BoardID != "" && existing.BoardID == policy.BoardID {
+			return model.NewErrBadRequest("the board already has a retention policy")
+		}
+		if policy.TeamID != "" && existing.TeamID == policy.TeamID {
+			return model.NewErrBadRequest("the team already has a retention policy")
+		}
+	}
+	return nil
+}
+
+// GetRetentionCutoffs returns the data retention cutoffs at a given time,
+// from the retention policies and the global data retention setting.
+func (a *App) GetRetentionCutoffs(now time.Time) (*model.RetentionCutoffs, error) {
+	policies, err := a.store.GetRetentionPolicies()
+	if err != nil {
+		return nil, err
+	}
+
+	globalDays := 0
+	if a.config.EnableDataRetention {
+		globalDays = a.config.DataRetentionDays
+	}
+	return model.NewRetentionCutoffs(policies, globalDays, now), nil
+}
+
+// RunDataRetention deletes the boards without activity since their
+// retention cutoff, and returns the number of deleted rows.
+func (a *App) RunDataRetention(now time.Time, batchSize int64) (int64, error) {
+	cutoffs, err := a.GetRetentionCutoffs(now)
+	if err != nil {
+		return 0, err
+	}
+
+	if cutoffs.IsEmpty() {
+		a.logger.Debug("No data retention policy applies, skipping data retention")
+		return 0, nil
+	}
+
+	count, err := a.store.RunDataRetention(cutoffs, batchSize)
+	if err != nil {
+		a.logger.Error("Data retention failed", mlog.Err(err))
+		return count, err
+	}
+	return count, nil
+}
+
+// PreviewDataRetention reports what a data retention run at a given time
+// would delete.
+func (a *App) PreviewDataRetention(now time.Time) (*model.RetentionPreview, error) {
+	cutoffs, err := a.GetRetentionCutoffs(now)
+	if err != nil {
+		return nil, err
+	}
+
+	if cutoffs.IsEmpty() {
+		return &model.RetentionPreview{}, nil
+	}
+	return a.store.GetDataRetentionPreview(cutoffs)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateRetentionPolicy(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	userID := utils.NewID(utils.IDTypeUser)
+	teamPolicy := &model.RetentionPolicy{
+		ID:            utils.NewID(utils.IDTypeNone),
+		TeamID:        "team-id",
+		RetentionDays: 30,
+	}
+
+	t.Run("create policy", func(t *testing.T) {
+		th.Store.EXPECT().GetRetentionPolicies().Return([]*model.RetentionPolicy{teamPolicy}, nil)
+		th.Store.EXPECT().CreateRetentionPolicy(gomock.Any()).DoAndReturn(
+			func(policy *model.RetentionPolicy) (*model.RetentionPolicy, error) {
+				return policy, nil
+			})
+
+		policy, err := th.App.CreateRetentionPolicy(&model.RetentionPolicy{BoardID: "board-id"}, userID)
+		require.NoError(t, err)
+		require.NotEmpty(t, policy.ID)
+		require.Equal(t, userID, policy.CreatedBy)
+	})
+
+	t.Run("team with a policy", func(t *testing.T) {
+		th.Store.EXPECT().GetRetentionPolicies().Return([]*model.RetentionPolicy{teamPolicy}, nil)
+
+		policy, err := th.App.CreateRetentionPolicy(&model.RetentionPolicy{TeamID: "team-id", RetentionDays: 7}, userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, policy)
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		policy, err := th.App.CreateRetentionPolicy(&model.RetentionPolicy{RetentionDays: 7}, userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, policy)
+	})
+}
+
+func TestRunDataRetention(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	now := time.Now()
+	boardPolicy := &model.RetentionPolicy{
+		ID:            utils.NewID(utils.IDTypeNone),
+		BoardID:       "board-id",
+		RetentionDays: 7,
+	}
+
+	t.Run("no applicable policy", func(t *testing.T) {
+		th.App.config.EnableDataRetention = false
+		th.Store.EXPECT().GetRetentionPolicies().Return([]*model.RetentionPolicy{}, nil)
+
+		count, err := th.App.RunDataRetention(now, 10)
+		require.NoError(t, err)
+		require.Zero(t, count)
+	})
+
+	t.Run("board policy without global setting", func(t *testing.T) {
+		th.App.config.EnableDataRetention = false
+		th.Store.EXPECT().GetRetentionPolicies().Return([]*model.RetentionPolicy{boardPolicy}, nil)
+		th.Store.EXPECT().RunDataRetention(gomock.Any(), int64(10)).DoAndReturn(
+			func(cutoffs *model.RetentionCutoffs, batchSize int64) (int64, error) {
+				require.Zero(t, cutoffs.Global)
+				require.Equal(t, model.RetentionCutoffDate(7, now), cutoffs.Boards["board-id"])
+				return 5, nil
+			})
+
+		count, err := th.App.RunDataRetention(now, 10)
+		require.NoError(t, err)
+		require.Equal(t, int64(5), count)
+	})
+
+	t.Run("preview with global setting", func(t *testing.T) {
+		th.App.config.EnableDataRetention = true
+		th.App.config.DataRetentionDays = 30
+		defer func() { th.App.config.EnableDataRetention = false }()
+
+		expected := &model.RetentionPreview{BoardCount: 1, BlockCount: 3, HistoryCount: 4}
+		th.Store.EXPECT().GetRetentionPolicies().Return([]*model.RetentionPolicy{}, nil)
+		th.Store.EXPECT().GetDataRetentionPreview(gomock.Any()).DoAndReturn(
+			func(cutoffs *model.RetentionCutoffs) (*model.RetentionPreview, error) {
+				require.Equal(t, model.RetentionCutoffDate(30, now), cutoffs.Global)
+				return expected, nil
+			})
+
+		preview, err := th.App.PreviewDataRetention(now)
+		require.NoError(t, err)
+		require.Equal(t, expected, preview)
+	})
+}
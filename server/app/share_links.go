@@ -0,0 +1,269 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+const (
+	// shareLinkAccessFieldMaxBytes is the length the remote address and user
+	// agent of an access are truncated to.
+	shareLinkAccessFieldMaxBytes = 255
+)
+
+func (a *App) CreateShareLink(link *model.ShareLink, userID string) (*model.ShareLink, error) {
+	newLink := *link
+	newLink.ID = utils.NewID(utils.IDTypeNone)
+	newLink.Token = utils.NewID(utils.IDTypeToken)
+	newLink.RevokedAt = 0
+	newLink.CreatedBy = userID
+	if newLink.Mode == "" {
+		newLink.Mode = model.ShareLinkModeViewer
+	}
+
+	if err := newLink.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	if err := a.checkShareLinkView(&newLink); err != nil {
+		return nil, err
+	}
+
+	if err := newLink.SetPassword(link.Password); err != nil {
+		return nil, err
+	}
+
+	return a.store.CreateShareLink(&newLink)
+}
+
+// UpdateShareLink replaces the name, mode, view and expiration of a share
+// link. Its password is replaced if a new one is set, and removed if the link
+// has no password anymore.
+func (a *App) UpdateShareLink(link *model.ShareLink) (*model.ShareLink, error) {
+	existingLink, err := a.store.GetShareLink(link.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedLink := *existingLink
+	updatedLink.Name = link.Name
+	updatedLink.Mode = link.Mode
+	if updatedLink.Mode == "" {
+		updatedLink.Mode = model.ShareLinkModeViewer
+	}
+	updatedLink.ViewID = link.ViewID
+	updatedLink.ExpiresAt = link.ExpiresAt
+	updatedLink.Password = link.Password
+
+	if err = updatedLink.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	if err = a.checkShareLinkView(&updatedLink); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case link.Password != "":
+		err = updatedLink.SetPassword(link.Password)
+	case !link.HasPassword:
+		err = updatedLink.SetPassword("")
+	default:
+		updatedLink.Password = ""
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return a.store.UpdateShareLink(&updatedLink)
+}
+
+// RevokeShareLink revokes a share link. A revoked link cannot be used
+// anymore, but is kept along with its access log.
+func (a *App) RevokeShareLink(id string) (*model.ShareLink, error) {
+	link, err := a.store.GetShareLink(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if link.RevokedAt != 0 {
+		return link, nil
+	}
+
+	link.RevokedAt = utils.GetMillis()
+	return a.store.UpdateShareLink(link)
+}
+
+func (a *App) GetShareLink(id string) (*model.ShareLink, error) {
+	return a.store.GetShareLink(id)
+}
+
+func (a *App) GetShareLinksForBoard(boardID string) ([]*model.ShareLink, error) {
+	return a.store.GetShareLinksForBoard(boardID)
+}
+
+func (a *App) DeleteShareLink(id string) error {
+	return a.store.DeleteShareLink(id)
+}
+
+func (a *App) GetShareLinkAccesses(shareLinkID string, page, perPage int) ([]*model.ShareLinkAccess, error) {
+	return a.store.GetShareLinkAccesses(shareLinkID, page, perPage)
+}
+
+// GetReadTokenAccess validates a read token for a board and returns the
+// access it gives, nil if the token is not valid. Once too many wrong
+// passwords have been tried for a share link, from the given address or
+// from all addresses, the link cannot be unlocked until the attempt window
+// has passed, even with the right password.
+func (a *App) GetReadTokenAccess(boardID, readToken, password, remoteAddr string) (*model.ReadTokenAccess, error) {
+	access, err := a.auth.GetReadTokenAccess(boardID, readToken, password)
+	if password == "" {
+		return access, err
+	}
+
+	var shareLinkID string
+	var passwordErr model.ErrWrongShareLinkPassword
+	switch {
+	case errors.As(err, &passwordErr):
+		shareLinkID = passwordErr.ShareLinkID
+	case err == nil && access != nil && access.ShareLinkID != "":
+		shareLinkID = access.ShareLinkID
+	default:
+		return access, err
+	}
+
+	if limitErr := a.checkShareLinkPasswordAttempts(shareLinkID, remoteAddr); limitErr != nil {
+		return nil, limitErr
+	}
+	return access, err
+}
+
+// checkShareLinkPasswordAttempts returns model.ErrTooManyRequests if too
+// many wrong passwords have been tried for a share link.
+func (a *App) checkShareLinkPasswordAttempts(shareLinkID, remoteAddr string) error {
+	since := utils.GetMillis() - model.ShareLinkPasswordAttemptWindow
+
+	count, err := a.store.CountFailedShareLinkAccessesSince(shareLinkID, remoteAddr, since)
+	if err != nil {
+		return err
+	}
+	if count >= model.ShareLinkPasswordAttemptLimit {
+		return fmt.Errorf("too many wrong share link passwords: %w", model.ErrTooManyRequests)
+	}
+
+	count, err = a.store.CountFailedShareLinkAccessesSince(shareLinkID, "", since)
+	if err != nil {
+		return err
+	}
+	if count >= model.ShareLinkPasswordLinkAttemptLimit {
+		return fmt.Errorf("too many wrong share link passwords: %w", model.ErrTooManyRequests)
+	}
+	return nil
+}
+
+// RecordShareLinkAccess adds an entry to the access log of a share link.
+func (a *App) RecordShareLinkAccess(access *model.ShareLinkAccess) error {
+	newAccess := *access
+	newAccess.ID = utils.NewID(utils.IDTypeNone)
+	newAccess.RemoteAddr = truncateString(newAccess.RemoteAddr, shareLinkAccessFieldMaxBytes)
+	newAccess.UserAgent = truncateString(newAccess.UserAgent, shareLinkAccessFieldMaxBytes)
+	newAccess.CreateAt = utils.GetMillis()
+
+	return a.store.InsertShareLinkAccess(&newAccess)
+}
+
+// FilterBlocksForView removes from the blocks of a board the other views,
+// the cards that don't match the filter of the view, and the content of
+// those cards.
+func (a *App) FilterBlocksForView(boardID, viewID string, blocks []*model.Block) ([]*model.Block, error) {
+	viewCards, err := a.GetCardsForView(boardID, viewID)
+	if err != nil {
+		return nil, err
+	}
+
+	cardIDs := make(map[string]bool, len(viewCards.Cards))
+	for _, card := range viewCards.Cards {
+		cardIDs[card.ID] = true
+	}
+
+	filtered := make([]*model.Block, 0, len(blocks))
+	for _, block := range blocks {
+		switch {
+		case block.Type == model.TypeView:
+			if block.ID != viewID {
+				continue
+			}
+		case block.Type == model.TypeCard:
+			if !cardIDs[block.ID] {
+				continue
+			}
+		case block.ParentID != boardID && !cardIDs[block.ParentID]:
+			continue
+		}
+		filtered = append(filtered, block)
+	}
+	return filtered, nil
+}
+
+// ValidateFileInView returns a permission error if a file is not attached to
+// the board itself or to one of the cards of a view, as a share link limited
+// to the view only gives access to those files.
+func (a *App) ValidateFileInView(boardID, viewID, filename string) error {
+	viewCards, err := a.GetCardsForView(boardID, viewID)
+	if err != nil {
+		return err
+	}
+
+	parentIDs := make(map[string]bool, len(viewCards.Cards)+1)
+	parentIDs[boardID] = true
+	for _, card := range viewCards.Cards {
+		parentIDs[card.ID] = true
+	}
+
+	for _, blockType := range []string{model.TypeImage, model.TypeAttachment} {
+		blocks, err := a.store.GetBlocksWithType(boardID, blockType)
+		if err != nil {
+			return err
+		}
+		for _, block := range blocks {
+			if parentIDs[block.ParentID] && blockReferencesFile(block, filename) {
+				return nil
+			}
+		}
+	}
+	return model.NewErrPermission("access denied to file")
+}
+
+// checkShareLinkView returns an error if the view of a share link is not a
+// view of its board.
+func (a *App) checkShareLinkView(link *model.ShareLink) error {
+	if link.ViewID == "" {
+		return nil
+	}
+
+	view, err := a.store.GetBlock(link.ViewID)
+	if model.IsErrNotFound(err) {
+		return model.NewErrBadRequest("invalid view id: " + link.ViewID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if view.BoardID != link.BoardID || view.Type != model.TypeView {
+		return model.NewErrBadRequest("invalid view id: " + link.ViewID)
+	}
+	return nil
+}
+
+func truncateString(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	return s[:maxBytes]
+}
@@ -0,0 +1,341 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateShareLink(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	userID := utils.NewID(utils.IDTypeUser)
+	boardID := utils.NewID(utils.IDTypeBoard)
+	viewID := utils.NewID(utils.IDTypeView)
+
+	t.Run("create link", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(viewID).Return(&model.Block{ID: viewID, BoardID: boardID, Type: model.TypeView}, nil)
+		th.Store.EXPECT().CreateShareLink(gomock.Any()).DoAndReturn(
+			func(link *model.ShareLink) (*model.ShareLink, error) {
+				return link, nil
+			})
+
+		link, err := th.App.CreateShareLink(&model.ShareLink{
+			BoardID:  boardID,
+			Name:     "partners",
+			ViewID:   viewID,
+			Password: "secret",
+		}, userID)
+		require.NoError(t, err)
+		require.NotEmpty(t, link.ID)
+		require.NotEmpty(t, link.Token)
+		require.Equal(t, userID, link.CreatedBy)
+		require.True(t, link.HasPassword)
+		require.Empty(t, link.Password)
+		require.True(t, link.CheckPassword("secret"))
+	})
+
+	t.Run("view of another board", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(viewID).Return(&model.Block{ID: viewID, BoardID: "other-board", Type: model.TypeView}, nil)
+
+		link, err := th.App.CreateShareLink(&model.ShareLink{BoardID: boardID, Name: "partners", ViewID: viewID}, userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, link)
+	})
+
+	t.Run("invalid link", func(t *testing.T) {
+		link, err := th.App.CreateShareLink(&model.ShareLink{BoardID: boardID}, userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, link)
+	})
+}
+
+func TestUpdateShareLink(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	existingLink := &model.ShareLink{
+		ID:      utils.NewID(utils.IDTypeNone),
+		BoardID: utils.NewID(utils.IDTypeBoard),
+		Name:    "partners",
+		Token:   "token",
+	}
+	require.NoError(t, existingLink.SetPassword("secret"))
+
+	returnLink := func(link *model.ShareLink) (*model.ShareLink, error) {
+		return link, nil
+	}
+
+	t.Run("keep password", func(t *testing.T) {
+		th.Store.EXPECT().GetShareLink(existingLink.ID).Return(existingLink, nil)
+		th.Store.EXPECT().UpdateShareLink(gomock.Any()).DoAndReturn(returnLink)
+
+		link, err := th.App.UpdateShareLink(&model.ShareLink{ID: existingLink.ID, Name: "customers", HasPassword: true, ExpiresAt: 1000})
+		require.NoError(t, err)
+		require.Equal(t, "customers", link.Name)
+		require.Equal(t, int64(1000), link.ExpiresAt)
+		require.Equal(t, "token", link.Token)
+		require.True(t, link.CheckPassword("secret"))
+	})
+
+	t.Run("change password", func(t *testing.T) {
+		th.Store.EXPECT().GetShareLink(existingLink.ID).Return(existingLink, nil)
+		th.Store.EXPECT().UpdateShareLink(gomock.Any()).DoAndReturn(returnLink)
+
+		link, err := th.App.UpdateShareLink(&model.ShareLink{ID: existingLink.ID, Name: "partners", Password: "other"})
+		require.NoError(t, err)
+		require.True(t, link.CheckPassword("other"))
+		require.Empty(t, link.Password)
+	})
+
+	t.Run("remove password", func(t *testing.T) {
+		th.Store.EXPECT().GetShareLink(existingLink.ID).Return(existingLink, nil)
+		th.Store.EXPECT().UpdateShareLink(gomock.Any()).DoAndReturn(returnLink)
+
+		link, err := th.App.UpdateShareLink(&model.ShareLink{ID: existingLink.ID, Name: "partners"})
+		require.NoError(t, err)
+		require.False(t, link.HasPassword)
+		require.True(t, link.CheckPassword(""))
+	})
+}
+
+func TestRevokeShareLink(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	link := &model.ShareLink{
+		ID:      utils.NewID(utils.IDTypeNone),
+		BoardID: utils.NewID(utils.IDTypeBoard),
+		Name:    "partners",
+		Token:   "token",
+	}
+
+	th.Store.EXPECT().GetShareLink(link.ID).Return(link, nil)
+	th.Store.EXPECT().UpdateShareLink(gomock.Any()).DoAndReturn(
+		func(link *model.ShareLink) (*model.ShareLink, error) {
+			return link, nil
+		})
+
+	revokedLink, err := th.App.RevokeShareLink(link.ID)
+	require.NoError(t, err)
+	require.NotZero(t, revokedLink.RevokedAt)
+	require.False(t, revokedLink.IsActive(utils.GetMillis()))
+}
+
+func TestGetReadTokenAccess(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.App.config.EnablePublicSharedBoards = true
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	viewID := utils.NewID(utils.IDTypeView)
+	sharing := &model.Sharing{ID: boardID, Enabled: true, Token: "sharing-token"}
+
+	link := &model.ShareLink{
+		ID:      utils.NewID(utils.IDTypeNone),
+		BoardID: boardID,
+		Name:    "partners",
+		Token:   "link-token",
+		ViewID:  viewID,
+	}
+	protectedLink := &model.ShareLink{
+		ID:      utils.NewID(utils.IDTypeNone),
+		BoardID: boardID,
+		Name:    "protected",
+		Token:   "protected-token",
+		Mode:    model.ShareLinkModeCommenter,
+	}
+	require.NoError(t, protectedLink.SetPassword("secret"))
+	revokedLink := &model.ShareLink{
+		ID:        utils.NewID(utils.IDTypeNone),
+		BoardID:   boardID,
+		Name:      "revoked",
+		Token:     "revoked-token",
+		RevokedAt: 1000,
+	}
+	expiredLink := &model.ShareLink{
+		ID:        utils.NewID(utils.IDTypeNone),
+		BoardID:   boardID,
+		Name:      "expired",
+		Token:     "expired-token",
+		ExpiresAt: 1000,
+	}
+
+	th.Store.EXPECT().GetSharing(boardID).Return(sharing, nil).AnyTimes()
+	th.Store.EXPECT().GetShareLinkByToken(boardID, link.Token).Return(link, nil).AnyTimes()
+	th.Store.EXPECT().GetShareLinkByToken(boardID, protectedLink.Token).Return(protectedLink, nil).AnyTimes()
+	th.Store.EXPECT().GetShareLinkByToken(boardID, revokedLink.Token).Return(revokedLink, nil).AnyTimes()
+	th.Store.EXPECT().GetShareLinkByToken(boardID, expiredLink.Token).Return(expiredLink, nil).AnyTimes()
+	th.Store.EXPECT().GetShareLinkByToken(boardID, "unknown-token").Return(nil, model.NewErrNotFound("share link")).AnyTimes()
+
+	t.Run("board sharing token", func(t *testing.T) {
+		access, err := th.App.GetReadTokenAccess(boardID, sharing.Token, "", "10.0.0.1")
+		require.NoError(t, err)
+		require.Equal(t, &model.ReadTokenAccess{BoardID: boardID}, access)
+	})
+
+	t.Run("share link token restricted to a view", func(t *testing.T) {
+		access, err := th.App.GetReadTokenAccess(boardID, link.Token, "", "10.0.0.1")
+		require.NoError(t, err)
+		require.Equal(t, link.ID, access.ShareLinkID)
+		require.Equal(t, viewID, access.ViewID)
+		require.False(t, access.CanComment)
+
+		valid, err := th.App.IsValidReadToken(boardID, link.Token)
+		require.NoError(t, err)
+		require.False(t, valid)
+	})
+
+	t.Run("password protected share link", func(t *testing.T) {
+		th.Store.EXPECT().CountFailedShareLinkAccessesSince(protectedLink.ID, gomock.Any(), gomock.Any()).Return(0, nil).Times(4)
+
+		access, err := th.App.GetReadTokenAccess(boardID, protectedLink.Token, "wrong", "10.0.0.1")
+		require.ErrorIs(t, err, model.ErrShareLinkPasswordRequired)
+		var passwordErr model.ErrWrongShareLinkPassword
+		require.ErrorAs(t, err, &passwordErr)
+		require.Equal(t, protectedLink.ID, passwordErr.ShareLinkID)
+		require.Nil(t, access)
+
+		access, err = th.App.GetReadTokenAccess(boardID, protectedLink.Token, "secret", "10.0.0.1")
+		require.NoError(t, err)
+		require.Equal(t, protectedLink.ID, access.ShareLinkID)
+		require.True(t, access.CanComment)
+
+		valid, err := th.App.IsValidReadToken(boardID, protectedLink.Token)
+		require.NoError(t, err)
+		require.False(t, valid)
+	})
+
+	t.Run("too many wrong passwords", func(t *testing.T) {
+		th.Store.EXPECT().CountFailedShareLinkAccessesSince(protectedLink.ID, "10.0.0.2", gomock.Any()).Return(model.ShareLinkPasswordAttemptLimit, nil).Times(2)
+
+		access, err := th.App.GetReadTokenAccess(boardID, protectedLink.Token, "wrong", "10.0.0.2")
+		require.True(t, model.IsErrTooManyRequests(err))
+		require.Nil(t, access)
+
+		access, err = th.App.GetReadTokenAccess(boardID, protectedLink.Token, "secret", "10.0.0.2")
+		require.True(t, model.IsErrTooManyRequests(err), "the right password is rejected too")
+		require.Nil(t, access)
+
+		th.Store.EXPECT().CountFailedShareLinkAccessesSince(protectedLink.ID, "10.0.0.3", gomock.Any()).Return(0, nil)
+		th.Store.EXPECT().CountFailedShareLinkAccessesSince(protectedLink.ID, "", gomock.Any()).Return(model.ShareLinkPasswordLinkAttemptLimit, nil)
+
+		access, err = th.App.GetReadTokenAccess(boardID, protectedLink.Token, "secret", "10.0.0.3")
+		require.True(t, model.IsErrTooManyRequests(err))
+		require.Nil(t, access)
+	})
+
+	t.Run("revoked, expired and unknown tokens", func(t *testing.T) {
+		for _, token := range []string{revokedLink.Token, expiredLink.Token, "unknown-token"} {
+			access, err := th.App.GetReadTokenAccess(boardID, token, "", "10.0.0.1")
+			require.NoError(t, err)
+			require.Nil(t, access)
+		}
+	})
+
+	t.Run("board without sharing", func(t *testing.T) {
+		otherBoardID := utils.NewID(utils.IDTypeBoard)
+		th.Store.EXPECT().GetSharing(otherBoardID).Return(nil, sql.ErrNoRows)
+		th.Store.EXPECT().GetShareLinkByToken(otherBoardID, link.Token).Return(nil, model.NewErrNotFound("share link"))
+
+		access, err := th.App.GetReadTokenAccess(otherBoardID, link.Token, "", "10.0.0.1")
+		require.NoError(t, err)
+		require.Nil(t, access)
+	})
+}
+
+func TestFilterBlocksForView(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:             utils.NewID(utils.IDTypeBoard),
+		CardProperties: []map[string]interface{}{},
+	}
+	view := &model.Block{
+		ID:       utils.NewID(utils.IDTypeView),
+		BoardID:  board.ID,
+		ParentID: board.ID,
+		Type:     model.TypeView,
+		Fields: map[string]interface{}{
+			"filter": map[string]interface{}{
+				"operation": "and",
+				"filters": []interface{}{
+					map[string]interface{}{"propertyId": "title", "condition": "contains", "values": []interface{}{"shared"}},
+				},
+			},
+		},
+	}
+	otherView := &model.Block{ID: utils.NewID(utils.IDTypeView), BoardID: board.ID, ParentID: board.ID, Type: model.TypeView}
+	sharedCard := &model.Block{ID: utils.NewID(utils.IDTypeCard), BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard, Title: "shared card"}
+	privateCard := &model.Block{ID: utils.NewID(utils.IDTypeCard), BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard, Title: "private card"}
+	sharedComment := &model.Block{ID: utils.NewID(utils.IDTypeBlock), BoardID: board.ID, ParentID: sharedCard.ID, Type: model.TypeComment}
+	privateComment := &model.Block{ID: utils.NewID(utils.IDTypeBlock), BoardID: board.ID, ParentID: privateCard.ID, Type: model.TypeComment}
+
+	th.Store.EXPECT().GetBlock(view.ID).Return(view, nil)
+	th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+	th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeCard).Return([]*model.Block{sharedCard, privateCard}, nil)
+
+	blocks := []*model.Block{view, otherView, sharedCard, privateCard, sharedComment, privateComment}
+	filtered, err := th.App.FilterBlocksForView(board.ID, view.ID, blocks)
+	require.NoError(t, err)
+	require.Equal(t, []*model.Block{view, sharedCard, sharedComment}, filtered)
+}
+
+func TestValidateFileInView(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:             utils.NewID(utils.IDTypeBoard),
+		CardProperties: []map[string]interface{}{},
+	}
+	view := &model.Block{
+		ID:       utils.NewID(utils.IDTypeView),
+		BoardID:  board.ID,
+		ParentID: board.ID,
+		Type:     model.TypeView,
+		Fields: map[string]interface{}{
+			"filter": map[string]interface{}{
+				"operation": "and",
+				"filters": []interface{}{
+					map[string]interface{}{"propertyId": "title", "condition": "contains", "values": []interface{}{"shared"}},
+				},
+			},
+		},
+	}
+	sharedCard := &model.Block{ID: utils.NewID(utils.IDTypeCard), BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard, Title: "shared card"}
+	privateCard := &model.Block{ID: utils.NewID(utils.IDTypeCard), BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard, Title: "private card"}
+	sharedImage := &model.Block{ID: utils.NewID(utils.IDTypeBlock), BoardID: board.ID, ParentID: sharedCard.ID, Type: model.TypeImage,
+		Fields: map[string]interface{}{model.BlockFieldFileId: "shared.png"}}
+	privateAttachment := &model.Block{ID: utils.NewID(utils.IDTypeBlock), BoardID: board.ID, ParentID: privateCard.ID, Type: model.TypeAttachment,
+		Fields: map[string]interface{}{model.BlockFieldAttachmentId: "private.pdf"}}
+
+	expectViewBlocks := func() {
+		th.Store.EXPECT().GetBlock(view.ID).Return(view, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeCard).Return([]*model.Block{sharedCard, privateCard}, nil)
+		th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeImage).Return([]*model.Block{sharedImage}, nil)
+		th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeAttachment).Return([]*model.Block{privateAttachment}, nil).MaxTimes(1)
+	}
+
+	t.Run("file of a card of the view", func(t *testing.T) {
+		expectViewBlocks()
+		require.NoError(t, th.App.ValidateFileInView(board.ID, view.ID, "shared.png"))
+	})
+
+	t.Run("file of a card outside the view", func(t *testing.T) {
+		expectViewBlocks()
+		err := th.App.ValidateFileInView(board.ID, view.ID, "private.pdf")
+		require.True(t, model.IsErrForbidden(err))
+	})
+}
@@ -34,6 +34,28 @@ func (a *App) DeleteSubscription(blockID string, subscriberID string) (*model.Su
 	return sub, nil
 }
 
+// UpdateSubscriptionFilter changes the types of changes a subscriber is
+// notified of; a nil filter notifies them of every change.
+func (a *App) UpdateSubscriptionFilter(blockID string, subscriberID string, filter *model.SubscriptionFilter) (*model.Subscription, error) {
+	if filter != nil {
+		if err := filter.IsValid(); err != nil {
+			return nil, model.NewErrBadRequest(err.Error())
+		}
+	}
+
+	sub, err := a.store.GetSubscription(blockID, subscriberID)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.store.UpdateSubscriptionFilter(blockID, subscriberID, filter); err != nil {
+		return nil, err
+	}
+	sub.Filter = filter
+	a.notifySubscriptionChanged(sub)
+
+	return sub, nil
+}
+
 func (a *App) GetSubscriptions(subscriberID string) ([]*model.Subscription, error) {
 	return a.store.GetSubscriptions(subscriberID)
 }
@@ -0,0 +1,139 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+// CreateTimeEntry logs time spent by a user on a card.
+func (a *App) CreateTimeEntry(entry *model.TimeEntry, userID string) (*model.TimeEntry, error) {
+	card, err := a.GetCardByID(entry.CardID)
+	if err != nil {
+		return nil, err
+	}
+
+	newEntry := &model.TimeEntry{
+		ID:       utils.NewID(utils.IDTypeNone),
+		CardID:   card.ID,
+		BoardID:  card.BoardID,
+		UserID:   userID,
+		StartAt:  entry.StartAt,
+		Duration: entry.Duration,
+		Note:     entry.Note,
+	}
+
+	if err = newEntry.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	return a.store.CreateTimeEntry(newEntry)
+}
+
+// UpdateTimeEntry replaces the start time, duration and note of a time entry.
+// The duration of a running timer is only set when it is stopped.
+func (a *App) UpdateTimeEntry(entry *model.TimeEntry) (*model.TimeEntry, error) {
+	existingEntry, err := a.store.GetTimeEntry(entry.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedEntry := *existingEntry
+	updatedEntry.StartAt = entry.StartAt
+	updatedEntry.Note = entry.Note
+	if !updatedEntry.Running {
+		updatedEntry.Duration = entry.Duration
+	}
+
+	if err = updatedEntry.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	return a.store.UpdateTimeEntry(&updatedEntry)
+}
+
+func (a *App) GetTimeEntry(id string) (*model.TimeEntry, error) {
+	return a.store.GetTimeEntry(id)
+}
+
+func (a *App) GetTimeEntriesForCard(cardID string) ([]*model.TimeEntry, error) {
+	return a.store.GetTimeEntriesForCard(cardID)
+}
+
+func (a *App) GetTimeEntriesForBoard(boardID string) ([]*model.TimeEntry, error) {
+	return a.store.GetTimeEntriesForBoard(boardID)
+}
+
+func (a *App) DeleteTimeEntry(id string) error {
+	return a.store.DeleteTimeEntry(id)
+}
+
+// GetRunningTimeEntry returns the running timer of a user.
+func (a *App) GetRunningTimeEntry(userID string) (*model.TimeEntry, error) {
+	return a.store.GetRunningTimeEntry(userID)
+}
+
+// StartTimer starts a timer for a user on a card. A user has at most one
+// running timer, so the timer already running is stopped first.
+func (a *App) StartTimer(cardID, note, userID string) (*model.TimeEntry, error) {
+	card, err := a.GetCardByID(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = a.StopTimer(userID); err != nil && !model.IsErrNotFound(err) {
+		return nil, err
+	}
+
+	newEntry := &model.TimeEntry{
+		ID:      utils.NewID(utils.IDTypeNone),
+		CardID:  card.ID,
+		BoardID: card.BoardID,
+		UserID:  userID,
+		StartAt: utils.GetMillis(),
+		Running: true,
+		Note:    note,
+	}
+
+	if err = newEntry.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	return a.store.CreateTimeEntry(newEntry)
+}
+
+// StopTimer stops the running timer of a user, and returns a not found error
+// if there is none.
+func (a *App) StopTimer(userID string) (*model.TimeEntry, error) {
+	entry, err := a.store.GetRunningTimeEntry(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// a timer stopped within the same millisecond still has a duration
+	entry.Duration = max(entry.Elapsed(utils.GetMillis()), 1)
+	entry.Running = false
+
+	return a.store.UpdateTimeEntry(entry)
+}
+
+// GetCardTimeSummary returns the time spent on a card, per user.
+func (a *App) GetCardTimeSummary(cardID string) (*model.TimeSummary, error) {
+	entries, err := a.store.GetTimeEntriesForCard(cardID)
+	if err != nil {
+		return nil, err
+	}
+	return model.SummarizeTimeEntries(entries, utils.GetMillis(), false), nil
+}
+
+// GetBoardTimeSummary returns the time spent on the cards of a board, per
+// user and per card.
+func (a *App) GetBoardTimeSummary(boardID string) (*model.TimeSummary, error) {
+	entries, err := a.store.GetTimeEntriesForBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	return model.SummarizeTimeEntries(entries, utils.GetMillis(), true), nil
+}
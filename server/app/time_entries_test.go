@@ -0,0 +1,157 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTimeEntry(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	userID := utils.NewID(utils.IDTypeUser)
+	card := &model.Block{
+		ID:      utils.NewID(utils.IDTypeCard),
+		BoardID: utils.NewID(utils.IDTypeBoard),
+		Type:    model.TypeCard,
+	}
+
+	t.Run("log time", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(card.ID).Return(card, nil)
+		th.Store.EXPECT().CreateTimeEntry(gomock.Any()).DoAndReturn(
+			func(entry *model.TimeEntry) (*model.TimeEntry, error) {
+				return entry, nil
+			})
+
+		entry, err := th.App.CreateTimeEntry(&model.TimeEntry{
+			CardID:   card.ID,
+			UserID:   "other-user",
+			StartAt:  1000,
+			Duration: 60000,
+			Running:  true,
+			Note:     "design review",
+		}, userID)
+		require.NoError(t, err)
+		require.NotEmpty(t, entry.ID)
+		require.Equal(t, card.BoardID, entry.BoardID)
+		require.Equal(t, userID, entry.UserID)
+		require.False(t, entry.Running)
+		require.Equal(t, "design review", entry.Note)
+	})
+
+	t.Run("no duration", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(card.ID).Return(card, nil)
+
+		entry, err := th.App.CreateTimeEntry(&model.TimeEntry{CardID: card.ID, StartAt: 1000}, userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, entry)
+	})
+}
+
+func TestUpdateTimeEntry(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	returnEntry := func(entry *model.TimeEntry) (*model.TimeEntry, error) {
+		return entry, nil
+	}
+
+	t.Run("edit entry", func(t *testing.T) {
+		existingEntry := &model.TimeEntry{ID: "entry-id", CardID: utils.NewID(utils.IDTypeCard), BoardID: utils.NewID(utils.IDTypeBoard), UserID: "user-id", StartAt: 1000, Duration: 60000}
+		th.Store.EXPECT().GetTimeEntry("entry-id").Return(existingEntry, nil)
+		th.Store.EXPECT().UpdateTimeEntry(gomock.Any()).DoAndReturn(returnEntry)
+
+		entry, err := th.App.UpdateTimeEntry(&model.TimeEntry{ID: "entry-id", StartAt: 2000, Duration: 30000, Note: "fixed"})
+		require.NoError(t, err)
+		require.Equal(t, int64(2000), entry.StartAt)
+		require.Equal(t, int64(30000), entry.Duration)
+		require.Equal(t, "fixed", entry.Note)
+		require.Equal(t, "user-id", entry.UserID)
+	})
+
+	t.Run("running timer keeps running", func(t *testing.T) {
+		existingEntry := &model.TimeEntry{ID: "entry-id", CardID: utils.NewID(utils.IDTypeCard), BoardID: utils.NewID(utils.IDTypeBoard), UserID: "user-id", StartAt: 1000, Running: true}
+		th.Store.EXPECT().GetTimeEntry("entry-id").Return(existingEntry, nil)
+		th.Store.EXPECT().UpdateTimeEntry(gomock.Any()).DoAndReturn(returnEntry)
+
+		entry, err := th.App.UpdateTimeEntry(&model.TimeEntry{ID: "entry-id", StartAt: 500, Duration: 30000})
+		require.NoError(t, err)
+		require.True(t, entry.Running)
+		require.Zero(t, entry.Duration)
+		require.Equal(t, int64(500), entry.StartAt)
+	})
+}
+
+func TestTimer(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	userID := utils.NewID(utils.IDTypeUser)
+	card := &model.Block{
+		ID:      utils.NewID(utils.IDTypeCard),
+		BoardID: utils.NewID(utils.IDTypeBoard),
+		Type:    model.TypeCard,
+	}
+
+	returnEntry := func(entry *model.TimeEntry) (*model.TimeEntry, error) {
+		return entry, nil
+	}
+
+	t.Run("start timer", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(card.ID).Return(card, nil)
+		th.Store.EXPECT().GetRunningTimeEntry(userID).Return(nil, model.NewErrNotFound("running time entry"))
+		th.Store.EXPECT().CreateTimeEntry(gomock.Any()).DoAndReturn(returnEntry)
+
+		entry, err := th.App.StartTimer(card.ID, "standup", userID)
+		require.NoError(t, err)
+		require.True(t, entry.Running)
+		require.Zero(t, entry.Duration)
+		require.NotZero(t, entry.StartAt)
+		require.Equal(t, "standup", entry.Note)
+	})
+
+	t.Run("starting a timer stops the running one", func(t *testing.T) {
+		runningEntry := &model.TimeEntry{ID: "running-id", CardID: utils.NewID(utils.IDTypeCard), BoardID: card.BoardID, UserID: userID, StartAt: utils.GetMillis() - 60000, Running: true}
+		th.Store.EXPECT().GetBlock(card.ID).Return(card, nil)
+		th.Store.EXPECT().GetRunningTimeEntry(userID).Return(runningEntry, nil)
+		th.Store.EXPECT().UpdateTimeEntry(gomock.Any()).DoAndReturn(
+			func(entry *model.TimeEntry) (*model.TimeEntry, error) {
+				require.Equal(t, "running-id", entry.ID)
+				require.False(t, entry.Running)
+				require.GreaterOrEqual(t, entry.Duration, int64(60000))
+				return entry, nil
+			})
+		th.Store.EXPECT().CreateTimeEntry(gomock.Any()).DoAndReturn(returnEntry)
+
+		entry, err := th.App.StartTimer(card.ID, "", userID)
+		require.NoError(t, err)
+		require.Equal(t, card.ID, entry.CardID)
+		require.True(t, entry.Running)
+	})
+
+	t.Run("stop timer", func(t *testing.T) {
+		runningEntry := &model.TimeEntry{ID: "running-id", CardID: card.ID, BoardID: card.BoardID, UserID: userID, StartAt: utils.GetMillis(), Running: true}
+		th.Store.EXPECT().GetRunningTimeEntry(userID).Return(runningEntry, nil)
+		th.Store.EXPECT().UpdateTimeEntry(gomock.Any()).DoAndReturn(returnEntry)
+
+		entry, err := th.App.StopTimer(userID)
+		require.NoError(t, err)
+		require.False(t, entry.Running)
+		require.Positive(t, entry.Duration)
+	})
+
+	t.Run("no running timer", func(t *testing.T) {
+		th.Store.EXPECT().GetRunningTimeEntry(userID).Return(nil, model.NewErrNotFound("running time entry"))
+
+		entry, err := th.App.StopTimer(userID)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, entry)
+	})
+}
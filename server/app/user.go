@@ -30,6 +30,10 @@ func (a *App) SearchTeamUsers(teamID string, searchQuery string, asGuestID strin
 }
 
 func (a *App) UpdateUserConfig(userID string, patch model.UserPreferencesPatch) ([]mmModel.Preference, error) {
+	if frequency, ok := patch.UpdatedFields[model.PreferenceNotificationDigest]; ok && !model.IsValidDigestFrequency(frequency) {
+		return nil, model.NewErrBadRequest("invalid notification digest frequency: " + frequency)
+	}
+
 	updatedPreferences, err := a.store.PatchUserPreferences(userID, patch)
 	if err != nil {
 		return nil, err
@@ -86,3 +86,36 @@ func TestSearchUsers(t *testing.T) {
 		assert.Equal(t, 0, len(channels))
 	})
 }
+
+func TestUpdateUserConfig(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	userID := "user-id-1"
+
+	t.Run("valid notification digest frequency", func(t *testing.T) {
+		patch := model.UserPreferencesPatch{
+			UpdatedFields: map[string]string{model.PreferenceNotificationDigest: model.DigestFrequencyDaily},
+		}
+		preferences := []mmModel.Preference{{
+			UserId:   userID,
+			Category: model.PreferencesCategoryFocalboard,
+			Name:     model.PreferenceNotificationDigest,
+			Value:    model.DigestFrequencyDaily,
+		}}
+		th.Store.EXPECT().PatchUserPreferences(userID, patch).Return(preferences, nil)
+
+		updated, err := th.App.UpdateUserConfig(userID, patch)
+		assert.NoError(t, err)
+		assert.Equal(t, preferences, updated)
+	})
+
+	t.Run("invalid notification digest frequency", func(t *testing.T) {
+		patch := model.UserPreferencesPatch{
+			UpdatedFields: map[string]string{model.PreferenceNotificationDigest: "monthly"},
+		}
+
+		_, err := th.App.UpdateUserConfig(userID, patch)
+		assert.True(t, model.IsErrBadRequest(err))
+	})
+}
@@ -9,11 +9,13 @@ import (
 	"github.com/mattermost/mattermost-plugin-boards/server/services/config"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/permissions"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
 	"github.com/pkg/errors"
 )
 
 type AuthInterface interface {
 	IsValidReadToken(boardID string, readToken string) (bool, error)
+	GetReadTokenAccess(boardID string, readToken string, password string) (*model.ReadTokenAccess, error)
 	DoesUserHaveTeamAccess(userID string, teamID string) bool
 }
 
@@ -29,25 +31,69 @@ func New(config *config.Configuration, store store.Store, permissions permission
 	return &Auth{config: config, store: store, permissions: permissions}
 }
 
-// IsValidReadToken validates the read token for a board.
+// IsValidReadToken validates the read token for a board. The token of a
+// share link is only valid if the link has no password and gives access to
+// all the views of the board.
 func (a *Auth) IsValidReadToken(boardID string, readToken string) (bool, error) {
-	sharing, err := a.store.GetSharing(boardID)
-	if model.IsErrNotFound(err) {
+	access, err := a.GetReadTokenAccess(boardID, readToken, "")
+	if errors.Is(err, model.ErrShareLinkPasswordRequired) {
 		return false, nil
 	}
 	if err != nil {
 		return false, err
 	}
 
-	if !a.config.EnablePublicSharedBoards {
-		return false, errors.New("public shared boards disabled")
+	return access != nil && access.ViewID == "", nil
+}
+
+// GetReadTokenAccess validates the read token for a board, either the token
+// of the board sharing or the token of one of its active share links, and
+// returns the access it gives. It returns nil if the token is not valid, and
+// a model.ErrWrongShareLinkPassword if the password of the share link is
+// missing or wrong.
+func (a *Auth) GetReadTokenAccess(boardID string, readToken string, password string) (*model.ReadTokenAccess, error) {
+	if readToken == "" {
+		return nil, nil
+	}
+
+	sharing, err := a.store.GetSharing(boardID)
+	if err != nil && !model.IsErrNotFound(err) {
+		return nil, err
 	}
 
 	if sharing != nil && (sharing.ID == boardID && sharing.Enabled && sharing.Token == readToken) {
-		return true, nil
+		if !a.config.EnablePublicSharedBoards {
+			return nil, errors.New("public shared boards disabled")
+		}
+		return &model.ReadTokenAccess{BoardID: boardID}, nil
+	}
+
+	link, err := a.store.GetShareLinkByToken(boardID, readToken)
+	if model.IsErrNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !a.config.EnablePublicSharedBoards {
+		return nil, errors.New("public shared boards disabled")
+	}
+
+	if !link.IsActive(utils.GetMillis()) {
+		return nil, nil
+	}
+
+	if !link.CheckPassword(password) {
+		return nil, model.ErrWrongShareLinkPassword{ShareLinkID: link.ID}
 	}
 
-	return false, nil
+	return &model.ReadTokenAccess{
+		BoardID:     boardID,
+		ShareLinkID: link.ID,
+		ViewID:      link.ViewID,
+		CanComment:  link.Mode == model.ShareLinkModeCommenter,
+	}, nil
 }
 
 func (a *Auth) DoesUserHaveTeamAccess(userID string, teamID string) bool {
@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	model "github.com/mattermost/mattermost-plugin-boards/server/model"
 )
 
 // MockAuthInterface is a mock of AuthInterface interface.
@@ -47,6 +48,21 @@ func (mr *MockAuthInterfaceMockRecorder) DoesUserHaveTeamAccess(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoesUserHaveTeamAccess", reflect.TypeOf((*MockAuthInterface)(nil).DoesUserHaveTeamAccess), arg0, arg1)
 }
 
+// GetReadTokenAccess mocks base method.
+func (m *MockAuthInterface) GetReadTokenAccess(arg0, arg1, arg2 string) (*model.ReadTokenAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReadTokenAccess", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.ReadTokenAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReadTokenAccess indicates an expected call of GetReadTokenAccess.
+func (mr *MockAuthInterfaceMockRecorder) GetReadTokenAccess(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReadTokenAccess", reflect.TypeOf((*MockAuthInterface)(nil).GetReadTokenAccess), arg0, arg1, arg2)
+}
+
 // IsValidReadToken mocks base method.
 func (m *MockAuthInterface) IsValidReadToken(arg0, arg1 string) (bool, error) {
 	m.ctrl.T.Helper()
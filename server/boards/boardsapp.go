@@ -39,6 +39,10 @@ type BoardsEmbed struct {
 	BoardID      string `json:"boardID"`
 	CardID       string `json:"cardID"`
 	ReadToken    string `json:"readToken,omitempty"`
+
+	// Preview is only set when the card can be shown to everyone in the
+	// channel of the post.
+	Preview *model.CardPreview `json:"preview,omitempty"`
 }
 
 type BoardsApp struct {
@@ -56,6 +60,9 @@ type BoardsApp struct {
 
 	servicesAPI model.ServicesAPI
 	logger      mlog.LoggerIFace
+
+	command      *boardsCommand
+	cardPreviews *cardPreviewsBackend
 }
 
 func NewBoardsApp(api model.ServicesAPI, manifest *mm_model.Manifest) (*BoardsApp, error) {
@@ -120,6 +127,27 @@ func NewBoardsApp(api model.ServicesAPI, manifest *mm_model.Manifest) (*BoardsAp
 	notifyBackends = append(notifyBackends, subscriptionsBackend)
 	mentionsBackend.AddListener(subscriptionsBackend)
 
+	rulesBackend, err := createRulesNotifyBackend(backendParams)
+	if err != nil {
+		return nil, fmt.Errorf("error creating rules notifications backend: %w", err)
+	}
+	notifyBackends = append(notifyBackends, rulesBackend)
+
+	remindersBackend, err := createRemindersNotifyBackend(backendParams)
+	if err != nil {
+		return nil, fmt.Errorf("error creating reminders notifications backend: %w", err)
+	}
+	notifyBackends = append(notifyBackends, remindersBackend)
+
+	channelFeedBackend, err := createChannelFeedNotifyBackend(backendParams)
+	if err != nil {
+		return nil, fmt.Errorf("error creating channel feed notifications backend: %w", err)
+	}
+	notifyBackends = append(notifyBackends, channelFeedBackend)
+
+	cardPreviews := createCardPreviewsBackend(backendParams)
+	notifyBackends = append(notifyBackends, cardPreviews)
+
 	params := server.Params{
 		Cfg:                cfg,
 		SingleUserToken:    "",
@@ -154,12 +182,21 @@ func NewBoardsApp(api model.ServicesAPI, manifest *mm_model.Manifest) (*BoardsAp
 		}
 	*/
 
+	command := &boardsCommand{
+		app:         server.App(),
+		permissions: permissionsService,
+		serverRoot:  baseURL + "/boards",
+		logger:      logger,
+	}
+
 	return &BoardsApp{
 		manifest:        manifest,
 		server:          server,
 		wsPluginAdapter: wsPluginAdapter,
 		servicesAPI:     api,
 		logger:          logger,
+		command:         command,
+		cardPreviews:    cardPreviews,
 	}, nil
 }
 
@@ -170,6 +207,10 @@ func (b *BoardsApp) Start() error {
 
 	b.servicesAPI.RegisterRouter(b.server.GetRootRouter())
 
+	if err := b.servicesAPI.RegisterCommand(createBoardsCommand()); err != nil {
+		return fmt.Errorf("error registering the boards command: %w", err)
+	}
+
 	b.logger.Info("Boards product successfully started.")
 
 	return nil
@@ -184,11 +225,30 @@ func (b *BoardsApp) Stop() error {
 //
 
 func (b *BoardsApp) MessageWillBePosted(_ *plugin.Context, post *mm_model.Post) (*mm_model.Post, string) {
-	return postWithBoardsEmbed(post), ""
+	return b.cardPreviews.embedPost(post), ""
+}
+
+func (b *BoardsApp) MessageHasBeenPosted(_ *plugin.Context, post *mm_model.Post) {
+	b.cardPreviews.postSaved(post, nil)
 }
 
 func (b *BoardsApp) MessageWillBeUpdated(_ *plugin.Context, newPost, _ *mm_model.Post) (*mm_model.Post, string) {
-	return postWithBoardsEmbed(newPost), ""
+	return b.cardPreviews.embedPost(newPost), ""
+}
+
+func (b *BoardsApp) MessageHasBeenUpdated(_ *plugin.Context, newPost, oldPost *mm_model.Post) {
+	b.cardPreviews.postSaved(newPost, oldPost)
+}
+
+func (b *BoardsApp) MessageHasBeenDeleted(_ *plugin.Context, post *mm_model.Post) {
+	if linkedCardID(post) != "" {
+		b.cardPreviews.postDeleted(post)
+	}
+}
+
+// ExecuteCommand runs the `/boards` slash command.
+func (b *BoardsApp) ExecuteCommand(_ *plugin.Context, args *mm_model.CommandArgs) (*mm_model.CommandResponse, *mm_model.AppError) {
+	return b.command.Execute(args), nil
 }
 
 func (b *BoardsApp) OnWebSocketConnect(webConnID, userID string) {
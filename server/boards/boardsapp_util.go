@@ -80,6 +80,11 @@ func createBoardsConfig(mmconfig mm_model.Config, baseURL string, serverID strin
 		showFullName = *mmconfig.PrivacySettings.ShowFullName
 	}
 
+	allowedInternalHosts := ""
+	if mmconfig.ServiceSettings.AllowedUntrustedInternalConnections != nil {
+		allowedInternalHosts = *mmconfig.ServiceSettings.AllowedUntrustedInternalConnections
+	}
+
 	serverRoot := baseURL + "/plugins/focalboard"
 
 	return &config.Configuration{
@@ -98,6 +103,8 @@ func createBoardsConfig(mmconfig mm_model.Config, baseURL string, serverID strin
 		Telemetry:                enableTelemetry,
 		TelemetryID:              serverID,
 		WebhookUpdate:            []string{},
+		AllowedInternalHosts:     allowedInternalHosts,
+		TrustedProxyIPHeader:     mmconfig.ServiceSettings.TrustedProxyIPHeader,
 		SessionExpireTime:        2592000,
 		SessionRefreshTime:       18000,
 		LocalOnly:                false,
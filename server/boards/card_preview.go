@@ -0,0 +1,195 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package boards
+
+import (
+	"encoding/json"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/permissions"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const cardPreviewsBackendName = "cardPreviews"
+
+// cardPreviewsBackend adds the preview of the linked card to the boards embed
+// of posts, and renders the previews again when the card, its board or the
+// members of its board change, so that previews in older posts stay current
+// and are only shown while they are allowed.
+type cardPreviewsBackend struct {
+	appAPI      *appAPI
+	servicesAPI model.ServicesAPI
+	permissions permissions.PermissionsService
+	logger      mlog.LoggerIFace
+}
+
+func createCardPreviewsBackend(params notifyBackendParams) *cardPreviewsBackend {
+	return &cardPreviewsBackend{
+		appAPI:      params.appAPI,
+		servicesAPI: params.servicesAPI,
+		permissions: params.permissions,
+		logger:      params.logger,
+	}
+}
+
+func (b *cardPreviewsBackend) Start() error {
+	return nil
+}
+
+func (b *cardPreviewsBackend) ShutDown() error {
+	return nil
+}
+
+func (b *cardPreviewsBackend) Name() string {
+	return cardPreviewsBackendName
+}
+
+// embedPost adds the boards embed, with the preview of the linked card if
+// any, to a post.
+func (b *cardPreviewsBackend) embedPost(post *mm_model.Post) *mm_model.Post {
+	return postWithBoardsEmbed(post, b.cardPreview)
+}
+
+// cardPreview returns the preview of the card linked by a post. The preview
+// is only shown if the author of the post can view the card, and if the
+// members of the channel of the post can view it too: the board is linked to
+// the channel, or is open to the team of the channel. Otherwise the embed
+// stays generic, and the card is shown to those allowed once opened.
+func (b *cardPreviewsBackend) cardPreview(post *mm_model.Post, embed BoardsEmbed) *model.CardPreview {
+	preview, err := b.appAPI.GetCardPreview(embed.CardID)
+	if err != nil {
+		b.logger.Debug("Cannot get card preview for post",
+			mlog.String("post_id", post.Id),
+			mlog.String("card_id", embed.CardID),
+			mlog.Err(err),
+		)
+		return nil
+	}
+
+	if !b.permissions.HasPermissionToBoard(post.UserId, preview.BoardID, model.PermissionViewBoard) {
+		return nil
+	}
+
+	board, err := b.appAPI.GetBoard(preview.BoardID)
+	if err != nil {
+		return nil
+	}
+	channel, err := b.servicesAPI.GetChannelByID(post.ChannelId)
+	if err != nil {
+		return nil
+	}
+
+	linkedToChannel := board.ChannelID != "" && board.ChannelID == channel.Id
+	openToTeam := board.Type == model.BoardTypeOpen && board.TeamID == channel.TeamId
+	if !linkedToChannel && !openToTeam {
+		return nil
+	}
+	return preview
+}
+
+// postSaved records the card linked by a post, so that its preview is
+// rendered again when the card or its board changes, including when the card
+// cannot be previewed yet.
+func (b *cardPreviewsBackend) postSaved(post, oldPost *mm_model.Post) {
+	if cardID := linkedCardID(post); cardID != "" {
+		previewPost := &model.CardPreviewPost{CardID: cardID, PostID: post.Id}
+		if err := b.appAPI.SaveCardPreviewPost(previewPost); err != nil {
+			b.logger.Error("Cannot save card preview post", mlog.String("post_id", post.Id), mlog.Err(err))
+		}
+		return
+	}
+
+	if oldPost != nil && linkedCardID(oldPost) != "" {
+		b.postDeleted(post)
+	}
+}
+
+// postDeleted forgets the card previewed by a post.
+func (b *cardPreviewsBackend) postDeleted(post *mm_model.Post) {
+	if err := b.appAPI.DeleteCardPreviewPost(post.Id); err != nil {
+		b.logger.Error("Cannot delete card preview post", mlog.String("post_id", post.Id), mlog.Err(err))
+	}
+}
+
+// BlockChanged renders again the previews of a card in the posts linking to
+// it when the card is updated or deleted.
+func (b *cardPreviewsBackend) BlockChanged(evt notify.BlockChangeEvent) error {
+	if evt.Action == notify.Add || evt.BlockChanged == nil || evt.BlockChanged.Type != model.TypeCard {
+		return nil
+	}
+
+	postIDs, err := b.appAPI.GetCardPreviewPostIDs(evt.BlockChanged.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, postID := range postIDs {
+		b.refreshPost(postID)
+	}
+	return nil
+}
+
+// BoardChanged renders again the previews of the cards of a board when the
+// board or its members change, as the board may have been made private,
+// unlinked from a channel or had its properties edited, and the authors of
+// the posts may have lost access to it.
+func (b *cardPreviewsBackend) BoardChanged(evt notify.BoardChangeEvent) error {
+	if evt.Board == nil {
+		return nil
+	}
+
+	postIDs, err := b.appAPI.GetCardPreviewPostIDsForBoard(evt.Board.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, postID := range postIDs {
+		b.refreshPost(postID)
+	}
+	return nil
+}
+
+func (b *cardPreviewsBackend) refreshPost(postID string) {
+	post, err := b.servicesAPI.GetPost(postID)
+	if err != nil {
+		b.logger.Warn("Cannot get post to refresh card preview", mlog.String("post_id", postID), mlog.Err(err))
+		return
+	}
+	if post.DeleteAt != 0 {
+		b.postDeleted(post)
+		return
+	}
+
+	oldEmbed := post.GetProp("boards")
+	post = b.embedPost(post.Clone())
+	if post.GetProp("boards") == oldEmbed {
+		return
+	}
+
+	if _, err := b.servicesAPI.UpdatePost(post); err != nil {
+		b.logger.Error("Cannot update card preview of post", mlog.String("post_id", postID), mlog.Err(err))
+		return
+	}
+	if linkedCardID(post) == "" {
+		b.postDeleted(post)
+	}
+}
+
+// linkedCardID returns the ID of the card linked by the boards embed of a
+// post, if any, whether the card is previewed or not.
+func linkedCardID(post *mm_model.Post) string {
+	data, ok := post.GetProp("boards").(string)
+	if !ok || data == "" {
+		return ""
+	}
+
+	var embed BoardsEmbed
+	if err := json.Unmarshal([]byte(data), &embed); err != nil {
+		return ""
+	}
+	return embed.CardID
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package boards
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	boardsModel "github.com/mattermost/mattermost-plugin-boards/server/model"
+	servicesMocks "github.com/mattermost/mattermost-plugin-boards/server/model/mocks"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store/mockstore"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+type testCardPreviewApp struct {
+	appIface
+	preview *boardsModel.CardPreview
+}
+
+func (a *testCardPreviewApp) GetCardPreview(cardID string) (*boardsModel.CardPreview, error) {
+	if a.preview == nil || a.preview.CardID != cardID {
+		return nil, boardsModel.NewErrNotFound("card ID=" + cardID)
+	}
+	return a.preview, nil
+}
+
+func TestCardPreviewsBackend(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockstore.NewMockStore(ctrl)
+	servicesAPI := servicesMocks.NewMockServicesAPI(ctrl)
+	permissions := &testCommandPermissions{allowed: true}
+	app := &testCardPreviewApp{
+		preview: &boardsModel.CardPreview{CardID: "card-id", BoardID: "board-id", TeamID: "team-id", Title: "Fix login"},
+	}
+	logger, _ := mlog.NewLogger()
+
+	backend := &cardPreviewsBackend{
+		appAPI:      &appAPI{store: store, app: app},
+		servicesAPI: servicesAPI,
+		permissions: permissions,
+		logger:      logger,
+	}
+
+	cardChanged := notify.BlockChangeEvent{
+		Action:       notify.Update,
+		BlockChanged: &boardsModel.Block{ID: "card-id", Type: boardsModel.TypeCard},
+	}
+	cardLink := "https://mattermost.example.com/boards/team/team-id/board-id/view-id/card-id"
+	newPost := func(channelID string) *model.Post {
+		return &model.Post{Id: "post-id", UserId: "user-id", ChannelId: channelID, Message: cardLink}
+	}
+	embedOf := func(post *model.Post) BoardsEmbed {
+		var embed BoardsEmbed
+		require.NoError(t, json.Unmarshal([]byte(post.GetProp("boards").(string)), &embed))
+		return embed
+	}
+
+	openBoard := &boardsModel.Board{ID: "board-id", TeamID: "team-id", Type: boardsModel.BoardTypeOpen}
+	privateBoard := &boardsModel.Board{ID: "board-id", TeamID: "team-id", Type: boardsModel.BoardTypePrivate, ChannelID: "linked-channel"}
+
+	testCases := []struct {
+		name        string
+		board       *boardsModel.Board
+		channel     *model.Channel
+		allowed     bool
+		withPreview bool
+	}{
+		{"open board in a channel of the team", openBoard, &model.Channel{Id: "channel-id", TeamId: "team-id"}, true, true},
+		{"open board in a channel of another team", openBoard, &model.Channel{Id: "channel-id", TeamId: "other-team"}, true, false},
+		{"private board in its linked channel", privateBoard, &model.Channel{Id: "linked-channel", TeamId: "team-id"}, true, true},
+		{"private board in another channel", privateBoard, &model.Channel{Id: "channel-id", TeamId: "team-id"}, true, false},
+		{"author without access", openBoard, &model.Channel{Id: "channel-id", TeamId: "team-id"}, false, false},
+	}
+
+	var board *boardsModel.Board
+	var channel *model.Channel
+	store.EXPECT().GetBoard("board-id").DoAndReturn(func(string) (*boardsModel.Board, error) {
+		return board, nil
+	}).AnyTimes()
+	servicesAPI.EXPECT().GetChannelByID(gomock.Any()).DoAndReturn(func(string) (*model.Channel, error) {
+		return channel, nil
+	}).AnyTimes()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			permissions.allowed = tc.allowed
+			board, channel = tc.board, tc.channel
+
+			post := backend.embedPost(newPost(tc.channel.Id))
+			embed := embedOf(post)
+			require.Equal(t, "card-id", embed.CardID)
+			require.Equal(t, "card-id", linkedCardID(post))
+			if tc.withPreview {
+				require.Equal(t, app.preview, embed.Preview)
+			} else {
+				require.Nil(t, embed.Preview)
+			}
+		})
+	}
+
+	t.Run("refresh the preview of a changed card", func(t *testing.T) {
+		permissions.allowed = true
+		board, channel = privateBoard, &model.Channel{Id: "linked-channel", TeamId: "team-id"}
+		post := backend.embedPost(newPost(channel.Id))
+
+		app.preview = &boardsModel.CardPreview{CardID: "card-id", BoardID: "board-id", TeamID: "team-id", Title: "Fix the login page"}
+		store.EXPECT().GetCardPreviewPostIDs("card-id").Return([]string{post.Id}, nil)
+		servicesAPI.EXPECT().GetPost(post.Id).Return(post, nil)
+		servicesAPI.EXPECT().UpdatePost(gomock.Any()).DoAndReturn(func(updated *model.Post) (*model.Post, error) {
+			require.Equal(t, "Fix the login page", embedOf(updated).Preview.Title)
+			return updated, nil
+		})
+
+		err := backend.BlockChanged(cardChanged)
+		require.NoError(t, err)
+	})
+
+	t.Run("hide the preview when the board is made private", func(t *testing.T) {
+		permissions.allowed = true
+		board, channel = openBoard, &model.Channel{Id: "channel-id", TeamId: "team-id"}
+		post := backend.embedPost(newPost(channel.Id))
+		require.NotNil(t, embedOf(post).Preview)
+
+		board = &boardsModel.Board{ID: "board-id", TeamID: "team-id", Type: boardsModel.BoardTypePrivate}
+		store.EXPECT().GetCardPreviewPostIDsForBoard("board-id").Return([]string{post.Id}, nil)
+		servicesAPI.EXPECT().GetPost(post.Id).Return(post, nil)
+		servicesAPI.EXPECT().UpdatePost(gomock.Any()).DoAndReturn(func(updated *model.Post) (*model.Post, error) {
+			require.Nil(t, embedOf(updated).Preview)
+			return updated, nil
+		})
+
+		err := backend.BoardChanged(notify.BoardChangeEvent{TeamID: "team-id", Board: board})
+		require.NoError(t, err)
+	})
+
+	t.Run("forget deleted posts", func(t *testing.T) {
+		store.EXPECT().GetCardPreviewPostIDs("card-id").Return([]string{"deleted-id"}, nil)
+		servicesAPI.EXPECT().GetPost("deleted-id").Return(&model.Post{Id: "deleted-id", DeleteAt: 1}, nil)
+		store.EXPECT().DeleteCardPreviewPost("deleted-id").Return(nil)
+
+		err := backend.BlockChanged(cardChanged)
+		require.NoError(t, err)
+	})
+}
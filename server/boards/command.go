@@ -0,0 +1,482 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package boards
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/permissions"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	commandTrigger = "boards"
+
+	// maxCommandCards is the number of cards listed by `/boards mine`.
+	maxCommandCards = 20
+
+	commandHelpText = "###### Boards slash command\n" +
+		"- `/boards add <title> [property=value ...]` - add a card to the board linked to this channel\n" +
+		"- `/boards mine` - list the cards assigned to you in this team\n" +
+		"- `/boards move <card> <status>` - change the status of a card of the board linked to this channel\n" +
+		"- `/boards subscribe [board]` - notify this channel of the changes to a board\n" +
+		"Use quotes for values with spaces, e.g. `/boards add \"Fix login\" status=Doing assignee=@alice`, " +
+		"and `--board <board>` to use a board other than the one linked to this channel."
+)
+
+// commandAppIface provides the app APIs used by the slash command.
+type commandAppIface interface {
+	GetBoardsForUserAndTeam(userID, teamID string, includePublicBoards bool) ([]*model.Board, error)
+	GetCardsForBoard(boardID string, page int, perPage int) ([]*model.Card, error)
+	GetCardByID(cardID string) (*model.Card, error)
+	GetBoard(boardID string) (*model.Board, error)
+	CreateCard(card *model.Card, boardID string, userID string, disableNotify bool) (*model.Card, error)
+	PatchCard(cardPatch *model.CardPatch, cardID string, userID string, disableNotify bool) (*model.Card, error)
+	CardPropertiesFromText(boardID string, values map[string]string) (map[string]any, error)
+	CreateSubscription(sub *model.Subscription) (*model.Subscription, error)
+	GetChannel(teamID, channelID string) (*mm_model.Channel, error)
+}
+
+// boardsCommand executes the `/boards` slash command, which creates and
+// queries cards from a channel.
+type boardsCommand struct {
+	app         commandAppIface
+	permissions permissions.PermissionsService
+	serverRoot  string
+	logger      mlog.LoggerIFace
+}
+
+// commandArgs are the arguments of a subcommand: the positional arguments,
+// the property=value pairs and the --board option.
+type commandArgs struct {
+	positional []string
+	properties map[string]string
+	board      string
+}
+
+func createBoardsCommand() *mm_model.Command {
+	autocomplete := mm_model.NewAutocompleteData(commandTrigger, "[command]", "Create and find cards from this channel")
+
+	add := mm_model.NewAutocompleteData("add", "<title> [property=value ...]", "Add a card to the board linked to this channel")
+	add.AddTextArgument("Title of the card, followed by property values", "<title> [property=value ...]", "")
+	autocomplete.AddCommand(add)
+
+	autocomplete.AddCommand(mm_model.NewAutocompleteData("mine", "", "List the cards assigned to you"))
+
+	move := mm_model.NewAutocompleteData("move", "<card> <status>", "Change the status of a card")
+	move.AddTextArgument("Title or ID of the card, followed by the new status", "<card> <status>", "")
+	autocomplete.AddCommand(move)
+
+	subscribe := mm_model.NewAutocompleteData("subscribe", "[board]", "Notify this channel of the changes to a board")
+	subscribe.AddTextArgument("Title of the board, the board linked to this channel by default", "[board]", "")
+	autocomplete.AddCommand(subscribe)
+
+	autocomplete.AddCommand(mm_model.NewAutocompleteData("help", "", "Show the available commands"))
+
+	return &mm_model.Command{
+		Trigger:          commandTrigger,
+		DisplayName:      "Boards",
+		Description:      "Create and find cards from a channel.",
+		AutoComplete:     true,
+		AutoCompleteDesc: "Available commands: add, mine, move, subscribe, help",
+		AutoCompleteHint: "[command]",
+		AutocompleteData: autocomplete,
+	}
+}
+
+// Execute runs a `/boards` command and returns the response shown to the user.
+func (c *boardsCommand) Execute(args *mm_model.CommandArgs) *mm_model.CommandResponse {
+	tokens := splitCommandArgs(args.Command)
+	if len(tokens) > 0 && strings.TrimPrefix(tokens[0], "/") == commandTrigger {
+		tokens = tokens[1:]
+	}
+	if len(tokens) == 0 {
+		return ephemeralResponse(commandHelpText)
+	}
+
+	subcommand := strings.ToLower(tokens[0])
+	cmdArgs := parseCommandArgs(tokens[1:])
+
+	var text string
+	var err error
+	responseType := mm_model.CommandResponseTypeEphemeral
+	switch subcommand {
+	case "add":
+		text, err = c.executeAdd(args, cmdArgs)
+	case "mine":
+		text, err = c.executeMine(args)
+	case "move":
+		text, err = c.executeMove(args, cmdArgs)
+	case "subscribe":
+		text, err = c.executeSubscribe(args, cmdArgs)
+		responseType = mm_model.CommandResponseTypeInChannel
+	case "help":
+		text = commandHelpText
+	default:
+		err = model.NewErrBadRequest(fmt.Sprintf("unknown command %q, see `/boards help`", subcommand))
+	}
+
+	if err != nil {
+		return ephemeralResponse(c.errorText(subcommand, args, err))
+	}
+	return &mm_model.CommandResponse{
+		ResponseType: responseType,
+		Text:         text,
+	}
+}
+
+func (c *boardsCommand) executeAdd(args *mm_model.CommandArgs, cmdArgs commandArgs) (string, error) {
+	title := strings.Join(cmdArgs.positional, " ")
+	if title == "" {
+		return "", model.NewErrBadRequest("missing card title, e.g. `/boards add \"Fix login\" status=Doing`")
+	}
+
+	board, err := c.commandBoard(args, cmdArgs.board)
+	if err != nil {
+		return "", err
+	}
+	if !c.permissions.HasPermissionToBoard(args.UserId, board.ID, model.PermissionManageBoardCards) {
+		return "", model.NewErrPermission("access denied to create card")
+	}
+
+	properties, err := c.app.CardPropertiesFromText(board.ID, cmdArgs.properties)
+	if err != nil {
+		return "", err
+	}
+
+	card := &model.Card{
+		Title:      title,
+		Properties: properties,
+	}
+	card.PopulateWithBoardID(board.ID)
+	if err = card.CheckValid(); err != nil {
+		return "", model.NewErrBadRequest(err.Error())
+	}
+
+	card, err = c.app.CreateCard(card, board.ID, args.UserId, false)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Added the card %s to the board **%s**.", c.cardLink(board, card), board.Title), nil
+}
+
+func (c *boardsCommand) executeMine(args *mm_model.CommandArgs) (string, error) {
+	boards, err := c.app.GetBoardsForUserAndTeam(args.UserId, args.TeamId, false)
+	if err != nil {
+		return "", err
+	}
+	sort.Slice(boards, func(i, j int) bool {
+		return strings.ToLower(boards[i].Title) < strings.ToLower(boards[j].Title)
+	})
+
+	var lines []string
+	count := 0
+	for _, board := range boards {
+		if board.IsTemplate {
+			continue
+		}
+		schema, err := model.ParsePropertySchema(board)
+		if err != nil {
+			return "", err
+		}
+
+		cards, err := c.app.GetCardsForBoard(board.ID, 0, 0)
+		if err != nil {
+			return "", err
+		}
+		for _, card := range cards {
+			if card.IsTemplate || !isAssignedTo(card, schema, args.UserId) {
+				continue
+			}
+			count++
+			if count <= maxCommandCards {
+				lines = append(lines, fmt.Sprintf("- %s on **%s**", c.cardLink(board, card), board.Title))
+			}
+		}
+	}
+
+	if count == 0 {
+		return "No cards are assigned to you.", nil
+	}
+	text := "###### Cards assigned to you\n" + strings.Join(lines, "\n")
+	if count > maxCommandCards {
+		text += fmt.Sprintf("\n...and %d more.", count-maxCommandCards)
+	}
+	return text, nil
+}
+
+func (c *boardsCommand) executeMove(args *mm_model.CommandArgs, cmdArgs commandArgs) (string, error) {
+	if len(cmdArgs.positional) < 2 {
+		return "", model.NewErrBadRequest("missing card or status, e.g. `/boards move \"Fix login\" Done`")
+	}
+	cardRef := cmdArgs.positional[0]
+	status := strings.Join(cmdArgs.positional[1:], " ")
+
+	board, card, err := c.commandCard(args, cmdArgs.board, cardRef)
+	if err != nil {
+		return "", err
+	}
+	if !c.permissions.HasPermissionToBoard(args.UserId, board.ID, model.PermissionManageBoardCards) {
+		return "", model.NewErrPermission("access denied to modify card")
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return "", err
+	}
+	statusProp, ok := findStatusProperty(schema)
+	if !ok {
+		return "", model.NewErrBadRequest(fmt.Sprintf("the board **%s** has no status property", board.Title))
+	}
+
+	properties, err := c.app.CardPropertiesFromText(board.ID, map[string]string{statusProp.Name: status})
+	if err != nil {
+		return "", err
+	}
+
+	patch := &model.CardPatch{UpdatedProperties: properties}
+	if _, err = c.app.PatchCard(patch, card.ID, args.UserId, false); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Moved the card %s to **%s**.", c.cardLink(board, card), status), nil
+}
+
+func (c *boardsCommand) executeSubscribe(args *mm_model.CommandArgs, cmdArgs commandArgs) (string, error) {
+	boardTitle := cmdArgs.board
+	if boardTitle == "" {
+		boardTitle = strings.Join(cmdArgs.positional, " ")
+	}
+
+	board, err := c.commandBoard(args, boardTitle)
+	if err != nil {
+		return "", err
+	}
+	// the channel members may not be members of the board, so subscribing a
+	// channel takes the same permission as linking the board to a channel.
+	if !c.permissions.HasPermissionToBoard(args.UserId, board.ID, model.PermissionManageBoardRoles) {
+		return "", model.NewErrPermission("access denied to subscribe a channel to the board")
+	}
+	// the changes are only posted to the channel while all of its members
+	// can view the board.
+	if board.ChannelID != args.ChannelId {
+		channel, err := c.app.GetChannel(args.TeamId, args.ChannelId)
+		if err != nil {
+			return "", err
+		}
+		if !board.IsVisibleToChannel(channel) {
+			return "", model.NewErrBadRequest(fmt.Sprintf("the board **%s** is private and not linked to this channel", board.Title))
+		}
+	}
+
+	sub := &model.Subscription{
+		BlockType:      model.TypeBoard,
+		BlockID:        board.ID,
+		SubscriberType: model.SubTypeChannel,
+		SubscriberID:   args.ChannelId,
+	}
+	if _, err = c.app.CreateSubscription(sub); err != nil {
+		return "", err
+	}
+
+	link := fmt.Sprintf("[%s](%s)", board.Title, utils.MakeBoardLink(c.serverRoot, board.TeamID, board.ID))
+	return fmt.Sprintf("This channel is now subscribed to the changes of the board %s.", link), nil
+}
+
+// commandBoard returns the board with the given title, or the board linked to
+// the channel of the command when no title is given.
+func (c *boardsCommand) commandBoard(args *mm_model.CommandArgs, title string) (*model.Board, error) {
+	boards, err := c.app.GetBoardsForUserAndTeam(args.UserId, args.TeamId, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []*model.Board
+	for _, board := range boards {
+		if board.IsTemplate {
+			continue
+		}
+		if title != "" && strings.EqualFold(board.Title, title) {
+			found = append(found, board)
+		}
+		if title == "" && board.ChannelID == args.ChannelId {
+			found = append(found, board)
+		}
+	}
+
+	switch {
+	case len(found) == 1:
+		return found[0], nil
+	case title != "" && len(found) == 0:
+		return nil, model.NewErrNotFound(fmt.Sprintf("board %q", title))
+	case title != "":
+		return nil, model.NewErrBadRequest(fmt.Sprintf("several boards are named %q", title))
+	case len(found) == 0:
+		return nil, model.NewErrBadRequest("no board is linked to this channel, use `--board <board>`")
+	}
+
+	titles := make([]string, 0, len(found))
+	for _, board := range found {
+		titles = append(titles, fmt.Sprintf("%q", board.Title))
+	}
+	sort.Strings(titles)
+	return nil, model.NewErrBadRequest("several boards are linked to this channel, use `--board <board>` with one of " +
+		strings.Join(titles, ", "))
+}
+
+// commandCard returns a card from its id, or from its title on the board of
+// the command.
+func (c *boardsCommand) commandCard(args *mm_model.CommandArgs, boardTitle string, cardRef string) (*model.Board, *model.Card, error) {
+	if card, err := c.app.GetCardByID(cardRef); err == nil {
+		if !c.permissions.HasPermissionToBoard(args.UserId, card.BoardID, model.PermissionViewBoard) {
+			return nil, nil, model.NewErrPermission("access denied to card")
+		}
+		board, err := c.app.GetBoard(card.BoardID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return board, card, nil
+	} else if !model.IsErrNotFound(err) {
+		return nil, nil, err
+	}
+
+	board, err := c.commandBoard(args, boardTitle)
+	if err != nil {
+		return nil, nil, err
+	}
+	cards, err := c.app.GetCardsForBoard(board.ID, 0, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var found *model.Card
+	for _, card := range cards {
+		if card.IsTemplate || !strings.EqualFold(card.Title, cardRef) {
+			continue
+		}
+		if found != nil {
+			return nil, nil, model.NewErrBadRequest(fmt.Sprintf("several cards are named %q, use the card ID", cardRef))
+		}
+		found = card
+	}
+	if found == nil {
+		return nil, nil, model.NewErrNotFound(fmt.Sprintf("card %q on the board %q", cardRef, board.Title))
+	}
+	return board, found, nil
+}
+
+func (c *boardsCommand) cardLink(board *model.Board, card *model.Card) string {
+	title := card.Title
+	if title == "" {
+		title = "Untitled"
+	}
+	return fmt.Sprintf("[%s](%s)", title, utils.MakeCardLink(c.serverRoot, board.TeamID, board.ID, card.ID))
+}
+
+// errorText returns the message shown for an error; unexpected errors are
+// logged rather than shown.
+func (c *boardsCommand) errorText(subcommand string, args *mm_model.CommandArgs, err error) string {
+	if model.IsErrBadRequest(err) || model.IsErrNotFound(err) || model.IsErrForbidden(err) {
+		return "Error: " + err.Error()
+	}
+
+	c.logger.Error("Cannot execute boards command",
+		mlog.String("command", subcommand),
+		mlog.String("user_id", args.UserId),
+		mlog.String("channel_id", args.ChannelId),
+		mlog.Err(err),
+	)
+	return "An error occurred while executing the command."
+}
+
+func ephemeralResponse(text string) *mm_model.CommandResponse {
+	return &mm_model.CommandResponse{
+		ResponseType: mm_model.CommandResponseTypeEphemeral,
+		Text:         text,
+	}
+}
+
+// findStatusProperty returns the select property named "Status", or else the
+// first select property of a board.
+func findStatusProperty(schema model.PropSchema) (model.PropDef, bool) {
+	var first model.PropDef
+	found := false
+	for _, prop := range schema {
+		if prop.Type != model.PropTypeSelect {
+			continue
+		}
+		if strings.EqualFold(prop.Name, "status") {
+			return prop, true
+		}
+		if !found || prop.Index < first.Index {
+			first = prop
+			found = true
+		}
+	}
+	return first, found
+}
+
+func isAssignedTo(card *model.Card, schema model.PropSchema, userID string) bool {
+	for _, assignee := range model.CardAssignees(model.Card2Block(card), schema) {
+		if assignee == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// splitCommandArgs splits a command into words separated by spaces. Double
+// quotes group words, including within a word such as status="In progress".
+func splitCommandArgs(command string) []string {
+	var tokens []string
+	var sb strings.Builder
+	inQuotes := false
+	inToken := false
+
+	for _, r := range command {
+		switch {
+		case r == '"' || r == '“' || r == '”':
+			inQuotes = !inQuotes
+			inToken = true
+		case (r == ' ' || r == '\t' || r == '\n') && !inQuotes:
+			if inToken {
+				tokens = append(tokens, sb.String())
+				sb.Reset()
+				inToken = false
+			}
+		default:
+			sb.WriteRune(r)
+			inToken = true
+		}
+	}
+	if inToken {
+		tokens = append(tokens, sb.String())
+	}
+	return tokens
+}
+
+// parseCommandArgs sorts the words of a subcommand into positional arguments,
+// property=value pairs and the --board option.
+func parseCommandArgs(tokens []string) commandArgs {
+	args := commandArgs{properties: map[string]string{}}
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if token == "--board" && i+1 < len(tokens) {
+			args.board = tokens[i+1]
+			i++
+			continue
+		}
+		if name, value, ok := strings.Cut(token, "="); ok && name != "" {
+			args.properties[name] = value
+			continue
+		}
+		args.positional = append(args.positional, token)
+	}
+	return args
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package boards
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	boardsModel "github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+type testCommandApp struct {
+	commandAppIface
+	boards  []*boardsModel.Board
+	created *boardsModel.Card
+	subs    []*boardsModel.Subscription
+}
+
+func (a *testCommandApp) GetBoardsForUserAndTeam(userID, teamID string, includePublicBoards bool) ([]*boardsModel.Board, error) {
+	return a.boards, nil
+}
+
+func (a *testCommandApp) CardPropertiesFromText(boardID string, values map[string]string) (map[string]any, error) {
+	properties := map[string]any{}
+	for name, value := range values {
+		properties[name] = value
+	}
+	return properties, nil
+}
+
+func (a *testCommandApp) CreateCard(card *boardsModel.Card, boardID string, userID string, disableNotify bool) (*boardsModel.Card, error) {
+	card.ID = "card-id"
+	a.created = card
+	return card, nil
+}
+
+func (a *testCommandApp) CreateSubscription(sub *boardsModel.Subscription) (*boardsModel.Subscription, error) {
+	a.subs = append(a.subs, sub)
+	return sub, nil
+}
+
+func (a *testCommandApp) GetChannel(teamID, channelID string) (*model.Channel, error) {
+	return &model.Channel{Id: channelID, TeamId: teamID}, nil
+}
+
+type testCommandPermissions struct {
+	allowed bool
+}
+
+func (p *testCommandPermissions) HasPermissionTo(userID string, permission *model.Permission) bool {
+	return p.allowed
+}
+
+func (p *testCommandPermissions) HasPermissionToTeam(userID, teamID string, permission *model.Permission) bool {
+	return p.allowed
+}
+
+func (p *testCommandPermissions) HasPermissionToChannel(userID, channelID string, permission *model.Permission) bool {
+	return p.allowed
+}
+
+func (p *testCommandPermissions) HasPermissionToBoard(userID, boardID string, permission *model.Permission) bool {
+	return p.allowed
+}
+
+func TestSplitCommandArgs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		command  string
+		expected []string
+	}{
+		{"empty", "  ", nil},
+		{"words", "/boards mine", []string{"/boards", "mine"}},
+		{"quoted", `/boards add "Fix login" status=Doing`, []string{"/boards", "add", "Fix login", "status=Doing"}},
+		{"quoted value", `add card status="In progress"`, []string{"add", "card", "status=In progress"}},
+		{"smart quotes", "add “Fix login”", []string{"add", "Fix login"}},
+		{"empty quotes", `add ""`, []string{"add", ""}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, splitCommandArgs(tc.command))
+		})
+	}
+}
+
+func TestParseCommandArgs(t *testing.T) {
+	args := parseCommandArgs([]string{"Fix", "login", "status=Doing", "--board", "Roadmap", "assignee=@alice", "=x"})
+	assert.Equal(t, []string{"Fix", "login", "=x"}, args.positional)
+	assert.Equal(t, map[string]string{"status": "Doing", "assignee": "@alice"}, args.properties)
+	assert.Equal(t, "Roadmap", args.board)
+}
+
+func TestFindStatusProperty(t *testing.T) {
+	t.Run("named status", func(t *testing.T) {
+		schema := boardsModel.PropSchema{
+			"priority": {ID: "priority", Index: 0, Name: "Priority", Type: boardsModel.PropTypeSelect},
+			"status":   {ID: "status", Index: 1, Name: "Status", Type: boardsModel.PropTypeSelect},
+		}
+		prop, ok := findStatusProperty(schema)
+		require.True(t, ok)
+		assert.Equal(t, "status", prop.ID)
+	})
+
+	t.Run("first select property", func(t *testing.T) {
+		schema := boardsModel.PropSchema{
+			"stage":    {ID: "stage", Index: 2, Name: "Stage", Type: boardsModel.PropTypeSelect},
+			"priority": {ID: "priority", Index: 1, Name: "Priority", Type: boardsModel.PropTypeSelect},
+			"owner":    {ID: "owner", Index: 0, Name: "Status", Type: boardsModel.PropTypePerson},
+		}
+		prop, ok := findStatusProperty(schema)
+		require.True(t, ok)
+		assert.Equal(t, "priority", prop.ID)
+	})
+
+	t.Run("no select property", func(t *testing.T) {
+		_, ok := findStatusProperty(boardsModel.PropSchema{})
+		assert.False(t, ok)
+	})
+}
+
+func TestBoardsCommandExecute(t *testing.T) {
+	linked := &boardsModel.Board{ID: "board-id", TeamID: "team-id", Title: "Roadmap", ChannelID: "channel-id"}
+	other := &boardsModel.Board{ID: "other-id", TeamID: "team-id", Title: "Other"}
+	args := &model.CommandArgs{UserId: "user-id", TeamId: "team-id", ChannelId: "channel-id"}
+
+	newCommand := func(allowed bool) (*boardsCommand, *testCommandApp) {
+		app := &testCommandApp{boards: []*boardsModel.Board{linked, other}}
+		logger, _ := mlog.NewLogger()
+		return &boardsCommand{
+			app:         app,
+			permissions: &testCommandPermissions{allowed: allowed},
+			serverRoot:  "http://localhost/boards",
+			logger:      logger,
+		}, app
+	}
+
+	t.Run("help", func(t *testing.T) {
+		cmd, _ := newCommand(true)
+		args.Command = "/boards"
+		resp := cmd.Execute(args)
+		assert.Equal(t, model.CommandResponseTypeEphemeral, resp.ResponseType)
+		assert.Equal(t, commandHelpText, resp.Text)
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		cmd, _ := newCommand(true)
+		args.Command = "/boards foo"
+		resp := cmd.Execute(args)
+		assert.Contains(t, resp.Text, `unknown command "foo"`)
+	})
+
+	t.Run("add to the linked board", func(t *testing.T) {
+		cmd, app := newCommand(true)
+		args.Command = `/boards add "Fix login" status=Doing`
+		resp := cmd.Execute(args)
+		require.NotNil(t, app.created)
+		assert.Equal(t, "Fix login", app.created.Title)
+		assert.Equal(t, "board-id", app.created.BoardID)
+		assert.Equal(t, map[string]any{"status": "Doing"}, app.created.Properties)
+		assert.Contains(t, resp.Text, "Added the card [Fix login](http://localhost/boards/team/team-id/board-id/0/card-id)")
+	})
+
+	t.Run("add to another board", func(t *testing.T) {
+		cmd, app := newCommand(true)
+		args.Command = `/boards add Docs --board other`
+		cmd.Execute(args)
+		require.NotNil(t, app.created)
+		assert.Equal(t, "other-id", app.created.BoardID)
+	})
+
+	t.Run("add without permission", func(t *testing.T) {
+		cmd, app := newCommand(false)
+		args.Command = `/boards add "Fix login"`
+		resp := cmd.Execute(args)
+		assert.Nil(t, app.created)
+		assert.Contains(t, resp.Text, "access denied")
+	})
+
+	t.Run("subscribe the channel", func(t *testing.T) {
+		cmd, app := newCommand(true)
+		args.Command = "/boards subscribe"
+		resp := cmd.Execute(args)
+		assert.Equal(t, model.CommandResponseTypeInChannel, resp.ResponseType)
+		require.Len(t, app.subs, 1)
+		assert.Equal(t, "board-id", app.subs[0].BlockID)
+		assert.Equal(t, boardsModel.SubscriberType(boardsModel.SubTypeChannel), app.subs[0].SubscriberType)
+		assert.Equal(t, "channel-id", app.subs[0].SubscriberID)
+	})
+
+	t.Run("subscribe another channel to a private board", func(t *testing.T) {
+		cmd, app := newCommand(true)
+		resp := cmd.Execute(&model.CommandArgs{UserId: "user-id", TeamId: "team-id", ChannelId: "town-square", Command: "/boards subscribe Roadmap"})
+		assert.Empty(t, app.subs)
+		assert.Contains(t, resp.Text, "is private and not linked to this channel")
+	})
+
+	t.Run("subscribe another channel to an open board", func(t *testing.T) {
+		cmd, app := newCommand(true)
+		app.boards = []*boardsModel.Board{{ID: "open-id", TeamID: "team-id", Title: "Open", Type: boardsModel.BoardTypeOpen}}
+		cmd.Execute(&model.CommandArgs{UserId: "user-id", TeamId: "team-id", ChannelId: "town-square", Command: "/boards subscribe Open"})
+		require.Len(t, app.subs, 1)
+		assert.Equal(t, "town-square", app.subs[0].SubscriberID)
+	})
+
+	t.Run("no linked board", func(t *testing.T) {
+		cmd, app := newCommand(true)
+		resp := cmd.Execute(&model.CommandArgs{UserId: "user-id", TeamId: "team-id", ChannelId: "town-square", Command: "/boards subscribe"})
+		assert.Empty(t, app.subs)
+		assert.Contains(t, resp.Text, "no board is linked to this channel")
+	})
+}
@@ -16,16 +16,7 @@ func (b *BoardsApp) RunDataRetention(nowTime, batchSize int64) (int64, error) {
 		return 0, ErrInsufficientLicense
 	}
 
-	if b.server.Config().EnableDataRetention {
-		boardsRetentionDays := b.server.Config().DataRetentionDays
-		endTimeBoards := convertDaysToCutoff(boardsRetentionDays, time.Unix(nowTime/1000, 0))
-		return b.server.Store().RunDataRetention(endTimeBoards, batchSize)
-	}
-	return 0, nil
-}
-
-func convertDaysToCutoff(days int, now time.Time) int64 {
-	upToStartOfDay := now.AddDate(0, 0, -days)
-	cutoffDate := time.Date(upToStartOfDay.Year(), upToStartOfDay.Month(), upToStartOfDay.Day(), 0, 0, 0, 0, time.Local)
-	return cutoffDate.UnixNano() / int64(time.Millisecond)
+	// the global setting applies to the boards without a team or board
+	// retention policy
+	return b.server.App().RunDataRetention(time.Unix(nowTime/1000, 0), batchSize)
 }
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	boardsModel "github.com/mattermost/mattermost-plugin-boards/server/model"
 	"github.com/mattermost/mattermost-plugin-boards/server/server"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/config"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/permissions/localpermissions"
@@ -117,6 +118,7 @@ func TestRunDataRetention(t *testing.T) {
 					DataRetention: &trueValue,
 				},
 			})
+		th.Store.EXPECT().GetRetentionPolicies().Return([]*boardsModel.RetentionPolicy{}, nil)
 
 		count, err := b.RunDataRetention(now, 10)
 		assert.Nil(t, err)
@@ -132,6 +134,7 @@ func TestRunDataRetention(t *testing.T) {
 				},
 			})
 
+		th.Store.EXPECT().GetRetentionPolicies().Return([]*boardsModel.RetentionPolicy{}, nil)
 		th.Store.EXPECT().RunDataRetention(gomock.Any(), int64(10)).Return(int64(100), nil)
 		b.server.Config().EnableDataRetention = true
 
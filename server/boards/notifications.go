@@ -9,12 +9,16 @@ import (
 
 	"github.com/mattermost/mattermost-plugin-boards/server/model"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/config"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify/notifychannelfeed"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/notify/notifymentions"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify/notifyreminders"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify/notifyrules"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/notify/notifysubscriptions"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/notify/plugindelivery"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/permissions"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
 
+	mm_model "github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
 
@@ -65,6 +69,55 @@ func createSubscriptionsNotifyBackend(params notifyBackendParams) (*notifysubscr
 	return backend, nil
 }
 
+func createRulesNotifyBackend(params notifyBackendParams) (*notifyrules.Backend, error) {
+	delivery, err := createDelivery(params.servicesAPI, params.serverRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	backendParams := notifyrules.BackendParams{
+		AppAPI:   params.appAPI,
+		Delivery: delivery,
+		Logger:   params.logger,
+	}
+	backend := notifyrules.New(backendParams)
+
+	return backend, nil
+}
+
+func createRemindersNotifyBackend(params notifyBackendParams) (*notifyreminders.Backend, error) {
+	delivery, err := createDelivery(params.servicesAPI, params.serverRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	backendParams := notifyreminders.BackendParams{
+		AppAPI:   params.appAPI,
+		Delivery: delivery,
+		Logger:   params.logger,
+	}
+	backend := notifyreminders.New(backendParams)
+
+	return backend, nil
+}
+
+func createChannelFeedNotifyBackend(params notifyBackendParams) (*notifychannelfeed.Backend, error) {
+	delivery, err := createDelivery(params.servicesAPI, params.serverRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	backendParams := notifychannelfeed.BackendParams{
+		AppAPI:     params.appAPI,
+		Delivery:   delivery,
+		ServerRoot: params.serverRoot,
+		Logger:     params.logger,
+	}
+	backend := notifychannelfeed.New(backendParams)
+
+	return backend, nil
+}
+
 func createDelivery(servicesAPI model.ServicesAPI, serverRoot string) (*plugindelivery.PluginDelivery, error) {
 	bot := model.FocalboardBot
 
@@ -79,6 +132,9 @@ func createDelivery(servicesAPI model.ServicesAPI, serverRoot string) (*pluginde
 type appIface interface {
 	CreateSubscription(sub *model.Subscription) (*model.Subscription, error)
 	AddMemberToBoard(member *model.BoardMember) (*model.BoardMember, error)
+	MoveCardToBoard(cardID string, boardID string, userID string) error
+	InsertBlockAndNotify(block *model.Block, modifiedByID string, disableNotify bool) error
+	GetCardPreview(cardID string) (*model.CardPreview, error)
 }
 
 // appAPI provides app and store APIs for notification services. Where appropriate calls are made to the
@@ -102,6 +158,10 @@ func (a *appAPI) GetBlockHistoryNewestChildren(parentID string, opts model.Query
 	return a.store.GetBlockHistoryNewestChildren(parentID, opts)
 }
 
+func (a *appAPI) GetBlockHistoryDescendants(boardID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error) {
+	return a.store.GetBlockHistoryDescendants(boardID, opts)
+}
+
 func (a *appAPI) GetBoardAndCardByID(blockID string) (board *model.Board, card *model.Block, err error) {
 	return a.store.GetBoardAndCardByID(blockID)
 }
@@ -110,6 +170,14 @@ func (a *appAPI) GetUserByID(userID string) (*model.User, error) {
 	return a.store.GetUserByID(userID)
 }
 
+func (a *appAPI) GetUserPreferences(userID string) (mm_model.Preferences, error) {
+	return a.store.GetUserPreferences(userID)
+}
+
+func (a *appAPI) GetSubscription(blockID string, subscriberID string) (*model.Subscription, error) {
+	return a.store.GetSubscription(blockID, subscriberID)
+}
+
 func (a *appAPI) CreateSubscription(sub *model.Subscription) (*model.Subscription, error) {
 	return a.app.CreateSubscription(sub)
 }
@@ -130,6 +198,18 @@ func (a *appAPI) GetNextNotificationHint(remove bool) (*model.NotificationHint,
 	return a.store.GetNextNotificationHint(remove)
 }
 
+func (a *appAPI) AddNotificationDigestItem(item *model.NotificationDigestItem) error {
+	return a.store.AddNotificationDigestItem(item)
+}
+
+func (a *appAPI) GetDueNotificationDigestUserIDs(notifyAt int64) ([]string, error) {
+	return a.store.GetDueNotificationDigestUserIDs(notifyAt)
+}
+
+func (a *appAPI) TakeNotificationDigestItems(userID string) ([]*model.NotificationDigestItem, error) {
+	return a.store.TakeNotificationDigestItems(userID)
+}
+
 func (a *appAPI) GetMemberForBoard(boardID, userID string) (*model.BoardMember, error) {
 	return a.store.GetMemberForBoard(boardID, userID)
 }
@@ -137,3 +217,83 @@ func (a *appAPI) GetMemberForBoard(boardID, userID string) (*model.BoardMember,
 func (a *appAPI) AddMemberToBoard(member *model.BoardMember) (*model.BoardMember, error) {
 	return a.app.AddMemberToBoard(member)
 }
+
+func (a *appAPI) GetBoardRulesForBoard(boardID string) ([]*model.BoardRule, error) {
+	return a.store.GetBoardRulesForBoard(boardID)
+}
+
+func (a *appAPI) MoveCardToBoard(cardID string, boardID string, userID string) error {
+	return a.app.MoveCardToBoard(cardID, boardID, userID)
+}
+
+func (a *appAPI) InsertBlockAndNotify(block *model.Block, modifiedByID string, disableNotify bool) error {
+	return a.app.InsertBlockAndNotify(block, modifiedByID, disableNotify)
+}
+
+func (a *appAPI) GetAllCardReminderSettings() ([]*model.CardReminderSettings, error) {
+	return a.store.GetAllCardReminderSettings()
+}
+
+func (a *appAPI) CreateCardReminder(reminder *model.CardReminder) (bool, error) {
+	return a.store.CreateCardReminder(reminder)
+}
+
+func (a *appAPI) DeleteCardRemindersDueBefore(dueAt int64) error {
+	return a.store.DeleteCardRemindersDueBefore(dueAt)
+}
+
+func (a *appAPI) GetBoard(boardID string) (*model.Board, error) {
+	return a.store.GetBoard(boardID)
+}
+
+func (a *appAPI) GetBlocksWithType(boardID, blockType string) ([]*model.Block, error) {
+	return a.store.GetBlocksWithType(boardID, blockType)
+}
+
+func (a *appAPI) GetMembersForBoard(boardID string) ([]*model.BoardMember, error) {
+	return a.store.GetMembersForBoard(boardID)
+}
+
+func (a *appAPI) GetUserTimezone(userID string) (string, error) {
+	return a.store.GetUserTimezone(userID)
+}
+
+func (a *appAPI) GetChannel(teamID, channelID string) (*mm_model.Channel, error) {
+	return a.store.GetChannel(teamID, channelID)
+}
+
+func (a *appAPI) GetChannelFeedSettings(boardID string) (*model.ChannelFeedSettings, error) {
+	return a.store.GetChannelFeedSettings(boardID)
+}
+
+func (a *appAPI) AddChannelFeedItem(item *model.ChannelFeedItem) error {
+	return a.store.AddChannelFeedItem(item)
+}
+
+func (a *appAPI) GetDueChannelFeedBoardIDs(notifyAt int64) ([]string, error) {
+	return a.store.GetDueChannelFeedBoardIDs(notifyAt)
+}
+
+func (a *appAPI) TakeChannelFeedItems(boardID string) ([]*model.ChannelFeedItem, error) {
+	return a.store.TakeChannelFeedItems(boardID)
+}
+
+func (a *appAPI) GetCardPreview(cardID string) (*model.CardPreview, error) {
+	return a.app.GetCardPreview(cardID)
+}
+
+func (a *appAPI) SaveCardPreviewPost(previewPost *model.CardPreviewPost) error {
+	return a.store.SaveCardPreviewPost(previewPost)
+}
+
+func (a *appAPI) GetCardPreviewPostIDs(cardID string) ([]string, error) {
+	return a.store.GetCardPreviewPostIDs(cardID)
+}
+
+func (a *appAPI) GetCardPreviewPostIDsForBoard(boardID string) ([]string, error) {
+	return a.store.GetCardPreviewPostIDsForBoard(boardID)
+}
+
+func (a *appAPI) DeleteCardPreviewPost(postID string) error {
+	return a.store.DeleteCardPreviewPost(postID)
+}
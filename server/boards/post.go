@@ -9,11 +9,17 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
 	mm_model "github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/markdown"
 )
 
-func postWithBoardsEmbed(post *mm_model.Post) *mm_model.Post {
+// cardPreviewFunc returns the preview of the card linked by a post, or nil
+// if the card cannot be previewed in the post.
+type cardPreviewFunc func(post *mm_model.Post, embed BoardsEmbed) *model.CardPreview
+
+func postWithBoardsEmbed(post *mm_model.Post, cardPreview cardPreviewFunc) *mm_model.Post {
 	if _, ok := post.GetProps()["boards"]; ok {
 		post.AddProp("boards", nil)
 	}
@@ -45,14 +51,18 @@ func postWithBoardsEmbed(post *mm_model.Post) *mm_model.Post {
 	teamID, boardID, viewID, cardID := returnBoardsParams(pathSplit)
 
 	if teamID != "" && boardID != "" && viewID != "" && cardID != "" {
-		b, _ := json.Marshal(BoardsEmbed{
+		embed := BoardsEmbed{
 			TeamID:       teamID,
 			BoardID:      boardID,
 			ViewID:       viewID,
 			CardID:       cardID,
 			ReadToken:    queryParams.Get("r"),
 			OriginalPath: u.RequestURI(),
-		})
+		}
+		if cardPreview != nil {
+			embed.Preview = cardPreview(post, embed)
+		}
+		b, _ := json.Marshal(embed)
 
 		BoardsPostEmbed := &mm_model.PostEmbed{
 			Type: mm_model.PostEmbedBoards,
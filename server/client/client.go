@@ -10,6 +10,8 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/mattermost/mattermost-plugin-boards/server/api"
@@ -346,6 +348,25 @@ func (c *Client) CreateCard(boardID string, card *model.Card, disableNotify bool
 	return cardNew, BuildResponse(r)
 }
 
+func (c *Client) CreateCardFromPost(boardID, postID string, opts model.CardFromPostOptions, disableNotify bool) (*model.Card, *Response) {
+	var queryParams string
+	if disableNotify {
+		queryParams = "?" + disableNotifyQueryParam
+	}
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/cards/from-post/"+postID+queryParams, toJSON(opts))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var cardNew *model.Card
+	if err := json.NewDecoder(r.Body).Decode(&cardNew); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return cardNew, BuildResponse(r)
+}
+
 func (c *Client) GetCards(boardID string, page int, perPage int) ([]*model.Card, *Response) {
 	url := fmt.Sprintf("%s/cards?page=%d&per_page=%d", c.GetBoardRoute(boardID), page, perPage)
 	r, err := c.DoAPIGet(url, "")
@@ -363,6 +384,38 @@ func (c *Client) GetCards(boardID string, page int, perPage int) ([]*model.Card,
 	return cards, BuildResponse(r)
 }
 
+func (c *Client) QueryCards(boardID string, query *model.CardQuery) ([]*model.Card, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/cards/query", toJSON(query))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	defer closeBody(r)
+
+	var cards []*model.Card
+	if err := json.NewDecoder(r.Body).Decode(&cards); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return cards, BuildResponse(r)
+}
+
+func (c *Client) GetViewCards(boardID, viewID string) (*model.ViewCards, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/views/%s/cards", c.GetBoardRoute(boardID), viewID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	defer closeBody(r)
+
+	var viewCards *model.ViewCards
+	if err := json.NewDecoder(r.Body).Decode(&viewCards); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return viewCards, BuildResponse(r)
+}
+
 func (c *Client) PatchCard(cardID string, cardPatch *model.CardPatch, disableNotify bool) (*model.Card, *Response) {
 	var queryParams string
 	if disableNotify {
@@ -399,6 +452,22 @@ func (c *Client) GetCard(cardID string) (*model.Card, *Response) {
 	return card, BuildResponse(r)
 }
 
+func (c *Client) GetCardPreview(cardID string) (*model.CardPreview, *Response) {
+	r, err := c.DoAPIGet(c.GetCardRoute(cardID)+"/preview", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	defer closeBody(r)
+
+	var preview *model.CardPreview
+	if err := json.NewDecoder(r.Body).Decode(&preview); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return preview, BuildResponse(r)
+}
+
 //
 // Boards and blocks.
 //
@@ -691,6 +760,22 @@ func (c *Client) SearchBoardsForTeam(teamID, term string) ([]*model.Board, *Resp
 	return model.BoardsFromJSON(r.Body), BuildResponse(r)
 }
 
+func (c *Client) SearchCards(teamID, term string, page, perPage int) ([]*model.CardSearchResult, *Response) {
+	query := fmt.Sprintf("q=%s&page=%d&per_page=%d", url.QueryEscape(term), page, perPage)
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/cards/search?"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var results []*model.CardSearchResult
+	if err := json.NewDecoder(r.Body).Decode(&results); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return results, BuildResponse(r)
+}
+
 func (c *Client) GetMembersForBoard(boardID string) ([]*model.BoardMember, *Response) {
 	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/members", "")
 	if err != nil {
@@ -802,24 +887,50 @@ func (c *Client) GetSubscriptionsRoute() string {
 	return "/subscriptions"
 }
 
-func (c *Client) CreateSubscription(sub *model.Subscription) (*model.Subscription, *Response) {
-	r, err := c.DoAPIPost(c.GetSubscriptionsRoute(), toJSON(&sub))
+func (c *Client) GetCardDependenciesForBoard(boardID string) ([]*model.CardDependency, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/dependencies", "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	subNew, err := model.SubscriptionFromJSON(r.Body)
+	var dependencies []*model.CardDependency
+	if err := json.NewDecoder(r.Body).Decode(&dependencies); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return dependencies, BuildResponse(r)
+}
+
+func (c *Client) GetCardDependencies(cardID string) ([]*model.CardDependency, *Response) {
+	r, err := c.DoAPIGet(c.GetCardRoute(cardID)+"/dependencies", "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
-	return subNew, BuildResponse(r)
+	defer closeBody(r)
+
+	var dependencies []*model.CardDependency
+	if err := json.NewDecoder(r.Body).Decode(&dependencies); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return dependencies, BuildResponse(r)
 }
 
-func (c *Client) DeleteSubscription(blockID string, subscriberID string) *Response {
-	url := fmt.Sprintf("%s/%s/%s", c.GetSubscriptionsRoute(), blockID, subscriberID)
+func (c *Client) CreateCardDependency(cardID string, dependency *model.CardDependency) (*model.CardDependency, *Response) {
+	r, err := c.DoAPIPost(c.GetCardRoute(cardID)+"/dependencies", toJSON(&dependency))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
 
-	r, err := c.DoAPIDelete(url, "")
+	newDependency, err := model.CardDependencyFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return newDependency, BuildResponse(r)
+}
+
+func (c *Client) DeleteCardDependency(cardID, dependencyID string) *Response {
+	r, err := c.DoAPIDelete(fmt.Sprintf("%s/dependencies/%s", c.GetCardRoute(cardID), dependencyID), "")
 	if err != nil {
 		return BuildErrorResponse(r, err)
 	}
@@ -828,65 +939,258 @@ func (c *Client) DeleteSubscription(blockID string, subscriberID string) *Respon
 	return BuildResponse(r)
 }
 
-func (c *Client) GetSubscriptions(subscriberID string) ([]*model.Subscription, *Response) {
-	url := fmt.Sprintf("%s/%s", c.GetSubscriptionsRoute(), subscriberID)
+func (c *Client) GetCardRecurrencesForBoard(boardID string) ([]*model.CardRecurrence, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/recurrences", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
 
-	r, err := c.DoAPIGet(url, "")
+	var recurrences []*model.CardRecurrence
+	if err := json.NewDecoder(r.Body).Decode(&recurrences); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return recurrences, BuildResponse(r)
+}
+
+func (c *Client) GetCardRecurrence(cardID string) (*model.CardRecurrence, *Response) {
+	r, err := c.DoAPIGet(c.GetCardRoute(cardID)+"/recurrence", "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	var subs []*model.Subscription
-	err = json.NewDecoder(r.Body).Decode(&subs)
+	recurrence, err := model.CardRecurrenceFromJSON(r.Body)
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
+	return recurrence, BuildResponse(r)
+}
 
-	return subs, BuildResponse(r)
+func (c *Client) SetCardRecurrence(cardID string, recurrence *model.CardRecurrence) (*model.CardRecurrence, *Response) {
+	r, err := c.DoAPIPut(c.GetCardRoute(cardID)+"/recurrence", toJSON(&recurrence))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	newRecurrence, err := model.CardRecurrenceFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return newRecurrence, BuildResponse(r)
 }
 
-func (c *Client) GetTemplatesForTeam(teamID string) ([]*model.Board, *Response) {
-	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/templates", "")
+func (c *Client) DeleteCardRecurrence(cardID string) *Response {
+	r, err := c.DoAPIDelete(c.GetCardRoute(cardID)+"/recurrence", "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetCardReminderSettings(boardID string) (*model.CardReminderSettings, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/reminder-settings", "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardsFromJSON(r.Body), BuildResponse(r)
+	settings, err := model.CardReminderSettingsFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return settings, BuildResponse(r)
 }
 
-func (c *Client) ExportBoardArchive(boardID string) ([]byte, *Response) {
-	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/archive/export", "")
+func (c *Client) SetCardReminderSettings(boardID string, settings *model.CardReminderSettings) (*model.CardReminderSettings, *Response) {
+	r, err := c.DoAPIPut(c.GetBoardRoute(boardID)+"/reminder-settings", toJSON(&settings))
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	buf, err := io.ReadAll(r.Body)
+	newSettings, err := model.CardReminderSettingsFromJSON(r.Body)
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
-	return buf, BuildResponse(r)
+	return newSettings, BuildResponse(r)
 }
 
-func (c *Client) ImportArchive(teamID string, data io.Reader) *Response {
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-	part, err := writer.CreateFormFile(api.UploadFormFileKey, "file")
+func (c *Client) DeleteCardReminderSettings(boardID string) *Response {
+	r, err := c.DoAPIDelete(c.GetBoardRoute(boardID)+"/reminder-settings", "")
 	if err != nil {
-		return &Response{Error: err}
+		return BuildErrorResponse(r, err)
 	}
-	if _, err = io.Copy(part, data); err != nil {
-		return &Response{Error: err}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetChannelFeedSettings(boardID string) (*model.ChannelFeedSettings, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/channel-feed", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
 	}
-	writer.Close()
+	defer closeBody(r)
 
-	opt := func(r *http.Request) {
-		r.Header.Add("Content-Type", writer.FormDataContentType())
+	settings, err := model.ChannelFeedSettingsFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
 	}
+	return settings, BuildResponse(r)
+}
 
-	r, err := c.doAPIRequestReader(http.MethodPost, c.APIURL+c.GetTeamRoute(teamID)+"/archive/import", body, "", opt)
+func (c *Client) SetChannelFeedSettings(boardID string, settings *model.ChannelFeedSettings) (*model.ChannelFeedSettings, *Response) {
+	r, err := c.DoAPIPut(c.GetBoardRoute(boardID)+"/channel-feed", toJSON(&settings))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	newSettings, err := model.ChannelFeedSettingsFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return newSettings, BuildResponse(r)
+}
+
+func (c *Client) DeleteChannelFeedSettings(boardID string) *Response {
+	r, err := c.DoAPIDelete(c.GetBoardRoute(boardID)+"/channel-feed", "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetBoardCustomRoles(boardID string) ([]*model.BoardCustomRole, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/custom-roles", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var roles []*model.BoardCustomRole
+	if err := json.NewDecoder(r.Body).Decode(&roles); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return roles, BuildResponse(r)
+}
+
+func (c *Client) CreateBoardCustomRole(boardID string, role *model.BoardCustomRole) (*model.BoardCustomRole, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/custom-roles", toJSON(&role))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	newRole, err := model.BoardCustomRoleFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return newRole, BuildResponse(r)
+}
+
+func (c *Client) UpdateBoardCustomRole(boardID string, role *model.BoardCustomRole) (*model.BoardCustomRole, *Response) {
+	r, err := c.DoAPIPut(fmt.Sprintf("%s/custom-roles/%s", c.GetBoardRoute(boardID), role.ID), toJSON(&role))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	updatedRole, err := model.BoardCustomRoleFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return updatedRole, BuildResponse(r)
+}
+
+func (c *Client) DeleteBoardCustomRole(boardID, roleID string) *Response {
+	r, err := c.DoAPIDelete(fmt.Sprintf("%s/custom-roles/%s", c.GetBoardRoute(boardID), roleID), "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetTimeEntriesForBoard(boardID string) ([]*model.TimeEntry, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/time-entries", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var entries []*model.TimeEntry
+	if err := json.NewDecoder(r.Body).Decode(&entries); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return entries, BuildResponse(r)
+}
+
+func (c *Client) GetBoardTimeSummary(boardID string) (*model.TimeSummary, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/time-summary", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var summary *model.TimeSummary
+	if err := json.NewDecoder(r.Body).Decode(&summary); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return summary, BuildResponse(r)
+}
+
+func (c *Client) GetTimeEntriesForCard(cardID string) ([]*model.TimeEntry, *Response) {
+	r, err := c.DoAPIGet(c.GetCardRoute(cardID)+"/time-entries", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var entries []*model.TimeEntry
+	if err := json.NewDecoder(r.Body).Decode(&entries); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return entries, BuildResponse(r)
+}
+
+func (c *Client) CreateTimeEntry(cardID string, entry *model.TimeEntry) (*model.TimeEntry, *Response) {
+	r, err := c.DoAPIPost(c.GetCardRoute(cardID)+"/time-entries", toJSON(&entry))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	newEntry, err := model.TimeEntryFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return newEntry, BuildResponse(r)
+}
+
+func (c *Client) UpdateTimeEntry(cardID string, entry *model.TimeEntry) (*model.TimeEntry, *Response) {
+	r, err := c.DoAPIPut(fmt.Sprintf("%s/time-entries/%s", c.GetCardRoute(cardID), entry.ID), toJSON(&entry))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	updatedEntry, err := model.TimeEntryFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return updatedEntry, BuildResponse(r)
+}
+
+func (c *Client) DeleteTimeEntry(cardID, entryID string) *Response {
+	r, err := c.DoAPIDelete(fmt.Sprintf("%s/time-entries/%s", c.GetCardRoute(cardID), entryID), "")
 	if err != nil {
 		return BuildErrorResponse(r, err)
 	}
@@ -895,6 +1199,876 @@ func (c *Client) ImportArchive(teamID string, data io.Reader) *Response {
 	return BuildResponse(r)
 }
 
+func (c *Client) GetCardTimeSummary(cardID string) (*model.TimeSummary, *Response) {
+	r, err := c.DoAPIGet(c.GetCardRoute(cardID)+"/time-summary", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var summary *model.TimeSummary
+	if err := json.NewDecoder(r.Body).Decode(&summary); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return summary, BuildResponse(r)
+}
+
+func (c *Client) StartTimer(cardID, note string) (*model.TimeEntry, *Response) {
+	r, err := c.DoAPIPost(c.GetCardRoute(cardID)+"/timer", toJSON(&model.TimeEntry{Note: note}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	entry, err := model.TimeEntryFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return entry, BuildResponse(r)
+}
+
+func (c *Client) StopTimer(cardID string) (*model.TimeEntry, *Response) {
+	r, err := c.DoAPIDelete(c.GetCardRoute(cardID)+"/timer", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	entry, err := model.TimeEntryFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return entry, BuildResponse(r)
+}
+
+func (c *Client) GetMyTimer() (*model.TimeEntry, *Response) {
+	r, err := c.DoAPIGet(c.GetMeRoute()+"/timer", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	entry, err := model.TimeEntryFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return entry, BuildResponse(r)
+}
+
+func (c *Client) GetIterations(boardID string) ([]*model.Iteration, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/iterations", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var iterations []*model.Iteration
+	if err := json.NewDecoder(r.Body).Decode(&iterations); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return iterations, BuildResponse(r)
+}
+
+func (c *Client) GetIteration(boardID, iterationID string) (*model.Iteration, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/iterations/%s", c.GetBoardRoute(boardID), iterationID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	iteration, err := model.IterationFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return iteration, BuildResponse(r)
+}
+
+func (c *Client) CreateIteration(boardID string, iteration *model.Iteration) (*model.Iteration, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/iterations", toJSON(&iteration))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	newIteration, err := model.IterationFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return newIteration, BuildResponse(r)
+}
+
+func (c *Client) UpdateIteration(boardID string, iteration *model.Iteration) (*model.Iteration, *Response) {
+	r, err := c.DoAPIPut(fmt.Sprintf("%s/iterations/%s", c.GetBoardRoute(boardID), iteration.ID), toJSON(&iteration))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	updatedIteration, err := model.IterationFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return updatedIteration, BuildResponse(r)
+}
+
+func (c *Client) DeleteIteration(boardID, iterationID string) *Response {
+	r, err := c.DoAPIDelete(fmt.Sprintf("%s/iterations/%s", c.GetBoardRoute(boardID), iterationID), "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) StartIteration(boardID, iterationID string) (*model.Iteration, *Response) {
+	r, err := c.DoAPIPost(fmt.Sprintf("%s/iterations/%s/start", c.GetBoardRoute(boardID), iterationID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	iteration, err := model.IterationFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return iteration, BuildResponse(r)
+}
+
+func (c *Client) CloseIteration(boardID, iterationID string) (*model.Iteration, *Response) {
+	r, err := c.DoAPIPost(fmt.Sprintf("%s/iterations/%s/close", c.GetBoardRoute(boardID), iterationID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	iteration, err := model.IterationFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return iteration, BuildResponse(r)
+}
+
+func (c *Client) MoveUnfinishedIterationCards(boardID, iterationID, targetIterationID string) (*model.IterationCardsMove, *Response) {
+	move := &model.IterationCardsMove{IterationID: targetIterationID}
+	r, err := c.DoAPIPost(fmt.Sprintf("%s/iterations/%s/move-unfinished", c.GetBoardRoute(boardID), iterationID), toJSON(move))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result model.IterationCardsMove
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return &result, BuildResponse(r)
+}
+
+func (c *Client) GetIterationBurndown(boardID, iterationID string) (*model.IterationBurndown, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/iterations/%s/burndown", c.GetBoardRoute(boardID), iterationID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var burndown model.IterationBurndown
+	if err := json.NewDecoder(r.Body).Decode(&burndown); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return &burndown, BuildResponse(r)
+}
+
+func (c *Client) GetBoardVelocity(boardID string) ([]*model.IterationVelocity, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/velocity", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var velocity []*model.IterationVelocity
+	if err := json.NewDecoder(r.Body).Decode(&velocity); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return velocity, BuildResponse(r)
+}
+
+func (c *Client) GetBoardAnalytics(boardID string, opts model.BoardAnalyticsOptions) (*model.BoardAnalytics, *Response) {
+	query := url.Values{}
+	query.Set("property_id", opts.PropertyID)
+	if opts.From != 0 {
+		query.Set("from", strconv.FormatInt(opts.From, 10))
+	}
+	if opts.To != 0 {
+		query.Set("to", strconv.FormatInt(opts.To, 10))
+	}
+	if len(opts.StartOptionIDs) != 0 {
+		query.Set("start_options", strings.Join(opts.StartOptionIDs, ","))
+	}
+	if len(opts.DoneOptionIDs) != 0 {
+		query.Set("done_options", strings.Join(opts.DoneOptionIDs, ","))
+	}
+
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/analytics?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var analytics *model.BoardAnalytics
+	if err := json.NewDecoder(r.Body).Decode(&analytics); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return analytics, BuildResponse(r)
+}
+
+func (c *Client) GetBoardRules(boardID string) ([]*model.BoardRule, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/rules", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var rules []*model.BoardRule
+	if err := json.NewDecoder(r.Body).Decode(&rules); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return rules, BuildResponse(r)
+}
+
+func (c *Client) GetBoardRule(boardID, ruleID string) (*model.BoardRule, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/rules/%s", c.GetBoardRoute(boardID), ruleID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	rule, err := model.BoardRuleFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return rule, BuildResponse(r)
+}
+
+func (c *Client) CreateBoardRule(boardID string, rule *model.BoardRule) (*model.BoardRule, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/rules", toJSON(&rule))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	newRule, err := model.BoardRuleFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return newRule, BuildResponse(r)
+}
+
+func (c *Client) UpdateBoardRule(boardID string, rule *model.BoardRule) (*model.BoardRule, *Response) {
+	r, err := c.DoAPIPut(fmt.Sprintf("%s/rules/%s", c.GetBoardRoute(boardID), rule.ID), toJSON(&rule))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	updatedRule, err := model.BoardRuleFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return updatedRule, BuildResponse(r)
+}
+
+func (c *Client) DeleteBoardRule(boardID, ruleID string) *Response {
+	r, err := c.DoAPIDelete(fmt.Sprintf("%s/rules/%s", c.GetBoardRoute(boardID), ruleID), "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetBoardWebhooks(boardID string) ([]*model.BoardWebhook, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/webhooks", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var webhooks []*model.BoardWebhook
+	if err := json.NewDecoder(r.Body).Decode(&webhooks); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return webhooks, BuildResponse(r)
+}
+
+func (c *Client) GetBoardWebhook(boardID, webhookID string) (*model.BoardWebhook, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/webhooks/%s", c.GetBoardRoute(boardID), webhookID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	webhook, err := model.BoardWebhookFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return webhook, BuildResponse(r)
+}
+
+func (c *Client) CreateBoardWebhook(boardID string, webhook *model.BoardWebhook) (*model.BoardWebhook, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/webhooks", toJSON(&webhook))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	newWebhook, err := model.BoardWebhookFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return newWebhook, BuildResponse(r)
+}
+
+func (c *Client) UpdateBoardWebhook(boardID string, webhook *model.BoardWebhook) (*model.BoardWebhook, *Response) {
+	r, err := c.DoAPIPut(fmt.Sprintf("%s/webhooks/%s", c.GetBoardRoute(boardID), webhook.ID), toJSON(&webhook))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	updatedWebhook, err := model.BoardWebhookFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return updatedWebhook, BuildResponse(r)
+}
+
+func (c *Client) DeleteBoardWebhook(boardID, webhookID string) *Response {
+	r, err := c.DoAPIDelete(fmt.Sprintf("%s/webhooks/%s", c.GetBoardRoute(boardID), webhookID), "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetWebhookDeliveries(boardID, webhookID string, page, perPage int) ([]*model.WebhookDelivery, *Response) {
+	url := fmt.Sprintf("%s/webhooks/%s/deliveries?page=%d&per_page=%d", c.GetBoardRoute(boardID), webhookID, page, perPage)
+	r, err := c.DoAPIGet(url, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var deliveries []*model.WebhookDelivery
+	if err := json.NewDecoder(r.Body).Decode(&deliveries); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return deliveries, BuildResponse(r)
+}
+
+func (c *Client) GetIncomingWebhooks(boardID string) ([]*model.IncomingWebhook, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/incoming-webhooks", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var webhooks []*model.IncomingWebhook
+	if err := json.NewDecoder(r.Body).Decode(&webhooks); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return webhooks, BuildResponse(r)
+}
+
+func (c *Client) GetIncomingWebhook(boardID, webhookID string) (*model.IncomingWebhook, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/incoming-webhooks/%s", c.GetBoardRoute(boardID), webhookID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	webhook, err := model.IncomingWebhookFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return webhook, BuildResponse(r)
+}
+
+func (c *Client) CreateIncomingWebhook(boardID string, webhook *model.IncomingWebhook) (*model.IncomingWebhook, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/incoming-webhooks", toJSON(&webhook))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	newWebhook, err := model.IncomingWebhookFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return newWebhook, BuildResponse(r)
+}
+
+func (c *Client) UpdateIncomingWebhook(boardID string, webhook *model.IncomingWebhook) (*model.IncomingWebhook, *Response) {
+	r, err := c.DoAPIPut(fmt.Sprintf("%s/incoming-webhooks/%s", c.GetBoardRoute(boardID), webhook.ID), toJSON(&webhook))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	updatedWebhook, err := model.IncomingWebhookFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return updatedWebhook, BuildResponse(r)
+}
+
+func (c *Client) RegenerateIncomingWebhookToken(boardID, webhookID string) (*model.IncomingWebhook, *Response) {
+	r, err := c.DoAPIPost(fmt.Sprintf("%s/incoming-webhooks/%s/token", c.GetBoardRoute(boardID), webhookID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	webhook, err := model.IncomingWebhookFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return webhook, BuildResponse(r)
+}
+
+func (c *Client) DeleteIncomingWebhook(boardID, webhookID string) *Response {
+	r, err := c.DoAPIDelete(fmt.Sprintf("%s/incoming-webhooks/%s", c.GetBoardRoute(boardID), webhookID), "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+// ExecuteIncomingWebhook posts to an incoming webhook, which does not
+// require a session.
+func (c *Client) ExecuteIncomingWebhook(webhookID, token string, request *model.IncomingWebhookRequest) (*model.Card, *Response) {
+	url := fmt.Sprintf("%s/hooks/%s", c.URL, webhookID)
+	r, err := c.doAPIRequestReader(http.MethodPost, url, strings.NewReader(toJSON(&request)), "", func(r *http.Request) {
+		r.Header.Set("X-Hook-Token", token)
+	})
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var card *model.Card
+	if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return card, BuildResponse(r)
+}
+
+func (c *Client) GetRetentionPolicies() ([]*model.RetentionPolicy, *Response) {
+	r, err := c.DoAPIGet("/retention-policies", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var policies []*model.RetentionPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policies); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return policies, BuildResponse(r)
+}
+
+func (c *Client) GetRetentionPolicy(policyID string) (*model.RetentionPolicy, *Response) {
+	r, err := c.DoAPIGet("/retention-policies/"+policyID, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	policy, err := model.RetentionPolicyFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return policy, BuildResponse(r)
+}
+
+func (c *Client) CreateRetentionPolicy(policy *model.RetentionPolicy) (*model.RetentionPolicy, *Response) {
+	r, err := c.DoAPIPost("/retention-policies", toJSON(&policy))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	newPolicy, err := model.RetentionPolicyFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return newPolicy, BuildResponse(r)
+}
+
+func (c *Client) UpdateRetentionPolicy(policy *model.RetentionPolicy) (*model.RetentionPolicy, *Response) {
+	r, err := c.DoAPIPut("/retention-policies/"+policy.ID, toJSON(&policy))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	updatedPolicy, err := model.RetentionPolicyFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return updatedPolicy, BuildResponse(r)
+}
+
+func (c *Client) DeleteRetentionPolicy(policyID string) *Response {
+	r, err := c.DoAPIDelete("/retention-policies/"+policyID, "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) PreviewDataRetention() (*model.RetentionPreview, *Response) {
+	r, err := c.DoAPIGet("/retention-policies/preview", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var preview *model.RetentionPreview
+	if err := json.NewDecoder(r.Body).Decode(&preview); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return preview, BuildResponse(r)
+}
+
+func (c *Client) GetShareLinks(boardID string) ([]*model.ShareLink, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/share-links", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var links []*model.ShareLink
+	if err := json.NewDecoder(r.Body).Decode(&links); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return links, BuildResponse(r)
+}
+
+func (c *Client) GetShareLink(boardID, linkID string) (*model.ShareLink, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/share-links/%s", c.GetBoardRoute(boardID), linkID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	link, err := model.ShareLinkFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return link, BuildResponse(r)
+}
+
+func (c *Client) CreateShareLink(boardID string, link *model.ShareLink) (*model.ShareLink, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/share-links", toJSON(&link))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	newLink, err := model.ShareLinkFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return newLink, BuildResponse(r)
+}
+
+func (c *Client) UpdateShareLink(boardID string, link *model.ShareLink) (*model.ShareLink, *Response) {
+	r, err := c.DoAPIPut(fmt.Sprintf("%s/share-links/%s", c.GetBoardRoute(boardID), link.ID), toJSON(&link))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	updatedLink, err := model.ShareLinkFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return updatedLink, BuildResponse(r)
+}
+
+func (c *Client) RevokeShareLink(boardID, linkID string) (*model.ShareLink, *Response) {
+	r, err := c.DoAPIPost(fmt.Sprintf("%s/share-links/%s/revoke", c.GetBoardRoute(boardID), linkID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	link, err := model.ShareLinkFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return link, BuildResponse(r)
+}
+
+func (c *Client) DeleteShareLink(boardID, linkID string) *Response {
+	r, err := c.DoAPIDelete(fmt.Sprintf("%s/share-links/%s", c.GetBoardRoute(boardID), linkID), "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetShareLinkAccesses(boardID, linkID string, page, perPage int) ([]*model.ShareLinkAccess, *Response) {
+	route := fmt.Sprintf("%s/share-links/%s/accesses?page=%d&per_page=%d", c.GetBoardRoute(boardID), linkID, page, perPage)
+	r, err := c.DoAPIGet(route, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var accesses []*model.ShareLinkAccess
+	if err := json.NewDecoder(r.Body).Decode(&accesses); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return accesses, BuildResponse(r)
+}
+
+func (c *Client) CreateGuestComment(boardID, cardID, readToken string, comment *model.GuestComment) (*model.GuestComment, *Response) {
+	route := fmt.Sprintf("%s/cards/%s/guest-comments?read_token=%s", c.GetBoardRoute(boardID), cardID, readToken)
+	r, err := c.DoAPIPost(route, toJSON(&comment))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	newComment, err := model.GuestCommentFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return newComment, BuildResponse(r)
+}
+
+func (c *Client) GetGuestComments(boardID, status string) ([]*model.GuestComment, *Response) {
+	route := c.GetBoardRoute(boardID) + "/guest-comments"
+	if status != "" {
+		route += "?status=" + status
+	}
+	r, err := c.DoAPIGet(route, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var comments []*model.GuestComment
+	if err := json.NewDecoder(r.Body).Decode(&comments); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return comments, BuildResponse(r)
+}
+
+func (c *Client) ApproveGuestComment(boardID, commentID string) (*model.GuestComment, *Response) {
+	r, err := c.DoAPIPost(fmt.Sprintf("%s/guest-comments/%s/approve", c.GetBoardRoute(boardID), commentID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	comment, err := model.GuestCommentFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return comment, BuildResponse(r)
+}
+
+func (c *Client) RejectGuestComment(boardID, commentID string) (*model.GuestComment, *Response) {
+	r, err := c.DoAPIPost(fmt.Sprintf("%s/guest-comments/%s/reject", c.GetBoardRoute(boardID), commentID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	comment, err := model.GuestCommentFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return comment, BuildResponse(r)
+}
+
+func (c *Client) CreateSubscription(sub *model.Subscription) (*model.Subscription, *Response) {
+	r, err := c.DoAPIPost(c.GetSubscriptionsRoute(), toJSON(&sub))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	subNew, err := model.SubscriptionFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return subNew, BuildResponse(r)
+}
+
+func (c *Client) DeleteSubscription(blockID string, subscriberID string) *Response {
+	url := fmt.Sprintf("%s/%s/%s", c.GetSubscriptionsRoute(), blockID, subscriberID)
+
+	r, err := c.DoAPIDelete(url, "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) UpdateSubscriptionFilter(blockID string, subscriberID string, filter *model.SubscriptionFilter) (*model.Subscription, *Response) {
+	url := fmt.Sprintf("%s/%s/%s/filter", c.GetSubscriptionsRoute(), blockID, subscriberID)
+
+	r, err := c.DoAPIPut(url, toJSON(filter))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	sub, err := model.SubscriptionFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return sub, BuildResponse(r)
+}
+
+func (c *Client) GetSubscriptions(subscriberID string) ([]*model.Subscription, *Response) {
+	url := fmt.Sprintf("%s/%s", c.GetSubscriptionsRoute(), subscriberID)
+
+	r, err := c.DoAPIGet(url, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var subs []*model.Subscription
+	err = json.NewDecoder(r.Body).Decode(&subs)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return subs, BuildResponse(r)
+}
+
+func (c *Client) GetTemplatesForTeam(teamID string) ([]*model.Board, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/templates", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardsFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) ExportBoardArchive(boardID string) ([]byte, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/archive/export", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	buf, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return buf, BuildResponse(r)
+}
+
+func (c *Client) ImportArchive(teamID string, data io.Reader) *Response {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(api.UploadFormFileKey, "file")
+	if err != nil {
+		return &Response{Error: err}
+	}
+	if _, err = io.Copy(part, data); err != nil {
+		return &Response{Error: err}
+	}
+	writer.Close()
+
+	opt := func(r *http.Request) {
+		r.Header.Add("Content-Type", writer.FormDataContentType())
+	}
+
+	r, err := c.doAPIRequestReader(http.MethodPost, c.APIURL+c.GetTeamRoute(teamID)+"/archive/import", body, "", opt)
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) ImportBoardCSV(boardID string, data io.Reader, dryRun bool) (*model.CSVImportResult, *Response) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(api.UploadFormFileKey, "file.csv")
+	if err != nil {
+		return nil, &Response{Error: err}
+	}
+	if _, err = io.Copy(part, data); err != nil {
+		return nil, &Response{Error: err}
+	}
+	writer.Close()
+
+	opt := func(r *http.Request) {
+		r.Header.Add("Content-Type", writer.FormDataContentType())
+	}
+
+	route := c.APIURL + c.GetBoardRoute(boardID) + "/import/csv"
+	if dryRun {
+		route += "?dry_run=true"
+	}
+
+	r, err := c.doAPIRequestReader(http.MethodPost, route, body, "", opt)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result *model.CSVImportResult
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return result, BuildResponse(r)
+}
+
+func (c *Client) ExportBoardCards(boardID, viewID, format string) ([]byte, *Response) {
+	query := url.Values{}
+	if viewID != "" {
+		query.Set("view_id", viewID)
+	}
+	if format != "" {
+		query.Set("format", format)
+	}
+
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/export/cards?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	buf, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return buf, BuildResponse(r)
+}
+
 func (c *Client) MoveContentBlock(srcBlockID string, dstBlockID string, where string, userID string) (bool, *Response) {
 	r, err := c.DoAPIPost("/content-blocks/"+srcBlockID+"/moveto/"+where+"/"+dstBlockID, "")
 	if err != nil {
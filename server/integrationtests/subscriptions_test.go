@@ -153,3 +153,61 @@ func TestDeleteSubscription(t *testing.T) {
 		require.Error(t, resp.Error)
 	})
 }
+
+func TestUpdateSubscriptionFilter(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	subs, userID, err := createTestSubscriptions(th.Client, 1)
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+	blockID := subs[0].BlockID
+
+	t.Run("Set a filter", func(t *testing.T) {
+		filter := &model.SubscriptionFilter{Comments: true, PropertyIDs: []string{"status"}}
+		sub, resp := th.Client.UpdateSubscriptionFilter(blockID, userID, filter)
+		require.NoError(t, resp.Error)
+		require.Equal(t, filter, sub.Filter)
+
+		subsFound, resp := th.Client.GetSubscriptions(userID)
+		require.NoError(t, resp.Error)
+		require.Len(t, subsFound, 1)
+		require.Equal(t, filter, subsFound[0].Filter)
+	})
+
+	t.Run("Resubscribing keeps the filter", func(t *testing.T) {
+		_, resp := th.Client.CreateSubscription(&model.Subscription{
+			BlockType:      model.TypeCard,
+			BlockID:        blockID,
+			SubscriberType: model.SubTypeUser,
+			SubscriberID:   userID,
+		})
+		require.NoError(t, resp.Error)
+
+		subsFound, resp := th.Client.GetSubscriptions(userID)
+		require.NoError(t, resp.Error)
+		require.Len(t, subsFound, 1)
+		require.NotNil(t, subsFound[0].Filter)
+	})
+
+	t.Run("Clear the filter", func(t *testing.T) {
+		sub, resp := th.Client.UpdateSubscriptionFilter(blockID, userID, nil)
+		require.NoError(t, resp.Error)
+		require.Nil(t, sub.Filter)
+	})
+
+	t.Run("Filter excluding every change", func(t *testing.T) {
+		_, resp := th.Client.UpdateSubscriptionFilter(blockID, userID, &model.SubscriptionFilter{})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("Filter of another user", func(t *testing.T) {
+		_, resp := th.Client.UpdateSubscriptionFilter(blockID, "other-user", &model.SubscriptionFilter{Title: true})
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("Filter of an unknown subscription", func(t *testing.T) {
+		_, resp := th.Client.UpdateSubscriptionFilter("bogus", userID, &model.SubscriptionFilter{Title: true})
+		th.CheckNotFound(resp)
+	})
+}
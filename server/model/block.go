@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"reflect"
 	"regexp"
 	"strconv"
 	"unicode/utf8"
@@ -305,6 +306,38 @@ func (p *BlockPatch) Patch(block *Block) *Block {
 	return block
 }
 
+// ChangedCardPropertyIDs returns the ids of the properties of a card whose
+// values are changed by the patch. It returns false if the patch changes
+// anything else than the property values of the card.
+func (p *BlockPatch) ChangedCardPropertyIDs(card *Block) ([]string, bool) {
+	if card.Type != TypeCard || p.ParentID != nil || p.Schema != nil || p.Type != nil || p.Title != nil || len(p.DeletedFields) != 0 {
+		return nil, false
+	}
+
+	newValue, ok := p.UpdatedFields["properties"]
+	if !ok || len(p.UpdatedFields) != 1 {
+		return nil, false
+	}
+	newProperties, ok := newValue.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	oldProperties, _ := card.Fields["properties"].(map[string]interface{})
+
+	propertyIDs := []string{}
+	for id, value := range newProperties {
+		if oldValue, ok := oldProperties[id]; !ok || !reflect.DeepEqual(oldValue, value) {
+			propertyIDs = append(propertyIDs, id)
+		}
+	}
+	for id := range oldProperties {
+		if _, ok := newProperties[id]; !ok {
+			propertyIDs = append(propertyIDs, id)
+		}
+	}
+	return propertyIDs, true
+}
+
 type QueryBlocksOptions struct {
 	BoardID   string    // if not empty then filter for blocks belonging to specified board
 	ParentID  string    // if not empty then filter for blocks belonging to specified parent
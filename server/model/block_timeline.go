@@ -0,0 +1,55 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import "sort"
+
+// BlockTimeline replays the versions of the blocks of a board, as stored in
+// blocks_history, to find the state of the board at a given time.
+type BlockTimeline struct {
+	versions map[string][]*Block
+}
+
+// NewBlockTimeline indexes block versions by block. The versions of a block
+// must be in the order they were saved.
+func NewBlockTimeline(blocks []*Block) *BlockTimeline {
+	h := &BlockTimeline{versions: map[string][]*Block{}}
+	for _, block := range blocks {
+		h.versions[block.ID] = append(h.versions[block.ID], block)
+	}
+	return h
+}
+
+// BlocksAt returns the version of each block current at the given time,
+// including deleted blocks.
+func (h *BlockTimeline) BlocksAt(at int64) []*Block {
+	blocks := make([]*Block, 0, len(h.versions))
+	for _, versions := range h.versions {
+		var current *Block
+		for _, version := range versions {
+			if version.UpdateAt <= at {
+				current = version
+			}
+		}
+		if current != nil {
+			blocks = append(blocks, current)
+		}
+	}
+	return blocks
+}
+
+// BlockIDs returns the ids of the blocks of the timeline, sorted.
+func (h *BlockTimeline) BlockIDs() []string {
+	ids := make([]string, 0, len(h.versions))
+	for id := range h.versions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Versions returns the versions of a block, in the order they were saved.
+func (h *BlockTimeline) Versions(blockID string) []*Block {
+	return h.versions[blockID]
+}
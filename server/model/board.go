@@ -125,6 +125,19 @@ func (b *Board) GetPropertyString(propName string) (string, error) {
 	return s, nil
 }
 
+// IsVisibleToChannel returns true if every member of a channel can view the
+// board, because the board is linked to the channel, or is open and the
+// channel belongs to the team of the board.
+func (b *Board) IsVisibleToChannel(channel *mmModel.Channel) bool {
+	if channel == nil {
+		return false
+	}
+	if b.ChannelID == channel.Id {
+		return true
+	}
+	return b.Type == BoardTypeOpen && channel.TeamId == b.TeamID
+}
+
 // BoardPatch is a patch for modify boards
 // swagger:model
 type BoardPatch struct {
@@ -208,6 +221,11 @@ type BoardMember struct {
 	// required: true
 	SchemeViewer bool `json:"schemeViewer"`
 
+	// The id of the custom role of the user on the board, which replaces
+	// its scheme roles. Updating a member without it keeps its custom role
+	// required: false
+	CustomRoleID string `json:"customRoleId,omitempty"`
+
 	// Marks the membership as generated by an access group
 	// required: true
 	Synthetic bool `json:"synthetic"`
@@ -377,6 +395,12 @@ func (p *BoardPatch) IsValid() error {
 		return InvalidBoardErr{"invalid-channel-id"}
 	}
 
+	for _, property := range p.UpdatedCardProperties {
+		if err := ValidateComputedProperty(property); err != nil {
+			return InvalidBoardErr{"invalid-card-property: " + err.Error()}
+		}
+	}
+
 	return nil
 }
 
@@ -418,6 +442,15 @@ func (b *Board) baseValidation() error {
 		return InvalidBoardErr{"invalid-board-minimum-role"}
 	}
 
+	for _, property := range b.CardProperties {
+		if propertyType, _ := property["type"].(string); propertyType != PropTypeRollup && propertyType != PropTypeFormula {
+			continue
+		}
+		if err := ValidateComputedProperty(property); err != nil {
+			return InvalidBoardErr{"invalid-card-property: " + err.Error()}
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,432 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// BoardAnalyticsDefaultRange is the date range of the analytics when no
+	// start date is given, 90 days.
+	BoardAnalyticsDefaultRange = 90 * dayMillis
+
+	// boardAnalyticsMaxFlowPoints bounds the number of daily points of the
+	// cumulative flow.
+	boardAnalyticsMaxFlowPoints = 366
+
+	weekMillis = 7 * dayMillis
+)
+
+// BoardAnalyticsOptions are the parameters of the analytics of a board.
+type BoardAnalyticsOptions struct {
+	// The id of the select property whose options are the states of the cards
+	PropertyID string
+
+	// The date range in milliseconds since the current epoch
+	From int64
+	To   int64
+
+	// The states where work on a card starts, for the cycle time
+	StartOptionIDs []string
+
+	// The states where a card is done
+	DoneOptionIDs []string
+}
+
+// ErrInvalidBoardAnalyticsOptions is returned when the analytics of a board
+// cannot be computed with the given options.
+type ErrInvalidBoardAnalyticsOptions struct {
+	msg string
+}
+
+func (e ErrInvalidBoardAnalyticsOptions) Error() string {
+	return e.msg
+}
+
+// Resolve checks the options against the schema of the board and fills in
+// the defaults. The date range ends now and lasts BoardAnalyticsDefaultRange,
+// a card is done in the last option of the property and work on it starts
+// in any option after the first one.
+func (o *BoardAnalyticsOptions) Resolve(schema PropSchema, now int64) error {
+	prop, ok := schema[o.PropertyID]
+	if !ok {
+		return ErrInvalidBoardAnalyticsOptions{"unknown property: " + o.PropertyID}
+	}
+	if prop.Type != PropTypeSelect {
+		return ErrInvalidBoardAnalyticsOptions{"property is not a select property: " + prop.Name}
+	}
+	if len(prop.Options) == 0 {
+		return ErrInvalidBoardAnalyticsOptions{"property has no options: " + prop.Name}
+	}
+
+	if o.To == 0 {
+		o.To = now
+	}
+	if o.From == 0 {
+		o.From = o.To - BoardAnalyticsDefaultRange
+	}
+	if o.From >= o.To {
+		return ErrInvalidBoardAnalyticsOptions{"the date range ends before it starts"}
+	}
+
+	for _, optionID := range append(append([]string{}, o.StartOptionIDs...), o.DoneOptionIDs...) {
+		if _, ok := prop.Options[optionID]; !ok {
+			return ErrInvalidBoardAnalyticsOptions{fmt.Sprintf("unknown option of property %s: %s", prop.Name, optionID)}
+		}
+	}
+
+	options := make([]PropDefOption, 0, len(prop.Options))
+	for _, option := range prop.Options {
+		options = append(options, option)
+	}
+	sort.Slice(options, func(i, j int) bool { return options[i].Index < options[j].Index })
+
+	if len(o.DoneOptionIDs) == 0 {
+		o.DoneOptionIDs = []string{options[len(options)-1].ID}
+	}
+	if len(o.StartOptionIDs) == 0 {
+		o.StartOptionIDs = []string{}
+		for _, option := range options[1:] {
+			o.StartOptionIDs = append(o.StartOptionIDs, option.ID)
+		}
+	}
+	return nil
+}
+
+// BoardAnalytics is the flow of the cards of a board through the states of a
+// select property, computed from the history of the cards.
+// swagger:model
+type BoardAnalytics struct {
+	// The id of the select property
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The start of the date range in milliseconds since the current epoch
+	// required: true
+	From int64 `json:"from"`
+
+	// The end of the date range in milliseconds since the current epoch
+	// required: true
+	To int64 `json:"to"`
+
+	// The states where work on a card starts
+	// required: true
+	StartOptionIDs []string `json:"startOptionIds"`
+
+	// The states where a card is done
+	// required: true
+	DoneOptionIDs []string `json:"doneOptionIds"`
+
+	// The time spent by each card in each state within the date range
+	// required: true
+	TimeInState []*CardTimeInState `json:"timeInState"`
+
+	// The time from the start of the work on a card to its completion, for
+	// the cards completed within the date range
+	// required: true
+	CycleTime *DurationDistribution `json:"cycleTime"`
+
+	// The time from the creation of a card to its completion, for the cards
+	// completed within the date range
+	// required: true
+	LeadTime *DurationDistribution `json:"leadTime"`
+
+	// The number of cards completed per week
+	// required: true
+	Throughput []*ThroughputPoint `json:"throughput"`
+
+	// The daily number of cards in each state
+	// required: true
+	CumulativeFlow []*CumulativeFlowPoint `json:"cumulativeFlow"`
+}
+
+// CardTimeInState is the time spent by a card in each state.
+// swagger:model
+type CardTimeInState struct {
+	// The id of the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The title of the card
+	// required: true
+	Title string `json:"title"`
+
+	// The time spent in milliseconds, keyed by state option id
+	// required: true
+	Durations map[string]int64 `json:"durations"`
+}
+
+// DurationDistribution summarizes a set of durations in milliseconds.
+// swagger:model
+type DurationDistribution struct {
+	// The number of durations
+	// required: true
+	Count int `json:"count"`
+
+	// The shortest duration
+	// required: true
+	Min int64 `json:"min"`
+
+	// The longest duration
+	// required: true
+	Max int64 `json:"max"`
+
+	// The average duration
+	// required: true
+	Mean int64 `json:"mean"`
+
+	// The median duration
+	// required: true
+	Median int64 `json:"median"`
+
+	// The 85th percentile
+	// required: true
+	P85 int64 `json:"p85"`
+
+	// The 95th percentile
+	// required: true
+	P95 int64 `json:"p95"`
+}
+
+// ThroughputPoint is the number of cards completed in a week.
+// swagger:model
+type ThroughputPoint struct {
+	// The start of the week in milliseconds since the current epoch, a Monday
+	// in UTC
+	// required: true
+	WeekStart int64 `json:"weekStart"`
+
+	// The number of cards completed
+	// required: true
+	Count int `json:"count"`
+}
+
+// CumulativeFlowPoint is the number of cards in each state at a given time.
+// swagger:model
+type CumulativeFlowPoint struct {
+	// The time of the point in milliseconds since the current epoch
+	// required: true
+	Date int64 `json:"date"`
+
+	// The number of cards, keyed by state option id
+	// required: true
+	Counts map[string]int `json:"counts"`
+}
+
+// cardState is a period of time a card spent in a state.
+type cardState struct {
+	optionID string
+	startAt  int64
+	endAt    int64
+}
+
+// BuildBoardAnalytics computes the analytics of the cards of a board from
+// their versions. A card is completed when it last entered a done state, if
+// it is still done at the end of the date range.
+func BuildBoardAnalytics(timeline *BlockTimeline, opts BoardAnalyticsOptions) *BoardAnalytics {
+	analytics := &BoardAnalytics{
+		PropertyID:     opts.PropertyID,
+		From:           opts.From,
+		To:             opts.To,
+		StartOptionIDs: opts.StartOptionIDs,
+		DoneOptionIDs:  opts.DoneOptionIDs,
+		TimeInState:    []*CardTimeInState{},
+		Throughput:     []*ThroughputPoint{},
+		CumulativeFlow: []*CumulativeFlowPoint{},
+	}
+
+	isStart := stringSet(opts.StartOptionIDs)
+	isDone := stringSet(opts.DoneOptionIDs)
+
+	var cycleTimes, leadTimes []int64
+	completions := []int64{}
+	for _, id := range timeline.BlockIDs() {
+		versions := timeline.Versions(id)
+		if len(versions) == 0 || versions[0].Type != TypeCard {
+			continue
+		}
+		states := cardStates(versions, opts.PropertyID, opts.To)
+		if len(states) == 0 {
+			continue
+		}
+
+		durations := map[string]int64{}
+		for _, state := range states {
+			start := max(state.startAt, opts.From)
+			end := min(state.endAt, opts.To)
+			if state.optionID != "" && end > start {
+				durations[state.optionID] += end - start
+			}
+		}
+		if len(durations) != 0 {
+			analytics.TimeInState = append(analytics.TimeInState, &CardTimeInState{
+				CardID:    id,
+				Title:     versions[len(versions)-1].Title,
+				Durations: durations,
+			})
+		}
+
+		completedAt, ok := cardCompletion(states, isDone, opts.To)
+		if !ok || completedAt < opts.From {
+			continue
+		}
+		completions = append(completions, completedAt)
+
+		createAt := versions[0].CreateAt
+		if createAt == 0 {
+			createAt = states[0].startAt
+		}
+		leadTimes = append(leadTimes, completedAt-createAt)
+
+		for _, state := range states {
+			if state.startAt > completedAt {
+				break
+			}
+			if isStart[state.optionID] {
+				cycleTimes = append(cycleTimes, completedAt-state.startAt)
+				break
+			}
+		}
+	}
+
+	analytics.CycleTime = NewDurationDistribution(cycleTimes)
+	analytics.LeadTime = NewDurationDistribution(leadTimes)
+	analytics.Throughput = buildThroughput(completions, opts.From, opts.To)
+	analytics.CumulativeFlow = buildCumulativeFlow(timeline, opts)
+	return analytics
+}
+
+// cardStates returns the periods of time a card spent in each state, up to
+// its deletion or the given end time.
+func cardStates(versions []*Block, propertyID string, end int64) []cardState {
+	states := []cardState{}
+	for _, version := range versions {
+		if version.UpdateAt > end {
+			break
+		}
+		if len(states) != 0 {
+			states[len(states)-1].endAt = version.UpdateAt
+		}
+		if version.DeleteAt != 0 {
+			return states
+		}
+
+		optionID, _ := getCardPropertyValue(version, propertyID).(string)
+		if len(states) != 0 && states[len(states)-1].optionID == optionID {
+			states[len(states)-1].endAt = end
+			continue
+		}
+		states = append(states, cardState{optionID: optionID, startAt: version.UpdateAt, endAt: end})
+	}
+	return states
+}
+
+// cardCompletion returns the time the card last entered a done state, if it
+// is done at the end of its states.
+func cardCompletion(states []cardState, isDone map[string]bool, end int64) (int64, bool) {
+	if len(states) == 0 {
+		return 0, false
+	}
+	last := states[len(states)-1]
+	if !isDone[last.optionID] || last.endAt < end {
+		// not done, or deleted
+		return 0, false
+	}
+
+	completedAt := last.startAt
+	for i := len(states) - 2; i >= 0 && isDone[states[i].optionID]; i-- {
+		completedAt = states[i].startAt
+	}
+	return completedAt, true
+}
+
+// NewDurationDistribution summarizes durations, using the nearest rank
+// method for percentiles.
+func NewDurationDistribution(durations []int64) *DurationDistribution {
+	distribution := &DurationDistribution{Count: len(durations)}
+	if len(durations) == 0 {
+		return distribution
+	}
+
+	sorted := append([]int64{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total int64
+	for _, duration := range sorted {
+		total += duration
+	}
+	percentile := func(p int) int64 {
+		rank := (p*len(sorted) + 99) / 100
+		return sorted[max(rank, 1)-1]
+	}
+
+	distribution.Min = sorted[0]
+	distribution.Max = sorted[len(sorted)-1]
+	distribution.Mean = total / int64(len(sorted))
+	distribution.Median = percentile(50)
+	distribution.P85 = percentile(85)
+	distribution.P95 = percentile(95)
+	return distribution
+}
+
+// buildThroughput counts the completions per week, from the week of the start
+// of the date range to the week of its end.
+func buildThroughput(completions []int64, from, to int64) []*ThroughputPoint {
+	throughput := []*ThroughputPoint{}
+	for weekStart := startOfWeek(from); weekStart <= to; weekStart += weekMillis {
+		point := &ThroughputPoint{WeekStart: weekStart}
+		for _, completedAt := range completions {
+			if completedAt >= weekStart && completedAt < weekStart+weekMillis {
+				point.Count++
+			}
+		}
+		throughput = append(throughput, point)
+	}
+	return throughput
+}
+
+// startOfWeek returns the start of the Monday of the week of a time, in UTC.
+func startOfWeek(at int64) int64 {
+	t := time.UnixMilli(at).UTC()
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	day := time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, time.UTC)
+	return day.UnixMilli()
+}
+
+// buildCumulativeFlow counts the cards in each state every day of the date
+// range, and at its end.
+func buildCumulativeFlow(timeline *BlockTimeline, opts BoardAnalyticsOptions) []*CumulativeFlowPoint {
+	flow := []*CumulativeFlowPoint{}
+
+	addPoint := func(at int64) {
+		point := &CumulativeFlowPoint{Date: at, Counts: map[string]int{}}
+		for _, block := range timeline.BlocksAt(at) {
+			if block.Type != TypeCard || block.DeleteAt != 0 {
+				continue
+			}
+			if optionID, _ := getCardPropertyValue(block, opts.PropertyID).(string); optionID != "" {
+				point.Counts[optionID]++
+			}
+		}
+		flow = append(flow, point)
+	}
+
+	at := opts.From
+	for ; at < opts.To && len(flow) < boardAnalyticsMaxFlowPoints; at += dayMillis {
+		addPoint(at)
+	}
+	addPoint(opts.To)
+	return flow
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func makeAnalyticsSchema() PropSchema {
+	return PropSchema{
+		"status": {
+			ID:   "status",
+			Name: "Status",
+			Type: PropTypeSelect,
+			Options: map[string]PropDefOption{
+				"todo":  {ID: "todo", Index: 0, Value: "To Do"},
+				"doing": {ID: "doing", Index: 1, Value: "Doing"},
+				"done":  {ID: "done", Index: 2, Value: "Done"},
+			},
+		},
+		"points": {ID: "points", Name: "Points", Type: PropTypeNumber},
+	}
+}
+
+func TestBoardAnalyticsOptionsResolve(t *testing.T) {
+	schema := makeAnalyticsSchema()
+
+	t.Run("defaults", func(t *testing.T) {
+		opts := BoardAnalyticsOptions{PropertyID: "status"}
+		require.NoError(t, opts.Resolve(schema, 100*dayMillis))
+		require.Equal(t, int64(100*dayMillis), opts.To)
+		require.Equal(t, int64(10*dayMillis), opts.From)
+		require.Equal(t, []string{"doing", "done"}, opts.StartOptionIDs)
+		require.Equal(t, []string{"done"}, opts.DoneOptionIDs)
+	})
+
+	t.Run("given options", func(t *testing.T) {
+		opts := BoardAnalyticsOptions{PropertyID: "status", From: 5, To: 10, StartOptionIDs: []string{"doing"}, DoneOptionIDs: []string{"doing", "done"}}
+		require.NoError(t, opts.Resolve(schema, 100*dayMillis))
+		require.Equal(t, int64(5), opts.From)
+		require.Equal(t, []string{"doing"}, opts.StartOptionIDs)
+		require.Equal(t, []string{"doing", "done"}, opts.DoneOptionIDs)
+	})
+
+	testCases := []struct {
+		name string
+		opts BoardAnalyticsOptions
+	}{
+		{name: "unknown property", opts: BoardAnalyticsOptions{PropertyID: "priority"}},
+		{name: "not a select property", opts: BoardAnalyticsOptions{PropertyID: "points"}},
+		{name: "unknown option", opts: BoardAnalyticsOptions{PropertyID: "status", DoneOptionIDs: []string{"closed"}}},
+		{name: "range ends before it starts", opts: BoardAnalyticsOptions{PropertyID: "status", From: 10, To: 5}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.opts.Resolve(schema, 100*dayMillis)
+			var errInvalid ErrInvalidBoardAnalyticsOptions
+			require.ErrorAs(t, err, &errInvalid)
+		})
+	}
+}
+
+func makeAnalyticsCardVersion(id string, createAt, updateAt int64, status string) *Block {
+	return &Block{
+		ID:       id,
+		Type:     TypeCard,
+		Title:    "Card " + id,
+		CreateAt: createAt,
+		UpdateAt: updateAt,
+		Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": status},
+		},
+	}
+}
+
+func TestBuildBoardAnalytics(t *testing.T) {
+	deletedCard := makeAnalyticsCardVersion("card4", dayMillis, 2*dayMillis, "todo")
+	deletedCard.DeleteAt = 2 * dayMillis
+
+	timeline := NewBlockTimeline([]*Block{
+		makeAnalyticsCardVersion("card1", dayMillis, dayMillis, "todo"),
+		makeAnalyticsCardVersion("card2", dayMillis, dayMillis, "todo"),
+		makeAnalyticsCardVersion("card3", dayMillis, dayMillis, "doing"),
+		makeAnalyticsCardVersion("card4", dayMillis, dayMillis, "todo"),
+		makeAnalyticsCardVersion("card1", dayMillis, 2*dayMillis, "doing"),
+		makeAnalyticsCardVersion("card3", dayMillis, 2*dayMillis, "done"),
+		deletedCard,
+		makeAnalyticsCardVersion("card2", dayMillis, 3*dayMillis, "doing"),
+		// reopened, then done again
+		makeAnalyticsCardVersion("card3", dayMillis, 3*dayMillis, "doing"),
+		makeAnalyticsCardVersion("card1", dayMillis, 4*dayMillis, "done"),
+		makeAnalyticsCardVersion("card3", dayMillis, 5*dayMillis, "done"),
+		// not a card
+		{ID: "view", Type: TypeView, UpdateAt: dayMillis},
+	})
+
+	opts := BoardAnalyticsOptions{
+		PropertyID:     "status",
+		From:           0,
+		To:             6 * dayMillis,
+		StartOptionIDs: []string{"doing", "done"},
+		DoneOptionIDs:  []string{"done"},
+	}
+	analytics := BuildBoardAnalytics(timeline, opts)
+
+	t.Run("time in state", func(t *testing.T) {
+		require.Len(t, analytics.TimeInState, 4)
+		require.Equal(t, CardTimeInState{
+			CardID:    "card1",
+			Title:     "Card card1",
+			Durations: map[string]int64{"todo": dayMillis, "doing": 2 * dayMillis, "done": 2 * dayMillis},
+		}, *analytics.TimeInState[0])
+		require.Equal(t, map[string]int64{"todo": dayMillis}, analytics.TimeInState[3].Durations)
+	})
+
+	t.Run("time in state clipped to the range", func(t *testing.T) {
+		clipped := BuildBoardAnalytics(timeline, BoardAnalyticsOptions{
+			PropertyID:    "status",
+			From:          3 * dayMillis,
+			To:            4 * dayMillis,
+			DoneOptionIDs: []string{"done"},
+		})
+		require.Equal(t, map[string]int64{"doing": dayMillis}, clipped.TimeInState[0].Durations)
+		// card1 is done at the end of the range, card3 only after it
+		require.Equal(t, 1, clipped.LeadTime.Count)
+	})
+
+	t.Run("cycle and lead time", func(t *testing.T) {
+		require.Equal(t, DurationDistribution{
+			Count:  2,
+			Min:    2 * dayMillis,
+			Max:    4 * dayMillis,
+			Mean:   3 * dayMillis,
+			Median: 2 * dayMillis,
+			P85:    4 * dayMillis,
+			P95:    4 * dayMillis,
+		}, *analytics.CycleTime)
+		require.Equal(t, 2, analytics.LeadTime.Count)
+		require.Equal(t, int64(3*dayMillis), analytics.LeadTime.Min)
+		require.Equal(t, int64(4*dayMillis), analytics.LeadTime.Max)
+	})
+
+	t.Run("throughput", func(t *testing.T) {
+		// the epoch is a Thursday
+		require.Equal(t, []*ThroughputPoint{
+			{WeekStart: -3 * dayMillis, Count: 0},
+			{WeekStart: 4 * dayMillis, Count: 2},
+		}, analytics.Throughput)
+	})
+
+	t.Run("cumulative flow", func(t *testing.T) {
+		require.Len(t, analytics.CumulativeFlow, 7)
+		require.Empty(t, analytics.CumulativeFlow[0].Counts)
+		require.Equal(t, map[string]int{"todo": 1, "doing": 1, "done": 1}, analytics.CumulativeFlow[2].Counts)
+		require.Equal(t, CumulativeFlowPoint{Date: 6 * dayMillis, Counts: map[string]int{"doing": 1, "done": 2}}, *analytics.CumulativeFlow[6])
+	})
+}
+
+func TestNewDurationDistribution(t *testing.T) {
+	require.Equal(t, DurationDistribution{}, *NewDurationDistribution(nil))
+
+	durations := []int64{}
+	for i := 20; i > 0; i-- {
+		durations = append(durations, int64(i))
+	}
+	require.Equal(t, DurationDistribution{Count: 20, Min: 1, Max: 20, Mean: 10, Median: 10, P85: 17, P95: 19}, *NewDurationDistribution(durations))
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	mmModel "github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	// BoardCustomRoleNameMaxRunes is the maximum length of the name of a
+	// custom role.
+	BoardCustomRoleNameMaxRunes = 64
+
+	// BoardCustomRoleDescriptionMaxRunes is the maximum length of the
+	// description of a custom role.
+	BoardCustomRoleDescriptionMaxRunes = 1000
+)
+
+// boardCustomRolePermissions are the permissions a custom role can grant.
+// Managing the roles, the type and the deletion of a board remain with its
+// admins, so that a custom role cannot be used to take over a board.
+var boardCustomRolePermissions = map[string]bool{
+	PermissionViewBoard.Id:               true,
+	PermissionCommentBoardCards.Id:       true,
+	PermissionEditBoardCardProperties.Id: true,
+	PermissionManageBoardCards.Id:        true,
+	PermissionManageBoardProperties.Id:   true,
+	PermissionDeleteOthersComments.Id:    true,
+	PermissionShareBoard.Id:              true,
+}
+
+// BoardCustomRole is a role defined by the admins of a board, granting a set
+// of board permissions to the members it is assigned to. The members of a
+// custom role can always view the board.
+// swagger:model
+type BoardCustomRole struct {
+	// The id of the role
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board the role is defined for
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The name of the role, unique within the board
+	// required: true
+	Name string `json:"name"`
+
+	// The description of the role
+	// required: false
+	Description string `json:"description"`
+
+	// The ids of the permissions granted by the role: view_board,
+	// comment_board_cards, edit_board_card_properties, manage_board_cards,
+	// manage_board_properties, delete_others_comments and share_board
+	// required: true
+	Permissions []string `json:"permissions"`
+
+	// The ids of the card properties the members of the role can edit with
+	// the edit_board_card_properties permission, all of them when empty
+	// required: false
+	PropertyIDs []string `json:"propertyIds"`
+
+	// The id of the user who created the role
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The id of the user who last modified the role
+	// required: true
+	ModifiedBy string `json:"modifiedBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+func (r *BoardCustomRole) IsValid() error {
+	if r == nil {
+		return ErrInvalidBoardCustomRole{"cannot be nil"}
+	}
+
+	if err := IsValidId(r.BoardID); err != nil {
+		return ErrInvalidBoardCustomRole{"invalid board id: " + err.Error()}
+	}
+
+	if r.Name == "" {
+		return ErrInvalidBoardCustomRole{"missing name"}
+	}
+	if len([]rune(r.Name)) > BoardCustomRoleNameMaxRunes {
+		return ErrInvalidBoardCustomRole{"name is too long"}
+	}
+	if len([]rune(r.Description)) > BoardCustomRoleDescriptionMaxRunes {
+		return ErrInvalidBoardCustomRole{"description is too long"}
+	}
+
+	for _, permissionID := range r.Permissions {
+		if !boardCustomRolePermissions[permissionID] {
+			return ErrInvalidBoardCustomRole{"permission cannot be granted by a custom role: " + permissionID}
+		}
+	}
+
+	if len(r.PropertyIDs) > 0 && (!r.hasPermissionID(PermissionEditBoardCardProperties.Id) || r.hasPermissionID(PermissionManageBoardCards.Id)) {
+		return ErrInvalidBoardCustomRole{"property ids only restrict the edit_board_card_properties permission"}
+	}
+	return nil
+}
+
+// IsValidForSchema checks that the properties the role can edit are
+// properties of the board.
+func (r *BoardCustomRole) IsValidForSchema(schema PropSchema) error {
+	for _, propertyID := range r.PropertyIDs {
+		if _, ok := schema[propertyID]; !ok {
+			return ErrInvalidBoardCustomRole{"unknown property: " + propertyID}
+		}
+	}
+	return nil
+}
+
+func (r *BoardCustomRole) hasPermissionID(permissionID string) bool {
+	for _, id := range r.Permissions {
+		if id == permissionID {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPermission returns true if the role grants a board permission. Managing
+// cards includes editing their properties.
+func (r *BoardCustomRole) HasPermission(permission *mmModel.Permission) bool {
+	if permission == nil || !boardCustomRolePermissions[permission.Id] {
+		return false
+	}
+
+	switch permission.Id {
+	case PermissionViewBoard.Id:
+		return true
+	case PermissionEditBoardCardProperties.Id:
+		return r.hasPermissionID(PermissionEditBoardCardProperties.Id) || r.hasPermissionID(PermissionManageBoardCards.Id)
+	}
+	return r.hasPermissionID(permission.Id)
+}
+
+// CanEditCardProperties returns true if the role lets its members change the
+// values of the given properties of the cards of the board.
+func (r *BoardCustomRole) CanEditCardProperties(propertyIDs []string) bool {
+	if r.hasPermissionID(PermissionManageBoardCards.Id) {
+		return true
+	}
+	if !r.hasPermissionID(PermissionEditBoardCardProperties.Id) {
+		return false
+	}
+	if len(r.PropertyIDs) == 0 {
+		return true
+	}
+
+	editable := make(map[string]bool, len(r.PropertyIDs))
+	for _, id := range r.PropertyIDs {
+		editable[id] = true
+	}
+	for _, id := range propertyIDs {
+		if !editable[id] {
+			return false
+		}
+	}
+	return true
+}
+
+func BoardCustomRoleFromJSON(data io.Reader) (*BoardCustomRole, error) {
+	var role BoardCustomRole
+	if err := json.NewDecoder(data).Decode(&role); err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// RemapBoardCustomRoles returns copies of the custom roles of an archive for
+// the board they are imported to, with new ids, and the new id of each role
+// by its archived id.
+func RemapBoardCustomRoles(roles []*BoardCustomRole, boardID, userID string) ([]*BoardCustomRole, map[string]string) {
+	remapped := make([]*BoardCustomRole, 0, len(roles))
+	roleIDs := make(map[string]string, len(roles))
+	for _, role := range roles {
+		newRole := &BoardCustomRole{
+			ID:          utils.NewID(utils.IDTypeNone),
+			BoardID:     boardID,
+			Name:        role.Name,
+			Description: role.Description,
+			Permissions: role.Permissions,
+			PropertyIDs: role.PropertyIDs,
+			CreatedBy:   userID,
+			ModifiedBy:  userID,
+		}
+		roleIDs[role.ID] = newRole.ID
+		remapped = append(remapped, newRole)
+	}
+	return remapped, roleIDs
+}
+
+type ErrInvalidBoardCustomRole struct {
+	msg string
+}
+
+func (e ErrInvalidBoardCustomRole) Error() string {
+	return e.msg
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardCustomRoleIsValid(t *testing.T) {
+	role := &BoardCustomRole{
+		BoardID:     utils.NewID(utils.IDTypeBoard),
+		Name:        "Contractor",
+		Permissions: []string{PermissionManageBoardCards.Id, PermissionCommentBoardCards.Id},
+	}
+	require.NoError(t, role.IsValid())
+
+	role.Name = ""
+	require.Error(t, role.IsValid())
+	role.Name = "Contractor"
+
+	role.Permissions = []string{PermissionManageBoardRoles.Id}
+	require.Error(t, role.IsValid(), "admin permissions cannot be granted")
+
+	role.Permissions = []string{PermissionManageBoardCards.Id}
+	role.PropertyIDs = []string{"status"}
+	require.Error(t, role.IsValid(), "property ids need the edit_board_card_properties permission alone")
+
+	role.Permissions = []string{PermissionEditBoardCardProperties.Id}
+	require.NoError(t, role.IsValid())
+
+	schema := PropSchema{
+		"status": {ID: "status", Name: "Status", Type: PropTypeSelect},
+	}
+	require.NoError(t, role.IsValidForSchema(schema))
+
+	role.PropertyIDs = []string{"priority"}
+	require.Error(t, role.IsValidForSchema(schema))
+}
+
+func TestBoardCustomRoleHasPermission(t *testing.T) {
+	editor := &BoardCustomRole{Permissions: []string{PermissionManageBoardCards.Id}}
+	require.True(t, editor.HasPermission(PermissionViewBoard))
+	require.True(t, editor.HasPermission(PermissionManageBoardCards))
+	require.True(t, editor.HasPermission(PermissionEditBoardCardProperties), "managing cards includes editing their properties")
+	require.False(t, editor.HasPermission(PermissionManageBoardProperties))
+	require.False(t, editor.HasPermission(PermissionCommentBoardCards))
+
+	mover := &BoardCustomRole{
+		Permissions: []string{PermissionCommentBoardCards.Id, PermissionEditBoardCardProperties.Id},
+		PropertyIDs: []string{"status"},
+	}
+	require.True(t, mover.HasPermission(PermissionCommentBoardCards))
+	require.False(t, mover.HasPermission(PermissionManageBoardCards))
+
+	require.True(t, mover.CanEditCardProperties([]string{"status"}))
+	require.False(t, mover.CanEditCardProperties([]string{"status", "assignee"}))
+	require.True(t, editor.CanEditCardProperties([]string{"status", "assignee"}))
+
+	forged := &BoardCustomRole{Permissions: []string{PermissionDeleteBoard.Id}}
+	require.False(t, forged.HasPermission(PermissionDeleteBoard))
+}
+
+func TestChangedCardPropertyIDs(t *testing.T) {
+	card := &Block{
+		Type: TypeCard,
+		Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "todo", "assignee": "alice"},
+		},
+	}
+
+	t.Run("block patch of property values", func(t *testing.T) {
+		patch := &BlockPatch{UpdatedFields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "done", "assignee": "alice"},
+		}}
+		propertyIDs, ok := patch.ChangedCardPropertyIDs(card)
+		require.True(t, ok)
+		require.Equal(t, []string{"status"}, propertyIDs)
+	})
+
+	t.Run("block patch removing a property value", func(t *testing.T) {
+		patch := &BlockPatch{UpdatedFields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "todo"},
+		}}
+		propertyIDs, ok := patch.ChangedCardPropertyIDs(card)
+		require.True(t, ok)
+		require.Equal(t, []string{"assignee"}, propertyIDs)
+	})
+
+	t.Run("block patch of the title", func(t *testing.T) {
+		title := "New title"
+		patch := &BlockPatch{Title: &title}
+		_, ok := patch.ChangedCardPropertyIDs(card)
+		require.False(t, ok)
+	})
+
+	t.Run("card patch", func(t *testing.T) {
+		patch := &CardPatch{UpdatedProperties: map[string]any{"status": "done"}}
+		propertyIDs, ok := patch.ChangedPropertyIDs()
+		require.True(t, ok)
+		require.Equal(t, []string{"status"}, propertyIDs)
+
+		icon := "🚀"
+		patch.Icon = &icon
+		_, ok = patch.ChangedPropertyIDs()
+		require.False(t, ok)
+	})
+}
+
+func TestRemapBoardCustomRoles(t *testing.T) {
+	roles := []*BoardCustomRole{
+		{ID: "old-role", BoardID: "old-board", Name: "Contractor", Permissions: []string{PermissionCommentBoardCards.Id}},
+	}
+
+	remapped, roleIDs := RemapBoardCustomRoles(roles, "new-board", "user")
+	require.Len(t, remapped, 1)
+	require.NotEqual(t, "old-role", remapped[0].ID)
+	require.Equal(t, remapped[0].ID, roleIDs["old-role"])
+	require.Equal(t, "new-board", remapped[0].BoardID)
+	require.Equal(t, "Contractor", remapped[0].Name)
+	require.Equal(t, "user", remapped[0].CreatedBy)
+}
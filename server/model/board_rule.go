@@ -0,0 +1,296 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	RuleActionSetProperty   = "setProperty"
+	RuleActionAssignPerson  = "assignPerson"
+	RuleActionMoveCard      = "moveCard"
+	RuleActionPostToChannel = "postToChannel"
+	RuleActionAddComment    = "addComment"
+
+	BoardRuleMaxActions = 20
+)
+
+// BoardRule is an automation rule of a board: when a card property becomes a
+// given value, the actions of the rule are executed on the card.
+// swagger:model
+type BoardRule struct {
+	// The id of the rule
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board the rule belongs to
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The title of the rule
+	// required: false
+	Title string `json:"title"`
+
+	// Whether the rule is evaluated or not
+	// required: true
+	Enabled bool `json:"enabled"`
+
+	// The property change that triggers the rule
+	// required: true
+	Trigger RuleTrigger `json:"trigger"`
+
+	// The actions executed when the rule is triggered, in order
+	// required: true
+	Actions []RuleAction `json:"actions"`
+
+	// The id of the user who created the rule
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The id of the user who last modified the rule
+	// required: true
+	ModifiedBy string `json:"modifiedBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// RuleTrigger fires when the property of a card becomes the value. For
+// multi value properties, it fires when the value is added to the property.
+// swagger:model
+type RuleTrigger struct {
+	// The id of the card property
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The value of the property, an option id for select properties
+	// required: true
+	Value string `json:"value"`
+}
+
+// RuleAction is an action executed on the card that triggered a rule.
+// swagger:model
+type RuleAction struct {
+	// The type of the action: setProperty, assignPerson, moveCard, postToChannel or addComment
+	// required: true
+	Type string `json:"type"`
+
+	// The id of the card property set by setProperty and assignPerson actions
+	// required: false
+	PropertyID string `json:"propertyId,omitempty"`
+
+	// The value set by setProperty actions, or the id of the user assigned by assignPerson actions
+	// required: false
+	Value string `json:"value,omitempty"`
+
+	// The id of the destination board of moveCard actions
+	// required: false
+	BoardID string `json:"boardId,omitempty"`
+
+	// The id of the channel of postToChannel actions
+	// required: false
+	ChannelID string `json:"channelId,omitempty"`
+
+	// The text of postToChannel and addComment actions
+	// required: false
+	Message string `json:"message,omitempty"`
+}
+
+// IsSideEffect returns true for the actions that are executed after the
+// triggering change has been saved, as opposed to the property actions that are
+// saved along with it.
+func (ra RuleAction) IsSideEffect() bool {
+	return ra.Type != RuleActionSetProperty && ra.Type != RuleActionAssignPerson
+}
+
+func (ra RuleAction) IsValid() error {
+	switch ra.Type {
+	case RuleActionSetProperty:
+		if ra.PropertyID == "" {
+			return ErrInvalidBoardRule{"missing property id for setProperty action"}
+		}
+	case RuleActionAssignPerson:
+		if ra.PropertyID == "" {
+			return ErrInvalidBoardRule{"missing property id for assignPerson action"}
+		}
+		if err := IsValidId(ra.Value); err != nil {
+			return ErrInvalidBoardRule{"invalid user id for assignPerson action: " + err.Error()}
+		}
+	case RuleActionMoveCard:
+		if err := IsValidId(ra.BoardID); err != nil {
+			return ErrInvalidBoardRule{"invalid board id for moveCard action: " + err.Error()}
+		}
+	case RuleActionPostToChannel:
+		if err := IsValidId(ra.ChannelID); err != nil {
+			return ErrInvalidBoardRule{"invalid channel id for postToChannel action: " + err.Error()}
+		}
+		if ra.Message == "" {
+			return ErrInvalidBoardRule{"missing message for postToChannel action"}
+		}
+	case RuleActionAddComment:
+		if ra.Message == "" {
+			return ErrInvalidBoardRule{"missing message for addComment action"}
+		}
+	default:
+		return ErrInvalidBoardRule{fmt.Sprintf("unsupported action type %q", ra.Type)}
+	}
+	return nil
+}
+
+func (br *BoardRule) IsValid() error {
+	if br == nil {
+		return ErrInvalidBoardRule{"cannot be nil"}
+	}
+
+	if err := IsValidId(br.BoardID); err != nil {
+		return ErrInvalidBoardRule{"invalid board id: " + err.Error()}
+	}
+
+	if br.Trigger.PropertyID == "" {
+		return ErrInvalidBoardRule{"missing trigger property id"}
+	}
+
+	if len(br.Actions) == 0 {
+		return ErrInvalidBoardRule{"missing actions"}
+	}
+	if len(br.Actions) > BoardRuleMaxActions {
+		return ErrInvalidBoardRule{fmt.Sprintf("too many actions, the maximum is %d", BoardRuleMaxActions)}
+	}
+
+	moves := 0
+	for _, action := range br.Actions {
+		if err := action.IsValid(); err != nil {
+			return err
+		}
+		if action.Type == RuleActionMoveCard {
+			moves++
+		}
+	}
+	if moves > 1 {
+		return ErrInvalidBoardRule{"a rule can move the card only once"}
+	}
+	return nil
+}
+
+// IsTriggered returns true if the change of the card properties from the old to
+// the new values fires the rule.
+func (br *BoardRule) IsTriggered(oldProperties, newProperties map[string]interface{}) bool {
+	if !br.Enabled {
+		return false
+	}
+	id := br.Trigger.PropertyID
+	return !propertyHasValue(oldProperties[id], br.Trigger.Value) && propertyHasValue(newProperties[id], br.Trigger.Value)
+}
+
+func BoardRuleFromJSON(data io.Reader) (*BoardRule, error) {
+	var rule BoardRule
+	if err := json.NewDecoder(data).Decode(&rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// EvaluateBoardRules returns the card properties resulting from applying the
+// property actions of the rules fired by the change of the card properties, and
+// the list of fired rules in the order they fired.
+//
+// The property actions of a fired rule can fire other rules, but every rule
+// fires at most once per evaluation, so chained rules cannot loop forever.
+func EvaluateBoardRules(board *Board, rules []*BoardRule, oldProperties, newProperties map[string]interface{}) (map[string]interface{}, []*BoardRule) {
+	properties := make(map[string]interface{}, len(newProperties))
+	for id, value := range newProperties {
+		properties[id] = value
+	}
+
+	schema, err := ParsePropertySchema(board)
+	if err != nil {
+		schema = PropSchema{}
+	}
+
+	fired := []*BoardRule{}
+	firedIDs := map[string]bool{}
+	for {
+		changed := false
+		for _, rule := range rules {
+			if firedIDs[rule.ID] || !rule.IsTriggered(oldProperties, properties) {
+				continue
+			}
+			firedIDs[rule.ID] = true
+			fired = append(fired, rule)
+
+			for _, action := range rule.Actions {
+				if applyRulePropertyAction(schema, properties, action) {
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			return properties, fired
+		}
+	}
+}
+
+// applyRulePropertyAction applies a setProperty or assignPerson action to the
+// card properties and returns true if they changed.
+func applyRulePropertyAction(schema PropSchema, properties map[string]interface{}, action RuleAction) bool {
+	switch action.Type {
+	case RuleActionSetProperty:
+		if action.Value == "" {
+			if _, ok := properties[action.PropertyID]; !ok {
+				return false
+			}
+			delete(properties, action.PropertyID)
+			return true
+		}
+		if s, ok := properties[action.PropertyID].(string); ok && s == action.Value {
+			return false
+		}
+		properties[action.PropertyID] = action.Value
+		return true
+	case RuleActionAssignPerson:
+		current := properties[action.PropertyID]
+		if propertyHasValue(current, action.Value) {
+			return false
+		}
+		if pd, ok := schema[action.PropertyID]; ok && pd.Type == PropTypeMultiPerson {
+			values, _ := stringSliceValue(current)
+			properties[action.PropertyID] = append(append([]string{}, values...), action.Value)
+			return true
+		}
+		properties[action.PropertyID] = action.Value
+		return true
+	}
+	return false
+}
+
+// propertyHasValue returns true if the property value is the given value or,
+// for multi value properties, contains it.
+func propertyHasValue(v interface{}, value string) bool {
+	if values, ok := stringSliceValue(v); ok {
+		for _, val := range values {
+			if val == value {
+				return true
+			}
+		}
+		return false
+	}
+	s, ok := v.(string)
+	return ok && s == value
+}
+
+type ErrInvalidBoardRule struct {
+	msg string
+}
+
+func (e ErrInvalidBoardRule) Error() string {
+	return e.msg
+}
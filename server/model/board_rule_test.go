@@ -0,0 +1,149 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardRuleIsValid(t *testing.T) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	validRule := func() *BoardRule {
+		return &BoardRule{
+			BoardID: boardID,
+			Trigger: RuleTrigger{PropertyID: "status", Value: "done"},
+			Actions: []RuleAction{{Type: RuleActionAddComment, Message: "Done!"}},
+		}
+	}
+
+	t.Run("valid rule", func(t *testing.T) {
+		require.NoError(t, validRule().IsValid())
+	})
+
+	t.Run("missing trigger property", func(t *testing.T) {
+		rule := validRule()
+		rule.Trigger.PropertyID = ""
+		require.ErrorAs(t, rule.IsValid(), &ErrInvalidBoardRule{})
+	})
+
+	t.Run("missing actions", func(t *testing.T) {
+		rule := validRule()
+		rule.Actions = nil
+		require.ErrorAs(t, rule.IsValid(), &ErrInvalidBoardRule{})
+	})
+
+	t.Run("unsupported action", func(t *testing.T) {
+		rule := validRule()
+		rule.Actions = []RuleAction{{Type: "deleteCard"}}
+		require.ErrorAs(t, rule.IsValid(), &ErrInvalidBoardRule{})
+	})
+
+	t.Run("invalid action", func(t *testing.T) {
+		rule := validRule()
+		rule.Actions = []RuleAction{{Type: RuleActionPostToChannel, ChannelID: "not-an-id", Message: "hi"}}
+		require.ErrorAs(t, rule.IsValid(), &ErrInvalidBoardRule{})
+	})
+
+	t.Run("several moves", func(t *testing.T) {
+		rule := validRule()
+		rule.Actions = []RuleAction{
+			{Type: RuleActionMoveCard, BoardID: utils.NewID(utils.IDTypeBoard)},
+			{Type: RuleActionMoveCard, BoardID: utils.NewID(utils.IDTypeBoard)},
+		}
+		require.ErrorAs(t, rule.IsValid(), &ErrInvalidBoardRule{})
+	})
+}
+
+func TestEvaluateBoardRules(t *testing.T) {
+	userID := utils.NewID(utils.IDTypeUser)
+	board := &Board{
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": "select"},
+			{"id": "priority", "name": "Priority", "type": "select"},
+			{"id": "owners", "name": "Owners", "type": PropTypeMultiPerson},
+		},
+	}
+
+	statusDone := &BoardRule{
+		ID:      "rule-1",
+		Enabled: true,
+		Trigger: RuleTrigger{PropertyID: "status", Value: "done"},
+		Actions: []RuleAction{
+			{Type: RuleActionSetProperty, PropertyID: "priority", Value: "low"},
+			{Type: RuleActionAddComment, Message: "Done!"},
+		},
+	}
+	priorityLow := &BoardRule{
+		ID:      "rule-2",
+		Enabled: true,
+		Trigger: RuleTrigger{PropertyID: "priority", Value: "low"},
+		Actions: []RuleAction{
+			{Type: RuleActionAssignPerson, PropertyID: "owners", Value: userID},
+		},
+	}
+
+	t.Run("no rule fired", func(t *testing.T) {
+		oldProperties := map[string]interface{}{"status": "todo"}
+		newProperties := map[string]interface{}{"status": "doing"}
+
+		properties, fired := EvaluateBoardRules(board, []*BoardRule{statusDone}, oldProperties, newProperties)
+		require.Empty(t, fired)
+		require.Equal(t, newProperties, properties)
+	})
+
+	t.Run("value already set", func(t *testing.T) {
+		oldProperties := map[string]interface{}{"status": "done"}
+		newProperties := map[string]interface{}{"status": "done", "priority": "high"}
+
+		_, fired := EvaluateBoardRules(board, []*BoardRule{statusDone}, oldProperties, newProperties)
+		require.Empty(t, fired)
+	})
+
+	t.Run("chained rules", func(t *testing.T) {
+		oldProperties := map[string]interface{}{"status": "todo", "owners": []interface{}{"someone"}}
+		newProperties := map[string]interface{}{"status": "done", "owners": []interface{}{"someone"}}
+
+		properties, fired := EvaluateBoardRules(board, []*BoardRule{priorityLow, statusDone}, oldProperties, newProperties)
+		require.Equal(t, []*BoardRule{statusDone, priorityLow}, fired)
+		require.Equal(t, "low", properties["priority"])
+		require.Equal(t, []string{"someone", userID}, properties["owners"])
+		require.NotContains(t, newProperties, "priority", "the new properties are not modified")
+	})
+
+	t.Run("disabled rule", func(t *testing.T) {
+		disabled := *statusDone
+		disabled.Enabled = false
+
+		oldProperties := map[string]interface{}{}
+		newProperties := map[string]interface{}{"status": "done"}
+
+		_, fired := EvaluateBoardRules(board, []*BoardRule{&disabled}, oldProperties, newProperties)
+		require.Empty(t, fired)
+	})
+
+	t.Run("rules setting each other do not loop", func(t *testing.T) {
+		toHigh := &BoardRule{
+			ID:      "rule-3",
+			Enabled: true,
+			Trigger: RuleTrigger{PropertyID: "priority", Value: "low"},
+			Actions: []RuleAction{{Type: RuleActionSetProperty, PropertyID: "priority", Value: "high"}},
+		}
+		toLow := &BoardRule{
+			ID:      "rule-4",
+			Enabled: true,
+			Trigger: RuleTrigger{PropertyID: "priority", Value: "high"},
+			Actions: []RuleAction{{Type: RuleActionSetProperty, PropertyID: "priority", Value: "low"}},
+		}
+
+		oldProperties := map[string]interface{}{"priority": "medium"}
+		newProperties := map[string]interface{}{"priority": "low"}
+
+		properties, fired := EvaluateBoardRules(board, []*BoardRule{toHigh, toLow}, oldProperties, newProperties)
+		require.Len(t, fired, 2)
+		require.Equal(t, "low", properties["priority"])
+	})
+}
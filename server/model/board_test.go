@@ -181,6 +181,19 @@ func TestBoardIsValidForImport(t *testing.T) {
 	})
 }
 
+func TestBoardIsVisibleToChannel(t *testing.T) {
+	board := &Board{ID: "board-id", TeamID: "team-id", Type: BoardTypePrivate, ChannelID: "linked-id"}
+
+	require.True(t, board.IsVisibleToChannel(&model.Channel{Id: "linked-id", TeamId: "team-id"}))
+	require.False(t, board.IsVisibleToChannel(&model.Channel{Id: "channel-id", TeamId: "team-id"}))
+	require.False(t, board.IsVisibleToChannel(nil))
+
+	board.Type = BoardTypeOpen
+	require.True(t, board.IsVisibleToChannel(&model.Channel{Id: "channel-id", TeamId: "team-id"}))
+	require.False(t, board.IsVisibleToChannel(&model.Channel{Id: "channel-id", TeamId: "other-team-id"}))
+	require.False(t, board.IsVisibleToChannel(&model.Channel{Id: "dm-id"}))
+}
+
 func TestBoardPatchIsValid(t *testing.T) {
 	t.Run("Should return nil for valid board patch with channel ID", func(t *testing.T) {
 		validChannelID := model.NewId()
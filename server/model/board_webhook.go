@@ -0,0 +1,297 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"reflect"
+	"sort"
+)
+
+const (
+	WebhookEventCardCreated     = "card.created"
+	WebhookEventPropertyChanged = "card.propertyChanged"
+	WebhookEventCommentAdded    = "comment.added"
+	WebhookEventBoardDeleted    = "board.deleted"
+)
+
+const (
+	WebhookDeliveryPending = "pending"
+	WebhookDeliverySuccess = "success"
+	WebhookDeliveryFailed  = "failed"
+)
+
+const (
+	// WebhookDeliveryMaxAttempts is the number of times a delivery is attempted
+	// before it is marked as failed.
+	WebhookDeliveryMaxAttempts = 8
+
+	// webhookRetryBaseDelay is the delay before the first retry of a delivery,
+	// in milliseconds. It doubles with every failed attempt.
+	webhookRetryBaseDelay = int64(30 * 1000)
+)
+
+var webhookEvents = map[string]bool{
+	WebhookEventCardCreated:     true,
+	WebhookEventPropertyChanged: true,
+	WebhookEventCommentAdded:    true,
+	WebhookEventBoardDeleted:    true,
+}
+
+// BoardWebhook is an outgoing webhook subscription of a board. The payloads
+// of the subscribed events are signed with the secret of the webhook.
+// swagger:model
+type BoardWebhook struct {
+	// The id of the webhook
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board of the webhook
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The http or https URL the events are posted to
+	// required: true
+	URL string `json:"url"`
+
+	// The secret the payloads are signed with. It is only returned when the webhook is created
+	// required: false
+	Secret string `json:"secret,omitempty"`
+
+	// The events the webhook is subscribed to
+	// required: true
+	Events []string `json:"events"`
+
+	// Whether events are posted to the webhook
+	// required: true
+	Enabled bool `json:"enabled"`
+
+	// The id of the user who created the webhook
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+func (w *BoardWebhook) IsValid() error {
+	if w == nil {
+		return ErrInvalidBoardWebhook{"cannot be nil"}
+	}
+
+	if err := IsValidId(w.BoardID); err != nil {
+		return ErrInvalidBoardWebhook{"invalid board id: " + err.Error()}
+	}
+
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidBoardWebhook{"invalid url: must be an absolute http or https URL"}
+	}
+
+	if len(w.Events) == 0 {
+		return ErrInvalidBoardWebhook{"at least one event is required"}
+	}
+	for _, event := range w.Events {
+		if !webhookEvents[event] {
+			return ErrInvalidBoardWebhook{"unsupported event: " + event}
+		}
+	}
+	return nil
+}
+
+// Subscribes returns whether events of the given type are posted to the webhook.
+func (w *BoardWebhook) Subscribes(event string) bool {
+	if !w.Enabled {
+		return false
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Sanitize removes the secret of the webhook.
+func (w *BoardWebhook) Sanitize() {
+	w.Secret = ""
+}
+
+func BoardWebhookFromJSON(data io.Reader) (*BoardWebhook, error) {
+	var webhook BoardWebhook
+	if err := json.NewDecoder(data).Decode(&webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// WebhookDelivery is an event queued for delivery to a webhook, along with
+// the outcome of its last attempt.
+// swagger:model
+type WebhookDelivery struct {
+	// The id of the delivery. Receivers can use it to detect retried deliveries
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the webhook
+	// required: true
+	WebhookID string `json:"webhookId"`
+
+	// The id of the board of the webhook
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The type of the delivered event
+	// required: true
+	Event string `json:"event"`
+
+	// The JSON payload posted to the webhook
+	// required: true
+	Payload string `json:"payload"`
+
+	// The state of the delivery: pending, success or failed
+	// required: true
+	Status string `json:"status"`
+
+	// The number of delivery attempts
+	// required: true
+	Attempts int `json:"attempts"`
+
+	// The time of the next attempt in milliseconds since the current epoch
+	// required: false
+	NextAttemptAt int64 `json:"nextAttemptAt"`
+
+	// The time of the last attempt in milliseconds since the current epoch
+	// required: false
+	LastAttemptAt int64 `json:"lastAttemptAt"`
+
+	// The HTTP status code of the last attempt
+	// required: false
+	ResponseCode int `json:"responseCode"`
+
+	// The error of the last attempt
+	// required: false
+	Error string `json:"error"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// RecordAttempt updates the delivery with the outcome of an attempt made at
+// the given time, scheduling a retry if the attempt failed.
+func (d *WebhookDelivery) RecordAttempt(at int64, responseCode int, err error) {
+	d.Attempts++
+	d.LastAttemptAt = at
+	d.ResponseCode = responseCode
+	d.Error = ""
+	if err != nil {
+		d.Error = err.Error()
+	}
+
+	switch {
+	case err == nil && responseCode >= 200 && responseCode < 300:
+		d.Status = WebhookDeliverySuccess
+		d.NextAttemptAt = 0
+	case d.Attempts >= WebhookDeliveryMaxAttempts:
+		d.Status = WebhookDeliveryFailed
+		d.NextAttemptAt = 0
+	default:
+		d.Status = WebhookDeliveryPending
+		d.NextAttemptAt = at + WebhookRetryDelay(d.Attempts)
+	}
+}
+
+// Abandon marks a delivery that cannot be attempted anymore as failed, with
+// the reason as its error.
+func (d *WebhookDelivery) Abandon(reason string) {
+	d.Status = WebhookDeliveryFailed
+	d.NextAttemptAt = 0
+	d.Error = reason
+}
+
+// WebhookRetryDelay returns the delay before retrying a delivery that failed
+// the given number of times, in milliseconds.
+func WebhookRetryDelay(attempts int) int64 {
+	if attempts < 1 {
+		return 0
+	}
+	return webhookRetryBaseDelay << (attempts - 1)
+}
+
+// WebhookPayload is the body posted to a webhook.
+// swagger:model
+type WebhookPayload struct {
+	// The type of the event
+	// required: true
+	Event string `json:"event"`
+
+	// The id of the team of the board
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The time of the event in milliseconds since the current epoch
+	// required: true
+	Timestamp int64 `json:"timestamp"`
+
+	// The board, for board events
+	// required: false
+	Board *Board `json:"board,omitempty"`
+
+	// The card the event refers to
+	// required: false
+	Card *Block `json:"card,omitempty"`
+
+	// The added comment, for comment events
+	// required: false
+	Comment *Block `json:"comment,omitempty"`
+
+	// The ids of the changed properties, for property change events
+	// required: false
+	ChangedProperties []string `json:"changedProperties,omitempty"`
+}
+
+// ChangedCardProperties returns the ids of the properties that differ between
+// two versions of a card.
+func ChangedCardProperties(oldCard, newCard *Block) []string {
+	oldProperties, _ := oldCard.Fields["properties"].(map[string]interface{})
+	newProperties, _ := newCard.Fields["properties"].(map[string]interface{})
+
+	changed := []string{}
+	for id, value := range newProperties {
+		if !reflect.DeepEqual(oldProperties[id], value) {
+			changed = append(changed, id)
+		}
+	}
+	for id := range oldProperties {
+		if _, ok := newProperties[id]; !ok {
+			changed = append(changed, id)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+type ErrInvalidBoardWebhook struct {
+	msg string
+}
+
+func (e ErrInvalidBoardWebhook) Error() string {
+	return e.msg
+}
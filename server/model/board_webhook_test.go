@@ -0,0 +1,108 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardWebhookIsValid(t *testing.T) {
+	newWebhook := func() *BoardWebhook {
+		return &BoardWebhook{
+			ID:      utils.NewID(utils.IDTypeBlock),
+			BoardID: utils.NewID(utils.IDTypeBoard),
+			URL:     "https://example.com/hook",
+			Events:  []string{WebhookEventCardCreated},
+		}
+	}
+
+	t.Run("valid webhook", func(t *testing.T) {
+		require.NoError(t, newWebhook().IsValid())
+	})
+
+	t.Run("invalid board id", func(t *testing.T) {
+		webhook := newWebhook()
+		webhook.BoardID = "invalid"
+		require.Error(t, webhook.IsValid())
+	})
+
+	t.Run("invalid urls", func(t *testing.T) {
+		for _, url := range []string{"", "example.com/hook", "ftp://example.com", "https://"} {
+			webhook := newWebhook()
+			webhook.URL = url
+			require.Error(t, webhook.IsValid(), url)
+		}
+	})
+
+	t.Run("no events", func(t *testing.T) {
+		webhook := newWebhook()
+		webhook.Events = nil
+		require.Error(t, webhook.IsValid())
+	})
+
+	t.Run("unsupported event", func(t *testing.T) {
+		webhook := newWebhook()
+		webhook.Events = []string{WebhookEventCardCreated, "card.deleted"}
+		require.Error(t, webhook.IsValid())
+	})
+}
+
+func TestBoardWebhookSubscribes(t *testing.T) {
+	webhook := &BoardWebhook{
+		Events:  []string{WebhookEventCardCreated},
+		Enabled: true,
+	}
+	require.True(t, webhook.Subscribes(WebhookEventCardCreated))
+	require.False(t, webhook.Subscribes(WebhookEventCommentAdded))
+
+	webhook.Enabled = false
+	require.False(t, webhook.Subscribes(WebhookEventCardCreated))
+}
+
+func TestWebhookDeliveryRecordAttempt(t *testing.T) {
+	t.Run("successful attempt", func(t *testing.T) {
+		delivery := &WebhookDelivery{Status: WebhookDeliveryPending, NextAttemptAt: 1000}
+		delivery.RecordAttempt(1000, 200, nil)
+		require.Equal(t, WebhookDeliverySuccess, delivery.Status)
+		require.Equal(t, 1, delivery.Attempts)
+		require.Equal(t, int64(0), delivery.NextAttemptAt)
+		require.Empty(t, delivery.Error)
+	})
+
+	t.Run("failed attempts are retried with exponential backoff", func(t *testing.T) {
+		delivery := &WebhookDelivery{Status: WebhookDeliveryPending}
+
+		delivery.RecordAttempt(1000, 500, errors.New("unexpected response status: 500"))
+		require.Equal(t, WebhookDeliveryPending, delivery.Status)
+		require.Equal(t, int64(1000)+webhookRetryBaseDelay, delivery.NextAttemptAt)
+		require.Equal(t, "unexpected response status: 500", delivery.Error)
+
+		delivery.RecordAttempt(2000, 0, errors.New("connection refused"))
+		require.Equal(t, WebhookDeliveryPending, delivery.Status)
+		require.Equal(t, int64(2000)+2*webhookRetryBaseDelay, delivery.NextAttemptAt)
+	})
+
+	t.Run("delivery fails after the last attempt", func(t *testing.T) {
+		delivery := &WebhookDelivery{Status: WebhookDeliveryPending, Attempts: WebhookDeliveryMaxAttempts - 1}
+		delivery.RecordAttempt(1000, 500, errors.New("unexpected response status: 500"))
+		require.Equal(t, WebhookDeliveryFailed, delivery.Status)
+		require.Equal(t, int64(0), delivery.NextAttemptAt)
+	})
+}
+
+func TestChangedCardProperties(t *testing.T) {
+	oldCard := &Block{Fields: map[string]interface{}{
+		"properties": map[string]interface{}{"status": "todo", "priority": "high", "owner": "user1"},
+	}}
+	newCard := &Block{Fields: map[string]interface{}{
+		"properties": map[string]interface{}{"status": "done", "priority": "high", "estimate": "3"},
+	}}
+
+	require.Equal(t, []string{"estimate", "owner", "status"}, ChangedCardProperties(oldCard, newCard))
+	require.Empty(t, ChangedCardProperties(oldCard, oldCard))
+}
@@ -76,6 +76,10 @@ type Card struct {
 	// required: false
 	Properties map[string]any `json:"properties"`
 
+	// The id of the post this card was created from, if any
+	// required: false
+	SourcePostID string `json:"sourcePostId,omitempty"`
+
 	// The creation time in milliseconds since the current epoch
 	// required: false
 	CreateAt int64 `json:"createAt"`
@@ -140,6 +144,18 @@ func (c *Card) CheckValid() error {
 	return nil
 }
 
+// CardFromPostOptions are the options for creating a card from a post.
+// swagger:model
+type CardFromPostOptions struct {
+	// The display title, the first line of the post by default
+	// required: false
+	Title string `json:"title"`
+
+	// True to add the replies of the post's thread as comments
+	// required: false
+	IncludeThread bool `json:"includeThread"`
+}
+
 // CardPatch is a patch for modifying cards
 // swagger:model
 type CardPatch struct {
@@ -187,6 +203,20 @@ func (p *CardPatch) Patch(card *Card) *Card {
 	return card
 }
 
+// ChangedPropertyIDs returns the ids of the properties updated by the patch.
+// It returns false if the patch changes anything else than property values.
+func (p *CardPatch) ChangedPropertyIDs() ([]string, bool) {
+	if p.Title != nil || p.ContentOrder != nil || p.Icon != nil {
+		return nil, false
+	}
+
+	propertyIDs := make([]string, 0, len(p.UpdatedProperties))
+	for id := range p.UpdatedProperties {
+		propertyIDs = append(propertyIDs, id)
+	}
+	return propertyIDs, true
+}
+
 // CheckValid returns an error if the CardPatch has invalid field values.
 func (p *CardPatch) CheckValid() error {
 	if p.Icon != nil && uniseg.GraphemeClusterCount(*p.Icon) > 1 {
@@ -203,6 +233,9 @@ func Card2Block(card *Card) *Block {
 	fields["icon"] = card.Icon
 	fields["isTemplate"] = card.IsTemplate
 	fields["properties"] = card.Properties
+	if card.SourcePostID != "" {
+		fields["sourcePostId"] = card.SourcePostID
+	}
 
 	return &Block{
 		ID:         card.ID,
@@ -230,6 +263,7 @@ func Block2Card(block *Block) (*Card, error) {
 	icon := ""
 	isTemplate := false
 	properties := make(map[string]any)
+	sourcePostID := ""
 
 	if co, ok := block.Fields["contentOrder"]; ok {
 		switch arr := co.(type) {
@@ -264,6 +298,14 @@ func Block2Card(block *Block) (*Card, error) {
 		}
 	}
 
+	if postIDAny, ok := block.Fields["sourcePostId"]; ok {
+		if postID, ok := postIDAny.(string); ok {
+			sourcePostID = postID
+		} else {
+			return nil, ErrInvalidFieldType{"sourcePostId"}
+		}
+	}
+
 	if props, ok := block.Fields["properties"]; ok {
 		if propMap, ok := props.(map[string]any); ok {
 			for k, v := range propMap {
@@ -284,6 +326,7 @@ func Block2Card(block *Block) (*Card, error) {
 		Icon:         icon,
 		IsTemplate:   isTemplate,
 		Properties:   properties,
+		SourcePostID: sourcePostID,
 		CreateAt:     block.CreateAt,
 		UpdateAt:     block.UpdateAt,
 		DeleteAt:     block.DeleteAt,
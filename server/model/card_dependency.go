@@ -0,0 +1,128 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+// CardDependency is a blocking relationship between two cards: the blocked card
+// cannot progress until the blocking card is done. The cards can belong to
+// different boards of the same team.
+// swagger:model
+type CardDependency struct {
+	// The id of the dependency
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the card that blocks the other card
+	// required: true
+	BlockingCardID string `json:"blockingCardId"`
+
+	// The id of the board of the blocking card
+	// required: true
+	BlockingBoardID string `json:"blockingBoardId"`
+
+	// The id of the card that is blocked
+	// required: true
+	BlockedCardID string `json:"blockedCardId"`
+
+	// The id of the board of the blocked card
+	// required: true
+	BlockedBoardID string `json:"blockedBoardId"`
+
+	// The id of the user who created the dependency
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+func (cd *CardDependency) IsValid() error {
+	if cd == nil {
+		return ErrInvalidCardDependency{"cannot be nil"}
+	}
+
+	if err := IsValidId(cd.BlockingCardID); err != nil {
+		return ErrInvalidCardDependency{"invalid blocking card id: " + err.Error()}
+	}
+
+	if err := IsValidId(cd.BlockedCardID); err != nil {
+		return ErrInvalidCardDependency{"invalid blocked card id: " + err.Error()}
+	}
+
+	if cd.BlockingCardID == cd.BlockedCardID {
+		return ErrInvalidCardDependency{"a card cannot block itself"}
+	}
+	return nil
+}
+
+// Involves returns true if the card is either side of the dependency.
+func (cd *CardDependency) Involves(cardID string) bool {
+	return cd.BlockingCardID == cardID || cd.BlockedCardID == cardID
+}
+
+// Cards returns the blocking and blocked card blocks of the dependency, with
+// only their ids, board ids and type set.
+func (cd *CardDependency) Cards() []*Block {
+	return []*Block{
+		{ID: cd.BlockingCardID, BoardID: cd.BlockingBoardID, Type: TypeCard},
+		{ID: cd.BlockedCardID, BoardID: cd.BlockedBoardID, Type: TypeCard},
+	}
+}
+
+// IsWithinBoard returns true if both cards of the dependency belong to the board.
+func (cd *CardDependency) IsWithinBoard(boardID string) bool {
+	return cd.BlockingBoardID == boardID && cd.BlockedBoardID == boardID
+}
+
+func CardDependencyFromJSON(data io.Reader) (*CardDependency, error) {
+	var dependency CardDependency
+	if err := json.NewDecoder(data).Decode(&dependency); err != nil {
+		return nil, err
+	}
+	return &dependency, nil
+}
+
+// RemapCardDependencies returns copies of the dependencies that link two cards present
+// in cardIDs, which maps old card ids to new ones, with new ids and the given board id.
+// It is used to carry dependencies over when the cards of a board are duplicated or
+// imported with new ids.
+func RemapCardDependencies(dependencies []*CardDependency, cardIDs map[string]string, boardID string) []*CardDependency {
+	remapped := make([]*CardDependency, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		blockingCardID, ok := cardIDs[dependency.BlockingCardID]
+		if !ok {
+			continue
+		}
+		blockedCardID, ok := cardIDs[dependency.BlockedCardID]
+		if !ok {
+			continue
+		}
+
+		remapped = append(remapped, &CardDependency{
+			ID:              utils.NewID(utils.IDTypeBlock),
+			BlockingCardID:  blockingCardID,
+			BlockingBoardID: boardID,
+			BlockedCardID:   blockedCardID,
+			BlockedBoardID:  boardID,
+			CreatedBy:       dependency.CreatedBy,
+			CreateAt:        dependency.CreateAt,
+		})
+	}
+	return remapped
+}
+
+type ErrInvalidCardDependency struct {
+	msg string
+}
+
+func (e ErrInvalidCardDependency) Error() string {
+	return e.msg
+}
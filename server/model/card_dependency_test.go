@@ -0,0 +1,74 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardDependencyIsValid(t *testing.T) {
+	cardID1 := utils.NewID(utils.IDTypeCard)
+	cardID2 := utils.NewID(utils.IDTypeCard)
+
+	t.Run("valid dependency", func(t *testing.T) {
+		dependency := &CardDependency{BlockingCardID: cardID1, BlockedCardID: cardID2}
+		require.NoError(t, dependency.IsValid())
+	})
+
+	t.Run("invalid card id", func(t *testing.T) {
+		dependency := &CardDependency{BlockingCardID: "not-an-id", BlockedCardID: cardID2}
+		require.ErrorAs(t, dependency.IsValid(), &ErrInvalidCardDependency{})
+	})
+
+	t.Run("card blocking itself", func(t *testing.T) {
+		dependency := &CardDependency{BlockingCardID: cardID1, BlockedCardID: cardID1}
+		require.ErrorAs(t, dependency.IsValid(), &ErrInvalidCardDependency{})
+	})
+
+	t.Run("nil dependency", func(t *testing.T) {
+		var dependency *CardDependency
+		require.ErrorAs(t, dependency.IsValid(), &ErrInvalidCardDependency{})
+	})
+}
+
+func TestRemapCardDependencies(t *testing.T) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	newBoardID := utils.NewID(utils.IDTypeBoard)
+	cardID1 := utils.NewID(utils.IDTypeCard)
+	cardID2 := utils.NewID(utils.IDTypeCard)
+	outsideCardID := utils.NewID(utils.IDTypeCard)
+	newCardID1 := utils.NewID(utils.IDTypeCard)
+	newCardID2 := utils.NewID(utils.IDTypeCard)
+
+	dependencies := []*CardDependency{
+		{
+			ID:              utils.NewID(utils.IDTypeBlock),
+			BlockingCardID:  cardID1,
+			BlockingBoardID: boardID,
+			BlockedCardID:   cardID2,
+			BlockedBoardID:  boardID,
+			CreatedBy:       "user-id",
+			CreateAt:        100,
+		},
+		{
+			ID:              utils.NewID(utils.IDTypeBlock),
+			BlockingCardID:  outsideCardID,
+			BlockingBoardID: utils.NewID(utils.IDTypeBoard),
+			BlockedCardID:   cardID1,
+			BlockedBoardID:  boardID,
+		},
+	}
+
+	remapped := RemapCardDependencies(dependencies, map[string]string{cardID1: newCardID1, cardID2: newCardID2}, newBoardID)
+	require.Len(t, remapped, 1)
+	require.NotEqual(t, dependencies[0].ID, remapped[0].ID)
+	require.Equal(t, newCardID1, remapped[0].BlockingCardID)
+	require.Equal(t, newCardID2, remapped[0].BlockedCardID)
+	require.True(t, remapped[0].IsWithinBoard(newBoardID))
+	require.Equal(t, "user-id", remapped[0].CreatedBy)
+	require.Equal(t, int64(100), remapped[0].CreateAt)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+const (
+	CardExportFormatCSV  = "csv"
+	CardExportFormatXLSX = "xlsx"
+)
+
+// CardExportOptions provides options when exporting the cards of a board as
+// a spreadsheet.
+type CardExportOptions struct {
+	BoardID string
+
+	// ViewID limits the export to the cards matching the filter of a view,
+	// sorted like the view, and to its visible properties. All the cards and
+	// properties of the board are exported if empty.
+	ViewID string
+
+	// Format is either CardExportFormatCSV or CardExportFormatXLSX.
+	Format string
+}
+
+func (o CardExportOptions) IsValid() error {
+	if o.BoardID == "" {
+		return ErrInvalidCardExportOptions{"board id is required"}
+	}
+
+	switch o.Format {
+	case CardExportFormatCSV, CardExportFormatXLSX:
+		return nil
+	}
+	return ErrInvalidCardExportOptions{"invalid format: " + o.Format}
+}
+
+// CardExportTable is the content of a cards export, a header row naming the
+// columns followed by a row per card.
+type CardExportTable struct {
+	Title string
+	Rows  [][]string
+
+	// NumericColumns are the indexes of the columns holding numbers.
+	NumericColumns map[int]bool
+}
+
+type ErrInvalidCardExportOptions struct {
+	msg string
+}
+
+func (e ErrInvalidCardExportOptions) Error() string {
+	return e.msg
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// MaxCardPreviewProperties is the number of properties shown in the preview of a card.
+const MaxCardPreviewProperties = 3
+
+// CardPreview is the summary of a card shown in the link previews of posts.
+// swagger:model
+type CardPreview struct {
+	// The id of the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The id of the board of the card
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The id of the team of the board
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The title of the card
+	// required: true
+	Title string `json:"title"`
+
+	// The icon of the card
+	// required: false
+	Icon string `json:"icon"`
+
+	// The title of the board of the card
+	// required: true
+	BoardTitle string `json:"boardTitle"`
+
+	// The key properties of the card with a value, in the order of the board
+	// required: true
+	Properties []CardPreviewProperty `json:"properties"`
+
+	// The number of properties with a value not shown in the preview
+	// required: true
+	MoreProperties int `json:"moreProperties"`
+
+	// The usernames of the users assigned to the card
+	// required: true
+	Assignees []string `json:"assignees"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+
+	// The username of the user who last modified the card
+	// required: true
+	UpdatedBy string `json:"updatedBy"`
+}
+
+// CardPreviewProperty is a property value of a card preview.
+// swagger:model
+type CardPreviewProperty struct {
+	// The id of the property
+	// required: true
+	ID string `json:"id"`
+
+	// The name of the property
+	// required: true
+	Name string `json:"name"`
+
+	// The value of the property as displayed
+	// required: true
+	Value string `json:"value"`
+
+	// The color of the selected option, for select properties
+	// required: false
+	Color string `json:"color,omitempty"`
+}
+
+// CardPreviewPost records a post showing the preview of a card, so that the
+// preview is refreshed when the card changes.
+type CardPreviewPost struct {
+	CardID   string `json:"cardId"`
+	PostID   string `json:"postId"`
+	CreateAt int64  `json:"createAt"`
+}
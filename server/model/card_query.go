@@ -0,0 +1,662 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// TitlePropertyID is the pseudo property id used by view filters to refer to the card title.
+	TitlePropertyID = "title"
+
+	// TitleSortPropertyID is the pseudo property id used by view sort options to refer to the card title.
+	TitleSortPropertyID = "__title"
+
+	// CardQueryDefaultPerPage is the page size used when a query doesn't specify one.
+	CardQueryDefaultPerPage = 100
+
+	// halfDayMillis is the tolerance used when comparing a day against a timestamp
+	// that includes the time, such as the card creation time.
+	halfDayMillis = 12 * 60 * 60 * 1000
+)
+
+const (
+	FilterOperationAnd = "and"
+	FilterOperationOr  = "or"
+)
+
+const (
+	FilterConditionIncludes      = "includes"
+	FilterConditionNotIncludes   = "notIncludes"
+	FilterConditionIsEmpty       = "isEmpty"
+	FilterConditionIsNotEmpty    = "isNotEmpty"
+	FilterConditionIsSet         = "isSet"
+	FilterConditionIsNotSet      = "isNotSet"
+	FilterConditionIs            = "is"
+	FilterConditionContains      = "contains"
+	FilterConditionNotContains   = "notContains"
+	FilterConditionStartsWith    = "startsWith"
+	FilterConditionNotStartsWith = "notStartsWith"
+	FilterConditionEndsWith      = "endsWith"
+	FilterConditionNotEndsWith   = "notEndsWith"
+	FilterConditionIsBefore      = "isBefore"
+	FilterConditionIsAfter       = "isAfter"
+)
+
+var validFilterConditions = map[string]bool{
+	FilterConditionIncludes:      true,
+	FilterConditionNotIncludes:   true,
+	FilterConditionIsEmpty:       true,
+	FilterConditionIsNotEmpty:    true,
+	FilterConditionIsSet:         true,
+	FilterConditionIsNotSet:      true,
+	FilterConditionIs:            true,
+	FilterConditionContains:      true,
+	FilterConditionNotContains:   true,
+	FilterConditionStartsWith:    true,
+	FilterConditionNotStartsWith: true,
+	FilterConditionEndsWith:      true,
+	FilterConditionNotEndsWith:   true,
+	FilterConditionIsBefore:      true,
+	FilterConditionIsAfter:       true,
+}
+
+var ErrInvalidFilterItem = errors.New("filter item must contain either a clause or a group")
+
+// ErrInvalidCardQuery is returned when a card query, its filters or its sort options are malformed.
+type ErrInvalidCardQuery struct {
+	msg string
+}
+
+func NewErrInvalidCardQuery(msg string) ErrInvalidCardQuery {
+	return ErrInvalidCardQuery{
+		msg: msg,
+	}
+}
+
+func (e ErrInvalidCardQuery) Error() string {
+	return fmt.Sprintf("invalid card query, %s", e.msg)
+}
+
+// FilterClause is a single condition evaluated against one card property.
+// swagger:model
+type FilterClause struct {
+	// The id of the property to evaluate, or "title" for the card title
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The condition to evaluate (includes, notIncludes, isEmpty, isNotEmpty, isSet, isNotSet, is,
+	// contains, notContains, startsWith, notStartsWith, endsWith, notEndsWith, isBefore, isAfter)
+	// required: true
+	Condition string `json:"condition"`
+
+	// The values the condition is evaluated against. Option ids for select properties, and
+	// timestamps in milliseconds for dates
+	// required: false
+	Values []string `json:"values"`
+}
+
+// FilterGroup combines filter clauses and nested groups with an "and" or an "or" operation.
+// It shares its JSON representation with the filter stored in view blocks.
+// swagger:model
+type FilterGroup struct {
+	// The operation used to combine the filters (and, or)
+	// required: true
+	Operation string `json:"operation"`
+
+	// The clauses and nested groups of this group
+	// required: false
+	Filters []FilterItem `json:"filters"`
+}
+
+// FilterItem is an entry of a FilterGroup and holds either a clause or a nested group.
+type FilterItem struct {
+	Clause *FilterClause
+	Group  *FilterGroup
+}
+
+func (fi FilterItem) MarshalJSON() ([]byte, error) {
+	if fi.Group != nil {
+		return json.Marshal(fi.Group)
+	}
+	if fi.Clause != nil {
+		return json.Marshal(fi.Clause)
+	}
+	return nil, ErrInvalidFilterItem
+}
+
+func (fi *FilterItem) UnmarshalJSON(data []byte) error {
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+
+	_, hasOperation := probe["operation"]
+	_, hasFilters := probe["filters"]
+	if hasOperation && hasFilters {
+		fi.Group = &FilterGroup{}
+		return json.Unmarshal(data, fi.Group)
+	}
+
+	fi.Clause = &FilterClause{}
+	return json.Unmarshal(data, fi.Clause)
+}
+
+// SortOption is a sort key applied to cards.
+// swagger:model
+type SortOption struct {
+	// The id of the property to sort by, or "__title" for the card title
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// True to sort in descending order
+	// required: false
+	Reversed bool `json:"reversed"`
+}
+
+// CardQuery describes a server side query over the cards of a board.
+// swagger:model
+type CardQuery struct {
+	// The filter tree that cards must satisfy
+	// required: false
+	Filter *FilterGroup `json:"filter"`
+
+	// The sort keys, in order of precedence
+	// required: false
+	SortOptions []SortOption `json:"sortOptions"`
+
+	// The page to select (default=0)
+	// required: false
+	Page int `json:"page"`
+
+	// Number of cards to return per page (default=100, -1 for all)
+	// required: false
+	PerPage int `json:"perPage"`
+}
+
+// IsValid returns an error if the query has invalid field values.
+func (q *CardQuery) IsValid() error {
+	if q.Page < 0 {
+		return NewErrInvalidCardQuery("page cannot be negative")
+	}
+	if q.Filter != nil {
+		if err := q.Filter.IsValid(); err != nil {
+			return err
+		}
+	}
+	for _, so := range q.SortOptions {
+		if so.PropertyID == "" {
+			return NewErrInvalidCardQuery("sort option propertyId is missing")
+		}
+	}
+	return nil
+}
+
+// IsValid returns an error if the group or any of its descendants is malformed.
+func (fg *FilterGroup) IsValid() error {
+	if fg.Operation != FilterOperationAnd && fg.Operation != FilterOperationOr {
+		return NewErrInvalidCardQuery(fmt.Sprintf("invalid filter operation %q", fg.Operation))
+	}
+	for _, item := range fg.Filters {
+		switch {
+		case item.Group != nil:
+			if err := item.Group.IsValid(); err != nil {
+				return err
+			}
+		case item.Clause != nil:
+			if err := item.Clause.IsValid(); err != nil {
+				return err
+			}
+		default:
+			return NewErrInvalidCardQuery(ErrInvalidFilterItem.Error())
+		}
+	}
+	return nil
+}
+
+// IsValid returns an error if the clause is malformed.
+func (fc *FilterClause) IsValid() error {
+	if fc.PropertyID == "" {
+		return NewErrInvalidCardQuery("filter propertyId is missing")
+	}
+	if !validFilterConditions[fc.Condition] {
+		return NewErrInvalidCardQuery(fmt.Sprintf("invalid filter condition %q", fc.Condition))
+	}
+	return nil
+}
+
+// Matches returns true if the card satisfies the filter group. An empty group
+// is always satisfied.
+func (fg *FilterGroup) Matches(card *Card, schema PropSchema) bool {
+	if len(fg.Filters) == 0 {
+		return true
+	}
+
+	if fg.Operation == FilterOperationOr {
+		for _, item := range fg.Filters {
+			if item.matches(card, schema) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, item := range fg.Filters {
+		if !item.matches(card, schema) {
+			return false
+		}
+	}
+	return true
+}
+
+func (fi FilterItem) matches(card *Card, schema PropSchema) bool {
+	if fi.Group != nil {
+		return fi.Group.Matches(card, schema)
+	}
+	if fi.Clause != nil {
+		return fi.Clause.Matches(card, schema)
+	}
+	return true
+}
+
+// Matches returns true if the card satisfies the clause. Conditions that need
+// values are ignored (always met) when no values are provided, as in the board views.
+func (fc *FilterClause) Matches(card *Card, schema PropSchema) bool {
+	propDef, hasDef := schema[fc.PropertyID]
+
+	var value interface{}
+	if fc.PropertyID == TitlePropertyID {
+		value = card.Title
+	} else {
+		value = card.Properties[fc.PropertyID]
+	}
+
+	var date *dateRange
+	if hasDef {
+		switch propDef.Type {
+		case PropTypeDate:
+			date = parseDateRange(value)
+		case PropTypeCreatedBy:
+			value = card.CreatedBy
+		case PropTypeUpdatedBy:
+			value = card.ModifiedBy
+		case PropTypeCreatedTime:
+			date = &dateRange{From: card.CreateAt}
+		case PropTypeUpdatedTime:
+			date = &dateRange{From: card.UpdateAt}
+		}
+	}
+	includesTime := hasDef && (propDef.Type == PropTypeCreatedTime || propDef.Type == PropTypeUpdatedTime)
+
+	switch fc.Condition {
+	case FilterConditionIncludes:
+		if len(fc.Values) == 0 {
+			return true
+		}
+		return valueIncludesAny(value, fc.Values)
+	case FilterConditionNotIncludes:
+		if len(fc.Values) == 0 {
+			return true
+		}
+		return !valueIncludesAny(value, fc.Values)
+	case FilterConditionIsEmpty:
+		return isEmptyValue(value)
+	case FilterConditionIsNotEmpty:
+		return !isEmptyValue(value)
+	case FilterConditionIsSet:
+		return isSetValue(value)
+	case FilterConditionIsNotSet:
+		return !isSetValue(value)
+	}
+
+	if len(fc.Values) == 0 {
+		return true
+	}
+	filterValue := fc.Values[0]
+
+	switch fc.Condition {
+	case FilterConditionIs:
+		if date != nil {
+			day, err := strconv.ParseInt(filterValue, 10, 64)
+			if err != nil {
+				return false
+			}
+			return date.is(day, includesTime)
+		}
+		return strings.EqualFold(stringValue(value), filterValue)
+	case FilterConditionContains:
+		return strings.Contains(lowerStringValue(value), strings.ToLower(filterValue))
+	case FilterConditionNotContains:
+		return !strings.Contains(lowerStringValue(value), strings.ToLower(filterValue))
+	case FilterConditionStartsWith:
+		return strings.HasPrefix(lowerStringValue(value), strings.ToLower(filterValue))
+	case FilterConditionNotStartsWith:
+		return !strings.HasPrefix(lowerStringValue(value), strings.ToLower(filterValue))
+	case FilterConditionEndsWith:
+		return strings.HasSuffix(lowerStringValue(value), strings.ToLower(filterValue))
+	case FilterConditionNotEndsWith:
+		return !strings.HasSuffix(lowerStringValue(value), strings.ToLower(filterValue))
+	case FilterConditionIsBefore, FilterConditionIsAfter:
+		if date == nil {
+			return false
+		}
+		day, err := strconv.ParseInt(filterValue, 10, 64)
+		if err != nil {
+			return false
+		}
+		if fc.Condition == FilterConditionIsBefore {
+			return date.isBefore(day, includesTime)
+		}
+		return date.isAfter(day, includesTime)
+	}
+	return true
+}
+
+// dateRange is the decoded value of a date property.
+type dateRange struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+// parseDateRange decodes a date property value, which is either a JSON snippet of the
+// form {"from":1642161600000,"to":1642161600000} or a single timestamp in milliseconds.
+func parseDateRange(v interface{}) *dateRange {
+	s := stringValue(v)
+	if s == "" {
+		return &dateRange{}
+	}
+	if ts, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return &dateRange{From: ts}
+	}
+	var dr dateRange
+	if err := json.Unmarshal([]byte(s), &dr); err != nil {
+		return &dateRange{}
+	}
+	return &dr
+}
+
+func (dr *dateRange) is(day int64, includesTime bool) bool {
+	if includesTime {
+		return dr.From != 0 && dr.From > day-halfDayMillis && dr.From < day+halfDayMillis
+	}
+	if dr.From != 0 && dr.To != 0 {
+		return dr.From <= day && dr.To >= day
+	}
+	return dr.From == day
+}
+
+func (dr *dateRange) isBefore(day int64, includesTime bool) bool {
+	if dr.From == 0 {
+		return false
+	}
+	if includesTime {
+		return dr.From < day-halfDayMillis
+	}
+	return dr.From < day
+}
+
+func (dr *dateRange) isAfter(day int64, includesTime bool) bool {
+	if includesTime {
+		return dr.From != 0 && dr.From > day+halfDayMillis
+	}
+	if dr.To != 0 {
+		return dr.To > day
+	}
+	return dr.From != 0 && dr.From > day
+}
+
+// stringSliceValue returns the values of a multi value property, or nil if
+// the value is not a slice.
+func stringSliceValue(v interface{}) ([]string, bool) {
+	switch arr := v.(type) {
+	case []string:
+		return arr, true
+	case []interface{}:
+		values := make([]string, 0, len(arr))
+		for _, item := range arr {
+			values = append(values, fmt.Sprintf("%v", item))
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+func stringValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	}
+	if arr, ok := stringSliceValue(v); ok {
+		return strings.Join(arr, ",")
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func lowerStringValue(v interface{}) string {
+	return strings.ToLower(stringValue(v))
+}
+
+func valueIncludesAny(v interface{}, values []string) bool {
+	arr, isSlice := stringSliceValue(v)
+	for _, fv := range values {
+		if isSlice {
+			for _, item := range arr {
+				if item == fv {
+					return true
+				}
+			}
+		} else if stringValue(v) == fv {
+			return true
+		}
+	}
+	return false
+}
+
+func isEmptyValue(v interface{}) bool {
+	if arr, ok := stringSliceValue(v); ok {
+		return len(arr) == 0
+	}
+	return stringValue(v) == ""
+}
+
+func isSetValue(v interface{}) bool {
+	switch val := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return val
+	case string:
+		return val != "" && val != "false"
+	}
+	return !isEmptyValue(v)
+}
+
+// SortCards sorts cards in place using the sort options in order of precedence. Cards
+// with an empty value are always placed last, and ties are broken by title, creation
+// time and id so the result is stable across queries. A resolver can optionally be
+// provided to sort person properties by username instead of user id.
+func SortCards(cards []*Card, sortOptions []SortOption, schema PropSchema, resolver PropValueResolver) {
+	s := &cardSorter{
+		schema:    schema,
+		resolver:  resolver,
+		usernames: make(map[string]string),
+	}
+
+	sort.SliceStable(cards, func(i, j int) bool {
+		a, b := cards[i], cards[j]
+		for _, so := range sortOptions {
+			if result := s.compare(a, b, so); result != 0 {
+				return result < 0
+			}
+		}
+		if result := compareTitleOrCreated(a, b); result != 0 {
+			return result < 0
+		}
+		return a.ID < b.ID
+	})
+}
+
+type cardSorter struct {
+	schema    PropSchema
+	resolver  PropValueResolver
+	usernames map[string]string
+}
+
+func (s *cardSorter) compare(a, b *Card, so SortOption) int {
+	var result int
+	if so.PropertyID == TitleSortPropertyID || so.PropertyID == TitlePropertyID {
+		result = compareTitleOrCreated(a, b)
+		if so.Reversed {
+			return -result
+		}
+		return result
+	}
+
+	propDef, ok := s.schema[so.PropertyID]
+	if !ok {
+		return 0
+	}
+
+	switch propDef.Type {
+	case PropTypeCreatedTime:
+		result = compareInt64(a.CreateAt, b.CreateAt)
+	case PropTypeUpdatedTime:
+		result = compareInt64(a.UpdateAt, b.UpdateAt)
+	case PropTypeNumber, PropTypeDate:
+		aValue, aOK := s.numericValue(propDef, a.Properties[so.PropertyID])
+		bValue, bOK := s.numericValue(propDef, b.Properties[so.PropertyID])
+		if emptyResult, decided := compareEmpty(!aOK, !bOK); decided {
+			return emptyResult
+		}
+		result = compareFloat64(aValue, bValue)
+	default:
+		aValue := s.sortValue(propDef, a)
+		bValue := s.sortValue(propDef, b)
+		if emptyResult, decided := compareEmpty(aValue == "", bValue == ""); decided {
+			return emptyResult
+		}
+		result = strings.Compare(strings.ToLower(aValue), strings.ToLower(bValue))
+	}
+
+	if so.Reversed {
+		return -result
+	}
+	return result
+}
+
+// compareEmpty orders empty values last regardless of the sort direction. It
+// returns false if neither value is empty and the values need to be compared.
+func compareEmpty(aEmpty, bEmpty bool) (int, bool) {
+	switch {
+	case aEmpty && bEmpty:
+		return 0, true
+	case aEmpty:
+		return 1, true
+	case bEmpty:
+		return -1, true
+	}
+	return 0, false
+}
+
+func (s *cardSorter) numericValue(propDef PropDef, v interface{}) (float64, bool) {
+	if propDef.Type == PropTypeDate {
+		dr := parseDateRange(v)
+		return float64(dr.From), dr.From != 0
+	}
+	str := stringValue(v)
+	if str == "" {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+func (s *cardSorter) sortValue(propDef PropDef, card *Card) string {
+	v := card.Properties[propDef.ID]
+
+	switch propDef.Type {
+	case PropTypeCreatedBy:
+		return s.username(card.CreatedBy)
+	case PropTypeUpdatedBy:
+		return s.username(card.ModifiedBy)
+	case PropTypeSelect, PropTypeMultiSelect:
+		optionID := stringValue(v)
+		if arr, ok := stringSliceValue(v); ok {
+			if len(arr) == 0 {
+				return ""
+			}
+			optionID = arr[0]
+		}
+		return propDef.Options[optionID].Value
+	case PropTypePerson:
+		return s.username(stringValue(v))
+	case PropTypeMultiPerson:
+		userIDs, _ := stringSliceValue(v)
+		usernames := make([]string, 0, len(userIDs))
+		for _, userID := range userIDs {
+			usernames = append(usernames, s.username(userID))
+		}
+		return strings.Join(usernames, ",")
+	}
+	return stringValue(v)
+}
+
+func (s *cardSorter) username(userID string) string {
+	if userID == "" || s.resolver == nil {
+		return userID
+	}
+	if username, ok := s.usernames[userID]; ok {
+		return username
+	}
+
+	username := userID
+	if user, err := s.resolver.GetUserByID(userID); err == nil && user != nil {
+		username = user.Username
+	}
+	s.usernames[userID] = username
+	return username
+}
+
+// compareTitleOrCreated orders cards by title, placing untitled cards last
+// ordered by creation time.
+func compareTitleOrCreated(a, b *Card) int {
+	if a.Title != "" && b.Title != "" {
+		return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	}
+	if emptyResult, decided := compareEmpty(a.Title == "", b.Title == ""); decided && emptyResult != 0 {
+		return emptyResult
+	}
+	return compareInt64(a.CreateAt, b.CreateAt)
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloat64(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,311 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	queryStatusPropID  = "status"
+	queryPointsPropID  = "points"
+	queryDuePropID     = "due"
+	queryTagsPropID    = "tags"
+	queryNotesPropID   = "notes"
+	queryStatusTodoID  = "todo"
+	queryStatusDoingID = "doing"
+	queryStatusDoneID  = "done"
+	queryTagBugID      = "bug"
+	queryTagFeatureID  = "feature"
+	queryDayMillis     = int64(24 * 60 * 60 * 1000)
+	queryJan10thMillis = int64(1641772800000)
+	queryJan20thMillis = queryJan10thMillis + 10*queryDayMillis
+	queryJan15thMillis = queryJan10thMillis + 5*queryDayMillis
+	queryCreatedPropID = "created"
+)
+
+func makeQuerySchema() PropSchema {
+	return PropSchema{
+		queryStatusPropID: {
+			ID:   queryStatusPropID,
+			Name: "Status",
+			Type: PropTypeSelect,
+			Options: map[string]PropDefOption{
+				queryStatusTodoID:  {ID: queryStatusTodoID, Value: "To Do"},
+				queryStatusDoingID: {ID: queryStatusDoingID, Value: "Doing"},
+				queryStatusDoneID:  {ID: queryStatusDoneID, Value: "Done"},
+			},
+		},
+		queryPointsPropID:  {ID: queryPointsPropID, Name: "Points", Type: PropTypeNumber},
+		queryDuePropID:     {ID: queryDuePropID, Name: "Due", Type: PropTypeDate},
+		queryNotesPropID:   {ID: queryNotesPropID, Name: "Notes", Type: PropTypeText},
+		queryCreatedPropID: {ID: queryCreatedPropID, Name: "Created", Type: PropTypeCreatedTime},
+		queryTagsPropID: {
+			ID:   queryTagsPropID,
+			Name: "Tags",
+			Type: PropTypeMultiSelect,
+			Options: map[string]PropDefOption{
+				queryTagBugID:     {ID: queryTagBugID, Value: "Bug"},
+				queryTagFeatureID: {ID: queryTagFeatureID, Value: "Feature"},
+			},
+		},
+	}
+}
+
+func makeQueryCards() []*Card {
+	return []*Card{
+		{
+			ID:       "card1",
+			Title:    "Fix login",
+			CreateAt: queryJan10thMillis,
+			Properties: map[string]any{
+				queryStatusPropID: queryStatusDoingID,
+				queryPointsPropID: "3",
+				queryDuePropID:    `{"from":1641772800000}`,
+				queryTagsPropID:   []any{queryTagBugID},
+				queryNotesPropID:  "Customer reported",
+			},
+		},
+		{
+			ID:       "card2",
+			Title:    "Add export",
+			CreateAt: queryJan20thMillis,
+			Properties: map[string]any{
+				queryStatusPropID: queryStatusTodoID,
+				queryPointsPropID: "8",
+				queryDuePropID:    `{"from":1642636800000}`,
+				queryTagsPropID:   []any{queryTagFeatureID, queryTagBugID},
+			},
+		},
+		{
+			ID:       "card3",
+			Title:    "Write docs",
+			CreateAt: queryJan15thMillis,
+			Properties: map[string]any{
+				queryStatusPropID: queryStatusDoneID,
+			},
+		},
+	}
+}
+
+func filteredCardIDs(fg *FilterGroup, cards []*Card, schema PropSchema) []string {
+	ids := []string{}
+	for _, card := range cards {
+		if fg.Matches(card, schema) {
+			ids = append(ids, card.ID)
+		}
+	}
+	return ids
+}
+
+func clause(propertyID, condition string, values ...string) FilterItem {
+	return FilterItem{Clause: &FilterClause{PropertyID: propertyID, Condition: condition, Values: values}}
+}
+
+func TestFilterGroupJSON(t *testing.T) {
+	viewFilterJSON := `{
+		"operation": "and",
+		"filters": [
+			{"propertyId": "status", "condition": "includes", "values": ["doing"]},
+			{"operation": "or", "filters": [
+				{"propertyId": "title", "condition": "contains", "values": ["fix"]}
+			]}
+		]
+	}`
+
+	var fg FilterGroup
+	require.NoError(t, json.Unmarshal([]byte(viewFilterJSON), &fg))
+	require.Len(t, fg.Filters, 2)
+	require.NotNil(t, fg.Filters[0].Clause)
+	require.Equal(t, queryStatusPropID, fg.Filters[0].Clause.PropertyID)
+	require.NotNil(t, fg.Filters[1].Group)
+	require.Equal(t, FilterOperationOr, fg.Filters[1].Group.Operation)
+	require.NoError(t, fg.IsValid())
+
+	data, err := json.Marshal(fg)
+	require.NoError(t, err)
+	assert.JSONEq(t, viewFilterJSON, string(data))
+}
+
+func TestCardQueryIsValid(t *testing.T) {
+	t.Run("valid query", func(t *testing.T) {
+		q := &CardQuery{
+			Filter:      &FilterGroup{Operation: FilterOperationAnd, Filters: []FilterItem{clause(queryStatusPropID, FilterConditionIsEmpty)}},
+			SortOptions: []SortOption{{PropertyID: TitleSortPropertyID}},
+		}
+		require.NoError(t, q.IsValid())
+	})
+
+	t.Run("invalid operation", func(t *testing.T) {
+		q := &CardQuery{Filter: &FilterGroup{Operation: "xor"}}
+		require.ErrorAs(t, q.IsValid(), &ErrInvalidCardQuery{})
+	})
+
+	t.Run("invalid nested condition", func(t *testing.T) {
+		q := &CardQuery{Filter: &FilterGroup{
+			Operation: FilterOperationAnd,
+			Filters: []FilterItem{
+				{Group: &FilterGroup{Operation: FilterOperationOr, Filters: []FilterItem{clause(queryStatusPropID, "isMaybe")}}},
+			},
+		}}
+		require.ErrorAs(t, q.IsValid(), &ErrInvalidCardQuery{})
+	})
+
+	t.Run("negative page", func(t *testing.T) {
+		q := &CardQuery{Page: -1}
+		require.ErrorAs(t, q.IsValid(), &ErrInvalidCardQuery{})
+	})
+}
+
+func TestFilterGroupMatches(t *testing.T) {
+	schema := makeQuerySchema()
+	cards := makeQueryCards()
+
+	testCases := []struct {
+		name     string
+		filter   *FilterGroup
+		expected []string
+	}{
+		{
+			name:     "empty group matches everything",
+			filter:   &FilterGroup{Operation: FilterOperationAnd},
+			expected: []string{"card1", "card2", "card3"},
+		},
+		{
+			name:     "select includes",
+			filter:   &FilterGroup{Operation: FilterOperationAnd, Filters: []FilterItem{clause(queryStatusPropID, FilterConditionIncludes, queryStatusDoingID, queryStatusDoneID)}},
+			expected: []string{"card1", "card3"},
+		},
+		{
+			name:     "multi select not includes",
+			filter:   &FilterGroup{Operation: FilterOperationAnd, Filters: []FilterItem{clause(queryTagsPropID, FilterConditionNotIncludes, queryTagFeatureID)}},
+			expected: []string{"card1", "card3"},
+		},
+		{
+			name:     "is empty",
+			filter:   &FilterGroup{Operation: FilterOperationAnd, Filters: []FilterItem{clause(queryTagsPropID, FilterConditionIsEmpty)}},
+			expected: []string{"card3"},
+		},
+		{
+			name:     "title contains ignores case",
+			filter:   &FilterGroup{Operation: FilterOperationAnd, Filters: []FilterItem{clause(TitlePropertyID, FilterConditionContains, "LOGIN")}},
+			expected: []string{"card1"},
+		},
+		{
+			name:     "text starts with",
+			filter:   &FilterGroup{Operation: FilterOperationAnd, Filters: []FilterItem{clause(queryNotesPropID, FilterConditionStartsWith, "customer")}},
+			expected: []string{"card1"},
+		},
+		{
+			name:     "date before",
+			filter:   &FilterGroup{Operation: FilterOperationAnd, Filters: []FilterItem{clause(queryDuePropID, FilterConditionIsBefore, "1642204800000")}},
+			expected: []string{"card1"},
+		},
+		{
+			name:     "date after",
+			filter:   &FilterGroup{Operation: FilterOperationAnd, Filters: []FilterItem{clause(queryDuePropID, FilterConditionIsAfter, "1642204800000")}},
+			expected: []string{"card2"},
+		},
+		{
+			name:     "date is",
+			filter:   &FilterGroup{Operation: FilterOperationAnd, Filters: []FilterItem{clause(queryDuePropID, FilterConditionIs, "1641772800000")}},
+			expected: []string{"card1"},
+		},
+		{
+			name:     "created time is uses half a day of tolerance",
+			filter:   &FilterGroup{Operation: FilterOperationAnd, Filters: []FilterItem{clause(queryCreatedPropID, FilterConditionIs, "1642204900000")}},
+			expected: []string{"card3"},
+		},
+		{
+			name:     "clause without values is ignored",
+			filter:   &FilterGroup{Operation: FilterOperationAnd, Filters: []FilterItem{clause(queryStatusPropID, FilterConditionIncludes)}},
+			expected: []string{"card1", "card2", "card3"},
+		},
+		{
+			name: "or group",
+			filter: &FilterGroup{Operation: FilterOperationOr, Filters: []FilterItem{
+				clause(queryStatusPropID, FilterConditionIncludes, queryStatusDoneID),
+				clause(queryTagsPropID, FilterConditionIncludes, queryTagFeatureID),
+			}},
+			expected: []string{"card2", "card3"},
+		},
+		{
+			name: "nested groups",
+			filter: &FilterGroup{Operation: FilterOperationAnd, Filters: []FilterItem{
+				clause(queryTagsPropID, FilterConditionIncludes, queryTagBugID),
+				{Group: &FilterGroup{Operation: FilterOperationOr, Filters: []FilterItem{
+					clause(queryStatusPropID, FilterConditionIncludes, queryStatusTodoID),
+					clause(queryPointsPropID, FilterConditionIs, "5"),
+				}}},
+			}},
+			expected: []string{"card2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, filteredCardIDs(tc.filter, cards, schema))
+		})
+	}
+}
+
+func TestSortCards(t *testing.T) {
+	schema := makeQuerySchema()
+
+	cardIDs := func(cards []*Card) []string {
+		ids := make([]string, 0, len(cards))
+		for _, card := range cards {
+			ids = append(ids, card.ID)
+		}
+		return ids
+	}
+
+	testCases := []struct {
+		name        string
+		sortOptions []SortOption
+		expected    []string
+	}{
+		{
+			name:     "no sort options orders by title",
+			expected: []string{"card2", "card1", "card3"},
+		},
+		{
+			name:        "title reversed",
+			sortOptions: []SortOption{{PropertyID: TitleSortPropertyID, Reversed: true}},
+			expected:    []string{"card3", "card1", "card2"},
+		},
+		{
+			name:        "select by option value",
+			sortOptions: []SortOption{{PropertyID: queryStatusPropID}},
+			expected:    []string{"card1", "card3", "card2"},
+		},
+		{
+			name:        "number puts empty values last",
+			sortOptions: []SortOption{{PropertyID: queryPointsPropID, Reversed: true}},
+			expected:    []string{"card2", "card1", "card3"},
+		},
+		{
+			name:        "date",
+			sortOptions: []SortOption{{PropertyID: queryDuePropID}},
+			expected:    []string{"card1", "card2", "card3"},
+		},
+		{
+			name:        "created time",
+			sortOptions: []SortOption{{PropertyID: queryCreatedPropID}},
+			expected:    []string{"card1", "card3", "card2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cards := makeQueryCards()
+			SortCards(cards, tc.sortOptions, schema, nil)
+			require.Equal(t, tc.expected, cardIDs(cards))
+		})
+	}
+}
@@ -0,0 +1,317 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+const (
+	RecurrenceFrequencyDaily   = "DAILY"
+	RecurrenceFrequencyWeekly  = "WEEKLY"
+	RecurrenceFrequencyMonthly = "MONTHLY"
+)
+
+var recurrenceWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// CardRecurrence is the recurrence rule of a card. Every time the rule fires
+// the card is duplicated into its board, with its date properties moved forward
+// by the time elapsed since the start of the recurrence.
+// swagger:model
+type CardRecurrence struct {
+	// The id of the recurring card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The id of the board of the recurring card
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The recurrence rule, in a subset of the iCalendar RRULE format (e.g. FREQ=WEEKLY;BYDAY=MO,WE)
+	// required: true
+	Rule string `json:"rule"`
+
+	// The time of the occurrence represented by the original card, in milliseconds since the current epoch
+	// required: true
+	StartAt int64 `json:"startAt"`
+
+	// The time the card will be duplicated next, in milliseconds since the current epoch
+	// required: false
+	NextRunAt int64 `json:"nextRunAt"`
+
+	// The time the card was last duplicated, in milliseconds since the current epoch
+	// required: false
+	LastRunAt int64 `json:"lastRunAt"`
+
+	// The id of the user who created the recurrence. Duplicated cards are created on their behalf
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+func (cr *CardRecurrence) IsValid() error {
+	if cr == nil {
+		return ErrInvalidCardRecurrence{"cannot be nil"}
+	}
+
+	if err := IsValidId(cr.CardID); err != nil {
+		return ErrInvalidCardRecurrence{"invalid card id: " + err.Error()}
+	}
+
+	if _, err := ParseRecurrenceRule(cr.Rule); err != nil {
+		return err
+	}
+
+	if cr.StartAt < 0 {
+		return ErrInvalidCardRecurrence{"invalid start time"}
+	}
+	return nil
+}
+
+// NextOccurrence returns the time of the first occurrence of the recurrence after
+// the given time, in milliseconds since the current epoch.
+func (cr *CardRecurrence) NextOccurrence(after int64) (int64, error) {
+	rule, err := ParseRecurrenceRule(cr.Rule)
+	if err != nil {
+		return 0, err
+	}
+
+	start := utils.GetTimeForMillis(cr.StartAt).UTC()
+	next := rule.Next(start, utils.GetTimeForMillis(after).UTC())
+	return utils.GetMillisForTime(next), nil
+}
+
+func CardRecurrenceFromJSON(data io.Reader) (*CardRecurrence, error) {
+	var recurrence CardRecurrence
+	if err := json.NewDecoder(data).Decode(&recurrence); err != nil {
+		return nil, err
+	}
+	return &recurrence, nil
+}
+
+// RecurrenceRule is a parsed recurrence rule. It supports the FREQ, INTERVAL,
+// BYDAY (weekly rules) and BYMONTHDAY (monthly rules) parts of an RRULE.
+type RecurrenceRule struct {
+	Frequency  string
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay int
+}
+
+// ParseRecurrenceRule parses a rule like FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR. An
+// optional RRULE: prefix is accepted.
+func ParseRecurrenceRule(s string) (*RecurrenceRule, error) {
+	s = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "RRULE:")
+	if s == "" {
+		return nil, ErrInvalidCardRecurrence{"missing recurrence rule"}
+	}
+
+	rule := &RecurrenceRule{Interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, ErrInvalidCardRecurrence{fmt.Sprintf("invalid recurrence rule part %q", part)}
+		}
+
+		switch key {
+		case "FREQ":
+			switch value {
+			case RecurrenceFrequencyDaily, RecurrenceFrequencyWeekly, RecurrenceFrequencyMonthly:
+				rule.Frequency = value
+			default:
+				return nil, ErrInvalidCardRecurrence{fmt.Sprintf("unsupported frequency %q", value)}
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 {
+				return nil, ErrInvalidCardRecurrence{fmt.Sprintf("invalid interval %q", value)}
+			}
+			rule.Interval = interval
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := recurrenceWeekdays[day]
+				if !ok {
+					return nil, ErrInvalidCardRecurrence{fmt.Sprintf("invalid week day %q", day)}
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			day, err := strconv.Atoi(value)
+			if err != nil || day < 1 || day > 31 {
+				return nil, ErrInvalidCardRecurrence{fmt.Sprintf("invalid month day %q", value)}
+			}
+			rule.ByMonthDay = day
+		default:
+			return nil, ErrInvalidCardRecurrence{fmt.Sprintf("unsupported recurrence rule part %q", key)}
+		}
+	}
+
+	if rule.Frequency == "" {
+		return nil, ErrInvalidCardRecurrence{"missing frequency"}
+	}
+	if len(rule.ByDay) > 0 && rule.Frequency != RecurrenceFrequencyWeekly {
+		return nil, ErrInvalidCardRecurrence{"BYDAY is only supported for weekly rules"}
+	}
+	if rule.ByMonthDay != 0 && rule.Frequency != RecurrenceFrequencyMonthly {
+		return nil, ErrInvalidCardRecurrence{"BYMONTHDAY is only supported for monthly rules"}
+	}
+
+	// week days are ordered from monday, the start of the week
+	sort.Slice(rule.ByDay, func(i, j int) bool {
+		return weekdayOffset(rule.ByDay[i]) < weekdayOffset(rule.ByDay[j])
+	})
+
+	return rule, nil
+}
+
+// Next returns the first occurrence of the rule strictly after the given time.
+// The start time is the first occurrence of the rule, and provides the time of
+// day of every occurrence as well as the default week day or month day.
+func (r *RecurrenceRule) Next(start, after time.Time) time.Time {
+	if after.Before(start) {
+		return start
+	}
+
+	switch r.Frequency {
+	case RecurrenceFrequencyWeekly:
+		return r.nextWeekly(start, after)
+	case RecurrenceFrequencyMonthly:
+		return r.nextMonthly(start, after)
+	default:
+		return r.nextDaily(start, after)
+	}
+}
+
+func (r *RecurrenceRule) nextDaily(start, after time.Time) time.Time {
+	days := int(after.Sub(start).Hours() / 24)
+	next := start.AddDate(0, 0, days-days%r.Interval)
+	for !next.After(after) {
+		next = next.AddDate(0, 0, r.Interval)
+	}
+	return next
+}
+
+func (r *RecurrenceRule) nextWeekly(start, after time.Time) time.Time {
+	days := r.ByDay
+	if len(days) == 0 {
+		days = []time.Weekday{start.Weekday()}
+	}
+
+	firstWeek := start.AddDate(0, 0, -weekdayOffset(start.Weekday()))
+	weeks := int(after.Sub(firstWeek).Hours() / (24 * 7))
+	for week := weeks - weeks%r.Interval; ; week += r.Interval {
+		for _, day := range days {
+			next := firstWeek.AddDate(0, 0, week*7+weekdayOffset(day))
+			if next.After(after) && !next.Before(start) {
+				return next
+			}
+		}
+	}
+}
+
+func (r *RecurrenceRule) nextMonthly(start, after time.Time) time.Time {
+	day := r.ByMonthDay
+	if day == 0 {
+		day = start.Day()
+	}
+
+	months := (after.Year()-start.Year())*12 + int(after.Month()-start.Month())
+	for month := months - months%r.Interval; ; month += r.Interval {
+		next := monthDay(start, month, day)
+		if next.After(after) && !next.Before(start) {
+			return next
+		}
+	}
+}
+
+// monthDay returns the given day of the month that is months after the start, at
+// the time of day of the start. Days past the end of the month fall on its last day.
+func monthDay(start time.Time, months int, day int) time.Time {
+	first := time.Date(start.Year(), start.Month()+time.Month(months), 1,
+		start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+	if lastDay := first.AddDate(0, 1, -1).Day(); day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// weekdayOffset returns the number of days between monday and the week day.
+func weekdayOffset(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// ShiftDateProperties returns a copy of the card properties with the values of
+// the date properties of the board moved by delta milliseconds.
+func ShiftDateProperties(board *Board, properties map[string]interface{}, delta int64) (map[string]interface{}, error) {
+	schema, err := ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	shifted := make(map[string]interface{}, len(properties))
+	for id, value := range properties {
+		shifted[id] = value
+
+		if pd, ok := schema[id]; !ok || pd.Type != PropTypeDate {
+			continue
+		}
+
+		s, ok := value.(string)
+		if !ok || s == "" {
+			continue
+		}
+
+		var dates map[string]int64
+		if err := json.Unmarshal([]byte(s), &dates); err != nil {
+			continue
+		}
+		for key, ts := range dates {
+			if ts != 0 {
+				dates[key] = ts + delta
+			}
+		}
+
+		data, err := json.Marshal(dates)
+		if err != nil {
+			return nil, err
+		}
+		shifted[id] = string(data)
+	}
+	return shifted, nil
+}
+
+type ErrInvalidCardRecurrence struct {
+	msg string
+}
+
+func (e ErrInvalidCardRecurrence) Error() string {
+	return e.msg
+}
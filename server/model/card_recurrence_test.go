@@ -0,0 +1,161 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecurrenceRule(t *testing.T) {
+	t.Run("weekly rule", func(t *testing.T) {
+		rule, err := ParseRecurrenceRule("RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=FR,MO")
+		require.NoError(t, err)
+		require.Equal(t, RecurrenceFrequencyWeekly, rule.Frequency)
+		require.Equal(t, 2, rule.Interval)
+		require.Equal(t, []time.Weekday{time.Monday, time.Friday}, rule.ByDay)
+	})
+
+	t.Run("default interval", func(t *testing.T) {
+		rule, err := ParseRecurrenceRule("freq=daily")
+		require.NoError(t, err)
+		require.Equal(t, RecurrenceFrequencyDaily, rule.Frequency)
+		require.Equal(t, 1, rule.Interval)
+	})
+
+	testCases := []struct {
+		name string
+		rule string
+	}{
+		{name: "empty rule", rule: ""},
+		{name: "missing frequency", rule: "INTERVAL=2"},
+		{name: "unsupported frequency", rule: "FREQ=YEARLY"},
+		{name: "invalid interval", rule: "FREQ=DAILY;INTERVAL=0"},
+		{name: "invalid week day", rule: "FREQ=WEEKLY;BYDAY=XX"},
+		{name: "invalid month day", rule: "FREQ=MONTHLY;BYMONTHDAY=32"},
+		{name: "week days on a monthly rule", rule: "FREQ=MONTHLY;BYDAY=MO"},
+		{name: "unsupported part", rule: "FREQ=DAILY;COUNT=3"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tc.rule)
+			require.ErrorAs(t, err, &ErrInvalidCardRecurrence{})
+			require.Nil(t, rule)
+		})
+	}
+}
+
+func TestRecurrenceRuleNext(t *testing.T) {
+	// a wednesday
+	start := time.Date(2024, time.January, 31, 9, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		rule     string
+		after    time.Time
+		expected time.Time
+	}{
+		{
+			name:     "before the start",
+			rule:     "FREQ=DAILY",
+			after:    start.Add(-time.Hour),
+			expected: start,
+		},
+		{
+			name:     "daily",
+			rule:     "FREQ=DAILY",
+			after:    start,
+			expected: time.Date(2024, time.February, 1, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "every third day after a few days",
+			rule:     "FREQ=DAILY;INTERVAL=3",
+			after:    time.Date(2024, time.February, 4, 12, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, time.February, 6, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekly on the start week day",
+			rule:     "FREQ=WEEKLY",
+			after:    start,
+			expected: time.Date(2024, time.February, 7, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekly on several days",
+			rule:     "FREQ=WEEKLY;BYDAY=MO,FR",
+			after:    start,
+			expected: time.Date(2024, time.February, 2, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "every other week on monday",
+			rule:     "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO",
+			after:    start,
+			expected: time.Date(2024, time.February, 12, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "monthly on a day missing from the next month",
+			rule:     "FREQ=MONTHLY",
+			after:    start,
+			expected: time.Date(2024, time.February, 29, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "monthly keeps the start day",
+			rule:     "FREQ=MONTHLY",
+			after:    time.Date(2024, time.February, 29, 9, 30, 0, 0, time.UTC),
+			expected: time.Date(2024, time.March, 31, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "quarterly on a month day",
+			rule:     "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=15",
+			after:    start,
+			expected: time.Date(2024, time.April, 15, 9, 30, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tc.rule)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, rule.Next(start, tc.after))
+		})
+	}
+}
+
+func TestCardRecurrenceIsValid(t *testing.T) {
+	t.Run("valid recurrence", func(t *testing.T) {
+		recurrence := &CardRecurrence{CardID: utils.NewID(utils.IDTypeCard), Rule: "FREQ=DAILY"}
+		require.NoError(t, recurrence.IsValid())
+	})
+
+	t.Run("invalid card id", func(t *testing.T) {
+		recurrence := &CardRecurrence{CardID: "not-an-id", Rule: "FREQ=DAILY"}
+		require.ErrorAs(t, recurrence.IsValid(), &ErrInvalidCardRecurrence{})
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		recurrence := &CardRecurrence{CardID: utils.NewID(utils.IDTypeCard), Rule: "FREQ=HOURLY"}
+		require.ErrorAs(t, recurrence.IsValid(), &ErrInvalidCardRecurrence{})
+	})
+}
+
+func TestShiftDateProperties(t *testing.T) {
+	board := &Board{
+		CardProperties: []map[string]interface{}{
+			{"id": "due", "name": "Due", "type": PropTypeDate},
+			{"id": "status", "name": "Status", "type": "select"},
+		},
+	}
+
+	properties := map[string]interface{}{
+		"due":    `{"from":1000,"to":2000}`,
+		"status": "done",
+	}
+
+	shifted, err := ShiftDateProperties(board, properties, 500)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"from":1500,"to":2500}`, shifted["due"].(string))
+	require.Equal(t, "done", shifted["status"])
+	require.Equal(t, `{"from":1000,"to":2000}`, properties["due"], "original properties are not modified")
+}
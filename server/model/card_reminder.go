@@ -0,0 +1,218 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+const (
+	// CardReminderKindUpcoming is the reminder sent before the due date of a
+	// card.
+	CardReminderKindUpcoming = "upcoming"
+
+	// CardReminderKindOverdue is the reminder sent when a card becomes
+	// overdue.
+	CardReminderKindOverdue = "overdue"
+
+	// CardReminderMaxHoursBefore bounds how long before the due date the
+	// upcoming reminder can be sent, 30 days.
+	CardReminderMaxHoursBefore = 30 * 24
+
+	// CardReminderOverdueWindow is how long after the due date a card is
+	// still reported as overdue, so that enabling reminders on a board does
+	// not report every card that was due long ago.
+	CardReminderOverdueWindow = 24 * time.Hour
+)
+
+// CardReminderSettings designate the date property holding the due date of
+// the cards of a board. The users assigned to a card through its person and
+// multi person properties are sent a direct message before the due date and
+// when the card becomes overdue.
+// swagger:model
+type CardReminderSettings struct {
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The id of the date property holding the due date of the cards
+	// required: true
+	DueDatePropertyID string `json:"dueDatePropertyId"`
+
+	// How many hours before the due date the upcoming reminder is sent, zero
+	// to only send overdue reminders
+	// required: true
+	HoursBefore int `json:"hoursBefore"`
+
+	// The id of the user who last modified the settings
+	// required: true
+	ModifiedBy string `json:"modifiedBy"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+func (s *CardReminderSettings) IsValid() error {
+	if s == nil {
+		return ErrInvalidCardReminderSettings{"cannot be nil"}
+	}
+
+	if err := IsValidId(s.BoardID); err != nil {
+		return ErrInvalidCardReminderSettings{"invalid board id: " + err.Error()}
+	}
+
+	if s.DueDatePropertyID == "" {
+		return ErrInvalidCardReminderSettings{"missing due date property id"}
+	}
+
+	if s.HoursBefore < 0 || s.HoursBefore > CardReminderMaxHoursBefore {
+		return ErrInvalidCardReminderSettings{"hours before must be between 0 and 720"}
+	}
+	return nil
+}
+
+// IsValidForSchema checks that the due date property is a date property of
+// the board.
+func (s *CardReminderSettings) IsValidForSchema(schema PropSchema) error {
+	prop, ok := schema[s.DueDatePropertyID]
+	if !ok {
+		return ErrInvalidCardReminderSettings{"unknown due date property: " + s.DueDatePropertyID}
+	}
+	if prop.Type != PropTypeDate {
+		return ErrInvalidCardReminderSettings{"due date property is not a date property: " + prop.Name}
+	}
+	return nil
+}
+
+func CardReminderSettingsFromJSON(data io.Reader) (*CardReminderSettings, error) {
+	var settings CardReminderSettings
+	if err := json.NewDecoder(data).Decode(&settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// CardReminder records a reminder sent to a user about a card, so that it is
+// sent only once for a given due date.
+type CardReminder struct {
+	CardID  string
+	BoardID string
+	UserID  string
+	Kind    string
+	DueAt   int64
+	SentAt  int64
+}
+
+// CardDueDate is the due date of a card, the value of a date property in
+// milliseconds since the current epoch. Dates without a time are stored at
+// noon UTC.
+type CardDueDate struct {
+	From        int64 `json:"from"`
+	To          int64 `json:"to"`
+	IncludeTime bool  `json:"includeTime"`
+}
+
+// GetCardDueDate returns the due date of a card, if its due date property is
+// set.
+func GetCardDueDate(card *Block, propertyID string) (*CardDueDate, bool) {
+	s, ok := getCardPropertyValue(card, propertyID).(string)
+	if !ok || s == "" {
+		return nil, false
+	}
+
+	var dueDate CardDueDate
+	if err := json.Unmarshal([]byte(s), &dueDate); err != nil {
+		return nil, false
+	}
+	if dueDate.value() == 0 {
+		return nil, false
+	}
+	return &dueDate, true
+}
+
+// value returns the end of a date range, or its start if it has no end.
+func (d *CardDueDate) value() int64 {
+	if d.To != 0 {
+		return d.To
+	}
+	return d.From
+}
+
+// DueAt returns the time the card is due in the given time zone. A date
+// without a time is due at the end of that day.
+func (d *CardDueDate) DueAt(loc *time.Location) int64 {
+	if d.IncludeTime {
+		return d.value()
+	}
+
+	day := utils.GetTimeForMillis(d.value()).UTC()
+	endOfDay := time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, loc)
+	return utils.GetMillisForTime(endOfDay)
+}
+
+// Format returns the due date as displayed to a user of the given time zone.
+func (d *CardDueDate) Format(loc *time.Location) string {
+	if d.IncludeTime {
+		return utils.GetTimeForMillis(d.value()).In(loc).Format("January 02, 2006 3:04 PM MST")
+	}
+	return utils.GetTimeForMillis(d.value()).UTC().Format("January 02, 2006")
+}
+
+// CardAssignees returns the ids of the users assigned to a card through its
+// person and multi person properties, sorted.
+func CardAssignees(card *Block, schema PropSchema) []string {
+	seen := map[string]bool{}
+	for _, prop := range schema {
+		switch value := getCardPropertyValue(card, prop.ID).(type) {
+		case string:
+			if prop.Type == PropTypePerson && value != "" {
+				seen[value] = true
+			}
+		case []interface{}:
+			if prop.Type != PropTypeMultiPerson {
+				continue
+			}
+			for _, item := range value {
+				if userID, ok := item.(string); ok && userID != "" {
+					seen[userID] = true
+				}
+			}
+		}
+	}
+
+	assignees := make([]string, 0, len(seen))
+	for userID := range seen {
+		assignees = append(assignees, userID)
+	}
+	sort.Strings(assignees)
+	return assignees
+}
+
+// CardReminderKindAt returns the reminder due for a card at the given time,
+// if any.
+func (s *CardReminderSettings) CardReminderKindAt(dueAt, now int64) (string, bool) {
+	switch {
+	case now >= dueAt:
+		if now-dueAt < CardReminderOverdueWindow.Milliseconds() {
+			return CardReminderKindOverdue, true
+		}
+	case s.HoursBefore > 0 && now >= dueAt-int64(s.HoursBefore)*time.Hour.Milliseconds():
+		return CardReminderKindUpcoming, true
+	}
+	return "", false
+}
+
+type ErrInvalidCardReminderSettings struct {
+	msg string
+}
+
+func (e ErrInvalidCardReminderSettings) Error() string {
+	return e.msg
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardReminderSettingsIsValid(t *testing.T) {
+	settings := &CardReminderSettings{BoardID: utils.NewID(utils.IDTypeBoard), DueDatePropertyID: "due", HoursBefore: 24}
+	require.NoError(t, settings.IsValid())
+
+	settings.HoursBefore = CardReminderMaxHoursBefore + 1
+	require.Error(t, settings.IsValid())
+
+	settings.HoursBefore = 0
+	settings.DueDatePropertyID = ""
+	require.Error(t, settings.IsValid())
+
+	schema := PropSchema{
+		"due":   {ID: "due", Name: "Due", Type: PropTypeDate},
+		"owner": {ID: "owner", Name: "Owner", Type: PropTypePerson},
+	}
+	settings.DueDatePropertyID = "due"
+	require.NoError(t, settings.IsValidForSchema(schema))
+	settings.DueDatePropertyID = "owner"
+	require.Error(t, settings.IsValidForSchema(schema))
+	settings.DueDatePropertyID = "deadline"
+	require.Error(t, settings.IsValidForSchema(schema))
+}
+
+func TestCardDueDate(t *testing.T) {
+	makeCard := func(value interface{}) *Block {
+		return &Block{Fields: map[string]interface{}{"properties": map[string]interface{}{"due": value}}}
+	}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	noon := time.Date(2024, time.May, 20, 12, 0, 0, 0, time.UTC).UnixMilli()
+
+	t.Run("date without a time", func(t *testing.T) {
+		dueDate, ok := GetCardDueDate(makeCard(`{"from":1716206400000}`), "due")
+		require.True(t, ok)
+		require.Equal(t, time.Date(2024, time.May, 21, 0, 0, 0, 0, tokyo).UnixMilli(), dueDate.DueAt(tokyo))
+		require.Equal(t, "May 20, 2024", dueDate.Format(tokyo))
+	})
+
+	t.Run("date range with a time", func(t *testing.T) {
+		dueDate, ok := GetCardDueDate(makeCard(`{"from":1000,"to":1716206400000,"includeTime":true}`), "due")
+		require.True(t, ok)
+		require.Equal(t, noon, dueDate.DueAt(tokyo))
+		require.Equal(t, "May 20, 2024 9:00 PM JST", dueDate.Format(tokyo))
+	})
+
+	t.Run("no due date", func(t *testing.T) {
+		_, ok := GetCardDueDate(makeCard(""), "due")
+		require.False(t, ok)
+		_, ok = GetCardDueDate(makeCard(`not json`), "due")
+		require.False(t, ok)
+		_, ok = GetCardDueDate(makeCard(nil), "due")
+		require.False(t, ok)
+	})
+}
+
+func TestCardReminderKindAt(t *testing.T) {
+	hour := time.Hour.Milliseconds()
+	settings := &CardReminderSettings{HoursBefore: 2}
+
+	testCases := []struct {
+		name string
+		now  int64
+		kind string
+		ok   bool
+	}{
+		{name: "too early", now: 10*hour - 3*hour},
+		{name: "upcoming", now: 10*hour - hour, kind: CardReminderKindUpcoming, ok: true},
+		{name: "overdue", now: 10*hour + hour, kind: CardReminderKindOverdue, ok: true},
+		{name: "overdue for too long", now: 10*hour + CardReminderOverdueWindow.Milliseconds()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kind, ok := settings.CardReminderKindAt(10*hour, tc.now)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.kind, kind)
+		})
+	}
+}
+
+func TestCardAssignees(t *testing.T) {
+	schema := PropSchema{
+		"owner":     {ID: "owner", Type: PropTypePerson},
+		"reviewers": {ID: "reviewers", Type: PropTypeMultiPerson},
+		"notes":     {ID: "notes", Type: PropTypeText},
+	}
+	card := &Block{Fields: map[string]interface{}{"properties": map[string]interface{}{
+		"owner":     "user2",
+		"reviewers": []interface{}{"user1", "user2"},
+		"notes":     "user3",
+	}}}
+
+	require.Equal(t, []string{"user1", "user2"}, CardAssignees(card, schema))
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+)
+
+const (
+	CardSearchDefaultPerPage = 20
+	CardSearchMaxPerPage     = 100
+)
+
+// The places of a card where a search term can be found.
+const (
+	CardSearchMatchTitle    = "title"
+	CardSearchMatchProperty = "property"
+	CardSearchMatchContent  = "content"
+	CardSearchMatchComment  = "comment"
+)
+
+// cardSearchWeights are the weights applied to the rank of each kind of match,
+// so that title matches rank above property, content and comment matches.
+var cardSearchWeights = map[string]float64{
+	CardSearchMatchTitle:    4,
+	CardSearchMatchProperty: 2,
+	CardSearchMatchContent:  1,
+	CardSearchMatchComment:  1,
+}
+
+// CardSearchWeight returns the rank weight of a kind of match.
+func CardSearchWeight(match string) float64 {
+	return cardSearchWeights[match]
+}
+
+// CardSearchOptions are the query options that can be passed to SearchCards.
+type CardSearchOptions struct {
+	BoardIDs []string // the boards to search in
+	Term     string   // the search term
+	Page     int      // page number to select when paginating
+	PerPage  int      // number of results per page
+}
+
+// SearchWords breaks the search term into lowercase, space separated words.
+func (o CardSearchOptions) SearchWords() []string {
+	return strings.Fields(strings.ToLower(o.Term))
+}
+
+// CardSearchResult is a card that matches a search term.
+// swagger:model
+type CardSearchResult struct {
+	// The card that matches the search term
+	// required: true
+	Card *Card `json:"card"`
+
+	// The relevance of the result, higher is more relevant
+	// required: true
+	Rank float64 `json:"rank"`
+
+	// The places of the card where the term was found (title, property, content, comment)
+	// required: true
+	Matches []string `json:"matches"`
+}
+
+// AddMatch records a match of a given kind and rank, weighting the rank by the kind of
+// the match. Each kind is listed only once in Matches.
+func (r *CardSearchResult) AddMatch(match string, rank float64) {
+	r.Rank += rank * CardSearchWeight(match)
+	for _, m := range r.Matches {
+		if m == match {
+			return
+		}
+	}
+	r.Matches = append(r.Matches, match)
+}
+
+// IsTextPropType returns true if values of a property type are free text that can be searched.
+func IsTextPropType(propType string) bool {
+	switch propType {
+	case PropTypeText, PropTypeNumber, PropTypeEmail, PropTypePhone, PropTypeURL:
+		return true
+	}
+	return false
+}
+
+// ContainsAllWords returns true if the text contains all of the lowercase words, ignoring case.
+func ContainsAllWords(text string, words []string) bool {
+	text = strings.ToLower(text)
+	for _, word := range words {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
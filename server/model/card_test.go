@@ -48,6 +48,17 @@ func TestBlock2Card(t *testing.T) {
 		assert.EqualValues(t, fields["properties"], card.Properties)
 	})
 
+	t.Run("Source post", func(t *testing.T) {
+		card, err := Block2Card(block)
+		require.NoError(t, err)
+		assert.Empty(t, card.SourcePostID)
+
+		card.SourcePostID = "post-id"
+		card, err = Block2Card(Card2Block(card))
+		require.NoError(t, err)
+		assert.Equal(t, "post-id", card.SourcePostID)
+	})
+
 	t.Run("Not a card", func(t *testing.T) {
 		blockNotCard := &Block{}
 
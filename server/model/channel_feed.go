@@ -0,0 +1,203 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+const (
+	// ChannelFeedEventCardCreated reports the cards added to the board.
+	ChannelFeedEventCardCreated = "cardCreated"
+
+	// ChannelFeedEventStatusChanged reports the cards moved to another
+	// option of the status property.
+	ChannelFeedEventStatusChanged = "statusChanged"
+
+	// ChannelFeedEventCardCompleted reports the cards moved to one of the
+	// done options of the status property.
+	ChannelFeedEventCardCompleted = "cardCompleted"
+
+	// ChannelFeedDefaultWindowMinutes is how long changes are batched when
+	// the settings do not say.
+	ChannelFeedDefaultWindowMinutes = 15
+
+	// ChannelFeedMaxWindowMinutes bounds how long changes are batched, a day.
+	ChannelFeedMaxWindowMinutes = 24 * 60
+)
+
+var channelFeedEvents = map[string]bool{
+	ChannelFeedEventCardCreated:   true,
+	ChannelFeedEventStatusChanged: true,
+	ChannelFeedEventCardCompleted: true,
+}
+
+// ChannelFeedSettings turn on the activity feed of a board: the changes to
+// the cards of the board are batched and posted to the channel linked to the
+// board.
+// swagger:model
+type ChannelFeedSettings struct {
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The changes posted to the channel: cardCreated, statusChanged and cardCompleted
+	// required: true
+	Events []string `json:"events"`
+
+	// The id of the select property holding the status of the cards, needed
+	// for the statusChanged and cardCompleted events
+	// required: false
+	StatusPropertyID string `json:"statusPropertyId"`
+
+	// The ids of the options of the status property of completed cards,
+	// the last option of the property by default
+	// required: false
+	DoneOptionIDs []string `json:"doneOptionIds"`
+
+	// How many minutes changes are batched before being posted
+	// required: true
+	WindowMinutes int `json:"windowMinutes"`
+
+	// The id of the user who last modified the settings
+	// required: true
+	ModifiedBy string `json:"modifiedBy"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+func (s *ChannelFeedSettings) IsValid() error {
+	if s == nil {
+		return ErrInvalidChannelFeedSettings{"cannot be nil"}
+	}
+
+	if err := IsValidId(s.BoardID); err != nil {
+		return ErrInvalidChannelFeedSettings{"invalid board id: " + err.Error()}
+	}
+
+	if len(s.Events) == 0 {
+		return ErrInvalidChannelFeedSettings{"missing events"}
+	}
+	for _, event := range s.Events {
+		if !channelFeedEvents[event] {
+			return ErrInvalidChannelFeedSettings{"unknown event: " + event}
+		}
+	}
+
+	if s.NeedsStatus() && s.StatusPropertyID == "" {
+		return ErrInvalidChannelFeedSettings{"status events need a status property"}
+	}
+
+	if s.WindowMinutes < 1 || s.WindowMinutes > ChannelFeedMaxWindowMinutes {
+		return ErrInvalidChannelFeedSettings{"window minutes must be between 1 and 1440"}
+	}
+	return nil
+}
+
+// IsValidForSchema checks that the status property is a select property of
+// the board with the done options, and sets the default done option.
+func (s *ChannelFeedSettings) IsValidForSchema(schema PropSchema) error {
+	if s.StatusPropertyID == "" {
+		return nil
+	}
+
+	prop, ok := schema[s.StatusPropertyID]
+	if !ok {
+		return ErrInvalidChannelFeedSettings{"unknown status property: " + s.StatusPropertyID}
+	}
+	if prop.Type != PropTypeSelect {
+		return ErrInvalidChannelFeedSettings{"status property is not a select property: " + prop.Name}
+	}
+	if len(prop.Options) == 0 {
+		return ErrInvalidChannelFeedSettings{"status property has no options: " + prop.Name}
+	}
+
+	for _, optionID := range s.DoneOptionIDs {
+		if _, ok := prop.Options[optionID]; !ok {
+			return ErrInvalidChannelFeedSettings{fmt.Sprintf("unknown option of property %s: %s", prop.Name, optionID)}
+		}
+	}
+
+	if len(s.DoneOptionIDs) == 0 {
+		options := make([]PropDefOption, 0, len(prop.Options))
+		for _, option := range prop.Options {
+			options = append(options, option)
+		}
+		sort.Slice(options, func(i, j int) bool { return options[i].Index < options[j].Index })
+		s.DoneOptionIDs = []string{options[len(options)-1].ID}
+	}
+	return nil
+}
+
+// HasEvent returns true if the feed reports an event.
+func (s *ChannelFeedSettings) HasEvent(event string) bool {
+	for _, e := range s.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsStatus returns true if the feed reports changes of the status of
+// cards.
+func (s *ChannelFeedSettings) NeedsStatus() bool {
+	return s.HasEvent(ChannelFeedEventStatusChanged) || s.HasEvent(ChannelFeedEventCardCompleted)
+}
+
+// IsDoneOption returns true if an option of the status property is one of
+// the done options.
+func (s *ChannelFeedSettings) IsDoneOption(optionID string) bool {
+	for _, id := range s.DoneOptionIDs {
+		if id == optionID {
+			return true
+		}
+	}
+	return false
+}
+
+// CardStatus returns the option of the status property of a card.
+func (s *ChannelFeedSettings) CardStatus(card *Block) string {
+	if s.StatusPropertyID == "" || card == nil {
+		return ""
+	}
+	status, _ := getCardPropertyValue(card, s.StatusPropertyID).(string)
+	return status
+}
+
+func ChannelFeedSettingsFromJSON(data io.Reader) (*ChannelFeedSettings, error) {
+	var settings ChannelFeedSettings
+	if err := json.NewDecoder(data).Decode(&settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// ChannelFeedItem is a change to a card waiting to be posted to the channel
+// linked to its board. The old and new values are options of the status
+// property for status changes.
+type ChannelFeedItem struct {
+	ID         string
+	BoardID    string
+	CardID     string
+	Event      string
+	OldValue   string
+	NewValue   string
+	ModifiedBy string
+	NotifyAt   int64
+	CreateAt   int64
+}
+
+type ErrInvalidChannelFeedSettings struct {
+	msg string
+}
+
+func (e ErrInvalidChannelFeedSettings) Error() string {
+	return e.msg
+}
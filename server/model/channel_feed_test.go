@@ -0,0 +1,52 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelFeedSettingsIsValid(t *testing.T) {
+	settings := &ChannelFeedSettings{
+		BoardID:       utils.NewID(utils.IDTypeBoard),
+		Events:        []string{ChannelFeedEventCardCreated},
+		WindowMinutes: 15,
+	}
+	require.NoError(t, settings.IsValid())
+
+	settings.Events = []string{"cardDeleted"}
+	require.Error(t, settings.IsValid())
+
+	settings.Events = []string{ChannelFeedEventCardCompleted}
+	require.Error(t, settings.IsValid(), "status events need a status property")
+
+	settings.StatusPropertyID = "status"
+	require.NoError(t, settings.IsValid())
+
+	settings.WindowMinutes = ChannelFeedMaxWindowMinutes + 1
+	require.Error(t, settings.IsValid())
+	settings.WindowMinutes = 15
+
+	schema := PropSchema{
+		"status": {ID: "status", Name: "Status", Type: PropTypeSelect, Options: map[string]PropDefOption{
+			"todo":  {ID: "todo", Index: 0, Value: "To Do"},
+			"doing": {ID: "doing", Index: 1, Value: "Doing"},
+			"done":  {ID: "done", Index: 2, Value: "Done"},
+		}},
+		"notes": {ID: "notes", Name: "Notes", Type: PropTypeText},
+	}
+	require.NoError(t, settings.IsValidForSchema(schema))
+	require.Equal(t, []string{"done"}, settings.DoneOptionIDs, "the last option is done by default")
+	require.True(t, settings.IsDoneOption("done"))
+	require.False(t, settings.IsDoneOption("doing"))
+
+	settings.DoneOptionIDs = []string{"shipped"}
+	require.Error(t, settings.IsValidForSchema(schema))
+
+	settings.StatusPropertyID = "notes"
+	require.Error(t, settings.IsValidForSchema(schema))
+}
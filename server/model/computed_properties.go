@@ -0,0 +1,592 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidRollup = errors.New("invalid rollup property")
+var ErrInvalidFormula = errors.New("invalid formula property")
+
+// CardFieldParentCardID is the card field holding the id of the parent card,
+// used to aggregate child cards in rollup properties.
+const CardFieldParentCardID = "parentCardId"
+
+// Relations between cards aggregated by rollup properties.
+const (
+	// RollupRelationChildren aggregates the cards whose parent is the card.
+	RollupRelationChildren = "children"
+	// RollupRelationBlocking aggregates the cards blocked by the card.
+	RollupRelationBlocking = "blocking"
+	// RollupRelationBlockedBy aggregates the cards blocking the card.
+	RollupRelationBlockedBy = "blockedBy"
+)
+
+// Aggregation functions of rollup properties.
+const (
+	RollupFunctionSum   = "sum"
+	RollupFunctionAvg   = "avg"
+	RollupFunctionMin   = "min"
+	RollupFunctionMax   = "max"
+	RollupFunctionCount = "count"
+)
+
+// computedValuePrecision is the number of decimals computed values are
+// rounded to, so that float arithmetic does not leak into stored values.
+const computedValuePrecision = 1e6
+
+// maxFormulaLength is the maximum length of a formula.
+const maxFormulaLength = 1000
+
+// PropRollup defines a rollup property, which aggregates a numeric property
+// across the cards related to a card.
+type PropRollup struct {
+	// The relation of the aggregated cards: children, blocking or blockedBy
+	Relation string `json:"relation"`
+
+	// The id of the aggregated property, not needed to count cards
+	PropertyID string `json:"propertyId"`
+
+	// The aggregation function: sum, avg, min, max or count
+	Function string `json:"function"`
+}
+
+func (pr *PropRollup) IsValid() error {
+	switch pr.Relation {
+	case RollupRelationChildren, RollupRelationBlocking, RollupRelationBlockedBy:
+	default:
+		return fmt.Errorf("unknown relation %q: %w", pr.Relation, ErrInvalidRollup)
+	}
+
+	switch pr.Function {
+	case RollupFunctionCount:
+		return nil
+	case RollupFunctionSum, RollupFunctionAvg, RollupFunctionMin, RollupFunctionMax:
+	default:
+		return fmt.Errorf("unknown function %q: %w", pr.Function, ErrInvalidRollup)
+	}
+
+	if pr.PropertyID == "" {
+		return fmt.Errorf("missing property id: %w", ErrInvalidRollup)
+	}
+	return nil
+}
+
+// Aggregate applies the rollup function to the values of the cards. Cards
+// without a numeric value are counted, but not aggregated otherwise.
+func (pr *PropRollup) Aggregate(cards []*Block) (float64, bool) {
+	if pr.Function == RollupFunctionCount {
+		return float64(len(cards)), true
+	}
+
+	var result float64
+	var count int
+	for _, card := range cards {
+		value, ok := numericValue(getCardPropertyValue(card, pr.PropertyID))
+		if !ok {
+			continue
+		}
+		switch {
+		case count == 0:
+			result = value
+		case pr.Function == RollupFunctionMin:
+			result = math.Min(result, value)
+		case pr.Function == RollupFunctionMax:
+			result = math.Max(result, value)
+		default:
+			result += value
+		}
+		count++
+	}
+
+	if count == 0 {
+		// the sum of no values is zero, but they have no average or extrema
+		return 0, pr.Function == RollupFunctionSum
+	}
+	if pr.Function == RollupFunctionAvg {
+		result /= float64(count)
+	}
+	return result, true
+}
+
+// IsComputed returns true if the values of the property are computed by the
+// server.
+func (pd PropDef) IsComputed() bool {
+	return pd.Type == PropTypeRollup || pd.Type == PropTypeFormula
+}
+
+// HasComputedProperties returns true if the schema has rollup or formula
+// properties.
+func (s PropSchema) HasComputedProperties() bool {
+	for _, pd := range s {
+		if pd.IsComputed() {
+			return true
+		}
+	}
+	return false
+}
+
+// ComputedPropertiesChanged returns true if the computed values of the cards
+// of a board may differ between two versions of its schema: a computed
+// property was added, removed or redefined, or, as computed properties refer
+// to other properties by id or name, a property was added, removed, renamed or
+// retyped.
+func ComputedPropertiesChanged(oldSchema, newSchema PropSchema) bool {
+	if !oldSchema.HasComputedProperties() && !newSchema.HasComputedProperties() {
+		return false
+	}
+	if len(oldSchema) != len(newSchema) {
+		return true
+	}
+
+	for id, newPD := range newSchema {
+		oldPD, ok := oldSchema[id]
+		if !ok || oldPD.Name != newPD.Name || oldPD.Type != newPD.Type {
+			return true
+		}
+		if oldPD.Formula != newPD.Formula || !reflect.DeepEqual(oldPD.Rollup, newPD.Rollup) {
+			return true
+		}
+	}
+	return false
+}
+
+// findProperty returns the property definition with the given id or, failing
+// that, name.
+func (s PropSchema) findProperty(ref string) (PropDef, bool) {
+	if pd, ok := s[ref]; ok {
+		return pd, true
+	}
+	for _, pd := range s {
+		if strings.EqualFold(pd.Name, ref) {
+			return pd, true
+		}
+	}
+	return PropDef{}, false
+}
+
+// ValidateComputedProperty checks the definition of a rollup or formula card
+// property, as found in a board's CardProperties. Other property types are
+// always valid.
+func ValidateComputedProperty(prop map[string]interface{}) error {
+	pd, err := parsePropDef(prop, 0)
+	if err != nil {
+		return err
+	}
+
+	switch pd.Type {
+	case PropTypeRollup:
+		if pd.Rollup == nil {
+			return fmt.Errorf("missing rollup definition: %w", ErrInvalidRollup)
+		}
+		return pd.Rollup.IsValid()
+	case PropTypeFormula:
+		return ValidateFormula(pd.Formula)
+	}
+	return nil
+}
+
+// ValidateFormula checks the syntax of a formula.
+func ValidateFormula(formula string) error {
+	if strings.TrimSpace(formula) == "" {
+		return fmt.Errorf("empty formula: %w", ErrInvalidFormula)
+	}
+	if len(formula) > maxFormulaLength {
+		return fmt.Errorf("formula is too long: %w", ErrInvalidFormula)
+	}
+
+	_, _, err := EvaluateFormula(formula, func(string) (float64, bool) { return 1, true })
+	return err
+}
+
+// EvaluateFormula computes a formula. Formulas combine numbers and property
+// values, referenced as prop("name or id"), with the + - * / operators,
+// parentheses and the min, max, round and abs functions. The resolver returns
+// the numeric value of a property, and false if it has none, in which case
+// the formula has no value either. A division by zero has no value.
+func EvaluateFormula(formula string, resolve func(ref string) (float64, bool)) (float64, bool, error) {
+	p := &formulaParser{input: []rune(formula), resolve: resolve}
+
+	value, ok, err := p.parseExpression()
+	if err != nil {
+		return 0, false, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return 0, false, p.errorf("unexpected %q", string(p.input[p.pos]))
+	}
+	if ok && (math.IsNaN(value) || math.IsInf(value, 0)) {
+		return 0, false, nil
+	}
+	return value, ok, nil
+}
+
+// formulaParser evaluates a formula while parsing it by recursive descent:
+//
+//	expression := term (("+" | "-") term)*
+//	term       := unary (("*" | "/") unary)*
+//	unary      := "-" unary | primary
+//	primary    := number | "(" expression ")" | name "(" arguments ")"
+type formulaParser struct {
+	input   []rune
+	pos     int
+	resolve func(ref string) (float64, bool)
+}
+
+func (p *formulaParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%s at position %d: %w", fmt.Sprintf(format, args...), p.pos+1, ErrInvalidFormula)
+}
+
+func (p *formulaParser) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+// accept consumes the next non space rune if it is r.
+func (p *formulaParser) accept(r rune) bool {
+	p.skipSpaces()
+	if p.pos < len(p.input) && p.input[p.pos] == r {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *formulaParser) parseExpression() (float64, bool, error) {
+	value, ok, err := p.parseTerm()
+	if err != nil {
+		return 0, false, err
+	}
+
+	for {
+		var sign float64
+		switch {
+		case p.accept('+'):
+			sign = 1
+		case p.accept('-'):
+			sign = -1
+		default:
+			return value, ok, nil
+		}
+
+		right, rightOK, err := p.parseTerm()
+		if err != nil {
+			return 0, false, err
+		}
+		value += sign * right
+		ok = ok && rightOK
+	}
+}
+
+func (p *formulaParser) parseTerm() (float64, bool, error) {
+	value, ok, err := p.parseUnary()
+	if err != nil {
+		return 0, false, err
+	}
+
+	for {
+		var divide bool
+		switch {
+		case p.accept('*'):
+		case p.accept('/'):
+			divide = true
+		default:
+			return value, ok, nil
+		}
+
+		right, rightOK, err := p.parseUnary()
+		if err != nil {
+			return 0, false, err
+		}
+		ok = ok && rightOK
+		switch {
+		case !divide:
+			value *= right
+		case right == 0:
+			ok = false
+		default:
+			value /= right
+		}
+	}
+}
+
+func (p *formulaParser) parseUnary() (float64, bool, error) {
+	if p.accept('-') {
+		value, ok, err := p.parseUnary()
+		return -value, ok, err
+	}
+	return p.parsePrimary()
+}
+
+func (p *formulaParser) parsePrimary() (float64, bool, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return 0, false, p.errorf("unexpected end of formula")
+	}
+
+	r := p.input[p.pos]
+	switch {
+	case r == '(':
+		p.pos++
+		value, ok, err := p.parseExpression()
+		if err != nil {
+			return 0, false, err
+		}
+		if !p.accept(')') {
+			return 0, false, p.errorf("missing closing parenthesis")
+		}
+		return value, ok, nil
+
+	case unicode.IsDigit(r) || r == '.':
+		start := p.pos
+		for p.pos < len(p.input) && (unicode.IsDigit(p.input[p.pos]) || p.input[p.pos] == '.') {
+			p.pos++
+		}
+		value, err := strconv.ParseFloat(string(p.input[start:p.pos]), 64)
+		if err != nil {
+			p.pos = start
+			return 0, false, p.errorf("invalid number")
+		}
+		return value, true, nil
+
+	case unicode.IsLetter(r):
+		start := p.pos
+		for p.pos < len(p.input) && unicode.IsLetter(p.input[p.pos]) {
+			p.pos++
+		}
+		name := strings.ToLower(string(p.input[start:p.pos]))
+		if !p.accept('(') {
+			return 0, false, p.errorf("missing arguments of %s", name)
+		}
+		if name == "prop" {
+			return p.parseProp()
+		}
+		return p.parseFunction(name)
+	}
+	return 0, false, p.errorf("unexpected %q", string(r))
+}
+
+// parseProp resolves the property reference of prop("name or id").
+func (p *formulaParser) parseProp() (float64, bool, error) {
+	if !p.accept('"') {
+		return 0, false, p.errorf("prop expects a quoted property name")
+	}
+	start := p.pos
+	for p.pos < len(p.input) && p.input[p.pos] != '"' {
+		p.pos++
+	}
+	if p.pos >= len(p.input) {
+		return 0, false, p.errorf("missing closing quote")
+	}
+	ref := string(p.input[start:p.pos])
+	p.pos++
+	if !p.accept(')') {
+		return 0, false, p.errorf("missing closing parenthesis")
+	}
+
+	value, ok := p.resolve(ref)
+	return value, ok, nil
+}
+
+func (p *formulaParser) parseFunction(name string) (float64, bool, error) {
+	var args []float64
+	ok := true
+	for {
+		value, argOK, err := p.parseExpression()
+		if err != nil {
+			return 0, false, err
+		}
+		args = append(args, value)
+		ok = ok && argOK
+		if p.accept(')') {
+			break
+		}
+		if !p.accept(',') {
+			return 0, false, p.errorf("missing closing parenthesis")
+		}
+	}
+
+	switch name {
+	case "min", "max":
+		result := args[0]
+		for _, arg := range args[1:] {
+			if name == "min" {
+				result = math.Min(result, arg)
+			} else {
+				result = math.Max(result, arg)
+			}
+		}
+		return result, ok, nil
+	case "round", "abs":
+		if len(args) != 1 {
+			return 0, false, p.errorf("%s expects one argument", name)
+		}
+		if name == "round" {
+			return math.Round(args[0]), ok, nil
+		}
+		return math.Abs(args[0]), ok, nil
+	}
+	return 0, false, p.errorf("unknown function %s", name)
+}
+
+// CardRelations are the cards related to a card, keyed by rollup relation.
+type CardRelations map[string][]*Block
+
+// ComputeCardProperties computes the rollup and formula properties of a card.
+// Rollups aggregate the stored values of the related cards, while formulas
+// use the computed values of the card itself. Properties without a value,
+// for instance formulas whose inputs are missing or that reference
+// themselves, map to an empty string.
+func ComputeCardProperties(schema PropSchema, card *Block, related CardRelations) map[string]string {
+	c := &cardComputation{
+		schema:    schema,
+		card:      card,
+		related:   related,
+		values:    map[string]string{},
+		computing: map[string]bool{},
+	}
+
+	for id, pd := range schema {
+		if pd.IsComputed() {
+			c.compute(id)
+		}
+	}
+	return c.values
+}
+
+type cardComputation struct {
+	schema    PropSchema
+	card      *Block
+	related   CardRelations
+	values    map[string]string
+	computing map[string]bool
+}
+
+func (c *cardComputation) compute(id string) string {
+	if value, ok := c.values[id]; ok {
+		return value
+	}
+	if c.computing[id] {
+		// a formula referencing itself, directly or not, has no value
+		return ""
+	}
+	c.computing[id] = true
+	defer delete(c.computing, id)
+
+	pd := c.schema[id]
+	var value float64
+	var ok bool
+	switch {
+	case pd.Type == PropTypeRollup && pd.Rollup != nil:
+		value, ok = pd.Rollup.Aggregate(c.related[pd.Rollup.Relation])
+	case pd.Type == PropTypeFormula:
+		var err error
+		value, ok, err = EvaluateFormula(pd.Formula, c.resolve)
+		ok = ok && err == nil
+	}
+
+	result := ""
+	if ok {
+		result = formatComputedValue(value)
+	}
+	c.values[id] = result
+	return result
+}
+
+func (c *cardComputation) resolve(ref string) (float64, bool) {
+	pd, ok := c.schema.findProperty(ref)
+	if !ok {
+		return 0, false
+	}
+	if pd.IsComputed() {
+		return numericValue(c.compute(pd.ID))
+	}
+	return numericValue(getCardPropertyValue(c.card, pd.ID))
+}
+
+// ApplyComputedProperties computes the rollup and formula properties of a
+// card and returns its properties updated with them, and whether any of them
+// changed. The card is not modified.
+func ApplyComputedProperties(schema PropSchema, card *Block, related CardRelations) (map[string]interface{}, bool) {
+	properties := map[string]interface{}{}
+	if current, ok := card.Fields["properties"].(map[string]interface{}); ok {
+		for key, value := range current {
+			properties[key] = value
+		}
+	}
+
+	changed := false
+	for id, value := range ComputeCardProperties(schema, card, related) {
+		current, exists := properties[id]
+		if value == "" {
+			if exists {
+				delete(properties, id)
+				changed = true
+			}
+			continue
+		}
+		if !exists || !reflect.DeepEqual(current, value) {
+			properties[id] = value
+			changed = true
+		}
+	}
+	return properties, changed
+}
+
+// GetParentCardID returns the id of the parent card of a card, if any.
+func GetParentCardID(card *Block) string {
+	if card == nil {
+		return ""
+	}
+	parentID, _ := card.Fields[CardFieldParentCardID].(string)
+	return parentID
+}
+
+func getCardPropertyValue(card *Block, propertyID string) interface{} {
+	if card == nil {
+		return nil
+	}
+	properties, ok := card.Fields["properties"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return properties[propertyID]
+}
+
+// numericValue returns the numeric value of a property value, which is
+// typically stored as a string.
+func numericValue(v interface{}) (float64, bool) {
+	switch value := v.(type) {
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return 0, false
+		}
+		return f, true
+	case float64:
+		return value, true
+	case int:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	}
+	return 0, false
+}
+
+func formatComputedValue(value float64) string {
+	rounded := math.Round(value*computedValuePrecision) / computedValuePrecision
+	if rounded == 0 {
+		// avoid "-0"
+		rounded = 0
+	}
+	return strconv.FormatFloat(rounded, 'f', -1, 64)
+}
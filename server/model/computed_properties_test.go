@@ -0,0 +1,246 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mmModel "github.com/mattermost/mattermost/server/public/model"
+)
+
+func makeComputedTestCard(id string, properties map[string]interface{}) *Block {
+	return &Block{
+		ID:     id,
+		Type:   TypeCard,
+		Fields: map[string]interface{}{"properties": properties},
+	}
+}
+
+func TestEvaluateFormula(t *testing.T) {
+	values := map[string]float64{"points": 5, "done": 2}
+	resolve := func(ref string) (float64, bool) {
+		value, ok := values[ref]
+		return value, ok
+	}
+
+	testCases := []struct {
+		formula  string
+		expected float64
+		noValue  bool
+	}{
+		{formula: "1 + 2 * 3", expected: 7},
+		{formula: "(1 + 2) * 3", expected: 9},
+		{formula: "10 - 4 - 3", expected: 3},
+		{formula: "-2 * -3", expected: 6},
+		{formula: "7 / 2", expected: 3.5},
+		{formula: `prop("points") - prop("done")`, expected: 3},
+		{formula: `round(prop("done") / prop("points") * 100)`, expected: 40},
+		{formula: "min(3, 1, 2) + max(3, 1, 2)", expected: 4},
+		{formula: "abs(1 - 3)", expected: 2},
+		{formula: `prop("missing") + 1`, noValue: true},
+		{formula: "1 / 0", noValue: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.formula, func(t *testing.T) {
+			value, ok, err := EvaluateFormula(tc.formula, resolve)
+			require.NoError(t, err)
+			require.Equal(t, !tc.noValue, ok)
+			if !tc.noValue {
+				assert.InDelta(t, tc.expected, value, 1e-9)
+			}
+		})
+	}
+}
+
+func TestValidateFormula(t *testing.T) {
+	require.NoError(t, ValidateFormula(`prop("points") * 2 + round(prop("hours") / 8)`))
+
+	for _, formula := range []string{"", "1 +", "(1 + 2", "2 3", `prop(points)`, `prop("points"`, "sqrt(4)", "round(1, 2)", "1 % 2"} {
+		err := ValidateFormula(formula)
+		require.ErrorIs(t, err, ErrInvalidFormula, formula)
+	}
+}
+
+func TestPropRollupAggregate(t *testing.T) {
+	cards := []*Block{
+		makeComputedTestCard("card1", map[string]interface{}{"points": "3"}),
+		makeComputedTestCard("card2", map[string]interface{}{"points": "5"}),
+		makeComputedTestCard("card3", map[string]interface{}{"points": "not a number"}),
+		makeComputedTestCard("card4", map[string]interface{}{}),
+	}
+
+	testCases := []struct {
+		function string
+		expected float64
+	}{
+		{function: RollupFunctionSum, expected: 8},
+		{function: RollupFunctionAvg, expected: 4},
+		{function: RollupFunctionMin, expected: 3},
+		{function: RollupFunctionMax, expected: 5},
+		{function: RollupFunctionCount, expected: 4},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.function, func(t *testing.T) {
+			rollup := &PropRollup{Relation: RollupRelationChildren, PropertyID: "points", Function: tc.function}
+			value, ok := rollup.Aggregate(cards)
+			require.True(t, ok)
+			require.Equal(t, tc.expected, value)
+		})
+	}
+
+	t.Run("no cards", func(t *testing.T) {
+		value, ok := (&PropRollup{PropertyID: "points", Function: RollupFunctionSum}).Aggregate(nil)
+		require.True(t, ok)
+		require.Zero(t, value)
+
+		_, ok = (&PropRollup{PropertyID: "points", Function: RollupFunctionAvg}).Aggregate(nil)
+		require.False(t, ok)
+	})
+}
+
+func TestValidateComputedProperty(t *testing.T) {
+	require.NoError(t, ValidateComputedProperty(map[string]interface{}{"id": "text", "type": PropTypeText}))
+	require.NoError(t, ValidateComputedProperty(map[string]interface{}{
+		"id":     "total",
+		"type":   PropTypeRollup,
+		"rollup": map[string]interface{}{"relation": RollupRelationChildren, "propertyId": "points", "function": RollupFunctionSum},
+	}))
+	require.NoError(t, ValidateComputedProperty(map[string]interface{}{
+		"id":     "children",
+		"type":   PropTypeRollup,
+		"rollup": map[string]interface{}{"relation": RollupRelationChildren, "function": RollupFunctionCount},
+	}))
+
+	err := ValidateComputedProperty(map[string]interface{}{"id": "total", "type": PropTypeRollup})
+	require.ErrorIs(t, err, ErrInvalidRollup)
+
+	err = ValidateComputedProperty(map[string]interface{}{
+		"id":     "total",
+		"type":   PropTypeRollup,
+		"rollup": map[string]interface{}{"relation": "siblings", "propertyId": "points", "function": RollupFunctionSum},
+	})
+	require.ErrorIs(t, err, ErrInvalidRollup)
+
+	err = ValidateComputedProperty(map[string]interface{}{
+		"id":     "total",
+		"type":   PropTypeRollup,
+		"rollup": map[string]interface{}{"relation": RollupRelationChildren, "function": RollupFunctionMax},
+	})
+	require.ErrorIs(t, err, ErrInvalidRollup)
+
+	err = ValidateComputedProperty(map[string]interface{}{"id": "remaining", "type": PropTypeFormula, "formula": "1 +"})
+	require.ErrorIs(t, err, ErrInvalidFormula)
+}
+
+func TestComputeCardProperties(t *testing.T) {
+	board := &Board{CardProperties: []map[string]interface{}{
+		{"id": "points", "name": "Points", "type": PropTypeNumber},
+		{"id": "done", "name": "Done", "type": PropTypeNumber},
+		{
+			"id":     "total",
+			"name":   "Total",
+			"type":   PropTypeRollup,
+			"rollup": map[string]interface{}{"relation": RollupRelationChildren, "propertyId": "points", "function": RollupFunctionSum},
+		},
+		{"id": "remaining", "name": "Remaining", "type": PropTypeFormula, "formula": `prop("Total") - prop("done")`},
+		{"id": "loop", "name": "Loop", "type": PropTypeFormula, "formula": `prop("loop") + 1`},
+	}}
+	schema, err := ParsePropertySchema(board)
+	require.NoError(t, err)
+	require.True(t, schema.HasComputedProperties())
+	require.Equal(t, RollupRelationChildren, schema["total"].Rollup.Relation)
+	require.Equal(t, `prop("Total") - prop("done")`, schema["remaining"].Formula)
+
+	epic := makeComputedTestCard("epic", map[string]interface{}{"done": "2.5", "loop": "1"})
+	related := CardRelations{RollupRelationChildren: {
+		makeComputedTestCard("story1", map[string]interface{}{"points": "3"}),
+		makeComputedTestCard("story2", map[string]interface{}{"points": "5"}),
+	}}
+
+	values := ComputeCardProperties(schema, epic, related)
+	require.Equal(t, map[string]string{"total": "8", "remaining": "5.5", "loop": ""}, values)
+
+	t.Run("apply the values", func(t *testing.T) {
+		properties, changed := ApplyComputedProperties(schema, epic, related)
+		require.True(t, changed)
+		require.Equal(t, map[string]interface{}{"done": "2.5", "total": "8", "remaining": "5.5"}, properties)
+
+		// the card itself is not modified
+		require.Equal(t, "1", epic.Fields["properties"].(map[string]interface{})["loop"])
+
+		epic.Fields["properties"] = properties
+		_, changed = ApplyComputedProperties(schema, epic, related)
+		require.False(t, changed)
+	})
+}
+
+func TestBoardPatchIsValidComputedProperty(t *testing.T) {
+	patch := &BoardPatch{UpdatedCardProperties: []map[string]interface{}{
+		{"id": "remaining", "type": PropTypeFormula, "formula": `prop("points") -`},
+	}}
+	require.Error(t, patch.IsValid())
+
+	patch.UpdatedCardProperties[0]["formula"] = `prop("points") - 1`
+	require.NoError(t, patch.IsValid())
+}
+
+func TestBoardIsValidComputedProperty(t *testing.T) {
+	board := &Board{
+		TeamID:      mmModel.NewId(),
+		Type:        BoardTypeOpen,
+		MinimumRole: BoardRoleViewer,
+		CardProperties: []map[string]interface{}{
+			{"id": "points", "name": "Points", "type": PropTypeNumber},
+			{"id": "total", "name": "Total", "type": PropTypeRollup, "rollup": map[string]interface{}{"relation": "siblings", "function": RollupFunctionCount}},
+		},
+	}
+	require.Error(t, board.IsValid())
+	require.Error(t, board.IsValidForImport())
+
+	board.CardProperties[1]["rollup"] = map[string]interface{}{"relation": RollupRelationChildren, "function": RollupFunctionCount}
+	require.NoError(t, board.IsValid())
+	require.NoError(t, board.IsValidForImport())
+}
+
+func TestComputedPropertiesChanged(t *testing.T) {
+	parse := func(cardProperties []map[string]interface{}) PropSchema {
+		schema, err := ParsePropertySchema(&Board{CardProperties: cardProperties})
+		require.NoError(t, err)
+		return schema
+	}
+	points := map[string]interface{}{"id": "points", "name": "Points", "type": PropTypeNumber}
+	status := map[string]interface{}{"id": "status", "name": "Status", "type": PropTypeSelect}
+	total := map[string]interface{}{
+		"id":     "total",
+		"name":   "Total",
+		"type":   PropTypeRollup,
+		"rollup": map[string]interface{}{"relation": RollupRelationChildren, "propertyId": "points", "function": RollupFunctionSum},
+	}
+	oldSchema := parse([]map[string]interface{}{points, status, total})
+
+	require.False(t, ComputedPropertiesChanged(parse([]map[string]interface{}{points}), parse([]map[string]interface{}{points, status})),
+		"no computed property")
+
+	withOption := map[string]interface{}{"id": "status", "name": "Status", "type": PropTypeSelect,
+		"options": []interface{}{map[string]interface{}{"id": "done", "value": "Done"}}}
+	require.False(t, ComputedPropertiesChanged(oldSchema, parse([]map[string]interface{}{points, withOption, total})),
+		"an option was added")
+
+	renamed := map[string]interface{}{"id": "points", "name": "Estimate", "type": PropTypeNumber}
+	require.True(t, ComputedPropertiesChanged(oldSchema, parse([]map[string]interface{}{renamed, status, total})))
+
+	redefined := map[string]interface{}{
+		"id":     "total",
+		"name":   "Total",
+		"type":   PropTypeRollup,
+		"rollup": map[string]interface{}{"relation": RollupRelationChildren, "propertyId": "points", "function": RollupFunctionMax},
+	}
+	require.True(t, ComputedPropertiesChanged(oldSchema, parse([]map[string]interface{}{points, status, redefined})))
+	require.True(t, ComputedPropertiesChanged(oldSchema, parse([]map[string]interface{}{points, total})))
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// ImportCSVOptions provides options when importing the rows of a CSV file as
+// cards of an existing board.
+type ImportCSVOptions struct {
+	BoardID    string
+	ModifiedBy string
+
+	// DryRun validates the rows and reports their errors without changing
+	// the board.
+	DryRun bool
+
+	// AllowNewOptions allows the import to add the select and multi select
+	// options that are not defined in the board yet. If false, unknown option
+	// labels are reported as row errors.
+	AllowNewOptions bool
+}
+
+// CSVImportResult is the outcome of a CSV import.
+// swagger:model
+type CSVImportResult struct {
+	// Whether the import only validated the rows
+	// required: true
+	DryRun bool `json:"dryRun"`
+
+	// Whether the cards have been created. Nothing is created if any row has an error
+	// required: true
+	Imported bool `json:"imported"`
+
+	// The number of data rows in the file
+	// required: true
+	RowCount int `json:"rowCount"`
+
+	// The ids of the created cards
+	// required: true
+	CardIDs []string `json:"cardIds"`
+
+	// The column holding the card titles
+	// required: true
+	TitleColumn string `json:"titleColumn"`
+
+	// The columns mapped onto card properties, keyed by column name
+	// required: true
+	MappedColumns map[string]string `json:"mappedColumns"`
+
+	// The columns that do not match a writable card property
+	// required: true
+	IgnoredColumns []string `json:"ignoredColumns"`
+
+	// The options added to the board, keyed by property name
+	// required: true
+	NewOptions map[string][]string `json:"newOptions"`
+
+	// The errors of the rows that cannot be imported
+	// required: true
+	Errors []*CSVImportRowError `json:"errors"`
+}
+
+// CSVImportRowError is a value of a CSV row that cannot be imported.
+// swagger:model
+type CSVImportRowError struct {
+	// The line of the row in the file, the header being line 1
+	// required: true
+	Row int `json:"row"`
+
+	// The column of the value
+	// required: false
+	Column string `json:"column,omitempty"`
+
+	// The reason why the value cannot be imported
+	// required: true
+	Message string `json:"message"`
+}
+
+func NewCSVImportResult(dryRun bool) *CSVImportResult {
+	return &CSVImportResult{
+		DryRun:         dryRun,
+		CardIDs:        []string{},
+		MappedColumns:  map[string]string{},
+		IgnoredColumns: []string{},
+		NewOptions:     map[string][]string{},
+		Errors:         []*CSVImportRowError{},
+	}
+}
+
+func (r *CSVImportResult) AddError(row int, column, message string) {
+	r.Errors = append(r.Errors, &CSVImportRowError{Row: row, Column: column, Message: message})
+}
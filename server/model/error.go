@@ -28,6 +28,8 @@ var (
 
 	ErrRequestEntityTooLarge = errors.New("request entity too large")
 
+	ErrTooManyRequests = errors.New("too many requests")
+
 	ErrInvalidBoardSearchField = errors.New("invalid board search field")
 )
 
@@ -308,6 +310,13 @@ func IsErrRequestEntityTooLarge(err error) bool {
 	return errors.Is(err, ErrRequestEntityTooLarge)
 }
 
+// IsErrTooManyRequests returns true if `err` is or wraps one of:
+// - model.ErrTooManyRequests.
+func IsErrTooManyRequests(err error) bool {
+	// check if this is a model.ErrTooManyRequests
+	return errors.Is(err, ErrTooManyRequests)
+}
+
 // IsErrNotImplemented returns true if `err` is or wraps one of:
 // - model.ErrNotImplemented
 // - model.ErrInsufficientLicense.
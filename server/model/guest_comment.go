@@ -0,0 +1,139 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+const (
+	GuestCommentPending  = "pending"
+	GuestCommentApproved = "approved"
+	GuestCommentRejected = "rejected"
+)
+
+const (
+	// GuestCommentAuthorMaxRunes is the maximum length of the display name of
+	// the author of a guest comment.
+	GuestCommentAuthorMaxRunes = 64
+
+	// GuestCommentTextMaxRunes is the maximum length of a guest comment.
+	GuestCommentTextMaxRunes = 4000
+
+	// GuestCommentRateLimit is the number of comments that can be submitted
+	// through a share link from the same address during
+	// GuestCommentRateLimitWindow.
+	GuestCommentRateLimit = 5
+
+	// GuestCommentRateLimitWindow is the rate limit window, in milliseconds.
+	GuestCommentRateLimitWindow = int64(10 * 60 * 1000)
+)
+
+// GuestComment is a comment on a card submitted through a commenter share
+// link by someone outside of the team. It is added to the card as a comment
+// block once approved by a board admin.
+// swagger:model
+type GuestComment struct {
+	// The id of the guest comment
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board of the card
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The id of the commented card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The id of the share link the comment was submitted through
+	// required: true
+	ShareLinkID string `json:"shareLinkId"`
+
+	// The display name supplied by the author
+	// required: true
+	AuthorName string `json:"authorName"`
+
+	// The text of the comment
+	// required: true
+	Text string `json:"text"`
+
+	// The moderation status of the comment, pending, approved or rejected
+	// required: true
+	Status string `json:"status"`
+
+	// The address the comment was submitted from
+	// required: false
+	RemoteAddr string `json:"remoteAddr"`
+
+	// The id of the comment block created when the comment was approved
+	// required: false
+	CommentID string `json:"commentId"`
+
+	// The id of the board admin who approved or rejected the comment
+	// required: false
+	ModeratedBy string `json:"moderatedBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+func (c *GuestComment) IsValid() error {
+	if c == nil {
+		return ErrInvalidGuestComment{"cannot be nil"}
+	}
+
+	if err := IsValidId(c.BoardID); err != nil {
+		return ErrInvalidGuestComment{"invalid board id: " + err.Error()}
+	}
+
+	if err := IsValidId(c.CardID); err != nil {
+		return ErrInvalidGuestComment{"invalid card id: " + err.Error()}
+	}
+
+	if strings.TrimSpace(c.AuthorName) == "" {
+		return ErrInvalidGuestComment{"author name is required"}
+	}
+
+	if len([]rune(c.AuthorName)) > GuestCommentAuthorMaxRunes {
+		return ErrInvalidGuestComment{"author name is too long"}
+	}
+
+	if strings.TrimSpace(c.Text) == "" {
+		return ErrInvalidGuestComment{"text is required"}
+	}
+
+	if len([]rune(c.Text)) > GuestCommentTextMaxRunes {
+		return ErrInvalidGuestComment{"text is too long"}
+	}
+
+	switch c.Status {
+	case GuestCommentPending, GuestCommentApproved, GuestCommentRejected:
+		return nil
+	}
+	return ErrInvalidGuestComment{"invalid status: " + c.Status}
+}
+
+func GuestCommentFromJSON(data io.Reader) (*GuestComment, error) {
+	var comment GuestComment
+	if err := json.NewDecoder(data).Decode(&comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+type ErrInvalidGuestComment struct {
+	msg string
+}
+
+func (e ErrInvalidGuestComment) Error() string {
+	return e.msg
+}
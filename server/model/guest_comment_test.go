@@ -0,0 +1,61 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuestCommentIsValid(t *testing.T) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	cardID := utils.NewID(utils.IDTypeCard)
+
+	newComment := func() *GuestComment {
+		return &GuestComment{
+			BoardID:    boardID,
+			CardID:     cardID,
+			AuthorName: "Jane",
+			Text:       "Looks good",
+			Status:     GuestCommentPending,
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		modify func(c *GuestComment)
+		valid  bool
+	}{
+		{"valid comment", func(c *GuestComment) {}, true},
+		{"approved comment", func(c *GuestComment) { c.Status = GuestCommentApproved }, true},
+		{"no board", func(c *GuestComment) { c.BoardID = "" }, false},
+		{"no card", func(c *GuestComment) { c.CardID = "" }, false},
+		{"blank author", func(c *GuestComment) { c.AuthorName = "  " }, false},
+		{"long author", func(c *GuestComment) { c.AuthorName = strings.Repeat("a", GuestCommentAuthorMaxRunes+1) }, false},
+		{"blank text", func(c *GuestComment) { c.Text = "" }, false},
+		{"long text", func(c *GuestComment) { c.Text = strings.Repeat("a", GuestCommentTextMaxRunes+1) }, false},
+		{"invalid status", func(c *GuestComment) { c.Status = "deleted" }, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			comment := newComment()
+			tc.modify(comment)
+			err := comment.IsValid()
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+
+	t.Run("nil comment", func(t *testing.T) {
+		var comment *GuestComment
+		require.Error(t, comment.IsValid())
+	})
+}
@@ -0,0 +1,274 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// IncomingWebhook allows an external system to create and update the cards
+// of a board without a user session. Requests are authenticated with the
+// token of the webhook and are made on behalf of the user who created it.
+// swagger:model
+type IncomingWebhook struct {
+	// The id of the webhook
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board the cards are created in
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The title of the webhook
+	// required: false
+	Title string `json:"title"`
+
+	// Access token
+	// required: true
+	Token string `json:"token"`
+
+	// Whether requests to the webhook are accepted
+	// required: true
+	Enabled bool `json:"enabled"`
+
+	// The id of the user who created the webhook, and on behalf of whom the cards are changed
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+func (w *IncomingWebhook) IsValid() error {
+	if w == nil {
+		return ErrInvalidIncomingWebhook{"cannot be nil"}
+	}
+
+	if err := IsValidId(w.BoardID); err != nil {
+		return ErrInvalidIncomingWebhook{"invalid board id: " + err.Error()}
+	}
+
+	if w.Token == "" {
+		return ErrInvalidIncomingWebhook{"token is required"}
+	}
+
+	if len(w.Title) > 255 {
+		return ErrInvalidIncomingWebhook{"title is too long"}
+	}
+	return nil
+}
+
+func IncomingWebhookFromJSON(data io.Reader) (*IncomingWebhook, error) {
+	var webhook IncomingWebhook
+	if err := json.NewDecoder(data).Decode(&webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// IncomingWebhookRequest is the body posted to an incoming webhook. Without a
+// card id a new card is created, otherwise the card is patched.
+// swagger:model
+type IncomingWebhookRequest struct {
+	// The id of the card to patch
+	// required: false
+	CardID string `json:"cardId"`
+
+	// The title of the card
+	// required: false
+	Title *string `json:"title"`
+
+	// The icon of the card
+	// required: false
+	Icon *string `json:"icon"`
+
+	// The card properties keyed by property name. Select and multi select
+	// values are option labels, and a null value clears the property
+	// required: false
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// CardProperties maps the properties of the request, keyed by property name,
+// onto card properties keyed by property id. Names and option labels are
+// matched case insensitively. Cleared properties are mapped to nil.
+func (r *IncomingWebhookRequest) CardProperties(board *Board) (map[string]interface{}, error) {
+	properties := map[string]interface{}{}
+	if len(r.Properties) == 0 {
+		return properties, nil
+	}
+
+	schema, err := ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, value := range r.Properties {
+		propDef, ok := schema.findByName(name)
+		if !ok {
+			return nil, ErrInvalidIncomingWebhookRequest{"unknown property: " + name}
+		}
+
+		if value == nil {
+			properties[propDef.ID] = nil
+			continue
+		}
+
+		propValue, err := propDef.valueFromExternal(value)
+		if err != nil {
+			return nil, ErrInvalidIncomingWebhookRequest{fmt.Sprintf("invalid value for property %s: %s", name, err)}
+		}
+		properties[propDef.ID] = propValue
+	}
+	return properties, nil
+}
+
+// findByName returns the definition of the property with the given name.
+func (ps PropSchema) findByName(name string) (PropDef, bool) {
+	for _, propDef := range ps {
+		if strings.EqualFold(propDef.Name, name) {
+			return propDef, true
+		}
+	}
+	return PropDef{}, false
+}
+
+// findOptionByLabel returns the id of the option with the given label.
+func (pd PropDef) findOptionByLabel(label string) (string, bool) {
+	for _, option := range pd.Options {
+		if strings.EqualFold(option.Value, label) {
+			return option.ID, true
+		}
+	}
+	return "", false
+}
+
+// valueFromExternal converts a property value posted by an external system
+// into the value stored in the card.
+func (pd PropDef) valueFromExternal(value interface{}) (interface{}, error) {
+	switch pd.Type {
+	case PropTypeSelect:
+		label, ok := value.(string)
+		if !ok {
+			return nil, ErrInvalidPropertyValueType
+		}
+		id, ok := pd.findOptionByLabel(label)
+		if !ok {
+			return nil, fmt.Errorf("unknown option %q", label)
+		}
+		return id, nil
+
+	case PropTypeMultiSelect:
+		labels, ok := stringSliceValue(value)
+		if !ok {
+			label, isString := value.(string)
+			if !isString {
+				return nil, ErrInvalidPropertyValueType
+			}
+			labels = []string{label}
+		}
+		ids := make([]string, 0, len(labels))
+		for _, label := range labels {
+			id, ok := pd.findOptionByLabel(label)
+			if !ok {
+				return nil, fmt.Errorf("unknown option %q", label)
+			}
+			ids = append(ids, id)
+		}
+		return ids, nil
+
+	case PropTypeMultiPerson:
+		userIDs, ok := stringSliceValue(value)
+		if !ok {
+			return nil, ErrInvalidPropertyValueType
+		}
+		return userIDs, nil
+
+	case PropTypeCheckbox:
+		switch v := value.(type) {
+		case bool:
+			return strconv.FormatBool(v), nil
+		case string:
+			checked, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, ErrInvalidPropertyValueType
+			}
+			return strconv.FormatBool(checked), nil
+		}
+		return nil, ErrInvalidPropertyValueType
+
+	case PropTypeNumber:
+		switch v := value.(type) {
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case string:
+			if _, err := strconv.ParseFloat(v, 64); err != nil {
+				return nil, ErrInvalidPropertyValueType
+			}
+			return v, nil
+		}
+		return nil, ErrInvalidPropertyValueType
+
+	case PropTypeDate:
+		// dates are posted in milliseconds since the epoch, and stored as a
+		// JSON snippet of the form {"from":1642161600000}
+		millis, ok := value.(float64)
+		if !ok {
+			return nil, ErrInvalidPropertyValueType
+		}
+		return fmt.Sprintf(`{"from":%d}`, int64(millis)), nil
+
+	case PropTypeCreatedTime, PropTypeCreatedBy, PropTypeUpdatedTime, PropTypeUpdatedBy:
+		return nil, fmt.Errorf("property of type %s is read only", pd.Type)
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case float64, bool:
+		return fmt.Sprintf("%v", v), nil
+	}
+	return nil, ErrInvalidPropertyValueType
+}
+
+// MergeCardProperties returns the properties of a card with the given
+// properties applied, removing the ones mapped to nil.
+func MergeCardProperties(cardProperties, properties map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(cardProperties)+len(properties))
+	for id, value := range cardProperties {
+		merged[id] = value
+	}
+	for id, value := range properties {
+		if value == nil {
+			delete(merged, id)
+			continue
+		}
+		merged[id] = value
+	}
+	return merged
+}
+
+type ErrInvalidIncomingWebhook struct {
+	msg string
+}
+
+func (e ErrInvalidIncomingWebhook) Error() string {
+	return e.msg
+}
+
+type ErrInvalidIncomingWebhookRequest struct {
+	msg string
+}
+
+func (e ErrInvalidIncomingWebhookRequest) Error() string {
+	return e.msg
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncomingWebhookRequestCardProperties(t *testing.T) {
+	board := &Board{
+		CardProperties: []map[string]interface{}{
+			{
+				"id": "status", "name": "Status", "type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "todo", "value": "To Do"},
+					map[string]interface{}{"id": "done", "value": "Done"},
+				},
+			},
+			{
+				"id": "labels", "name": "Labels", "type": "multiSelect",
+				"options": []interface{}{
+					map[string]interface{}{"id": "ci", "value": "CI"},
+					map[string]interface{}{"id": "flaky", "value": "Flaky"},
+				},
+			},
+			{"id": "estimate", "name": "Estimate", "type": "number"},
+			{"id": "blocked", "name": "Blocked", "type": "checkbox"},
+			{"id": "due", "name": "Due", "type": "date"},
+			{"id": "link", "name": "Link", "type": "url"},
+			{"id": "created", "name": "Created", "type": "createdTime"},
+		},
+	}
+
+	t.Run("properties mapped by name and label", func(t *testing.T) {
+		request := &IncomingWebhookRequest{
+			Properties: map[string]interface{}{
+				"status":   "done",
+				"Labels":   []interface{}{"ci", "Flaky"},
+				"Estimate": float64(2.5),
+				"Blocked":  true,
+				"Due":      float64(1642161600000),
+				"Link":     "https://ci.example.com/1",
+			},
+		}
+
+		properties, err := request.CardProperties(board)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"status":   "done",
+			"labels":   []string{"ci", "flaky"},
+			"estimate": "2.5",
+			"blocked":  "true",
+			"due":      `{"from":1642161600000}`,
+			"link":     "https://ci.example.com/1",
+		}, properties)
+	})
+
+	t.Run("single multi select label", func(t *testing.T) {
+		request := &IncomingWebhookRequest{Properties: map[string]interface{}{"Labels": "CI"}}
+		properties, err := request.CardProperties(board)
+		require.NoError(t, err)
+		require.Equal(t, []string{"ci"}, properties["labels"])
+	})
+
+	t.Run("cleared property", func(t *testing.T) {
+		request := &IncomingWebhookRequest{Properties: map[string]interface{}{"Status": nil}}
+		properties, err := request.CardProperties(board)
+		require.NoError(t, err)
+		require.Contains(t, properties, "status")
+		require.Nil(t, properties["status"])
+	})
+
+	t.Run("invalid properties", func(t *testing.T) {
+		for name, value := range map[string]interface{}{
+			"Priority": "High",
+			"Status":   "Doing",
+			"Labels":   []interface{}{"CI", "Nightly"},
+			"Estimate": "many",
+			"Blocked":  "maybe",
+			"Due":      "tomorrow",
+			"Created":  float64(1),
+		} {
+			request := &IncomingWebhookRequest{Properties: map[string]interface{}{name: value}}
+			_, err := request.CardProperties(board)
+			require.Error(t, err, name)
+		}
+	})
+}
+
+func TestMergeCardProperties(t *testing.T) {
+	cardProperties := map[string]interface{}{"status": "todo", "estimate": "3"}
+
+	merged := MergeCardProperties(cardProperties, map[string]interface{}{"status": "done", "estimate": nil, "link": "url"})
+	require.Equal(t, map[string]interface{}{"status": "done", "link": "url"}, merged)
+	require.Equal(t, map[string]interface{}{"status": "todo", "estimate": "3"}, cardProperties, "the card properties are not modified")
+}
@@ -0,0 +1,379 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+)
+
+// CardFieldIterationID is the card field holding the id of the iteration the
+// card is planned in.
+const CardFieldIterationID = "iterationId"
+
+// States of an iteration. An iteration is planned, then started, then closed,
+// and a board has at most one active iteration.
+const (
+	IterationStatePlanned = "planned"
+	IterationStateActive  = "active"
+	IterationStateClosed  = "closed"
+)
+
+// Units of the iteration reports.
+const (
+	IterationUnitPoints = "points"
+	IterationUnitCards  = "cards"
+)
+
+const (
+	// IterationNameMaxRunes is the maximum length of the name of an iteration.
+	IterationNameMaxRunes = 255
+	// IterationGoalMaxRunes is the maximum length of the goal of an iteration.
+	IterationGoalMaxRunes = 4000
+
+	// iterationBurndownMaxPoints bounds the number of daily points of a
+	// burndown, for iterations spanning years.
+	iterationBurndownMaxPoints = 366
+
+	dayMillis = 24 * 60 * 60 * 1000
+)
+
+// Iteration is a time boxed period of work of a board, also known as a
+// sprint. Cards are planned in an iteration through their iterationId field.
+// swagger:model
+type Iteration struct {
+	// The id of the iteration
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board of the iteration
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The name of the iteration
+	// required: true
+	Name string `json:"name"`
+
+	// The goal of the iteration
+	// required: false
+	Goal string `json:"goal"`
+
+	// The planned start time in milliseconds since the current epoch
+	// required: false
+	StartAt int64 `json:"startAt"`
+
+	// The planned end time in milliseconds since the current epoch
+	// required: false
+	EndAt int64 `json:"endAt"`
+
+	// The state of the iteration: planned, active or closed
+	// required: true
+	State string `json:"state"`
+
+	// The id of the select property holding the status of the cards
+	// required: false
+	StatusPropertyID string `json:"statusPropertyId"`
+
+	// The ids of the options of the status property of done cards
+	// required: false
+	DoneOptionIDs []string `json:"doneOptionIds"`
+
+	// The id of the numeric property holding the estimate of the cards.
+	// Reports count cards if it is not set
+	// required: false
+	EstimatePropertyID string `json:"estimatePropertyId"`
+
+	// The id of the user who created the iteration
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The time the iteration was started in milliseconds since the current epoch
+	// required: false
+	StartedAt int64 `json:"startedAt"`
+
+	// The time the iteration was closed in milliseconds since the current epoch
+	// required: false
+	ClosedAt int64 `json:"closedAt"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+func (it *Iteration) IsValid() error {
+	if it == nil {
+		return ErrInvalidIteration{"cannot be nil"}
+	}
+
+	if err := IsValidId(it.BoardID); err != nil {
+		return ErrInvalidIteration{"invalid board id: " + err.Error()}
+	}
+
+	if it.Name == "" {
+		return ErrInvalidIteration{"missing name"}
+	}
+	if len([]rune(it.Name)) > IterationNameMaxRunes {
+		return ErrInvalidIteration{"name is too long"}
+	}
+	if len([]rune(it.Goal)) > IterationGoalMaxRunes {
+		return ErrInvalidIteration{"goal is too long"}
+	}
+
+	if it.StartAt < 0 || it.EndAt < 0 {
+		return ErrInvalidIteration{"invalid dates"}
+	}
+	if it.StartAt != 0 && it.EndAt != 0 && it.EndAt <= it.StartAt {
+		return ErrInvalidIteration{"the end date must be after the start date"}
+	}
+
+	switch it.State {
+	case IterationStatePlanned, IterationStateActive, IterationStateClosed:
+	default:
+		return ErrInvalidIteration{"invalid state"}
+	}
+
+	if len(it.DoneOptionIDs) != 0 && it.StatusPropertyID == "" {
+		return ErrInvalidIteration{"done options need a status property"}
+	}
+	return nil
+}
+
+// HasCard returns true if the card is planned in the iteration.
+func (it *Iteration) HasCard(card *Block) bool {
+	if card == nil || card.Type != TypeCard || card.DeleteAt != 0 {
+		return false
+	}
+	iterationID, _ := card.Fields[CardFieldIterationID].(string)
+	return iterationID == it.ID
+}
+
+// IsCardDone returns true if the status of the card is one of the done
+// options of the iteration.
+func (it *Iteration) IsCardDone(card *Block) bool {
+	if it.StatusPropertyID == "" {
+		return false
+	}
+	status, _ := getCardPropertyValue(card, it.StatusPropertyID).(string)
+	for _, optionID := range it.DoneOptionIDs {
+		if status == optionID {
+			return true
+		}
+	}
+	return false
+}
+
+// CardEstimate returns the amount of work of a card: its estimate if the
+// iteration has an estimate property, or one to count cards.
+func (it *Iteration) CardEstimate(card *Block) float64 {
+	if it.EstimatePropertyID == "" {
+		return 1
+	}
+	estimate, _ := numericValue(getCardPropertyValue(card, it.EstimatePropertyID))
+	return estimate
+}
+
+// Unit returns the unit of the reports of the iteration: points or cards.
+func (it *Iteration) Unit() string {
+	if it.EstimatePropertyID == "" {
+		return IterationUnitCards
+	}
+	return IterationUnitPoints
+}
+
+func IterationFromJSON(data io.Reader) (*Iteration, error) {
+	var iteration Iteration
+	if err := json.NewDecoder(data).Decode(&iteration); err != nil {
+		return nil, err
+	}
+	return &iteration, nil
+}
+
+// IterationCardsMove moves the unfinished cards of an iteration to another
+// iteration.
+// swagger:model
+type IterationCardsMove struct {
+	// The id of the iteration the cards are moved to
+	// required: true
+	IterationID string `json:"iterationId"`
+
+	// The ids of the moved cards. Only set in responses
+	// required: false
+	CardIDs []string `json:"cardIds"`
+}
+
+// IterationBurndown is the daily scope and completed work of an iteration,
+// for burndown and burnup charts.
+// swagger:model
+type IterationBurndown struct {
+	// The id of the iteration
+	// required: true
+	IterationID string `json:"iterationId"`
+
+	// The unit of the values: points or cards
+	// required: true
+	Unit string `json:"unit"`
+
+	// The daily values, oldest first, up to the end of the iteration or now
+	// required: true
+	Points []*BurndownPoint `json:"points"`
+}
+
+// BurndownPoint is the state of the work of an iteration at a given time.
+// swagger:model
+type BurndownPoint struct {
+	// The time of the point in milliseconds since the current epoch
+	// required: true
+	Date int64 `json:"date"`
+
+	// The total work planned in the iteration
+	// required: true
+	Scope float64 `json:"scope"`
+
+	// The work done
+	// required: true
+	Completed float64 `json:"completed"`
+
+	// The work left to do
+	// required: true
+	Remaining float64 `json:"remaining"`
+}
+
+// IterationVelocity is the work committed and completed in a closed
+// iteration.
+// swagger:model
+type IterationVelocity struct {
+	// The id of the iteration
+	// required: true
+	IterationID string `json:"iterationId"`
+
+	// The name of the iteration
+	// required: true
+	Name string `json:"name"`
+
+	// The unit of the values: points or cards
+	// required: true
+	Unit string `json:"unit"`
+
+	// The work planned when the iteration started
+	// required: true
+	Committed float64 `json:"committed"`
+
+	// The work done when the iteration closed
+	// required: true
+	Completed float64 `json:"completed"`
+
+	// The time the iteration was closed in milliseconds since the current epoch
+	// required: true
+	ClosedAt int64 `json:"closedAt"`
+}
+
+// WorkAt returns the scope and the completed work of the iteration at the
+// given time.
+func (it *Iteration) WorkAt(timeline *BlockTimeline, at int64) (scope, completed float64) {
+	for _, block := range timeline.BlocksAt(at) {
+		if !it.HasCard(block) {
+			continue
+		}
+		estimate := it.CardEstimate(block)
+		scope += estimate
+		if it.IsCardDone(block) {
+			completed += estimate
+		}
+	}
+	return scope, completed
+}
+
+// BuildIterationBurndown computes a daily point from the start of the
+// iteration to its end, or to now if it is not over. Iterations without
+// planned dates use the times they were started and closed.
+func BuildIterationBurndown(iteration *Iteration, timeline *BlockTimeline, now int64) *IterationBurndown {
+	burndown := &IterationBurndown{
+		IterationID: iteration.ID,
+		Unit:        iteration.Unit(),
+		Points:      []*BurndownPoint{},
+	}
+
+	start := iteration.StartAt
+	if start == 0 {
+		start = iteration.StartedAt
+	}
+	if start == 0 {
+		// the iteration is neither scheduled nor started
+		return burndown
+	}
+
+	end := iteration.EndAt
+	if iteration.ClosedAt != 0 && (end == 0 || iteration.ClosedAt < end) {
+		end = iteration.ClosedAt
+	}
+	if end == 0 || now < end {
+		end = now
+	}
+
+	addPoint := func(at int64) {
+		scope, completed := iteration.WorkAt(timeline, at)
+		burndown.Points = append(burndown.Points, &BurndownPoint{
+			Date:      at,
+			Scope:     scope,
+			Completed: completed,
+			Remaining: scope - completed,
+		})
+	}
+
+	at := start
+	for ; at < end && len(burndown.Points) < iterationBurndownMaxPoints; at += dayMillis {
+		addPoint(at)
+	}
+	if end >= start {
+		addPoint(end)
+	}
+	return burndown
+}
+
+// BuildIterationVelocity computes the committed and completed work of the
+// closed iterations, oldest first.
+func BuildIterationVelocity(iterations []*Iteration, timeline *BlockTimeline) []*IterationVelocity {
+	closed := []*Iteration{}
+	for _, iteration := range iterations {
+		if iteration.State == IterationStateClosed {
+			closed = append(closed, iteration)
+		}
+	}
+	sort.Slice(closed, func(i, j int) bool {
+		return closed[i].ClosedAt < closed[j].ClosedAt
+	})
+
+	velocity := make([]*IterationVelocity, 0, len(closed))
+	for _, iteration := range closed {
+		startedAt := iteration.StartedAt
+		if startedAt == 0 {
+			startedAt = iteration.StartAt
+		}
+		committed, _ := iteration.WorkAt(timeline, startedAt)
+		_, completed := iteration.WorkAt(timeline, iteration.ClosedAt)
+
+		velocity = append(velocity, &IterationVelocity{
+			IterationID: iteration.ID,
+			Name:        iteration.Name,
+			Unit:        iteration.Unit(),
+			Committed:   committed,
+			Completed:   completed,
+			ClosedAt:    iteration.ClosedAt,
+		})
+	}
+	return velocity
+}
+
+type ErrInvalidIteration struct {
+	msg string
+}
+
+func (e ErrInvalidIteration) Error() string {
+	return e.msg
+}
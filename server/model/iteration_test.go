@@ -0,0 +1,132 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIterationIsValid(t *testing.T) {
+	validIteration := func() *Iteration {
+		return &Iteration{
+			BoardID:          utils.NewID(utils.IDTypeBoard),
+			Name:             "Sprint 1",
+			StartAt:          1000,
+			EndAt:            2000,
+			State:            IterationStatePlanned,
+			StatusPropertyID: "status",
+			DoneOptionIDs:    []string{"done"},
+		}
+	}
+
+	require.NoError(t, validIteration().IsValid())
+
+	testCases := []struct {
+		name   string
+		modify func(it *Iteration)
+	}{
+		{name: "missing name", modify: func(it *Iteration) { it.Name = "" }},
+		{name: "invalid board id", modify: func(it *Iteration) { it.BoardID = "board" }},
+		{name: "end before start", modify: func(it *Iteration) { it.EndAt = 500 }},
+		{name: "invalid state", modify: func(it *Iteration) { it.State = "paused" }},
+		{name: "done options without status", modify: func(it *Iteration) { it.StatusPropertyID = "" }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			iteration := validIteration()
+			tc.modify(iteration)
+			require.Error(t, iteration.IsValid())
+		})
+	}
+}
+
+func makeIterationCardVersion(id string, updateAt int64, iterationID, status, points string) *Block {
+	return &Block{
+		ID:       id,
+		Type:     TypeCard,
+		UpdateAt: updateAt,
+		Fields: map[string]interface{}{
+			CardFieldIterationID: iterationID,
+			"properties":         map[string]interface{}{"status": status, "points": points},
+		},
+	}
+}
+
+func TestBuildIterationBurndown(t *testing.T) {
+	iteration := &Iteration{
+		ID:                 "sprint",
+		StartAt:            dayMillis,
+		EndAt:              4 * dayMillis,
+		State:              IterationStateActive,
+		StatusPropertyID:   "status",
+		DoneOptionIDs:      []string{"done"},
+		EstimatePropertyID: "points",
+	}
+
+	timeline := NewBlockTimeline([]*Block{
+		makeIterationCardVersion("card1", dayMillis/2, "sprint", "todo", "3"),
+		makeIterationCardVersion("card2", dayMillis/2, "sprint", "todo", "5"),
+		// done during the first day
+		makeIterationCardVersion("card1", dayMillis+10, "sprint", "done", "3"),
+		// added during the second day
+		makeIterationCardVersion("card3", 2*dayMillis+10, "sprint", "todo", "2"),
+		// moved out of the iteration during the third day
+		makeIterationCardVersion("card2", 3*dayMillis+10, "next", "todo", "5"),
+		// not in the iteration
+		makeIterationCardVersion("card4", dayMillis/2, "next", "done", "8"),
+	})
+
+	t.Run("iteration in progress", func(t *testing.T) {
+		burndown := BuildIterationBurndown(iteration, timeline, 2*dayMillis+20)
+		require.Equal(t, IterationUnitPoints, burndown.Unit)
+		require.Len(t, burndown.Points, 3)
+		require.Equal(t, BurndownPoint{Date: dayMillis, Scope: 8, Completed: 0, Remaining: 8}, *burndown.Points[0])
+		require.Equal(t, BurndownPoint{Date: 2 * dayMillis, Scope: 8, Completed: 3, Remaining: 5}, *burndown.Points[1])
+		require.Equal(t, BurndownPoint{Date: 2*dayMillis + 20, Scope: 10, Completed: 3, Remaining: 7}, *burndown.Points[2])
+	})
+
+	t.Run("iteration over", func(t *testing.T) {
+		burndown := BuildIterationBurndown(iteration, timeline, 10*dayMillis)
+		require.Len(t, burndown.Points, 4)
+		require.Equal(t, BurndownPoint{Date: 4 * dayMillis, Scope: 5, Completed: 3, Remaining: 2}, *burndown.Points[3])
+	})
+
+	t.Run("counting cards", func(t *testing.T) {
+		countingIteration := *iteration
+		countingIteration.EstimatePropertyID = ""
+		burndown := BuildIterationBurndown(&countingIteration, timeline, 10*dayMillis)
+		require.Equal(t, IterationUnitCards, burndown.Unit)
+		require.Equal(t, float64(2), burndown.Points[3].Scope)
+		require.Equal(t, float64(1), burndown.Points[3].Completed)
+	})
+
+	t.Run("iteration not started", func(t *testing.T) {
+		burndown := BuildIterationBurndown(&Iteration{ID: "sprint", State: IterationStatePlanned}, timeline, 10*dayMillis)
+		require.Empty(t, burndown.Points)
+	})
+}
+
+func TestBuildIterationVelocity(t *testing.T) {
+	iterations := []*Iteration{
+		{ID: "sprint2", Name: "Sprint 2", State: IterationStateClosed, StartedAt: 300, ClosedAt: 400},
+		{ID: "sprint1", Name: "Sprint 1", State: IterationStateClosed, StartedAt: 100, ClosedAt: 200, StatusPropertyID: "status", DoneOptionIDs: []string{"done"}},
+		{ID: "sprint3", Name: "Sprint 3", State: IterationStateActive, StartedAt: 500},
+	}
+
+	timeline := NewBlockTimeline([]*Block{
+		makeIterationCardVersion("card1", 50, "sprint1", "todo", ""),
+		makeIterationCardVersion("card2", 50, "sprint1", "todo", ""),
+		makeIterationCardVersion("card1", 150, "sprint1", "done", ""),
+		makeIterationCardVersion("card2", 250, "sprint2", "todo", ""),
+	})
+
+	velocity := BuildIterationVelocity(iterations, timeline)
+	require.Len(t, velocity, 2)
+	require.Equal(t, IterationVelocity{IterationID: "sprint1", Name: "Sprint 1", Unit: IterationUnitCards, Committed: 2, Completed: 1, ClosedAt: 200}, *velocity[0])
+	require.Equal(t, IterationVelocity{IterationID: "sprint2", Name: "Sprint 2", Unit: IterationUnitCards, Committed: 1, Completed: 0, ClosedAt: 400}, *velocity[1])
+}
@@ -199,6 +199,21 @@ func (mr *MockServicesAPIMockRecorder) GetDirectChannelOrCreate(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirectChannelOrCreate", reflect.TypeOf((*MockServicesAPI)(nil).GetDirectChannelOrCreate), arg0, arg1)
 }
 
+// GetFile mocks base method.
+func (m *MockServicesAPI) GetFile(arg0 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFile", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFile indicates an expected call of GetFile.
+func (mr *MockServicesAPIMockRecorder) GetFile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFile", reflect.TypeOf((*MockServicesAPI)(nil).GetFile), arg0)
+}
+
 // GetFileInfo mocks base method.
 func (m *MockServicesAPI) GetFileInfo(arg0 string) (*model.FileInfo, error) {
 	m.ctrl.T.Helper()
@@ -257,6 +272,36 @@ func (mr *MockServicesAPIMockRecorder) GetMasterDB() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMasterDB", reflect.TypeOf((*MockServicesAPI)(nil).GetMasterDB))
 }
 
+// GetPost mocks base method.
+func (m *MockServicesAPI) GetPost(arg0 string) (*model.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPost", arg0)
+	ret0, _ := ret[0].(*model.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPost indicates an expected call of GetPost.
+func (mr *MockServicesAPIMockRecorder) GetPost(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPost", reflect.TypeOf((*MockServicesAPI)(nil).GetPost), arg0)
+}
+
+// GetPostThread mocks base method.
+func (m *MockServicesAPI) GetPostThread(arg0 string) (*model.PostList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostThread", arg0)
+	ret0, _ := ret[0].(*model.PostList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostThread indicates an expected call of GetPostThread.
+func (mr *MockServicesAPIMockRecorder) GetPostThread(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostThread", reflect.TypeOf((*MockServicesAPI)(nil).GetPostThread), arg0)
+}
+
 // GetPreferencesForUser mocks base method.
 func (m *MockServicesAPI) GetPreferencesForUser(arg0 string) (model.Preferences, error) {
 	m.ctrl.T.Helper()
@@ -430,6 +475,20 @@ func (mr *MockServicesAPIMockRecorder) PublishWebSocketEvent(arg0, arg1, arg2 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishWebSocketEvent", reflect.TypeOf((*MockServicesAPI)(nil).PublishWebSocketEvent), arg0, arg1, arg2)
 }
 
+// RegisterCommand mocks base method.
+func (m *MockServicesAPI) RegisterCommand(arg0 *model.Command) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterCommand", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterCommand indicates an expected call of RegisterCommand.
+func (mr *MockServicesAPIMockRecorder) RegisterCommand(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterCommand", reflect.TypeOf((*MockServicesAPI)(nil).RegisterCommand), arg0)
+}
+
 // RegisterRouter mocks base method.
 func (m *MockServicesAPI) RegisterRouter(arg0 *mux.Router) {
 	m.ctrl.T.Helper()
@@ -442,6 +501,21 @@ func (mr *MockServicesAPIMockRecorder) RegisterRouter(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRouter", reflect.TypeOf((*MockServicesAPI)(nil).RegisterRouter), arg0)
 }
 
+// UpdatePost mocks base method.
+func (m *MockServicesAPI) UpdatePost(arg0 *model.Post) (*model.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePost", arg0)
+	ret0, _ := ret[0].(*model.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePost indicates an expected call of UpdatePost.
+func (mr *MockServicesAPIMockRecorder) UpdatePost(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePost", reflect.TypeOf((*MockServicesAPI)(nil).UpdatePost), arg0)
+}
+
 // UpdatePreferencesForUser mocks base method.
 func (m *MockServicesAPI) UpdatePreferencesForUser(arg0 string, arg1 model.Preferences) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,94 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	mmModel "github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	// PreferenceNotificationDigest is the name of the user preference
+	// holding how often the changes to the subscribed boards and cards are
+	// notified.
+	PreferenceNotificationDigest = "notificationDigest"
+
+	DigestFrequencyImmediate = "immediate"
+	DigestFrequencyHourly    = "hourly"
+	DigestFrequencyDaily     = "daily"
+	DigestFrequencyWeekly    = "weekly"
+
+	// digestHour is the local time of the day the daily and weekly digests
+	// are sent at.
+	digestHour = 9
+)
+
+// IsValidDigestFrequency returns true if the frequency is a known
+// notification digest frequency.
+func IsValidDigestFrequency(frequency string) bool {
+	switch frequency {
+	case DigestFrequencyImmediate, DigestFrequencyHourly, DigestFrequencyDaily, DigestFrequencyWeekly:
+		return true
+	}
+	return false
+}
+
+// GetDigestFrequency returns the notification digest frequency of a user from
+// their preferences, immediate by default.
+func GetDigestFrequency(preferences mmModel.Preferences) string {
+	for _, preference := range preferences {
+		if preference.Category == PreferencesCategoryFocalboard && preference.Name == PreferenceNotificationDigest &&
+			IsValidDigestFrequency(preference.Value) {
+			return preference.Value
+		}
+	}
+	return DigestFrequencyImmediate
+}
+
+// NextDigestAt returns the time of the first digest of the given frequency
+// after a time, in milliseconds since the current epoch. Hourly digests are
+// sent at the start of every hour, daily digests every day at 9 AM and
+// weekly digests on Mondays at 9 AM, in the time zone of the user.
+func NextDigestAt(frequency string, after int64, loc *time.Location) int64 {
+	t := utils.GetTimeForMillis(after).In(loc)
+
+	var next time.Time
+	switch frequency {
+	case DigestFrequencyHourly:
+		next = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+	case DigestFrequencyDaily:
+		next = time.Date(t.Year(), t.Month(), t.Day(), digestHour, 0, 0, 0, loc)
+		if !next.After(t) {
+			next = next.AddDate(0, 0, 1)
+		}
+	case DigestFrequencyWeekly:
+		daysUntilMonday := (int(time.Monday) - int(t.Weekday()) + 7) % 7
+		next = time.Date(t.Year(), t.Month(), t.Day()+daysUntilMonday, digestHour, 0, 0, 0, loc)
+		if !next.After(t) {
+			next = next.AddDate(0, 0, 7)
+		}
+	default:
+		return after
+	}
+	return utils.GetMillisForTime(next)
+}
+
+// NotificationDigestItem is a subscribed block with changes waiting to be
+// sent to a user in their next notification digest.
+type NotificationDigestItem struct {
+	UserID  string
+	BlockID string
+	BoardID string
+
+	// The changes made after this time are part of the digest
+	SinceAt int64
+
+	// The time the digest is due
+	NotifyAt int64
+
+	CreateAt int64
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mmModel "github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestGetDigestFrequency(t *testing.T) {
+	require.Equal(t, DigestFrequencyImmediate, GetDigestFrequency(nil))
+
+	preferences := mmModel.Preferences{
+		{Category: "other", Name: PreferenceNotificationDigest, Value: DigestFrequencyHourly},
+		{Category: PreferencesCategoryFocalboard, Name: PreferenceNotificationDigest, Value: DigestFrequencyWeekly},
+	}
+	require.Equal(t, DigestFrequencyWeekly, GetDigestFrequency(preferences))
+
+	preferences[1].Value = "monthly"
+	require.Equal(t, DigestFrequencyImmediate, GetDigestFrequency(preferences))
+}
+
+func TestNextDigestAt(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	at := func(day, hour, minute int) int64 {
+		return time.Date(2024, time.March, day, hour, minute, 0, 0, loc).UnixMilli()
+	}
+
+	// Wednesday, March 13, 2024
+	wednesday := at(13, 10, 30)
+
+	testCases := []struct {
+		name      string
+		frequency string
+		after     int64
+		expected  int64
+	}{
+		{"immediate", DigestFrequencyImmediate, wednesday, wednesday},
+		{"hourly", DigestFrequencyHourly, wednesday, at(13, 11, 0)},
+		{"hourly on the hour", DigestFrequencyHourly, at(13, 11, 0), at(13, 12, 0)},
+		{"daily after the digest hour", DigestFrequencyDaily, wednesday, at(14, 9, 0)},
+		{"daily before the digest hour", DigestFrequencyDaily, at(13, 8, 0), at(13, 9, 0)},
+		{"weekly", DigestFrequencyWeekly, wednesday, at(18, 9, 0)},
+		{"weekly on Monday before the digest hour", DigestFrequencyWeekly, at(18, 8, 0), at(18, 9, 0)},
+		{"weekly on Monday at the digest hour", DigestFrequencyWeekly, at(18, 9, 0), at(25, 9, 0)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, NextDigestAt(tc.frequency, tc.after, loc))
+		})
+	}
+
+	t.Run("in the user timezone", func(t *testing.T) {
+		// 10:30 in New York is 15:30 in Paris, after the digest hour
+		paris, err := time.LoadLocation("Europe/Paris")
+		require.NoError(t, err)
+		expected := time.Date(2024, time.March, 14, 9, 0, 0, 0, paris).UnixMilli()
+		require.Equal(t, expected, NextDigestAt(DigestFrequencyDaily, wednesday, paris))
+	})
+}
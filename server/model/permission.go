@@ -25,4 +25,6 @@ var (
 	PermissionManageBoardProperties = &mmModel.Permission{Id: "manage_board_properties", Name: "", Description: "", Scope: ""}
 	PermissionCommentBoardCards     = &mmModel.Permission{Id: "comment_board_cards", Name: "", Description: "", Scope: ""}
 	PermissionDeleteOthersComments  = &mmModel.Permission{Id: "delete_others_comments", Name: "", Description: "", Scope: ""}
+
+	PermissionEditBoardCardProperties = &mmModel.Permission{Id: "edit_board_card_properties", Name: "", Description: "", Scope: ""}
 )
@@ -21,6 +21,27 @@ var ErrInvalidPropertyValue = errors.New("invalid property value")
 var ErrInvalidPropertyValueType = errors.New("invalid property value type")
 var ErrInvalidDate = errors.New("invalid date property")
 
+// Property types as defined in a board's card properties.
+const (
+	PropTypeText        = "text"
+	PropTypeNumber      = "number"
+	PropTypeEmail       = "email"
+	PropTypePhone       = "phone"
+	PropTypeURL         = "url"
+	PropTypeCheckbox    = "checkbox"
+	PropTypeSelect      = "select"
+	PropTypeMultiSelect = "multiSelect"
+	PropTypeDate        = "date"
+	PropTypePerson      = "person"
+	PropTypeMultiPerson = "multiPerson"
+	PropTypeCreatedTime = "createdTime"
+	PropTypeCreatedBy   = "createdBy"
+	PropTypeUpdatedTime = "updatedTime"
+	PropTypeUpdatedBy   = "updatedBy"
+	PropTypeRollup      = "rollup"
+	PropTypeFormula     = "formula"
+)
+
 // PropValueResolver allows PropDef.GetValue to further decode property values, such as
 // looking up usernames from ids.
 type PropValueResolver interface {
@@ -56,6 +77,8 @@ type PropDef struct {
 	Name    string                   `json:"name"`
 	Type    string                   `json:"type"`
 	Options map[string]PropDefOption `json:"options"`
+	Rollup  *PropRollup              `json:"rollup,omitempty"`
+	Formula string                   `json:"formula,omitempty"`
 }
 
 // GetValue resolves the value of a property if the passed value is an ID for an option,
@@ -179,36 +202,57 @@ func ParsePropertySchema(board *Board) (PropSchema, error) {
 	schema := make(map[string]PropDef)
 
 	for i, prop := range board.CardProperties {
-		pd := PropDef{
-			ID:      getMapString("id", prop),
-			Index:   i,
-			Name:    getMapString("name", prop),
-			Type:    getMapString("type", prop),
-			Options: make(map[string]PropDefOption),
+		pd, err := parsePropDef(prop, i)
+		if err != nil {
+			return nil, err
 		}
-		optsIface, ok := prop["options"]
-		if ok {
-			opts, ok := optsIface.([]interface{})
+		schema[pd.ID] = pd
+	}
+	return schema, nil
+}
+
+func parsePropDef(prop map[string]interface{}, index int) (PropDef, error) {
+	pd := PropDef{
+		ID:      getMapString("id", prop),
+		Index:   index,
+		Name:    getMapString("name", prop),
+		Type:    getMapString("type", prop),
+		Options: make(map[string]PropDefOption),
+		Formula: getMapString("formula", prop),
+	}
+	optsIface, ok := prop["options"]
+	if ok {
+		opts, ok := optsIface.([]interface{})
+		if !ok {
+			return pd, ErrInvalidPropSchema
+		}
+		for j, propOptIface := range opts {
+			propOpt, ok := propOptIface.(map[string]interface{})
 			if !ok {
-				return nil, ErrInvalidPropSchema
+				return pd, ErrInvalidPropSchema
 			}
-			for j, propOptIface := range opts {
-				propOpt, ok := propOptIface.(map[string]interface{})
-				if !ok {
-					return nil, ErrInvalidPropSchema
-				}
-				po := PropDefOption{
-					ID:    getMapString("id", propOpt),
-					Index: j,
-					Value: getMapString("value", propOpt),
-					Color: getMapString("color", propOpt),
-				}
-				pd.Options[po.ID] = po
+			po := PropDefOption{
+				ID:    getMapString("id", propOpt),
+				Index: j,
+				Value: getMapString("value", propOpt),
+				Color: getMapString("color", propOpt),
 			}
+			pd.Options[po.ID] = po
 		}
-		schema[pd.ID] = pd
 	}
-	return schema, nil
+	rollupIface, ok := prop["rollup"]
+	if ok && rollupIface != nil {
+		rollup, ok := rollupIface.(map[string]interface{})
+		if !ok {
+			return pd, ErrInvalidPropSchema
+		}
+		pd.Rollup = &PropRollup{
+			Relation:   getMapString("relation", rollup),
+			PropertyID: getMapString("propertyId", rollup),
+			Function:   getMapString("function", rollup),
+		}
+	}
+	return pd, nil
 }
 
 func getMapString(key string, m map[string]interface{}) string {
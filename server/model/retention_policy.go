@@ -0,0 +1,206 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// RetentionPolicy overrides the global data retention setting for the
+// boards of a team or for a single board. A policy without retention days
+// retains the boards indefinitely.
+// swagger:model
+type RetentionPolicy struct {
+	// The id of the policy
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the team the policy applies to. Either the team id or the board id is set
+	// required: false
+	TeamID string `json:"teamId"`
+
+	// The id of the board the policy applies to. Either the team id or the board id is set
+	// required: false
+	BoardID string `json:"boardId"`
+
+	// The number of days without activity after which a board is deleted, 0 to never delete it
+	// required: true
+	RetentionDays int `json:"retentionDays"`
+
+	// The ids of the boards of the team that are never deleted
+	// required: false
+	ExemptBoardIDs []string `json:"exemptBoardIds"`
+
+	// The id of the user who created the policy
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+func (p *RetentionPolicy) IsValid() error {
+	if p == nil {
+		return ErrInvalidRetentionPolicy{"cannot be nil"}
+	}
+
+	if (p.TeamID == "") == (p.BoardID == "") {
+		return ErrInvalidRetentionPolicy{"either a team id or a board id is required"}
+	}
+
+	if p.BoardID != "" && len(p.ExemptBoardIDs) != 0 {
+		return ErrInvalidRetentionPolicy{"exempt boards can only be set in a team policy"}
+	}
+
+	if p.RetentionDays < 0 {
+		return ErrInvalidRetentionPolicy{"retention days cannot be negative"}
+	}
+	return nil
+}
+
+// IsIndefinite returns true if the boards of the policy are never deleted.
+func (p *RetentionPolicy) IsIndefinite() bool {
+	return p.RetentionDays == 0
+}
+
+func RetentionPolicyFromJSON(data io.Reader) (*RetentionPolicy, error) {
+	var policy RetentionPolicy
+	if err := json.NewDecoder(data).Decode(&policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// RetentionCutoffs are the dates, in milliseconds since the epoch, before
+// which the boards without activity are deleted by data retention. A zero
+// date retains the boards indefinitely.
+type RetentionCutoffs struct {
+	// Global applies to the boards without a policy
+	Global int64
+
+	// Teams are the dates of the team policies, keyed by team id
+	Teams map[string]int64
+
+	// Boards are the dates of the board policies, keyed by board id
+	Boards map[string]int64
+
+	// Exempt are the ids of the boards that are never deleted, keyed by the
+	// id of the team whose policy exempts them
+	Exempt map[string]map[string]bool
+}
+
+// NewRetentionCutoffs returns the cutoffs of the policies at a given time.
+// globalDays is the global retention setting, 0 if it is disabled.
+func NewRetentionCutoffs(policies []*RetentionPolicy, globalDays int, now time.Time) *RetentionCutoffs {
+	cutoffs := &RetentionCutoffs{
+		Teams:  map[string]int64{},
+		Boards: map[string]int64{},
+		Exempt: map[string]map[string]bool{},
+	}
+	if globalDays > 0 {
+		cutoffs.Global = RetentionCutoffDate(globalDays, now)
+	}
+
+	for _, policy := range policies {
+		var cutoff int64
+		if !policy.IsIndefinite() {
+			cutoff = RetentionCutoffDate(policy.RetentionDays, now)
+		}
+
+		if policy.BoardID != "" {
+			cutoffs.Boards[policy.BoardID] = cutoff
+			continue
+		}
+
+		cutoffs.Teams[policy.TeamID] = cutoff
+		if len(policy.ExemptBoardIDs) == 0 {
+			continue
+		}
+		exempt := map[string]bool{}
+		for _, boardID := range policy.ExemptBoardIDs {
+			exempt[boardID] = true
+		}
+		cutoffs.Exempt[policy.TeamID] = exempt
+	}
+	return cutoffs
+}
+
+// CutoffForBoard returns the date before which a board without activity is
+// deleted. A board policy takes precedence over a team policy, which takes
+// precedence over the global setting.
+func (c *RetentionCutoffs) CutoffForBoard(teamID, boardID string) int64 {
+	if c.Exempt[teamID][boardID] {
+		return 0
+	}
+	if cutoff, ok := c.Boards[boardID]; ok {
+		return cutoff
+	}
+	if cutoff, ok := c.Teams[teamID]; ok {
+		return cutoff
+	}
+	return c.Global
+}
+
+// IsEmpty returns true if no board can be deleted with the cutoffs.
+func (c *RetentionCutoffs) IsEmpty() bool {
+	if c.Global != 0 {
+		return false
+	}
+	for _, cutoff := range c.Teams {
+		if cutoff != 0 {
+			return false
+		}
+	}
+	for _, cutoff := range c.Boards {
+		if cutoff != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// RetentionCutoffDate returns the start of the day a number of days before
+// now, in milliseconds since the epoch.
+func RetentionCutoffDate(days int, now time.Time) int64 {
+	upToStartOfDay := now.AddDate(0, 0, -days)
+	cutoffDate := time.Date(upToStartOfDay.Year(), upToStartOfDay.Month(), upToStartOfDay.Day(), 0, 0, 0, 0, time.Local)
+	return cutoffDate.UnixNano() / int64(time.Millisecond)
+}
+
+// RetentionPreview reports what a data retention run would delete.
+// swagger:model
+type RetentionPreview struct {
+	// The number of boards that would be deleted
+	// required: true
+	BoardCount int64 `json:"boardCount"`
+
+	// The number of blocks that would be deleted
+	// required: true
+	BlockCount int64 `json:"blockCount"`
+
+	// The number of block and board history rows that would be deleted
+	// required: true
+	HistoryCount int64 `json:"historyCount"`
+
+	// The number of rows of the other data of the boards and their cards,
+	// such as share links, guest comments, webhooks and reminders, that
+	// would be deleted
+	// required: true
+	DataCount int64 `json:"dataCount"`
+}
+
+type ErrInvalidRetentionPolicy struct {
+	msg string
+}
+
+func (e ErrInvalidRetentionPolicy) Error() string {
+	return e.msg
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetentionPolicyIsValid(t *testing.T) {
+	testCases := []struct {
+		name   string
+		policy *RetentionPolicy
+		valid  bool
+	}{
+		{"team policy", &RetentionPolicy{TeamID: "team-id", RetentionDays: 30, ExemptBoardIDs: []string{"board-id"}}, true},
+		{"indefinite board policy", &RetentionPolicy{BoardID: "board-id"}, true},
+		{"no scope", &RetentionPolicy{RetentionDays: 30}, false},
+		{"team and board", &RetentionPolicy{TeamID: "team-id", BoardID: "board-id", RetentionDays: 30}, false},
+		{"board policy with exempt boards", &RetentionPolicy{BoardID: "board-id", ExemptBoardIDs: []string{"other-id"}}, false},
+		{"negative days", &RetentionPolicy{TeamID: "team-id", RetentionDays: -1}, false},
+		{"nil policy", nil, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy.IsValid()
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestRetentionCutoffs(t *testing.T) {
+	now := time.Date(2024, time.March, 15, 10, 30, 0, 0, time.Local)
+	policies := []*RetentionPolicy{
+		{TeamID: "team-1", RetentionDays: 30, ExemptBoardIDs: []string{"audit-board"}},
+		{TeamID: "team-2"},
+		{BoardID: "short-board", RetentionDays: 7},
+	}
+
+	cutoffs := NewRetentionCutoffs(policies, 365, now)
+
+	t.Run("cutoff dates", func(t *testing.T) {
+		require.Equal(t, RetentionCutoffDate(365, now), cutoffs.Global)
+		require.Equal(t, time.Date(2023, time.March, 16, 0, 0, 0, 0, time.Local).UnixMilli(), cutoffs.Global)
+		require.Equal(t, time.Date(2024, time.February, 14, 0, 0, 0, 0, time.Local).UnixMilli(), cutoffs.Teams["team-1"])
+	})
+
+	t.Run("precedence", func(t *testing.T) {
+		require.Equal(t, cutoffs.Teams["team-1"], cutoffs.CutoffForBoard("team-1", "board"))
+		require.Equal(t, int64(0), cutoffs.CutoffForBoard("team-1", "audit-board"))
+		// the exemption of a team policy does not apply to the other teams
+		require.Equal(t, cutoffs.Global, cutoffs.CutoffForBoard("team-3", "audit-board"))
+		require.Equal(t, int64(0), cutoffs.CutoffForBoard("team-2", "board"))
+		require.Equal(t, cutoffs.Boards["short-board"], cutoffs.CutoffForBoard("team-2", "short-board"))
+		require.Equal(t, cutoffs.Global, cutoffs.CutoffForBoard("team-3", "board"))
+	})
+
+	t.Run("empty cutoffs", func(t *testing.T) {
+		require.False(t, cutoffs.IsEmpty())
+		require.True(t, NewRetentionCutoffs(nil, 0, now).IsEmpty())
+		require.True(t, NewRetentionCutoffs(policies[1:2], 0, now).IsEmpty())
+		require.False(t, NewRetentionCutoffs(policies[2:], 0, now).IsEmpty())
+	})
+}
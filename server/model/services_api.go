@@ -37,6 +37,9 @@ type ServicesAPI interface {
 
 	// Post service
 	CreatePost(post *mm_model.Post) (*mm_model.Post, error)
+	GetPost(postID string) (*mm_model.Post, error)
+	GetPostThread(postID string) (*mm_model.PostList, error)
+	UpdatePost(post *mm_model.Post) (*mm_model.Post, error)
 
 	// User service
 	GetUserByID(userID string) (*mm_model.User, error)
@@ -62,6 +65,7 @@ type ServicesAPI interface {
 
 	// FileInfoStore service
 	GetFileInfo(fileID string) (*mm_model.FileInfo, error)
+	GetFile(fileID string) ([]byte, error)
 
 	// Cluster service
 	PublishWebSocketEvent(event string, payload map[string]interface{}, broadcast *mm_model.WebsocketBroadcast)
@@ -85,6 +89,9 @@ type ServicesAPI interface {
 	// Router service
 	RegisterRouter(sub *mux.Router)
 
+	// Command service
+	RegisterCommand(command *mm_model.Command) error
+
 	// Preferences services
 	GetPreferencesForUser(userID string) (mm_model.Preferences, error)
 	UpdatePreferencesForUser(userID string, preferences mm_model.Preferences) error
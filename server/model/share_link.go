@@ -0,0 +1,255 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// ShareLinkNameMaxRunes is the maximum length of the name of a share link.
+	ShareLinkNameMaxRunes = 100
+
+	// ShareLinkPasswordMaxBytes is the maximum length of the password of a
+	// share link, the longest password bcrypt can hash.
+	ShareLinkPasswordMaxBytes = 72
+
+	// ShareLinkPasswordAttemptLimit is the number of wrong passwords that can
+	// be tried for a share link from the same address within
+	// ShareLinkPasswordAttemptWindow.
+	ShareLinkPasswordAttemptLimit = 5
+
+	// ShareLinkPasswordLinkAttemptLimit is the number of wrong passwords that
+	// can be tried for a share link from all addresses within
+	// ShareLinkPasswordAttemptWindow.
+	ShareLinkPasswordLinkAttemptLimit = 50
+
+	// ShareLinkPasswordAttemptWindow is the password attempt limit window, in
+	// milliseconds.
+	ShareLinkPasswordAttemptWindow = int64(15 * 60 * 1000)
+)
+
+const (
+	// ShareLinkModeViewer gives read only access to the board.
+	ShareLinkModeViewer = "viewer"
+
+	// ShareLinkModeCommenter also allows to submit comments on the cards,
+	// which are shown once approved by a board admin.
+	ShareLinkModeCommenter = "commenter"
+)
+
+var ErrShareLinkPasswordRequired = errors.New("share link password required")
+
+// ErrWrongShareLinkPassword is returned when the password of a share link is
+// missing or wrong. It wraps ErrShareLinkPasswordRequired.
+type ErrWrongShareLinkPassword struct {
+	ShareLinkID string
+}
+
+func (e ErrWrongShareLinkPassword) Error() string {
+	return ErrShareLinkPasswordRequired.Error()
+}
+
+func (e ErrWrongShareLinkPassword) Unwrap() error {
+	return ErrShareLinkPasswordRequired
+}
+
+// ShareLink is a named public link to a board. A link can expire, be
+// protected by a password and be restricted to a single view of the board.
+// swagger:model
+type ShareLink struct {
+	// The id of the share link
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the shared board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The name of the share link
+	// required: true
+	Name string `json:"name"`
+
+	// The read token of the share link
+	// required: true
+	Token string `json:"token"`
+
+	// The access the link gives, viewer or commenter. Defaults to viewer
+	// required: false
+	Mode string `json:"mode"`
+
+	// The id of the only view of the board the link gives access to, empty for all the views
+	// required: false
+	ViewID string `json:"viewId"`
+
+	// The time in milliseconds since the current epoch after which the link cannot be used, 0 if it never expires
+	// required: false
+	ExpiresAt int64 `json:"expiresAt"`
+
+	// The password of the link. It is only accepted when the link is created or updated, and never returned
+	// required: false
+	Password string `json:"password,omitempty"`
+
+	// Whether a password is required to use the link. Updating a link with false removes its password
+	// required: false
+	HasPassword bool `json:"hasPassword"`
+
+	// The bcrypt hash of the password of the link
+	PasswordHash string `json:"-"`
+
+	// The time in milliseconds since the current epoch the link was revoked at, 0 if it is not revoked
+	// required: false
+	RevokedAt int64 `json:"revokedAt"`
+
+	// The id of the user who created the link
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+func (l *ShareLink) IsValid() error {
+	if l == nil {
+		return ErrInvalidShareLink{"cannot be nil"}
+	}
+
+	if err := IsValidId(l.BoardID); err != nil {
+		return ErrInvalidShareLink{"invalid board id: " + err.Error()}
+	}
+
+	if l.Name == "" {
+		return ErrInvalidShareLink{"name is required"}
+	}
+
+	if len([]rune(l.Name)) > ShareLinkNameMaxRunes {
+		return ErrInvalidShareLink{"name is too long"}
+	}
+
+	if l.Token == "" {
+		return ErrInvalidShareLink{"token is required"}
+	}
+
+	if l.Mode != ShareLinkModeViewer && l.Mode != ShareLinkModeCommenter {
+		return ErrInvalidShareLink{"invalid mode: " + l.Mode}
+	}
+
+	if l.ExpiresAt < 0 {
+		return ErrInvalidShareLink{"expiration time cannot be negative"}
+	}
+
+	if len(l.Password) > ShareLinkPasswordMaxBytes {
+		return ErrInvalidShareLink{"password is too long"}
+	}
+	return nil
+}
+
+// IsActive returns true if the link can be used at the given time, in
+// milliseconds since the epoch.
+func (l *ShareLink) IsActive(now int64) bool {
+	if l.RevokedAt != 0 {
+		return false
+	}
+	return l.ExpiresAt == 0 || now < l.ExpiresAt
+}
+
+// SetPassword hashes the password of the link, or removes it if empty.
+func (l *ShareLink) SetPassword(password string) error {
+	l.Password = ""
+	if password == "" {
+		l.PasswordHash = ""
+		l.HasPassword = false
+		return nil
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	l.PasswordHash = string(hash)
+	l.HasPassword = true
+	return nil
+}
+
+// CheckPassword returns true if the password matches the one of the link,
+// or if the link has no password.
+func (l *ShareLink) CheckPassword(password string) bool {
+	if l.PasswordHash == "" {
+		return true
+	}
+	return bcrypt.CompareHashAndPassword([]byte(l.PasswordHash), []byte(password)) == nil
+}
+
+func ShareLinkFromJSON(data io.Reader) (*ShareLink, error) {
+	var link ShareLink
+	if err := json.NewDecoder(data).Decode(&link); err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// ShareLinkAccess is an entry of the access log of a share link.
+// swagger:model
+type ShareLinkAccess struct {
+	// The id of the access
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the share link used
+	// required: true
+	ShareLinkID string `json:"shareLinkId"`
+
+	// The id of the shared board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The address the request was made from
+	// required: false
+	RemoteAddr string `json:"remoteAddr"`
+
+	// The user agent of the request
+	// required: false
+	UserAgent string `json:"userAgent"`
+
+	// True if the request was rejected because of a wrong password
+	// required: false
+	Failed bool `json:"failed"`
+
+	// The access time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+// ReadTokenAccess describes the access a valid read token gives to a board.
+type ReadTokenAccess struct {
+	BoardID string
+
+	// ShareLinkID is the id of the share link of the token, empty for the
+	// token of the board sharing.
+	ShareLinkID string
+
+	// ViewID is the only view of the board the token gives access to, empty
+	// for all the views.
+	ViewID string
+
+	// CanComment is true if the token allows to submit guest comments.
+	CanComment bool
+}
+
+type ErrInvalidShareLink struct {
+	msg string
+}
+
+func (e ErrInvalidShareLink) Error() string {
+	return e.msg
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareLinkIsValid(t *testing.T) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+
+	testCases := []struct {
+		name  string
+		link  *ShareLink
+		valid bool
+	}{
+		{"valid link", &ShareLink{BoardID: boardID, Name: "name", Token: "token", Mode: ShareLinkModeViewer}, true},
+		{"expiring link", &ShareLink{BoardID: boardID, Name: "name", Token: "token", Mode: ShareLinkModeViewer, ExpiresAt: 1000}, true},
+		{"commenter link", &ShareLink{BoardID: boardID, Name: "name", Token: "token", Mode: ShareLinkModeCommenter}, true},
+		{"no mode", &ShareLink{BoardID: boardID, Name: "name", Token: "token"}, false},
+		{"invalid mode", &ShareLink{BoardID: boardID, Name: "name", Token: "token", Mode: "editor"}, false},
+		{"no board", &ShareLink{Name: "name", Token: "token", Mode: ShareLinkModeViewer}, false},
+		{"no name", &ShareLink{BoardID: boardID, Token: "token", Mode: ShareLinkModeViewer}, false},
+		{"long name", &ShareLink{BoardID: boardID, Name: strings.Repeat("a", ShareLinkNameMaxRunes+1), Token: "token", Mode: ShareLinkModeViewer}, false},
+		{"no token", &ShareLink{BoardID: boardID, Name: "name"}, false},
+		{"negative expiration", &ShareLink{BoardID: boardID, Name: "name", Token: "token", Mode: ShareLinkModeViewer, ExpiresAt: -1}, false},
+		{"long password", &ShareLink{BoardID: boardID, Name: "name", Token: "token", Mode: ShareLinkModeViewer, Password: strings.Repeat("a", ShareLinkPasswordMaxBytes+1)}, false},
+		{"nil link", nil, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.link.IsValid()
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestShareLinkIsActive(t *testing.T) {
+	require.True(t, (&ShareLink{}).IsActive(1000))
+	require.True(t, (&ShareLink{ExpiresAt: 2000}).IsActive(1000))
+	require.False(t, (&ShareLink{ExpiresAt: 1000}).IsActive(1000))
+	require.False(t, (&ShareLink{RevokedAt: 500}).IsActive(1000))
+}
+
+func TestShareLinkPassword(t *testing.T) {
+	link := &ShareLink{}
+	require.True(t, link.CheckPassword(""))
+
+	require.NoError(t, link.SetPassword("secret"))
+	require.True(t, link.HasPassword)
+	require.Empty(t, link.Password)
+	require.NotEqual(t, "secret", link.PasswordHash)
+	require.True(t, link.CheckPassword("secret"))
+	require.False(t, link.CheckPassword("wrong"))
+	require.False(t, link.CheckPassword(""))
+
+	require.NoError(t, link.SetPassword(""))
+	require.False(t, link.HasPassword)
+	require.Empty(t, link.PasswordHash)
+	require.True(t, link.CheckPassword(""))
+}
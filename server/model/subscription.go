@@ -46,6 +46,10 @@ type Subscription struct {
 	// required: true
 	NotifiedAt int64 `json:"notifiedAt,omitempty"`
 
+	// Filter selects the changes the subscriber is notified of, or all changes if nil
+	// required: false
+	Filter *SubscriptionFilter `json:"filter,omitempty"`
+
 	// CreatedAt is the timestamp this subscription was created in miliseconds since the current epoch
 	// required: true
 	CreateAt int64 `json:"createAt"`
@@ -73,6 +77,11 @@ func (s *Subscription) IsValid() error {
 	if !s.SubscriberType.IsValid() {
 		return ErrInvalidSubscription{"invalid subscriber type"}
 	}
+	if s.Filter != nil {
+		if err := s.Filter.IsValid(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -105,4 +114,97 @@ type Subscriber struct {
 
 	// NotifiedAt is the timestamp this subscriber was last notified
 	NotifiedAt int64 `json:"notified_at"`
+
+	// Filter selects the changes the subscriber is notified of, or all changes if nil
+	Filter *SubscriptionFilter `json:"filter,omitempty"`
+}
+
+// SubscriptionFilter selects the types of changes a subscriber is notified of.
+// swagger:model
+type SubscriptionFilter struct {
+	// Cards notifies of cards being added or deleted
+	// required: false
+	Cards bool `json:"cards"`
+
+	// Title notifies of changes to card titles
+	// required: false
+	Title bool `json:"title"`
+
+	// Properties notifies of changes to every card property
+	// required: false
+	Properties bool `json:"properties"`
+
+	// PropertyIDs notifies of changes to the listed card properties only
+	// required: false
+	PropertyIDs []string `json:"propertyIds,omitempty"`
+
+	// Comments notifies of comments being added or deleted
+	// required: false
+	Comments bool `json:"comments"`
+
+	// Attachments notifies of file attachments being added or deleted
+	// required: false
+	Attachments bool `json:"attachments"`
+
+	// Content notifies of changes to card descriptions
+	// required: false
+	Content bool `json:"content"`
+
+	// AssignedToMe notifies a user subscriber of being assigned to a card
+	// with a person property, whatever the other filters
+	// required: false
+	AssignedToMe bool `json:"assignedToMe"`
+}
+
+func (f *SubscriptionFilter) IsValid() error {
+	if !f.Cards && !f.Title && !f.Properties && len(f.PropertyIDs) == 0 &&
+		!f.Comments && !f.Attachments && !f.Content && !f.AssignedToMe {
+		return ErrInvalidSubscription{"filter excludes every change"}
+	}
+	for _, id := range f.PropertyIDs {
+		if id == "" {
+			return ErrInvalidSubscription{"empty filter property id"}
+		}
+	}
+	return nil
+}
+
+// IncludesProperty returns true if the changes to a card property pass the filter.
+func (f *SubscriptionFilter) IncludesProperty(propertyID string) bool {
+	if f.Properties {
+		return true
+	}
+	for _, id := range f.PropertyIDs {
+		if id == propertyID {
+			return true
+		}
+	}
+	return false
+}
+
+// SubscriptionFilterFromJSON parses a subscription filter; null is a nil filter.
+func SubscriptionFilterFromJSON(data io.Reader) (*SubscriptionFilter, error) {
+	var filter *SubscriptionFilter
+	if err := json.NewDecoder(data).Decode(&filter); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// IsAssignedByProperty returns true if a user is assigned to a card by a person
+// or multi person property of its new version but not by its old version.
+func IsAssignedByProperty(oldCard, newCard *Block, prop PropDef, userID string) bool {
+	schema := PropSchema{prop.ID: prop}
+	isAssigned := func(card *Block) bool {
+		if card == nil {
+			return false
+		}
+		for _, assignee := range CardAssignees(card, schema) {
+			if assignee == userID {
+				return true
+			}
+		}
+		return false
+	}
+	return isAssigned(newCard) && !isAssigned(oldCard)
 }
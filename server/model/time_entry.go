@@ -0,0 +1,244 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+const (
+	// TimeEntryNoteMaxRunes is the maximum length of the note of a time entry.
+	TimeEntryNoteMaxRunes = 1000
+)
+
+// TimeEntry is an amount of time spent by a user on a card. A running entry
+// is a timer started by the user, whose duration is set when it is stopped.
+// swagger:model
+type TimeEntry struct {
+	// The id of the time entry
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the card the time was spent on
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The id of the board of the card
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The id of the user who spent the time
+	// required: true
+	UserID string `json:"userId"`
+
+	// The start time in milliseconds since the current epoch
+	// required: true
+	StartAt int64 `json:"startAt"`
+
+	// The time spent in milliseconds, zero while the timer is running
+	// required: true
+	Duration int64 `json:"duration"`
+
+	// Whether the entry is a running timer
+	// required: false
+	Running bool `json:"running"`
+
+	// A note describing the work done
+	// required: false
+	Note string `json:"note"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+func (te *TimeEntry) IsValid() error {
+	if te == nil {
+		return ErrInvalidTimeEntry{"cannot be nil"}
+	}
+
+	if err := IsValidId(te.CardID); err != nil {
+		return ErrInvalidTimeEntry{"invalid card id: " + err.Error()}
+	}
+
+	if err := IsValidId(te.BoardID); err != nil {
+		return ErrInvalidTimeEntry{"invalid board id: " + err.Error()}
+	}
+
+	if te.UserID == "" {
+		return ErrInvalidTimeEntry{"missing user id"}
+	}
+
+	if te.StartAt <= 0 {
+		return ErrInvalidTimeEntry{"invalid start time"}
+	}
+
+	if te.Duration < 0 {
+		return ErrInvalidTimeEntry{"invalid duration"}
+	}
+
+	if te.Running && te.Duration != 0 {
+		return ErrInvalidTimeEntry{"a running entry cannot have a duration"}
+	}
+
+	if !te.Running && te.Duration == 0 {
+		return ErrInvalidTimeEntry{"missing duration"}
+	}
+
+	if len([]rune(te.Note)) > TimeEntryNoteMaxRunes {
+		return ErrInvalidTimeEntry{"note is too long"}
+	}
+	return nil
+}
+
+// Elapsed returns the time spent of the entry, counting a running timer up to
+// the given time.
+func (te *TimeEntry) Elapsed(now int64) int64 {
+	if !te.Running {
+		return te.Duration
+	}
+	return max(now-te.StartAt, 0)
+}
+
+func TimeEntryFromJSON(data io.Reader) (*TimeEntry, error) {
+	var entry TimeEntry
+	if err := json.NewDecoder(data).Decode(&entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// TimeSummary is the total time spent on a card or a board.
+// swagger:model
+type TimeSummary struct {
+	// The total time spent in milliseconds, including running timers
+	// required: true
+	Duration int64 `json:"duration"`
+
+	// The number of time entries
+	// required: true
+	EntryCount int `json:"entryCount"`
+
+	// The number of running timers
+	// required: true
+	RunningCount int `json:"runningCount"`
+
+	// The time spent per user, longest first
+	// required: true
+	Users []*TimeTotal `json:"users"`
+
+	// The time spent per card, longest first. Only set for boards
+	// required: false
+	Cards []*TimeTotal `json:"cards,omitempty"`
+}
+
+// TimeTotal is the time spent by a user, or on a card.
+// swagger:model
+type TimeTotal struct {
+	// The id of the user or the card
+	// required: true
+	ID string `json:"id"`
+
+	// The time spent in milliseconds
+	// required: true
+	Duration int64 `json:"duration"`
+
+	// The number of time entries
+	// required: true
+	EntryCount int `json:"entryCount"`
+}
+
+// SummarizeTimeEntries adds up the time spent of the entries, counting the
+// running timers up to the given time. The time spent per card is only
+// included if withCards is true.
+func SummarizeTimeEntries(entries []*TimeEntry, now int64, withCards bool) *TimeSummary {
+	summary := &TimeSummary{Users: []*TimeTotal{}}
+
+	users := map[string]*TimeTotal{}
+	cards := map[string]*TimeTotal{}
+	for _, entry := range entries {
+		elapsed := entry.Elapsed(now)
+		summary.Duration += elapsed
+		summary.EntryCount++
+		if entry.Running {
+			summary.RunningCount++
+		}
+		addTimeTotal(users, entry.UserID, elapsed)
+		addTimeTotal(cards, entry.CardID, elapsed)
+	}
+
+	summary.Users = sortedTimeTotals(users)
+	if withCards {
+		summary.Cards = sortedTimeTotals(cards)
+	}
+	return summary
+}
+
+func addTimeTotal(totals map[string]*TimeTotal, id string, duration int64) {
+	total, ok := totals[id]
+	if !ok {
+		total = &TimeTotal{ID: id}
+		totals[id] = total
+	}
+	total.Duration += duration
+	total.EntryCount++
+}
+
+func sortedTimeTotals(totals map[string]*TimeTotal) []*TimeTotal {
+	sorted := make([]*TimeTotal, 0, len(totals))
+	for _, total := range totals {
+		sorted = append(sorted, total)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Duration != sorted[j].Duration {
+			return sorted[i].Duration > sorted[j].Duration
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted
+}
+
+// RemapTimeEntries returns copies of the completed entries of the cards
+// present in cardIDs, which maps old card ids to new ones, with new ids and
+// the given board id. It is used to carry time entries over when the cards of
+// a board are imported with new ids.
+func RemapTimeEntries(entries []*TimeEntry, cardIDs map[string]string, boardID string) []*TimeEntry {
+	remapped := make([]*TimeEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Running {
+			continue
+		}
+		cardID, ok := cardIDs[entry.CardID]
+		if !ok {
+			continue
+		}
+
+		remapped = append(remapped, &TimeEntry{
+			ID:       utils.NewID(utils.IDTypeNone),
+			CardID:   cardID,
+			BoardID:  boardID,
+			UserID:   entry.UserID,
+			StartAt:  entry.StartAt,
+			Duration: entry.Duration,
+			Note:     entry.Note,
+		})
+	}
+	return remapped
+}
+
+type ErrInvalidTimeEntry struct {
+	msg string
+}
+
+func (e ErrInvalidTimeEntry) Error() string {
+	return e.msg
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeEntryIsValid(t *testing.T) {
+	cardID := utils.NewID(utils.IDTypeCard)
+	boardID := utils.NewID(utils.IDTypeBoard)
+
+	newEntry := func() *TimeEntry {
+		return &TimeEntry{
+			CardID:   cardID,
+			BoardID:  boardID,
+			UserID:   "user-id",
+			StartAt:  1000,
+			Duration: 60000,
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		modify func(te *TimeEntry)
+		valid  bool
+	}{
+		{"valid entry", func(te *TimeEntry) {}, true},
+		{"running entry", func(te *TimeEntry) { te.Duration = 0; te.Running = true }, true},
+		{"no card", func(te *TimeEntry) { te.CardID = "" }, false},
+		{"no board", func(te *TimeEntry) { te.BoardID = "" }, false},
+		{"no user", func(te *TimeEntry) { te.UserID = "" }, false},
+		{"no start time", func(te *TimeEntry) { te.StartAt = 0 }, false},
+		{"negative duration", func(te *TimeEntry) { te.Duration = -1 }, false},
+		{"no duration", func(te *TimeEntry) { te.Duration = 0 }, false},
+		{"running entry with a duration", func(te *TimeEntry) { te.Running = true }, false},
+		{"long note", func(te *TimeEntry) { te.Note = strings.Repeat("a", TimeEntryNoteMaxRunes+1) }, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			entry := newEntry()
+			tc.modify(entry)
+			err := entry.IsValid()
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestSummarizeTimeEntries(t *testing.T) {
+	entries := []*TimeEntry{
+		{CardID: "card1", UserID: "user1", StartAt: 1000, Duration: 3000},
+		{CardID: "card1", UserID: "user2", StartAt: 1000, Duration: 1000},
+		{CardID: "card2", UserID: "user1", StartAt: 8000, Running: true},
+	}
+
+	t.Run("card summary", func(t *testing.T) {
+		summary := SummarizeTimeEntries(entries, 10000, false)
+		require.Equal(t, int64(6000), summary.Duration)
+		require.Equal(t, 3, summary.EntryCount)
+		require.Equal(t, 1, summary.RunningCount)
+		require.Equal(t, []*TimeTotal{
+			{ID: "user1", Duration: 5000, EntryCount: 2},
+			{ID: "user2", Duration: 1000, EntryCount: 1},
+		}, summary.Users)
+		require.Nil(t, summary.Cards)
+	})
+
+	t.Run("board summary", func(t *testing.T) {
+		summary := SummarizeTimeEntries(entries, 10000, true)
+		require.Equal(t, []*TimeTotal{
+			{ID: "card1", Duration: 4000, EntryCount: 2},
+			{ID: "card2", Duration: 2000, EntryCount: 1},
+		}, summary.Cards)
+	})
+
+	t.Run("no entries", func(t *testing.T) {
+		summary := SummarizeTimeEntries(nil, 10000, true)
+		require.Zero(t, summary.Duration)
+		require.Empty(t, summary.Users)
+		require.Empty(t, summary.Cards)
+	})
+}
+
+func TestRemapTimeEntries(t *testing.T) {
+	cardID := utils.NewID(utils.IDTypeCard)
+	newCardID := utils.NewID(utils.IDTypeCard)
+	newBoardID := utils.NewID(utils.IDTypeBoard)
+
+	entries := []*TimeEntry{
+		{ID: "entry1", CardID: cardID, UserID: "user1", StartAt: 1000, Duration: 3000, Note: "design"},
+		{ID: "entry2", CardID: cardID, UserID: "user1", StartAt: 5000, Running: true},
+		{ID: "entry3", CardID: utils.NewID(utils.IDTypeCard), UserID: "user1", StartAt: 1000, Duration: 3000},
+	}
+
+	remapped := RemapTimeEntries(entries, map[string]string{cardID: newCardID}, newBoardID)
+	require.Len(t, remapped, 1)
+	require.NotEqual(t, "entry1", remapped[0].ID)
+	require.Equal(t, newCardID, remapped[0].CardID)
+	require.Equal(t, newBoardID, remapped[0].BoardID)
+	require.Equal(t, int64(3000), remapped[0].Duration)
+	require.Equal(t, "design", remapped[0].Note)
+}
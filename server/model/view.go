@@ -0,0 +1,261 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+const (
+	ViewTypeBoard    = "board"
+	ViewTypeTable    = "table"
+	ViewTypeGallery  = "gallery"
+	ViewTypeCalendar = "calendar"
+)
+
+var ErrNotViewBlock = errors.New("not a view block")
+
+// ViewSettings holds the settings of a view block that determine which cards the
+// view shows and how they are ordered and grouped. They are stored in the block Fields.
+type ViewSettings struct {
+	ViewType           string       `json:"viewType"`
+	GroupByID          string       `json:"groupById"`
+	Filter             *FilterGroup `json:"filter"`
+	SortOptions        []SortOption `json:"sortOptions"`
+	VisiblePropertyIDs []string     `json:"visiblePropertyIds"`
+	VisibleOptionIDs   []string     `json:"visibleOptionIds"`
+	HiddenOptionIDs    []string     `json:"hiddenOptionIds"`
+	CardOrder          []string     `json:"cardOrder"`
+}
+
+// ParseViewSettings extracts the view settings from a view block's Fields.
+func ParseViewSettings(block *Block) (*ViewSettings, error) {
+	if block.Type != TypeView {
+		return nil, fmt.Errorf("cannot parse view settings: %w", ErrNotViewBlock)
+	}
+
+	data, err := json.Marshal(block.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &ViewSettings{}
+	if err := json.Unmarshal(data, settings); err != nil {
+		return nil, fmt.Errorf("cannot parse view settings: %w", err)
+	}
+
+	if settings.ViewType == "" {
+		settings.ViewType = ViewTypeBoard
+	}
+	if settings.Filter != nil && settings.Filter.Operation == "" {
+		settings.Filter.Operation = FilterOperationAnd
+	}
+	return settings, nil
+}
+
+// IsGrouped returns true if the view type supports grouping and the view has
+// a group by property.
+func (vs *ViewSettings) IsGrouped() bool {
+	return vs.GroupByID != "" && (vs.ViewType == ViewTypeBoard || vs.ViewType == ViewTypeTable)
+}
+
+// ViewCardGroup is a group of cards sharing the same value for the view's group by property.
+// swagger:model
+type ViewCardGroup struct {
+	// The option id (or user id for person properties) of the group. Empty for the cards without a value
+	// required: true
+	ID string `json:"id"`
+
+	// The display value of the group
+	// required: true
+	Value string `json:"value"`
+
+	// The color of the option
+	// required: false
+	Color string `json:"color"`
+
+	// True if the view hides this group
+	// required: true
+	Hidden bool `json:"hidden"`
+
+	// The cards of the group, in view order
+	// required: true
+	Cards []*Card `json:"cards"`
+}
+
+// ViewCards contains the cards shown by a view, in view order, and grouped if the
+// view has a group by property.
+// swagger:model
+type ViewCards struct {
+	// The id of the view
+	// required: true
+	ViewID string `json:"viewId"`
+
+	// The view type (board, table, gallery, calendar)
+	// required: true
+	ViewType string `json:"viewType"`
+
+	// The id of the property the cards are grouped by
+	// required: false
+	GroupByPropertyID string `json:"groupByPropertyId,omitempty"`
+
+	// The ids of the properties shown by the view
+	// required: true
+	VisiblePropertyIDs []string `json:"visiblePropertyIds"`
+
+	// The cards that match the view filter, in view order
+	// required: true
+	Cards []*Card `json:"cards"`
+
+	// The groups of cards, in view order. Only present if the view is grouped
+	// required: false
+	Groups []*ViewCardGroup `json:"groups,omitempty"`
+}
+
+// SortCardsByOrder sorts cards in place following a manual card order, as used by views
+// without sort options. Cards missing from the order are placed last, by title.
+func SortCardsByOrder(cards []*Card, cardOrder []string) {
+	index := make(map[string]int, len(cardOrder))
+	for i, id := range cardOrder {
+		if _, ok := index[id]; !ok {
+			index[id] = i
+		}
+	}
+
+	sort.SliceStable(cards, func(i, j int) bool {
+		a, b := cards[i], cards[j]
+		aIndex, aOK := index[a.ID]
+		bIndex, bOK := index[b.ID]
+		switch {
+		case aOK && bOK:
+			return aIndex < bIndex
+		case aOK:
+			return true
+		case bOK:
+			return false
+		}
+		if result := compareTitleOrCreated(a, b); result != 0 {
+			return result < 0
+		}
+		return a.ID < b.ID
+	})
+}
+
+// GroupCards groups sorted cards by the view's group by property. Groups of select
+// properties follow the view's option order, with unassigned options after the
+// ordered ones and the empty group first unless the view places it. Groups of person
+// properties follow the order in which users appear in the cards. A resolver can
+// optionally be provided to show usernames instead of user ids.
+func (vs *ViewSettings) GroupCards(cards []*Card, schema PropSchema, resolver PropValueResolver) []*ViewCardGroup {
+	propDef, ok := schema[vs.GroupByID]
+	if !ok {
+		return []*ViewCardGroup{}
+	}
+
+	switch propDef.Type {
+	case PropTypePerson, PropTypeCreatedBy, PropTypeUpdatedBy:
+		return vs.groupCardsByPerson(cards, propDef, resolver)
+	}
+	return vs.groupCardsByOption(cards, propDef)
+}
+
+func (vs *ViewSettings) groupCardsByOption(cards []*Card, propDef PropDef) []*ViewCardGroup {
+	visible := make(map[string]bool, len(vs.VisibleOptionIDs))
+	for _, id := range vs.VisibleOptionIDs {
+		visible[id] = true
+	}
+	hidden := make(map[string]bool, len(vs.HiddenOptionIDs))
+	for _, id := range vs.HiddenOptionIDs {
+		hidden[id] = true
+	}
+
+	options := make([]PropDefOption, 0, len(propDef.Options))
+	for _, option := range propDef.Options {
+		options = append(options, option)
+	}
+	sort.Slice(options, func(i, j int) bool { return options[i].Index < options[j].Index })
+
+	visibleIDs := append([]string{}, vs.VisibleOptionIDs...)
+	for _, option := range options {
+		if !visible[option.ID] && !hidden[option.ID] {
+			visibleIDs = append(visibleIDs, option.ID)
+		}
+	}
+	if !visible[""] && !hidden[""] {
+		visibleIDs = append([]string{""}, visibleIDs...)
+	}
+
+	groups := make([]*ViewCardGroup, 0, len(visibleIDs)+len(vs.HiddenOptionIDs))
+	addGroup := func(optionID string, isHidden bool) {
+		group := &ViewCardGroup{ID: optionID, Hidden: isHidden, Cards: []*Card{}}
+		if optionID == "" {
+			group.Value = "No " + propDef.Name
+		} else {
+			option, ok := propDef.Options[optionID]
+			if !ok {
+				// deleted options can be ignored
+				return
+			}
+			group.Value = option.Value
+			group.Color = option.Color
+		}
+
+		for _, card := range cards {
+			cardOptionID := stringValue(card.Properties[propDef.ID])
+			_, isOption := propDef.Options[cardOptionID]
+			if (optionID == "" && !isOption) || (optionID != "" && cardOptionID == optionID) {
+				group.Cards = append(group.Cards, card)
+			}
+		}
+		groups = append(groups, group)
+	}
+
+	for _, optionID := range visibleIDs {
+		addGroup(optionID, false)
+	}
+	for _, optionID := range vs.HiddenOptionIDs {
+		addGroup(optionID, true)
+	}
+	return groups
+}
+
+func (vs *ViewSettings) groupCardsByPerson(cards []*Card, propDef PropDef, resolver PropValueResolver) []*ViewCardGroup {
+	hidden := make(map[string]bool, len(vs.HiddenOptionIDs))
+	for _, id := range vs.HiddenOptionIDs {
+		hidden[id] = true
+	}
+
+	groups := make([]*ViewCardGroup, 0)
+	groupsByUser := make(map[string]*ViewCardGroup)
+	for _, card := range cards {
+		var userID string
+		switch propDef.Type {
+		case PropTypeCreatedBy:
+			userID = card.CreatedBy
+		case PropTypeUpdatedBy:
+			userID = card.ModifiedBy
+		default:
+			userID = stringValue(card.Properties[propDef.ID])
+		}
+
+		group, ok := groupsByUser[userID]
+		if !ok {
+			group = &ViewCardGroup{ID: userID, Value: userID, Hidden: hidden[userID], Cards: []*Card{}}
+			if userID == "" {
+				group.Value = "No " + propDef.Name
+			} else if resolver != nil {
+				if user, err := resolver.GetUserByID(userID); err == nil && user != nil {
+					group.Value = user.Username
+				}
+			}
+			groupsByUser[userID] = group
+			groups = append(groups, group)
+		}
+		group.Cards = append(group.Cards, card)
+	}
+	return groups
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func groupIDs(groups []*ViewCardGroup) []string {
+	ids := make([]string, 0, len(groups))
+	for _, group := range groups {
+		ids = append(ids, group.ID)
+	}
+	return ids
+}
+
+func TestParseViewSettings(t *testing.T) {
+	t.Run("view block", func(t *testing.T) {
+		block := &Block{
+			Type: TypeView,
+			Fields: map[string]interface{}{
+				"groupById":       queryStatusPropID,
+				"hiddenOptionIds": []interface{}{queryStatusDoneID},
+				"sortOptions":     []interface{}{map[string]interface{}{"propertyId": queryPointsPropID, "reversed": true}},
+				"filter": map[string]interface{}{
+					"filters": []interface{}{
+						map[string]interface{}{"propertyId": queryStatusPropID, "condition": "includes", "values": []interface{}{queryStatusTodoID}},
+					},
+				},
+			},
+		}
+
+		settings, err := ParseViewSettings(block)
+		require.NoError(t, err)
+		require.Equal(t, ViewTypeBoard, settings.ViewType)
+		require.Equal(t, queryStatusPropID, settings.GroupByID)
+		require.Equal(t, []string{queryStatusDoneID}, settings.HiddenOptionIDs)
+		require.Equal(t, []SortOption{{PropertyID: queryPointsPropID, Reversed: true}}, settings.SortOptions)
+		require.Equal(t, FilterOperationAnd, settings.Filter.Operation)
+		require.Len(t, settings.Filter.Filters, 1)
+		require.True(t, settings.IsGrouped())
+	})
+
+	t.Run("gallery views are not grouped", func(t *testing.T) {
+		block := &Block{
+			Type:   TypeView,
+			Fields: map[string]interface{}{"viewType": ViewTypeGallery, "groupById": queryStatusPropID},
+		}
+
+		settings, err := ParseViewSettings(block)
+		require.NoError(t, err)
+		require.False(t, settings.IsGrouped())
+	})
+
+	t.Run("not a view", func(t *testing.T) {
+		settings, err := ParseViewSettings(&Block{Type: TypeCard})
+		require.ErrorIs(t, err, ErrNotViewBlock)
+		require.Nil(t, settings)
+	})
+}
+
+func TestSortCardsByOrder(t *testing.T) {
+	cards := makeQueryCards()
+	SortCardsByOrder(cards, []string{"card3", "card1"})
+
+	ids := make([]string, 0, len(cards))
+	for _, card := range cards {
+		ids = append(ids, card.ID)
+	}
+	require.Equal(t, []string{"card3", "card1", "card2"}, ids)
+}
+
+func TestGroupCards(t *testing.T) {
+	schema := makeQuerySchema()
+
+	t.Run("select property", func(t *testing.T) {
+		cards := makeQueryCards()
+		cards = append(cards, &Card{ID: "card4", Title: "No status", Properties: map[string]any{}})
+
+		vs := &ViewSettings{
+			ViewType:         ViewTypeBoard,
+			GroupByID:        queryStatusPropID,
+			VisibleOptionIDs: []string{queryStatusDoingID, ""},
+			HiddenOptionIDs:  []string{queryStatusDoneID},
+		}
+		groups := vs.GroupCards(cards, schema, nil)
+
+		require.Equal(t, []string{queryStatusDoingID, "", queryStatusTodoID, queryStatusDoneID}, groupIDs(groups))
+		require.Equal(t, "Doing", groups[0].Value)
+		require.Equal(t, "No Status", groups[1].Value)
+		require.Len(t, groups[1].Cards, 1)
+		require.Equal(t, "card4", groups[1].Cards[0].ID)
+		require.False(t, groups[2].Hidden)
+		require.True(t, groups[3].Hidden)
+		require.Len(t, groups[3].Cards, 1)
+	})
+
+	t.Run("empty group first by default", func(t *testing.T) {
+		vs := &ViewSettings{ViewType: ViewTypeBoard, GroupByID: queryStatusPropID}
+		groups := vs.GroupCards(makeQueryCards(), schema, nil)
+
+		require.Equal(t, "", groups[0].ID)
+		require.Len(t, groups, 4)
+	})
+
+	t.Run("person property", func(t *testing.T) {
+		schema := PropSchema{"owner": {ID: "owner", Name: "Owner", Type: PropTypePerson}}
+		cards := []*Card{
+			{ID: "card1", Properties: map[string]any{"owner": "user2"}},
+			{ID: "card2", Properties: map[string]any{}},
+			{ID: "card3", Properties: map[string]any{"owner": "user1"}},
+			{ID: "card4", Properties: map[string]any{"owner": "user2"}},
+		}
+
+		vs := &ViewSettings{ViewType: ViewTypeTable, GroupByID: "owner", HiddenOptionIDs: []string{"user1"}}
+		groups := vs.GroupCards(cards, schema, nil)
+
+		require.Equal(t, []string{"user2", "", "user1"}, groupIDs(groups))
+		require.Len(t, groups[0].Cards, 2)
+		require.Equal(t, "No Owner", groups[1].Value)
+		require.True(t, groups[2].Hidden)
+	})
+
+	t.Run("unknown property", func(t *testing.T) {
+		vs := &ViewSettings{ViewType: ViewTypeBoard, GroupByID: "missing"}
+		require.Empty(t, vs.GroupCards(makeQueryCards(), schema, nil))
+	})
+}
@@ -91,6 +91,22 @@ func (p *Plugin) MessageWillBeUpdated(ctx *plugin.Context, newPost, oldPost *mm_
 	return p.boardsApp.MessageWillBeUpdated(ctx, newPost, oldPost)
 }
 
+func (p *Plugin) MessageHasBeenPosted(ctx *plugin.Context, post *mm_model.Post) {
+	p.boardsApp.MessageHasBeenPosted(ctx, post)
+}
+
+func (p *Plugin) MessageHasBeenUpdated(ctx *plugin.Context, newPost, oldPost *mm_model.Post) {
+	p.boardsApp.MessageHasBeenUpdated(ctx, newPost, oldPost)
+}
+
+func (p *Plugin) MessageHasBeenDeleted(ctx *plugin.Context, post *mm_model.Post) {
+	p.boardsApp.MessageHasBeenDeleted(ctx, post)
+}
+
+func (p *Plugin) ExecuteCommand(ctx *plugin.Context, args *mm_model.CommandArgs) (*mm_model.CommandResponse, *mm_model.AppError) {
+	return p.boardsApp.ExecuteCommand(ctx, args)
+}
+
 func (p *Plugin) RunDataRetention(nowTime, batchSize int64) (int64, error) {
 	return p.boardsApp.RunDataRetention(nowTime, batchSize)
 }
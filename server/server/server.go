@@ -41,8 +41,10 @@ import (
 )
 
 const (
-	cleanupSessionTaskFrequency = 10 * time.Minute
-	updateMetricsTaskFrequency  = 15 * time.Minute
+	cleanupSessionTaskFrequency  = 10 * time.Minute
+	updateMetricsTaskFrequency   = 15 * time.Minute
+	cardRecurrenceTaskFrequency  = 1 * time.Minute
+	webhookDeliveryTaskFrequency = 30 * time.Second
 )
 
 type Server struct {
@@ -57,6 +59,8 @@ type Server struct {
 	metricsServer          *metrics.Service
 	metricsService         *metrics.Metrics
 	metricsUpdaterTask     *scheduler.ScheduledTask
+	cardRecurrenceTask     *scheduler.ScheduledTask
+	webhookDeliveryTask    *scheduler.ScheduledTask
 	auditService           *audit.Audit
 	notificationService    *notify.Service
 	servicesStartStopMutex sync.Mutex
@@ -276,6 +280,10 @@ func (s *Server) Start() error {
 	// metricsUpdater()   Calling this immediately causes integration unit tests to fail.
 	s.metricsUpdaterTask = scheduler.CreateRecurringTask("updateMetrics", metricsUpdater, updateMetricsTaskFrequency)
 
+	s.cardRecurrenceTask = scheduler.CreateRecurringTask("processCardRecurrences", s.app.ProcessCardRecurrences, cardRecurrenceTaskFrequency)
+
+	s.webhookDeliveryTask = scheduler.CreateRecurringTask("processWebhookDeliveries", s.app.ProcessWebhookDeliveries, webhookDeliveryTaskFrequency)
+
 	if s.config.Telemetry {
 		firstRun := utils.GetMillis()
 		s.telemetry.RunTelemetryJob(firstRun)
@@ -317,6 +325,14 @@ func (s *Server) Shutdown() error {
 		s.metricsUpdaterTask.Cancel()
 	}
 
+	if s.cardRecurrenceTask != nil {
+		s.cardRecurrenceTask.Cancel()
+	}
+
+	if s.webhookDeliveryTask != nil {
+		s.webhookDeliveryTask.Cancel()
+	}
+
 	if err := s.telemetry.Shutdown(); err != nil {
 		s.logger.Warn("Error occurred when shutting down telemetry", mlog.Err(err))
 	}
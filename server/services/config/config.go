@@ -48,6 +48,8 @@ type Configuration struct {
 	TelemetryID              string            `json:"telemetryid" mapstructure:"telemetryid"`
 	PrometheusAddress        string            `json:"prometheusaddress" mapstructure:"prometheusaddress"`
 	WebhookUpdate            []string          `json:"webhook_update" mapstructure:"webhook_update"`
+	AllowedInternalHosts     string            `json:"allowed_internal_hosts" mapstructure:"allowed_internal_hosts"`
+	TrustedProxyIPHeader     []string          `json:"trusted_proxy_ip_header" mapstructure:"trusted_proxy_ip_header"`
 	Secret                   string            `json:"secret" mapstructure:"secret"`
 	SessionExpireTime        int64             `json:"session_expire_time" mapstructure:"session_expire_time"`
 	SessionRefreshTime       int64             `json:"session_refresh_time" mapstructure:"session_refresh_time"`
@@ -97,6 +99,8 @@ func ReadConfigFile(configFilePath string) (*Configuration, error) {
 	viper.SetDefault("Telemetry", true)
 	viper.SetDefault("TelemetryID", "")
 	viper.SetDefault("WebhookUpdate", nil)
+	viper.SetDefault("AllowedInternalHosts", "")
+	viper.SetDefault("TrustedProxyIPHeader", nil)
 	viper.SetDefault("SessionExpireTime", 60*60*24*30) // 30 days session lifetime
 	viper.SetDefault("SessionRefreshTime", 60*60*5)    // 5 minutes session refresh
 	viper.SetDefault("LocalOnly", false)
@@ -0,0 +1,17 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifychannelfeed
+
+import "github.com/mattermost/mattermost-plugin-boards/server/model"
+
+type AppAPI interface {
+	GetChannelFeedSettings(boardID string) (*model.ChannelFeedSettings, error)
+	AddChannelFeedItem(item *model.ChannelFeedItem) error
+	GetDueChannelFeedBoardIDs(notifyAt int64) ([]string, error)
+	TakeChannelFeedItems(boardID string) ([]*model.ChannelFeedItem, error)
+
+	GetBoard(boardID string) (*model.Board, error)
+	GetBoardAndCardByID(blockID string) (board *model.Board, card *model.Block, err error)
+	GetUserByID(userID string) (*model.User, error)
+}
@@ -0,0 +1,327 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifychannelfeed
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/scheduler"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	backendName = "notifyChannelFeed"
+
+	feedTaskFrequency = time.Minute
+
+	// maxFeedLines bounds the length of a message, when many cards change
+	// at once.
+	maxFeedLines = 50
+
+	// TODO: localize these when i18n is available.
+	defFeedTemplate          = "Activity in board [%s](%s):"
+	defFeedCreatedTemplate   = "- **%s** created [%s](%s)"
+	defFeedMovedTemplate     = "- **%s** moved [%s](%s) from %s to %s"
+	defFeedCompletedTemplate = "- **%s** completed [%s](%s)"
+	defFeedMoreTemplate      = "- and %d more changes"
+	defFeedNoStatus          = "no status"
+	defFeedUnknownUser       = "unknown user"
+)
+
+type BackendParams struct {
+	AppAPI     AppAPI
+	Delivery   ChannelFeedDelivery
+	ServerRoot string
+	Logger     mlog.LoggerIFace
+}
+
+// Backend posts the activity of boards to the channels they are linked to.
+// The changes to the cards of a board with a channel feed are queued as they
+// happen and posted in a single message once the batching window of the
+// board has elapsed since the first of them.
+type Backend struct {
+	appAPI     AppAPI
+	delivery   ChannelFeedDelivery
+	serverRoot string
+	logger     mlog.LoggerIFace
+
+	mux  sync.Mutex
+	task *scheduler.ScheduledTask
+}
+
+func New(params BackendParams) *Backend {
+	return &Backend{
+		appAPI:     params.AppAPI,
+		delivery:   params.Delivery,
+		serverRoot: params.ServerRoot,
+		logger:     params.Logger,
+	}
+}
+
+func (b *Backend) Start() error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	if b.task == nil {
+		b.task = scheduler.CreateRecurringTask("postChannelFeeds", b.postDueFeeds, feedTaskFrequency)
+	}
+	return nil
+}
+
+func (b *Backend) ShutDown() error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	if b.task != nil {
+		b.task.Cancel()
+		b.task = nil
+	}
+	_ = b.logger.Flush()
+	return nil
+}
+
+func (b *Backend) Name() string {
+	return backendName
+}
+
+// BlockChanged queues the creation and the status changes of the cards of
+// boards with a channel feed.
+func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
+	if evt.Board == nil || evt.Board.ChannelID == "" || evt.Board.IsTemplate {
+		return nil
+	}
+	if evt.BlockChanged == nil || evt.BlockChanged.Type != model.TypeCard || evt.Action == notify.Delete {
+		return nil
+	}
+
+	settings, err := b.appAPI.GetChannelFeedSettings(evt.Board.ID)
+	if model.IsErrNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot fetch channel feed settings: %w", err)
+	}
+
+	item := feedItemForChange(settings, evt)
+	if item == nil {
+		return nil
+	}
+	item.CreateAt = utils.GetMillis()
+	item.NotifyAt = item.CreateAt + (time.Duration(settings.WindowMinutes) * time.Minute).Milliseconds()
+
+	b.logger.Debug("Queue channel feed item",
+		mlog.String("board_id", item.BoardID),
+		mlog.String("card_id", item.CardID),
+		mlog.String("event", item.Event),
+	)
+	return b.appAPI.AddChannelFeedItem(item)
+}
+
+// feedItemForChange returns the change to a card reported by a channel feed,
+// if any. Completed cards are queued as status changes, and told apart when
+// the feed is posted.
+func feedItemForChange(settings *model.ChannelFeedSettings, evt notify.BlockChangeEvent) *model.ChannelFeedItem {
+	item := &model.ChannelFeedItem{
+		BoardID: evt.Board.ID,
+		CardID:  evt.BlockChanged.ID,
+	}
+	if evt.ModifiedBy != nil {
+		item.ModifiedBy = evt.ModifiedBy.UserID
+	}
+
+	switch evt.Action {
+	case notify.Add:
+		if !settings.HasEvent(model.ChannelFeedEventCardCreated) {
+			return nil
+		}
+		item.Event = model.ChannelFeedEventCardCreated
+		return item
+
+	case notify.Update:
+		if !settings.NeedsStatus() || evt.BlockOld == nil {
+			return nil
+		}
+		oldStatus := settings.CardStatus(evt.BlockOld)
+		newStatus := settings.CardStatus(evt.BlockChanged)
+		if oldStatus == newStatus {
+			return nil
+		}
+		item.Event = model.ChannelFeedEventStatusChanged
+		item.OldValue = oldStatus
+		item.NewValue = newStatus
+		return item
+	}
+	return nil
+}
+
+func (b *Backend) postDueFeeds() {
+	b.postFeeds(utils.GetMillis())
+}
+
+// postFeeds posts the activity of every board with changes due at the given
+// time.
+func (b *Backend) postFeeds(now int64) {
+	boardIDs, err := b.appAPI.GetDueChannelFeedBoardIDs(now)
+	if err != nil {
+		b.logger.Error("Cannot fetch due channel feeds", mlog.Err(err))
+		return
+	}
+
+	for _, boardID := range boardIDs {
+		if err := b.postFeed(boardID); err != nil {
+			b.logger.Error("Cannot post channel feed", mlog.String("board_id", boardID), mlog.Err(err))
+		}
+	}
+}
+
+// postFeed posts the changes waiting for the channel feed of a board in a
+// single message.
+func (b *Backend) postFeed(boardID string) error {
+	items, err := b.appAPI.TakeChannelFeedItems(boardID)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	// the feed may have been turned off or the board unlinked since the
+	// changes were queued.
+	settings, err := b.appAPI.GetChannelFeedSettings(boardID)
+	if model.IsErrNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	board, err := b.appAPI.GetBoard(boardID)
+	if model.IsErrNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if board.ChannelID == "" {
+		return nil
+	}
+
+	lines := b.feedLines(board, settings, items)
+	if len(lines) == 0 {
+		return nil
+	}
+	if len(lines) > maxFeedLines {
+		more := len(lines) - maxFeedLines
+		lines = append(lines[:maxFeedLines], fmt.Sprintf(defFeedMoreTemplate, more))
+	}
+
+	boardLink := utils.MakeBoardLink(b.serverRoot, board.TeamID, board.ID)
+	message := fmt.Sprintf(defFeedTemplate, board.Title, boardLink) + "\n" + strings.Join(lines, "\n")
+
+	b.logger.Debug("Post channel feed",
+		mlog.String("board_id", board.ID),
+		mlog.String("channel_id", board.ChannelID),
+		mlog.Int("item_count", len(items)),
+	)
+	return b.delivery.ChannelFeedDeliver(board.ChannelID, message)
+}
+
+// cardActivity is the activity of a card within a batching window: several
+// status changes are reported as one, from the first status to the last.
+type cardActivity struct {
+	cardID     string
+	createdBy  string
+	created    bool
+	movedBy    string
+	moved      bool
+	fromStatus string
+	toStatus   string
+}
+
+// feedLines returns a line for each change to report, in the order the
+// cards changed.
+func (b *Backend) feedLines(board *model.Board, settings *model.ChannelFeedSettings, items []*model.ChannelFeedItem) []string {
+	activities := make([]*cardActivity, 0, len(items))
+	byCard := map[string]*cardActivity{}
+	for _, item := range items {
+		activity, ok := byCard[item.CardID]
+		if !ok {
+			activity = &cardActivity{cardID: item.CardID}
+			byCard[item.CardID] = activity
+			activities = append(activities, activity)
+		}
+
+		switch item.Event {
+		case model.ChannelFeedEventCardCreated:
+			activity.created = true
+			activity.createdBy = item.ModifiedBy
+		case model.ChannelFeedEventStatusChanged:
+			if !activity.moved {
+				activity.fromStatus = item.OldValue
+			}
+			activity.moved = true
+			activity.movedBy = item.ModifiedBy
+			activity.toStatus = item.NewValue
+		}
+	}
+
+	var statusOptions map[string]model.PropDefOption
+	if schema, err := model.ParsePropertySchema(board); err == nil {
+		statusOptions = schema[settings.StatusPropertyID].Options
+	}
+	statusName := func(optionID string) string {
+		if option, ok := statusOptions[optionID]; ok {
+			return option.Value
+		}
+		return defFeedNoStatus
+	}
+
+	usernames := map[string]string{}
+	lines := []string{}
+	for _, activity := range activities {
+		_, card, err := b.appAPI.GetBoardAndCardByID(activity.cardID)
+		if err != nil || card == nil || card.DeleteAt != 0 {
+			continue
+		}
+		link := utils.MakeCardLink(b.serverRoot, board.TeamID, board.ID, card.ID)
+
+		if activity.created && settings.HasEvent(model.ChannelFeedEventCardCreated) {
+			lines = append(lines, fmt.Sprintf(defFeedCreatedTemplate, b.username(activity.createdBy, usernames), card.Title, link))
+		}
+
+		if !activity.moved || activity.fromStatus == activity.toStatus {
+			continue
+		}
+		completed := settings.IsDoneOption(activity.toStatus) && !settings.IsDoneOption(activity.fromStatus)
+		switch {
+		case completed && settings.HasEvent(model.ChannelFeedEventCardCompleted):
+			lines = append(lines, fmt.Sprintf(defFeedCompletedTemplate, b.username(activity.movedBy, usernames), card.Title, link))
+		case settings.HasEvent(model.ChannelFeedEventStatusChanged):
+			lines = append(lines, fmt.Sprintf(defFeedMovedTemplate, b.username(activity.movedBy, usernames), card.Title, link,
+				statusName(activity.fromStatus), statusName(activity.toStatus)))
+		}
+	}
+	return lines
+}
+
+// username returns the username of a user without the @, so that the feed
+// does not mention the authors of the changes.
+func (b *Backend) username(userID string, cache map[string]string) string {
+	if username, ok := cache[userID]; ok {
+		return username
+	}
+
+	username := defFeedUnknownUser
+	if user, err := b.appAPI.GetUserByID(userID); err == nil && user != nil {
+		username = user.Username
+	}
+	cache[userID] = username
+	return username
+}
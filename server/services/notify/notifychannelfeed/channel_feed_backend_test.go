@@ -0,0 +1,184 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifychannelfeed
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+type testAppAPI struct {
+	settings *model.ChannelFeedSettings
+	board    *model.Board
+	cards    map[string]*model.Block
+	users    map[string]*model.User
+	items    []*model.ChannelFeedItem
+}
+
+func (a *testAppAPI) GetChannelFeedSettings(boardID string) (*model.ChannelFeedSettings, error) {
+	if a.settings == nil || a.settings.BoardID != boardID {
+		return nil, model.NewErrNotFound("channel feed settings BoardID=" + boardID)
+	}
+	return a.settings, nil
+}
+
+func (a *testAppAPI) AddChannelFeedItem(item *model.ChannelFeedItem) error {
+	a.items = append(a.items, item)
+	return nil
+}
+
+func (a *testAppAPI) GetDueChannelFeedBoardIDs(notifyAt int64) ([]string, error) {
+	for _, item := range a.items {
+		if item.NotifyAt <= notifyAt {
+			return []string{item.BoardID}, nil
+		}
+	}
+	return []string{}, nil
+}
+
+func (a *testAppAPI) TakeChannelFeedItems(_ string) ([]*model.ChannelFeedItem, error) {
+	items := a.items
+	a.items = nil
+	return items, nil
+}
+
+func (a *testAppAPI) GetBoard(boardID string) (*model.Board, error) {
+	if a.board.ID != boardID {
+		return nil, model.NewErrNotFound("board ID=" + boardID)
+	}
+	return a.board, nil
+}
+
+func (a *testAppAPI) GetBoardAndCardByID(blockID string) (*model.Board, *model.Block, error) {
+	card, ok := a.cards[blockID]
+	if !ok {
+		return nil, nil, model.NewErrNotFound("block ID=" + blockID)
+	}
+	return a.board, card, nil
+}
+
+func (a *testAppAPI) GetUserByID(userID string) (*model.User, error) {
+	user, ok := a.users[userID]
+	if !ok {
+		return nil, model.NewErrNotFound("user ID=" + userID)
+	}
+	return user, nil
+}
+
+type testDelivery struct {
+	channelIDs []string
+	messages   []string
+}
+
+func (d *testDelivery) ChannelFeedDeliver(channelID string, message string) error {
+	d.channelIDs = append(d.channelIDs, channelID)
+	d.messages = append(d.messages, message)
+	return nil
+}
+
+func TestChannelFeed(t *testing.T) {
+	board := &model.Board{
+		ID:        "board",
+		TeamID:    "team",
+		ChannelID: "channel",
+		Title:     "Roadmap",
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status",
+				"name": "Status",
+				"type": model.PropTypeSelect,
+				"options": []interface{}{
+					map[string]interface{}{"id": "todo", "value": "To Do"},
+					map[string]interface{}{"id": "doing", "value": "Doing"},
+					map[string]interface{}{"id": "done", "value": "Done"},
+				},
+			},
+		},
+	}
+	makeCard := func(id, title, status string) *model.Block {
+		return &model.Block{
+			ID:      id,
+			BoardID: board.ID,
+			Type:    model.TypeCard,
+			Title:   title,
+			Fields:  map[string]interface{}{"properties": map[string]interface{}{"status": status}},
+		}
+	}
+	login := makeCard("login", "Fix login", "done")
+	docs := makeCard("docs", "Write docs", "doing")
+
+	newBackend := func(events ...string) (*Backend, *testAppAPI, *testDelivery) {
+		appAPI := &testAppAPI{
+			settings: &model.ChannelFeedSettings{
+				BoardID:          board.ID,
+				Events:           events,
+				StatusPropertyID: "status",
+				DoneOptionIDs:    []string{"done"},
+				WindowMinutes:    10,
+			},
+			board: board,
+			cards: map[string]*model.Block{login.ID: login, docs.ID: docs},
+			users: map[string]*model.User{"alice": {ID: "alice", Username: "alice"}},
+		}
+		delivery := &testDelivery{}
+		logger, _ := mlog.NewLogger()
+		backend := New(BackendParams{AppAPI: appAPI, Delivery: delivery, ServerRoot: "http://localhost/boards", Logger: logger})
+		return backend, appAPI, delivery
+	}
+
+	changes := []notify.BlockChangeEvent{
+		{Action: notify.Add, Board: board, BlockChanged: makeCard("docs", "Write docs", "todo")},
+		{Action: notify.Update, Board: board, BlockChanged: makeCard("login", "Fix login", "doing"), BlockOld: makeCard("login", "Fix login", "todo")},
+		{Action: notify.Update, Board: board, BlockChanged: makeCard("login", "Fix login", "done"), BlockOld: makeCard("login", "Fix login", "doing")},
+		{Action: notify.Update, Board: board, BlockChanged: makeCard("docs", "Write docs", "doing"), BlockOld: makeCard("docs", "Write docs", "todo")},
+	}
+	for i := range changes {
+		changes[i].ModifiedBy = &model.BoardMember{UserID: "alice"}
+	}
+
+	t.Run("batch the changes of a window", func(t *testing.T) {
+		backend, appAPI, delivery := newBackend(model.ChannelFeedEventCardCreated, model.ChannelFeedEventStatusChanged, model.ChannelFeedEventCardCompleted)
+		for _, evt := range changes {
+			require.NoError(t, backend.BlockChanged(evt))
+		}
+		require.Len(t, appAPI.items, 4)
+
+		backend.postFeeds(appAPI.items[0].CreateAt)
+		require.Empty(t, delivery.messages)
+
+		backend.postFeeds(appAPI.items[0].NotifyAt)
+		require.Equal(t, []string{"channel"}, delivery.channelIDs)
+		require.Equal(t, "Activity in board [Roadmap](http://localhost/boards/team/team/board):\n"+
+			"- **alice** created [Write docs](http://localhost/boards/team/team/board/0/docs)\n"+
+			"- **alice** moved [Write docs](http://localhost/boards/team/team/board/0/docs) from To Do to Doing\n"+
+			"- **alice** completed [Fix login](http://localhost/boards/team/team/board/0/login)",
+			delivery.messages[0])
+		require.Empty(t, appAPI.items)
+	})
+
+	t.Run("only completed cards", func(t *testing.T) {
+		backend, appAPI, delivery := newBackend(model.ChannelFeedEventCardCompleted)
+		for _, evt := range changes {
+			require.NoError(t, backend.BlockChanged(evt))
+		}
+		require.Len(t, appAPI.items, 3)
+
+		backend.postFeeds(appAPI.items[0].NotifyAt)
+		require.Len(t, delivery.messages, 1)
+		require.Contains(t, delivery.messages[0], "**alice** completed [Fix login]")
+		require.NotContains(t, delivery.messages[0], "Write docs")
+	})
+
+	t.Run("boards without a channel are ignored", func(t *testing.T) {
+		backend, appAPI, _ := newBackend(model.ChannelFeedEventCardCreated)
+		unlinked := &model.Board{ID: board.ID}
+		require.NoError(t, backend.BlockChanged(notify.BlockChangeEvent{Action: notify.Add, Board: unlinked, BlockChanged: docs}))
+		require.Empty(t, appAPI.items)
+	})
+}
@@ -0,0 +1,10 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifychannelfeed
+
+// ChannelFeedDelivery provides an interface for posting the activity of
+// boards to their linked channels.
+type ChannelFeedDelivery interface {
+	ChannelFeedDeliver(channelID string, message string) error
+}
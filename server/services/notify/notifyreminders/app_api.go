@@ -0,0 +1,17 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyreminders
+
+import "github.com/mattermost/mattermost-plugin-boards/server/model"
+
+type AppAPI interface {
+	GetAllCardReminderSettings() ([]*model.CardReminderSettings, error)
+	CreateCardReminder(reminder *model.CardReminder) (bool, error)
+	DeleteCardRemindersDueBefore(dueAt int64) error
+
+	GetBoard(boardID string) (*model.Board, error)
+	GetBlocksWithType(boardID, blockType string) ([]*model.Block, error)
+	GetMembersForBoard(boardID string) ([]*model.BoardMember, error)
+	GetUserTimezone(userID string) (string, error)
+}
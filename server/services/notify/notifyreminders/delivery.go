@@ -0,0 +1,14 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyreminders
+
+import (
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+)
+
+// ReminderDelivery provides an interface for sending due date reminders to
+// users.
+type ReminderDelivery interface {
+	ReminderDeliver(userID string, kind string, dueDate string, card *model.Block, board *model.Board) error
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyreminders
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/scheduler"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	backendName = "notifyReminders"
+
+	reminderTaskFrequency = 5 * time.Minute
+)
+
+type BackendParams struct {
+	AppAPI   AppAPI
+	Delivery ReminderDelivery
+	Logger   mlog.LoggerIFace
+}
+
+// Backend sends direct messages to the users assigned to cards before the due
+// date of the cards and when they become overdue. The cards of the boards
+// with reminder settings are scanned periodically; every reminder is recorded
+// before being sent, so that it is sent once across restarts and nodes.
+type Backend struct {
+	appAPI   AppAPI
+	delivery ReminderDelivery
+	logger   mlog.LoggerIFace
+
+	mux  sync.Mutex
+	task *scheduler.ScheduledTask
+}
+
+func New(params BackendParams) *Backend {
+	return &Backend{
+		appAPI:   params.AppAPI,
+		delivery: params.Delivery,
+		logger:   params.Logger,
+	}
+}
+
+func (b *Backend) Start() error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	if b.task == nil {
+		b.task = scheduler.CreateRecurringTask("processCardReminders", b.processReminders, reminderTaskFrequency)
+	}
+	return nil
+}
+
+func (b *Backend) ShutDown() error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	if b.task != nil {
+		b.task.Cancel()
+		b.task = nil
+	}
+	_ = b.logger.Flush()
+	return nil
+}
+
+func (b *Backend) Name() string {
+	return backendName
+}
+
+// BlockChanged does nothing, the reminders are sent on schedule.
+func (b *Backend) BlockChanged(_ notify.BlockChangeEvent) error {
+	return nil
+}
+
+func (b *Backend) processReminders() {
+	b.sendReminders(utils.GetMillis())
+}
+
+// sendReminders sends the reminders due at the given time for every board
+// with reminder settings.
+func (b *Backend) sendReminders(now int64) {
+	allSettings, err := b.appAPI.GetAllCardReminderSettings()
+	if err != nil {
+		b.logger.Error("Cannot fetch card reminder settings", mlog.Err(err))
+		return
+	}
+
+	timezones := map[string]*time.Location{}
+	for _, settings := range allSettings {
+		if err := b.sendBoardReminders(settings, now, timezones); err != nil {
+			b.logger.Error("Cannot send card reminders",
+				mlog.String("board_id", settings.BoardID),
+				mlog.Err(err),
+			)
+		}
+	}
+
+	// older reminders cannot be sent again
+	if err := b.appAPI.DeleteCardRemindersDueBefore(now - model.CardReminderOverdueWindow.Milliseconds()); err != nil {
+		b.logger.Error("Cannot delete old card reminders", mlog.Err(err))
+	}
+}
+
+func (b *Backend) sendBoardReminders(settings *model.CardReminderSettings, now int64, timezones map[string]*time.Location) error {
+	board, err := b.appAPI.GetBoard(settings.BoardID)
+	if model.IsErrNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot fetch board: %w", err)
+	}
+	if board.IsTemplate {
+		return nil
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return err
+	}
+	if err = settings.IsValidForSchema(schema); err != nil {
+		b.logger.Debug("Skipping card reminders of board", mlog.String("board_id", board.ID), mlog.Err(err))
+		return nil
+	}
+
+	members, err := b.appAPI.GetMembersForBoard(board.ID)
+	if err != nil {
+		return fmt.Errorf("cannot fetch board members: %w", err)
+	}
+	isMember := map[string]bool{}
+	for _, member := range members {
+		isMember[member.UserID] = true
+	}
+
+	cards, err := b.appAPI.GetBlocksWithType(board.ID, model.TypeCard)
+	if err != nil {
+		return fmt.Errorf("cannot fetch cards: %w", err)
+	}
+
+	for _, card := range cards {
+		dueDate, ok := model.GetCardDueDate(card, settings.DueDatePropertyID)
+		if !ok {
+			continue
+		}
+
+		for _, userID := range model.CardAssignees(card, schema) {
+			if !isMember[userID] {
+				continue
+			}
+
+			loc, ok := timezones[userID]
+			if !ok {
+				loc = b.userLocation(userID)
+				timezones[userID] = loc
+			}
+
+			dueAt := dueDate.DueAt(loc)
+			kind, ok := settings.CardReminderKindAt(dueAt, now)
+			if !ok {
+				continue
+			}
+
+			reminder := &model.CardReminder{
+				CardID:  card.ID,
+				BoardID: board.ID,
+				UserID:  userID,
+				Kind:    kind,
+				DueAt:   dueAt,
+				SentAt:  now,
+			}
+			created, err := b.appAPI.CreateCardReminder(reminder)
+			if err != nil {
+				return fmt.Errorf("cannot record card reminder: %w", err)
+			}
+			if !created || b.delivery == nil {
+				continue
+			}
+
+			if err := b.delivery.ReminderDeliver(userID, kind, dueDate.Format(loc), card, board); err != nil {
+				b.logger.Warn("Cannot deliver card reminder",
+					mlog.String("card_id", card.ID),
+					mlog.String("user_id", userID),
+					mlog.Err(err),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// userLocation returns the time zone of a user, or UTC if it is unknown.
+func (b *Backend) userLocation(userID string) *time.Location {
+	timezone, err := b.appAPI.GetUserTimezone(userID)
+	if err != nil {
+		b.logger.Debug("Cannot fetch user timezone", mlog.String("user_id", userID), mlog.Err(err))
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		b.logger.Debug("Invalid user timezone", mlog.String("user_id", userID), mlog.String("timezone", timezone))
+		return time.UTC
+	}
+	return loc
+}
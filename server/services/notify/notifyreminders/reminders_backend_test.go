@@ -0,0 +1,142 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyreminders
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+type testAppAPI struct {
+	settings  []*model.CardReminderSettings
+	board     *model.Board
+	cards     []*model.Block
+	members   []*model.BoardMember
+	timezones map[string]string
+	reminders map[string]bool
+}
+
+func (a *testAppAPI) GetAllCardReminderSettings() ([]*model.CardReminderSettings, error) {
+	return a.settings, nil
+}
+
+func (a *testAppAPI) CreateCardReminder(reminder *model.CardReminder) (bool, error) {
+	key := fmt.Sprintf("%s/%s/%s/%d", reminder.CardID, reminder.UserID, reminder.Kind, reminder.DueAt)
+	if a.reminders[key] {
+		return false, nil
+	}
+	a.reminders[key] = true
+	return true, nil
+}
+
+func (a *testAppAPI) DeleteCardRemindersDueBefore(_ int64) error {
+	return nil
+}
+
+func (a *testAppAPI) GetBoard(boardID string) (*model.Board, error) {
+	if a.board.ID != boardID {
+		return nil, model.NewErrNotFound("board ID=" + boardID)
+	}
+	return a.board, nil
+}
+
+func (a *testAppAPI) GetBlocksWithType(_, _ string) ([]*model.Block, error) {
+	return a.cards, nil
+}
+
+func (a *testAppAPI) GetMembersForBoard(_ string) ([]*model.BoardMember, error) {
+	return a.members, nil
+}
+
+func (a *testAppAPI) GetUserTimezone(userID string) (string, error) {
+	return a.timezones[userID], nil
+}
+
+type testDelivery struct {
+	messages []string
+}
+
+func (d *testDelivery) ReminderDeliver(userID string, kind string, dueDate string, card *model.Block, _ *model.Board) error {
+	d.messages = append(d.messages, fmt.Sprintf("%s %s %s %s", userID, kind, card.ID, dueDate))
+	return nil
+}
+
+func TestSendReminders(t *testing.T) {
+	board := &model.Board{
+		ID:     "board",
+		TeamID: "team",
+		CardProperties: []map[string]interface{}{
+			{"id": "due", "name": "Due", "type": model.PropTypeDate},
+			{"id": "owner", "name": "Owner", "type": model.PropTypePerson},
+			{"id": "reviewers", "name": "Reviewers", "type": model.PropTypeMultiPerson},
+		},
+	}
+
+	// March 10, 2024 at noon UTC, a date without a time
+	due := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC).UnixMilli()
+	card := &model.Block{
+		ID:   "card",
+		Type: model.TypeCard,
+		Fields: map[string]interface{}{
+			"properties": map[string]interface{}{
+				"due":       fmt.Sprintf(`{"from":%d}`, due),
+				"owner":     "alice",
+				"reviewers": []interface{}{"bob", "carol"},
+			},
+		},
+	}
+
+	appAPI := &testAppAPI{
+		settings: []*model.CardReminderSettings{{BoardID: board.ID, DueDatePropertyID: "due", HoursBefore: 2}},
+		board:    board,
+		cards:    []*model.Block{card},
+		// carol is not a member of the board anymore
+		members:   []*model.BoardMember{{UserID: "alice"}, {UserID: "bob"}},
+		timezones: map[string]string{"alice": "America/New_York", "bob": "Europe/Paris"},
+		reminders: map[string]bool{},
+	}
+	delivery := &testDelivery{}
+	logger, _ := mlog.NewLogger()
+	backend := New(BackendParams{AppAPI: appAPI, Delivery: delivery, Logger: logger})
+
+	// the end of March 10 in Paris, 5 hours before the end of the day in New York
+	parisEndOfDay := time.Date(2024, time.March, 10, 23, 0, 0, 0, time.UTC).UnixMilli()
+
+	t.Run("nothing due yet", func(t *testing.T) {
+		backend.sendReminders(parisEndOfDay - 3*time.Hour.Milliseconds())
+		require.Empty(t, delivery.messages)
+	})
+
+	t.Run("upcoming in the user timezone", func(t *testing.T) {
+		backend.sendReminders(parisEndOfDay - time.Hour.Milliseconds())
+		require.Equal(t, []string{"bob upcoming card March 10, 2024"}, delivery.messages)
+	})
+
+	t.Run("no duplicate reminders", func(t *testing.T) {
+		backend.sendReminders(parisEndOfDay - time.Hour.Milliseconds())
+		require.Len(t, delivery.messages, 1)
+	})
+
+	t.Run("overdue", func(t *testing.T) {
+		delivery.messages = nil
+		backend.sendReminders(parisEndOfDay + 3*time.Hour.Milliseconds())
+		require.Equal(t, []string{
+			"alice upcoming card March 10, 2024",
+			"bob overdue card March 10, 2024",
+		}, delivery.messages)
+	})
+
+	t.Run("board without the due date property", func(t *testing.T) {
+		delivery.messages = nil
+		appAPI.settings[0].DueDatePropertyID = "owner"
+		backend.sendReminders(parisEndOfDay + 6*time.Hour.Milliseconds())
+		require.Empty(t, delivery.messages)
+	})
+}
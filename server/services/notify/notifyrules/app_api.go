@@ -0,0 +1,12 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyrules
+
+import "github.com/mattermost/mattermost-plugin-boards/server/model"
+
+type AppAPI interface {
+	GetBoardRulesForBoard(boardID string) ([]*model.BoardRule, error)
+	MoveCardToBoard(cardID string, boardID string, userID string) error
+	InsertBlockAndNotify(block *model.Block, modifiedByID string, disableNotify bool) error
+}
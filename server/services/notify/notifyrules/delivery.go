@@ -0,0 +1,13 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyrules
+
+import (
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
+)
+
+// RuleDelivery provides an interface for posting the messages of board rules.
+type RuleDelivery interface {
+	RuleDeliver(channelID string, message string, evt notify.BlockChangeEvent) error
+}
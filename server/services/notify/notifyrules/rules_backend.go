@@ -0,0 +1,166 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyrules
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/wiggin77/merror"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	backendName = "notifyRules"
+)
+
+type BackendParams struct {
+	AppAPI   AppAPI
+	Delivery RuleDelivery
+	Logger   mlog.LoggerIFace
+}
+
+// Backend executes the actions of the board automation rules fired by card
+// changes. The property actions of the rules are saved by the app along with
+// the triggering change; this backend takes care of the remaining actions,
+// which are executed without notifications so they never fire other rules.
+// As the property actions, they are executed for the changes saved with
+// notifications disabled too.
+type Backend struct {
+	appAPI   AppAPI
+	delivery RuleDelivery
+	logger   mlog.LoggerIFace
+}
+
+func New(params BackendParams) *Backend {
+	return &Backend{
+		appAPI:   params.AppAPI,
+		delivery: params.Delivery,
+		logger:   params.Logger,
+	}
+}
+
+func (b *Backend) Start() error {
+	return nil
+}
+
+func (b *Backend) ShutDown() error {
+	_ = b.logger.Flush()
+	return nil
+}
+
+func (b *Backend) Name() string {
+	return backendName
+}
+
+func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
+	if evt.Board == nil || evt.Card == nil || evt.BlockChanged == nil {
+		return nil
+	}
+
+	// the rules only fire on card updates: the property actions are applied
+	// by the app to card patches, not to inserted cards
+	if evt.Action != notify.Update || evt.BlockChanged.Type != model.TypeCard {
+		return nil
+	}
+
+	rules, err := b.appAPI.GetBoardRulesForBoard(evt.Board.ID)
+	if err != nil {
+		return fmt.Errorf("cannot fetch rules for board %s: %w", evt.Board.ID, err)
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	_, fired := model.EvaluateBoardRules(evt.Board, rules, cardProperties(evt.BlockOld), cardProperties(evt.BlockChanged))
+
+	merr := merror.New()
+
+	// the card is moved at most once, after every other action has been
+	// executed on its original board
+	var move *model.RuleAction
+	var moveRule *model.BoardRule
+	for _, rule := range fired {
+		for i, action := range rule.Actions {
+			if !action.IsSideEffect() {
+				continue
+			}
+			if action.Type == model.RuleActionMoveCard {
+				if move == nil {
+					move, moveRule = &rule.Actions[i], rule
+				}
+				continue
+			}
+			if err := b.executeAction(rule, action, evt); err != nil {
+				merr.Append(err)
+			}
+		}
+	}
+	if move != nil {
+		if err := b.executeAction(moveRule, *move, evt); err != nil {
+			merr.Append(err)
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+// BlockChangedSilently executes the rules fired by the card changes saved with
+// notifications disabled.
+func (b *Backend) BlockChangedSilently(evt notify.BlockChangeEvent) error {
+	return b.BlockChanged(evt)
+}
+
+func (b *Backend) executeAction(rule *model.BoardRule, action model.RuleAction, evt notify.BlockChangeEvent) error {
+	b.logger.Debug("Executing board rule action",
+		mlog.String("rule_id", rule.ID),
+		mlog.String("action", action.Type),
+		mlog.String("card_id", evt.Card.ID),
+	)
+
+	var err error
+	switch action.Type {
+	case model.RuleActionMoveCard:
+		if action.BoardID != evt.Board.ID {
+			err = b.appAPI.MoveCardToBoard(evt.Card.ID, action.BoardID, rule.ModifiedBy)
+		}
+	case model.RuleActionPostToChannel:
+		if b.delivery != nil {
+			err = b.delivery.RuleDeliver(action.ChannelID, action.Message, evt)
+		}
+	case model.RuleActionAddComment:
+		now := utils.GetMillis()
+		comment := &model.Block{
+			ID:         utils.NewID(utils.IDTypeBlock),
+			ParentID:   evt.Card.ID,
+			BoardID:    evt.Card.BoardID,
+			CreatedBy:  rule.ModifiedBy,
+			ModifiedBy: rule.ModifiedBy,
+			Type:       model.TypeComment,
+			Title:      action.Message,
+			Fields:     map[string]interface{}{},
+			CreateAt:   now,
+			UpdateAt:   now,
+		}
+		err = b.appAPI.InsertBlockAndNotify(comment, rule.ModifiedBy, true)
+	}
+
+	if err != nil {
+		return fmt.Errorf("cannot execute action %s of rule %s: %w", action.Type, rule.ID, err)
+	}
+	return nil
+}
+
+func cardProperties(card *model.Block) map[string]interface{} {
+	if card == nil {
+		return map[string]interface{}{}
+	}
+	properties, ok := card.Fields["properties"].(map[string]interface{})
+	if !ok {
+		return map[string]interface{}{}
+	}
+	return properties
+}
@@ -7,19 +7,31 @@ import (
 	"time"
 
 	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
 )
 
 type AppAPI interface {
 	GetBlockHistory(blockID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error)
 	GetBlockHistoryNewestChildren(parentID string, opts model.QueryBlockHistoryChildOptions) ([]*model.Block, bool, error)
+	GetBlockHistoryDescendants(boardID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error)
+	GetBoard(boardID string) (*model.Board, error)
 	GetBoardAndCardByID(blockID string) (board *model.Board, card *model.Block, err error)
 
 	GetUserByID(userID string) (*model.User, error)
+	GetUserPreferences(userID string) (mm_model.Preferences, error)
+	GetUserTimezone(userID string) (string, error)
+	GetChannel(teamID, channelID string) (*mm_model.Channel, error)
 
 	CreateSubscription(sub *model.Subscription) (*model.Subscription, error)
+	GetSubscription(blockID string, subscriberID string) (*model.Subscription, error)
 	GetSubscribersForBlock(blockID string) ([]*model.Subscriber, error)
 	UpdateSubscribersNotifiedAt(blockID string, notifyAt int64) error
 
 	UpsertNotificationHint(hint *model.NotificationHint, notificationFreq time.Duration) (*model.NotificationHint, error)
 	GetNextNotificationHint(remove bool) (*model.NotificationHint, error)
+
+	AddNotificationDigestItem(item *model.NotificationDigestItem) error
+	GetDueNotificationDigestUserIDs(notifyAt int64) ([]string, error)
+	TakeNotificationDigestItems(userID string) ([]*model.NotificationDigestItem, error)
 }
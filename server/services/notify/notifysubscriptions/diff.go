@@ -56,6 +56,11 @@ type diffGenerator struct {
 }
 
 func (dg *diffGenerator) generateDiffs() ([]*Diff, error) {
+	// boards are not blocks, so board hints are diffed card by card.
+	if dg.hint.BlockType == model.TypeBoard {
+		return dg.generateDiffsForBoard()
+	}
+
 	// use block_history to fetch blocks in case they were deleted and no longer exist in blocks table.
 	opts := model.QueryBlockHistoryOptions{
 		Limit:      1,
@@ -82,9 +87,7 @@ func (dg *diffGenerator) generateDiffs() ([]*Diff, error) {
 
 	switch block.Type {
 	case model.TypeBoard:
-		dg.logger.Warn("generateDiffs for board skipped", mlog.String("block_id", block.ID))
-		// TODO: Fix this
-		// return dg.generateDiffsForBoard(block, schema)
+		dg.logger.Warn("generateDiffs for board block skipped", mlog.String("block_id", block.ID))
 		return nil, nil
 	case model.TypeCard:
 		diff, err := dg.generateDiffsForCard(block, schema)
@@ -101,45 +104,70 @@ func (dg *diffGenerator) generateDiffs() ([]*Diff, error) {
 	}
 }
 
-// TODO: fix this
-/*
-func (dg *diffGenerator) generateDiffsForBoard(board *model.Board, schema model.PropSchema) ([]*Diff, error) {
-	opts := model.QuerySubtreeOptions{
-		AfterUpdateAt: dg.lastNotifyAt,
+// generateDiffsForBoard generates a diff for each card of the board that changed, or
+// whose content changed, since the last notify.
+func (dg *diffGenerator) generateDiffsForBoard() ([]*Diff, error) {
+	if dg.board == nil {
+		return nil, fmt.Errorf("cannot generate diff for board %s; must have a valid board", dg.hint.BlockID)
 	}
 
-	find all child blocks of the board that updated since last notify.
-	blocks, err := dg.store.GetSubTree2(board.ID, board.ID, opts)
+	schema, err := model.ParsePropertySchema(dg.board)
 	if err != nil {
-		return nil, fmt.Errorf("could not get subtree for board %s: %w", board.ID, err)
+		return nil, fmt.Errorf("could not parse property schema for board %s: %w", dg.board.ID, err)
 	}
 
-	var diffs []*Diff
-
-	generate diff for board title change or description
-	boardDiff, err := dg.generateDiffForBlock(board, schema)
+	// find all blocks of the board that updated since last notify.
+	opts := model.QueryBlockHistoryOptions{
+		AfterUpdateAt: dg.lastNotifyAt,
+	}
+	blocks, err := dg.store.GetBlockHistoryDescendants(dg.board.ID, opts)
 	if err != nil {
-		return nil, fmt.Errorf("could not generate diff for board %s: %w", board.ID, err)
+		return nil, fmt.Errorf("could not get block history for board %s: %w", dg.board.ID, err)
 	}
 
-	if boardDiff != nil {
-		TODO: phase 2 feature (generate schema diffs and add to board diff) goes here.
-		diffs = append(diffs, boardDiff)
+	var cardIDs []string
+	seen := make(map[string]bool)
+	for _, block := range blocks {
+		cardID := block.ParentID
+		if block.Type == model.TypeCard {
+			cardID = block.ID
+		}
+		if cardID == "" || cardID == dg.board.ID || seen[cardID] {
+			continue
+		}
+		seen[cardID] = true
+		cardIDs = append(cardIDs, cardID)
 	}
 
-	for _, b := range blocks {
-		block := b
-		if block.Type == model.TypeCard {
-			cardDiffs, err := dg.generateDiffsForCard(&block, schema)
-			if err != nil {
-				return nil, err
-			}
-			diffs = append(diffs, cardDiffs)
+	var diffs []*Diff
+	for _, cardID := range cardIDs {
+		history, err := dg.store.GetBlockHistory(cardID, model.QueryBlockHistoryOptions{Limit: 1, Descending: true})
+		if err != nil {
+			return nil, fmt.Errorf("could not get block history for card %s: %w", cardID, err)
+		}
+		if len(history) == 0 || history[0].Type != model.TypeCard {
+			continue
+		}
+		card := history[0]
+
+		cardGenerator := *dg
+		cardGenerator.card = card
+		diff, err := cardGenerator.generateDiffsForCard(card, schema)
+		if err != nil {
+			return nil, err
+		}
+		if diff != nil {
+			diffs = append(diffs, diff)
 		}
 	}
+
+	dg.logger.Debug("generateDiffsForBoard",
+		mlog.String("board_id", dg.board.ID),
+		mlog.Int("changed_blocks", len(blocks)),
+		mlog.Int("card_diffs", len(diffs)),
+	)
 	return diffs, nil
 }
-*/
 
 func (dg *diffGenerator) generateDiffsForCard(card *model.Block, schema model.PropSchema) (*Diff, error) {
 	// generate diff for card title change and properties.
@@ -37,6 +37,11 @@ type DiffConvOpts struct {
 	MakeCardLink  func(block *model.Block, board *model.Board, card *model.Block) string
 	MakeBoardLink func(board *model.Board) string
 	Logger        mlog.LoggerIFace
+
+	// Filter selects the changes converted for a subscriber, or all changes if nil.
+	Filter *model.SubscriptionFilter
+	// SubscriberID is the subscriber the changes are converted for, if filtered.
+	SubscriberID string
 }
 
 // getTemplate returns a new or cached named template based on the language specified.
@@ -142,8 +147,13 @@ func cardDiff2SlackAttachment(cardDiff *Diff, opts DiffConvOpts) (*mm_model.Slac
 	attachment := &mm_model.SlackAttachment{}
 	buf := &bytes.Buffer{}
 
+	filter := newDiffFilter(cardDiff, opts)
+
 	// card added
 	if cardDiff.NewBlock != nil && cardDiff.OldBlock == nil {
+		if !filter.includeCards() {
+			return nil, nil
+		}
 		if err := execTemplate(buf, "AddCardNotify", opts, defAddCardNotify, cardDiff); err != nil {
 			return nil, err
 		}
@@ -154,6 +164,9 @@ func cardDiff2SlackAttachment(cardDiff *Diff, opts DiffConvOpts) (*mm_model.Slac
 
 	// card deleted
 	if (cardDiff.NewBlock == nil || cardDiff.NewBlock.DeleteAt != 0) && cardDiff.OldBlock != nil {
+		if !filter.includeCards() {
+			return nil, nil
+		}
 		buf.Reset()
 		if err := execTemplate(buf, "DeleteCardNotify", opts, defDeleteCardNotify, cardDiff); err != nil {
 			return nil, err
@@ -181,19 +194,27 @@ func cardDiff2SlackAttachment(cardDiff *Diff, opts DiffConvOpts) (*mm_model.Slac
 	attachment.Fallback = attachment.Pretext
 
 	// title changes
-	attachment.Fields = appendTitleChanges(attachment.Fields, cardDiff)
+	if filter.includeTitle() {
+		attachment.Fields = appendTitleChanges(attachment.Fields, cardDiff)
+	}
 
 	// property changes
-	attachment.Fields = appendPropertyChanges(attachment.Fields, cardDiff)
+	attachment.Fields = appendPropertyChanges(attachment.Fields, cardDiff, filter)
 
 	// comment add/delete
-	attachment.Fields = appendCommentChanges(attachment.Fields, cardDiff)
+	if filter.includeComments() {
+		attachment.Fields = appendCommentChanges(attachment.Fields, cardDiff)
+	}
 
 	// File Attachment add/delete
-	attachment.Fields = appendAttachmentChanges(attachment.Fields, cardDiff)
+	if filter.includeAttachments() {
+		attachment.Fields = appendAttachmentChanges(attachment.Fields, cardDiff)
+	}
 
 	// content/description changes
-	attachment.Fields = appendContentChanges(attachment.Fields, cardDiff, opts.Logger)
+	if filter.includeContent() {
+		attachment.Fields = appendContentChanges(attachment.Fields, cardDiff, opts.Logger)
+	}
 
 	if len(attachment.Fields) == 0 {
 		return nil, nil
@@ -212,7 +233,7 @@ func appendTitleChanges(fields []*mm_model.SlackAttachmentField, cardDiff *Diff)
 	return fields
 }
 
-func appendPropertyChanges(fields []*mm_model.SlackAttachmentField, cardDiff *Diff) []*mm_model.SlackAttachmentField {
+func appendPropertyChanges(fields []*mm_model.SlackAttachmentField, cardDiff *Diff, filter *diffFilter) []*mm_model.SlackAttachmentField {
 	if len(cardDiff.PropDiffs) == 0 {
 		return fields
 	}
@@ -221,6 +242,9 @@ func appendPropertyChanges(fields []*mm_model.SlackAttachmentField, cardDiff *Di
 		if propDiff.NewValue == propDiff.OldValue {
 			continue
 		}
+		if !filter.includeProperty(propDiff.ID) {
+			continue
+		}
 
 		var val string
 		if propDiff.OldValue != "" {
@@ -0,0 +1,70 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifysubscriptions
+
+import (
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// diffFilter applies the subscription filter of a subscriber to the changes of
+// a card. A nil filter includes every change.
+type diffFilter struct {
+	filter *model.SubscriptionFilter
+
+	// assignedBy holds the ids of the person properties assigning the
+	// subscriber to the card.
+	assignedBy map[string]bool
+}
+
+func newDiffFilter(cardDiff *Diff, opts DiffConvOpts) *diffFilter {
+	df := &diffFilter{filter: opts.Filter}
+	if df.filter == nil || !df.filter.AssignedToMe || opts.SubscriberID == "" {
+		return df
+	}
+
+	schema, err := model.ParsePropertySchema(cardDiff.Board)
+	if err != nil {
+		opts.Logger.Warn("Cannot parse property schema for subscription filter",
+			mlog.String("board_id", cardDiff.Board.ID),
+			mlog.Err(err),
+		)
+		return df
+	}
+
+	df.assignedBy = map[string]bool{}
+	for _, prop := range schema {
+		if model.IsAssignedByProperty(cardDiff.OldBlock, cardDiff.NewBlock, prop, opts.SubscriberID) {
+			df.assignedBy[prop.ID] = true
+		}
+	}
+	return df
+}
+
+// includeCards returns true for cards added or deleted, or added with the
+// subscriber assigned.
+func (df *diffFilter) includeCards() bool {
+	return df.filter == nil || df.filter.Cards || len(df.assignedBy) > 0
+}
+
+func (df *diffFilter) includeTitle() bool {
+	return df.filter == nil || df.filter.Title
+}
+
+func (df *diffFilter) includeProperty(propertyID string) bool {
+	return df.filter == nil || df.filter.IncludesProperty(propertyID) || df.assignedBy[propertyID]
+}
+
+func (df *diffFilter) includeComments() bool {
+	return df.filter == nil || df.filter.Comments
+}
+
+func (df *diffFilter) includeAttachments() bool {
+	return df.filter == nil || df.filter.Attachments
+}
+
+func (df *diffFilter) includeContent() bool {
+	return df.filter == nil || df.filter.Content
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifysubscriptions
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/stretchr/testify/require"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func TestDiffs2SlackAttachmentsFilter(t *testing.T) {
+	logger, _ := mlog.NewLogger()
+
+	board := &model.Board{
+		ID:    "board",
+		Title: "Board",
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": "text"},
+			{"id": "owner", "name": "Owner", "type": model.PropTypePerson},
+		},
+	}
+	oldCard := &model.Block{ID: "card", Type: model.TypeCard, Title: "Old title"}
+	newCard := &model.Block{
+		ID:    "card",
+		Type:  model.TypeCard,
+		Title: "New title",
+		Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"owner": "alice"},
+		},
+	}
+
+	diff := &Diff{
+		Board:     board,
+		Card:      newCard,
+		Authors:   StringMap{"bob": "bob"},
+		BlockType: model.TypeCard,
+		OldBlock:  oldCard,
+		NewBlock:  newCard,
+		PropDiffs: []PropDiff{
+			{ID: "status", Index: 0, Name: "Status", OldValue: "todo", NewValue: "done"},
+			{ID: "owner", Index: 1, Name: "Owner", NewValue: "alice"},
+		},
+		Diffs: []*Diff{
+			{
+				BlockType: model.TypeComment,
+				Authors:   StringMap{"bob": "bob"},
+				NewBlock:  &model.Block{ID: "comment", Type: model.TypeComment, Title: "Looks good"},
+			},
+		},
+	}
+
+	fieldTitles := func(t *testing.T, filter *model.SubscriptionFilter, subscriberID string) []string {
+		opts := DiffConvOpts{Logger: logger, Filter: filter, SubscriberID: subscriberID}
+		attachments, err := Diffs2SlackAttachments([]*Diff{diff}, opts)
+		require.NoError(t, err)
+		if len(attachments) == 0 {
+			return nil
+		}
+		require.Len(t, attachments, 1)
+
+		var titles []string
+		for _, field := range attachments[0].Fields {
+			titles = append(titles, field.Title)
+		}
+		return titles
+	}
+
+	t.Run("no filter", func(t *testing.T) {
+		require.Equal(t, []string{"Title", "Status", "Owner", "Comment by @bob"}, fieldTitles(t, nil, "alice"))
+	})
+
+	t.Run("comments only", func(t *testing.T) {
+		require.Equal(t, []string{"Comment by @bob"}, fieldTitles(t, &model.SubscriptionFilter{Comments: true}, "alice"))
+	})
+
+	t.Run("specific properties", func(t *testing.T) {
+		filter := &model.SubscriptionFilter{PropertyIDs: []string{"status"}}
+		require.Equal(t, []string{"Status"}, fieldTitles(t, filter, "alice"))
+	})
+
+	t.Run("assigned to me", func(t *testing.T) {
+		filter := &model.SubscriptionFilter{AssignedToMe: true}
+		require.Equal(t, []string{"Owner"}, fieldTitles(t, filter, "alice"))
+		require.Nil(t, fieldTitles(t, filter, "carol"))
+	})
+
+	t.Run("card added", func(t *testing.T) {
+		added := *diff
+		added.OldBlock = nil
+		opts := DiffConvOpts{Logger: logger, Filter: &model.SubscriptionFilter{Title: true}, SubscriberID: "alice"}
+
+		attachments, err := Diffs2SlackAttachments([]*Diff{&added}, opts)
+		require.NoError(t, err)
+		require.Empty(t, attachments)
+
+		opts.Filter.Cards = true
+		attachments, err = Diffs2SlackAttachments([]*Diff{&added}, opts)
+		require.NoError(t, err)
+		require.Equal(t, []*mm_model.SlackAttachment{{
+			Pretext:  "@bob has added the card `New title`\n",
+			Fallback: "@bob has added the card `New title`\n",
+		}}, attachments)
+	})
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifysubscriptions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/wiggin77/merror"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	digestTaskFrequency = time.Minute
+
+	// TODO: localize these when i18n is available.
+	defDigestTemplate      = "Your %s digest: %s changed in %s"
+	defDigestBoardTemplate = "- %s: %s"
+)
+
+// queueDigestItem adds the changes of a hint to the next notification digest
+// of a subscriber, unless they are notified immediately. It returns true when
+// the changes are queued.
+func (n *notifier) queueDigestItem(sub *model.Subscriber, board *model.Board, hint *model.NotificationHint) (bool, error) {
+	// digests are collected per card, so board changes are always sent immediately.
+	if sub.SubscriberType != model.SubTypeUser || hint.BlockType == model.TypeBoard {
+		return false, nil
+	}
+
+	frequency := n.digestFrequency(sub.SubscriberID)
+	if frequency == model.DigestFrequencyImmediate {
+		return false, nil
+	}
+
+	item := &model.NotificationDigestItem{
+		UserID:   sub.SubscriberID,
+		BlockID:  hint.BlockID,
+		BoardID:  board.ID,
+		SinceAt:  sub.NotifiedAt,
+		NotifyAt: model.NextDigestAt(frequency, utils.GetMillis(), n.userLocation(sub.SubscriberID)),
+	}
+
+	n.logger.Debug("notifySubscribers - queue digest item",
+		mlog.Any("hint", hint),
+		mlog.String("subscriber_id", sub.SubscriberID),
+		mlog.String("frequency", frequency),
+		mlog.Int("notify_at", item.NotifyAt),
+	)
+
+	if err := n.store.AddNotificationDigestItem(item); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (n *notifier) sendDueDigests() {
+	n.sendDigests(utils.GetMillis())
+}
+
+// sendDigests sends the notification digests due at the given time.
+func (n *notifier) sendDigests(now int64) {
+	userIDs, err := n.store.GetDueNotificationDigestUserIDs(now)
+	if err != nil {
+		n.logger.Error("Cannot fetch due notification digests", mlog.Err(err))
+		return
+	}
+
+	for _, userID := range userIDs {
+		if err := n.sendDigest(userID); err != nil {
+			n.logger.Error("Cannot send notification digest", mlog.String("user_id", userID), mlog.Err(err))
+		}
+	}
+}
+
+// sendDigest sends a single direct message to a user with the changes to all
+// the boards and cards waiting for their digest. If the message cannot be
+// delivered, the items are added back to be sent with the next digest.
+func (n *notifier) sendDigest(userID string) error {
+	items, err := n.store.TakeNotificationDigestItems(userID)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	merr := merror.New()
+	opts := n.diffConvOpts()
+
+	var attachments []*mm_model.SlackAttachment
+	var boards []*model.Board
+	cardCounts := map[string]int{}
+	for _, item := range items {
+		board, card, err := n.store.GetBoardAndCardByID(item.BlockID)
+		if err != nil || board == nil || card == nil {
+			merr.Append(fmt.Errorf("could not get board & card for block %s: %w", item.BlockID, err))
+			continue
+		}
+
+		// make sure the subscriber still has permissions for the board.
+		if !n.permissions.HasPermissionToBoard(userID, board.ID, model.PermissionViewBoard) {
+			continue
+		}
+
+		dg := &diffGenerator{
+			board:        board,
+			card:         card,
+			store:        n.store,
+			hint:         &model.NotificationHint{BlockType: card.Type, BlockID: item.BlockID},
+			lastNotifyAt: item.SinceAt,
+			logger:       n.logger,
+		}
+		diffs, err := dg.generateDiffs()
+		if err != nil {
+			merr.Append(err)
+			continue
+		}
+
+		// don't notify the author of their own changes.
+		diffAuthors := make(StringMap)
+		for _, d := range diffs {
+			diffAuthors.Append(d.Authors)
+		}
+		if _, isAuthor := diffAuthors[userID]; isAuthor && len(diffAuthors) == 1 {
+			continue
+		}
+
+		// the subscription filter may have changed since the changes were queued.
+		sub, err := n.store.GetSubscription(item.BlockID, userID)
+		if model.IsErrNotFound(err) {
+			continue
+		}
+		if err != nil {
+			merr.Append(fmt.Errorf("could not get subscription for block %s: %w", item.BlockID, err))
+			continue
+		}
+
+		cardAttachments, err := Diffs2SlackAttachments(diffs, n.filteredDiffConvOpts(sub.Filter, userID))
+		if err != nil {
+			merr.Append(err)
+			continue
+		}
+		if len(cardAttachments) == 0 {
+			continue
+		}
+
+		if cardCounts[board.ID] == 0 {
+			boards = append(boards, board)
+		}
+		cardCounts[board.ID]++
+		attachments = append(attachments, cardAttachments...)
+	}
+
+	if len(attachments) == 0 {
+		return merr.ErrorOrNil()
+	}
+
+	message := formatDigestMessage(n.digestFrequency(userID), boards, cardCounts, opts)
+
+	n.logger.Debug("sendDigest - deliver",
+		mlog.String("user_id", userID),
+		mlog.Int("board_count", len(boards)),
+		mlog.Int("attachment_count", len(attachments)),
+	)
+
+	if err := n.delivery.SubscriptionDeliverDigest(boards[0].TeamID, userID, message, attachments); err != nil {
+		merr.Append(fmt.Errorf("cannot deliver notification digest to user %s: %w", userID, err))
+		n.requeueDigestItems(items)
+	}
+	return merr.ErrorOrNil()
+}
+
+// requeueDigestItems adds back the items of a digest that could not be
+// delivered. They keep their since time, so that the next digest still covers
+// their changes.
+func (n *notifier) requeueDigestItems(items []*model.NotificationDigestItem) {
+	for _, item := range items {
+		if err := n.store.AddNotificationDigestItem(item); err != nil {
+			n.logger.Error("Cannot add back notification digest item",
+				mlog.String("user_id", item.UserID),
+				mlog.String("block_id", item.BlockID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
+
+// formatDigestMessage summarizes the changed cards of each board of a digest.
+func formatDigestMessage(frequency string, boards []*model.Board, cardCounts map[string]int, opts DiffConvOpts) string {
+	total := 0
+	lines := make([]string, 0, len(boards))
+	for _, board := range boards {
+		total += cardCounts[board.ID]
+		lines = append(lines, fmt.Sprintf(defDigestBoardTemplate, opts.MakeBoardLink(board), pluralize(cardCounts[board.ID], "card", "cards")))
+	}
+
+	header := fmt.Sprintf(defDigestTemplate, frequency, pluralize(total, "card", "cards"), pluralize(len(boards), "board", "boards"))
+	return header + "\n" + strings.Join(lines, "\n")
+}
+
+func pluralize(count int, singular, plural string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, singular)
+	}
+	return fmt.Sprintf("%d %s", count, plural)
+}
+
+// digestFrequency returns how often a user wants to be notified of changes,
+// immediately when their preferences cannot be read.
+func (n *notifier) digestFrequency(userID string) string {
+	preferences, err := n.store.GetUserPreferences(userID)
+	if err != nil {
+		n.logger.Debug("Cannot fetch user preferences", mlog.String("user_id", userID), mlog.Err(err))
+		return model.DigestFrequencyImmediate
+	}
+	return model.GetDigestFrequency(preferences)
+}
+
+// userLocation returns the time zone of a user, or UTC if it is unknown.
+func (n *notifier) userLocation(userID string) *time.Location {
+	timezone, err := n.store.GetUserTimezone(userID)
+	if err != nil {
+		n.logger.Debug("Cannot fetch user timezone", mlog.String("user_id", userID), mlog.Err(err))
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
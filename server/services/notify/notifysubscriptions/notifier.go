@@ -11,6 +11,7 @@ import (
 
 	"github.com/mattermost/mattermost-plugin-boards/server/model"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/permissions"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/scheduler"
 	"github.com/mattermost/mattermost-plugin-boards/server/utils"
 	"github.com/wiggin77/merror"
 
@@ -39,8 +40,9 @@ type notifier struct {
 
 	hints chan *model.NotificationHint
 
-	mux  sync.Mutex
-	done chan struct{}
+	mux        sync.Mutex
+	done       chan struct{}
+	digestTask *scheduler.ScheduledTask
 }
 
 func newNotifier(params BackendParams) *notifier {
@@ -55,6 +57,29 @@ func newNotifier(params BackendParams) *notifier {
 	}
 }
 
+// canViewBoard returns true if a subscriber can view a board. A channel is
+// only notified while all of its members can view the board, as the board may
+// have been made private or unlinked from the channel since it was subscribed.
+func (n *notifier) canViewBoard(sub *model.Subscriber, board *model.Board) bool {
+	if sub.SubscriberType != model.SubTypeChannel {
+		return n.permissions.HasPermissionToBoard(sub.SubscriberID, board.ID, model.PermissionViewBoard)
+	}
+	if board.ChannelID == sub.SubscriberID {
+		return true
+	}
+
+	channel, err := n.store.GetChannel(board.TeamID, sub.SubscriberID)
+	if err != nil {
+		n.logger.Warn("notifySubscribers - cannot fetch subscribed channel",
+			mlog.String("channel_id", sub.SubscriberID),
+			mlog.String("board_id", board.ID),
+			mlog.Err(err),
+		)
+		return false
+	}
+	return board.IsVisibleToChannel(channel)
+}
+
 func (n *notifier) start() {
 	n.mux.Lock()
 	defer n.mux.Unlock()
@@ -62,6 +87,7 @@ func (n *notifier) start() {
 	if n.done == nil {
 		n.done = make(chan struct{})
 		go n.loop()
+		n.digestTask = scheduler.CreateRecurringTask("sendNotificationDigests", n.sendDueDigests, digestTaskFrequency)
 	}
 }
 
@@ -72,6 +98,8 @@ func (n *notifier) stop() {
 	if n.done != nil {
 		close(n.done)
 		n.done = nil
+		n.digestTask.Cancel()
+		n.digestTask = nil
 	}
 }
 
@@ -159,16 +187,24 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 	// subs slice is sorted by `NotifiedAt`, therefore subs[0] contains the oldest NotifiedAt needed
 	oldestNotifiedAt := subs[0].NotifiedAt
 
-	// need the block's board and card.
-	board, card, err := n.store.GetBoardAndCardByID(hint.BlockID)
-	if err != nil || board == nil || card == nil {
-		return fmt.Errorf("could not get board & card for block %s: %w", hint.BlockID, err)
+	// need the block's board and card; board hints have no card.
+	var board *model.Board
+	var card *model.Block
+	if hint.BlockType == model.TypeBoard {
+		board, err = n.store.GetBoard(hint.BlockID)
+		if err != nil || board == nil {
+			return fmt.Errorf("could not get board %s: %w", hint.BlockID, err)
+		}
+	} else {
+		board, card, err = n.store.GetBoardAndCardByID(hint.BlockID)
+		if err != nil || board == nil || card == nil {
+			return fmt.Errorf("could not get board & card for block %s: %w", hint.BlockID, err)
+		}
 	}
 
 	n.logger.Debug("notifySubscribers - subscribers",
 		mlog.Any("hint", hint),
 		mlog.String("board_id", board.ID),
-		mlog.String("card_id", card.ID),
 		mlog.Int("sub_count", len(subs)),
 	)
 
@@ -199,18 +235,7 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 		diffAuthors.Append(d.Authors)
 	}
 
-	opts := DiffConvOpts{
-		Language: "en", // TODO: use correct language when i18n is available on server.
-		MakeCardLink: func(block *model.Block, board *model.Board, card *model.Block) string {
-			return fmt.Sprintf("[%s](%s)", block.Title, utils.MakeCardLink(n.serverRoot, board.TeamID, board.ID, card.ID))
-		},
-		MakeBoardLink: func(board *model.Board) string {
-			return fmt.Sprintf("[%s](%s)", board.Title, utils.MakeBoardLink(n.serverRoot, board.TeamID, board.ID))
-		},
-		Logger: n.logger,
-	}
-
-	attachments, err := Diffs2SlackAttachments(diffs, opts)
+	attachments, err := Diffs2SlackAttachments(diffs, n.diffConvOpts())
 	if err != nil {
 		return err
 	}
@@ -230,7 +255,7 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 			}
 
 			// make sure the subscriber still has permissions for the board.
-			if !n.permissions.HasPermissionToBoard(sub.SubscriberID, board.ID, model.PermissionViewBoard) {
+			if !n.canViewBoard(sub, board) {
 				n.logger.Debug("notifySubscribers - skipping non-board member",
 					mlog.Any("hint", hint),
 					mlog.String("subscriber_id", sub.SubscriberID),
@@ -239,6 +264,33 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 				continue
 			}
 
+			// subscribers with a filter only get the changes passing the filter.
+			subAttachments := attachments
+			if sub.Filter != nil {
+				subAttachments, err = Diffs2SlackAttachments(diffs, n.filteredDiffConvOpts(sub.Filter, sub.SubscriberID))
+				if err != nil {
+					merr.Append(fmt.Errorf("cannot filter notification for subscriber %s: %w", sub.SubscriberID, err))
+					continue
+				}
+				if len(subAttachments) == 0 {
+					n.logger.Debug("notifySubscribers - skipping filtered changes",
+						mlog.Any("hint", hint),
+						mlog.String("subscriber_id", sub.SubscriberID),
+					)
+					continue
+				}
+			}
+
+			// subscribers with a digest get the changes later, along with other changes.
+			queued, err := n.queueDigestItem(sub, board, hint)
+			if err != nil {
+				merr.Append(fmt.Errorf("cannot queue notification digest for subscriber %s: %w", sub.SubscriberID, err))
+				continue
+			}
+			if queued {
+				continue
+			}
+
 			n.logger.Debug("notifySubscribers - deliver",
 				mlog.Any("hint", hint),
 				mlog.String("modified_by_id", hint.ModifiedByID),
@@ -246,7 +298,7 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 				mlog.String("subscriber_type", string(sub.SubscriberType)),
 			)
 
-			if err = n.delivery.SubscriptionDeliverSlackAttachments(board.TeamID, sub.SubscriberID, sub.SubscriberType, attachments); err != nil {
+			if err = n.delivery.SubscriptionDeliverSlackAttachments(board.TeamID, sub.SubscriberID, sub.SubscriberType, subAttachments); err != nil {
 				merr.Append(fmt.Errorf("cannot deliver notification to subscriber %s [%s]: %w",
 					sub.SubscriberID, sub.SubscriberType, err))
 			}
@@ -279,3 +331,23 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 
 	return merr.ErrorOrNil()
 }
+
+func (n *notifier) diffConvOpts() DiffConvOpts {
+	return DiffConvOpts{
+		Language: "en", // TODO: use correct language when i18n is available on server.
+		MakeCardLink: func(block *model.Block, board *model.Board, card *model.Block) string {
+			return fmt.Sprintf("[%s](%s)", block.Title, utils.MakeCardLink(n.serverRoot, board.TeamID, board.ID, card.ID))
+		},
+		MakeBoardLink: func(board *model.Board) string {
+			return fmt.Sprintf("[%s](%s)", board.Title, utils.MakeBoardLink(n.serverRoot, board.TeamID, board.ID))
+		},
+		Logger: n.logger,
+	}
+}
+
+func (n *notifier) filteredDiffConvOpts(filter *model.SubscriptionFilter, subscriberID string) DiffConvOpts {
+	opts := n.diffConvOpts()
+	opts.Filter = filter
+	opts.SubscriberID = subscriberID
+	return opts
+}
@@ -0,0 +1,20 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugindelivery
+
+import (
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+)
+
+// ChannelFeedDeliver posts the batched activity of a board to its linked channel via the plugin API.
+func (pd *PluginDelivery) ChannelFeedDeliver(channelID string, message string) error {
+	post := &mm_model.Post{
+		UserId:    pd.botID,
+		ChannelId: channelID,
+		Message:   message,
+	}
+
+	_, err := pd.api.CreatePost(post)
+	return err
+}
@@ -13,6 +13,9 @@ const (
 	// TODO: localize these when i18n is available.
 	defCommentTemplate     = "@%s mentioned you in a comment on the card [%s](%s) in board [%s](%s)\n> %s"
 	defDescriptionTemplate = "@%s mentioned you in the card [%s](%s) in board [%s](%s)\n> %s"
+	defRuleTemplate        = "%s\n> Card [%s](%s) in board [%s](%s)"
+	defUpcomingTemplate    = "The card [%s](%s) in board [%s](%s) is due on %s"
+	defOverdueTemplate     = "The card [%s](%s) in board [%s](%s) is overdue, it was due on %s"
 )
 
 func formatMessage(author string, extract string, card string, link string, block *model.Block, boardLink string, board string) string {
@@ -22,3 +25,15 @@ func formatMessage(author string, extract string, card string, link string, bloc
 	}
 	return fmt.Sprintf(template, author, card, link, board, boardLink, extract)
 }
+
+func formatRuleMessage(message string, card string, link string, board string, boardLink string) string {
+	return fmt.Sprintf(defRuleTemplate, message, card, link, board, boardLink)
+}
+
+func formatReminderMessage(kind string, dueDate string, card string, link string, board string, boardLink string) string {
+	template := defUpcomingTemplate
+	if kind == model.CardReminderKindOverdue {
+		template = defOverdueTemplate
+	}
+	return fmt.Sprintf(template, card, link, board, boardLink, dueDate)
+}
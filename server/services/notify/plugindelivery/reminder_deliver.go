@@ -0,0 +1,32 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugindelivery
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+)
+
+// ReminderDeliver sends a due date reminder about a card to a user via the plugin API.
+func (pd *PluginDelivery) ReminderDeliver(userID string, kind string, dueDate string, card *model.Block, board *model.Board) error {
+	channel, err := pd.getDirectChannel(board.TeamID, userID, pd.botID)
+	if err != nil {
+		return fmt.Errorf("cannot get direct channel: %w", err)
+	}
+	link := utils.MakeCardLink(pd.serverRoot, board.TeamID, board.ID, card.ID)
+	boardLink := utils.MakeBoardLink(pd.serverRoot, board.TeamID, board.ID)
+
+	post := &mm_model.Post{
+		UserId:    pd.botID,
+		ChannelId: channel.Id,
+		Message:   formatReminderMessage(kind, dueDate, card.Title, link, board.Title, boardLink),
+	}
+
+	_, err = pd.api.CreatePost(post)
+	return err
+}
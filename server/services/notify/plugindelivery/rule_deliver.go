@@ -0,0 +1,26 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugindelivery
+
+import (
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+)
+
+// RuleDeliver posts the message of a board automation rule to a channel via the plugin API.
+func (pd *PluginDelivery) RuleDeliver(channelID string, message string, evt notify.BlockChangeEvent) error {
+	link := utils.MakeCardLink(pd.serverRoot, evt.Board.TeamID, evt.Board.ID, evt.Card.ID)
+	boardLink := utils.MakeBoardLink(pd.serverRoot, evt.Board.TeamID, evt.Board.ID)
+
+	post := &mm_model.Post{
+		UserId:    pd.botID,
+		ChannelId: channelID,
+		Message:   formatRuleMessage(message, evt.Card.Title, link, evt.Board.Title, boardLink),
+	}
+
+	_, err := pd.api.CreatePost(post)
+	return err
+}
@@ -45,6 +45,27 @@ func (pd *PluginDelivery) SubscriptionDeliverSlackAttachments(teamID string, sub
 	return err
 }
 
+// SubscriptionDeliverDigest sends a notification digest, a summary message with the changes of
+// several cards, to a user via the plugin API.
+func (pd *PluginDelivery) SubscriptionDeliverDigest(teamID string, userID string, message string,
+	attachments []*mm_model.SlackAttachment) error {
+	channel, err := pd.getDirectChannel(teamID, userID, pd.botID)
+	if err != nil {
+		return fmt.Errorf("cannot get direct channel: %w", err)
+	}
+
+	post := &mm_model.Post{
+		UserId:    pd.botID,
+		ChannelId: channel.Id,
+		Message:   message,
+	}
+
+	mm_model.ParseSlackAttachment(post, attachments)
+
+	_, err = pd.api.CreatePost(post)
+	return err
+}
+
 func (pd *PluginDelivery) getDirectChannelID(teamID string, subscriberID string, subscriberType model.SubscriberType, botID string) (string, error) {
 	switch subscriberType {
 	case model.SubTypeUser:
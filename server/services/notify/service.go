@@ -30,6 +30,13 @@ type BlockChangeEvent struct {
 	ModifiedBy   *model.BoardMember
 }
 
+// BoardChangeEvent is a change of a board or of its members, which may change
+// who can view the cards of the board.
+type BoardChangeEvent struct {
+	TeamID string
+	Board  *model.Board
+}
+
 // Backend provides an interface for sending notifications.
 type Backend interface {
 	Start() error
@@ -38,6 +45,18 @@ type Backend interface {
 	Name() string
 }
 
+// BoardBackend is implemented by the backends that are informed of board
+// changes.
+type BoardBackend interface {
+	BoardChanged(evt BoardChangeEvent) error
+}
+
+// SilentBackend is implemented by the backends that are also informed of the
+// block changes saved with notifications disabled.
+type SilentBackend interface {
+	BlockChangedSilently(evt BlockChangeEvent) error
+}
+
 // Service is a service that sends notifications based on block activity using one or more backends.
 type Service struct {
 	mux      sync.RWMutex
@@ -106,3 +125,47 @@ func (s *Service) BlockChanged(evt BlockChangeEvent) {
 		}
 	}
 }
+
+// BlockChangedSilently should be called instead of BlockChanged whenever a
+// block is changed with notifications disabled. Only the backends
+// implementing SilentBackend are informed of the event.
+func (s *Service) BlockChangedSilently(evt BlockChangeEvent) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, backend := range s.backends {
+		silentBackend, ok := backend.(SilentBackend)
+		if !ok {
+			continue
+		}
+		if err := silentBackend.BlockChangedSilently(evt); err != nil {
+			s.logger.Error("Error delivering silent notification",
+				mlog.String("backend", backend.Name()),
+				mlog.String("action", string(evt.Action)),
+				mlog.String("block_id", evt.BlockChanged.ID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
+
+// BoardChanged should be called whenever a board or its members are updated.
+// The backends implementing BoardBackend are informed of the event.
+func (s *Service) BoardChanged(evt BoardChangeEvent) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, backend := range s.backends {
+		boardBackend, ok := backend.(BoardBackend)
+		if !ok {
+			continue
+		}
+		if err := boardBackend.BoardChanged(evt); err != nil {
+			s.logger.Error("Error delivering board notification",
+				mlog.String("backend", backend.Name()),
+				mlog.String("board_id", evt.Board.ID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
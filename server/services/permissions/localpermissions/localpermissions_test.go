@@ -169,4 +169,88 @@ func TestHasPermissionToBoard(t *testing.T) {
 
 		th.checkBoardPermissions("viewer", member, hasPermissionTo, hasNotPermissionTo)
 	})
+
+	t.Run("board custom role", func(t *testing.T) {
+		member := &model.BoardMember{
+			UserID:       "user-id",
+			BoardID:      "board-id",
+			SchemeViewer: true,
+			CustomRoleID: "role-id",
+		}
+		role := &model.BoardCustomRole{
+			ID:          "role-id",
+			BoardID:     "board-id",
+			Name:        "Contractor",
+			Permissions: []string{model.PermissionCommentBoardCards.Id, model.PermissionEditBoardCardProperties.Id},
+		}
+
+		th.store.EXPECT().
+			GetBoardCustomRole("role-id").
+			Return(role, nil).
+			AnyTimes()
+
+		hasPermissionTo := []*mmModel.Permission{
+			model.PermissionViewBoard,
+			model.PermissionCommentBoardCards,
+			model.PermissionEditBoardCardProperties,
+		}
+
+		hasNotPermissionTo := []*mmModel.Permission{
+			model.PermissionManageBoardType,
+			model.PermissionDeleteBoard,
+			model.PermissionManageBoardRoles,
+			model.PermissionShareBoard,
+			model.PermissionManageBoardCards,
+			model.PermissionManageBoardProperties,
+		}
+
+		th.checkBoardPermissions("custom", member, hasPermissionTo, hasNotPermissionTo)
+	})
+
+	t.Run("board custom role of another board", func(t *testing.T) {
+		member := &model.BoardMember{
+			UserID:       "user-id",
+			BoardID:      "board-id",
+			SchemeEditor: true,
+			CustomRoleID: "other-role-id",
+		}
+
+		th.store.EXPECT().
+			GetBoardCustomRole("other-role-id").
+			Return(&model.BoardCustomRole{ID: "other-role-id", BoardID: "other-board-id", Permissions: []string{model.PermissionManageBoardCards.Id}}, nil).
+			AnyTimes()
+
+		hasNotPermissionTo := []*mmModel.Permission{
+			model.PermissionViewBoard,
+			model.PermissionManageBoardCards,
+		}
+
+		th.checkBoardPermissions("foreign custom", member, []*mmModel.Permission{}, hasNotPermissionTo)
+	})
+
+	t.Run("deleted board custom role", func(t *testing.T) {
+		member := &model.BoardMember{
+			UserID:       "user-id",
+			BoardID:      "board-id",
+			SchemeViewer: true,
+			CustomRoleID: "deleted-role-id",
+		}
+
+		th.store.EXPECT().
+			GetBoardCustomRole("deleted-role-id").
+			Return(nil, model.NewErrNotFound("board custom role ID=deleted-role-id")).
+			AnyTimes()
+
+		hasPermissionTo := []*mmModel.Permission{
+			model.PermissionViewBoard,
+		}
+
+		hasNotPermissionTo := []*mmModel.Permission{
+			model.PermissionCommentBoardCards,
+			model.PermissionManageBoardCards,
+			model.PermissionManageBoardRoles,
+		}
+
+		th.checkBoardPermissions("deleted custom", member, hasPermissionTo, hasNotPermissionTo)
+	})
 }
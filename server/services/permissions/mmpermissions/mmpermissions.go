@@ -95,6 +95,22 @@ func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmMod
 		return false
 	}
 
+	// a custom role replaces the scheme roles of the member, while the
+	// minimum role of the board still applies. If the role no longer
+	// exists, the member keeps the viewer role it was given with it
+	if member.CustomRoleID != "" {
+		granted, found := s.hasCustomRolePermission(member, permission)
+		if granted {
+			return true
+		}
+		member.SchemeAdmin = false
+		member.SchemeEditor = false
+		member.SchemeCommenter = false
+		if found {
+			member.SchemeViewer = false
+		}
+	}
+
 	switch member.MinimumRole {
 	case "admin":
 		member.SchemeAdmin = true
@@ -116,7 +132,7 @@ func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmMod
 	switch permission {
 	case model.PermissionManageBoardType, model.PermissionDeleteBoard, model.PermissionManageBoardRoles, model.PermissionShareBoard, model.PermissionDeleteOthersComments:
 		return member.SchemeAdmin
-	case model.PermissionManageBoardCards, model.PermissionManageBoardProperties:
+	case model.PermissionManageBoardCards, model.PermissionManageBoardProperties, model.PermissionEditBoardCardProperties:
 		return member.SchemeAdmin || member.SchemeEditor
 	case model.PermissionCommentBoardCards:
 		return member.SchemeAdmin || member.SchemeEditor || member.SchemeCommenter
@@ -126,3 +142,22 @@ func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmMod
 		return false
 	}
 }
+
+// hasCustomRolePermission returns true if the custom role of a member grants
+// a permission on its board, and whether the role was found.
+func (s *Service) hasCustomRolePermission(member *model.BoardMember, permission *mmModel.Permission) (bool, bool) {
+	role, err := s.store.GetBoardCustomRole(member.CustomRoleID)
+	if model.IsErrNotFound(err) {
+		return false, false
+	}
+	if err != nil {
+		s.logger.Error("error getting custom role for board member",
+			mlog.String("boardID", member.BoardID),
+			mlog.String("userID", member.UserID),
+			mlog.String("roleID", member.CustomRoleID),
+			mlog.Err(err),
+		)
+		return false, true
+	}
+	return role.BoardID == member.BoardID && role.HasPermission(permission), true
+}
@@ -243,4 +243,68 @@ func TestHasPermissionToBoard(t *testing.T) {
 		hasNotPermissionTo := []*mmModel.Permission{}
 		th.checkBoardPermissions("elevated-admin", member, teamID, hasPermissionTo, hasNotPermissionTo)
 	})
+
+	t.Run("board custom role", func(t *testing.T) {
+		role := &model.BoardCustomRole{
+			ID:          "role-id",
+			BoardID:     boardID,
+			Name:        "Contractor",
+			Permissions: []string{model.PermissionCommentBoardCards.Id, model.PermissionEditBoardCardProperties.Id},
+		}
+
+		testCases := []struct {
+			permission *mmModel.Permission
+			expected   bool
+		}{
+			{model.PermissionViewBoard, true},
+			{model.PermissionCommentBoardCards, true},
+			{model.PermissionEditBoardCardProperties, true},
+			{model.PermissionManageBoardCards, false},
+			{model.PermissionManageBoardProperties, false},
+			{model.PermissionManageBoardRoles, false},
+			{model.PermissionDeleteBoard, false},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.permission.Id, func(t *testing.T) {
+				member := &model.BoardMember{
+					UserID:       userID,
+					BoardID:      boardID,
+					SchemeEditor: true,
+					CustomRoleID: role.ID,
+				}
+
+				th.store.EXPECT().
+					GetBoard(boardID).
+					Return(&model.Board{ID: boardID, TeamID: teamID}, nil).
+					Times(1)
+
+				th.api.EXPECT().
+					HasPermissionToTeam(userID, teamID, model.PermissionViewTeam).
+					Return(true).
+					Times(1)
+
+				th.store.EXPECT().
+					GetMemberForBoard(boardID, userID).
+					Return(member, nil).
+					Times(1)
+
+				th.store.EXPECT().
+					GetBoardCustomRole(role.ID).
+					Return(role, nil).
+					Times(1)
+
+				// the scheme roles of the member are replaced by its custom role
+				if !tc.expected {
+					th.api.EXPECT().
+						HasPermissionToTeam(userID, teamID, model.PermissionManageTeam).
+						Return(false).
+						Times(1)
+				}
+
+				hasPermission := th.permissions.HasPermissionToBoard(userID, boardID, tc.permission)
+				assert.Equal(t, tc.expected, hasPermission)
+			})
+		}
+	})
 }
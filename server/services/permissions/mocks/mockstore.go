@@ -49,6 +49,21 @@ func (mr *MockStoreMockRecorder) GetBoard(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*MockStore)(nil).GetBoard), arg0)
 }
 
+// GetBoardCustomRole mocks base method.
+func (m *MockStore) GetBoardCustomRole(arg0 string) (*model.BoardCustomRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardCustomRole", arg0)
+	ret0, _ := ret[0].(*model.BoardCustomRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardCustomRole indicates an expected call of GetBoardCustomRole.
+func (mr *MockStoreMockRecorder) GetBoardCustomRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardCustomRole", reflect.TypeOf((*MockStore)(nil).GetBoardCustomRole), arg0)
+}
+
 // GetBoardHistory mocks base method.
 func (m *MockStore) GetBoardHistory(arg0 string, arg1 model.QueryBoardHistoryOptions) ([]*model.Board, error) {
 	m.ctrl.T.Helper()
@@ -36,6 +36,34 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AddChannelFeedItem mocks base method.
+func (m *MockStore) AddChannelFeedItem(arg0 *model.ChannelFeedItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddChannelFeedItem", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddChannelFeedItem indicates an expected call of AddChannelFeedItem.
+func (mr *MockStoreMockRecorder) AddChannelFeedItem(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChannelFeedItem", reflect.TypeOf((*MockStore)(nil).AddChannelFeedItem), arg0)
+}
+
+// AddNotificationDigestItem mocks base method.
+func (m *MockStore) AddNotificationDigestItem(arg0 *model.NotificationDigestItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddNotificationDigestItem", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddNotificationDigestItem indicates an expected call of AddNotificationDigestItem.
+func (mr *MockStoreMockRecorder) AddNotificationDigestItem(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNotificationDigestItem", reflect.TypeOf((*MockStore)(nil).AddNotificationDigestItem), arg0)
+}
+
 // AddUpdateCategoryBoard mocks base method.
 func (m *MockStore) AddUpdateCategoryBoard(arg0, arg1 string, arg2 []string) error {
 	m.ctrl.T.Helper()
@@ -50,6 +78,21 @@ func (mr *MockStoreMockRecorder) AddUpdateCategoryBoard(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUpdateCategoryBoard", reflect.TypeOf((*MockStore)(nil).AddUpdateCategoryBoard), arg0, arg1, arg2)
 }
 
+// AdvanceCardRecurrence mocks base method.
+func (m *MockStore) AdvanceCardRecurrence(arg0 *model.CardRecurrence, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvanceCardRecurrence", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdvanceCardRecurrence indicates an expected call of AdvanceCardRecurrence.
+func (mr *MockStoreMockRecorder) AdvanceCardRecurrence(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceCardRecurrence", reflect.TypeOf((*MockStore)(nil).AdvanceCardRecurrence), arg0, arg1)
+}
+
 // CanSeeUser mocks base method.
 func (m *MockStore) CanSeeUser(arg0, arg1 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +108,96 @@ func (mr *MockStoreMockRecorder) CanSeeUser(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanSeeUser", reflect.TypeOf((*MockStore)(nil).CanSeeUser), arg0, arg1)
 }
 
+// ClaimWebhookDelivery mocks base method.
+func (m *MockStore) ClaimWebhookDelivery(arg0 *model.WebhookDelivery, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWebhookDelivery", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimWebhookDelivery indicates an expected call of ClaimWebhookDelivery.
+func (mr *MockStoreMockRecorder) ClaimWebhookDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWebhookDelivery", reflect.TypeOf((*MockStore)(nil).ClaimWebhookDelivery), arg0, arg1)
+}
+
+// CountFailedShareLinkAccessesSince mocks base method.
+func (m *MockStore) CountFailedShareLinkAccessesSince(arg0 string, arg1 string, arg2 int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFailedShareLinkAccessesSince", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFailedShareLinkAccessesSince indicates an expected call of CountFailedShareLinkAccessesSince.
+func (mr *MockStoreMockRecorder) CountFailedShareLinkAccessesSince(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFailedShareLinkAccessesSince", reflect.TypeOf((*MockStore)(nil).CountFailedShareLinkAccessesSince), arg0, arg1, arg2)
+}
+
+// CountGuestCommentsSince mocks base method.
+func (m *MockStore) CountGuestCommentsSince(arg0 string, arg1 string, arg2 int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountGuestCommentsSince", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountGuestCommentsSince indicates an expected call of CountGuestCommentsSince.
+func (mr *MockStoreMockRecorder) CountGuestCommentsSince(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountGuestCommentsSince", reflect.TypeOf((*MockStore)(nil).CountGuestCommentsSince), arg0, arg1, arg2)
+}
+
+// CreateBoardCustomRole mocks base method.
+func (m *MockStore) CreateBoardCustomRole(arg0 *model.BoardCustomRole) (*model.BoardCustomRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoardCustomRole", arg0)
+	ret0, _ := ret[0].(*model.BoardCustomRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoardCustomRole indicates an expected call of CreateBoardCustomRole.
+func (mr *MockStoreMockRecorder) CreateBoardCustomRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardCustomRole", reflect.TypeOf((*MockStore)(nil).CreateBoardCustomRole), arg0)
+}
+
+// CreateBoardRule mocks base method.
+func (m *MockStore) CreateBoardRule(arg0 *model.BoardRule) (*model.BoardRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoardRule", arg0)
+	ret0, _ := ret[0].(*model.BoardRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoardRule indicates an expected call of CreateBoardRule.
+func (mr *MockStoreMockRecorder) CreateBoardRule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardRule", reflect.TypeOf((*MockStore)(nil).CreateBoardRule), arg0)
+}
+
+// CreateBoardWebhook mocks base method.
+func (m *MockStore) CreateBoardWebhook(arg0 *model.BoardWebhook) (*model.BoardWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoardWebhook", arg0)
+	ret0, _ := ret[0].(*model.BoardWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoardWebhook indicates an expected call of CreateBoardWebhook.
+func (mr *MockStoreMockRecorder) CreateBoardWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardWebhook", reflect.TypeOf((*MockStore)(nil).CreateBoardWebhook), arg0)
+}
+
 // CreateBoardsAndBlocks mocks base method.
 func (m *MockStore) CreateBoardsAndBlocks(arg0 *model.BoardsAndBlocks, arg1 string) (*model.BoardsAndBlocks, error) {
 	m.ctrl.T.Helper()
@@ -96,6 +229,36 @@ func (mr *MockStoreMockRecorder) CreateBoardsAndBlocksWithAdmin(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardsAndBlocksWithAdmin", reflect.TypeOf((*MockStore)(nil).CreateBoardsAndBlocksWithAdmin), arg0, arg1)
 }
 
+// CreateCardDependency mocks base method.
+func (m *MockStore) CreateCardDependency(arg0 *model.CardDependency) (*model.CardDependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCardDependency", arg0)
+	ret0, _ := ret[0].(*model.CardDependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCardDependency indicates an expected call of CreateCardDependency.
+func (mr *MockStoreMockRecorder) CreateCardDependency(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCardDependency", reflect.TypeOf((*MockStore)(nil).CreateCardDependency), arg0)
+}
+
+// CreateCardReminder mocks base method.
+func (m *MockStore) CreateCardReminder(arg0 *model.CardReminder) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCardReminder", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCardReminder indicates an expected call of CreateCardReminder.
+func (mr *MockStoreMockRecorder) CreateCardReminder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCardReminder", reflect.TypeOf((*MockStore)(nil).CreateCardReminder), arg0)
+}
+
 // CreateCategory mocks base method.
 func (m *MockStore) CreateCategory(arg0 model.Category) error {
 	m.ctrl.T.Helper()
@@ -110,6 +273,81 @@ func (mr *MockStoreMockRecorder) CreateCategory(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockStore)(nil).CreateCategory), arg0)
 }
 
+// CreateGuestComment mocks base method.
+func (m *MockStore) CreateGuestComment(arg0 *model.GuestComment, arg1 int, arg2 int64) (*model.GuestComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGuestComment", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.GuestComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGuestComment indicates an expected call of CreateGuestComment.
+func (mr *MockStoreMockRecorder) CreateGuestComment(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGuestComment", reflect.TypeOf((*MockStore)(nil).CreateGuestComment), arg0, arg1, arg2)
+}
+
+// CreateIncomingWebhook mocks base method.
+func (m *MockStore) CreateIncomingWebhook(arg0 *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIncomingWebhook", arg0)
+	ret0, _ := ret[0].(*model.IncomingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIncomingWebhook indicates an expected call of CreateIncomingWebhook.
+func (mr *MockStoreMockRecorder) CreateIncomingWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIncomingWebhook", reflect.TypeOf((*MockStore)(nil).CreateIncomingWebhook), arg0)
+}
+
+// CreateIteration mocks base method.
+func (m *MockStore) CreateIteration(arg0 *model.Iteration) (*model.Iteration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIteration", arg0)
+	ret0, _ := ret[0].(*model.Iteration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIteration indicates an expected call of CreateIteration.
+func (mr *MockStoreMockRecorder) CreateIteration(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIteration", reflect.TypeOf((*MockStore)(nil).CreateIteration), arg0)
+}
+
+// CreateRetentionPolicy mocks base method.
+func (m *MockStore) CreateRetentionPolicy(arg0 *model.RetentionPolicy) (*model.RetentionPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRetentionPolicy", arg0)
+	ret0, _ := ret[0].(*model.RetentionPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRetentionPolicy indicates an expected call of CreateRetentionPolicy.
+func (mr *MockStoreMockRecorder) CreateRetentionPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRetentionPolicy", reflect.TypeOf((*MockStore)(nil).CreateRetentionPolicy), arg0)
+}
+
+// CreateShareLink mocks base method.
+func (m *MockStore) CreateShareLink(arg0 *model.ShareLink) (*model.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShareLink", arg0)
+	ret0, _ := ret[0].(*model.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShareLink indicates an expected call of CreateShareLink.
+func (mr *MockStoreMockRecorder) CreateShareLink(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShareLink", reflect.TypeOf((*MockStore)(nil).CreateShareLink), arg0)
+}
+
 // CreateSubscription mocks base method.
 func (m *MockStore) CreateSubscription(arg0 *model.Subscription) (*model.Subscription, error) {
 	m.ctrl.T.Helper()
@@ -125,6 +363,21 @@ func (mr *MockStoreMockRecorder) CreateSubscription(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockStore)(nil).CreateSubscription), arg0)
 }
 
+// CreateTimeEntry mocks base method.
+func (m *MockStore) CreateTimeEntry(arg0 *model.TimeEntry) (*model.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTimeEntry", arg0)
+	ret0, _ := ret[0].(*model.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTimeEntry indicates an expected call of CreateTimeEntry.
+func (mr *MockStoreMockRecorder) CreateTimeEntry(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTimeEntry", reflect.TypeOf((*MockStore)(nil).CreateTimeEntry), arg0)
+}
+
 // DBType mocks base method.
 func (m *MockStore) DBType() string {
 	m.ctrl.T.Helper()
@@ -195,6 +448,20 @@ func (mr *MockStoreMockRecorder) DeleteBoard(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoard", reflect.TypeOf((*MockStore)(nil).DeleteBoard), arg0, arg1)
 }
 
+// DeleteBoardCustomRole mocks base method.
+func (m *MockStore) DeleteBoardCustomRole(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoardCustomRole", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoardCustomRole indicates an expected call of DeleteBoardCustomRole.
+func (mr *MockStoreMockRecorder) DeleteBoardCustomRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardCustomRole", reflect.TypeOf((*MockStore)(nil).DeleteBoardCustomRole), arg0)
+}
+
 // DeleteBoardRecord mocks base method.
 func (m *MockStore) DeleteBoardRecord(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -209,6 +476,34 @@ func (mr *MockStoreMockRecorder) DeleteBoardRecord(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardRecord", reflect.TypeOf((*MockStore)(nil).DeleteBoardRecord), arg0, arg1)
 }
 
+// DeleteBoardRule mocks base method.
+func (m *MockStore) DeleteBoardRule(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoardRule", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoardRule indicates an expected call of DeleteBoardRule.
+func (mr *MockStoreMockRecorder) DeleteBoardRule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardRule", reflect.TypeOf((*MockStore)(nil).DeleteBoardRule), arg0)
+}
+
+// DeleteBoardWebhook mocks base method.
+func (m *MockStore) DeleteBoardWebhook(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoardWebhook", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoardWebhook indicates an expected call of DeleteBoardWebhook.
+func (mr *MockStoreMockRecorder) DeleteBoardWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardWebhook", reflect.TypeOf((*MockStore)(nil).DeleteBoardWebhook), arg0)
+}
+
 // DeleteBoardsAndBlocks mocks base method.
 func (m *MockStore) DeleteBoardsAndBlocks(arg0 *model.DeleteBoardsAndBlocks, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -223,6 +518,76 @@ func (mr *MockStoreMockRecorder) DeleteBoardsAndBlocks(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardsAndBlocks", reflect.TypeOf((*MockStore)(nil).DeleteBoardsAndBlocks), arg0, arg1)
 }
 
+// DeleteCardDependency mocks base method.
+func (m *MockStore) DeleteCardDependency(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCardDependency", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCardDependency indicates an expected call of DeleteCardDependency.
+func (mr *MockStoreMockRecorder) DeleteCardDependency(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCardDependency", reflect.TypeOf((*MockStore)(nil).DeleteCardDependency), arg0)
+}
+
+// DeleteCardPreviewPost mocks base method.
+func (m *MockStore) DeleteCardPreviewPost(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCardPreviewPost", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCardPreviewPost indicates an expected call of DeleteCardPreviewPost.
+func (mr *MockStoreMockRecorder) DeleteCardPreviewPost(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCardPreviewPost", reflect.TypeOf((*MockStore)(nil).DeleteCardPreviewPost), arg0)
+}
+
+// DeleteCardRecurrence mocks base method.
+func (m *MockStore) DeleteCardRecurrence(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCardRecurrence", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCardRecurrence indicates an expected call of DeleteCardRecurrence.
+func (mr *MockStoreMockRecorder) DeleteCardRecurrence(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCardRecurrence", reflect.TypeOf((*MockStore)(nil).DeleteCardRecurrence), arg0)
+}
+
+// DeleteCardReminderSettings mocks base method.
+func (m *MockStore) DeleteCardReminderSettings(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCardReminderSettings", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCardReminderSettings indicates an expected call of DeleteCardReminderSettings.
+func (mr *MockStoreMockRecorder) DeleteCardReminderSettings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCardReminderSettings", reflect.TypeOf((*MockStore)(nil).DeleteCardReminderSettings), arg0)
+}
+
+// DeleteCardRemindersDueBefore mocks base method.
+func (m *MockStore) DeleteCardRemindersDueBefore(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCardRemindersDueBefore", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCardRemindersDueBefore indicates an expected call of DeleteCardRemindersDueBefore.
+func (mr *MockStoreMockRecorder) DeleteCardRemindersDueBefore(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCardRemindersDueBefore", reflect.TypeOf((*MockStore)(nil).DeleteCardRemindersDueBefore), arg0)
+}
+
 // DeleteCategory mocks base method.
 func (m *MockStore) DeleteCategory(arg0, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -237,6 +602,48 @@ func (mr *MockStoreMockRecorder) DeleteCategory(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockStore)(nil).DeleteCategory), arg0, arg1, arg2)
 }
 
+// DeleteChannelFeedSettings mocks base method.
+func (m *MockStore) DeleteChannelFeedSettings(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChannelFeedSettings", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChannelFeedSettings indicates an expected call of DeleteChannelFeedSettings.
+func (mr *MockStoreMockRecorder) DeleteChannelFeedSettings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChannelFeedSettings", reflect.TypeOf((*MockStore)(nil).DeleteChannelFeedSettings), arg0)
+}
+
+// DeleteIncomingWebhook mocks base method.
+func (m *MockStore) DeleteIncomingWebhook(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIncomingWebhook", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIncomingWebhook indicates an expected call of DeleteIncomingWebhook.
+func (mr *MockStoreMockRecorder) DeleteIncomingWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIncomingWebhook", reflect.TypeOf((*MockStore)(nil).DeleteIncomingWebhook), arg0)
+}
+
+// DeleteIteration mocks base method.
+func (m *MockStore) DeleteIteration(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIteration", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIteration indicates an expected call of DeleteIteration.
+func (mr *MockStoreMockRecorder) DeleteIteration(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIteration", reflect.TypeOf((*MockStore)(nil).DeleteIteration), arg0)
+}
+
 // DeleteMember mocks base method.
 func (m *MockStore) DeleteMember(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -265,6 +672,34 @@ func (mr *MockStoreMockRecorder) DeleteNotificationHint(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotificationHint", reflect.TypeOf((*MockStore)(nil).DeleteNotificationHint), arg0)
 }
 
+// DeleteRetentionPolicy mocks base method.
+func (m *MockStore) DeleteRetentionPolicy(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRetentionPolicy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRetentionPolicy indicates an expected call of DeleteRetentionPolicy.
+func (mr *MockStoreMockRecorder) DeleteRetentionPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRetentionPolicy", reflect.TypeOf((*MockStore)(nil).DeleteRetentionPolicy), arg0)
+}
+
+// DeleteShareLink mocks base method.
+func (m *MockStore) DeleteShareLink(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShareLink", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShareLink indicates an expected call of DeleteShareLink.
+func (mr *MockStoreMockRecorder) DeleteShareLink(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShareLink", reflect.TypeOf((*MockStore)(nil).DeleteShareLink), arg0)
+}
+
 // DeleteSubscription mocks base method.
 func (m *MockStore) DeleteSubscription(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -279,6 +714,20 @@ func (mr *MockStoreMockRecorder) DeleteSubscription(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockStore)(nil).DeleteSubscription), arg0, arg1)
 }
 
+// DeleteTimeEntry mocks base method.
+func (m *MockStore) DeleteTimeEntry(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTimeEntry", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTimeEntry indicates an expected call of DeleteTimeEntry.
+func (mr *MockStoreMockRecorder) DeleteTimeEntry(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTimeEntry", reflect.TypeOf((*MockStore)(nil).DeleteTimeEntry), arg0)
+}
+
 // DuplicateBlock mocks base method.
 func (m *MockStore) DuplicateBlock(arg0, arg1, arg2 string, arg3 bool) ([]*model.Block, error) {
 	m.ctrl.T.Helper()
@@ -325,10 +774,25 @@ func (mr *MockStoreMockRecorder) GetActiveUserCount(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveUserCount", reflect.TypeOf((*MockStore)(nil).GetActiveUserCount), arg0)
 }
 
-// GetAllTeams mocks base method.
-func (m *MockStore) GetAllTeams() ([]*model.Team, error) {
+// GetAllCardReminderSettings mocks base method.
+func (m *MockStore) GetAllCardReminderSettings() ([]*model.CardReminderSettings, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllTeams")
+	ret := m.ctrl.Call(m, "GetAllCardReminderSettings")
+	ret0, _ := ret[0].([]*model.CardReminderSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCardReminderSettings indicates an expected call of GetAllCardReminderSettings.
+func (mr *MockStoreMockRecorder) GetAllCardReminderSettings() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCardReminderSettings", reflect.TypeOf((*MockStore)(nil).GetAllCardReminderSettings))
+}
+
+// GetAllTeams mocks base method.
+func (m *MockStore) GetAllTeams() ([]*model.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTeams")
 	ret0, _ := ret[0].([]*model.Team)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
@@ -584,6 +1048,36 @@ func (mr *MockStoreMockRecorder) GetBoardCount(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardCount", reflect.TypeOf((*MockStore)(nil).GetBoardCount), arg0)
 }
 
+// GetBoardCustomRole mocks base method.
+func (m *MockStore) GetBoardCustomRole(arg0 string) (*model.BoardCustomRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardCustomRole", arg0)
+	ret0, _ := ret[0].(*model.BoardCustomRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardCustomRole indicates an expected call of GetBoardCustomRole.
+func (mr *MockStoreMockRecorder) GetBoardCustomRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardCustomRole", reflect.TypeOf((*MockStore)(nil).GetBoardCustomRole), arg0)
+}
+
+// GetBoardCustomRolesForBoard mocks base method.
+func (m *MockStore) GetBoardCustomRolesForBoard(arg0 string) ([]*model.BoardCustomRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardCustomRolesForBoard", arg0)
+	ret0, _ := ret[0].([]*model.BoardCustomRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardCustomRolesForBoard indicates an expected call of GetBoardCustomRolesForBoard.
+func (mr *MockStoreMockRecorder) GetBoardCustomRolesForBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardCustomRolesForBoard", reflect.TypeOf((*MockStore)(nil).GetBoardCustomRolesForBoard), arg0)
+}
+
 // GetBoardHistory mocks base method.
 func (m *MockStore) GetBoardHistory(arg0 string, arg1 model.QueryBoardHistoryOptions) ([]*model.Board, error) {
 	m.ctrl.T.Helper()
@@ -614,6 +1108,66 @@ func (mr *MockStoreMockRecorder) GetBoardMemberHistory(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardMemberHistory", reflect.TypeOf((*MockStore)(nil).GetBoardMemberHistory), arg0, arg1, arg2)
 }
 
+// GetBoardRule mocks base method.
+func (m *MockStore) GetBoardRule(arg0 string) (*model.BoardRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardRule", arg0)
+	ret0, _ := ret[0].(*model.BoardRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardRule indicates an expected call of GetBoardRule.
+func (mr *MockStoreMockRecorder) GetBoardRule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardRule", reflect.TypeOf((*MockStore)(nil).GetBoardRule), arg0)
+}
+
+// GetBoardRulesForBoard mocks base method.
+func (m *MockStore) GetBoardRulesForBoard(arg0 string) ([]*model.BoardRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardRulesForBoard", arg0)
+	ret0, _ := ret[0].([]*model.BoardRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardRulesForBoard indicates an expected call of GetBoardRulesForBoard.
+func (mr *MockStoreMockRecorder) GetBoardRulesForBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardRulesForBoard", reflect.TypeOf((*MockStore)(nil).GetBoardRulesForBoard), arg0)
+}
+
+// GetBoardWebhook mocks base method.
+func (m *MockStore) GetBoardWebhook(arg0 string) (*model.BoardWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardWebhook", arg0)
+	ret0, _ := ret[0].(*model.BoardWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardWebhook indicates an expected call of GetBoardWebhook.
+func (mr *MockStoreMockRecorder) GetBoardWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardWebhook", reflect.TypeOf((*MockStore)(nil).GetBoardWebhook), arg0)
+}
+
+// GetBoardWebhooksForBoard mocks base method.
+func (m *MockStore) GetBoardWebhooksForBoard(arg0 string) ([]*model.BoardWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardWebhooksForBoard", arg0)
+	ret0, _ := ret[0].([]*model.BoardWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardWebhooksForBoard indicates an expected call of GetBoardWebhooksForBoard.
+func (mr *MockStoreMockRecorder) GetBoardWebhooksForBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardWebhooksForBoard", reflect.TypeOf((*MockStore)(nil).GetBoardWebhooksForBoard), arg0)
+}
+
 // GetBoardsComplianceHistory mocks base method.
 func (m *MockStore) GetBoardsComplianceHistory(arg0 model.QueryBoardsComplianceHistoryOptions) ([]*model.BoardHistory, bool, error) {
 	m.ctrl.T.Helper()
@@ -676,6 +1230,51 @@ func (mr *MockStoreMockRecorder) GetBoardsInTeamByIds(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsInTeamByIds", reflect.TypeOf((*MockStore)(nil).GetBoardsInTeamByIds), arg0, arg1)
 }
 
+// GetCardDependenciesForBoard mocks base method.
+func (m *MockStore) GetCardDependenciesForBoard(arg0 string) ([]*model.CardDependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardDependenciesForBoard", arg0)
+	ret0, _ := ret[0].([]*model.CardDependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardDependenciesForBoard indicates an expected call of GetCardDependenciesForBoard.
+func (mr *MockStoreMockRecorder) GetCardDependenciesForBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardDependenciesForBoard", reflect.TypeOf((*MockStore)(nil).GetCardDependenciesForBoard), arg0)
+}
+
+// GetCardDependenciesForCard mocks base method.
+func (m *MockStore) GetCardDependenciesForCard(arg0 string) ([]*model.CardDependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardDependenciesForCard", arg0)
+	ret0, _ := ret[0].([]*model.CardDependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardDependenciesForCard indicates an expected call of GetCardDependenciesForCard.
+func (mr *MockStoreMockRecorder) GetCardDependenciesForCard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardDependenciesForCard", reflect.TypeOf((*MockStore)(nil).GetCardDependenciesForCard), arg0)
+}
+
+// GetCardDependency mocks base method.
+func (m *MockStore) GetCardDependency(arg0 string) (*model.CardDependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardDependency", arg0)
+	ret0, _ := ret[0].(*model.CardDependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardDependency indicates an expected call of GetCardDependency.
+func (mr *MockStoreMockRecorder) GetCardDependency(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardDependency", reflect.TypeOf((*MockStore)(nil).GetCardDependency), arg0)
+}
+
 // GetCardLimitTimestamp mocks base method.
 func (m *MockStore) GetCardLimitTimestamp() (int64, error) {
 	m.ctrl.T.Helper()
@@ -691,6 +1290,81 @@ func (mr *MockStoreMockRecorder) GetCardLimitTimestamp() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardLimitTimestamp", reflect.TypeOf((*MockStore)(nil).GetCardLimitTimestamp))
 }
 
+// GetCardPreviewPostIDs mocks base method.
+func (m *MockStore) GetCardPreviewPostIDs(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardPreviewPostIDs", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardPreviewPostIDs indicates an expected call of GetCardPreviewPostIDs.
+func (mr *MockStoreMockRecorder) GetCardPreviewPostIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardPreviewPostIDs", reflect.TypeOf((*MockStore)(nil).GetCardPreviewPostIDs), arg0)
+}
+
+// GetCardPreviewPostIDsForBoard mocks base method.
+func (m *MockStore) GetCardPreviewPostIDsForBoard(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardPreviewPostIDsForBoard", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardPreviewPostIDsForBoard indicates an expected call of GetCardPreviewPostIDsForBoard.
+func (mr *MockStoreMockRecorder) GetCardPreviewPostIDsForBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardPreviewPostIDsForBoard", reflect.TypeOf((*MockStore)(nil).GetCardPreviewPostIDsForBoard), arg0)
+}
+
+// GetCardRecurrence mocks base method.
+func (m *MockStore) GetCardRecurrence(arg0 string) (*model.CardRecurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardRecurrence", arg0)
+	ret0, _ := ret[0].(*model.CardRecurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardRecurrence indicates an expected call of GetCardRecurrence.
+func (mr *MockStoreMockRecorder) GetCardRecurrence(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardRecurrence", reflect.TypeOf((*MockStore)(nil).GetCardRecurrence), arg0)
+}
+
+// GetCardRecurrencesForBoard mocks base method.
+func (m *MockStore) GetCardRecurrencesForBoard(arg0 string) ([]*model.CardRecurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardRecurrencesForBoard", arg0)
+	ret0, _ := ret[0].([]*model.CardRecurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardRecurrencesForBoard indicates an expected call of GetCardRecurrencesForBoard.
+func (mr *MockStoreMockRecorder) GetCardRecurrencesForBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardRecurrencesForBoard", reflect.TypeOf((*MockStore)(nil).GetCardRecurrencesForBoard), arg0)
+}
+
+// GetCardReminderSettings mocks base method.
+func (m *MockStore) GetCardReminderSettings(arg0 string) (*model.CardReminderSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardReminderSettings", arg0)
+	ret0, _ := ret[0].(*model.CardReminderSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardReminderSettings indicates an expected call of GetCardReminderSettings.
+func (mr *MockStoreMockRecorder) GetCardReminderSettings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardReminderSettings", reflect.TypeOf((*MockStore)(nil).GetCardReminderSettings), arg0)
+}
+
 // GetCardsCount mocks base method.
 func (m *MockStore) GetCardsCount() (int64, error) {
 	m.ctrl.T.Helper()
@@ -736,6 +1410,96 @@ func (mr *MockStoreMockRecorder) GetChannel(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannel", reflect.TypeOf((*MockStore)(nil).GetChannel), arg0, arg1)
 }
 
+// GetChannelFeedSettings mocks base method.
+func (m *MockStore) GetChannelFeedSettings(arg0 string) (*model.ChannelFeedSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelFeedSettings", arg0)
+	ret0, _ := ret[0].(*model.ChannelFeedSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelFeedSettings indicates an expected call of GetChannelFeedSettings.
+func (mr *MockStoreMockRecorder) GetChannelFeedSettings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelFeedSettings", reflect.TypeOf((*MockStore)(nil).GetChannelFeedSettings), arg0)
+}
+
+// GetDataRetentionPreview mocks base method.
+func (m *MockStore) GetDataRetentionPreview(arg0 *model.RetentionCutoffs) (*model.RetentionPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataRetentionPreview", arg0)
+	ret0, _ := ret[0].(*model.RetentionPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDataRetentionPreview indicates an expected call of GetDataRetentionPreview.
+func (mr *MockStoreMockRecorder) GetDataRetentionPreview(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataRetentionPreview", reflect.TypeOf((*MockStore)(nil).GetDataRetentionPreview), arg0)
+}
+
+// GetDueCardRecurrences mocks base method.
+func (m *MockStore) GetDueCardRecurrences(arg0 int64) ([]*model.CardRecurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueCardRecurrences", arg0)
+	ret0, _ := ret[0].([]*model.CardRecurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueCardRecurrences indicates an expected call of GetDueCardRecurrences.
+func (mr *MockStoreMockRecorder) GetDueCardRecurrences(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueCardRecurrences", reflect.TypeOf((*MockStore)(nil).GetDueCardRecurrences), arg0)
+}
+
+// GetDueChannelFeedBoardIDs mocks base method.
+func (m *MockStore) GetDueChannelFeedBoardIDs(arg0 int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueChannelFeedBoardIDs", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueChannelFeedBoardIDs indicates an expected call of GetDueChannelFeedBoardIDs.
+func (mr *MockStoreMockRecorder) GetDueChannelFeedBoardIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueChannelFeedBoardIDs", reflect.TypeOf((*MockStore)(nil).GetDueChannelFeedBoardIDs), arg0)
+}
+
+// GetDueNotificationDigestUserIDs mocks base method.
+func (m *MockStore) GetDueNotificationDigestUserIDs(arg0 int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueNotificationDigestUserIDs", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueNotificationDigestUserIDs indicates an expected call of GetDueNotificationDigestUserIDs.
+func (mr *MockStoreMockRecorder) GetDueNotificationDigestUserIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueNotificationDigestUserIDs", reflect.TypeOf((*MockStore)(nil).GetDueNotificationDigestUserIDs), arg0)
+}
+
+// GetDueWebhookDeliveries mocks base method.
+func (m *MockStore) GetDueWebhookDeliveries(arg0 int64, arg1 int) ([]*model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueWebhookDeliveries", arg0, arg1)
+	ret0, _ := ret[0].([]*model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueWebhookDeliveries indicates an expected call of GetDueWebhookDeliveries.
+func (mr *MockStoreMockRecorder) GetDueWebhookDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).GetDueWebhookDeliveries), arg0, arg1)
+}
+
 // GetFileInfo mocks base method.
 func (m *MockStore) GetFileInfo(arg0 string) (*model0.FileInfo, error) {
 	m.ctrl.T.Helper()
@@ -751,6 +1515,96 @@ func (mr *MockStoreMockRecorder) GetFileInfo(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileInfo", reflect.TypeOf((*MockStore)(nil).GetFileInfo), arg0)
 }
 
+// GetGuestComment mocks base method.
+func (m *MockStore) GetGuestComment(arg0 string) (*model.GuestComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGuestComment", arg0)
+	ret0, _ := ret[0].(*model.GuestComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGuestComment indicates an expected call of GetGuestComment.
+func (mr *MockStoreMockRecorder) GetGuestComment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuestComment", reflect.TypeOf((*MockStore)(nil).GetGuestComment), arg0)
+}
+
+// GetGuestCommentsForBoard mocks base method.
+func (m *MockStore) GetGuestCommentsForBoard(arg0 string, arg1 string) ([]*model.GuestComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGuestCommentsForBoard", arg0, arg1)
+	ret0, _ := ret[0].([]*model.GuestComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGuestCommentsForBoard indicates an expected call of GetGuestCommentsForBoard.
+func (mr *MockStoreMockRecorder) GetGuestCommentsForBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuestCommentsForBoard", reflect.TypeOf((*MockStore)(nil).GetGuestCommentsForBoard), arg0, arg1)
+}
+
+// GetIncomingWebhook mocks base method.
+func (m *MockStore) GetIncomingWebhook(arg0 string) (*model.IncomingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncomingWebhook", arg0)
+	ret0, _ := ret[0].(*model.IncomingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncomingWebhook indicates an expected call of GetIncomingWebhook.
+func (mr *MockStoreMockRecorder) GetIncomingWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncomingWebhook", reflect.TypeOf((*MockStore)(nil).GetIncomingWebhook), arg0)
+}
+
+// GetIncomingWebhooksForBoard mocks base method.
+func (m *MockStore) GetIncomingWebhooksForBoard(arg0 string) ([]*model.IncomingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncomingWebhooksForBoard", arg0)
+	ret0, _ := ret[0].([]*model.IncomingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncomingWebhooksForBoard indicates an expected call of GetIncomingWebhooksForBoard.
+func (mr *MockStoreMockRecorder) GetIncomingWebhooksForBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncomingWebhooksForBoard", reflect.TypeOf((*MockStore)(nil).GetIncomingWebhooksForBoard), arg0)
+}
+
+// GetIteration mocks base method.
+func (m *MockStore) GetIteration(arg0 string) (*model.Iteration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIteration", arg0)
+	ret0, _ := ret[0].(*model.Iteration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIteration indicates an expected call of GetIteration.
+func (mr *MockStoreMockRecorder) GetIteration(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIteration", reflect.TypeOf((*MockStore)(nil).GetIteration), arg0)
+}
+
+// GetIterationsForBoard mocks base method.
+func (m *MockStore) GetIterationsForBoard(arg0 string) ([]*model.Iteration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIterationsForBoard", arg0)
+	ret0, _ := ret[0].([]*model.Iteration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIterationsForBoard indicates an expected call of GetIterationsForBoard.
+func (mr *MockStoreMockRecorder) GetIterationsForBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIterationsForBoard", reflect.TypeOf((*MockStore)(nil).GetIterationsForBoard), arg0)
+}
+
 // GetLicense mocks base method.
 func (m *MockStore) GetLicense() *model0.License {
 	m.ctrl.T.Helper()
@@ -774,85 +1628,190 @@ func (m *MockStore) GetMemberForBoard(arg0, arg1 string) (*model.BoardMember, er
 	return ret0, ret1
 }
 
-// GetMemberForBoard indicates an expected call of GetMemberForBoard.
-func (mr *MockStoreMockRecorder) GetMemberForBoard(arg0, arg1 interface{}) *gomock.Call {
+// GetMemberForBoard indicates an expected call of GetMemberForBoard.
+func (mr *MockStoreMockRecorder) GetMemberForBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberForBoard", reflect.TypeOf((*MockStore)(nil).GetMemberForBoard), arg0, arg1)
+}
+
+// GetMembersForBoard mocks base method.
+func (m *MockStore) GetMembersForBoard(arg0 string) ([]*model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembersForBoard", arg0)
+	ret0, _ := ret[0].([]*model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembersForBoard indicates an expected call of GetMembersForBoard.
+func (mr *MockStoreMockRecorder) GetMembersForBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembersForBoard", reflect.TypeOf((*MockStore)(nil).GetMembersForBoard), arg0)
+}
+
+// GetMembersForUser mocks base method.
+func (m *MockStore) GetMembersForUser(arg0 string) ([]*model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembersForUser", arg0)
+	ret0, _ := ret[0].([]*model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembersForUser indicates an expected call of GetMembersForUser.
+func (mr *MockStoreMockRecorder) GetMembersForUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembersForUser", reflect.TypeOf((*MockStore)(nil).GetMembersForUser), arg0)
+}
+
+// GetNextNotificationHint mocks base method.
+func (m *MockStore) GetNextNotificationHint(arg0 bool) (*model.NotificationHint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNextNotificationHint", arg0)
+	ret0, _ := ret[0].(*model.NotificationHint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNextNotificationHint indicates an expected call of GetNextNotificationHint.
+func (mr *MockStoreMockRecorder) GetNextNotificationHint(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextNotificationHint", reflect.TypeOf((*MockStore)(nil).GetNextNotificationHint), arg0)
+}
+
+// GetNotificationHint mocks base method.
+func (m *MockStore) GetNotificationHint(arg0 string) (*model.NotificationHint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationHint", arg0)
+	ret0, _ := ret[0].(*model.NotificationHint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationHint indicates an expected call of GetNotificationHint.
+func (mr *MockStoreMockRecorder) GetNotificationHint(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationHint", reflect.TypeOf((*MockStore)(nil).GetNotificationHint), arg0)
+}
+
+// GetRegisteredUserCount mocks base method.
+func (m *MockStore) GetRegisteredUserCount() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRegisteredUserCount")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRegisteredUserCount indicates an expected call of GetRegisteredUserCount.
+func (mr *MockStoreMockRecorder) GetRegisteredUserCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegisteredUserCount", reflect.TypeOf((*MockStore)(nil).GetRegisteredUserCount))
+}
+
+// GetRetentionPolicies mocks base method.
+func (m *MockStore) GetRetentionPolicies() ([]*model.RetentionPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRetentionPolicies")
+	ret0, _ := ret[0].([]*model.RetentionPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRetentionPolicies indicates an expected call of GetRetentionPolicies.
+func (mr *MockStoreMockRecorder) GetRetentionPolicies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRetentionPolicies", reflect.TypeOf((*MockStore)(nil).GetRetentionPolicies))
+}
+
+// GetRetentionPolicy mocks base method.
+func (m *MockStore) GetRetentionPolicy(arg0 string) (*model.RetentionPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRetentionPolicy", arg0)
+	ret0, _ := ret[0].(*model.RetentionPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRetentionPolicy indicates an expected call of GetRetentionPolicy.
+func (mr *MockStoreMockRecorder) GetRetentionPolicy(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberForBoard", reflect.TypeOf((*MockStore)(nil).GetMemberForBoard), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRetentionPolicy", reflect.TypeOf((*MockStore)(nil).GetRetentionPolicy), arg0)
 }
 
-// GetMembersForBoard mocks base method.
-func (m *MockStore) GetMembersForBoard(arg0 string) ([]*model.BoardMember, error) {
+// GetRunningTimeEntry mocks base method.
+func (m *MockStore) GetRunningTimeEntry(arg0 string) (*model.TimeEntry, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMembersForBoard", arg0)
-	ret0, _ := ret[0].([]*model.BoardMember)
+	ret := m.ctrl.Call(m, "GetRunningTimeEntry", arg0)
+	ret0, _ := ret[0].(*model.TimeEntry)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMembersForBoard indicates an expected call of GetMembersForBoard.
-func (mr *MockStoreMockRecorder) GetMembersForBoard(arg0 interface{}) *gomock.Call {
+// GetRunningTimeEntry indicates an expected call of GetRunningTimeEntry.
+func (mr *MockStoreMockRecorder) GetRunningTimeEntry(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembersForBoard", reflect.TypeOf((*MockStore)(nil).GetMembersForBoard), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningTimeEntry", reflect.TypeOf((*MockStore)(nil).GetRunningTimeEntry), arg0)
 }
 
-// GetMembersForUser mocks base method.
-func (m *MockStore) GetMembersForUser(arg0 string) ([]*model.BoardMember, error) {
+// GetShareLink mocks base method.
+func (m *MockStore) GetShareLink(arg0 string) (*model.ShareLink, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMembersForUser", arg0)
-	ret0, _ := ret[0].([]*model.BoardMember)
+	ret := m.ctrl.Call(m, "GetShareLink", arg0)
+	ret0, _ := ret[0].(*model.ShareLink)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMembersForUser indicates an expected call of GetMembersForUser.
-func (mr *MockStoreMockRecorder) GetMembersForUser(arg0 interface{}) *gomock.Call {
+// GetShareLink indicates an expected call of GetShareLink.
+func (mr *MockStoreMockRecorder) GetShareLink(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembersForUser", reflect.TypeOf((*MockStore)(nil).GetMembersForUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShareLink", reflect.TypeOf((*MockStore)(nil).GetShareLink), arg0)
 }
 
-// GetNextNotificationHint mocks base method.
-func (m *MockStore) GetNextNotificationHint(arg0 bool) (*model.NotificationHint, error) {
+// GetShareLinkAccesses mocks base method.
+func (m *MockStore) GetShareLinkAccesses(arg0 string, arg1 int, arg2 int) ([]*model.ShareLinkAccess, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNextNotificationHint", arg0)
-	ret0, _ := ret[0].(*model.NotificationHint)
+	ret := m.ctrl.Call(m, "GetShareLinkAccesses", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.ShareLinkAccess)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetNextNotificationHint indicates an expected call of GetNextNotificationHint.
-func (mr *MockStoreMockRecorder) GetNextNotificationHint(arg0 interface{}) *gomock.Call {
+// GetShareLinkAccesses indicates an expected call of GetShareLinkAccesses.
+func (mr *MockStoreMockRecorder) GetShareLinkAccesses(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextNotificationHint", reflect.TypeOf((*MockStore)(nil).GetNextNotificationHint), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShareLinkAccesses", reflect.TypeOf((*MockStore)(nil).GetShareLinkAccesses), arg0, arg1, arg2)
 }
 
-// GetNotificationHint mocks base method.
-func (m *MockStore) GetNotificationHint(arg0 string) (*model.NotificationHint, error) {
+// GetShareLinkByToken mocks base method.
+func (m *MockStore) GetShareLinkByToken(arg0 string, arg1 string) (*model.ShareLink, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNotificationHint", arg0)
-	ret0, _ := ret[0].(*model.NotificationHint)
+	ret := m.ctrl.Call(m, "GetShareLinkByToken", arg0, arg1)
+	ret0, _ := ret[0].(*model.ShareLink)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetNotificationHint indicates an expected call of GetNotificationHint.
-func (mr *MockStoreMockRecorder) GetNotificationHint(arg0 interface{}) *gomock.Call {
+// GetShareLinkByToken indicates an expected call of GetShareLinkByToken.
+func (mr *MockStoreMockRecorder) GetShareLinkByToken(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationHint", reflect.TypeOf((*MockStore)(nil).GetNotificationHint), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShareLinkByToken", reflect.TypeOf((*MockStore)(nil).GetShareLinkByToken), arg0, arg1)
 }
 
-// GetRegisteredUserCount mocks base method.
-func (m *MockStore) GetRegisteredUserCount() (int, error) {
+// GetShareLinksForBoard mocks base method.
+func (m *MockStore) GetShareLinksForBoard(arg0 string) ([]*model.ShareLink, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRegisteredUserCount")
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "GetShareLinksForBoard", arg0)
+	ret0, _ := ret[0].([]*model.ShareLink)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRegisteredUserCount indicates an expected call of GetRegisteredUserCount.
-func (mr *MockStoreMockRecorder) GetRegisteredUserCount() *gomock.Call {
+// GetShareLinksForBoard indicates an expected call of GetShareLinksForBoard.
+func (mr *MockStoreMockRecorder) GetShareLinksForBoard(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegisteredUserCount", reflect.TypeOf((*MockStore)(nil).GetRegisteredUserCount))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShareLinksForBoard", reflect.TypeOf((*MockStore)(nil).GetShareLinksForBoard), arg0)
 }
 
 // GetSharing mocks base method.
@@ -1035,6 +1994,51 @@ func (mr *MockStoreMockRecorder) GetTemplateBoards(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateBoards", reflect.TypeOf((*MockStore)(nil).GetTemplateBoards), arg0, arg1)
 }
 
+// GetTimeEntriesForBoard mocks base method.
+func (m *MockStore) GetTimeEntriesForBoard(arg0 string) ([]*model.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeEntriesForBoard", arg0)
+	ret0, _ := ret[0].([]*model.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeEntriesForBoard indicates an expected call of GetTimeEntriesForBoard.
+func (mr *MockStoreMockRecorder) GetTimeEntriesForBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeEntriesForBoard", reflect.TypeOf((*MockStore)(nil).GetTimeEntriesForBoard), arg0)
+}
+
+// GetTimeEntriesForCard mocks base method.
+func (m *MockStore) GetTimeEntriesForCard(arg0 string) ([]*model.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeEntriesForCard", arg0)
+	ret0, _ := ret[0].([]*model.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeEntriesForCard indicates an expected call of GetTimeEntriesForCard.
+func (mr *MockStoreMockRecorder) GetTimeEntriesForCard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeEntriesForCard", reflect.TypeOf((*MockStore)(nil).GetTimeEntriesForCard), arg0)
+}
+
+// GetTimeEntry mocks base method.
+func (m *MockStore) GetTimeEntry(arg0 string) (*model.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeEntry", arg0)
+	ret0, _ := ret[0].(*model.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeEntry indicates an expected call of GetTimeEntry.
+func (mr *MockStoreMockRecorder) GetTimeEntry(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeEntry", reflect.TypeOf((*MockStore)(nil).GetTimeEntry), arg0)
+}
+
 // GetUsedCardsCount mocks base method.
 func (m *MockStore) GetUsedCardsCount() (int64, error) {
 	m.ctrl.T.Helper()
@@ -1185,6 +2189,21 @@ func (mr *MockStoreMockRecorder) GetUsersList(arg0, arg1, arg2 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersList", reflect.TypeOf((*MockStore)(nil).GetUsersList), arg0, arg1, arg2)
 }
 
+// GetWebhookDeliveries mocks base method.
+func (m *MockStore) GetWebhookDeliveries(arg0 string, arg1, arg2 int) ([]*model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveries", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDeliveries indicates an expected call of GetWebhookDeliveries.
+func (mr *MockStoreMockRecorder) GetWebhookDeliveries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).GetWebhookDeliveries), arg0, arg1, arg2)
+}
+
 // InsertBlock mocks base method.
 func (m *MockStore) InsertBlock(arg0 *model.Block, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -1244,6 +2263,49 @@ func (mr *MockStoreMockRecorder) InsertBoardWithAdmin(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBoardWithAdmin", reflect.TypeOf((*MockStore)(nil).InsertBoardWithAdmin), arg0, arg1)
 }
 
+// InsertShareLinkAccess mocks base method.
+func (m *MockStore) InsertShareLinkAccess(arg0 *model.ShareLinkAccess) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertShareLinkAccess", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertShareLinkAccess indicates an expected call of InsertShareLinkAccess.
+func (mr *MockStoreMockRecorder) InsertShareLinkAccess(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertShareLinkAccess", reflect.TypeOf((*MockStore)(nil).InsertShareLinkAccess), arg0)
+}
+
+// InsertWebhookDelivery mocks base method.
+func (m *MockStore) InsertWebhookDelivery(arg0 *model.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWebhookDelivery", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertWebhookDelivery indicates an expected call of InsertWebhookDelivery.
+func (mr *MockStoreMockRecorder) InsertWebhookDelivery(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWebhookDelivery", reflect.TypeOf((*MockStore)(nil).InsertWebhookDelivery), arg0)
+}
+
+// MoveCardToBoard mocks base method.
+func (m *MockStore) MoveCardToBoard(arg0, arg1, arg2 string) ([]*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCardToBoard", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveCardToBoard indicates an expected call of MoveCardToBoard.
+func (mr *MockStoreMockRecorder) MoveCardToBoard(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCardToBoard", reflect.TypeOf((*MockStore)(nil).MoveCardToBoard), arg0, arg1, arg2)
+}
+
 // PatchBlock mocks base method.
 func (m *MockStore) PatchBlock(arg0 string, arg1 *model.BlockPatch, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -1375,8 +2437,22 @@ func (mr *MockStoreMockRecorder) ReorderCategoryBoards(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderCategoryBoards", reflect.TypeOf((*MockStore)(nil).ReorderCategoryBoards), arg0, arg1)
 }
 
+// ResetGuestCommentApproval mocks base method.
+func (m *MockStore) ResetGuestCommentApproval(arg0 *model.GuestComment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetGuestCommentApproval", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetGuestCommentApproval indicates an expected call of ResetGuestCommentApproval.
+func (mr *MockStoreMockRecorder) ResetGuestCommentApproval(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetGuestCommentApproval", reflect.TypeOf((*MockStore)(nil).ResetGuestCommentApproval), arg0)
+}
+
 // RunDataRetention mocks base method.
-func (m *MockStore) RunDataRetention(arg0, arg1 int64) (int64, error) {
+func (m *MockStore) RunDataRetention(arg0 *model.RetentionCutoffs, arg1 int64) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RunDataRetention", arg0, arg1)
 	ret0, _ := ret[0].(int64)
@@ -1390,6 +2466,65 @@ func (mr *MockStoreMockRecorder) RunDataRetention(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunDataRetention", reflect.TypeOf((*MockStore)(nil).RunDataRetention), arg0, arg1)
 }
 
+// SaveCardPreviewPost mocks base method.
+func (m *MockStore) SaveCardPreviewPost(arg0 *model.CardPreviewPost) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCardPreviewPost", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCardPreviewPost indicates an expected call of SaveCardPreviewPost.
+func (mr *MockStoreMockRecorder) SaveCardPreviewPost(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCardPreviewPost", reflect.TypeOf((*MockStore)(nil).SaveCardPreviewPost), arg0)
+}
+
+// SaveCardRecurrence mocks base method.
+func (m *MockStore) SaveCardRecurrence(arg0 *model.CardRecurrence) (*model.CardRecurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCardRecurrence", arg0)
+	ret0, _ := ret[0].(*model.CardRecurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveCardRecurrence indicates an expected call of SaveCardRecurrence.
+func (mr *MockStoreMockRecorder) SaveCardRecurrence(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCardRecurrence", reflect.TypeOf((*MockStore)(nil).SaveCardRecurrence), arg0)
+}
+
+// SaveCardReminderSettings mocks base method.
+func (m *MockStore) SaveCardReminderSettings(arg0 *model.CardReminderSettings) (*model.CardReminderSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCardReminderSettings", arg0)
+	ret0, _ := ret[0].(*model.CardReminderSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveCardReminderSettings indicates an expected call of SaveCardReminderSettings.
+func (mr *MockStoreMockRecorder) SaveCardReminderSettings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCardReminderSettings", reflect.TypeOf((*MockStore)(nil).SaveCardReminderSettings), arg0)
+}
+
+// SaveChannelFeedSettings mocks base method.
+func (m *MockStore) SaveChannelFeedSettings(arg0 *model.ChannelFeedSettings) (*model.ChannelFeedSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChannelFeedSettings", arg0)
+	ret0, _ := ret[0].(*model.ChannelFeedSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveChannelFeedSettings indicates an expected call of SaveChannelFeedSettings.
+func (mr *MockStoreMockRecorder) SaveChannelFeedSettings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChannelFeedSettings", reflect.TypeOf((*MockStore)(nil).SaveChannelFeedSettings), arg0)
+}
+
 // SaveFileInfo mocks base method.
 func (m *MockStore) SaveFileInfo(arg0 *model0.FileInfo) error {
 	m.ctrl.T.Helper()
@@ -1449,6 +2584,21 @@ func (mr *MockStoreMockRecorder) SearchBoardsForUserInTeam(arg0, arg1, arg2 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBoardsForUserInTeam", reflect.TypeOf((*MockStore)(nil).SearchBoardsForUserInTeam), arg0, arg1, arg2)
 }
 
+// SearchCards mocks base method.
+func (m *MockStore) SearchCards(arg0 model.CardSearchOptions) ([]*model.CardSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchCards", arg0)
+	ret0, _ := ret[0].([]*model.CardSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchCards indicates an expected call of SearchCards.
+func (mr *MockStoreMockRecorder) SearchCards(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCards", reflect.TypeOf((*MockStore)(nil).SearchCards), arg0)
+}
+
 // SearchUserChannels mocks base method.
 func (m *MockStore) SearchUserChannels(arg0, arg1, arg2 string) ([]*model0.Channel, error) {
 	m.ctrl.T.Helper()
@@ -1535,6 +2685,36 @@ func (mr *MockStoreMockRecorder) Shutdown() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockStore)(nil).Shutdown))
 }
 
+// TakeChannelFeedItems mocks base method.
+func (m *MockStore) TakeChannelFeedItems(arg0 string) ([]*model.ChannelFeedItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeChannelFeedItems", arg0)
+	ret0, _ := ret[0].([]*model.ChannelFeedItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeChannelFeedItems indicates an expected call of TakeChannelFeedItems.
+func (mr *MockStoreMockRecorder) TakeChannelFeedItems(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeChannelFeedItems", reflect.TypeOf((*MockStore)(nil).TakeChannelFeedItems), arg0)
+}
+
+// TakeNotificationDigestItems mocks base method.
+func (m *MockStore) TakeNotificationDigestItems(arg0 string) ([]*model.NotificationDigestItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeNotificationDigestItems", arg0)
+	ret0, _ := ret[0].([]*model.NotificationDigestItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeNotificationDigestItems indicates an expected call of TakeNotificationDigestItems.
+func (mr *MockStoreMockRecorder) TakeNotificationDigestItems(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeNotificationDigestItems", reflect.TypeOf((*MockStore)(nil).TakeNotificationDigestItems), arg0)
+}
+
 // UndeleteBlock mocks base method.
 func (m *MockStore) UndeleteBlock(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -1563,6 +2743,65 @@ func (mr *MockStoreMockRecorder) UndeleteBoard(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndeleteBoard", reflect.TypeOf((*MockStore)(nil).UndeleteBoard), arg0, arg1)
 }
 
+// UpdateBoardCustomRole mocks base method.
+func (m *MockStore) UpdateBoardCustomRole(arg0 *model.BoardCustomRole) (*model.BoardCustomRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBoardCustomRole", arg0)
+	ret0, _ := ret[0].(*model.BoardCustomRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBoardCustomRole indicates an expected call of UpdateBoardCustomRole.
+func (mr *MockStoreMockRecorder) UpdateBoardCustomRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBoardCustomRole", reflect.TypeOf((*MockStore)(nil).UpdateBoardCustomRole), arg0)
+}
+
+// UpdateBoardRule mocks base method.
+func (m *MockStore) UpdateBoardRule(arg0 *model.BoardRule) (*model.BoardRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBoardRule", arg0)
+	ret0, _ := ret[0].(*model.BoardRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBoardRule indicates an expected call of UpdateBoardRule.
+func (mr *MockStoreMockRecorder) UpdateBoardRule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBoardRule", reflect.TypeOf((*MockStore)(nil).UpdateBoardRule), arg0)
+}
+
+// UpdateBoardWebhook mocks base method.
+func (m *MockStore) UpdateBoardWebhook(arg0 *model.BoardWebhook) (*model.BoardWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBoardWebhook", arg0)
+	ret0, _ := ret[0].(*model.BoardWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBoardWebhook indicates an expected call of UpdateBoardWebhook.
+func (mr *MockStoreMockRecorder) UpdateBoardWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBoardWebhook", reflect.TypeOf((*MockStore)(nil).UpdateBoardWebhook), arg0)
+}
+
+// UpdateCardComputedProperties mocks base method.
+func (m *MockStore) UpdateCardComputedProperties(arg0 string, arg1 map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCardComputedProperties", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCardComputedProperties indicates an expected call of UpdateCardComputedProperties.
+func (mr *MockStoreMockRecorder) UpdateCardComputedProperties(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCardComputedProperties", reflect.TypeOf((*MockStore)(nil).UpdateCardComputedProperties), arg0, arg1)
+}
+
 // UpdateCardLimitTimestamp mocks base method.
 func (m *MockStore) UpdateCardLimitTimestamp(arg0 int) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1592,6 +2831,81 @@ func (mr *MockStoreMockRecorder) UpdateCategory(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockStore)(nil).UpdateCategory), arg0)
 }
 
+// UpdateGuestCommentStatus mocks base method.
+func (m *MockStore) UpdateGuestCommentStatus(arg0 *model.GuestComment) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGuestCommentStatus", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGuestCommentStatus indicates an expected call of UpdateGuestCommentStatus.
+func (mr *MockStoreMockRecorder) UpdateGuestCommentStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGuestCommentStatus", reflect.TypeOf((*MockStore)(nil).UpdateGuestCommentStatus), arg0)
+}
+
+// UpdateIncomingWebhook mocks base method.
+func (m *MockStore) UpdateIncomingWebhook(arg0 *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIncomingWebhook", arg0)
+	ret0, _ := ret[0].(*model.IncomingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIncomingWebhook indicates an expected call of UpdateIncomingWebhook.
+func (mr *MockStoreMockRecorder) UpdateIncomingWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIncomingWebhook", reflect.TypeOf((*MockStore)(nil).UpdateIncomingWebhook), arg0)
+}
+
+// UpdateIteration mocks base method.
+func (m *MockStore) UpdateIteration(arg0 *model.Iteration) (*model.Iteration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIteration", arg0)
+	ret0, _ := ret[0].(*model.Iteration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIteration indicates an expected call of UpdateIteration.
+func (mr *MockStoreMockRecorder) UpdateIteration(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIteration", reflect.TypeOf((*MockStore)(nil).UpdateIteration), arg0)
+}
+
+// UpdateRetentionPolicy mocks base method.
+func (m *MockStore) UpdateRetentionPolicy(arg0 *model.RetentionPolicy) (*model.RetentionPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRetentionPolicy", arg0)
+	ret0, _ := ret[0].(*model.RetentionPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRetentionPolicy indicates an expected call of UpdateRetentionPolicy.
+func (mr *MockStoreMockRecorder) UpdateRetentionPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRetentionPolicy", reflect.TypeOf((*MockStore)(nil).UpdateRetentionPolicy), arg0)
+}
+
+// UpdateShareLink mocks base method.
+func (m *MockStore) UpdateShareLink(arg0 *model.ShareLink) (*model.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateShareLink", arg0)
+	ret0, _ := ret[0].(*model.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateShareLink indicates an expected call of UpdateShareLink.
+func (mr *MockStoreMockRecorder) UpdateShareLink(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShareLink", reflect.TypeOf((*MockStore)(nil).UpdateShareLink), arg0)
+}
+
 // UpdateSubscribersNotifiedAt mocks base method.
 func (m *MockStore) UpdateSubscribersNotifiedAt(arg0 string, arg1 int64) error {
 	m.ctrl.T.Helper()
@@ -1606,6 +2920,49 @@ func (mr *MockStoreMockRecorder) UpdateSubscribersNotifiedAt(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscribersNotifiedAt", reflect.TypeOf((*MockStore)(nil).UpdateSubscribersNotifiedAt), arg0, arg1)
 }
 
+// UpdateSubscriptionFilter mocks base method.
+func (m *MockStore) UpdateSubscriptionFilter(arg0 string, arg1 string, arg2 *model.SubscriptionFilter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscriptionFilter", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSubscriptionFilter indicates an expected call of UpdateSubscriptionFilter.
+func (mr *MockStoreMockRecorder) UpdateSubscriptionFilter(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscriptionFilter", reflect.TypeOf((*MockStore)(nil).UpdateSubscriptionFilter), arg0, arg1, arg2)
+}
+
+// UpdateTimeEntry mocks base method.
+func (m *MockStore) UpdateTimeEntry(arg0 *model.TimeEntry) (*model.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTimeEntry", arg0)
+	ret0, _ := ret[0].(*model.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTimeEntry indicates an expected call of UpdateTimeEntry.
+func (mr *MockStoreMockRecorder) UpdateTimeEntry(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTimeEntry", reflect.TypeOf((*MockStore)(nil).UpdateTimeEntry), arg0)
+}
+
+// UpdateWebhookDelivery mocks base method.
+func (m *MockStore) UpdateWebhookDelivery(arg0 *model.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookDelivery", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhookDelivery indicates an expected call of UpdateWebhookDelivery.
+func (mr *MockStoreMockRecorder) UpdateWebhookDelivery(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookDelivery", reflect.TypeOf((*MockStore)(nil).UpdateWebhookDelivery), arg0)
+}
+
 // UpsertNotificationHint mocks base method.
 func (m *MockStore) UpsertNotificationHint(arg0 *model.NotificationHint, arg1 time.Duration) (*model.NotificationHint, error) {
 	m.ctrl.T.Helper()
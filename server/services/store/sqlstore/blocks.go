@@ -327,6 +327,39 @@ func (s *SQLStore) patchBlock(db sq.BaseRunner, blockID string, blockPatch *mode
 	return s.insertBlock(db, block, userID)
 }
 
+// updateCardComputedProperties saves the property values of a card after its
+// computed properties are recomputed. Unlike patchBlock, it neither records a
+// history entry nor changes the user who last modified the card, as the
+// values are derived from other cards.
+func (s *SQLStore) updateCardComputedProperties(db sq.BaseRunner, blockID string, properties map[string]interface{}) error {
+	existingBlock, err := s.getBlock(db, blockID)
+	if err != nil {
+		return err
+	}
+
+	fields := make(map[string]interface{}, len(existingBlock.Fields)+1)
+	for key, value := range existingBlock.Fields {
+		fields[key] = value
+	}
+	fields["properties"] = properties
+
+	fieldsJSON, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	query := s.getQueryBuilder(db).Update(s.tablePrefix+"blocks").
+		Where(sq.Eq{"id": blockID}).
+		Set("fields", fieldsJSON).
+		Set("update_at", utils.GetMillis())
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot save the computed properties of a card", mlog.String("blockID", blockID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
 func (s *SQLStore) patchBlocks(db sq.BaseRunner, blockPatches *model.BlockPatchBatch, userID string) error {
 	for i, blockID := range blockPatches.BlockIDs {
 		err := s.patchBlock(db, blockID, &blockPatches.BlockPatches[i], userID)
@@ -911,6 +944,69 @@ func (s *SQLStore) duplicateBlock(db sq.BaseRunner, boardID string, blockID stri
 	return allBlocks, nil
 }
 
+// moveCardToBoard moves a card and its content blocks to another board, and
+// returns the moved blocks.
+func (s *SQLStore) moveCardToBoard(db sq.BaseRunner, cardID string, boardID string, modifiedBy string) ([]*model.Block, error) {
+	card, err := s.getBlock(db, cardID)
+	if err != nil {
+		return nil, err
+	}
+	if card.Type != model.TypeCard {
+		return nil, model.ErrNotCardBlock
+	}
+
+	children, err := s.getBlocks(db, model.QueryBlocksOptions{BoardID: card.BoardID, ParentID: cardID})
+	if err != nil {
+		return nil, err
+	}
+	blocks := append([]*model.Block{card}, children...)
+
+	blockIDs := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		blockIDs = append(blockIDs, block.ID)
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"blocks").
+		Set("board_id", boardID).
+		Where(sq.Eq{"id": blockIDs})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot move card to board",
+			mlog.String("card_id", cardID),
+			mlog.String("board_id", boardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+
+	// keep the board references of the card records in sync
+	boardReferences := []struct{ table, boardColumn, cardColumn string }{
+		{"card_dependencies", "blocking_board_id", "blocking_card_id"},
+		{"card_dependencies", "blocked_board_id", "blocked_card_id"},
+		{"card_recurrences", "board_id", "card_id"},
+		{"time_entries", "board_id", "card_id"},
+	}
+	for _, ref := range boardReferences {
+		query := s.getQueryBuilder(db).
+			Update(s.tablePrefix+ref.table).
+			Set(ref.boardColumn, boardID).
+			Where(sq.Eq{ref.cardColumn: cardID})
+		if _, err := query.Exec(); err != nil {
+			return nil, err
+		}
+	}
+
+	// saving the blocks again records the move in their history
+	for _, block := range blocks {
+		block.BoardID = boardID
+		if err := s.insertBlock(db, block, modifiedBy); err != nil {
+			return nil, err
+		}
+	}
+	return blocks, nil
+}
+
 func (s *SQLStore) deleteBlockChildren(db sq.BaseRunner, boardID string, parentID string, modifiedBy string) error {
 	now := utils.GetMillis()
 
@@ -0,0 +1,256 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var boardCustomRoleFields = []string{
+	"id",
+	"board_id",
+	"name",
+	"description",
+	"permissions",
+	"property_ids",
+	"created_by",
+	"modified_by",
+	"create_at",
+	"update_at",
+}
+
+func (s *SQLStore) boardCustomRolesFromRows(rows *sql.Rows) ([]*model.BoardCustomRole, error) {
+	roles := []*model.BoardCustomRole{}
+
+	for rows.Next() {
+		var role model.BoardCustomRole
+		var description sql.NullString
+		var permissionsBytes []byte
+		var propertyIDsBytes []byte
+
+		err := rows.Scan(
+			&role.ID,
+			&role.BoardID,
+			&role.Name,
+			&description,
+			&permissionsBytes,
+			&propertyIDsBytes,
+			&role.CreatedBy,
+			&role.ModifiedBy,
+			&role.CreateAt,
+			&role.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		role.Description = description.String
+
+		role.Permissions = []string{}
+		if len(permissionsBytes) > 0 {
+			if err = json.Unmarshal(permissionsBytes, &role.Permissions); err != nil {
+				s.logger.Error("board custom role permissions unmarshal error", mlog.String("role_id", role.ID), mlog.Err(err))
+				return nil, err
+			}
+		}
+		role.PropertyIDs = []string{}
+		if len(propertyIDsBytes) > 0 {
+			if err = json.Unmarshal(propertyIDsBytes, &role.PropertyIDs); err != nil {
+				s.logger.Error("board custom role property ids unmarshal error", mlog.String("role_id", role.ID), mlog.Err(err))
+				return nil, err
+			}
+		}
+
+		roles = append(roles, &role)
+	}
+	return roles, nil
+}
+
+func (s *SQLStore) createBoardCustomRole(db sq.BaseRunner, role *model.BoardCustomRole) (*model.BoardCustomRole, error) {
+	if err := role.IsValid(); err != nil {
+		return nil, err
+	}
+
+	now := model.GetMillis()
+
+	roleAdd := *role
+	if roleAdd.ID == "" {
+		roleAdd.ID = utils.NewID(utils.IDTypeNone)
+	}
+	if roleAdd.Permissions == nil {
+		roleAdd.Permissions = []string{}
+	}
+	if roleAdd.PropertyIDs == nil {
+		roleAdd.PropertyIDs = []string{}
+	}
+	roleAdd.CreateAt = now
+	roleAdd.UpdateAt = now
+
+	permissionsBytes, err := s.MarshalJSONB(roleAdd.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	propertyIDsBytes, err := s.MarshalJSONB(roleAdd.PropertyIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_custom_roles").
+		Columns(boardCustomRoleFields...).
+		Values(
+			roleAdd.ID,
+			roleAdd.BoardID,
+			roleAdd.Name,
+			roleAdd.Description,
+			permissionsBytes,
+			propertyIDsBytes,
+			roleAdd.CreatedBy,
+			roleAdd.ModifiedBy,
+			roleAdd.CreateAt,
+			roleAdd.UpdateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create board custom role", mlog.String("board_id", role.BoardID), mlog.Err(err))
+		return nil, err
+	}
+	return &roleAdd, nil
+}
+
+// updateBoardCustomRole replaces the name, description, permissions and
+// properties of a custom role.
+func (s *SQLStore) updateBoardCustomRole(db sq.BaseRunner, role *model.BoardCustomRole) (*model.BoardCustomRole, error) {
+	if err := role.IsValid(); err != nil {
+		return nil, err
+	}
+
+	permissions := role.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+	propertyIDs := role.PropertyIDs
+	if propertyIDs == nil {
+		propertyIDs = []string{}
+	}
+
+	permissionsBytes, err := s.MarshalJSONB(permissions)
+	if err != nil {
+		return nil, err
+	}
+	propertyIDsBytes, err := s.MarshalJSONB(propertyIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"board_custom_roles").
+		Set("name", role.Name).
+		Set("description", role.Description).
+		Set("permissions", permissionsBytes).
+		Set("property_ids", propertyIDsBytes).
+		Set("modified_by", role.ModifiedBy).
+		Set("update_at", model.GetMillis()).
+		Where(sq.Eq{"id": role.ID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("Cannot update board custom role", mlog.String("role_id", role.ID), mlog.Err(err))
+		return nil, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, model.NewErrNotFound("board custom role ID=" + role.ID)
+	}
+
+	return s.getBoardCustomRole(db, role.ID)
+}
+
+func (s *SQLStore) getBoardCustomRole(db sq.BaseRunner, id string) (*model.BoardCustomRole, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardCustomRoleFields...).
+		From(s.tablePrefix + "board_custom_roles").
+		Where(sq.Eq{"id": id})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch board custom role", mlog.String("role_id", id), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	roles, err := s.boardCustomRolesFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(roles) == 0 {
+		return nil, model.NewErrNotFound("board custom role ID=" + id)
+	}
+	return roles[0], nil
+}
+
+// getBoardCustomRolesForBoard returns the custom roles of a board, sorted by
+// name.
+func (s *SQLStore) getBoardCustomRolesForBoard(db sq.BaseRunner, boardID string) ([]*model.BoardCustomRole, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardCustomRoleFields...).
+		From(s.tablePrefix+"board_custom_roles").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("name", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch board custom roles", mlog.String("board_id", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardCustomRolesFromRows(rows)
+}
+
+// deleteBoardCustomRole deletes a custom role, and unassigns it from the
+// members of its board, who are left with their scheme roles.
+func (s *SQLStore) deleteBoardCustomRole(db sq.BaseRunner, id string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "board_custom_roles").
+		Where(sq.Eq{"id": id})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return model.NewErrNotFound("board custom role ID=" + id)
+	}
+
+	// the members are unassigned in the same transaction, so that no member
+	// is left with a role that no longer exists
+	membersQuery := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"board_members").
+		Set("custom_role_id", "").
+		Where(sq.Eq{"custom_role_id": id})
+
+	if _, err := membersQuery.Exec(); err != nil {
+		return fmt.Errorf("cannot unassign board custom role: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var boardRuleFields = []string{
+	"id",
+	"board_id",
+	"title",
+	"enabled",
+	"rule_trigger",
+	"actions",
+	"created_by",
+	"modified_by",
+	"create_at",
+	"update_at",
+}
+
+func (s *SQLStore) boardRulesFromRows(rows *sql.Rows) ([]*model.BoardRule, error) {
+	rules := []*model.BoardRule{}
+
+	for rows.Next() {
+		var rule model.BoardRule
+		var triggerBytes []byte
+		var actionsBytes []byte
+
+		err := rows.Scan(
+			&rule.ID,
+			&rule.BoardID,
+			&rule.Title,
+			&rule.Enabled,
+			&triggerBytes,
+			&actionsBytes,
+			&rule.CreatedBy,
+			&rule.ModifiedBy,
+			&rule.CreateAt,
+			&rule.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = json.Unmarshal(triggerBytes, &rule.Trigger); err != nil {
+			s.logger.Error("board rule trigger unmarshal error", mlog.String("rule_id", rule.ID), mlog.Err(err))
+			return nil, err
+		}
+		if err = json.Unmarshal(actionsBytes, &rule.Actions); err != nil {
+			s.logger.Error("board rule actions unmarshal error", mlog.String("rule_id", rule.ID), mlog.Err(err))
+			return nil, err
+		}
+
+		rules = append(rules, &rule)
+	}
+	return rules, nil
+}
+
+func (s *SQLStore) createBoardRule(db sq.BaseRunner, rule *model.BoardRule) (*model.BoardRule, error) {
+	if err := rule.IsValid(); err != nil {
+		return nil, err
+	}
+
+	triggerBytes, err := s.MarshalJSONB(rule.Trigger)
+	if err != nil {
+		return nil, err
+	}
+	actionsBytes, err := s.MarshalJSONB(rule.Actions)
+	if err != nil {
+		return nil, err
+	}
+
+	now := model.GetMillis()
+
+	ruleAdd := *rule
+	ruleAdd.CreateAt = now
+	ruleAdd.UpdateAt = now
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_rules").
+		Columns(boardRuleFields...).
+		Values(
+			ruleAdd.ID,
+			ruleAdd.BoardID,
+			ruleAdd.Title,
+			ruleAdd.Enabled,
+			triggerBytes,
+			actionsBytes,
+			ruleAdd.CreatedBy,
+			ruleAdd.ModifiedBy,
+			ruleAdd.CreateAt,
+			ruleAdd.UpdateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create board rule", mlog.String("board_id", rule.BoardID), mlog.Err(err))
+		return nil, err
+	}
+	return &ruleAdd, nil
+}
+
+// updateBoardRule replaces the title, state, trigger and actions of an
+// existing rule.
+func (s *SQLStore) updateBoardRule(db sq.BaseRunner, rule *model.BoardRule) (*model.BoardRule, error) {
+	if err := rule.IsValid(); err != nil {
+		return nil, err
+	}
+
+	triggerBytes, err := s.MarshalJSONB(rule.Trigger)
+	if err != nil {
+		return nil, err
+	}
+	actionsBytes, err := s.MarshalJSONB(rule.Actions)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"board_rules").
+		Set("title", rule.Title).
+		Set("enabled", rule.Enabled).
+		Set("rule_trigger", triggerBytes).
+		Set("actions", actionsBytes).
+		Set("modified_by", rule.ModifiedBy).
+		Set("update_at", model.GetMillis()).
+		Where(sq.Eq{"id": rule.ID}).
+		Where(sq.Eq{"board_id": rule.BoardID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("Cannot update board rule", mlog.String("rule_id", rule.ID), mlog.Err(err))
+		return nil, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if count == 0 {
+		return nil, model.NewErrNotFound("board rule ID=" + rule.ID)
+	}
+
+	return s.getBoardRule(db, rule.ID)
+}
+
+func (s *SQLStore) getBoardRule(db sq.BaseRunner, id string) (*model.BoardRule, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardRuleFields...).
+		From(s.tablePrefix + "board_rules").
+		Where(sq.Eq{"id": id})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch board rule", mlog.String("rule_id", id), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	rules, err := s.boardRulesFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rules) == 0 {
+		return nil, model.NewErrNotFound("board rule ID=" + id)
+	}
+	return rules[0], nil
+}
+
+// getBoardRulesForBoard returns the rules of a board in the order they are
+// evaluated, oldest first.
+func (s *SQLStore) getBoardRulesForBoard(db sq.BaseRunner, boardID string) ([]*model.BoardRule, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardRuleFields...).
+		From(s.tablePrefix+"board_rules").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch board rules", mlog.String("board_id", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardRulesFromRows(rows)
+}
+
+func (s *SQLStore) deleteBoardRule(db sq.BaseRunner, id string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "board_rules").
+		Where(sq.Eq{"id": id})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return model.NewErrNotFound("board rule ID=" + id)
+	}
+	return nil
+}
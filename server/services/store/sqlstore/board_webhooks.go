@@ -0,0 +1,382 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var boardWebhookFields = []string{
+	"id",
+	"board_id",
+	"url",
+	"secret",
+	"events",
+	"enabled",
+	"created_by",
+	"create_at",
+	"update_at",
+}
+
+var webhookDeliveryFields = []string{
+	"id",
+	"webhook_id",
+	"board_id",
+	"event",
+	"payload",
+	"status",
+	"attempts",
+	"next_attempt_at",
+	"last_attempt_at",
+	"response_code",
+	"error_message",
+	"create_at",
+	"update_at",
+}
+
+func (s *SQLStore) boardWebhooksFromRows(rows *sql.Rows) ([]*model.BoardWebhook, error) {
+	webhooks := []*model.BoardWebhook{}
+
+	for rows.Next() {
+		var webhook model.BoardWebhook
+		var eventsBytes []byte
+
+		err := rows.Scan(
+			&webhook.ID,
+			&webhook.BoardID,
+			&webhook.URL,
+			&webhook.Secret,
+			&eventsBytes,
+			&webhook.Enabled,
+			&webhook.CreatedBy,
+			&webhook.CreateAt,
+			&webhook.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = json.Unmarshal(eventsBytes, &webhook.Events); err != nil {
+			s.logger.Error("board webhook events unmarshal error", mlog.String("webhook_id", webhook.ID), mlog.Err(err))
+			return nil, err
+		}
+
+		webhooks = append(webhooks, &webhook)
+	}
+	return webhooks, nil
+}
+
+func (s *SQLStore) webhookDeliveriesFromRows(rows *sql.Rows) ([]*model.WebhookDelivery, error) {
+	deliveries := []*model.WebhookDelivery{}
+
+	for rows.Next() {
+		var delivery model.WebhookDelivery
+		var errorMessage sql.NullString
+
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.BoardID,
+			&delivery.Event,
+			&delivery.Payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&delivery.LastAttemptAt,
+			&delivery.ResponseCode,
+			&errorMessage,
+			&delivery.CreateAt,
+			&delivery.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		delivery.Error = errorMessage.String
+
+		deliveries = append(deliveries, &delivery)
+	}
+	return deliveries, nil
+}
+
+func (s *SQLStore) createBoardWebhook(db sq.BaseRunner, webhook *model.BoardWebhook) (*model.BoardWebhook, error) {
+	if err := webhook.IsValid(); err != nil {
+		return nil, err
+	}
+
+	eventsBytes, err := s.MarshalJSONB(webhook.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	now := model.GetMillis()
+
+	webhookAdd := *webhook
+	webhookAdd.CreateAt = now
+	webhookAdd.UpdateAt = now
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_webhooks").
+		Columns(boardWebhookFields...).
+		Values(
+			webhookAdd.ID,
+			webhookAdd.BoardID,
+			webhookAdd.URL,
+			webhookAdd.Secret,
+			eventsBytes,
+			webhookAdd.Enabled,
+			webhookAdd.CreatedBy,
+			webhookAdd.CreateAt,
+			webhookAdd.UpdateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create board webhook", mlog.String("board_id", webhook.BoardID), mlog.Err(err))
+		return nil, err
+	}
+	return &webhookAdd, nil
+}
+
+// updateBoardWebhook replaces the URL, events and state of an existing
+// webhook. Its secret cannot be changed.
+func (s *SQLStore) updateBoardWebhook(db sq.BaseRunner, webhook *model.BoardWebhook) (*model.BoardWebhook, error) {
+	if err := webhook.IsValid(); err != nil {
+		return nil, err
+	}
+
+	eventsBytes, err := s.MarshalJSONB(webhook.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"board_webhooks").
+		Set("url", webhook.URL).
+		Set("events", eventsBytes).
+		Set("enabled", webhook.Enabled).
+		Set("update_at", model.GetMillis()).
+		Where(sq.Eq{"id": webhook.ID}).
+		Where(sq.Eq{"board_id": webhook.BoardID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("Cannot update board webhook", mlog.String("webhook_id", webhook.ID), mlog.Err(err))
+		return nil, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if count == 0 {
+		return nil, model.NewErrNotFound("board webhook ID=" + webhook.ID)
+	}
+
+	return s.getBoardWebhook(db, webhook.ID)
+}
+
+func (s *SQLStore) getBoardWebhook(db sq.BaseRunner, id string) (*model.BoardWebhook, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardWebhookFields...).
+		From(s.tablePrefix + "board_webhooks").
+		Where(sq.Eq{"id": id})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch board webhook", mlog.String("webhook_id", id), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	webhooks, err := s.boardWebhooksFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(webhooks) == 0 {
+		return nil, model.NewErrNotFound("board webhook ID=" + id)
+	}
+	return webhooks[0], nil
+}
+
+func (s *SQLStore) getBoardWebhooksForBoard(db sq.BaseRunner, boardID string) ([]*model.BoardWebhook, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardWebhookFields...).
+		From(s.tablePrefix+"board_webhooks").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch board webhooks", mlog.String("board_id", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardWebhooksFromRows(rows)
+}
+
+// deleteBoardWebhook deletes a webhook along with its delivery log and its
+// pending deliveries.
+func (s *SQLStore) deleteBoardWebhook(db sq.BaseRunner, id string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "board_webhooks").
+		Where(sq.Eq{"id": id})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return model.NewErrNotFound("board webhook ID=" + id)
+	}
+
+	deliveriesQuery := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "webhook_deliveries").
+		Where(sq.Eq{"webhook_id": id})
+
+	if _, err := deliveriesQuery.Exec(); err != nil {
+		s.logger.Error("Cannot delete webhook deliveries", mlog.String("webhook_id", id), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) insertWebhookDelivery(db sq.BaseRunner, delivery *model.WebhookDelivery) error {
+	now := model.GetMillis()
+	delivery.CreateAt = now
+	delivery.UpdateAt = now
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"webhook_deliveries").
+		Columns(webhookDeliveryFields...).
+		Values(
+			delivery.ID,
+			delivery.WebhookID,
+			delivery.BoardID,
+			delivery.Event,
+			delivery.Payload,
+			delivery.Status,
+			delivery.Attempts,
+			delivery.NextAttemptAt,
+			delivery.LastAttemptAt,
+			delivery.ResponseCode,
+			delivery.Error,
+			delivery.CreateAt,
+			delivery.UpdateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot insert webhook delivery", mlog.String("webhook_id", delivery.WebhookID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+// updateWebhookDelivery saves the outcome of the last attempt of a delivery.
+func (s *SQLStore) updateWebhookDelivery(db sq.BaseRunner, delivery *model.WebhookDelivery) error {
+	delivery.UpdateAt = model.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"webhook_deliveries").
+		Set("status", delivery.Status).
+		Set("attempts", delivery.Attempts).
+		Set("next_attempt_at", delivery.NextAttemptAt).
+		Set("last_attempt_at", delivery.LastAttemptAt).
+		Set("response_code", delivery.ResponseCode).
+		Set("error_message", delivery.Error).
+		Set("update_at", delivery.UpdateAt).
+		Where(sq.Eq{"id": delivery.ID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot update webhook delivery", mlog.String("delivery_id", delivery.ID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+// getWebhookDeliveries returns the delivery log of a webhook, newest first.
+func (s *SQLStore) getWebhookDeliveries(db sq.BaseRunner, webhookID string, page int, perPage int) ([]*model.WebhookDelivery, error) {
+	query := s.getQueryBuilder(db).
+		Select(webhookDeliveryFields...).
+		From(s.tablePrefix+"webhook_deliveries").
+		Where(sq.Eq{"webhook_id": webhookID}).
+		OrderBy("create_at DESC", "id DESC")
+
+	if page != 0 {
+		query = query.Offset(offset(page, perPage))
+	}
+
+	if perPage > 0 {
+		query = query.Limit(limit(perPage))
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch webhook deliveries", mlog.String("webhook_id", webhookID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.webhookDeliveriesFromRows(rows)
+}
+
+// getDueWebhookDeliveries returns the pending deliveries that should be
+// attempted before the given time, oldest first.
+func (s *SQLStore) getDueWebhookDeliveries(db sq.BaseRunner, until int64, maxDeliveries int) ([]*model.WebhookDelivery, error) {
+	query := s.getQueryBuilder(db).
+		Select(webhookDeliveryFields...).
+		From(s.tablePrefix+"webhook_deliveries").
+		Where(sq.Eq{"status": model.WebhookDeliveryPending}).
+		Where(sq.LtOrEq{"next_attempt_at": until}).
+		OrderBy("next_attempt_at", "id").
+		Limit(limit(maxDeliveries))
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch due webhook deliveries", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.webhookDeliveriesFromRows(rows)
+}
+
+// claimWebhookDelivery postpones the next attempt of a pending delivery to
+// the given time while it is being attempted. It only succeeds if the delivery
+// has not been claimed already, so that when several servers process the same
+// due delivery only one of them posts it.
+func (s *SQLStore) claimWebhookDelivery(db sq.BaseRunner, delivery *model.WebhookDelivery, until int64) (bool, error) {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"webhook_deliveries").
+		Set("next_attempt_at", until).
+		Set("update_at", model.GetMillis()).
+		Where(sq.Eq{"id": delivery.ID}).
+		Where(sq.Eq{"status": model.WebhookDeliveryPending}).
+		Where(sq.Eq{"next_attempt_at": delivery.NextAttemptAt})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("Cannot claim webhook delivery", mlog.String("delivery_id", delivery.ID), mlog.Err(err))
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return count == 1, nil
+}
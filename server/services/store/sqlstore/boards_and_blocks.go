@@ -177,10 +177,40 @@ func (s *SQLStore) duplicateBoard(db sq.BaseRunner, boardID string, userID strin
 	}
 	bab.Blocks = newBlocks
 
+	dependencies, err := s.getCardDependenciesForBoard(db, boardID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the blocks keep their identity while their ids are regenerated, so
+	// the old ids are kept to carry the card dependencies over
+	oldIDs := make(map[*model.Block]string, len(newBlocks))
+	for _, b := range newBlocks {
+		oldIDs[b] = b.ID
+	}
+
 	bab, err = model.GenerateBoardsAndBlocksIDs(bab, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return s.createBoardsAndBlocksWithAdmin(db, bab, userID)
+	cardIDs := map[string]string{}
+	for _, b := range bab.Blocks {
+		if b.Type == model.TypeCard {
+			cardIDs[oldIDs[b]] = b.ID
+		}
+	}
+
+	bab, members, err := s.createBoardsAndBlocksWithAdmin(db, bab, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, dependency := range model.RemapCardDependencies(dependencies, cardIDs, bab.Boards[0].ID) {
+		if _, err := s.createCardDependency(db, dependency); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return bab, members, nil
 }
@@ -0,0 +1,173 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func cardDependencyFields(prefix string) []string {
+	fields := []string{
+		"id",
+		"blocking_card_id",
+		"blocking_board_id",
+		"blocked_card_id",
+		"blocked_board_id",
+		"created_by",
+		"create_at",
+	}
+
+	if prefix == "" {
+		return fields
+	}
+
+	prefixedFields := make([]string, len(fields))
+	for i, field := range fields {
+		prefixedFields[i] = prefix + field
+	}
+	return prefixedFields
+}
+
+func (s *SQLStore) cardDependenciesFromRows(rows *sql.Rows) ([]*model.CardDependency, error) {
+	dependencies := []*model.CardDependency{}
+
+	for rows.Next() {
+		var dependency model.CardDependency
+		err := rows.Scan(
+			&dependency.ID,
+			&dependency.BlockingCardID,
+			&dependency.BlockingBoardID,
+			&dependency.BlockedCardID,
+			&dependency.BlockedBoardID,
+			&dependency.CreatedBy,
+			&dependency.CreateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		dependencies = append(dependencies, &dependency)
+	}
+	return dependencies, nil
+}
+
+func (s *SQLStore) createCardDependency(db sq.BaseRunner, dependency *model.CardDependency) (*model.CardDependency, error) {
+	if err := dependency.IsValid(); err != nil {
+		return nil, err
+	}
+
+	dependencyAdd := *dependency
+	if dependencyAdd.CreateAt == 0 {
+		dependencyAdd.CreateAt = model.GetMillis()
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"card_dependencies").
+		Columns(cardDependencyFields("")...).
+		Values(
+			dependencyAdd.ID,
+			dependencyAdd.BlockingCardID,
+			dependencyAdd.BlockingBoardID,
+			dependencyAdd.BlockedCardID,
+			dependencyAdd.BlockedBoardID,
+			dependencyAdd.CreatedBy,
+			dependencyAdd.CreateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create card dependency",
+			mlog.String("blocking_card_id", dependency.BlockingCardID),
+			mlog.String("blocked_card_id", dependency.BlockedCardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	return &dependencyAdd, nil
+}
+
+func (s *SQLStore) getCardDependency(db sq.BaseRunner, id string) (*model.CardDependency, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardDependencyFields("")...).
+		From(s.tablePrefix + "card_dependencies").
+		Where(sq.Eq{"id": id})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch card dependency", mlog.String("id", id), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	dependencies, err := s.cardDependenciesFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(dependencies) == 0 {
+		return nil, model.NewErrNotFound("card dependency ID=" + id)
+	}
+	return dependencies[0], nil
+}
+
+// getCardDependenciesByCondition returns the dependencies that match the conditions
+// and whose cards both exist, so the dependencies of deleted cards are left out.
+func (s *SQLStore) getCardDependenciesByCondition(db sq.BaseRunner, conditions ...interface{}) ([]*model.CardDependency, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardDependencyFields("d.")...).
+		From(s.tablePrefix+"card_dependencies AS d").
+		Join(s.tablePrefix+"blocks AS b1 ON b1.id = d.blocking_card_id").
+		Join(s.tablePrefix+"blocks AS b2 ON b2.id = d.blocked_card_id").
+		OrderBy("d.create_at", "d.id")
+	for _, c := range conditions {
+		query = query.Where(c)
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getCardDependenciesByCondition ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.cardDependenciesFromRows(rows)
+}
+
+func (s *SQLStore) getCardDependenciesForCard(db sq.BaseRunner, cardID string) ([]*model.CardDependency, error) {
+	return s.getCardDependenciesByCondition(db, sq.Or{
+		sq.Eq{"d.blocking_card_id": cardID},
+		sq.Eq{"d.blocked_card_id": cardID},
+	})
+}
+
+func (s *SQLStore) getCardDependenciesForBoard(db sq.BaseRunner, boardID string) ([]*model.CardDependency, error) {
+	return s.getCardDependenciesByCondition(db, sq.Or{
+		sq.Eq{"d.blocking_board_id": boardID},
+		sq.Eq{"d.blocked_board_id": boardID},
+	})
+}
+
+func (s *SQLStore) deleteCardDependency(db sq.BaseRunner, id string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "card_dependencies").
+		Where(sq.Eq{"id": id})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return model.NewErrNotFound("card dependency ID=" + id)
+	}
+	return nil
+}
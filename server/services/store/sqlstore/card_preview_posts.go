@@ -0,0 +1,108 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// saveCardPreviewPost records the card previewed by a post, replacing the
+// card previously previewed by the post, if any.
+func (s *SQLStore) saveCardPreviewPost(db sq.BaseRunner, previewPost *model.CardPreviewPost) error {
+	if previewPost.CreateAt == 0 {
+		previewPost.CreateAt = utils.GetMillis()
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"card_preview_posts").
+		Columns("post_id", "card_id", "create_at").
+		Values(previewPost.PostID, previewPost.CardID, previewPost.CreateAt)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE card_id = ?, create_at = ?", previewPost.CardID, previewPost.CreateAt)
+	} else {
+		query = query.Suffix("ON CONFLICT (post_id) DO UPDATE SET card_id = ?, create_at = ?", previewPost.CardID, previewPost.CreateAt)
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot save card preview post",
+			mlog.String("post_id", previewPost.PostID),
+			mlog.String("card_id", previewPost.CardID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// getCardPreviewPostIDs returns the ids of the posts previewing a card.
+func (s *SQLStore) getCardPreviewPostIDs(db sq.BaseRunner, cardID string) ([]string, error) {
+	query := s.getQueryBuilder(db).
+		Select("post_id").
+		From(s.tablePrefix+"card_preview_posts").
+		Where(sq.Eq{"card_id": cardID}).
+		OrderBy("create_at", "post_id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch card preview posts", mlog.String("card_id", cardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	postIDs := []string{}
+	for rows.Next() {
+		var postID string
+		if err := rows.Scan(&postID); err != nil {
+			return nil, err
+		}
+		postIDs = append(postIDs, postID)
+	}
+	return postIDs, nil
+}
+
+// getCardPreviewPostIDsForBoard returns the ids of the posts previewing the
+// cards of a board.
+func (s *SQLStore) getCardPreviewPostIDsForBoard(db sq.BaseRunner, boardID string) ([]string, error) {
+	query := s.getQueryBuilder(db).
+		Select("p.post_id").
+		From(s.tablePrefix+"card_preview_posts AS p").
+		Join(s.tablePrefix+"blocks AS b ON b.id = p.card_id").
+		Where(sq.Eq{"b.board_id": boardID}).
+		OrderBy("p.create_at", "p.post_id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch card preview posts", mlog.String("board_id", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	postIDs := []string{}
+	for rows.Next() {
+		var postID string
+		if err := rows.Scan(&postID); err != nil {
+			return nil, err
+		}
+		postIDs = append(postIDs, postID)
+	}
+	return postIDs, nil
+}
+
+// deleteCardPreviewPost forgets the card previewed by a post.
+func (s *SQLStore) deleteCardPreviewPost(db sq.BaseRunner, postID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "card_preview_posts").
+		Where(sq.Eq{"post_id": postID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot delete card preview post", mlog.String("post_id", postID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
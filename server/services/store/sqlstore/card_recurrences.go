@@ -0,0 +1,205 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var cardRecurrenceFields = []string{
+	"card_id",
+	"board_id",
+	"recurrence_rule",
+	"start_at",
+	"next_run_at",
+	"last_run_at",
+	"created_by",
+	"create_at",
+	"update_at",
+}
+
+func (s *SQLStore) cardRecurrencesFromRows(rows *sql.Rows) ([]*model.CardRecurrence, error) {
+	recurrences := []*model.CardRecurrence{}
+
+	for rows.Next() {
+		var recurrence model.CardRecurrence
+		err := rows.Scan(
+			&recurrence.CardID,
+			&recurrence.BoardID,
+			&recurrence.Rule,
+			&recurrence.StartAt,
+			&recurrence.NextRunAt,
+			&recurrence.LastRunAt,
+			&recurrence.CreatedBy,
+			&recurrence.CreateAt,
+			&recurrence.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		recurrences = append(recurrences, &recurrence)
+	}
+	return recurrences, nil
+}
+
+// saveCardRecurrence creates the recurrence of a card, or replaces the rule and
+// schedule of its existing recurrence.
+func (s *SQLStore) saveCardRecurrence(db sq.BaseRunner, recurrence *model.CardRecurrence) (*model.CardRecurrence, error) {
+	if err := recurrence.IsValid(); err != nil {
+		return nil, err
+	}
+
+	now := model.GetMillis()
+
+	recurrenceAdd := *recurrence
+	recurrenceAdd.CreateAt = now
+	recurrenceAdd.UpdateAt = now
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"card_recurrences").
+		Columns(cardRecurrenceFields...).
+		Values(
+			recurrenceAdd.CardID,
+			recurrenceAdd.BoardID,
+			recurrenceAdd.Rule,
+			recurrenceAdd.StartAt,
+			recurrenceAdd.NextRunAt,
+			recurrenceAdd.LastRunAt,
+			recurrenceAdd.CreatedBy,
+			recurrenceAdd.CreateAt,
+			recurrenceAdd.UpdateAt,
+		)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE recurrence_rule = ?, start_at = ?, next_run_at = ?, created_by = ?, update_at = ?",
+			recurrenceAdd.Rule, recurrenceAdd.StartAt, recurrenceAdd.NextRunAt, recurrenceAdd.CreatedBy, now)
+	} else {
+		query = query.Suffix("ON CONFLICT (card_id) DO UPDATE SET recurrence_rule = ?, start_at = ?, next_run_at = ?, created_by = ?, update_at = ?",
+			recurrenceAdd.Rule, recurrenceAdd.StartAt, recurrenceAdd.NextRunAt, recurrenceAdd.CreatedBy, now)
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot save card recurrence",
+			mlog.String("card_id", recurrence.CardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+
+	return s.getCardRecurrence(db, recurrence.CardID)
+}
+
+func (s *SQLStore) getCardRecurrence(db sq.BaseRunner, cardID string) (*model.CardRecurrence, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardRecurrenceFields...).
+		From(s.tablePrefix + "card_recurrences").
+		Where(sq.Eq{"card_id": cardID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch card recurrence", mlog.String("card_id", cardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	recurrences, err := s.cardRecurrencesFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(recurrences) == 0 {
+		return nil, model.NewErrNotFound("card recurrence CardID=" + cardID)
+	}
+	return recurrences[0], nil
+}
+
+func (s *SQLStore) getCardRecurrencesForBoard(db sq.BaseRunner, boardID string) ([]*model.CardRecurrence, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardRecurrenceFields...).
+		From(s.tablePrefix+"card_recurrences").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at", "card_id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch card recurrences for board", mlog.String("board_id", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.cardRecurrencesFromRows(rows)
+}
+
+// getDueCardRecurrences returns the recurrences whose next run is at or before
+// the given time, oldest first.
+func (s *SQLStore) getDueCardRecurrences(db sq.BaseRunner, until int64) ([]*model.CardRecurrence, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardRecurrenceFields...).
+		From(s.tablePrefix+"card_recurrences").
+		Where(sq.LtOrEq{"next_run_at": until}).
+		OrderBy("next_run_at", "card_id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch due card recurrences", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.cardRecurrencesFromRows(rows)
+}
+
+// advanceCardRecurrence marks the current run of the recurrence as done and
+// schedules the next one. It only succeeds if the run has not been advanced
+// already, so that when several servers process the same due recurrence only
+// one of them duplicates the card.
+func (s *SQLStore) advanceCardRecurrence(db sq.BaseRunner, recurrence *model.CardRecurrence, nextRunAt int64) (bool, error) {
+	now := model.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"card_recurrences").
+		Set("next_run_at", nextRunAt).
+		Set("last_run_at", now).
+		Set("update_at", now).
+		Where(sq.Eq{"card_id": recurrence.CardID}).
+		Where(sq.Eq{"next_run_at": recurrence.NextRunAt})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("Cannot advance card recurrence", mlog.String("card_id", recurrence.CardID), mlog.Err(err))
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return count == 1, nil
+}
+
+func (s *SQLStore) deleteCardRecurrence(db sq.BaseRunner, cardID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "card_recurrences").
+		Where(sq.Eq{"card_id": cardID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return model.NewErrNotFound("card recurrence CardID=" + cardID)
+	}
+	return nil
+}
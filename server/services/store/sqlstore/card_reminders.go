@@ -0,0 +1,192 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var cardReminderSettingsFields = []string{
+	"board_id",
+	"due_date_property_id",
+	"hours_before",
+	"modified_by",
+	"update_at",
+}
+
+func (s *SQLStore) cardReminderSettingsFromRows(rows *sql.Rows) ([]*model.CardReminderSettings, error) {
+	allSettings := []*model.CardReminderSettings{}
+
+	for rows.Next() {
+		var settings model.CardReminderSettings
+		err := rows.Scan(
+			&settings.BoardID,
+			&settings.DueDatePropertyID,
+			&settings.HoursBefore,
+			&settings.ModifiedBy,
+			&settings.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		allSettings = append(allSettings, &settings)
+	}
+	return allSettings, nil
+}
+
+// saveCardReminderSettings creates or replaces the reminder settings of a
+// board.
+func (s *SQLStore) saveCardReminderSettings(db sq.BaseRunner, settings *model.CardReminderSettings) (*model.CardReminderSettings, error) {
+	if err := settings.IsValid(); err != nil {
+		return nil, err
+	}
+
+	now := model.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"card_reminder_settings").
+		Columns(cardReminderSettingsFields...).
+		Values(
+			settings.BoardID,
+			settings.DueDatePropertyID,
+			settings.HoursBefore,
+			settings.ModifiedBy,
+			now,
+		)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE due_date_property_id = ?, hours_before = ?, modified_by = ?, update_at = ?",
+			settings.DueDatePropertyID, settings.HoursBefore, settings.ModifiedBy, now)
+	} else {
+		query = query.Suffix("ON CONFLICT (board_id) DO UPDATE SET due_date_property_id = ?, hours_before = ?, modified_by = ?, update_at = ?",
+			settings.DueDatePropertyID, settings.HoursBefore, settings.ModifiedBy, now)
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot save card reminder settings",
+			mlog.String("board_id", settings.BoardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+
+	return s.getCardReminderSettings(db, settings.BoardID)
+}
+
+func (s *SQLStore) getCardReminderSettings(db sq.BaseRunner, boardID string) (*model.CardReminderSettings, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardReminderSettingsFields...).
+		From(s.tablePrefix + "card_reminder_settings").
+		Where(sq.Eq{"board_id": boardID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch card reminder settings", mlog.String("board_id", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	allSettings, err := s.cardReminderSettingsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(allSettings) == 0 {
+		return nil, model.NewErrNotFound("card reminder settings BoardID=" + boardID)
+	}
+	return allSettings[0], nil
+}
+
+// getAllCardReminderSettings returns the reminder settings of every board.
+func (s *SQLStore) getAllCardReminderSettings(db sq.BaseRunner) ([]*model.CardReminderSettings, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardReminderSettingsFields...).
+		From(s.tablePrefix + "card_reminder_settings").
+		OrderBy("board_id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch all card reminder settings", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.cardReminderSettingsFromRows(rows)
+}
+
+func (s *SQLStore) deleteCardReminderSettings(db sq.BaseRunner, boardID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "card_reminder_settings").
+		Where(sq.Eq{"board_id": boardID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return model.NewErrNotFound("card reminder settings BoardID=" + boardID)
+	}
+	return nil
+}
+
+// createCardReminder records a reminder about to be sent. It returns false
+// when the reminder has already been recorded, by an earlier run or by
+// another node of the cluster, in which case it must not be sent again.
+func (s *SQLStore) createCardReminder(db sq.BaseRunner, reminder *model.CardReminder) (bool, error) {
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"card_reminders").
+		Columns("card_id", "user_id", "kind", "due_at", "board_id", "sent_at").
+		Values(
+			reminder.CardID,
+			reminder.UserID,
+			reminder.Kind,
+			reminder.DueAt,
+			reminder.BoardID,
+			reminder.SentAt,
+		)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE card_id = card_id")
+	} else {
+		query = query.Suffix("ON CONFLICT (card_id, user_id, kind, due_at) DO NOTHING")
+	}
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("Cannot record card reminder",
+			mlog.String("card_id", reminder.CardID),
+			mlog.String("user_id", reminder.UserID),
+			mlog.Err(err),
+		)
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return count == 1, nil
+}
+
+// deleteCardRemindersDueBefore deletes the reminders of the due dates that
+// are too old to be reminded of again.
+func (s *SQLStore) deleteCardRemindersDueBefore(db sq.BaseRunner, dueAt int64) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "card_reminders").
+		Where(sq.Lt{"due_at": dueAt})
+
+	_, err := query.Exec()
+	return err
+}
@@ -0,0 +1,299 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// cardSearchMaxCandidates caps the number of blocks loaded by each query of a
+// card search, the most relevant or most recently updated first, so that a
+// broad term does not load whole boards in memory to rank and paginate them.
+const cardSearchMaxCandidates = 500
+
+// likeEscaper escapes the wildcards of the LIKE patterns built from search
+// words, with "!" as the escape character as the backslash is itself an
+// escape character in MySQL string literals.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// containsPattern returns the LIKE pattern matching the texts that contain a
+// word, to be used with ESCAPE '!'.
+func containsPattern(word string) string {
+	return "%" + likeEscaper.Replace(word) + "%"
+}
+
+// searchCards returns the cards of a set of boards whose title, text properties,
+// content blocks or comments contain the search term, ordered by rank.
+// Postgres uses its full text search features to match and rank titles, while
+// the other databases require every word of the term to be contained in the title.
+// The results are ranked among at most cardSearchMaxCandidates title and
+// property matches.
+func (s *SQLStore) searchCards(db sq.BaseRunner, opts model.CardSearchOptions) ([]*model.CardSearchResult, error) {
+	words := opts.SearchWords()
+	if len(opts.BoardIDs) == 0 || len(words) == 0 {
+		return []*model.CardSearchResult{}, nil
+	}
+
+	hits, err := s.searchBlockTitles(db, opts, words)
+	if err != nil {
+		return nil, err
+	}
+
+	// property values are stored in the card fields, so cards with a property
+	// value containing the term are candidates to be checked against the
+	// board schema
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields("")...).
+		From(s.tablePrefix+"blocks").
+		Where(sq.Eq{
+			"board_id": opts.BoardIDs,
+			"type":     model.TypeCard,
+		}).
+		Where(s.propertyValuesContain(words)).
+		OrderBy("update_at DESC", "id").
+		Limit(cardSearchMaxCandidates)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`searchCards ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	cardBlocks, err := s.blocksFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	cardBlocksByID := make(map[string]*model.Block, len(cardBlocks))
+	for _, block := range cardBlocks {
+		cardBlocksByID[block.ID] = block
+	}
+
+	missingIDs := []string{}
+	for cardID := range hits {
+		if _, ok := cardBlocksByID[cardID]; !ok {
+			missingIDs = append(missingIDs, cardID)
+		}
+	}
+	if len(missingIDs) > 0 {
+		query = s.getQueryBuilder(db).
+			Select(s.blockFields("")...).
+			From(s.tablePrefix + "blocks").
+			Where(sq.Eq{
+				"id":       missingIDs,
+				"board_id": opts.BoardIDs,
+				"type":     model.TypeCard,
+			})
+
+		rows, err := query.Query()
+		if err != nil {
+			s.logger.Error(`searchCards ERROR`, mlog.Err(err))
+			return nil, err
+		}
+		defer s.CloseRows(rows)
+
+		blocks, err := s.blocksFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		for _, block := range blocks {
+			cardBlocksByID[block.ID] = block
+		}
+	}
+
+	if len(cardBlocksByID) == 0 {
+		return []*model.CardSearchResult{}, nil
+	}
+
+	schemas, err := s.getPropertySchemas(db, cardBlocksByID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*model.CardSearchResult, 0, len(cardBlocksByID))
+	for _, block := range cardBlocksByID {
+		card, err := model.Block2Card(block)
+		if err != nil {
+			return nil, fmt.Errorf("searchCards cannot convert block %s to card: %w", block.ID, err)
+		}
+		if card.IsTemplate {
+			continue
+		}
+
+		result := &model.CardSearchResult{Card: card, Matches: []string{}}
+		for _, hit := range hits[card.ID] {
+			result.AddMatch(hit.match, hit.rank)
+		}
+
+		schema := schemas[card.BoardID]
+		for propID, value := range card.Properties {
+			propDef, ok := schema[propID]
+			if !ok || !model.IsTextPropType(propDef.Type) {
+				continue
+			}
+			if text, ok := value.(string); ok && model.ContainsAllWords(text, words) {
+				result.AddMatch(model.CardSearchMatchProperty, 1)
+				break
+			}
+		}
+
+		if len(result.Matches) > 0 {
+			results = append(results, result)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Rank != b.Rank {
+			return a.Rank > b.Rank
+		}
+		if a.Card.UpdateAt != b.Card.UpdateAt {
+			return a.Card.UpdateAt > b.Card.UpdateAt
+		}
+		return a.Card.ID < b.Card.ID
+	})
+
+	if opts.PerPage > 0 {
+		start := int(offset(opts.Page, opts.PerPage))
+		if start >= len(results) {
+			return []*model.CardSearchResult{}, nil
+		}
+		end := start + opts.PerPage
+		if end > len(results) {
+			end = len(results)
+		}
+		results = results[start:end]
+	}
+
+	return results, nil
+}
+
+type cardSearchHit struct {
+	match string
+	rank  float64
+}
+
+// searchBlockTitles matches the term against the titles of cards, content blocks and
+// comments, returning the hits keyed by card id.
+func (s *SQLStore) searchBlockTitles(db sq.BaseRunner, opts model.CardSearchOptions, words []string) (map[string][]cardSearchHit, error) {
+	query := s.getQueryBuilder(db).
+		Select("id", "parent_id", "type").
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{
+			"board_id": opts.BoardIDs,
+			"type":     []string{string(model.TypeCard), string(model.TypeText), string(model.TypeComment)},
+		})
+
+	if s.dbType == model.PostgresDBType {
+		query = query.
+			Column(sq.Alias(sq.Expr("ts_rank(to_tsvector('english', title), plainto_tsquery('english', ?))", opts.Term), "search_rank")).
+			Where("to_tsvector('english', title) @@ plainto_tsquery('english', ?)", opts.Term).
+			OrderBy("search_rank DESC", "update_at DESC", "id")
+	} else {
+		query = query.
+			Column("1").
+			OrderBy("update_at DESC", "id")
+		for _, word := range words {
+			query = query.Where("lower(title) LIKE ? ESCAPE '!'", containsPattern(word))
+		}
+	}
+	query = query.Limit(cardSearchMaxCandidates)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`searchBlockTitles ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	hits := map[string][]cardSearchHit{}
+	for rows.Next() {
+		var id, parentID string
+		var blockType model.BlockType
+		var rank float64
+
+		if err := rows.Scan(&id, &parentID, &blockType, &rank); err != nil {
+			s.logger.Error(`searchBlockTitles ERROR`, mlog.Err(err))
+			return nil, err
+		}
+
+		switch blockType {
+		case model.TypeCard:
+			hits[id] = append(hits[id], cardSearchHit{match: model.CardSearchMatchTitle, rank: rank})
+		case model.TypeText:
+			hits[parentID] = append(hits[parentID], cardSearchHit{match: model.CardSearchMatchContent, rank: rank})
+		case model.TypeComment:
+			hits[parentID] = append(hits[parentID], cardSearchHit{match: model.CardSearchMatchComment, rank: rank})
+		}
+	}
+
+	return hits, nil
+}
+
+// getPropertySchemas returns the property schemas of the boards of a set of cards, keyed by board id.
+func (s *SQLStore) getPropertySchemas(db sq.BaseRunner, cardBlocks map[string]*model.Block) (map[string]model.PropSchema, error) {
+	boardIDs := []string{}
+	seen := map[string]bool{}
+	for _, block := range cardBlocks {
+		if !seen[block.BoardID] {
+			seen[block.BoardID] = true
+			boardIDs = append(boardIDs, block.BoardID)
+		}
+	}
+
+	boards, err := s.getBoardsByCondition(db, sq.Eq{"id": boardIDs})
+	if err != nil && !model.IsErrNotFound(err) {
+		return nil, err
+	}
+
+	schemas := make(map[string]model.PropSchema, len(boards))
+	for _, board := range boards {
+		schema, err := model.ParsePropertySchema(board)
+		if err != nil {
+			return nil, err
+		}
+		schemas[board.ID] = schema
+	}
+	return schemas, nil
+}
+
+// propertyValuesContain returns the condition matching the cards with a
+// property value that contains every word. MySQL cannot iterate over the
+// values of a JSON object before version 8, so there each word only has to be
+// contained in one of the values, and the cards are checked against the words
+// once loaded.
+func (s *SQLStore) propertyValuesContain(words []string) sq.Sqlizer {
+	var values string
+	switch s.dbType {
+	case model.PostgresDBType:
+		values = "json_each_text(CASE WHEN json_typeof(fields->'properties') = 'object' THEN fields->'properties' END)"
+	case model.SqliteDBType:
+		values = "json_each(CASE WHEN json_valid(fields) THEN fields ELSE '{}' END, '$.properties')"
+	default:
+		conditions := sq.And{}
+		for _, word := range words {
+			conditions = append(conditions, sq.Expr(
+				"JSON_SEARCH(lower(JSON_EXTRACT(CASE WHEN JSON_VALID(fields) THEN fields END, '$.properties')), 'one', ?, '!') IS NOT NULL",
+				containsPattern(word),
+			))
+		}
+		return conditions
+	}
+
+	conditions := make([]string, 0, len(words))
+	args := make([]interface{}, 0, len(words))
+	for _, word := range words {
+		conditions = append(conditions, "lower(p.value) LIKE ? ESCAPE '!'")
+		args = append(args, containsPattern(word))
+	}
+	return sq.Expr("EXISTS (SELECT 1 FROM "+values+" AS p WHERE "+strings.Join(conditions, " AND ")+")", args...)
+}
@@ -0,0 +1,323 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var channelFeedSettingsFields = []string{
+	"board_id",
+	"events",
+	"status_property_id",
+	"done_option_ids",
+	"window_minutes",
+	"modified_by",
+	"update_at",
+}
+
+var channelFeedItemFields = []string{
+	"id",
+	"board_id",
+	"card_id",
+	"event",
+	"old_value",
+	"new_value",
+	"modified_by",
+	"notify_at",
+	"create_at",
+}
+
+func (s *SQLStore) channelFeedSettingsFromRows(rows *sql.Rows) ([]*model.ChannelFeedSettings, error) {
+	allSettings := []*model.ChannelFeedSettings{}
+
+	for rows.Next() {
+		var settings model.ChannelFeedSettings
+		var eventsBytes []byte
+		var statusPropertyID sql.NullString
+		var doneOptionIDsBytes []byte
+
+		err := rows.Scan(
+			&settings.BoardID,
+			&eventsBytes,
+			&statusPropertyID,
+			&doneOptionIDsBytes,
+			&settings.WindowMinutes,
+			&settings.ModifiedBy,
+			&settings.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		settings.StatusPropertyID = statusPropertyID.String
+
+		if err = json.Unmarshal(eventsBytes, &settings.Events); err != nil {
+			s.logger.Error("channel feed events unmarshal error", mlog.String("board_id", settings.BoardID), mlog.Err(err))
+			return nil, err
+		}
+		settings.DoneOptionIDs = []string{}
+		if len(doneOptionIDsBytes) > 0 {
+			if err = json.Unmarshal(doneOptionIDsBytes, &settings.DoneOptionIDs); err != nil {
+				s.logger.Error("channel feed done options unmarshal error", mlog.String("board_id", settings.BoardID), mlog.Err(err))
+				return nil, err
+			}
+		}
+
+		allSettings = append(allSettings, &settings)
+	}
+	return allSettings, nil
+}
+
+func (s *SQLStore) channelFeedItemsFromRows(rows *sql.Rows) ([]*model.ChannelFeedItem, error) {
+	items := []*model.ChannelFeedItem{}
+
+	for rows.Next() {
+		var item model.ChannelFeedItem
+		var oldValue, newValue sql.NullString
+
+		err := rows.Scan(
+			&item.ID,
+			&item.BoardID,
+			&item.CardID,
+			&item.Event,
+			&oldValue,
+			&newValue,
+			&item.ModifiedBy,
+			&item.NotifyAt,
+			&item.CreateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		item.OldValue = oldValue.String
+		item.NewValue = newValue.String
+		items = append(items, &item)
+	}
+	return items, nil
+}
+
+// saveChannelFeedSettings creates or replaces the channel feed settings of a
+// board.
+func (s *SQLStore) saveChannelFeedSettings(db sq.BaseRunner, settings *model.ChannelFeedSettings) (*model.ChannelFeedSettings, error) {
+	if err := settings.IsValid(); err != nil {
+		return nil, err
+	}
+
+	eventsBytes, err := s.MarshalJSONB(settings.Events)
+	if err != nil {
+		return nil, err
+	}
+	doneOptionIDsBytes, err := s.MarshalJSONB(settings.DoneOptionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := model.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"channel_feed_settings").
+		Columns(channelFeedSettingsFields...).
+		Values(
+			settings.BoardID,
+			eventsBytes,
+			settings.StatusPropertyID,
+			doneOptionIDsBytes,
+			settings.WindowMinutes,
+			settings.ModifiedBy,
+			now,
+		)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE events = ?, status_property_id = ?, done_option_ids = ?, window_minutes = ?, modified_by = ?, update_at = ?",
+			eventsBytes, settings.StatusPropertyID, doneOptionIDsBytes, settings.WindowMinutes, settings.ModifiedBy, now)
+	} else {
+		query = query.Suffix("ON CONFLICT (board_id) DO UPDATE SET events = ?, status_property_id = ?, done_option_ids = ?, window_minutes = ?, modified_by = ?, update_at = ?",
+			eventsBytes, settings.StatusPropertyID, doneOptionIDsBytes, settings.WindowMinutes, settings.ModifiedBy, now)
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot save channel feed settings",
+			mlog.String("board_id", settings.BoardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+
+	return s.getChannelFeedSettings(db, settings.BoardID)
+}
+
+func (s *SQLStore) getChannelFeedSettings(db sq.BaseRunner, boardID string) (*model.ChannelFeedSettings, error) {
+	query := s.getQueryBuilder(db).
+		Select(channelFeedSettingsFields...).
+		From(s.tablePrefix + "channel_feed_settings").
+		Where(sq.Eq{"board_id": boardID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch channel feed settings", mlog.String("board_id", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	allSettings, err := s.channelFeedSettingsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(allSettings) == 0 {
+		return nil, model.NewErrNotFound("channel feed settings BoardID=" + boardID)
+	}
+	return allSettings[0], nil
+}
+
+// deleteChannelFeedSettings turns off the channel feed of a board, dropping
+// the changes waiting to be posted.
+func (s *SQLStore) deleteChannelFeedSettings(db sq.BaseRunner, boardID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "channel_feed_settings").
+		Where(sq.Eq{"board_id": boardID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return model.NewErrNotFound("channel feed settings BoardID=" + boardID)
+	}
+
+	itemsQuery := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "channel_feed_items").
+		Where(sq.Eq{"board_id": boardID})
+
+	if _, err := itemsQuery.Exec(); err != nil {
+		return fmt.Errorf("cannot delete channel feed items: %w", err)
+	}
+	return nil
+}
+
+// addChannelFeedItem queues a change to a card for the channel feed of its
+// board.
+func (s *SQLStore) addChannelFeedItem(db sq.BaseRunner, item *model.ChannelFeedItem) error {
+	if item.ID == "" {
+		item.ID = utils.NewID(utils.IDTypeNone)
+	}
+	if item.CreateAt == 0 {
+		item.CreateAt = utils.GetMillis()
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"channel_feed_items").
+		Columns(channelFeedItemFields...).
+		Values(
+			item.ID,
+			item.BoardID,
+			item.CardID,
+			item.Event,
+			item.OldValue,
+			item.NewValue,
+			item.ModifiedBy,
+			item.NotifyAt,
+			item.CreateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot add channel feed item",
+			mlog.String("board_id", item.BoardID),
+			mlog.String("card_id", item.CardID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// getDueChannelFeedBoardIDs returns the ids of the boards with changes due to
+// be posted at the given time.
+func (s *SQLStore) getDueChannelFeedBoardIDs(db sq.BaseRunner, notifyAt int64) ([]string, error) {
+	query := s.getQueryBuilder(db).
+		Select("DISTINCT board_id").
+		From(s.tablePrefix + "channel_feed_items").
+		Where(sq.LtOrEq{"notify_at": notifyAt}).
+		OrderBy("board_id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch due channel feeds", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	boardIDs := []string{}
+	for rows.Next() {
+		var boardID string
+		if err := rows.Scan(&boardID); err != nil {
+			return nil, err
+		}
+		boardIDs = append(boardIDs, boardID)
+	}
+	return boardIDs, nil
+}
+
+// takeChannelFeedItems returns and removes every change waiting for the
+// channel feed of a board, oldest first. When another node of the cluster
+// took them concurrently no item is returned, so that they are posted once.
+func (s *SQLStore) takeChannelFeedItems(db sq.BaseRunner, boardID string) ([]*model.ChannelFeedItem, error) {
+	selectQuery := s.getQueryBuilder(db).
+		Select(channelFeedItemFields...).
+		From(s.tablePrefix+"channel_feed_items").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at", "id")
+
+	rows, err := selectQuery.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch channel feed items", mlog.String("board_id", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	items, err := s.channelFeedItemsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return items, nil
+	}
+
+	itemIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		itemIDs = append(itemIDs, item.ID)
+	}
+
+	deleteQuery := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "channel_feed_items").
+		Where(sq.Eq{"id": itemIDs})
+
+	result, err := deleteQuery.Exec()
+	if err != nil {
+		return nil, fmt.Errorf("cannot delete while taking channel feed items: %w", err)
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("cannot verify delete while taking channel feed items: %w", err)
+	}
+	if count == 0 {
+		// another node has taken the items concurrently; let that node post them.
+		return []*model.ChannelFeedItem{}, nil
+	}
+	return items, nil
+}
@@ -3,7 +3,6 @@
 package sqlstore
 
 import (
-	"database/sql"
 	"strings"
 	"time"
 
@@ -20,13 +19,55 @@ type RetentionTableDeletionInfo struct {
 	Table         string
 	PrimaryKeys   []string
 	BoardIDColumn string
+	// CardIDColumn is set instead of BoardIDColumn for the tables that only
+	// reference the cards of the boards, which must then be deleted before
+	// the blocks.
+	CardIDColumn string
 }
 
-func (s *SQLStore) runDataRetention(db sq.BaseRunner, globalRetentionDate int64, batchSize int64) (int64, error) {
+// boardDataRetentionTables are the tables holding the data attached to the
+// boards and cards, besides their blocks, history and members.
+var boardDataRetentionTables = []RetentionTableDeletionInfo{
+	{Table: "card_preview_posts", PrimaryKeys: []string{"post_id"}, CardIDColumn: "card_id"},
+	{Table: "share_links", PrimaryKeys: []string{"id"}, BoardIDColumn: "board_id"},
+	{Table: "share_link_accesses", PrimaryKeys: []string{"id"}, BoardIDColumn: "board_id"},
+	{Table: "guest_comments", PrimaryKeys: []string{"id"}, BoardIDColumn: "board_id"},
+	{Table: "board_custom_roles", PrimaryKeys: []string{"id"}, BoardIDColumn: "board_id"},
+	{Table: "board_rules", PrimaryKeys: []string{"id"}, BoardIDColumn: "board_id"},
+	{Table: "board_webhooks", PrimaryKeys: []string{"id"}, BoardIDColumn: "board_id"},
+	{Table: "webhook_deliveries", PrimaryKeys: []string{"id"}, BoardIDColumn: "board_id"},
+	{Table: "incoming_webhooks", PrimaryKeys: []string{"id"}, BoardIDColumn: "board_id"},
+	{Table: "notification_digest_items", PrimaryKeys: []string{"board_id"}, BoardIDColumn: "board_id"},
+	{Table: "card_reminder_settings", PrimaryKeys: []string{"board_id"}, BoardIDColumn: "board_id"},
+	{Table: "card_reminders", PrimaryKeys: []string{"board_id"}, BoardIDColumn: "board_id"},
+	{Table: "card_recurrences", PrimaryKeys: []string{"card_id"}, BoardIDColumn: "board_id"},
+	{Table: "card_dependencies", PrimaryKeys: []string{"id"}, BoardIDColumn: "blocking_board_id"},
+	{Table: "card_dependencies", PrimaryKeys: []string{"id"}, BoardIDColumn: "blocked_board_id"},
+	{Table: "time_entries", PrimaryKeys: []string{"id"}, BoardIDColumn: "board_id"},
+	{Table: "iterations", PrimaryKeys: []string{"id"}, BoardIDColumn: "board_id"},
+	{Table: "channel_feed_settings", PrimaryKeys: []string{"board_id"}, BoardIDColumn: "board_id"},
+	{Table: "channel_feed_items", PrimaryKeys: []string{"id"}, BoardIDColumn: "board_id"},
+	{Table: "retention_policies", PrimaryKeys: []string{"id"}, BoardIDColumn: "board_id"},
+}
+
+// retentionWhereClause returns the condition selecting the rows of a table
+// that belong to the boards to delete.
+func (s *SQLStore) retentionWhereClause(info RetentionTableDeletionInfo, deleteIds []string) string {
+	boardIDs := "('" + strings.Join(deleteIds, "','") + "')"
+	if info.CardIDColumn != "" {
+		return info.CardIDColumn + " IN (SELECT id FROM " + s.tablePrefix + "blocks WHERE board_id IN " + boardIDs + ")"
+	}
+	return info.BoardIDColumn + " IN " + boardIDs
+}
+
+func (s *SQLStore) runDataRetention(db sq.BaseRunner, cutoffs *model.RetentionCutoffs, batchSize int64) (int64, error) {
 	s.logger.Info("Start Boards Data Retention",
-		mlog.String("Global Retention Date", time.Unix(globalRetentionDate/1000, 0).String()),
-		mlog.Int("Raw Date", globalRetentionDate))
-	deleteTables := []RetentionTableDeletionInfo{
+		mlog.String("Global Retention Date", time.Unix(cutoffs.Global/1000, 0).String()),
+		mlog.Int("Raw Date", cutoffs.Global),
+		mlog.Int("Team Policies", len(cutoffs.Teams)),
+		mlog.Int("Board Policies", len(cutoffs.Boards)))
+	deleteTables := append([]RetentionTableDeletionInfo{}, boardDataRetentionTables...)
+	deleteTables = append(deleteTables, []RetentionTableDeletionInfo{
 		{
 			Table:         "blocks",
 			PrimaryKeys:   []string{"id"},
@@ -67,30 +108,9 @@ func (s *SQLStore) runDataRetention(db sq.BaseRunner, globalRetentionDate int64,
 			PrimaryKeys:   []string{"id"},
 			BoardIDColumn: "board_id",
 		},
-	}
-
-	subBuilder := s.getQueryBuilder(db).
-		Select("board_id, MAX(update_at) AS maxDate").
-		From(s.tablePrefix + "blocks").
-		GroupBy("board_id")
-
-	subQuery, _, _ := subBuilder.ToSql()
-
-	builder := s.getQueryBuilder(db).
-		Select("id").
-		From(s.tablePrefix + "boards").
-		LeftJoin("( " + subQuery + " ) As subquery ON (subquery.board_id = id)").
-		Where(sq.Lt{"maxDate": globalRetentionDate}).
-		Where(sq.NotEq{"team_id": "0"}).
-		Where(sq.Eq{"is_template": false})
+	}...)
 
-	rows, err := builder.Query()
-	if err != nil {
-		s.logger.Error(`dataRetention subquery ERROR`, mlog.Err(err))
-		return 0, err
-	}
-	defer s.CloseRows(rows)
-	deleteIds, err := idsFromRows(rows)
+	deleteIds, err := s.getBoardIDsForRetention(db, cutoffs)
 	if err != nil {
 		return 0, err
 	}
@@ -111,17 +131,115 @@ func (s *SQLStore) runDataRetention(db sq.BaseRunner, globalRetentionDate int64,
 	return int64(totalAffected), nil
 }
 
-func idsFromRows(rows *sql.Rows) ([]string, error) {
+// getDataRetentionPreview counts the rows a data retention run would delete
+// with the given cutoffs, without deleting them.
+func (s *SQLStore) getDataRetentionPreview(db sq.BaseRunner, cutoffs *model.RetentionCutoffs) (*model.RetentionPreview, error) {
+	boardIDs, err := s.getBoardIDsForRetention(db, cutoffs)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &model.RetentionPreview{
+		BoardCount: int64(len(boardIDs)),
+	}
+	if len(boardIDs) == 0 {
+		return preview, nil
+	}
+
+	if preview.BlockCount, err = s.countRowsForBoards(db, "blocks", "board_id", boardIDs); err != nil {
+		return nil, err
+	}
+
+	blocksHistoryCount, err := s.countRowsForBoards(db, "blocks_history", "board_id", boardIDs)
+	if err != nil {
+		return nil, err
+	}
+	boardsHistoryCount, err := s.countRowsForBoards(db, "boards_history", "id", boardIDs)
+	if err != nil {
+		return nil, err
+	}
+	preview.HistoryCount = blocksHistoryCount + boardsHistoryCount
+
+	for _, info := range boardDataRetentionTables {
+		query := s.getQueryBuilder(db).
+			Select("COUNT(*)").
+			From(s.tablePrefix + info.Table).
+			Where(s.retentionWhereClause(info, boardIDs))
+
+		var count int64
+		if err := query.QueryRow().Scan(&count); err != nil {
+			s.logger.Error("Cannot count rows for data retention", mlog.String("table", info.Table), mlog.Err(err))
+			return nil, err
+		}
+		preview.DataCount += count
+	}
+
+	return preview, nil
+}
+
+func (s *SQLStore) countRowsForBoards(db sq.BaseRunner, table, boardIDColumn string, boardIDs []string) (int64, error) {
+	query := s.getQueryBuilder(db).
+		Select("COUNT(*)").
+		From(s.tablePrefix + table).
+		Where(sq.Eq{boardIDColumn: boardIDs})
+
+	var count int64
+	if err := query.QueryRow().Scan(&count); err != nil {
+		s.logger.Error("Cannot count rows for data retention", mlog.String("table", table), mlog.Err(err))
+		return 0, err
+	}
+	return count, nil
+}
+
+// getBoardIDsForRetention returns the ids of the non template boards whose
+// last block update is before their retention cutoff.
+func (s *SQLStore) getBoardIDsForRetention(db sq.BaseRunner, cutoffs *model.RetentionCutoffs) ([]string, error) {
+	// no board can be deleted after the latest cutoff
+	var latestCutoff int64
+	for _, cutoff := range cutoffs.Teams {
+		latestCutoff = max(latestCutoff, cutoff)
+	}
+	for _, cutoff := range cutoffs.Boards {
+		latestCutoff = max(latestCutoff, cutoff)
+	}
+	latestCutoff = max(latestCutoff, cutoffs.Global)
+	if latestCutoff == 0 {
+		return []string{}, nil
+	}
+
+	subBuilder := s.getQueryBuilder(db).
+		Select("board_id, MAX(update_at) AS maxDate").
+		From(s.tablePrefix + "blocks").
+		GroupBy("board_id")
+
+	subQuery, _, _ := subBuilder.ToSql()
+
+	builder := s.getQueryBuilder(db).
+		Select("id", "team_id", "maxDate").
+		From(s.tablePrefix + "boards").
+		LeftJoin("( " + subQuery + " ) As subquery ON (subquery.board_id = id)").
+		Where(sq.Lt{"maxDate": latestCutoff}).
+		Where(sq.NotEq{"team_id": "0"}).
+		Where(sq.Eq{"is_template": false})
+
+	rows, err := builder.Query()
+	if err != nil {
+		s.logger.Error(`dataRetention subquery ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
 	deleteIds := []string{}
 	for rows.Next() {
-		var boardID string
-		err := rows.Scan(
-			&boardID,
-		)
-		if err != nil {
+		var boardID, teamID string
+		var maxDate int64
+		if err := rows.Scan(&boardID, &teamID, &maxDate); err != nil {
 			return nil, err
 		}
-		deleteIds = append(deleteIds, boardID)
+
+		if cutoff := cutoffs.CutoffForBoard(teamID, boardID); cutoff != 0 && maxDate < cutoff {
+			deleteIds = append(deleteIds, boardID)
+		}
 	}
 	return deleteIds, nil
 }
@@ -134,7 +252,7 @@ func (s *SQLStore) genericRetentionPoliciesDeletion(
 	deleteIds []string,
 	batchSize int64,
 ) (int64, error) {
-	whereClause := info.BoardIDColumn + " IN ('" + strings.Join(deleteIds, "','") + "')"
+	whereClause := s.retentionWhereClause(info, deleteIds)
 	deleteQuery := s.getQueryBuilder(db).
 		Delete(s.tablePrefix + info.Table).
 		Where(whereClause)
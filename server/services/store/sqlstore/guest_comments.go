@@ -0,0 +1,238 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var guestCommentFields = []string{
+	"id",
+	"board_id",
+	"card_id",
+	"share_link_id",
+	"author_name",
+	"text",
+	"status",
+	"remote_addr",
+	"comment_id",
+	"moderated_by",
+	"create_at",
+	"update_at",
+}
+
+func guestCommentsFromRows(rows *sql.Rows) ([]*model.GuestComment, error) {
+	comments := []*model.GuestComment{}
+
+	for rows.Next() {
+		var comment model.GuestComment
+
+		err := rows.Scan(
+			&comment.ID,
+			&comment.BoardID,
+			&comment.CardID,
+			&comment.ShareLinkID,
+			&comment.AuthorName,
+			&comment.Text,
+			&comment.Status,
+			&comment.RemoteAddr,
+			&comment.CommentID,
+			&comment.ModeratedBy,
+			&comment.CreateAt,
+			&comment.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, &comment)
+	}
+	return comments, nil
+}
+
+// createGuestComment inserts a guest comment, unless limit comments were
+// submitted from the same address through the same share link since the given
+// time, in which case ErrTooManyRequests is returned. The share link is locked
+// while the comments are counted, so that concurrent submissions cannot exceed
+// the limit. SQLite does not support row locks, and relies on its writes being
+// serialized instead.
+func (s *SQLStore) createGuestComment(db sq.BaseRunner, comment *model.GuestComment, limit int, since int64) (*model.GuestComment, error) {
+	if err := comment.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if s.dbType != model.SqliteDBType {
+		lockQuery := s.getQueryBuilder(db).
+			Select("id").
+			From(s.tablePrefix + "share_links").
+			Where(sq.Eq{"id": comment.ShareLinkID}).
+			Suffix("FOR UPDATE")
+
+		rows, err := lockQuery.Query()
+		if err != nil {
+			s.logger.Error("Cannot lock share link", mlog.String("share_link_id", comment.ShareLinkID), mlog.Err(err))
+			return nil, err
+		}
+		s.CloseRows(rows)
+	}
+
+	count, err := s.countGuestCommentsSince(db, comment.ShareLinkID, comment.RemoteAddr, since)
+	if err != nil {
+		return nil, err
+	}
+	if count >= limit {
+		return nil, fmt.Errorf("cannot submit more guest comments: %w", model.ErrTooManyRequests)
+	}
+
+	now := model.GetMillis()
+
+	commentAdd := *comment
+	commentAdd.CreateAt = now
+	commentAdd.UpdateAt = now
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"guest_comments").
+		Columns(guestCommentFields...).
+		Values(
+			commentAdd.ID,
+			commentAdd.BoardID,
+			commentAdd.CardID,
+			commentAdd.ShareLinkID,
+			commentAdd.AuthorName,
+			commentAdd.Text,
+			commentAdd.Status,
+			commentAdd.RemoteAddr,
+			commentAdd.CommentID,
+			commentAdd.ModeratedBy,
+			commentAdd.CreateAt,
+			commentAdd.UpdateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create guest comment", mlog.String("guest_comment_id", comment.ID), mlog.Err(err))
+		return nil, err
+	}
+	return &commentAdd, nil
+}
+
+// updateGuestCommentStatus records the moderation of a guest comment. The
+// comment is only updated if it is still pending, and false is returned
+// otherwise, so that a comment cannot be moderated twice.
+func (s *SQLStore) updateGuestCommentStatus(db sq.BaseRunner, comment *model.GuestComment) (bool, error) {
+	if err := comment.IsValid(); err != nil {
+		return false, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"guest_comments").
+		Set("status", comment.Status).
+		Set("comment_id", comment.CommentID).
+		Set("moderated_by", comment.ModeratedBy).
+		Set("update_at", model.GetMillis()).
+		Where(sq.Eq{"id": comment.ID}).
+		Where(sq.Eq{"status": model.GuestCommentPending})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("Cannot update guest comment", mlog.String("guest_comment_id", comment.ID), mlog.Err(err))
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// resetGuestCommentApproval sets an approved guest comment back to pending,
+// when the comment block of its approval cannot be added to its card.
+func (s *SQLStore) resetGuestCommentApproval(db sq.BaseRunner, comment *model.GuestComment) error {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"guest_comments").
+		Set("status", model.GuestCommentPending).
+		Set("comment_id", "").
+		Set("moderated_by", "").
+		Set("update_at", model.GetMillis()).
+		Where(sq.Eq{"id": comment.ID}).
+		Where(sq.Eq{"status": model.GuestCommentApproved}).
+		Where(sq.Eq{"comment_id": comment.CommentID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot reset guest comment approval", mlog.String("guest_comment_id", comment.ID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) getGuestComment(db sq.BaseRunner, id string) (*model.GuestComment, error) {
+	query := s.getQueryBuilder(db).
+		Select(guestCommentFields...).
+		From(s.tablePrefix + "guest_comments").
+		Where(sq.Eq{"id": id})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch guest comment", mlog.String("guest_comment_id", id), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	comments, err := guestCommentsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(comments) == 0 {
+		return nil, model.NewErrNotFound("guest comment ID=" + id)
+	}
+	return comments[0], nil
+}
+
+// getGuestCommentsForBoard returns the guest comments of a board with the
+// given status, or with any status if empty, oldest first.
+func (s *SQLStore) getGuestCommentsForBoard(db sq.BaseRunner, boardID string, status string) ([]*model.GuestComment, error) {
+	query := s.getQueryBuilder(db).
+		Select(guestCommentFields...).
+		From(s.tablePrefix+"guest_comments").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at", "id")
+
+	if status != "" {
+		query = query.Where(sq.Eq{"status": status})
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch guest comments for board", mlog.String("board_id", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return guestCommentsFromRows(rows)
+}
+
+// countGuestCommentsSince returns the number of comments submitted through a
+// share link from an address since the given time.
+func (s *SQLStore) countGuestCommentsSince(db sq.BaseRunner, shareLinkID string, remoteAddr string, since int64) (int, error) {
+	query := s.getQueryBuilder(db).
+		Select("COUNT(*)").
+		From(s.tablePrefix + "guest_comments").
+		Where(sq.Eq{"share_link_id": shareLinkID}).
+		Where(sq.Eq{"remote_addr": remoteAddr}).
+		Where(sq.GtOrEq{"create_at": since})
+
+	var count int
+	if err := query.QueryRow().Scan(&count); err != nil {
+		s.logger.Error("Cannot count guest comments", mlog.String("share_link_id", shareLinkID), mlog.Err(err))
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var incomingWebhookFields = []string{
+	"id",
+	"board_id",
+	"title",
+	"token",
+	"enabled",
+	"created_by",
+	"create_at",
+	"update_at",
+}
+
+func (s *SQLStore) incomingWebhooksFromRows(rows *sql.Rows) ([]*model.IncomingWebhook, error) {
+	webhooks := []*model.IncomingWebhook{}
+
+	for rows.Next() {
+		var webhook model.IncomingWebhook
+		var title sql.NullString
+
+		err := rows.Scan(
+			&webhook.ID,
+			&webhook.BoardID,
+			&title,
+			&webhook.Token,
+			&webhook.Enabled,
+			&webhook.CreatedBy,
+			&webhook.CreateAt,
+			&webhook.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		webhook.Title = title.String
+
+		webhooks = append(webhooks, &webhook)
+	}
+	return webhooks, nil
+}
+
+func (s *SQLStore) createIncomingWebhook(db sq.BaseRunner, webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	if err := webhook.IsValid(); err != nil {
+		return nil, err
+	}
+
+	now := model.GetMillis()
+
+	webhookAdd := *webhook
+	webhookAdd.CreateAt = now
+	webhookAdd.UpdateAt = now
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"incoming_webhooks").
+		Columns(incomingWebhookFields...).
+		Values(
+			webhookAdd.ID,
+			webhookAdd.BoardID,
+			webhookAdd.Title,
+			webhookAdd.Token,
+			webhookAdd.Enabled,
+			webhookAdd.CreatedBy,
+			webhookAdd.CreateAt,
+			webhookAdd.UpdateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create incoming webhook", mlog.String("board_id", webhook.BoardID), mlog.Err(err))
+		return nil, err
+	}
+	return &webhookAdd, nil
+}
+
+// updateIncomingWebhook replaces the title, token and state of an existing
+// webhook.
+func (s *SQLStore) updateIncomingWebhook(db sq.BaseRunner, webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	if err := webhook.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"incoming_webhooks").
+		Set("title", webhook.Title).
+		Set("token", webhook.Token).
+		Set("enabled", webhook.Enabled).
+		Set("update_at", model.GetMillis()).
+		Where(sq.Eq{"id": webhook.ID}).
+		Where(sq.Eq{"board_id": webhook.BoardID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("Cannot update incoming webhook", mlog.String("webhook_id", webhook.ID), mlog.Err(err))
+		return nil, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if count == 0 {
+		return nil, model.NewErrNotFound("incoming webhook ID=" + webhook.ID)
+	}
+
+	return s.getIncomingWebhook(db, webhook.ID)
+}
+
+func (s *SQLStore) getIncomingWebhook(db sq.BaseRunner, id string) (*model.IncomingWebhook, error) {
+	query := s.getQueryBuilder(db).
+		Select(incomingWebhookFields...).
+		From(s.tablePrefix + "incoming_webhooks").
+		Where(sq.Eq{"id": id})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch incoming webhook", mlog.String("webhook_id", id), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	webhooks, err := s.incomingWebhooksFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(webhooks) == 0 {
+		return nil, model.NewErrNotFound("incoming webhook ID=" + id)
+	}
+	return webhooks[0], nil
+}
+
+func (s *SQLStore) getIncomingWebhooksForBoard(db sq.BaseRunner, boardID string) ([]*model.IncomingWebhook, error) {
+	query := s.getQueryBuilder(db).
+		Select(incomingWebhookFields...).
+		From(s.tablePrefix+"incoming_webhooks").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch incoming webhooks", mlog.String("board_id", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.incomingWebhooksFromRows(rows)
+}
+
+func (s *SQLStore) deleteIncomingWebhook(db sq.BaseRunner, id string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "incoming_webhooks").
+		Where(sq.Eq{"id": id})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("Cannot delete incoming webhook", mlog.String("webhook_id", id), mlog.Err(err))
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return model.NewErrNotFound("incoming webhook ID=" + id)
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var iterationFields = []string{
+	"id",
+	"board_id",
+	"name",
+	"goal",
+	"start_at",
+	"end_at",
+	"state",
+	"status_property_id",
+	"done_option_ids",
+	"estimate_property_id",
+	"created_by",
+	"started_at",
+	"closed_at",
+	"create_at",
+	"update_at",
+}
+
+func (s *SQLStore) iterationsFromRows(rows *sql.Rows) ([]*model.Iteration, error) {
+	iterations := []*model.Iteration{}
+
+	for rows.Next() {
+		var iteration model.Iteration
+		var goal sql.NullString
+		var statusPropertyID sql.NullString
+		var doneOptionIDsBytes []byte
+		var estimatePropertyID sql.NullString
+
+		err := rows.Scan(
+			&iteration.ID,
+			&iteration.BoardID,
+			&iteration.Name,
+			&goal,
+			&iteration.StartAt,
+			&iteration.EndAt,
+			&iteration.State,
+			&statusPropertyID,
+			&doneOptionIDsBytes,
+			&estimatePropertyID,
+			&iteration.CreatedBy,
+			&iteration.StartedAt,
+			&iteration.ClosedAt,
+			&iteration.CreateAt,
+			&iteration.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		iteration.Goal = goal.String
+		iteration.StatusPropertyID = statusPropertyID.String
+		iteration.EstimatePropertyID = estimatePropertyID.String
+
+		iteration.DoneOptionIDs = []string{}
+		if len(doneOptionIDsBytes) != 0 {
+			if err = json.Unmarshal(doneOptionIDsBytes, &iteration.DoneOptionIDs); err != nil {
+				s.logger.Error("iteration done options unmarshal error", mlog.String("iteration_id", iteration.ID), mlog.Err(err))
+				return nil, err
+			}
+		}
+
+		iterations = append(iterations, &iteration)
+	}
+	return iterations, nil
+}
+
+func (s *SQLStore) createIteration(db sq.BaseRunner, iteration *model.Iteration) (*model.Iteration, error) {
+	if err := iteration.IsValid(); err != nil {
+		return nil, err
+	}
+
+	doneOptionIDsBytes, err := s.MarshalJSONB(iteration.DoneOptionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := model.GetMillis()
+
+	iterationAdd := *iteration
+	iterationAdd.CreateAt = now
+	iterationAdd.UpdateAt = now
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"iterations").
+		Columns(iterationFields...).
+		Values(
+			iterationAdd.ID,
+			iterationAdd.BoardID,
+			iterationAdd.Name,
+			iterationAdd.Goal,
+			iterationAdd.StartAt,
+			iterationAdd.EndAt,
+			iterationAdd.State,
+			iterationAdd.StatusPropertyID,
+			doneOptionIDsBytes,
+			iterationAdd.EstimatePropertyID,
+			iterationAdd.CreatedBy,
+			iterationAdd.StartedAt,
+			iterationAdd.ClosedAt,
+			iterationAdd.CreateAt,
+			iterationAdd.UpdateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create iteration", mlog.String("board_id", iteration.BoardID), mlog.Err(err))
+		return nil, err
+	}
+	return &iterationAdd, nil
+}
+
+// updateIteration replaces the name, goal, dates, state and report settings
+// of an existing iteration.
+func (s *SQLStore) updateIteration(db sq.BaseRunner, iteration *model.Iteration) (*model.Iteration, error) {
+	if err := iteration.IsValid(); err != nil {
+		return nil, err
+	}
+
+	doneOptionIDsBytes, err := s.MarshalJSONB(iteration.DoneOptionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"iterations").
+		Set("name", iteration.Name).
+		Set("goal", iteration.Goal).
+		Set("start_at", iteration.StartAt).
+		Set("end_at", iteration.EndAt).
+		Set("state", iteration.State).
+		Set("status_property_id", iteration.StatusPropertyID).
+		Set("done_option_ids", doneOptionIDsBytes).
+		Set("estimate_property_id", iteration.EstimatePropertyID).
+		Set("started_at", iteration.StartedAt).
+		Set("closed_at", iteration.ClosedAt).
+		Set("update_at", model.GetMillis()).
+		Where(sq.Eq{"id": iteration.ID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("Cannot update iteration", mlog.String("iteration_id", iteration.ID), mlog.Err(err))
+		return nil, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if count == 0 {
+		return nil, model.NewErrNotFound("iteration ID=" + iteration.ID)
+	}
+
+	return s.getIteration(db, iteration.ID)
+}
+
+func (s *SQLStore) getIteration(db sq.BaseRunner, id string) (*model.Iteration, error) {
+	query := s.getQueryBuilder(db).
+		Select(iterationFields...).
+		From(s.tablePrefix + "iterations").
+		Where(sq.Eq{"id": id})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch iteration", mlog.String("iteration_id", id), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	iterations, err := s.iterationsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(iterations) == 0 {
+		return nil, model.NewErrNotFound("iteration ID=" + id)
+	}
+	return iterations[0], nil
+}
+
+// getIterationsForBoard returns the iterations of a board, in the order they
+// were created.
+func (s *SQLStore) getIterationsForBoard(db sq.BaseRunner, boardID string) ([]*model.Iteration, error) {
+	query := s.getQueryBuilder(db).
+		Select(iterationFields...).
+		From(s.tablePrefix+"iterations").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch iterations", mlog.String("board_id", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.iterationsFromRows(rows)
+}
+
+func (s *SQLStore) deleteIteration(db sq.BaseRunner, id string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "iterations").
+		Where(sq.Eq{"id": id})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return model.NewErrNotFound("iteration ID=" + id)
+	}
+	return nil
+}
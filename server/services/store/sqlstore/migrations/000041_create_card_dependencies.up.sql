@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}card_dependencies (
+    id VARCHAR(36) NOT NULL,
+    blocking_card_id VARCHAR(36) NOT NULL,
+    blocking_board_id VARCHAR(36) NOT NULL,
+    blocked_card_id VARCHAR(36) NOT NULL,
+    blocked_board_id VARCHAR(36) NOT NULL,
+    created_by VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    PRIMARY KEY (id),
+    CONSTRAINT unique_blocking_blocked_card UNIQUE (blocking_card_id, blocked_card_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "card_dependencies" "blocked_card_id" }}
+{{ createIndexIfNeeded "card_dependencies" "blocking_board_id" }}
+{{ createIndexIfNeeded "card_dependencies" "blocked_board_id" }}
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}board_rules (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    title VARCHAR(255),
+    enabled BOOLEAN,
+    {{if .mysql}}
+    rule_trigger JSON,
+    actions JSON,
+    {{end}}
+    {{if .postgres}}
+    rule_trigger JSONB,
+    actions JSONB,
+    {{end}}
+    {{if .sqlite}}
+    rule_trigger TEXT,
+    actions TEXT,
+    {{end}}
+    created_by VARCHAR(36) NOT NULL,
+    modified_by VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    update_at BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "board_rules" "board_id" }}
@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}board_webhooks (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    url TEXT NOT NULL,
+    secret VARCHAR(64) NOT NULL,
+    {{if .mysql}}
+    events JSON,
+    {{end}}
+    {{if .postgres}}
+    events JSONB,
+    {{end}}
+    {{if .sqlite}}
+    events TEXT,
+    {{end}}
+    enabled BOOLEAN,
+    created_by VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    update_at BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE TABLE IF NOT EXISTS {{.prefix}}webhook_deliveries (
+    id VARCHAR(36) NOT NULL,
+    webhook_id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    event VARCHAR(64) NOT NULL,
+    {{if .mysql}}
+    payload LONGTEXT,
+    {{else}}
+    payload TEXT,
+    {{end}}
+    status VARCHAR(16) NOT NULL,
+    attempts INT,
+    next_attempt_at BIGINT,
+    last_attempt_at BIGINT,
+    response_code INT,
+    error_message TEXT,
+    create_at BIGINT,
+    update_at BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "board_webhooks" "board_id" }}
+{{ createIndexIfNeeded "webhook_deliveries" "webhook_id, create_at" }}
+{{ createIndexIfNeeded "webhook_deliveries" "status, next_attempt_at" }}
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}incoming_webhooks (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    title VARCHAR(255),
+    token VARCHAR(100) NOT NULL,
+    enabled BOOLEAN,
+    created_by VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    update_at BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "incoming_webhooks" "board_id" }}
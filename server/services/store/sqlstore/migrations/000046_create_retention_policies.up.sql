@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}retention_policies (
+    id VARCHAR(36) NOT NULL,
+    team_id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    retention_days INT NOT NULL,
+    {{if .mysql}}
+    exempt_board_ids JSON,
+    {{end}}
+    {{if .postgres}}
+    exempt_board_ids JSONB,
+    {{end}}
+    {{if .sqlite}}
+    exempt_board_ids TEXT,
+    {{end}}
+    created_by VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    update_at BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "retention_policies" "team_id" }}
+{{ createIndexIfNeeded "retention_policies" "board_id" }}
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}share_links (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    token VARCHAR(100) NOT NULL,
+    view_id VARCHAR(36) NOT NULL,
+    expires_at BIGINT NOT NULL DEFAULT 0,
+    password_hash VARCHAR(128) NOT NULL,
+    revoked_at BIGINT NOT NULL DEFAULT 0,
+    created_by VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    update_at BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE TABLE IF NOT EXISTS {{.prefix}}share_link_accesses (
+    id VARCHAR(36) NOT NULL,
+    share_link_id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    remote_addr VARCHAR(255) NOT NULL,
+    user_agent VARCHAR(255) NOT NULL,
+    create_at BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "share_links" "board_id" }}
+{{ createIndexIfNeeded "share_links" "token" }}
+{{ createIndexIfNeeded "share_link_accesses" "share_link_id, create_at" }}
@@ -0,0 +1,22 @@
+{{- /* addColumnIfNeeded tableName columnName datatype constraint */ -}}
+{{ addColumnIfNeeded "share_links" "mode" "varchar(20)" "NOT NULL DEFAULT 'viewer'"}}
+
+CREATE TABLE IF NOT EXISTS {{.prefix}}guest_comments (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    card_id VARCHAR(36) NOT NULL,
+    share_link_id VARCHAR(36) NOT NULL,
+    author_name VARCHAR(64) NOT NULL,
+    text TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    remote_addr VARCHAR(255) NOT NULL,
+    comment_id VARCHAR(36) NOT NULL,
+    moderated_by VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    update_at BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "guest_comments" "board_id, status" }}
+{{ createIndexIfNeeded "guest_comments" "share_link_id, remote_addr, create_at" }}
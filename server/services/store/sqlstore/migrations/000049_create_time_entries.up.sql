@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}time_entries (
+    id VARCHAR(36) NOT NULL,
+    card_id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    start_at BIGINT NOT NULL,
+    duration BIGINT NOT NULL,
+    running BOOLEAN NOT NULL,
+    note TEXT,
+    create_at BIGINT,
+    update_at BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "time_entries" "card_id" }}
+{{ createIndexIfNeeded "time_entries" "board_id" }}
+{{ createIndexIfNeeded "time_entries" "user_id, running" }}
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}iterations (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    goal TEXT,
+    start_at BIGINT,
+    end_at BIGINT,
+    state VARCHAR(16) NOT NULL,
+    status_property_id VARCHAR(36),
+    {{if .mysql}}
+    done_option_ids JSON,
+    {{end}}
+    {{if .postgres}}
+    done_option_ids JSONB,
+    {{end}}
+    {{if .sqlite}}
+    done_option_ids TEXT,
+    {{end}}
+    estimate_property_id VARCHAR(36),
+    created_by VARCHAR(36) NOT NULL,
+    started_at BIGINT,
+    closed_at BIGINT,
+    create_at BIGINT,
+    update_at BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "iterations" "board_id" }}
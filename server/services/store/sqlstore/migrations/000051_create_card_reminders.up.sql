@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}card_reminder_settings (
+    board_id VARCHAR(36) NOT NULL,
+    due_date_property_id VARCHAR(36) NOT NULL,
+    hours_before INT NOT NULL,
+    modified_by VARCHAR(36) NOT NULL,
+    update_at BIGINT,
+    PRIMARY KEY (board_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE TABLE IF NOT EXISTS {{.prefix}}card_reminders (
+    card_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    due_at BIGINT NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    sent_at BIGINT,
+    PRIMARY KEY (card_id, user_id, kind, due_at)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "card_reminders" "due_at" }}
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}notification_digest_items (
+    user_id VARCHAR(36) NOT NULL,
+    block_id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    since_at BIGINT NOT NULL,
+    notify_at BIGINT NOT NULL,
+    create_at BIGINT,
+    PRIMARY KEY (user_id, block_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "notification_digest_items" "notify_at" }}
@@ -0,0 +1 @@
+{{ addColumnIfNeeded "subscriptions" "notify_filter" "TEXT" "" }}
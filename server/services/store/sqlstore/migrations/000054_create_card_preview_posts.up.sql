@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}card_preview_posts (
+    post_id VARCHAR(36) NOT NULL,
+    card_id VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    PRIMARY KEY (post_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "card_preview_posts" "card_id" }}
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}channel_feed_settings (
+    board_id VARCHAR(36) NOT NULL,
+    {{if .mysql}}
+    events JSON,
+    done_option_ids JSON,
+    {{end}}
+    {{if .postgres}}
+    events JSONB,
+    done_option_ids JSONB,
+    {{end}}
+    {{if .sqlite}}
+    events TEXT,
+    done_option_ids TEXT,
+    {{end}}
+    status_property_id VARCHAR(36),
+    window_minutes INT NOT NULL,
+    modified_by VARCHAR(36) NOT NULL,
+    update_at BIGINT,
+    PRIMARY KEY (board_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE TABLE IF NOT EXISTS {{.prefix}}channel_feed_items (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    card_id VARCHAR(36) NOT NULL,
+    event VARCHAR(32) NOT NULL,
+    old_value VARCHAR(36),
+    new_value VARCHAR(36),
+    modified_by VARCHAR(36) NOT NULL,
+    notify_at BIGINT NOT NULL,
+    create_at BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "channel_feed_items" "board_id" }}
+{{ createIndexIfNeeded "channel_feed_items" "notify_at" }}
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}board_custom_roles (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    {{if .mysql}}
+    permissions JSON,
+    property_ids JSON,
+    {{end}}
+    {{if .postgres}}
+    permissions JSONB,
+    property_ids JSONB,
+    {{end}}
+    {{if .sqlite}}
+    permissions TEXT,
+    property_ids TEXT,
+    {{end}}
+    created_by VARCHAR(36) NOT NULL,
+    modified_by VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    update_at BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "board_custom_roles" "board_id" }}
+
+{{- /* addColumnIfNeeded tableName columnName datatype constraint */ -}}
+{{ addColumnIfNeeded "board_members" "custom_role_id" "VARCHAR(36)" "" }}
@@ -0,0 +1 @@
+{{ addColumnIfNeeded "share_link_accesses" "failed" "boolean" "default false" }}
@@ -0,0 +1,169 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var notificationDigestItemFields = []string{
+	"user_id",
+	"block_id",
+	"board_id",
+	"since_at",
+	"notify_at",
+	"create_at",
+}
+
+func (s *SQLStore) notificationDigestItemsFromRows(rows *sql.Rows) ([]*model.NotificationDigestItem, error) {
+	items := []*model.NotificationDigestItem{}
+
+	for rows.Next() {
+		var item model.NotificationDigestItem
+		err := rows.Scan(
+			&item.UserID,
+			&item.BlockID,
+			&item.BoardID,
+			&item.SinceAt,
+			&item.NotifyAt,
+			&item.CreateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+	return items, nil
+}
+
+// addNotificationDigestItem adds a block to the next notification digest of
+// a user. A block already waiting for the digest keeps the earliest since and
+// notify times, so that the digest covers every change since the first one,
+// including when the items of a digest that could not be delivered are added
+// back.
+func (s *SQLStore) addNotificationDigestItem(db sq.BaseRunner, item *model.NotificationDigestItem) error {
+	table := s.tablePrefix + "notification_digest_items"
+
+	query := s.getQueryBuilder(db).
+		Insert(table).
+		Columns(notificationDigestItemFields...).
+		Values(
+			item.UserID,
+			item.BlockID,
+			item.BoardID,
+			item.SinceAt,
+			item.NotifyAt,
+			utils.GetMillis(),
+		)
+
+	switch s.dbType {
+	case model.MysqlDBType:
+		query = query.Suffix("ON DUPLICATE KEY UPDATE since_at = LEAST(since_at, VALUES(since_at)), notify_at = LEAST(notify_at, VALUES(notify_at))")
+	case model.SqliteDBType:
+		query = query.Suffix(fmt.Sprintf("ON CONFLICT (user_id, block_id) DO UPDATE SET since_at = MIN(%[1]s.since_at, excluded.since_at), notify_at = MIN(%[1]s.notify_at, excluded.notify_at)", table))
+	default:
+		query = query.Suffix(fmt.Sprintf("ON CONFLICT (user_id, block_id) DO UPDATE SET since_at = LEAST(%[1]s.since_at, EXCLUDED.since_at), notify_at = LEAST(%[1]s.notify_at, EXCLUDED.notify_at)", table))
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot add notification digest item",
+			mlog.String("user_id", item.UserID),
+			mlog.String("block_id", item.BlockID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// getDueNotificationDigestUserIDs returns the ids of the users with a
+// notification digest due at the given time.
+func (s *SQLStore) getDueNotificationDigestUserIDs(db sq.BaseRunner, notifyAt int64) ([]string, error) {
+	query := s.getQueryBuilder(db).
+		Select("DISTINCT user_id").
+		From(s.tablePrefix + "notification_digest_items").
+		Where(sq.LtOrEq{"notify_at": notifyAt}).
+		OrderBy("user_id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch due notification digests", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	userIDs := []string{}
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, nil
+}
+
+// takeNotificationDigestItems returns and removes every block waiting for the
+// notification digest of a user. The items are locked when read, and only the
+// items read are removed, so that an item added meanwhile waits for the next
+// digest. When another node of the cluster took them concurrently no item is
+// returned, so that the digest is sent once.
+func (s *SQLStore) takeNotificationDigestItems(db sq.BaseRunner, userID string) ([]*model.NotificationDigestItem, error) {
+	selectQuery := s.getQueryBuilder(db).
+		Select(notificationDigestItemFields...).
+		From(s.tablePrefix+"notification_digest_items").
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("board_id", "create_at", "block_id")
+
+	if s.dbType != model.SqliteDBType {
+		selectQuery = selectQuery.Suffix("FOR UPDATE")
+	}
+
+	rows, err := selectQuery.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch notification digest items", mlog.String("user_id", userID), mlog.Err(err))
+		return nil, err
+	}
+
+	items, err := s.notificationDigestItemsFromRows(rows)
+	// the rows are closed before the delete, which runs in the same transaction
+	s.CloseRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return items, nil
+	}
+
+	blockIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		blockIDs = append(blockIDs, item.BlockID)
+	}
+
+	deleteQuery := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "notification_digest_items").
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Eq{"block_id": blockIDs})
+
+	result, err := deleteQuery.Exec()
+	if err != nil {
+		return nil, fmt.Errorf("cannot delete while taking notification digest items: %w", err)
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("cannot verify delete while taking notification digest items: %w", err)
+	}
+	if count == 0 {
+		// another node has taken the items concurrently; let that node send the digest.
+		return []*model.NotificationDigestItem{}, nil
+	}
+	return items, nil
+}
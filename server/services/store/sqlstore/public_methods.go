@@ -22,6 +22,16 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
 
+func (s *SQLStore) AddChannelFeedItem(item *model.ChannelFeedItem) error {
+	return s.addChannelFeedItem(s.db, item)
+
+}
+
+func (s *SQLStore) AddNotificationDigestItem(item *model.NotificationDigestItem) error {
+	return s.addNotificationDigestItem(s.db, item)
+
+}
+
 func (s *SQLStore) AddUpdateCategoryBoard(userID string, categoryID string, boardIDs []string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.addUpdateCategoryBoard(s.db, userID, categoryID, boardIDs)
@@ -46,11 +56,46 @@ func (s *SQLStore) AddUpdateCategoryBoard(userID string, categoryID string, boar
 
 }
 
+func (s *SQLStore) AdvanceCardRecurrence(recurrence *model.CardRecurrence, nextRunAt int64) (bool, error) {
+	return s.advanceCardRecurrence(s.db, recurrence, nextRunAt)
+
+}
+
 func (s *SQLStore) CanSeeUser(seerID string, seenID string) (bool, error) {
 	return s.canSeeUser(s.db, seerID, seenID)
 
 }
 
+func (s *SQLStore) ClaimWebhookDelivery(delivery *model.WebhookDelivery, until int64) (bool, error) {
+	return s.claimWebhookDelivery(s.db, delivery, until)
+
+}
+
+func (s *SQLStore) CountFailedShareLinkAccessesSince(shareLinkID string, remoteAddr string, since int64) (int, error) {
+	return s.countFailedShareLinkAccessesSince(s.db, shareLinkID, remoteAddr, since)
+
+}
+
+func (s *SQLStore) CountGuestCommentsSince(shareLinkID string, remoteAddr string, since int64) (int, error) {
+	return s.countGuestCommentsSince(s.db, shareLinkID, remoteAddr, since)
+
+}
+
+func (s *SQLStore) CreateBoardCustomRole(role *model.BoardCustomRole) (*model.BoardCustomRole, error) {
+	return s.createBoardCustomRole(s.db, role)
+
+}
+
+func (s *SQLStore) CreateBoardRule(rule *model.BoardRule) (*model.BoardRule, error) {
+	return s.createBoardRule(s.db, rule)
+
+}
+
+func (s *SQLStore) CreateBoardWebhook(webhook *model.BoardWebhook) (*model.BoardWebhook, error) {
+	return s.createBoardWebhook(s.db, webhook)
+
+}
+
 func (s *SQLStore) CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
 	if s.dbType == model.SqliteDBType {
 		return s.createBoardsAndBlocks(s.db, bab, userID)
@@ -99,6 +144,16 @@ func (s *SQLStore) CreateBoardsAndBlocksWithAdmin(bab *model.BoardsAndBlocks, us
 
 }
 
+func (s *SQLStore) CreateCardDependency(dependency *model.CardDependency) (*model.CardDependency, error) {
+	return s.createCardDependency(s.db, dependency)
+
+}
+
+func (s *SQLStore) CreateCardReminder(reminder *model.CardReminder) (bool, error) {
+	return s.createCardReminder(s.db, reminder)
+
+}
+
 func (s *SQLStore) CreateCategory(category model.Category) error {
 	if s.dbType == model.SqliteDBType {
 		return s.createCategory(s.db, category)
@@ -123,11 +178,60 @@ func (s *SQLStore) CreateCategory(category model.Category) error {
 
 }
 
+func (s *SQLStore) CreateGuestComment(comment *model.GuestComment, limit int, since int64) (*model.GuestComment, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.createGuestComment(s.db, comment, limit, since)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.createGuestComment(tx, comment, limit, since)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CreateGuestComment"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
+func (s *SQLStore) CreateIncomingWebhook(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	return s.createIncomingWebhook(s.db, webhook)
+
+}
+
+func (s *SQLStore) CreateIteration(iteration *model.Iteration) (*model.Iteration, error) {
+	return s.createIteration(s.db, iteration)
+
+}
+
+func (s *SQLStore) CreateRetentionPolicy(policy *model.RetentionPolicy) (*model.RetentionPolicy, error) {
+	return s.createRetentionPolicy(s.db, policy)
+
+}
+
+func (s *SQLStore) CreateShareLink(link *model.ShareLink) (*model.ShareLink, error) {
+	return s.createShareLink(s.db, link)
+
+}
+
 func (s *SQLStore) CreateSubscription(sub *model.Subscription) (*model.Subscription, error) {
 	return s.createSubscription(s.db, sub)
 
 }
 
+func (s *SQLStore) CreateTimeEntry(entry *model.TimeEntry) (*model.TimeEntry, error) {
+	return s.createTimeEntry(s.db, entry)
+
+}
+
 func (s *SQLStore) DeleteBlock(blockID string, modifiedBy string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.deleteBlock(s.db, blockID, modifiedBy)
@@ -181,11 +285,64 @@ func (s *SQLStore) DeleteBoard(boardID string, userID string) error {
 
 }
 
+func (s *SQLStore) DeleteBoardCustomRole(id string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteBoardCustomRole(s.db, id)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.deleteBoardCustomRole(tx, id)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBoardCustomRole"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
 func (s *SQLStore) DeleteBoardRecord(boardID string, modifiedBy string) error {
 	return s.deleteBoardRecord(s.db, boardID, modifiedBy)
 
 }
 
+func (s *SQLStore) DeleteBoardRule(id string) error {
+	return s.deleteBoardRule(s.db, id)
+
+}
+
+func (s *SQLStore) DeleteBoardWebhook(id string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteBoardWebhook(s.db, id)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.deleteBoardWebhook(tx, id)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBoardWebhook"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
 func (s *SQLStore) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, userID string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.deleteBoardsAndBlocks(s.db, dbab, userID)
@@ -210,11 +367,70 @@ func (s *SQLStore) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, user
 
 }
 
+func (s *SQLStore) DeleteCardDependency(id string) error {
+	return s.deleteCardDependency(s.db, id)
+
+}
+
+func (s *SQLStore) DeleteCardPreviewPost(postID string) error {
+	return s.deleteCardPreviewPost(s.db, postID)
+
+}
+
+func (s *SQLStore) DeleteCardRecurrence(cardID string) error {
+	return s.deleteCardRecurrence(s.db, cardID)
+
+}
+
+func (s *SQLStore) DeleteCardReminderSettings(boardID string) error {
+	return s.deleteCardReminderSettings(s.db, boardID)
+
+}
+
+func (s *SQLStore) DeleteCardRemindersDueBefore(dueAt int64) error {
+	return s.deleteCardRemindersDueBefore(s.db, dueAt)
+
+}
+
 func (s *SQLStore) DeleteCategory(categoryID string, userID string, teamID string) error {
 	return s.deleteCategory(s.db, categoryID, userID, teamID)
 
 }
 
+func (s *SQLStore) DeleteChannelFeedSettings(boardID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteChannelFeedSettings(s.db, boardID)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.deleteChannelFeedSettings(tx, boardID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteChannelFeedSettings"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *SQLStore) DeleteIncomingWebhook(id string) error {
+	return s.deleteIncomingWebhook(s.db, id)
+
+}
+
+func (s *SQLStore) DeleteIteration(id string) error {
+	return s.deleteIteration(s.db, id)
+
+}
+
 func (s *SQLStore) DeleteMember(boardID string, userID string) error {
 	return s.deleteMember(s.db, boardID, userID)
 
@@ -225,11 +441,45 @@ func (s *SQLStore) DeleteNotificationHint(blockID string) error {
 
 }
 
+func (s *SQLStore) DeleteRetentionPolicy(id string) error {
+	return s.deleteRetentionPolicy(s.db, id)
+
+}
+
+func (s *SQLStore) DeleteShareLink(id string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteShareLink(s.db, id)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.deleteShareLink(tx, id)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteShareLink"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
 func (s *SQLStore) DeleteSubscription(blockID string, subscriberID string) error {
 	return s.deleteSubscription(s.db, blockID, subscriberID)
 
 }
 
+func (s *SQLStore) DeleteTimeEntry(id string) error {
+	return s.deleteTimeEntry(s.db, id)
+
+}
+
 func (s *SQLStore) DuplicateBlock(boardID string, blockID string, userID string, asTemplate bool) ([]*model.Block, error) {
 	if s.dbType == model.SqliteDBType {
 		return s.duplicateBlock(s.db, boardID, blockID, userID, asTemplate)
@@ -283,6 +533,11 @@ func (s *SQLStore) GetActiveUserCount(updatedSecondsAgo int64) (int, error) {
 
 }
 
+func (s *SQLStore) GetAllCardReminderSettings() ([]*model.CardReminderSettings, error) {
+	return s.getAllCardReminderSettings(s.db)
+
+}
+
 func (s *SQLStore) GetAllTeams() ([]*model.Team, error) {
 	return s.getAllTeams(s.db)
 
@@ -368,6 +623,16 @@ func (s *SQLStore) GetBoardCount(includeDeleted bool) (int64, error) {
 
 }
 
+func (s *SQLStore) GetBoardCustomRole(id string) (*model.BoardCustomRole, error) {
+	return s.getBoardCustomRole(s.db, id)
+
+}
+
+func (s *SQLStore) GetBoardCustomRolesForBoard(boardID string) ([]*model.BoardCustomRole, error) {
+	return s.getBoardCustomRolesForBoard(s.db, boardID)
+
+}
+
 func (s *SQLStore) GetBoardHistory(boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error) {
 	return s.getBoardHistory(s.db, boardID, opts)
 
@@ -378,6 +643,26 @@ func (s *SQLStore) GetBoardMemberHistory(boardID string, userID string, limit ui
 
 }
 
+func (s *SQLStore) GetBoardRule(id string) (*model.BoardRule, error) {
+	return s.getBoardRule(s.db, id)
+
+}
+
+func (s *SQLStore) GetBoardRulesForBoard(boardID string) ([]*model.BoardRule, error) {
+	return s.getBoardRulesForBoard(s.db, boardID)
+
+}
+
+func (s *SQLStore) GetBoardWebhook(id string) (*model.BoardWebhook, error) {
+	return s.getBoardWebhook(s.db, id)
+
+}
+
+func (s *SQLStore) GetBoardWebhooksForBoard(boardID string) ([]*model.BoardWebhook, error) {
+	return s.getBoardWebhooksForBoard(s.db, boardID)
+
+}
+
 func (s *SQLStore) GetBoardsComplianceHistory(opts model.QueryBoardsComplianceHistoryOptions) ([]*model.BoardHistory, bool, error) {
 	return s.getBoardsComplianceHistory(s.db, opts)
 
@@ -398,11 +683,51 @@ func (s *SQLStore) GetBoardsInTeamByIds(boardIDs []string, teamID string) ([]*mo
 
 }
 
+func (s *SQLStore) GetCardDependenciesForBoard(boardID string) ([]*model.CardDependency, error) {
+	return s.getCardDependenciesForBoard(s.db, boardID)
+
+}
+
+func (s *SQLStore) GetCardDependenciesForCard(cardID string) ([]*model.CardDependency, error) {
+	return s.getCardDependenciesForCard(s.db, cardID)
+
+}
+
+func (s *SQLStore) GetCardDependency(id string) (*model.CardDependency, error) {
+	return s.getCardDependency(s.db, id)
+
+}
+
 func (s *SQLStore) GetCardLimitTimestamp() (int64, error) {
 	return s.getCardLimitTimestamp(s.db)
 
 }
 
+func (s *SQLStore) GetCardPreviewPostIDs(cardID string) ([]string, error) {
+	return s.getCardPreviewPostIDs(s.db, cardID)
+
+}
+
+func (s *SQLStore) GetCardPreviewPostIDsForBoard(boardID string) ([]string, error) {
+	return s.getCardPreviewPostIDsForBoard(s.db, boardID)
+
+}
+
+func (s *SQLStore) GetCardRecurrence(cardID string) (*model.CardRecurrence, error) {
+	return s.getCardRecurrence(s.db, cardID)
+
+}
+
+func (s *SQLStore) GetCardRecurrencesForBoard(boardID string) ([]*model.CardRecurrence, error) {
+	return s.getCardRecurrencesForBoard(s.db, boardID)
+
+}
+
+func (s *SQLStore) GetCardReminderSettings(boardID string) (*model.CardReminderSettings, error) {
+	return s.getCardReminderSettings(s.db, boardID)
+
+}
+
 func (s *SQLStore) GetCardsCount() (int64, error) {
 	return s.getCardsCount(s.db)
 
@@ -418,11 +743,71 @@ func (s *SQLStore) GetChannel(teamID string, channelID string) (*mmModel.Channel
 
 }
 
+func (s *SQLStore) GetChannelFeedSettings(boardID string) (*model.ChannelFeedSettings, error) {
+	return s.getChannelFeedSettings(s.db, boardID)
+
+}
+
+func (s *SQLStore) GetDataRetentionPreview(cutoffs *model.RetentionCutoffs) (*model.RetentionPreview, error) {
+	return s.getDataRetentionPreview(s.db, cutoffs)
+
+}
+
+func (s *SQLStore) GetDueCardRecurrences(until int64) ([]*model.CardRecurrence, error) {
+	return s.getDueCardRecurrences(s.db, until)
+
+}
+
+func (s *SQLStore) GetDueChannelFeedBoardIDs(notifyAt int64) ([]string, error) {
+	return s.getDueChannelFeedBoardIDs(s.db, notifyAt)
+
+}
+
+func (s *SQLStore) GetDueNotificationDigestUserIDs(notifyAt int64) ([]string, error) {
+	return s.getDueNotificationDigestUserIDs(s.db, notifyAt)
+
+}
+
+func (s *SQLStore) GetDueWebhookDeliveries(until int64, maxDeliveries int) ([]*model.WebhookDelivery, error) {
+	return s.getDueWebhookDeliveries(s.db, until, maxDeliveries)
+
+}
+
 func (s *SQLStore) GetFileInfo(id string) (*mmModel.FileInfo, error) {
 	return s.getFileInfo(s.db, id)
 
 }
 
+func (s *SQLStore) GetGuestComment(id string) (*model.GuestComment, error) {
+	return s.getGuestComment(s.db, id)
+
+}
+
+func (s *SQLStore) GetGuestCommentsForBoard(boardID string, status string) ([]*model.GuestComment, error) {
+	return s.getGuestCommentsForBoard(s.db, boardID, status)
+
+}
+
+func (s *SQLStore) GetIncomingWebhook(id string) (*model.IncomingWebhook, error) {
+	return s.getIncomingWebhook(s.db, id)
+
+}
+
+func (s *SQLStore) GetIncomingWebhooksForBoard(boardID string) ([]*model.IncomingWebhook, error) {
+	return s.getIncomingWebhooksForBoard(s.db, boardID)
+
+}
+
+func (s *SQLStore) GetIteration(id string) (*model.Iteration, error) {
+	return s.getIteration(s.db, id)
+
+}
+
+func (s *SQLStore) GetIterationsForBoard(boardID string) ([]*model.Iteration, error) {
+	return s.getIterationsForBoard(s.db, boardID)
+
+}
+
 func (s *SQLStore) GetLicense() *mmModel.License {
 	return s.getLicense(s.db)
 
@@ -458,6 +843,41 @@ func (s *SQLStore) GetRegisteredUserCount() (int, error) {
 
 }
 
+func (s *SQLStore) GetRetentionPolicies() ([]*model.RetentionPolicy, error) {
+	return s.getRetentionPolicies(s.db)
+
+}
+
+func (s *SQLStore) GetRetentionPolicy(id string) (*model.RetentionPolicy, error) {
+	return s.getRetentionPolicy(s.db, id)
+
+}
+
+func (s *SQLStore) GetRunningTimeEntry(userID string) (*model.TimeEntry, error) {
+	return s.getRunningTimeEntry(s.db, userID)
+
+}
+
+func (s *SQLStore) GetShareLink(id string) (*model.ShareLink, error) {
+	return s.getShareLink(s.db, id)
+
+}
+
+func (s *SQLStore) GetShareLinkAccesses(shareLinkID string, page int, perPage int) ([]*model.ShareLinkAccess, error) {
+	return s.getShareLinkAccesses(s.db, shareLinkID, page, perPage)
+
+}
+
+func (s *SQLStore) GetShareLinkByToken(boardID string, token string) (*model.ShareLink, error) {
+	return s.getShareLinkByToken(s.db, boardID, token)
+
+}
+
+func (s *SQLStore) GetShareLinksForBoard(boardID string) ([]*model.ShareLink, error) {
+	return s.getShareLinksForBoard(s.db, boardID)
+
+}
+
 func (s *SQLStore) GetSharing(rootID string) (*model.Sharing, error) {
 	return s.getSharing(s.db, rootID)
 
@@ -518,6 +938,21 @@ func (s *SQLStore) GetTemplateBoards(teamID string, userID string) ([]*model.Boa
 
 }
 
+func (s *SQLStore) GetTimeEntriesForBoard(boardID string) ([]*model.TimeEntry, error) {
+	return s.getTimeEntriesForBoard(s.db, boardID)
+
+}
+
+func (s *SQLStore) GetTimeEntriesForCard(cardID string) ([]*model.TimeEntry, error) {
+	return s.getTimeEntriesForCard(s.db, cardID)
+
+}
+
+func (s *SQLStore) GetTimeEntry(id string) (*model.TimeEntry, error) {
+	return s.getTimeEntry(s.db, id)
+
+}
+
 func (s *SQLStore) GetUsedCardsCount() (int64, error) {
 	return s.getUsedCardsCount(s.db)
 
@@ -568,6 +1003,11 @@ func (s *SQLStore) GetUsersList(userIDs []string, showEmail bool, showName bool)
 
 }
 
+func (s *SQLStore) GetWebhookDeliveries(webhookID string, page int, perPage int) ([]*model.WebhookDelivery, error) {
+	return s.getWebhookDeliveries(s.db, webhookID, page, perPage)
+
+}
+
 func (s *SQLStore) InsertBlock(block *model.Block, userID string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.insertBlock(s.db, block, userID)
@@ -645,6 +1085,40 @@ func (s *SQLStore) InsertBoardWithAdmin(board *model.Board, userID string) (*mod
 
 }
 
+func (s *SQLStore) InsertShareLinkAccess(access *model.ShareLinkAccess) error {
+	return s.insertShareLinkAccess(s.db, access)
+
+}
+
+func (s *SQLStore) InsertWebhookDelivery(delivery *model.WebhookDelivery) error {
+	return s.insertWebhookDelivery(s.db, delivery)
+
+}
+
+func (s *SQLStore) MoveCardToBoard(cardID string, boardID string, modifiedBy string) ([]*model.Block, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.moveCardToBoard(s.db, cardID, boardID, modifiedBy)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.moveCardToBoard(tx, cardID, boardID, modifiedBy)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "MoveCardToBoard"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
 func (s *SQLStore) PatchBlock(blockID string, blockPatch *model.BlockPatch, userID string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.patchBlock(s.db, blockID, blockPatch, userID)
@@ -766,15 +1240,20 @@ func (s *SQLStore) ReorderCategoryBoards(categoryID string, newBoardsOrder []str
 
 }
 
-func (s *SQLStore) RunDataRetention(globalRetentionDate int64, batchSize int64) (int64, error) {
+func (s *SQLStore) ResetGuestCommentApproval(comment *model.GuestComment) error {
+	return s.resetGuestCommentApproval(s.db, comment)
+
+}
+
+func (s *SQLStore) RunDataRetention(cutoffs *model.RetentionCutoffs, batchSize int64) (int64, error) {
 	if s.dbType == model.SqliteDBType {
-		return s.runDataRetention(s.db, globalRetentionDate, batchSize)
+		return s.runDataRetention(s.db, cutoffs, batchSize)
 	}
 	tx, txErr := s.db.BeginTx(context.Background(), nil)
 	if txErr != nil {
 		return 0, txErr
 	}
-	result, err := s.runDataRetention(tx, globalRetentionDate, batchSize)
+	result, err := s.runDataRetention(tx, cutoffs, batchSize)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
 			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "RunDataRetention"))
@@ -790,6 +1269,26 @@ func (s *SQLStore) RunDataRetention(globalRetentionDate int64, batchSize int64)
 
 }
 
+func (s *SQLStore) SaveCardPreviewPost(previewPost *model.CardPreviewPost) error {
+	return s.saveCardPreviewPost(s.db, previewPost)
+
+}
+
+func (s *SQLStore) SaveCardRecurrence(recurrence *model.CardRecurrence) (*model.CardRecurrence, error) {
+	return s.saveCardRecurrence(s.db, recurrence)
+
+}
+
+func (s *SQLStore) SaveCardReminderSettings(settings *model.CardReminderSettings) (*model.CardReminderSettings, error) {
+	return s.saveCardReminderSettings(s.db, settings)
+
+}
+
+func (s *SQLStore) SaveChannelFeedSettings(settings *model.ChannelFeedSettings) (*model.ChannelFeedSettings, error) {
+	return s.saveChannelFeedSettings(s.db, settings)
+
+}
+
 func (s *SQLStore) SaveFileInfo(fileInfo *mmModel.FileInfo) error {
 	return s.saveFileInfo(s.db, fileInfo)
 
@@ -810,6 +1309,11 @@ func (s *SQLStore) SearchBoardsForUserInTeam(teamID string, term string, userID
 
 }
 
+func (s *SQLStore) SearchCards(opts model.CardSearchOptions) ([]*model.CardSearchResult, error) {
+	return s.searchCards(s.db, opts)
+
+}
+
 func (s *SQLStore) SearchUserChannels(teamID string, userID string, query string) ([]*mmModel.Channel, error) {
 	return s.searchUserChannels(s.db, teamID, userID, query)
 
@@ -835,6 +1339,35 @@ func (s *SQLStore) SetSystemSetting(key string, value string) error {
 
 }
 
+func (s *SQLStore) TakeChannelFeedItems(boardID string) ([]*model.ChannelFeedItem, error) {
+	return s.takeChannelFeedItems(s.db, boardID)
+
+}
+
+func (s *SQLStore) TakeNotificationDigestItems(userID string) ([]*model.NotificationDigestItem, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.takeNotificationDigestItems(s.db, userID)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.takeNotificationDigestItems(tx, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "TakeNotificationDigestItems"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
 func (s *SQLStore) UndeleteBlock(blockID string, modifiedBy string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.undeleteBlock(s.db, blockID, modifiedBy)
@@ -883,6 +1416,45 @@ func (s *SQLStore) UndeleteBoard(boardID string, modifiedBy string) error {
 
 }
 
+func (s *SQLStore) UpdateBoardCustomRole(role *model.BoardCustomRole) (*model.BoardCustomRole, error) {
+	return s.updateBoardCustomRole(s.db, role)
+
+}
+
+func (s *SQLStore) UpdateBoardRule(rule *model.BoardRule) (*model.BoardRule, error) {
+	return s.updateBoardRule(s.db, rule)
+
+}
+
+func (s *SQLStore) UpdateBoardWebhook(webhook *model.BoardWebhook) (*model.BoardWebhook, error) {
+	return s.updateBoardWebhook(s.db, webhook)
+
+}
+
+func (s *SQLStore) UpdateCardComputedProperties(blockID string, properties map[string]interface{}) error {
+	if s.dbType == model.SqliteDBType {
+		return s.updateCardComputedProperties(s.db, blockID, properties)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.updateCardComputedProperties(tx, blockID, properties)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "UpdateCardComputedProperties"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
 func (s *SQLStore) UpdateCardLimitTimestamp(cardLimit int) (int64, error) {
 	return s.updateCardLimitTimestamp(s.db, cardLimit)
 
@@ -893,11 +1465,51 @@ func (s *SQLStore) UpdateCategory(category model.Category) error {
 
 }
 
+func (s *SQLStore) UpdateGuestCommentStatus(comment *model.GuestComment) (bool, error) {
+	return s.updateGuestCommentStatus(s.db, comment)
+
+}
+
+func (s *SQLStore) UpdateIncomingWebhook(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	return s.updateIncomingWebhook(s.db, webhook)
+
+}
+
+func (s *SQLStore) UpdateIteration(iteration *model.Iteration) (*model.Iteration, error) {
+	return s.updateIteration(s.db, iteration)
+
+}
+
+func (s *SQLStore) UpdateRetentionPolicy(policy *model.RetentionPolicy) (*model.RetentionPolicy, error) {
+	return s.updateRetentionPolicy(s.db, policy)
+
+}
+
+func (s *SQLStore) UpdateShareLink(link *model.ShareLink) (*model.ShareLink, error) {
+	return s.updateShareLink(s.db, link)
+
+}
+
 func (s *SQLStore) UpdateSubscribersNotifiedAt(blockID string, notifiedAt int64) error {
 	return s.updateSubscribersNotifiedAt(s.db, blockID, notifiedAt)
 
 }
 
+func (s *SQLStore) UpdateSubscriptionFilter(blockID string, subscriberID string, filter *model.SubscriptionFilter) error {
+	return s.updateSubscriptionFilter(s.db, blockID, subscriberID, filter)
+
+}
+
+func (s *SQLStore) UpdateTimeEntry(entry *model.TimeEntry) (*model.TimeEntry, error) {
+	return s.updateTimeEntry(s.db, entry)
+
+}
+
+func (s *SQLStore) UpdateWebhookDelivery(delivery *model.WebhookDelivery) error {
+	return s.updateWebhookDelivery(s.db, delivery)
+
+}
+
 func (s *SQLStore) UpsertNotificationHint(hint *model.NotificationHint, notificationFreq time.Duration) (*model.NotificationHint, error) {
 	return s.upsertNotificationHint(s.db, hint, notificationFreq)
 
@@ -0,0 +1,203 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var retentionPolicyFields = []string{
+	"id",
+	"team_id",
+	"board_id",
+	"retention_days",
+	"exempt_board_ids",
+	"created_by",
+	"create_at",
+	"update_at",
+}
+
+func (s *SQLStore) retentionPoliciesFromRows(rows *sql.Rows) ([]*model.RetentionPolicy, error) {
+	policies := []*model.RetentionPolicy{}
+
+	for rows.Next() {
+		var policy model.RetentionPolicy
+		var exemptBoardIDsBytes []byte
+
+		err := rows.Scan(
+			&policy.ID,
+			&policy.TeamID,
+			&policy.BoardID,
+			&policy.RetentionDays,
+			&exemptBoardIDsBytes,
+			&policy.CreatedBy,
+			&policy.CreateAt,
+			&policy.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		policy.ExemptBoardIDs = []string{}
+		if len(exemptBoardIDsBytes) != 0 {
+			if err = json.Unmarshal(exemptBoardIDsBytes, &policy.ExemptBoardIDs); err != nil {
+				s.logger.Error("retention policy exempt boards unmarshal error", mlog.String("policy_id", policy.ID), mlog.Err(err))
+				return nil, err
+			}
+		}
+
+		policies = append(policies, &policy)
+	}
+	return policies, nil
+}
+
+func (s *SQLStore) createRetentionPolicy(db sq.BaseRunner, policy *model.RetentionPolicy) (*model.RetentionPolicy, error) {
+	if err := policy.IsValid(); err != nil {
+		return nil, err
+	}
+
+	now := model.GetMillis()
+
+	policyAdd := *policy
+	policyAdd.CreateAt = now
+	policyAdd.UpdateAt = now
+	if policyAdd.ExemptBoardIDs == nil {
+		policyAdd.ExemptBoardIDs = []string{}
+	}
+
+	exemptBoardIDsBytes, err := s.MarshalJSONB(policyAdd.ExemptBoardIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"retention_policies").
+		Columns(retentionPolicyFields...).
+		Values(
+			policyAdd.ID,
+			policyAdd.TeamID,
+			policyAdd.BoardID,
+			policyAdd.RetentionDays,
+			exemptBoardIDsBytes,
+			policyAdd.CreatedBy,
+			policyAdd.CreateAt,
+			policyAdd.UpdateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create retention policy", mlog.String("policy_id", policy.ID), mlog.Err(err))
+		return nil, err
+	}
+	return &policyAdd, nil
+}
+
+// updateRetentionPolicy replaces the retention days and exempt boards of an
+// existing policy. The scope of a policy cannot be changed.
+func (s *SQLStore) updateRetentionPolicy(db sq.BaseRunner, policy *model.RetentionPolicy) (*model.RetentionPolicy, error) {
+	if err := policy.IsValid(); err != nil {
+		return nil, err
+	}
+
+	exemptBoardIDs := policy.ExemptBoardIDs
+	if exemptBoardIDs == nil {
+		exemptBoardIDs = []string{}
+	}
+
+	exemptBoardIDsBytes, err := s.MarshalJSONB(exemptBoardIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"retention_policies").
+		Set("retention_days", policy.RetentionDays).
+		Set("exempt_board_ids", exemptBoardIDsBytes).
+		Set("update_at", model.GetMillis()).
+		Where(sq.Eq{"id": policy.ID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("Cannot update retention policy", mlog.String("policy_id", policy.ID), mlog.Err(err))
+		return nil, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if count == 0 {
+		return nil, model.NewErrNotFound("retention policy ID=" + policy.ID)
+	}
+
+	return s.getRetentionPolicy(db, policy.ID)
+}
+
+func (s *SQLStore) getRetentionPolicy(db sq.BaseRunner, id string) (*model.RetentionPolicy, error) {
+	query := s.getQueryBuilder(db).
+		Select(retentionPolicyFields...).
+		From(s.tablePrefix + "retention_policies").
+		Where(sq.Eq{"id": id})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch retention policy", mlog.String("policy_id", id), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	policies, err := s.retentionPoliciesFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(policies) == 0 {
+		return nil, model.NewErrNotFound("retention policy ID=" + id)
+	}
+	return policies[0], nil
+}
+
+func (s *SQLStore) getRetentionPolicies(db sq.BaseRunner) ([]*model.RetentionPolicy, error) {
+	query := s.getQueryBuilder(db).
+		Select(retentionPolicyFields...).
+		From(s.tablePrefix+"retention_policies").
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch retention policies", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.retentionPoliciesFromRows(rows)
+}
+
+func (s *SQLStore) deleteRetentionPolicy(db sq.BaseRunner, id string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "retention_policies").
+		Where(sq.Eq{"id": id})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("Cannot delete retention policy", mlog.String("policy_id", id), mlog.Err(err))
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return model.NewErrNotFound("retention policy ID=" + id)
+	}
+	return nil
+}
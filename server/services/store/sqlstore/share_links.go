@@ -0,0 +1,332 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var shareLinkFields = []string{
+	"id",
+	"board_id",
+	"name",
+	"token",
+	"mode",
+	"view_id",
+	"expires_at",
+	"password_hash",
+	"revoked_at",
+	"created_by",
+	"create_at",
+	"update_at",
+}
+
+var shareLinkAccessFields = []string{
+	"id",
+	"share_link_id",
+	"board_id",
+	"remote_addr",
+	"user_agent",
+	"failed",
+	"create_at",
+}
+
+func shareLinksFromRows(rows *sql.Rows) ([]*model.ShareLink, error) {
+	links := []*model.ShareLink{}
+
+	for rows.Next() {
+		var link model.ShareLink
+
+		err := rows.Scan(
+			&link.ID,
+			&link.BoardID,
+			&link.Name,
+			&link.Token,
+			&link.Mode,
+			&link.ViewID,
+			&link.ExpiresAt,
+			&link.PasswordHash,
+			&link.RevokedAt,
+			&link.CreatedBy,
+			&link.CreateAt,
+			&link.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		link.HasPassword = link.PasswordHash != ""
+		links = append(links, &link)
+	}
+	return links, nil
+}
+
+func shareLinkAccessesFromRows(rows *sql.Rows) ([]*model.ShareLinkAccess, error) {
+	accesses := []*model.ShareLinkAccess{}
+
+	for rows.Next() {
+		var access model.ShareLinkAccess
+
+		err := rows.Scan(
+			&access.ID,
+			&access.ShareLinkID,
+			&access.BoardID,
+			&access.RemoteAddr,
+			&access.UserAgent,
+			&access.Failed,
+			&access.CreateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		accesses = append(accesses, &access)
+	}
+	return accesses, nil
+}
+
+func (s *SQLStore) createShareLink(db sq.BaseRunner, link *model.ShareLink) (*model.ShareLink, error) {
+	if err := link.IsValid(); err != nil {
+		return nil, err
+	}
+
+	now := model.GetMillis()
+
+	linkAdd := *link
+	linkAdd.CreateAt = now
+	linkAdd.UpdateAt = now
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"share_links").
+		Columns(shareLinkFields...).
+		Values(
+			linkAdd.ID,
+			linkAdd.BoardID,
+			linkAdd.Name,
+			linkAdd.Token,
+			linkAdd.Mode,
+			linkAdd.ViewID,
+			linkAdd.ExpiresAt,
+			linkAdd.PasswordHash,
+			linkAdd.RevokedAt,
+			linkAdd.CreatedBy,
+			linkAdd.CreateAt,
+			linkAdd.UpdateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create share link", mlog.String("share_link_id", link.ID), mlog.Err(err))
+		return nil, err
+	}
+	return &linkAdd, nil
+}
+
+// updateShareLink replaces the name, mode, view, expiration, password and
+// revocation of an existing share link. Its board and token cannot be
+// changed.
+func (s *SQLStore) updateShareLink(db sq.BaseRunner, link *model.ShareLink) (*model.ShareLink, error) {
+	if err := link.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"share_links").
+		Set("name", link.Name).
+		Set("mode", link.Mode).
+		Set("view_id", link.ViewID).
+		Set("expires_at", link.ExpiresAt).
+		Set("password_hash", link.PasswordHash).
+		Set("revoked_at", link.RevokedAt).
+		Set("update_at", model.GetMillis()).
+		Where(sq.Eq{"id": link.ID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("Cannot update share link", mlog.String("share_link_id", link.ID), mlog.Err(err))
+		return nil, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if count == 0 {
+		return nil, model.NewErrNotFound("share link ID=" + link.ID)
+	}
+
+	return s.getShareLink(db, link.ID)
+}
+
+func (s *SQLStore) getShareLink(db sq.BaseRunner, id string) (*model.ShareLink, error) {
+	query := s.getQueryBuilder(db).
+		Select(shareLinkFields...).
+		From(s.tablePrefix + "share_links").
+		Where(sq.Eq{"id": id})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch share link", mlog.String("share_link_id", id), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	links, err := shareLinksFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(links) == 0 {
+		return nil, model.NewErrNotFound("share link ID=" + id)
+	}
+	return links[0], nil
+}
+
+func (s *SQLStore) getShareLinkByToken(db sq.BaseRunner, boardID, token string) (*model.ShareLink, error) {
+	query := s.getQueryBuilder(db).
+		Select(shareLinkFields...).
+		From(s.tablePrefix + "share_links").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"token": token})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch share link by token", mlog.String("board_id", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	links, err := shareLinksFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(links) == 0 {
+		return nil, model.NewErrNotFound("share link for board ID=" + boardID)
+	}
+	return links[0], nil
+}
+
+func (s *SQLStore) getShareLinksForBoard(db sq.BaseRunner, boardID string) ([]*model.ShareLink, error) {
+	query := s.getQueryBuilder(db).
+		Select(shareLinkFields...).
+		From(s.tablePrefix+"share_links").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch share links for board", mlog.String("board_id", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return shareLinksFromRows(rows)
+}
+
+// deleteShareLink deletes a share link along with its access log.
+func (s *SQLStore) deleteShareLink(db sq.BaseRunner, id string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "share_links").
+		Where(sq.Eq{"id": id})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("Cannot delete share link", mlog.String("share_link_id", id), mlog.Err(err))
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return model.NewErrNotFound("share link ID=" + id)
+	}
+
+	accessesQuery := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "share_link_accesses").
+		Where(sq.Eq{"share_link_id": id})
+
+	if _, err := accessesQuery.Exec(); err != nil {
+		s.logger.Error("Cannot delete share link accesses", mlog.String("share_link_id", id), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) insertShareLinkAccess(db sq.BaseRunner, access *model.ShareLinkAccess) error {
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"share_link_accesses").
+		Columns(shareLinkAccessFields...).
+		Values(
+			access.ID,
+			access.ShareLinkID,
+			access.BoardID,
+			access.RemoteAddr,
+			access.UserAgent,
+			access.Failed,
+			access.CreateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot insert share link access", mlog.String("share_link_id", access.ShareLinkID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) getShareLinkAccesses(db sq.BaseRunner, shareLinkID string, page int, perPage int) ([]*model.ShareLinkAccess, error) {
+	query := s.getQueryBuilder(db).
+		Select(shareLinkAccessFields...).
+		From(s.tablePrefix+"share_link_accesses").
+		Where(sq.Eq{"share_link_id": shareLinkID}).
+		OrderBy("create_at DESC", "id DESC")
+
+	if page != 0 {
+		query = query.Offset(offset(page, perPage))
+	}
+
+	if perPage > 0 {
+		query = query.Limit(limit(perPage))
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch share link accesses", mlog.String("share_link_id", shareLinkID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return shareLinkAccessesFromRows(rows)
+}
+
+// countFailedShareLinkAccessesSince returns the number of requests rejected
+// because of a wrong password made with a share link since a time, from an
+// address, or from all addresses if empty.
+func (s *SQLStore) countFailedShareLinkAccessesSince(db sq.BaseRunner, shareLinkID string, remoteAddr string, since int64) (int, error) {
+	query := s.getQueryBuilder(db).
+		Select("COUNT(*)").
+		From(s.tablePrefix + "share_link_accesses").
+		Where(sq.Eq{"share_link_id": shareLinkID}).
+		Where(sq.Eq{"failed": true}).
+		Where(sq.GtOrEq{"create_at": since})
+
+	if remoteAddr != "" {
+		query = query.Where(sq.Eq{"remote_addr": remoteAddr})
+	}
+
+	var count int
+	if err := query.QueryRow().Scan(&count); err != nil {
+		s.logger.Error("Cannot count failed share link accesses", mlog.String("share_link_id", shareLinkID), mlog.Err(err))
+		return 0, err
+	}
+	return count, nil
+}
@@ -28,6 +28,22 @@ func TestSQLStore(t *testing.T) {
 	t.Run("StoreTestCategoryStore", func(t *testing.T) { storetests.StoreTestCategoryStore(t, SetupTests) })
 	t.Run("StoreTestCategoryBoardsStore", func(t *testing.T) { storetests.StoreTestCategoryBoardsStore(t, SetupTests) })
 	t.Run("ComplianceHistoryStore", func(t *testing.T) { storetests.StoreTestComplianceHistoryStore(t, SetupTests) })
+	t.Run("CardSearchStore", func(t *testing.T) { storetests.StoreTestCardSearchStore(t, SetupTests) })
+	t.Run("CardDependencyStore", func(t *testing.T) { storetests.StoreTestCardDependencyStore(t, SetupTests) })
+	t.Run("CardRecurrenceStore", func(t *testing.T) { storetests.StoreTestCardRecurrenceStore(t, SetupTests) })
+	t.Run("BoardRuleStore", func(t *testing.T) { storetests.StoreTestBoardRuleStore(t, SetupTests) })
+	t.Run("BoardWebhookStore", func(t *testing.T) { storetests.StoreTestBoardWebhookStore(t, SetupTests) })
+	t.Run("IncomingWebhookStore", func(t *testing.T) { storetests.StoreTestIncomingWebhookStore(t, SetupTests) })
+	t.Run("RetentionPolicyStore", func(t *testing.T) { storetests.StoreTestRetentionPolicyStore(t, SetupTests) })
+	t.Run("ShareLinkStore", func(t *testing.T) { storetests.StoreTestShareLinkStore(t, SetupTests) })
+	t.Run("GuestCommentStore", func(t *testing.T) { storetests.StoreTestGuestCommentStore(t, SetupTests) })
+	t.Run("TimeEntryStore", func(t *testing.T) { storetests.StoreTestTimeEntryStore(t, SetupTests) })
+	t.Run("IterationStore", func(t *testing.T) { storetests.StoreTestIterationStore(t, SetupTests) })
+	t.Run("CardReminderStore", func(t *testing.T) { storetests.StoreTestCardReminderStore(t, SetupTests) })
+	t.Run("NotificationDigestStore", func(t *testing.T) { storetests.StoreTestNotificationDigestStore(t, SetupTests) })
+	t.Run("CardPreviewPostStore", func(t *testing.T) { storetests.StoreTestCardPreviewPostStore(t, SetupTests) })
+	t.Run("ChannelFeedStore", func(t *testing.T) { storetests.StoreTestChannelFeedStore(t, SetupTests) })
+	t.Run("BoardCustomRoleStore", func(t *testing.T) { storetests.StoreTestBoardCustomRoleStore(t, SetupTests) })
 }
 
 //  tests for  utility functions inside sqlstore.go
@@ -5,6 +5,7 @@ package sqlstore
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 
 	sq "github.com/Masterminds/squirrel"
@@ -21,9 +22,15 @@ var subscriptionFields = []string{
 	"notified_at",
 	"create_at",
 	"delete_at",
+	"notify_filter",
 }
 
-func valuesForSubscription(sub *model.Subscription) []interface{} {
+func valuesForSubscription(sub *model.Subscription) ([]interface{}, error) {
+	filter, err := subscriptionFilterToDB(sub.Filter)
+	if err != nil {
+		return nil, err
+	}
+
 	return []interface{}{
 		sub.BlockType,
 		sub.BlockID,
@@ -32,7 +39,32 @@ func valuesForSubscription(sub *model.Subscription) []interface{} {
 		sub.NotifiedAt,
 		sub.CreateAt,
 		sub.DeleteAt,
+		filter,
+	}, nil
+}
+
+// subscriptionFilterToDB returns the JSON of a subscription filter, or NULL
+// for no filter.
+func subscriptionFilterToDB(filter *model.SubscriptionFilter) (interface{}, error) {
+	if filter == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(filter)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func subscriptionFilterFromDB(data []byte) (*model.SubscriptionFilter, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var filter model.SubscriptionFilter
+	if err := json.Unmarshal(data, &filter); err != nil {
+		return nil, err
 	}
+	return &filter, nil
 }
 
 func (s *SQLStore) subscriptionsFromRows(rows *sql.Rows) ([]*model.Subscription, error) {
@@ -40,6 +72,7 @@ func (s *SQLStore) subscriptionsFromRows(rows *sql.Rows) ([]*model.Subscription,
 
 	for rows.Next() {
 		var sub model.Subscription
+		var filter []byte
 		err := rows.Scan(
 			&sub.BlockType,
 			&sub.BlockID,
@@ -48,17 +81,22 @@ func (s *SQLStore) subscriptionsFromRows(rows *sql.Rows) ([]*model.Subscription,
 			&sub.NotifiedAt,
 			&sub.CreateAt,
 			&sub.DeleteAt,
+			&filter,
 		)
 		if err != nil {
 			return nil, err
 		}
+		if sub.Filter, err = subscriptionFilterFromDB(filter); err != nil {
+			return nil, err
+		}
 		subscriptions = append(subscriptions, &sub)
 	}
 	return subscriptions, nil
 }
 
 // createSubscription creates a new subscription, or returns an existing subscription
-// for the block & subscriber.
+// for the block & subscriber. The filter of an existing subscription is only
+// replaced when a filter is given.
 func (s *SQLStore) createSubscription(db sq.BaseRunner, sub *model.Subscription) (*model.Subscription, error) {
 	if err := sub.IsValid(); err != nil {
 		return nil, err
@@ -71,15 +109,27 @@ func (s *SQLStore) createSubscription(db sq.BaseRunner, sub *model.Subscription)
 	subAdd.CreateAt = now
 	subAdd.DeleteAt = 0
 
+	values, err := valuesForSubscription(&subAdd)
+	if err != nil {
+		return nil, err
+	}
+
 	query := s.getQueryBuilder(db).
 		Insert(s.tablePrefix + "subscriptions").
 		Columns(subscriptionFields...).
-		Values(valuesForSubscription(&subAdd)...)
+		Values(values...)
+
+	update := "delete_at = 0, notified_at = ?"
+	args := []interface{}{now}
+	if subAdd.Filter != nil {
+		update += ", notify_filter = ?"
+		args = append(args, values[len(values)-1])
+	}
 
 	if s.dbType == model.MysqlDBType {
-		query = query.Suffix("ON DUPLICATE KEY UPDATE delete_at = 0, notified_at = ?", now)
+		query = query.Suffix("ON DUPLICATE KEY UPDATE "+update, args...)
 	} else {
-		query = query.Suffix("ON CONFLICT (block_id,subscriber_id) DO UPDATE SET delete_at = 0, notified_at = ?", now)
+		query = query.Suffix("ON CONFLICT (block_id,subscriber_id) DO UPDATE SET "+update, args...)
 	}
 
 	if _, err := query.Exec(); err != nil {
@@ -121,6 +171,32 @@ func (s *SQLStore) deleteSubscription(db sq.BaseRunner, blockID string, subscrib
 	return nil
 }
 
+// updateSubscriptionFilter replaces the filter of the subscription for a specific
+// block and subscriber; a nil filter notifies the subscriber of every change.
+func (s *SQLStore) updateSubscriptionFilter(db sq.BaseRunner, blockID string, subscriberID string, filter *model.SubscriptionFilter) error {
+	filterValue, err := subscriptionFilterToDB(filter)
+	if err != nil {
+		return err
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"subscriptions").
+		Set("notify_filter", filterValue).
+		Where(sq.Eq{"block_id": blockID}).
+		Where(sq.Eq{"subscriber_id": subscriberID}).
+		Where(sq.Eq{"delete_at": 0})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot update subscription filter",
+			mlog.String("block_id", blockID),
+			mlog.String("subscriber_id", subscriberID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
 // getSubscription fetches the subscription for a specific block and subscriber.
 func (s *SQLStore) getSubscription(db sq.BaseRunner, blockID string, subscriberID string) (*model.Subscription, error) {
 	query := s.getQueryBuilder(db).
@@ -185,6 +261,7 @@ func (s *SQLStore) getSubscribersForBlock(db sq.BaseRunner, blockID string) ([]*
 			"subscriber_type",
 			"subscriber_id",
 			"notified_at",
+			"notify_filter",
 		).
 		From(s.tablePrefix + "subscriptions").
 		Where(sq.Eq{"block_id": blockID}).
@@ -205,14 +282,19 @@ func (s *SQLStore) getSubscribersForBlock(db sq.BaseRunner, blockID string) ([]*
 
 	for rows.Next() {
 		var sub model.Subscriber
+		var filter []byte
 		err := rows.Scan(
 			&sub.SubscriberType,
 			&sub.SubscriberID,
 			&sub.NotifiedAt,
+			&filter,
 		)
 		if err != nil {
 			return nil, err
 		}
+		if sub.Filter, err = subscriptionFilterFromDB(filter); err != nil {
+			return nil, err
+		}
 		subscribers = append(subscribers, &sub)
 	}
 	return subscribers, nil
@@ -0,0 +1,232 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var timeEntryFields = []string{
+	"id",
+	"card_id",
+	"board_id",
+	"user_id",
+	"start_at",
+	"duration",
+	"running",
+	"note",
+	"create_at",
+	"update_at",
+}
+
+func timeEntriesFromRows(rows *sql.Rows) ([]*model.TimeEntry, error) {
+	entries := []*model.TimeEntry{}
+
+	for rows.Next() {
+		var entry model.TimeEntry
+		var note sql.NullString
+
+		err := rows.Scan(
+			&entry.ID,
+			&entry.CardID,
+			&entry.BoardID,
+			&entry.UserID,
+			&entry.StartAt,
+			&entry.Duration,
+			&entry.Running,
+			&note,
+			&entry.CreateAt,
+			&entry.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entry.Note = note.String
+
+		entries = append(entries, &entry)
+	}
+	return entries, nil
+}
+
+func (s *SQLStore) createTimeEntry(db sq.BaseRunner, entry *model.TimeEntry) (*model.TimeEntry, error) {
+	if err := entry.IsValid(); err != nil {
+		return nil, err
+	}
+
+	now := model.GetMillis()
+
+	entryAdd := *entry
+	entryAdd.CreateAt = now
+	entryAdd.UpdateAt = now
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"time_entries").
+		Columns(timeEntryFields...).
+		Values(
+			entryAdd.ID,
+			entryAdd.CardID,
+			entryAdd.BoardID,
+			entryAdd.UserID,
+			entryAdd.StartAt,
+			entryAdd.Duration,
+			entryAdd.Running,
+			entryAdd.Note,
+			entryAdd.CreateAt,
+			entryAdd.UpdateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create time entry", mlog.String("time_entry_id", entry.ID), mlog.Err(err))
+		return nil, err
+	}
+	return &entryAdd, nil
+}
+
+// updateTimeEntry replaces the start time, duration, running state and note of
+// a time entry.
+func (s *SQLStore) updateTimeEntry(db sq.BaseRunner, entry *model.TimeEntry) (*model.TimeEntry, error) {
+	if err := entry.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"time_entries").
+		Set("start_at", entry.StartAt).
+		Set("duration", entry.Duration).
+		Set("running", entry.Running).
+		Set("note", entry.Note).
+		Set("update_at", model.GetMillis()).
+		Where(sq.Eq{"id": entry.ID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("Cannot update time entry", mlog.String("time_entry_id", entry.ID), mlog.Err(err))
+		return nil, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, model.NewErrNotFound("time entry ID=" + entry.ID)
+	}
+
+	return s.getTimeEntry(db, entry.ID)
+}
+
+func (s *SQLStore) getTimeEntry(db sq.BaseRunner, id string) (*model.TimeEntry, error) {
+	query := s.getQueryBuilder(db).
+		Select(timeEntryFields...).
+		From(s.tablePrefix + "time_entries").
+		Where(sq.Eq{"id": id})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch time entry", mlog.String("time_entry_id", id), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	entries, err := timeEntriesFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		return nil, model.NewErrNotFound("time entry ID=" + id)
+	}
+	return entries[0], nil
+}
+
+// getTimeEntriesForCard returns the time entries of a card, oldest first.
+func (s *SQLStore) getTimeEntriesForCard(db sq.BaseRunner, cardID string) ([]*model.TimeEntry, error) {
+	query := s.getQueryBuilder(db).
+		Select(timeEntryFields...).
+		From(s.tablePrefix+"time_entries").
+		Where(sq.Eq{"card_id": cardID}).
+		OrderBy("start_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch time entries for card", mlog.String("card_id", cardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return timeEntriesFromRows(rows)
+}
+
+// getTimeEntriesForBoard returns the time entries of the cards of a board,
+// oldest first.
+func (s *SQLStore) getTimeEntriesForBoard(db sq.BaseRunner, boardID string) ([]*model.TimeEntry, error) {
+	query := s.getQueryBuilder(db).
+		Select(timeEntryFields...).
+		From(s.tablePrefix+"time_entries").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("start_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch time entries for board", mlog.String("board_id", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return timeEntriesFromRows(rows)
+}
+
+// getRunningTimeEntry returns the running timer of a user.
+func (s *SQLStore) getRunningTimeEntry(db sq.BaseRunner, userID string) (*model.TimeEntry, error) {
+	query := s.getQueryBuilder(db).
+		Select(timeEntryFields...).
+		From(s.tablePrefix + "time_entries").
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Eq{"running": true}).
+		OrderBy("start_at DESC").
+		Limit(1)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch running time entry", mlog.String("user_id", userID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	entries, err := timeEntriesFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		return nil, model.NewErrNotFound("running time entry UserID=" + userID)
+	}
+	return entries[0], nil
+}
+
+func (s *SQLStore) deleteTimeEntry(db sq.BaseRunner, id string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "time_entries").
+		Where(sq.Eq{"id": id})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return model.NewErrNotFound("time entry ID=" + id)
+	}
+	return nil
+}
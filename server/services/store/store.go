@@ -39,6 +39,8 @@ type Store interface {
 	GetBlock(blockID string) (*model.Block, error)
 	// @withTransaction
 	PatchBlock(blockID string, blockPatch *model.BlockPatch, userID string) error
+	// @withTransaction
+	UpdateCardComputedProperties(blockID string, properties map[string]interface{}) error
 	GetBlockHistory(blockID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error)
 	GetBlockHistoryDescendants(boardID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error)
 	GetBlockHistoryNewestChildren(parentID string, opts model.QueryBlockHistoryChildOptions) ([]*model.Block, bool, error)
@@ -50,6 +52,8 @@ type Store interface {
 	// @withTransaction
 	DuplicateBlock(boardID string, blockID string, userID string, asTemplate bool) ([]*model.Block, error)
 	// @withTransaction
+	MoveCardToBoard(cardID string, boardID string, modifiedBy string) ([]*model.Block, error)
+	// @withTransaction
 	PatchBlocks(blockPatches *model.BlockPatchBatch, userID string) error
 
 	Shutdown() error
@@ -100,6 +104,7 @@ type Store interface {
 	CanSeeUser(seerID string, seenID string) (bool, error)
 	SearchBoardsForUser(term string, searchField model.BoardSearchField, userID string, includePublicBoards bool) ([]*model.Board, error)
 	SearchBoardsForUserInTeam(teamID, term, userID string) ([]*model.Board, error)
+	SearchCards(opts model.CardSearchOptions) ([]*model.CardSearchResult, error)
 
 	// @withTransaction
 	CreateBoardsAndBlocksWithAdmin(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, []*model.BoardMember, error)
@@ -129,8 +134,86 @@ type Store interface {
 	ReorderCategoryBoards(categoryID string, newBoardsOrder []string) ([]string, error)
 	SetBoardVisibility(userID, categoryID, boardID string, visible bool) error
 
+	CreateCardDependency(dependency *model.CardDependency) (*model.CardDependency, error)
+	GetCardDependency(id string) (*model.CardDependency, error)
+	GetCardDependenciesForCard(cardID string) ([]*model.CardDependency, error)
+	GetCardDependenciesForBoard(boardID string) ([]*model.CardDependency, error)
+	DeleteCardDependency(id string) error
+
+	SaveCardRecurrence(recurrence *model.CardRecurrence) (*model.CardRecurrence, error)
+	GetCardRecurrence(cardID string) (*model.CardRecurrence, error)
+	GetCardRecurrencesForBoard(boardID string) ([]*model.CardRecurrence, error)
+	GetDueCardRecurrences(until int64) ([]*model.CardRecurrence, error)
+	AdvanceCardRecurrence(recurrence *model.CardRecurrence, nextRunAt int64) (bool, error)
+	DeleteCardRecurrence(cardID string) error
+
+	SaveCardReminderSettings(settings *model.CardReminderSettings) (*model.CardReminderSettings, error)
+	GetCardReminderSettings(boardID string) (*model.CardReminderSettings, error)
+	GetAllCardReminderSettings() ([]*model.CardReminderSettings, error)
+	DeleteCardReminderSettings(boardID string) error
+	CreateCardReminder(reminder *model.CardReminder) (bool, error)
+	DeleteCardRemindersDueBefore(dueAt int64) error
+
+	CreateBoardRule(rule *model.BoardRule) (*model.BoardRule, error)
+	UpdateBoardRule(rule *model.BoardRule) (*model.BoardRule, error)
+	GetBoardRule(id string) (*model.BoardRule, error)
+	GetBoardRulesForBoard(boardID string) ([]*model.BoardRule, error)
+	DeleteBoardRule(id string) error
+
+	CreateBoardWebhook(webhook *model.BoardWebhook) (*model.BoardWebhook, error)
+	UpdateBoardWebhook(webhook *model.BoardWebhook) (*model.BoardWebhook, error)
+	GetBoardWebhook(id string) (*model.BoardWebhook, error)
+	GetBoardWebhooksForBoard(boardID string) ([]*model.BoardWebhook, error)
+	// @withTransaction
+	DeleteBoardWebhook(id string) error
+	InsertWebhookDelivery(delivery *model.WebhookDelivery) error
+	UpdateWebhookDelivery(delivery *model.WebhookDelivery) error
+	GetWebhookDeliveries(webhookID string, page int, perPage int) ([]*model.WebhookDelivery, error)
+	GetDueWebhookDeliveries(until int64, maxDeliveries int) ([]*model.WebhookDelivery, error)
+	ClaimWebhookDelivery(delivery *model.WebhookDelivery, until int64) (bool, error)
+
+	CreateIncomingWebhook(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error)
+	UpdateIncomingWebhook(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error)
+	GetIncomingWebhook(id string) (*model.IncomingWebhook, error)
+	GetIncomingWebhooksForBoard(boardID string) ([]*model.IncomingWebhook, error)
+	DeleteIncomingWebhook(id string) error
+
+	CreateShareLink(link *model.ShareLink) (*model.ShareLink, error)
+	UpdateShareLink(link *model.ShareLink) (*model.ShareLink, error)
+	GetShareLink(id string) (*model.ShareLink, error)
+	GetShareLinkByToken(boardID, token string) (*model.ShareLink, error)
+	GetShareLinksForBoard(boardID string) ([]*model.ShareLink, error)
+	// @withTransaction
+	DeleteShareLink(id string) error
+	InsertShareLinkAccess(access *model.ShareLinkAccess) error
+	GetShareLinkAccesses(shareLinkID string, page int, perPage int) ([]*model.ShareLinkAccess, error)
+	CountFailedShareLinkAccessesSince(shareLinkID string, remoteAddr string, since int64) (int, error)
+
+	// @withTransaction
+	CreateGuestComment(comment *model.GuestComment, limit int, since int64) (*model.GuestComment, error)
+	UpdateGuestCommentStatus(comment *model.GuestComment) (bool, error)
+	ResetGuestCommentApproval(comment *model.GuestComment) error
+	GetGuestComment(id string) (*model.GuestComment, error)
+	GetGuestCommentsForBoard(boardID string, status string) ([]*model.GuestComment, error)
+	CountGuestCommentsSince(shareLinkID string, remoteAddr string, since int64) (int, error)
+
+	CreateTimeEntry(entry *model.TimeEntry) (*model.TimeEntry, error)
+	UpdateTimeEntry(entry *model.TimeEntry) (*model.TimeEntry, error)
+	GetTimeEntry(id string) (*model.TimeEntry, error)
+	GetTimeEntriesForCard(cardID string) ([]*model.TimeEntry, error)
+	GetTimeEntriesForBoard(boardID string) ([]*model.TimeEntry, error)
+	GetRunningTimeEntry(userID string) (*model.TimeEntry, error)
+	DeleteTimeEntry(id string) error
+
+	CreateIteration(iteration *model.Iteration) (*model.Iteration, error)
+	UpdateIteration(iteration *model.Iteration) (*model.Iteration, error)
+	GetIteration(id string) (*model.Iteration, error)
+	GetIterationsForBoard(boardID string) ([]*model.Iteration, error)
+	DeleteIteration(id string) error
+
 	CreateSubscription(sub *model.Subscription) (*model.Subscription, error)
 	DeleteSubscription(blockID string, subscriberID string) error
+	UpdateSubscriptionFilter(blockID string, subscriberID string, filter *model.SubscriptionFilter) error
 	GetSubscription(blockID string, subscriberID string) (*model.Subscription, error)
 	GetSubscriptions(subscriberID string) ([]*model.Subscription, error)
 	GetSubscribersForBlock(blockID string) ([]*model.Subscriber, error)
@@ -142,11 +225,43 @@ type Store interface {
 	GetNotificationHint(blockID string) (*model.NotificationHint, error)
 	GetNextNotificationHint(remove bool) (*model.NotificationHint, error)
 
+	AddNotificationDigestItem(item *model.NotificationDigestItem) error
+	GetDueNotificationDigestUserIDs(notifyAt int64) ([]string, error)
+	// @withTransaction
+	TakeNotificationDigestItems(userID string) ([]*model.NotificationDigestItem, error)
+
+	SaveCardPreviewPost(previewPost *model.CardPreviewPost) error
+	GetCardPreviewPostIDs(cardID string) ([]string, error)
+	GetCardPreviewPostIDsForBoard(boardID string) ([]string, error)
+	DeleteCardPreviewPost(postID string) error
+
+	SaveChannelFeedSettings(settings *model.ChannelFeedSettings) (*model.ChannelFeedSettings, error)
+	GetChannelFeedSettings(boardID string) (*model.ChannelFeedSettings, error)
+	// @withTransaction
+	DeleteChannelFeedSettings(boardID string) error
+	AddChannelFeedItem(item *model.ChannelFeedItem) error
+	GetDueChannelFeedBoardIDs(notifyAt int64) ([]string, error)
+	TakeChannelFeedItems(boardID string) ([]*model.ChannelFeedItem, error)
+
+	CreateBoardCustomRole(role *model.BoardCustomRole) (*model.BoardCustomRole, error)
+	UpdateBoardCustomRole(role *model.BoardCustomRole) (*model.BoardCustomRole, error)
+	GetBoardCustomRole(id string) (*model.BoardCustomRole, error)
+	GetBoardCustomRolesForBoard(boardID string) ([]*model.BoardCustomRole, error)
+	// @withTransaction
+	DeleteBoardCustomRole(id string) error
+
 	RemoveDefaultTemplates(boards []*model.Board) error
 	GetTemplateBoards(teamID, userID string) ([]*model.Board, error)
 
 	// @withTransaction
-	RunDataRetention(globalRetentionDate int64, batchSize int64) (int64, error)
+	RunDataRetention(cutoffs *model.RetentionCutoffs, batchSize int64) (int64, error)
+	GetDataRetentionPreview(cutoffs *model.RetentionCutoffs) (*model.RetentionPreview, error)
+
+	CreateRetentionPolicy(policy *model.RetentionPolicy) (*model.RetentionPolicy, error)
+	UpdateRetentionPolicy(policy *model.RetentionPolicy) (*model.RetentionPolicy, error)
+	GetRetentionPolicy(id string) (*model.RetentionPolicy, error)
+	GetRetentionPolicies() ([]*model.RetentionPolicy, error)
+	DeleteRetentionPolicy(id string) error
 
 	GetCardsCount() (int64, error)
 	GetUsedCardsCount() (int64, error)
@@ -40,6 +40,11 @@ func StoreTestBlocksStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testPatchBlock(t, store)
 	})
+	t.Run("UpdateCardComputedProperties", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateCardComputedProperties(t, store)
+	})
 	t.Run("PatchBlocks", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -457,6 +462,36 @@ func testPatchBlock(t *testing.T, store store.Store) {
 	})
 }
 
+func testUpdateCardComputedProperties(t *testing.T, store store.Store) {
+	card := &model.Block{
+		ID:      "card-id",
+		BoardID: "board-id",
+		Type:    model.TypeCard,
+		Title:   "card",
+		Fields: map[string]interface{}{
+			"icon":       "🚀",
+			"properties": map[string]interface{}{"points": "3", "total": "0"},
+		},
+	}
+	require.NoError(t, store.InsertBlock(card, "user-id-1"))
+
+	err := store.UpdateCardComputedProperties(card.ID, map[string]interface{}{"points": "3", "total": "8"})
+	require.NoError(t, err)
+
+	updated, err := store.GetBlock(card.ID)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"points": "3", "total": "8"}, updated.Fields["properties"])
+	require.Equal(t, "🚀", updated.Fields["icon"])
+	require.Equal(t, "user-id-1", updated.ModifiedBy)
+
+	history, err := store.GetBlockHistory(card.ID, model.QueryBlockHistoryOptions{})
+	require.NoError(t, err)
+	require.Len(t, history, 1, "the computed values are not recorded in the history")
+
+	err = store.UpdateCardComputedProperties("invalid-block-id", map[string]interface{}{})
+	require.True(t, model.IsErrNotFound(err))
+}
+
 func testPatchBlocks(t *testing.T, store store.Store) {
 	block := &model.Block{
 		ID:      "id-test",
@@ -0,0 +1,119 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestBoardCustomRoleStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateAndUpdateBoardCustomRole", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAndUpdateBoardCustomRole(t, store)
+	})
+	t.Run("DeleteBoardCustomRole", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteBoardCustomRole(t, store)
+	})
+}
+
+func testCreateAndUpdateBoardCustomRole(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+
+	role, err := store.CreateBoardCustomRole(&model.BoardCustomRole{
+		ID:          utils.NewID(utils.IDTypeNone),
+		BoardID:     boardID,
+		Name:        "Mover",
+		Permissions: []string{model.PermissionCommentBoardCards.Id, model.PermissionEditBoardCardProperties.Id},
+		PropertyIDs: []string{"status"},
+		CreatedBy:   "user-id",
+		ModifiedBy:  "user-id",
+	})
+	require.NoError(t, err)
+
+	_, err = store.CreateBoardCustomRole(&model.BoardCustomRole{
+		ID:          utils.NewID(utils.IDTypeNone),
+		BoardID:     boardID,
+		Name:        "Contractor",
+		Permissions: []string{model.PermissionManageBoardCards.Id},
+		CreatedBy:   "user-id",
+		ModifiedBy:  "user-id",
+	})
+	require.NoError(t, err)
+
+	saved, err := store.GetBoardCustomRole(role.ID)
+	require.NoError(t, err)
+	require.Equal(t, role.Permissions, saved.Permissions)
+	require.Equal(t, []string{"status"}, saved.PropertyIDs)
+	require.NotZero(t, saved.CreateAt)
+
+	roles, err := store.GetBoardCustomRolesForBoard(boardID)
+	require.NoError(t, err)
+	require.Len(t, roles, 2)
+	require.Equal(t, "Contractor", roles[0].Name, "roles are sorted by name")
+	require.Empty(t, roles[0].PropertyIDs)
+
+	t.Run("update the role", func(t *testing.T) {
+		saved.Name = "Status mover"
+		saved.PropertyIDs = []string{"status", "priority"}
+		saved.ModifiedBy = "other-user-id"
+		updated, err := store.UpdateBoardCustomRole(saved)
+		require.NoError(t, err)
+		require.Equal(t, "Status mover", updated.Name)
+		require.Equal(t, []string{"status", "priority"}, updated.PropertyIDs)
+		require.Equal(t, "other-user-id", updated.ModifiedBy)
+	})
+
+	t.Run("update a missing role", func(t *testing.T) {
+		missing := *saved
+		missing.ID = utils.NewID(utils.IDTypeNone)
+		_, err := store.UpdateBoardCustomRole(&missing)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testDeleteBoardCustomRole(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+
+	role, err := store.CreateBoardCustomRole(&model.BoardCustomRole{
+		ID:          utils.NewID(utils.IDTypeNone),
+		BoardID:     boardID,
+		Name:        "Contractor",
+		Permissions: []string{model.PermissionManageBoardCards.Id},
+		CreatedBy:   "user-id",
+		ModifiedBy:  "user-id",
+	})
+	require.NoError(t, err)
+
+	_, err = store.SaveMember(&model.BoardMember{
+		BoardID:      boardID,
+		UserID:       "user-id",
+		SchemeViewer: true,
+		CustomRoleID: role.ID,
+	})
+	require.NoError(t, err)
+
+	member, err := store.GetMemberForBoard(boardID, "user-id")
+	require.NoError(t, err)
+	require.Equal(t, role.ID, member.CustomRoleID)
+
+	require.NoError(t, store.DeleteBoardCustomRole(role.ID))
+
+	_, err = store.GetBoardCustomRole(role.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	member, err = store.GetMemberForBoard(boardID, "user-id")
+	require.NoError(t, err)
+	require.Empty(t, member.CustomRoleID, "the members of a deleted role are left with their scheme roles")
+	require.True(t, member.SchemeViewer)
+
+	require.True(t, model.IsErrNotFound(store.DeleteBoardCustomRole(role.ID)))
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestBoardRuleStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateAndGetBoardRule", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAndGetBoardRule(t, store)
+	})
+	t.Run("UpdateBoardRule", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateBoardRule(t, store)
+	})
+	t.Run("DeleteBoardRule", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteBoardRule(t, store)
+	})
+	t.Run("MoveCardToBoard", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testMoveCardToBoard(t, store)
+	})
+}
+
+func createTestBoardRule(t *testing.T, store store.Store, boardID, userID string) *model.BoardRule {
+	rule, err := store.CreateBoardRule(&model.BoardRule{
+		ID:      utils.NewID(utils.IDTypeBlock),
+		BoardID: boardID,
+		Title:   "status done",
+		Enabled: true,
+		Trigger: model.RuleTrigger{PropertyID: "status", Value: "done"},
+		Actions: []model.RuleAction{
+			{Type: model.RuleActionSetProperty, PropertyID: "priority", Value: "low"},
+			{Type: model.RuleActionAddComment, Message: "Done!"},
+		},
+		CreatedBy:  userID,
+		ModifiedBy: userID,
+	})
+	require.NoError(t, err)
+	return rule
+}
+
+func testCreateAndGetBoardRule(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	boards := createTestBoards(t, store, utils.NewID(utils.IDTypeTeam), userID, 2)
+
+	rule1 := createTestBoardRule(t, store, boards[0].ID, userID)
+	rule2 := createTestBoardRule(t, store, boards[0].ID, userID)
+	createTestBoardRule(t, store, boards[1].ID, userID)
+	require.NotZero(t, rule1.CreateAt)
+
+	t.Run("get by id", func(t *testing.T) {
+		got, err := store.GetBoardRule(rule1.ID)
+		require.NoError(t, err)
+		require.Equal(t, rule1, got)
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		got, err := store.GetBoardRule(utils.NewID(utils.IDTypeBlock))
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, got)
+	})
+
+	t.Run("get for board", func(t *testing.T) {
+		rules, err := store.GetBoardRulesForBoard(boards[0].ID)
+		require.NoError(t, err)
+		require.Len(t, rules, 2)
+		require.ElementsMatch(t, []string{rule1.ID, rule2.ID}, []string{rules[0].ID, rules[1].ID})
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		_, err := store.CreateBoardRule(&model.BoardRule{
+			ID:      utils.NewID(utils.IDTypeBlock),
+			BoardID: boards[0].ID,
+		})
+		require.Error(t, err)
+	})
+}
+
+func testUpdateBoardRule(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	boards := createTestBoards(t, store, utils.NewID(utils.IDTypeTeam), userID, 2)
+	rule := createTestBoardRule(t, store, boards[0].ID, userID)
+
+	t.Run("update", func(t *testing.T) {
+		otherUserID := utils.NewID(utils.IDTypeUser)
+		update := *rule
+		update.Title = "status archived"
+		update.Enabled = false
+		update.Trigger = model.RuleTrigger{PropertyID: "status", Value: "archived"}
+		update.Actions = []model.RuleAction{{Type: model.RuleActionMoveCard, BoardID: boards[1].ID}}
+		update.ModifiedBy = otherUserID
+
+		updated, err := store.UpdateBoardRule(&update)
+		require.NoError(t, err)
+		require.Equal(t, "status archived", updated.Title)
+		require.False(t, updated.Enabled)
+		require.Equal(t, update.Trigger, updated.Trigger)
+		require.Equal(t, update.Actions, updated.Actions)
+		require.Equal(t, otherUserID, updated.ModifiedBy)
+		require.Equal(t, userID, updated.CreatedBy)
+		require.Equal(t, rule.CreateAt, updated.CreateAt)
+	})
+
+	t.Run("update on another board", func(t *testing.T) {
+		update := *rule
+		update.BoardID = boards[1].ID
+		_, err := store.UpdateBoardRule(&update)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testDeleteBoardRule(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	boards := createTestBoards(t, store, utils.NewID(utils.IDTypeTeam), userID, 1)
+	rule := createTestBoardRule(t, store, boards[0].ID, userID)
+
+	require.NoError(t, store.DeleteBoardRule(rule.ID))
+
+	_, err := store.GetBoardRule(rule.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	err = store.DeleteBoardRule(rule.ID)
+	require.True(t, model.IsErrNotFound(err))
+}
+
+func testMoveCardToBoard(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	boards := createTestBoards(t, store, utils.NewID(utils.IDTypeTeam), userID, 2)
+	cards := createTestCards(t, store, userID, boards[0].ID, 2)
+	children := createTestBlocksForCard(t, store, cards[0].ID, 2)
+	createTestCardDependency(t, store, cards[1], cards[0])
+
+	otherUserID := utils.NewID(utils.IDTypeUser)
+	moved, err := store.MoveCardToBoard(cards[0].ID, boards[1].ID, otherUserID)
+	require.NoError(t, err)
+	require.Len(t, moved, 3)
+
+	for _, id := range []string{cards[0].ID, children[0].ID, children[1].ID} {
+		block, err := store.GetBlock(id)
+		require.NoError(t, err)
+		require.Equal(t, boards[1].ID, block.BoardID)
+		require.Equal(t, otherUserID, block.ModifiedBy)
+	}
+
+	blocks, err := store.GetBlocks(model.QueryBlocksOptions{BoardID: boards[0].ID})
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	require.Equal(t, cards[1].ID, blocks[0].ID)
+
+	dependencies, err := store.GetCardDependenciesForBoard(boards[1].ID)
+	require.NoError(t, err)
+	require.Len(t, dependencies, 1)
+	require.Equal(t, boards[1].ID, dependencies[0].BlockedBoardID)
+	require.Equal(t, boards[0].ID, dependencies[0].BlockingBoardID)
+
+	t.Run("not a card", func(t *testing.T) {
+		_, err := store.MoveCardToBoard(children[0].ID, boards[0].ID, userID)
+		require.ErrorIs(t, err, model.ErrNotCardBlock)
+	})
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestBoardWebhookStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateAndGetBoardWebhook", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAndGetBoardWebhook(t, store)
+	})
+	t.Run("UpdateBoardWebhook", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateBoardWebhook(t, store)
+	})
+	t.Run("DeleteBoardWebhook", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteBoardWebhook(t, store)
+	})
+	t.Run("WebhookDeliveries", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testWebhookDeliveries(t, store)
+	})
+	t.Run("ClaimWebhookDelivery", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testClaimWebhookDelivery(t, store)
+	})
+}
+
+func createTestBoardWebhook(t *testing.T, store store.Store, boardID, userID string) *model.BoardWebhook {
+	webhook, err := store.CreateBoardWebhook(&model.BoardWebhook{
+		ID:        utils.NewID(utils.IDTypeBlock),
+		BoardID:   boardID,
+		URL:       "https://example.com/hook",
+		Secret:    utils.NewID(utils.IDTypeToken),
+		Events:    []string{model.WebhookEventCardCreated, model.WebhookEventCommentAdded},
+		Enabled:   true,
+		CreatedBy: userID,
+	})
+	require.NoError(t, err)
+	return webhook
+}
+
+func createTestWebhookDelivery(t *testing.T, store store.Store, webhook *model.BoardWebhook, nextAttemptAt int64) *model.WebhookDelivery {
+	delivery := &model.WebhookDelivery{
+		ID:            utils.NewID(utils.IDTypeNone),
+		WebhookID:     webhook.ID,
+		BoardID:       webhook.BoardID,
+		Event:         model.WebhookEventCardCreated,
+		Payload:       `{"event":"card.created"}`,
+		Status:        model.WebhookDeliveryPending,
+		NextAttemptAt: nextAttemptAt,
+	}
+	require.NoError(t, store.InsertWebhookDelivery(delivery))
+	return delivery
+}
+
+func testCreateAndGetBoardWebhook(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	boards := createTestBoards(t, store, utils.NewID(utils.IDTypeTeam), userID, 2)
+
+	webhook1 := createTestBoardWebhook(t, store, boards[0].ID, userID)
+	webhook2 := createTestBoardWebhook(t, store, boards[0].ID, userID)
+	createTestBoardWebhook(t, store, boards[1].ID, userID)
+
+	t.Run("get a webhook", func(t *testing.T) {
+		webhook, err := store.GetBoardWebhook(webhook1.ID)
+		require.NoError(t, err)
+		require.Equal(t, webhook1.URL, webhook.URL)
+		require.Equal(t, webhook1.Secret, webhook.Secret)
+		require.Equal(t, webhook1.Events, webhook.Events)
+		require.True(t, webhook.Enabled)
+	})
+
+	t.Run("get the webhooks of a board", func(t *testing.T) {
+		webhooks, err := store.GetBoardWebhooksForBoard(boards[0].ID)
+		require.NoError(t, err)
+		require.Len(t, webhooks, 2)
+		require.ElementsMatch(t, []string{webhook1.ID, webhook2.ID}, []string{webhooks[0].ID, webhooks[1].ID})
+	})
+
+	t.Run("get a nonexistent webhook", func(t *testing.T) {
+		webhook, err := store.GetBoardWebhook(utils.NewID(utils.IDTypeBlock))
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, webhook)
+	})
+
+	t.Run("create an invalid webhook", func(t *testing.T) {
+		webhook, err := store.CreateBoardWebhook(&model.BoardWebhook{
+			ID:      utils.NewID(utils.IDTypeBlock),
+			BoardID: boards[0].ID,
+			URL:     "ftp://example.com",
+			Events:  []string{model.WebhookEventCardCreated},
+		})
+		require.Error(t, err)
+		require.Nil(t, webhook)
+	})
+}
+
+func testUpdateBoardWebhook(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	boards := createTestBoards(t, store, utils.NewID(utils.IDTypeTeam), userID, 1)
+	webhook := createTestBoardWebhook(t, store, boards[0].ID, userID)
+
+	webhook.URL = "https://example.com/other"
+	webhook.Events = []string{model.WebhookEventBoardDeleted}
+	webhook.Enabled = false
+	webhook.Secret = "changed"
+
+	updatedWebhook, err := store.UpdateBoardWebhook(webhook)
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/other", updatedWebhook.URL)
+	require.Equal(t, []string{model.WebhookEventBoardDeleted}, updatedWebhook.Events)
+	require.False(t, updatedWebhook.Enabled)
+	require.NotEqual(t, "changed", updatedWebhook.Secret)
+
+	t.Run("update a nonexistent webhook", func(t *testing.T) {
+		webhook.ID = utils.NewID(utils.IDTypeBlock)
+		_, err := store.UpdateBoardWebhook(webhook)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testDeleteBoardWebhook(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	boards := createTestBoards(t, store, utils.NewID(utils.IDTypeTeam), userID, 1)
+	webhook := createTestBoardWebhook(t, store, boards[0].ID, userID)
+	createTestWebhookDelivery(t, store, webhook, 0)
+
+	require.NoError(t, store.DeleteBoardWebhook(webhook.ID))
+
+	_, err := store.GetBoardWebhook(webhook.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	deliveries, err := store.GetWebhookDeliveries(webhook.ID, 0, 0)
+	require.NoError(t, err)
+	require.Empty(t, deliveries)
+
+	t.Run("delete a nonexistent webhook", func(t *testing.T) {
+		err := store.DeleteBoardWebhook(webhook.ID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testWebhookDeliveries(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	boards := createTestBoards(t, store, utils.NewID(utils.IDTypeTeam), userID, 1)
+	webhook := createTestBoardWebhook(t, store, boards[0].ID, userID)
+
+	due := createTestWebhookDelivery(t, store, webhook, 1000)
+	notDue := createTestWebhookDelivery(t, store, webhook, 5000)
+	done := createTestWebhookDelivery(t, store, webhook, 1000)
+
+	done.RecordAttempt(2000, 204, nil)
+	require.NoError(t, store.UpdateWebhookDelivery(done))
+
+	t.Run("get the due deliveries", func(t *testing.T) {
+		deliveries, err := store.GetDueWebhookDeliveries(2000, 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		require.Equal(t, due.ID, deliveries[0].ID)
+	})
+
+	t.Run("get the delivery log", func(t *testing.T) {
+		deliveries, err := store.GetWebhookDeliveries(webhook.ID, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 3)
+
+		ids := []string{}
+		for _, delivery := range deliveries {
+			ids = append(ids, delivery.ID)
+			if delivery.ID == done.ID {
+				require.Equal(t, model.WebhookDeliverySuccess, delivery.Status)
+				require.Equal(t, 1, delivery.Attempts)
+				require.Equal(t, 204, delivery.ResponseCode)
+				require.Equal(t, int64(2000), delivery.LastAttemptAt)
+			}
+		}
+		require.ElementsMatch(t, []string{due.ID, notDue.ID, done.ID}, ids)
+	})
+
+	t.Run("page the delivery log", func(t *testing.T) {
+		deliveries, err := store.GetWebhookDeliveries(webhook.ID, 1, 2)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+	})
+}
+
+func testClaimWebhookDelivery(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	boards := createTestBoards(t, store, utils.NewID(utils.IDTypeTeam), userID, 1)
+	webhook := createTestBoardWebhook(t, store, boards[0].ID, userID)
+	delivery := createTestWebhookDelivery(t, store, webhook, 1000)
+
+	claimed, err := store.ClaimWebhookDelivery(delivery, 9000)
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	// the delivery has been claimed with the fetched next attempt time
+	claimed, err = store.ClaimWebhookDelivery(delivery, 9000)
+	require.NoError(t, err)
+	require.False(t, claimed)
+
+	deliveries, err := store.GetDueWebhookDeliveries(5000, 10)
+	require.NoError(t, err)
+	require.Empty(t, deliveries)
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestCardDependencyStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateAndGetCardDependencies", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAndGetCardDependencies(t, store)
+	})
+	t.Run("DeleteCardDependency", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteCardDependency(t, store)
+	})
+	t.Run("DuplicateBoardWithCardDependencies", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDuplicateBoardWithCardDependencies(t, store)
+	})
+}
+
+func createTestCardDependency(t *testing.T, store store.Store, blocking, blocked *model.Block) *model.CardDependency {
+	dependency, err := store.CreateCardDependency(&model.CardDependency{
+		ID:              utils.NewID(utils.IDTypeBlock),
+		BlockingCardID:  blocking.ID,
+		BlockingBoardID: blocking.BoardID,
+		BlockedCardID:   blocked.ID,
+		BlockedBoardID:  blocked.BoardID,
+		CreatedBy:       blocking.CreatedBy,
+	})
+	require.NoError(t, err)
+	return dependency
+}
+
+func testCreateAndGetCardDependencies(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	teamID := utils.NewID(utils.IDTypeTeam)
+
+	boards := createTestBoards(t, store, teamID, userID, 2)
+	cards := createTestCards(t, store, userID, boards[0].ID, 3)
+	otherCards := createTestCards(t, store, userID, boards[1].ID, 1)
+
+	dependency1 := createTestCardDependency(t, store, cards[0], cards[1])
+	dependency2 := createTestCardDependency(t, store, cards[1], cards[2])
+	dependency3 := createTestCardDependency(t, store, otherCards[0], cards[0])
+
+	t.Run("get by id", func(t *testing.T) {
+		dependency, err := store.GetCardDependency(dependency1.ID)
+		require.NoError(t, err)
+		require.Equal(t, dependency1, dependency)
+		require.NotZero(t, dependency.CreateAt)
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		dependency, err := store.GetCardDependency(utils.NewID(utils.IDTypeBlock))
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, dependency)
+	})
+
+	t.Run("get for card", func(t *testing.T) {
+		dependencies, err := store.GetCardDependenciesForCard(cards[1].ID)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{dependency1.ID, dependency2.ID}, extractDependencyIDs(dependencies))
+
+		dependencies, err = store.GetCardDependenciesForCard(cards[0].ID)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{dependency1.ID, dependency3.ID}, extractDependencyIDs(dependencies))
+	})
+
+	t.Run("get for board", func(t *testing.T) {
+		dependencies, err := store.GetCardDependenciesForBoard(boards[0].ID)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{dependency1.ID, dependency2.ID, dependency3.ID}, extractDependencyIDs(dependencies))
+
+		dependencies, err = store.GetCardDependenciesForBoard(boards[1].ID)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{dependency3.ID}, extractDependencyIDs(dependencies))
+	})
+
+	t.Run("duplicated dependency", func(t *testing.T) {
+		_, err := store.CreateCardDependency(&model.CardDependency{
+			ID:              utils.NewID(utils.IDTypeBlock),
+			BlockingCardID:  cards[0].ID,
+			BlockingBoardID: boards[0].ID,
+			BlockedCardID:   cards[1].ID,
+			BlockedBoardID:  boards[0].ID,
+			CreatedBy:       userID,
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("dependencies of deleted cards are hidden", func(t *testing.T) {
+		require.NoError(t, store.DeleteBlock(cards[2].ID, userID))
+
+		dependencies, err := store.GetCardDependenciesForCard(cards[1].ID)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{dependency1.ID}, extractDependencyIDs(dependencies))
+
+		require.NoError(t, store.UndeleteBlock(cards[2].ID, userID))
+
+		dependencies, err = store.GetCardDependenciesForCard(cards[1].ID)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{dependency1.ID, dependency2.ID}, extractDependencyIDs(dependencies))
+	})
+}
+
+func testDeleteCardDependency(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	boards := createTestBoards(t, store, utils.NewID(utils.IDTypeTeam), userID, 1)
+	cards := createTestCards(t, store, userID, boards[0].ID, 2)
+
+	dependency := createTestCardDependency(t, store, cards[0], cards[1])
+
+	require.NoError(t, store.DeleteCardDependency(dependency.ID))
+
+	dependencies, err := store.GetCardDependenciesForCard(cards[0].ID)
+	require.NoError(t, err)
+	require.Empty(t, dependencies)
+
+	err = store.DeleteCardDependency(dependency.ID)
+	require.True(t, model.IsErrNotFound(err))
+}
+
+func testDuplicateBoardWithCardDependencies(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	teamID := utils.NewID(utils.IDTypeTeam)
+
+	boards := createTestBoards(t, store, teamID, userID, 2)
+	cards := createTestCards(t, store, userID, boards[0].ID, 2)
+	otherCards := createTestCards(t, store, userID, boards[1].ID, 1)
+
+	createTestCardDependency(t, store, cards[0], cards[1])
+	createTestCardDependency(t, store, otherCards[0], cards[0])
+
+	bab, _, err := store.DuplicateBoard(boards[0].ID, userID, teamID, false)
+	require.NoError(t, err)
+	require.Len(t, bab.Boards, 1)
+	newBoardID := bab.Boards[0].ID
+
+	newCardIDs := map[string]string{}
+	for _, block := range bab.Blocks {
+		if block.Type == model.TypeCard {
+			newCardIDs[block.Title] = block.ID
+		}
+	}
+
+	// only the dependency between cards of the board is duplicated
+	dependencies, err := store.GetCardDependenciesForBoard(newBoardID)
+	require.NoError(t, err)
+	require.Len(t, dependencies, 1)
+	require.Equal(t, newCardIDs[cards[0].Title], dependencies[0].BlockingCardID)
+	require.Equal(t, newCardIDs[cards[1].Title], dependencies[0].BlockedCardID)
+	require.True(t, dependencies[0].IsWithinBoard(newBoardID))
+
+	// the original dependencies are kept
+	dependencies, err = store.GetCardDependenciesForBoard(boards[0].ID)
+	require.NoError(t, err)
+	require.Len(t, dependencies, 2)
+}
+
+func extractDependencyIDs(dependencies []*model.CardDependency) []string {
+	ids := make([]string, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		ids = append(ids, dependency.ID)
+	}
+	return ids
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestCardPreviewPostStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SaveAndDeleteCardPreviewPosts", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSaveAndDeleteCardPreviewPosts(t, store)
+	})
+}
+
+func testSaveAndDeleteCardPreviewPosts(t *testing.T, store store.Store) {
+	cardID := utils.NewID(utils.IDTypeCard)
+	otherCardID := utils.NewID(utils.IDTypeCard)
+
+	require.NoError(t, store.SaveCardPreviewPost(&model.CardPreviewPost{CardID: cardID, PostID: "post-1", CreateAt: 100}))
+	require.NoError(t, store.SaveCardPreviewPost(&model.CardPreviewPost{CardID: cardID, PostID: "post-2", CreateAt: 200}))
+	require.NoError(t, store.SaveCardPreviewPost(&model.CardPreviewPost{CardID: otherCardID, PostID: "post-3", CreateAt: 300}))
+
+	postIDs, err := store.GetCardPreviewPostIDs(cardID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"post-1", "post-2"}, postIDs)
+
+	t.Run("an edited post previews another card", func(t *testing.T) {
+		require.NoError(t, store.SaveCardPreviewPost(&model.CardPreviewPost{CardID: otherCardID, PostID: "post-2", CreateAt: 400}))
+
+		postIDs, err := store.GetCardPreviewPostIDs(cardID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"post-1"}, postIDs)
+
+		postIDs, err = store.GetCardPreviewPostIDs(otherCardID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"post-3", "post-2"}, postIDs)
+	})
+
+	t.Run("the posts previewing the cards of a board", func(t *testing.T) {
+		boardID := utils.NewID(utils.IDTypeBoard)
+		InsertBlocks(t, store, []*model.Block{
+			{ID: otherCardID, BoardID: boardID, ParentID: boardID, Type: model.TypeCard, CreatedBy: testUserID},
+		}, testUserID)
+
+		postIDs, err := store.GetCardPreviewPostIDsForBoard(boardID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"post-3", "post-2"}, postIDs)
+
+		postIDs, err = store.GetCardPreviewPostIDsForBoard(utils.NewID(utils.IDTypeBoard))
+		require.NoError(t, err)
+		require.Empty(t, postIDs)
+	})
+
+	t.Run("a deleted post is forgotten", func(t *testing.T) {
+		require.NoError(t, store.DeleteCardPreviewPost("post-1"))
+
+		postIDs, err := store.GetCardPreviewPostIDs(cardID)
+		require.NoError(t, err)
+		require.Empty(t, postIDs)
+	})
+}
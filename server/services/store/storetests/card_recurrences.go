@@ -0,0 +1,147 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestCardRecurrenceStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SaveAndGetCardRecurrence", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSaveAndGetCardRecurrence(t, store)
+	})
+	t.Run("GetDueAndAdvanceCardRecurrences", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetDueAndAdvanceCardRecurrences(t, store)
+	})
+	t.Run("DeleteCardRecurrence", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteCardRecurrence(t, store)
+	})
+}
+
+func createTestCardRecurrence(t *testing.T, store store.Store, card *model.Block, nextRunAt int64) *model.CardRecurrence {
+	recurrence, err := store.SaveCardRecurrence(&model.CardRecurrence{
+		CardID:    card.ID,
+		BoardID:   card.BoardID,
+		Rule:      "FREQ=DAILY",
+		StartAt:   nextRunAt - 1000,
+		NextRunAt: nextRunAt,
+		CreatedBy: card.CreatedBy,
+	})
+	require.NoError(t, err)
+	return recurrence
+}
+
+func testSaveAndGetCardRecurrence(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	boards := createTestBoards(t, store, utils.NewID(utils.IDTypeTeam), userID, 1)
+	cards := createTestCards(t, store, userID, boards[0].ID, 2)
+
+	recurrence := createTestCardRecurrence(t, store, cards[0], 5000)
+	require.NotZero(t, recurrence.CreateAt)
+
+	t.Run("get by card", func(t *testing.T) {
+		got, err := store.GetCardRecurrence(cards[0].ID)
+		require.NoError(t, err)
+		require.Equal(t, recurrence, got)
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		got, err := store.GetCardRecurrence(cards[1].ID)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, got)
+	})
+
+	t.Run("replace recurrence", func(t *testing.T) {
+		updated, err := store.SaveCardRecurrence(&model.CardRecurrence{
+			CardID:    cards[0].ID,
+			BoardID:   boards[0].ID,
+			Rule:      "FREQ=WEEKLY;BYDAY=MO",
+			StartAt:   1000,
+			NextRunAt: 9000,
+			CreatedBy: userID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "FREQ=WEEKLY;BYDAY=MO", updated.Rule)
+		require.Equal(t, int64(9000), updated.NextRunAt)
+		require.Equal(t, recurrence.CreateAt, updated.CreateAt)
+	})
+
+	t.Run("invalid recurrence", func(t *testing.T) {
+		_, err := store.SaveCardRecurrence(&model.CardRecurrence{
+			CardID: cards[1].ID,
+			Rule:   "FREQ=YEARLY",
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("get for board", func(t *testing.T) {
+		createTestCardRecurrence(t, store, cards[1], 5000)
+
+		recurrences, err := store.GetCardRecurrencesForBoard(boards[0].ID)
+		require.NoError(t, err)
+		require.Len(t, recurrences, 2)
+	})
+}
+
+func testGetDueAndAdvanceCardRecurrences(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	boards := createTestBoards(t, store, utils.NewID(utils.IDTypeTeam), userID, 1)
+	cards := createTestCards(t, store, userID, boards[0].ID, 3)
+
+	recurrence1 := createTestCardRecurrence(t, store, cards[0], 2000)
+	recurrence2 := createTestCardRecurrence(t, store, cards[1], 1000)
+	createTestCardRecurrence(t, store, cards[2], 9000)
+
+	due, err := store.GetDueCardRecurrences(5000)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	require.Equal(t, recurrence2.CardID, due[0].CardID)
+	require.Equal(t, recurrence1.CardID, due[1].CardID)
+
+	advanced, err := store.AdvanceCardRecurrence(due[0], 10000)
+	require.NoError(t, err)
+	require.True(t, advanced)
+
+	// the same run cannot be advanced twice
+	advanced, err = store.AdvanceCardRecurrence(due[0], 10000)
+	require.NoError(t, err)
+	require.False(t, advanced)
+
+	recurrence, err := store.GetCardRecurrence(recurrence2.CardID)
+	require.NoError(t, err)
+	require.Equal(t, int64(10000), recurrence.NextRunAt)
+	require.NotZero(t, recurrence.LastRunAt)
+
+	due, err = store.GetDueCardRecurrences(5000)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	require.Equal(t, recurrence1.CardID, due[0].CardID)
+}
+
+func testDeleteCardRecurrence(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	boards := createTestBoards(t, store, utils.NewID(utils.IDTypeTeam), userID, 1)
+	cards := createTestCards(t, store, userID, boards[0].ID, 1)
+
+	createTestCardRecurrence(t, store, cards[0], 5000)
+
+	require.NoError(t, store.DeleteCardRecurrence(cards[0].ID))
+
+	_, err := store.GetCardRecurrence(cards[0].ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	err = store.DeleteCardRecurrence(cards[0].ID)
+	require.True(t, model.IsErrNotFound(err))
+}
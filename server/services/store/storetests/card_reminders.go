@@ -0,0 +1,117 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestCardReminderStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SaveAndGetCardReminderSettings", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSaveAndGetCardReminderSettings(t, store)
+	})
+	t.Run("CreateCardReminder", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateCardReminder(t, store)
+	})
+}
+
+func testSaveAndGetCardReminderSettings(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	boards := createTestBoards(t, store, utils.NewID(utils.IDTypeTeam), userID, 2)
+
+	settings, err := store.SaveCardReminderSettings(&model.CardReminderSettings{
+		BoardID:           boards[0].ID,
+		DueDatePropertyID: "due",
+		HoursBefore:       24,
+		ModifiedBy:        userID,
+	})
+	require.NoError(t, err)
+	require.NotZero(t, settings.UpdateAt)
+
+	t.Run("get by board", func(t *testing.T) {
+		got, err := store.GetCardReminderSettings(boards[0].ID)
+		require.NoError(t, err)
+		require.Equal(t, settings, got)
+
+		_, err = store.GetCardReminderSettings(boards[1].ID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		replaced, err := store.SaveCardReminderSettings(&model.CardReminderSettings{
+			BoardID:           boards[0].ID,
+			DueDatePropertyID: "deadline",
+			HoursBefore:       2,
+			ModifiedBy:        userID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "deadline", replaced.DueDatePropertyID)
+		require.Equal(t, 2, replaced.HoursBefore)
+
+		all, err := store.GetAllCardReminderSettings()
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, store.DeleteCardReminderSettings(boards[0].ID))
+		require.True(t, model.IsErrNotFound(store.DeleteCardReminderSettings(boards[0].ID)))
+
+		all, err := store.GetAllCardReminderSettings()
+		require.NoError(t, err)
+		require.Empty(t, all)
+	})
+}
+
+func testCreateCardReminder(t *testing.T, store store.Store) {
+	reminder := &model.CardReminder{
+		CardID:  utils.NewID(utils.IDTypeCard),
+		BoardID: utils.NewID(utils.IDTypeBoard),
+		UserID:  utils.NewID(utils.IDTypeUser),
+		Kind:    model.CardReminderKindUpcoming,
+		DueAt:   5000,
+		SentAt:  1000,
+	}
+
+	created, err := store.CreateCardReminder(reminder)
+	require.NoError(t, err)
+	require.True(t, created)
+
+	t.Run("already sent", func(t *testing.T) {
+		created, err := store.CreateCardReminder(reminder)
+		require.NoError(t, err)
+		require.False(t, created)
+	})
+
+	t.Run("other kind and due date", func(t *testing.T) {
+		overdue := *reminder
+		overdue.Kind = model.CardReminderKindOverdue
+		created, err := store.CreateCardReminder(&overdue)
+		require.NoError(t, err)
+		require.True(t, created)
+
+		moved := *reminder
+		moved.DueAt = 9000
+		created, err = store.CreateCardReminder(&moved)
+		require.NoError(t, err)
+		require.True(t, created)
+	})
+
+	t.Run("delete old reminders", func(t *testing.T) {
+		require.NoError(t, store.DeleteCardRemindersDueBefore(6000))
+
+		created, err := store.CreateCardReminder(reminder)
+		require.NoError(t, err)
+		require.True(t, created)
+	})
+}
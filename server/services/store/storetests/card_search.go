@@ -0,0 +1,200 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestCardSearchStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SearchCards", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSearchCards(t, store)
+	})
+}
+
+func testSearchCards(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	teamID := utils.NewID(utils.IDTypeTeam)
+	notesPropID := utils.NewID(utils.IDTypeBlock)
+	statusPropID := utils.NewID(utils.IDTypeBlock)
+
+	board := &model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: teamID,
+		Type:   model.BoardTypeOpen,
+		Title:  "Search board",
+		CardProperties: []map[string]interface{}{
+			{"id": notesPropID, "name": "Notes", "type": model.PropTypeText},
+			{"id": statusPropID, "name": "Status", "type": model.PropTypeSelect, "options": []interface{}{}},
+		},
+	}
+	_, err := store.InsertBoard(board, userID)
+	require.NoError(t, err)
+
+	otherBoard := &model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: teamID,
+		Type:   model.BoardTypeOpen,
+		Title:  "Other board",
+	}
+	_, err = store.InsertBoard(otherBoard, userID)
+	require.NoError(t, err)
+
+	makeBlock := func(boardID, parentID string, blockType model.BlockType, title string, fields map[string]interface{}) *model.Block {
+		id := utils.NewID(utils.IDTypeBlock)
+		if blockType == model.TypeCard {
+			id = utils.NewID(utils.IDTypeCard)
+		}
+		return &model.Block{
+			ID:        id,
+			BoardID:   boardID,
+			ParentID:  parentID,
+			Type:      blockType,
+			CreatedBy: userID,
+			Title:     title,
+			Fields:    fields,
+		}
+	}
+
+	titleCard := makeBlock(board.ID, board.ID, model.TypeCard, "Invoice overdue", nil)
+	contentCard := makeBlock(board.ID, board.ID, model.TypeCard, "Accounting", nil)
+	content := makeBlock(board.ID, contentCard.ID, model.TypeText, "The invoice was sent to the customer", nil)
+	commentCard := makeBlock(board.ID, board.ID, model.TypeCard, "Follow up", nil)
+	comment := makeBlock(board.ID, commentCard.ID, model.TypeComment, "Customer asked for a new invoice", nil)
+	propertyCard := makeBlock(board.ID, board.ID, model.TypeCard, "Payments", map[string]interface{}{
+		"properties": map[string]interface{}{notesPropID: "Invoice number 42"},
+	})
+	selectCard := makeBlock(board.ID, board.ID, model.TypeCard, "Unrelated", map[string]interface{}{
+		"properties": map[string]interface{}{statusPropID: "invoice"},
+	})
+	templateCard := makeBlock(board.ID, board.ID, model.TypeCard, "Invoice template", map[string]interface{}{
+		"isTemplate": true,
+	})
+	otherBoardCard := makeBlock(otherBoard.ID, otherBoard.ID, model.TypeCard, "Invoice elsewhere", nil)
+
+	InsertBlocks(t, store, []*model.Block{
+		titleCard, contentCard, content, commentCard, comment,
+		propertyCard, selectCard, templateCard, otherBoardCard,
+	}, userID)
+
+	resultIDs := func(results []*model.CardSearchResult) []string {
+		ids := make([]string, 0, len(results))
+		for _, result := range results {
+			ids = append(ids, result.Card.ID)
+		}
+		return ids
+	}
+
+	t.Run("matches titles, text properties, content and comments", func(t *testing.T) {
+		results, err := store.SearchCards(model.CardSearchOptions{
+			BoardIDs: []string{board.ID},
+			Term:     "invoice",
+		})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{titleCard.ID, contentCard.ID, commentCard.ID, propertyCard.ID}, resultIDs(results))
+
+		matches := map[string][]string{}
+		for _, result := range results {
+			matches[result.Card.ID] = result.Matches
+		}
+		require.Equal(t, []string{model.CardSearchMatchTitle}, matches[titleCard.ID])
+		require.Equal(t, []string{model.CardSearchMatchContent}, matches[contentCard.ID])
+		require.Equal(t, []string{model.CardSearchMatchComment}, matches[commentCard.ID])
+		require.Equal(t, []string{model.CardSearchMatchProperty}, matches[propertyCard.ID])
+	})
+
+	t.Run("title matches rank first", func(t *testing.T) {
+		results, err := store.SearchCards(model.CardSearchOptions{
+			BoardIDs: []string{board.ID},
+			Term:     "invoice",
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		require.Equal(t, titleCard.ID, results[0].Card.ID)
+		require.Equal(t, propertyCard.ID, results[1].Card.ID)
+	})
+
+	t.Run("all words must match", func(t *testing.T) {
+		results, err := store.SearchCards(model.CardSearchOptions{
+			BoardIDs: []string{board.ID},
+			Term:     "customer invoice",
+		})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{contentCard.ID, commentCard.ID}, resultIDs(results))
+	})
+
+	t.Run("only the given boards are searched", func(t *testing.T) {
+		results, err := store.SearchCards(model.CardSearchOptions{
+			BoardIDs: []string{otherBoard.ID},
+			Term:     "invoice",
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{otherBoardCard.ID}, resultIDs(results))
+
+		results, err = store.SearchCards(model.CardSearchOptions{
+			Term: "invoice",
+		})
+		require.NoError(t, err)
+		require.Empty(t, results)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		results, err := store.SearchCards(model.CardSearchOptions{
+			BoardIDs: []string{board.ID},
+			Term:     "invoice",
+			PerPage:  3,
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		results, err = store.SearchCards(model.CardSearchOptions{
+			BoardIDs: []string{board.ID},
+			Term:     "invoice",
+			Page:     1,
+			PerPage:  3,
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+	})
+
+	t.Run("wildcards and property ids are matched literally", func(t *testing.T) {
+		discountCard := makeBlock(board.ID, board.ID, model.TypeCard, "Discount", map[string]interface{}{
+			"properties": map[string]interface{}{notesPropID: "50% off"},
+		})
+		itemsCard := makeBlock(board.ID, board.ID, model.TypeCard, "Items", map[string]interface{}{
+			"properties": map[string]interface{}{notesPropID: "500 items"},
+		})
+		InsertBlocks(t, store, []*model.Block{discountCard, itemsCard}, userID)
+
+		results, err := store.SearchCards(model.CardSearchOptions{
+			BoardIDs: []string{board.ID},
+			Term:     "50%",
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{discountCard.ID}, resultIDs(results))
+
+		results, err = store.SearchCards(model.CardSearchOptions{
+			BoardIDs: []string{board.ID},
+			Term:     notesPropID,
+		})
+		require.NoError(t, err)
+		require.Empty(t, results)
+	})
+
+	t.Run("empty term", func(t *testing.T) {
+		results, err := store.SearchCards(model.CardSearchOptions{
+			BoardIDs: []string{board.ID},
+			Term:     "  ",
+		})
+		require.NoError(t, err)
+		require.Empty(t, results)
+	})
+}
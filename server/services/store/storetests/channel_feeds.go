@@ -0,0 +1,105 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestChannelFeedStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SaveAndDeleteChannelFeedSettings", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSaveAndDeleteChannelFeedSettings(t, store)
+	})
+	t.Run("AddAndTakeChannelFeedItems", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testAddAndTakeChannelFeedItems(t, store)
+	})
+}
+
+func testSaveAndDeleteChannelFeedSettings(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+
+	settings := &model.ChannelFeedSettings{
+		BoardID:          boardID,
+		Events:           []string{model.ChannelFeedEventCardCreated, model.ChannelFeedEventCardCompleted},
+		StatusPropertyID: "status",
+		DoneOptionIDs:    []string{"done"},
+		WindowMinutes:    10,
+		ModifiedBy:       "user-id",
+	}
+	saved, err := store.SaveChannelFeedSettings(settings)
+	require.NoError(t, err)
+	require.Equal(t, settings.Events, saved.Events)
+	require.Equal(t, []string{"done"}, saved.DoneOptionIDs)
+	require.NotZero(t, saved.UpdateAt)
+
+	t.Run("replace the settings", func(t *testing.T) {
+		settings.Events = []string{model.ChannelFeedEventCardCreated}
+		settings.WindowMinutes = 30
+		_, err := store.SaveChannelFeedSettings(settings)
+		require.NoError(t, err)
+
+		saved, err := store.GetChannelFeedSettings(boardID)
+		require.NoError(t, err)
+		require.Equal(t, []string{model.ChannelFeedEventCardCreated}, saved.Events)
+		require.Equal(t, 30, saved.WindowMinutes)
+	})
+
+	t.Run("delete the settings and the waiting changes", func(t *testing.T) {
+		require.NoError(t, store.AddChannelFeedItem(&model.ChannelFeedItem{
+			BoardID: boardID, CardID: "card", Event: model.ChannelFeedEventCardCreated, ModifiedBy: "user-id", NotifyAt: 100,
+		}))
+		require.NoError(t, store.DeleteChannelFeedSettings(boardID))
+
+		_, err := store.GetChannelFeedSettings(boardID)
+		require.True(t, model.IsErrNotFound(err))
+
+		boardIDs, err := store.GetDueChannelFeedBoardIDs(1000)
+		require.NoError(t, err)
+		require.Empty(t, boardIDs)
+
+		require.True(t, model.IsErrNotFound(store.DeleteChannelFeedSettings(boardID)))
+	})
+}
+
+func testAddAndTakeChannelFeedItems(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	otherBoardID := utils.NewID(utils.IDTypeBoard)
+
+	items := []*model.ChannelFeedItem{
+		{BoardID: boardID, CardID: "card-1", Event: model.ChannelFeedEventCardCreated, ModifiedBy: "user-id", NotifyAt: 1000, CreateAt: 100},
+		{BoardID: boardID, CardID: "card-1", Event: model.ChannelFeedEventStatusChanged, OldValue: "todo", NewValue: "done", ModifiedBy: "user-id", NotifyAt: 1200, CreateAt: 300},
+		{BoardID: otherBoardID, CardID: "card-2", Event: model.ChannelFeedEventCardCreated, ModifiedBy: "user-id", NotifyAt: 5000, CreateAt: 200},
+	}
+	for _, item := range items {
+		require.NoError(t, store.AddChannelFeedItem(item))
+	}
+
+	boardIDs, err := store.GetDueChannelFeedBoardIDs(1000)
+	require.NoError(t, err)
+	require.Equal(t, []string{boardID}, boardIDs)
+
+	taken, err := store.TakeChannelFeedItems(boardID)
+	require.NoError(t, err)
+	require.Len(t, taken, 2)
+	require.Equal(t, model.ChannelFeedEventCardCreated, taken[0].Event)
+	require.Equal(t, "todo", taken[1].OldValue)
+	require.Equal(t, "done", taken[1].NewValue)
+
+	taken, err = store.TakeChannelFeedItems(boardID)
+	require.NoError(t, err)
+	require.Empty(t, taken)
+
+	boardIDs, err = store.GetDueChannelFeedBoardIDs(5000)
+	require.NoError(t, err)
+	require.Equal(t, []string{otherBoardID}, boardIDs)
+}
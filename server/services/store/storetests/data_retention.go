@@ -36,6 +36,13 @@ func StoreTestDataRetention(t *testing.T, setup func(t *testing.T) (store.Store,
 		testRunDataRetention(t, store, 2)
 		testRunDataRetention(t, store, 10)
 	})
+
+	t.Run("RunDataRetentionWithPolicies", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+
+		testRunDataRetentionWithPolicies(t, store)
+	})
 }
 
 func LoadData(t *testing.T, store store.Store) {
@@ -92,6 +99,8 @@ func LoadData(t *testing.T, store store.Store) {
 	err = store.UpsertSharing(sharing)
 	require.NoError(t, err)
 
+	createTestShareLink(t, store, boardID)
+
 	err = store.AddUpdateCategoryBoard(testUserID, categoryID, []string{boardID})
 	require.NoError(t, err)
 }
@@ -105,13 +114,13 @@ func testRunDataRetention(t *testing.T, store store.Store, batchSize int) {
 	initialCount := len(blocks)
 
 	t.Run("test no deletions", func(t *testing.T) {
-		deletions, err := store.RunDataRetention(utils.GetMillisForTime(time.Now().Add(-time.Hour*1)), int64(batchSize))
+		deletions, err := store.RunDataRetention(&model.RetentionCutoffs{Global: utils.GetMillisForTime(time.Now().Add(-time.Hour * 1))}, int64(batchSize))
 		require.NoError(t, err)
 		require.Equal(t, int64(0), deletions)
 	})
 
 	t.Run("test all deletions", func(t *testing.T) {
-		deletions, err := store.RunDataRetention(utils.GetMillisForTime(time.Now().Add(time.Hour*1)), int64(batchSize))
+		deletions, err := store.RunDataRetention(&model.RetentionCutoffs{Global: utils.GetMillisForTime(time.Now().Add(time.Hour * 1))}, int64(batchSize))
 		require.NoError(t, err)
 		require.True(t, deletions > int64(initialCount))
 
@@ -135,5 +144,69 @@ func testRunDataRetention(t *testing.T, store store.Store, batchSize int) {
 		category, err := store.GetUserCategoryBoards(boardID, testTeamID)
 		require.NoError(t, err)
 		require.Empty(t, category)
+
+		shareLinks, err := store.GetShareLinksForBoard(boardID)
+		require.NoError(t, err)
+		require.Empty(t, shareLinks)
+	})
+}
+
+func testRunDataRetentionWithPolicies(t *testing.T, store store.Store) {
+	teamID1 := utils.NewID(utils.IDTypeTeam)
+	teamID2 := utils.NewID(utils.IDTypeTeam)
+	team1Boards := createTestBoards(t, store, teamID1, testUserID, 2)
+	team2Boards := createTestBoards(t, store, teamID2, testUserID, 2)
+
+	for _, board := range append(team1Boards, team2Boards...) {
+		block := &model.Block{
+			ID:         utils.NewID(utils.IDTypeBlock),
+			BoardID:    board.ID,
+			Type:       model.TypeCard,
+			ModifiedBy: testUserID,
+		}
+		require.NoError(t, store.InsertBlock(block, testUserID))
+	}
+
+	future := utils.GetMillisForTime(time.Now().Add(time.Hour * 1))
+	cutoffs := &model.RetentionCutoffs{
+		Global: future,
+		Teams:  map[string]int64{teamID1: future},
+		// the second board of the second team is retained indefinitely
+		Boards: map[string]int64{team2Boards[1].ID: 0},
+		Exempt: map[string]map[string]bool{teamID1: {team1Boards[1].ID: true}},
+	}
+
+	t.Run("preview", func(t *testing.T) {
+		preview, err := store.GetDataRetentionPreview(cutoffs)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), preview.BoardCount)
+		require.Equal(t, int64(2), preview.BlockCount)
+		require.GreaterOrEqual(t, preview.HistoryCount, int64(4))
+		require.Equal(t, int64(0), preview.DataCount)
+	})
+
+	t.Run("run", func(t *testing.T) {
+		deletions, err := store.RunDataRetention(cutoffs, 0)
+		require.NoError(t, err)
+		require.Greater(t, deletions, int64(0))
+
+		for _, boardID := range []string{team1Boards[0].ID, team2Boards[0].ID} {
+			_, err = store.GetBoard(boardID)
+			require.True(t, model.IsErrNotFound(err), boardID)
+		}
+
+		for _, boardID := range []string{team1Boards[1].ID, team2Boards[1].ID} {
+			board, err := store.GetBoard(boardID)
+			require.NoError(t, err)
+			require.Equal(t, boardID, board.ID)
+
+			blocks, err := store.GetBlocksForBoard(boardID)
+			require.NoError(t, err)
+			require.Len(t, blocks, 1)
+		}
+
+		preview, err := store.GetDataRetentionPreview(cutoffs)
+		require.NoError(t, err)
+		require.Equal(t, &model.RetentionPreview{}, preview)
 	})
 }
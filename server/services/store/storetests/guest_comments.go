@@ -0,0 +1,177 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestGuestCommentStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateAndGetGuestComment", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAndGetGuestComment(t, store)
+	})
+	t.Run("UpdateGuestCommentStatus", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateGuestCommentStatus(t, store)
+	})
+	t.Run("CountGuestCommentsSince", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCountGuestCommentsSince(t, store)
+	})
+}
+
+func createTestGuestComment(t *testing.T, store store.Store, boardID, shareLinkID string) *model.GuestComment {
+	comment := &model.GuestComment{
+		ID:          utils.NewID(utils.IDTypeNone),
+		BoardID:     boardID,
+		CardID:      utils.NewID(utils.IDTypeCard),
+		ShareLinkID: shareLinkID,
+		AuthorName:  "Jane",
+		Text:        "Looks good",
+		Status:      model.GuestCommentPending,
+		RemoteAddr:  "127.0.0.1",
+	}
+
+	newComment, err := store.CreateGuestComment(comment, model.GuestCommentRateLimit, utils.GetMillis()-model.GuestCommentRateLimitWindow)
+	require.NoError(t, err)
+	return newComment
+}
+
+func testCreateAndGetGuestComment(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	shareLinkID := utils.NewID(utils.IDTypeNone)
+	comment := createTestGuestComment(t, store, boardID, shareLinkID)
+	approved := createTestGuestComment(t, store, boardID, shareLinkID)
+	createTestGuestComment(t, store, utils.NewID(utils.IDTypeBoard), shareLinkID)
+
+	approved.Status = model.GuestCommentApproved
+	updated, err := store.UpdateGuestCommentStatus(approved)
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	t.Run("get a comment", func(t *testing.T) {
+		got, err := store.GetGuestComment(comment.ID)
+		require.NoError(t, err)
+		require.Equal(t, boardID, got.BoardID)
+		require.Equal(t, comment.CardID, got.CardID)
+		require.Equal(t, "Jane", got.AuthorName)
+		require.Equal(t, "Looks good", got.Text)
+		require.Equal(t, model.GuestCommentPending, got.Status)
+		require.NotZero(t, got.CreateAt)
+	})
+
+	t.Run("get the comments of a board", func(t *testing.T) {
+		comments, err := store.GetGuestCommentsForBoard(boardID, "")
+		require.NoError(t, err)
+		require.Len(t, comments, 2)
+
+		comments, err = store.GetGuestCommentsForBoard(boardID, model.GuestCommentPending)
+		require.NoError(t, err)
+		require.Len(t, comments, 1)
+		require.Equal(t, comment.ID, comments[0].ID)
+	})
+
+	t.Run("get a nonexistent comment", func(t *testing.T) {
+		_, err := store.GetGuestComment(utils.NewID(utils.IDTypeNone))
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testUpdateGuestCommentStatus(t *testing.T, store store.Store) {
+	comment := createTestGuestComment(t, store, utils.NewID(utils.IDTypeBoard), utils.NewID(utils.IDTypeNone))
+
+	comment.Status = model.GuestCommentApproved
+	comment.CommentID = utils.NewID(utils.IDTypeBlock)
+	comment.ModeratedBy = testUserID
+	updated, err := store.UpdateGuestCommentStatus(comment)
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	got, err := store.GetGuestComment(comment.ID)
+	require.NoError(t, err)
+	require.Equal(t, model.GuestCommentApproved, got.Status)
+	require.Equal(t, comment.CommentID, got.CommentID)
+	require.Equal(t, testUserID, got.ModeratedBy)
+
+	t.Run("a moderated comment is not updated", func(t *testing.T) {
+		comment.Status = model.GuestCommentRejected
+		updated, err := store.UpdateGuestCommentStatus(comment)
+		require.NoError(t, err)
+		require.False(t, updated)
+
+		got, err := store.GetGuestComment(comment.ID)
+		require.NoError(t, err)
+		require.Equal(t, model.GuestCommentApproved, got.Status)
+	})
+
+	t.Run("reset an approval", func(t *testing.T) {
+		comment.Status = model.GuestCommentApproved
+		require.NoError(t, store.ResetGuestCommentApproval(comment))
+
+		got, err := store.GetGuestComment(comment.ID)
+		require.NoError(t, err)
+		require.Equal(t, model.GuestCommentPending, got.Status)
+		require.Empty(t, got.CommentID)
+		require.Empty(t, got.ModeratedBy)
+
+		comment.ModeratedBy = testUserID
+		updated, err := store.UpdateGuestCommentStatus(comment)
+		require.NoError(t, err)
+		require.True(t, updated, "the comment can be approved again")
+	})
+}
+
+func testCountGuestCommentsSince(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	shareLinkID := utils.NewID(utils.IDTypeNone)
+	since := utils.GetMillis()
+
+	createTestGuestComment(t, store, boardID, shareLinkID)
+	createTestGuestComment(t, store, boardID, shareLinkID)
+	createTestGuestComment(t, store, boardID, utils.NewID(utils.IDTypeNone))
+
+	count, err := store.CountGuestCommentsSince(shareLinkID, "127.0.0.1", since)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	count, err = store.CountGuestCommentsSince(shareLinkID, "10.0.0.1", since)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	count, err = store.CountGuestCommentsSince(shareLinkID, "127.0.0.1", utils.GetMillis()+1000)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	t.Run("comments beyond the limit are rejected", func(t *testing.T) {
+		comment := &model.GuestComment{
+			ID:          utils.NewID(utils.IDTypeNone),
+			BoardID:     boardID,
+			CardID:      utils.NewID(utils.IDTypeCard),
+			ShareLinkID: shareLinkID,
+			AuthorName:  "Jane",
+			Text:        "One more thing",
+			Status:      model.GuestCommentPending,
+			RemoteAddr:  "127.0.0.1",
+		}
+
+		_, err := store.CreateGuestComment(comment, 2, since)
+		require.True(t, model.IsErrTooManyRequests(err))
+
+		_, err = store.GetGuestComment(comment.ID)
+		require.True(t, model.IsErrNotFound(err))
+
+		comment.RemoteAddr = "10.0.0.1"
+		_, err = store.CreateGuestComment(comment, 2, since)
+		require.NoError(t, err)
+	})
+}
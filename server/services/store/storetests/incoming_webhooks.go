@@ -0,0 +1,122 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestIncomingWebhookStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateAndGetIncomingWebhook", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAndGetIncomingWebhook(t, store)
+	})
+	t.Run("UpdateIncomingWebhook", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateIncomingWebhook(t, store)
+	})
+	t.Run("DeleteIncomingWebhook", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteIncomingWebhook(t, store)
+	})
+}
+
+func createTestIncomingWebhook(t *testing.T, store store.Store, boardID, userID string) *model.IncomingWebhook {
+	webhook, err := store.CreateIncomingWebhook(&model.IncomingWebhook{
+		ID:        utils.NewID(utils.IDTypeBlock),
+		BoardID:   boardID,
+		Title:     "CI",
+		Token:     utils.NewID(utils.IDTypeToken),
+		Enabled:   true,
+		CreatedBy: userID,
+	})
+	require.NoError(t, err)
+	return webhook
+}
+
+func testCreateAndGetIncomingWebhook(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	boards := createTestBoards(t, store, utils.NewID(utils.IDTypeTeam), userID, 2)
+
+	webhook1 := createTestIncomingWebhook(t, store, boards[0].ID, userID)
+	webhook2 := createTestIncomingWebhook(t, store, boards[0].ID, userID)
+	createTestIncomingWebhook(t, store, boards[1].ID, userID)
+
+	t.Run("get a webhook", func(t *testing.T) {
+		webhook, err := store.GetIncomingWebhook(webhook1.ID)
+		require.NoError(t, err)
+		require.Equal(t, webhook1.Title, webhook.Title)
+		require.Equal(t, webhook1.Token, webhook.Token)
+		require.Equal(t, userID, webhook.CreatedBy)
+		require.True(t, webhook.Enabled)
+	})
+
+	t.Run("get the webhooks of a board", func(t *testing.T) {
+		webhooks, err := store.GetIncomingWebhooksForBoard(boards[0].ID)
+		require.NoError(t, err)
+		require.Len(t, webhooks, 2)
+		require.ElementsMatch(t, []string{webhook1.ID, webhook2.ID}, []string{webhooks[0].ID, webhooks[1].ID})
+	})
+
+	t.Run("get a nonexistent webhook", func(t *testing.T) {
+		webhook, err := store.GetIncomingWebhook(utils.NewID(utils.IDTypeBlock))
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, webhook)
+	})
+
+	t.Run("create an invalid webhook", func(t *testing.T) {
+		webhook, err := store.CreateIncomingWebhook(&model.IncomingWebhook{
+			ID:      utils.NewID(utils.IDTypeBlock),
+			BoardID: boards[0].ID,
+		})
+		require.Error(t, err)
+		require.Nil(t, webhook)
+	})
+}
+
+func testUpdateIncomingWebhook(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	boards := createTestBoards(t, store, utils.NewID(utils.IDTypeTeam), userID, 1)
+	webhook := createTestIncomingWebhook(t, store, boards[0].ID, userID)
+
+	webhook.Title = "Deployments"
+	webhook.Token = utils.NewID(utils.IDTypeToken)
+	webhook.Enabled = false
+
+	updatedWebhook, err := store.UpdateIncomingWebhook(webhook)
+	require.NoError(t, err)
+	require.Equal(t, "Deployments", updatedWebhook.Title)
+	require.Equal(t, webhook.Token, updatedWebhook.Token)
+	require.False(t, updatedWebhook.Enabled)
+
+	t.Run("update a nonexistent webhook", func(t *testing.T) {
+		webhook.ID = utils.NewID(utils.IDTypeBlock)
+		_, err := store.UpdateIncomingWebhook(webhook)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testDeleteIncomingWebhook(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	boards := createTestBoards(t, store, utils.NewID(utils.IDTypeTeam), userID, 1)
+	webhook := createTestIncomingWebhook(t, store, boards[0].ID, userID)
+
+	require.NoError(t, store.DeleteIncomingWebhook(webhook.ID))
+
+	_, err := store.GetIncomingWebhook(webhook.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	t.Run("delete a nonexistent webhook", func(t *testing.T) {
+		err := store.DeleteIncomingWebhook(webhook.ID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
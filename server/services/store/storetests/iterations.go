@@ -0,0 +1,118 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestIterationStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateAndGetIteration", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAndGetIteration(t, store)
+	})
+	t.Run("UpdateIteration", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateIteration(t, store)
+	})
+	t.Run("DeleteIteration", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteIteration(t, store)
+	})
+}
+
+func createTestIteration(t *testing.T, store store.Store, boardID, name string) *model.Iteration {
+	iteration := &model.Iteration{
+		ID:               utils.NewID(utils.IDTypeNone),
+		BoardID:          boardID,
+		Name:             name,
+		Goal:             "ship the importer",
+		StartAt:          1000,
+		EndAt:            2000,
+		State:            model.IterationStatePlanned,
+		StatusPropertyID: "status",
+		DoneOptionIDs:    []string{"done", "wontfix"},
+		CreatedBy:        testUserID,
+	}
+
+	newIteration, err := store.CreateIteration(iteration)
+	require.NoError(t, err)
+	return newIteration
+}
+
+func testCreateAndGetIteration(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	iteration := createTestIteration(t, store, boardID, "Sprint 1")
+	createTestIteration(t, store, boardID, "Sprint 2")
+	createTestIteration(t, store, utils.NewID(utils.IDTypeBoard), "Sprint 1")
+
+	t.Run("get an iteration", func(t *testing.T) {
+		got, err := store.GetIteration(iteration.ID)
+		require.NoError(t, err)
+		require.Equal(t, boardID, got.BoardID)
+		require.Equal(t, "Sprint 1", got.Name)
+		require.Equal(t, "ship the importer", got.Goal)
+		require.Equal(t, int64(1000), got.StartAt)
+		require.Equal(t, int64(2000), got.EndAt)
+		require.Equal(t, model.IterationStatePlanned, got.State)
+		require.Equal(t, []string{"done", "wontfix"}, got.DoneOptionIDs)
+		require.Empty(t, got.EstimatePropertyID)
+		require.NotZero(t, got.CreateAt)
+	})
+
+	t.Run("get the iterations of a board", func(t *testing.T) {
+		iterations, err := store.GetIterationsForBoard(boardID)
+		require.NoError(t, err)
+		require.Len(t, iterations, 2)
+	})
+
+	t.Run("get a nonexistent iteration", func(t *testing.T) {
+		_, err := store.GetIteration(utils.NewID(utils.IDTypeNone))
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testUpdateIteration(t *testing.T, store store.Store) {
+	iteration := createTestIteration(t, store, utils.NewID(utils.IDTypeBoard), "Sprint 1")
+
+	iteration.Name = "Sprint 1b"
+	iteration.State = model.IterationStateClosed
+	iteration.DoneOptionIDs = []string{"done"}
+	iteration.EstimatePropertyID = "points"
+	iteration.StartedAt = 1500
+	iteration.ClosedAt = 2500
+
+	updated, err := store.UpdateIteration(iteration)
+	require.NoError(t, err)
+	require.Equal(t, "Sprint 1b", updated.Name)
+	require.Equal(t, model.IterationStateClosed, updated.State)
+	require.Equal(t, []string{"done"}, updated.DoneOptionIDs)
+	require.Equal(t, "points", updated.EstimatePropertyID)
+	require.Equal(t, int64(1500), updated.StartedAt)
+	require.Equal(t, int64(2500), updated.ClosedAt)
+
+	iteration.ID = utils.NewID(utils.IDTypeNone)
+	_, err = store.UpdateIteration(iteration)
+	require.True(t, model.IsErrNotFound(err))
+}
+
+func testDeleteIteration(t *testing.T, store store.Store) {
+	iteration := createTestIteration(t, store, utils.NewID(utils.IDTypeBoard), "Sprint 1")
+
+	require.NoError(t, store.DeleteIteration(iteration.ID))
+
+	_, err := store.GetIteration(iteration.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	err = store.DeleteIteration(iteration.ID)
+	require.True(t, model.IsErrNotFound(err))
+}
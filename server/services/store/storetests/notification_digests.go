@@ -0,0 +1,104 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestNotificationDigestStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("AddAndTakeNotificationDigestItems", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testAddAndTakeNotificationDigestItems(t, store)
+	})
+}
+
+func testAddAndTakeNotificationDigestItems(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	otherUserID := utils.NewID(utils.IDTypeUser)
+	boardID := utils.NewID(utils.IDTypeBoard)
+	cardID := utils.NewID(utils.IDTypeCard)
+	otherCardID := utils.NewID(utils.IDTypeCard)
+
+	items := []*model.NotificationDigestItem{
+		{UserID: userID, BlockID: cardID, BoardID: boardID, SinceAt: 100, NotifyAt: 1000},
+		{UserID: userID, BlockID: otherCardID, BoardID: boardID, SinceAt: 200, NotifyAt: 1000},
+		{UserID: otherUserID, BlockID: cardID, BoardID: boardID, SinceAt: 300, NotifyAt: 2000},
+	}
+	for _, item := range items {
+		require.NoError(t, store.AddNotificationDigestItem(item))
+	}
+
+	t.Run("a block already queued keeps its since time", func(t *testing.T) {
+		err := store.AddNotificationDigestItem(&model.NotificationDigestItem{
+			UserID: userID, BlockID: cardID, BoardID: boardID, SinceAt: 500, NotifyAt: 3000,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("a block added back keeps the earliest since time", func(t *testing.T) {
+		err := store.AddNotificationDigestItem(&model.NotificationDigestItem{
+			UserID: otherUserID, BlockID: otherCardID, BoardID: boardID, SinceAt: 900, NotifyAt: 2000,
+		})
+		require.NoError(t, err)
+
+		err = store.AddNotificationDigestItem(&model.NotificationDigestItem{
+			UserID: otherUserID, BlockID: otherCardID, BoardID: boardID, SinceAt: 400, NotifyAt: 1500,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("due users", func(t *testing.T) {
+		userIDs, err := store.GetDueNotificationDigestUserIDs(999)
+		require.NoError(t, err)
+		require.Empty(t, userIDs)
+
+		userIDs, err = store.GetDueNotificationDigestUserIDs(1000)
+		require.NoError(t, err)
+		require.Equal(t, []string{userID}, userIDs)
+
+		userIDs, err = store.GetDueNotificationDigestUserIDs(2000)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{userID, otherUserID}, userIDs)
+	})
+
+	t.Run("take items", func(t *testing.T) {
+		taken, err := store.TakeNotificationDigestItems(userID)
+		require.NoError(t, err)
+		require.Len(t, taken, 2)
+
+		sinceAt := map[string]int64{}
+		for _, item := range taken {
+			require.Equal(t, userID, item.UserID)
+			require.Equal(t, int64(1000), item.NotifyAt)
+			sinceAt[item.BlockID] = item.SinceAt
+		}
+		require.Equal(t, map[string]int64{cardID: 100, otherCardID: 200}, sinceAt)
+
+		taken, err = store.TakeNotificationDigestItems(userID)
+		require.NoError(t, err)
+		require.Empty(t, taken)
+
+		userIDs, err := store.GetDueNotificationDigestUserIDs(2000)
+		require.NoError(t, err)
+		require.Equal(t, []string{otherUserID}, userIDs)
+	})
+	t.Run("take items added back", func(t *testing.T) {
+		taken, err := store.TakeNotificationDigestItems(otherUserID)
+		require.NoError(t, err)
+		require.Len(t, taken, 2)
+
+		sinceAt := map[string]int64{}
+		for _, item := range taken {
+			sinceAt[item.BlockID] = item.SinceAt
+		}
+		require.Equal(t, map[string]int64{cardID: 300, otherCardID: 400}, sinceAt)
+	})
+}
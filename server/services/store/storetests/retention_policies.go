@@ -0,0 +1,128 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestRetentionPolicyStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateAndGetRetentionPolicy", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAndGetRetentionPolicy(t, store)
+	})
+	t.Run("UpdateRetentionPolicy", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateRetentionPolicy(t, store)
+	})
+	t.Run("DeleteRetentionPolicy", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteRetentionPolicy(t, store)
+	})
+}
+
+func createTestRetentionPolicy(t *testing.T, store store.Store, policy *model.RetentionPolicy) *model.RetentionPolicy {
+	policy.ID = utils.NewID(utils.IDTypeNone)
+	policy.CreatedBy = testUserID
+
+	newPolicy, err := store.CreateRetentionPolicy(policy)
+	require.NoError(t, err)
+	return newPolicy
+}
+
+func testCreateAndGetRetentionPolicy(t *testing.T, store store.Store) {
+	exemptBoardID := utils.NewID(utils.IDTypeBoard)
+	teamPolicy := createTestRetentionPolicy(t, store, &model.RetentionPolicy{
+		TeamID:         testTeamID,
+		RetentionDays:  30,
+		ExemptBoardIDs: []string{exemptBoardID},
+	})
+	boardPolicy := createTestRetentionPolicy(t, store, &model.RetentionPolicy{
+		BoardID: utils.NewID(utils.IDTypeBoard),
+	})
+
+	t.Run("get a policy", func(t *testing.T) {
+		policy, err := store.GetRetentionPolicy(teamPolicy.ID)
+		require.NoError(t, err)
+		require.Equal(t, testTeamID, policy.TeamID)
+		require.Empty(t, policy.BoardID)
+		require.Equal(t, 30, policy.RetentionDays)
+		require.Equal(t, []string{exemptBoardID}, policy.ExemptBoardIDs)
+
+		policy, err = store.GetRetentionPolicy(boardPolicy.ID)
+		require.NoError(t, err)
+		require.True(t, policy.IsIndefinite())
+		require.Empty(t, policy.ExemptBoardIDs)
+	})
+
+	t.Run("get all the policies", func(t *testing.T) {
+		policies, err := store.GetRetentionPolicies()
+		require.NoError(t, err)
+		require.Len(t, policies, 2)
+		require.ElementsMatch(t, []string{teamPolicy.ID, boardPolicy.ID}, []string{policies[0].ID, policies[1].ID})
+	})
+
+	t.Run("get a nonexistent policy", func(t *testing.T) {
+		policy, err := store.GetRetentionPolicy(utils.NewID(utils.IDTypeNone))
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, policy)
+	})
+
+	t.Run("create an invalid policy", func(t *testing.T) {
+		policy, err := store.CreateRetentionPolicy(&model.RetentionPolicy{
+			ID:            utils.NewID(utils.IDTypeNone),
+			RetentionDays: 30,
+		})
+		require.Error(t, err)
+		require.Nil(t, policy)
+	})
+}
+
+func testUpdateRetentionPolicy(t *testing.T, store store.Store) {
+	policy := createTestRetentionPolicy(t, store, &model.RetentionPolicy{
+		TeamID:        testTeamID,
+		RetentionDays: 30,
+	})
+
+	exemptBoardID := utils.NewID(utils.IDTypeBoard)
+	policy.RetentionDays = 90
+	policy.ExemptBoardIDs = []string{exemptBoardID}
+
+	updatedPolicy, err := store.UpdateRetentionPolicy(policy)
+	require.NoError(t, err)
+	require.Equal(t, 90, updatedPolicy.RetentionDays)
+	require.Equal(t, []string{exemptBoardID}, updatedPolicy.ExemptBoardIDs)
+	require.Equal(t, testTeamID, updatedPolicy.TeamID)
+
+	t.Run("update a nonexistent policy", func(t *testing.T) {
+		policy.ID = utils.NewID(utils.IDTypeNone)
+		_, err := store.UpdateRetentionPolicy(policy)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testDeleteRetentionPolicy(t *testing.T, store store.Store) {
+	policy := createTestRetentionPolicy(t, store, &model.RetentionPolicy{
+		TeamID:        testTeamID,
+		RetentionDays: 30,
+	})
+
+	require.NoError(t, store.DeleteRetentionPolicy(policy.ID))
+
+	_, err := store.GetRetentionPolicy(policy.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	t.Run("delete a nonexistent policy", func(t *testing.T) {
+		err := store.DeleteRetentionPolicy(policy.ID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
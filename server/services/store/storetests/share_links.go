@@ -0,0 +1,192 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestShareLinkStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateAndGetShareLink", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAndGetShareLink(t, store)
+	})
+	t.Run("UpdateShareLink", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateShareLink(t, store)
+	})
+	t.Run("DeleteShareLink", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteShareLink(t, store)
+	})
+	t.Run("ShareLinkAccesses", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testShareLinkAccesses(t, store)
+	})
+}
+
+func createTestShareLink(t *testing.T, store store.Store, boardID string) *model.ShareLink {
+	link := &model.ShareLink{
+		ID:        utils.NewID(utils.IDTypeNone),
+		BoardID:   boardID,
+		Name:      "partners",
+		Token:     utils.NewID(utils.IDTypeToken),
+		Mode:      model.ShareLinkModeViewer,
+		CreatedBy: testUserID,
+	}
+
+	newLink, err := store.CreateShareLink(link)
+	require.NoError(t, err)
+	return newLink
+}
+
+func testCreateAndGetShareLink(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	link := createTestShareLink(t, store, boardID)
+	createTestShareLink(t, store, boardID)
+	createTestShareLink(t, store, utils.NewID(utils.IDTypeBoard))
+
+	t.Run("get a link", func(t *testing.T) {
+		got, err := store.GetShareLink(link.ID)
+		require.NoError(t, err)
+		require.Equal(t, boardID, got.BoardID)
+		require.Equal(t, "partners", got.Name)
+		require.Equal(t, link.Token, got.Token)
+		require.Equal(t, model.ShareLinkModeViewer, got.Mode)
+		require.False(t, got.HasPassword)
+		require.NotZero(t, got.CreateAt)
+	})
+
+	t.Run("get a link by token", func(t *testing.T) {
+		got, err := store.GetShareLinkByToken(boardID, link.Token)
+		require.NoError(t, err)
+		require.Equal(t, link.ID, got.ID)
+
+		_, err = store.GetShareLinkByToken(utils.NewID(utils.IDTypeBoard), link.Token)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("get the links of a board", func(t *testing.T) {
+		links, err := store.GetShareLinksForBoard(boardID)
+		require.NoError(t, err)
+		require.Len(t, links, 2)
+	})
+
+	t.Run("get a nonexistent link", func(t *testing.T) {
+		_, err := store.GetShareLink(utils.NewID(utils.IDTypeNone))
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testUpdateShareLink(t *testing.T, store store.Store) {
+	link := createTestShareLink(t, store, utils.NewID(utils.IDTypeBoard))
+
+	link.Name = "customers"
+	link.Mode = model.ShareLinkModeCommenter
+	link.ViewID = utils.NewID(utils.IDTypeView)
+	link.ExpiresAt = 1000
+	link.RevokedAt = 500
+	require.NoError(t, link.SetPassword("secret"))
+
+	updated, err := store.UpdateShareLink(link)
+	require.NoError(t, err)
+	require.Equal(t, "customers", updated.Name)
+	require.Equal(t, model.ShareLinkModeCommenter, updated.Mode)
+	require.Equal(t, link.ViewID, updated.ViewID)
+	require.Equal(t, int64(1000), updated.ExpiresAt)
+	require.Equal(t, int64(500), updated.RevokedAt)
+	require.True(t, updated.HasPassword)
+	require.True(t, updated.CheckPassword("secret"))
+
+	link.ID = utils.NewID(utils.IDTypeNone)
+	_, err = store.UpdateShareLink(link)
+	require.True(t, model.IsErrNotFound(err))
+}
+
+func testDeleteShareLink(t *testing.T, store store.Store) {
+	link := createTestShareLink(t, store, utils.NewID(utils.IDTypeBoard))
+	require.NoError(t, store.InsertShareLinkAccess(&model.ShareLinkAccess{
+		ID:          utils.NewID(utils.IDTypeNone),
+		ShareLinkID: link.ID,
+		BoardID:     link.BoardID,
+		CreateAt:    utils.GetMillis(),
+	}))
+
+	require.NoError(t, store.DeleteShareLink(link.ID))
+
+	_, err := store.GetShareLink(link.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	accesses, err := store.GetShareLinkAccesses(link.ID, 0, 10)
+	require.NoError(t, err)
+	require.Empty(t, accesses)
+
+	err = store.DeleteShareLink(link.ID)
+	require.True(t, model.IsErrNotFound(err))
+}
+
+func testShareLinkAccesses(t *testing.T, store store.Store) {
+	link := createTestShareLink(t, store, utils.NewID(utils.IDTypeBoard))
+
+	for i := int64(1); i <= 3; i++ {
+		require.NoError(t, store.InsertShareLinkAccess(&model.ShareLinkAccess{
+			ID:          utils.NewID(utils.IDTypeNone),
+			ShareLinkID: link.ID,
+			BoardID:     link.BoardID,
+			RemoteAddr:  "127.0.0.1",
+			UserAgent:   "test",
+			CreateAt:    i * 1000,
+		}))
+	}
+
+	accesses, err := store.GetShareLinkAccesses(link.ID, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, accesses, 2)
+	require.Equal(t, int64(3000), accesses[0].CreateAt)
+	require.Equal(t, "127.0.0.1", accesses[0].RemoteAddr)
+
+	accesses, err = store.GetShareLinkAccesses(link.ID, 1, 2)
+	require.NoError(t, err)
+	require.Len(t, accesses, 1)
+	require.Equal(t, int64(1000), accesses[0].CreateAt)
+
+	t.Run("count failed accesses", func(t *testing.T) {
+		for i, remoteAddr := range []string{"10.0.0.1", "10.0.0.1", "10.0.0.2"} {
+			require.NoError(t, store.InsertShareLinkAccess(&model.ShareLinkAccess{
+				ID:          utils.NewID(utils.IDTypeNone),
+				ShareLinkID: link.ID,
+				BoardID:     link.BoardID,
+				RemoteAddr:  remoteAddr,
+				UserAgent:   "test",
+				Failed:      true,
+				CreateAt:    int64(i+4) * 1000,
+			}))
+		}
+
+		count, err := store.CountFailedShareLinkAccessesSince(link.ID, "10.0.0.1", 0)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		count, err = store.CountFailedShareLinkAccessesSince(link.ID, "", 0)
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+
+		count, err = store.CountFailedShareLinkAccessesSince(link.ID, "10.0.0.1", 5000)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		accesses, err := store.GetShareLinkAccesses(link.ID, 0, 1)
+		require.NoError(t, err)
+		require.True(t, accesses[0].Failed)
+	})
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestTimeEntryStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateAndGetTimeEntry", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAndGetTimeEntry(t, store)
+	})
+	t.Run("UpdateTimeEntry", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateTimeEntry(t, store)
+	})
+	t.Run("RunningTimeEntry", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testRunningTimeEntry(t, store)
+	})
+	t.Run("DeleteTimeEntry", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteTimeEntry(t, store)
+	})
+}
+
+func createTestTimeEntry(t *testing.T, store store.Store, boardID, cardID string, startAt int64) *model.TimeEntry {
+	entry := &model.TimeEntry{
+		ID:       utils.NewID(utils.IDTypeNone),
+		CardID:   cardID,
+		BoardID:  boardID,
+		UserID:   testUserID,
+		StartAt:  startAt,
+		Duration: 60000,
+		Note:     "design review",
+	}
+
+	newEntry, err := store.CreateTimeEntry(entry)
+	require.NoError(t, err)
+	return newEntry
+}
+
+func testCreateAndGetTimeEntry(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	cardID := utils.NewID(utils.IDTypeCard)
+	entry := createTestTimeEntry(t, store, boardID, cardID, 2000)
+	earlierEntry := createTestTimeEntry(t, store, boardID, cardID, 1000)
+	createTestTimeEntry(t, store, boardID, utils.NewID(utils.IDTypeCard), 1000)
+	createTestTimeEntry(t, store, utils.NewID(utils.IDTypeBoard), utils.NewID(utils.IDTypeCard), 1000)
+
+	t.Run("get an entry", func(t *testing.T) {
+		got, err := store.GetTimeEntry(entry.ID)
+		require.NoError(t, err)
+		require.Equal(t, cardID, got.CardID)
+		require.Equal(t, boardID, got.BoardID)
+		require.Equal(t, testUserID, got.UserID)
+		require.Equal(t, int64(2000), got.StartAt)
+		require.Equal(t, int64(60000), got.Duration)
+		require.False(t, got.Running)
+		require.Equal(t, "design review", got.Note)
+		require.NotZero(t, got.CreateAt)
+	})
+
+	t.Run("get the entries of a card", func(t *testing.T) {
+		entries, err := store.GetTimeEntriesForCard(cardID)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		require.Equal(t, earlierEntry.ID, entries[0].ID)
+	})
+
+	t.Run("get the entries of a board", func(t *testing.T) {
+		entries, err := store.GetTimeEntriesForBoard(boardID)
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+	})
+
+	t.Run("get a nonexistent entry", func(t *testing.T) {
+		_, err := store.GetTimeEntry(utils.NewID(utils.IDTypeNone))
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testUpdateTimeEntry(t *testing.T, store store.Store) {
+	entry := createTestTimeEntry(t, store, utils.NewID(utils.IDTypeBoard), utils.NewID(utils.IDTypeCard), 1000)
+
+	entry.StartAt = 5000
+	entry.Duration = 30000
+	entry.Note = "pairing"
+
+	updated, err := store.UpdateTimeEntry(entry)
+	require.NoError(t, err)
+	require.Equal(t, int64(5000), updated.StartAt)
+	require.Equal(t, int64(30000), updated.Duration)
+	require.Equal(t, "pairing", updated.Note)
+
+	entry.ID = utils.NewID(utils.IDTypeNone)
+	_, err = store.UpdateTimeEntry(entry)
+	require.True(t, model.IsErrNotFound(err))
+}
+
+func testRunningTimeEntry(t *testing.T, store store.Store) {
+	_, err := store.GetRunningTimeEntry(testUserID)
+	require.True(t, model.IsErrNotFound(err))
+
+	createTestTimeEntry(t, store, utils.NewID(utils.IDTypeBoard), utils.NewID(utils.IDTypeCard), 1000)
+	running, err := store.CreateTimeEntry(&model.TimeEntry{
+		ID:      utils.NewID(utils.IDTypeNone),
+		CardID:  utils.NewID(utils.IDTypeCard),
+		BoardID: utils.NewID(utils.IDTypeBoard),
+		UserID:  testUserID,
+		StartAt: 2000,
+		Running: true,
+	})
+	require.NoError(t, err)
+
+	got, err := store.GetRunningTimeEntry(testUserID)
+	require.NoError(t, err)
+	require.Equal(t, running.ID, got.ID)
+	require.True(t, got.Running)
+
+	got.Running = false
+	got.Duration = 1000
+	_, err = store.UpdateTimeEntry(got)
+	require.NoError(t, err)
+
+	_, err = store.GetRunningTimeEntry(testUserID)
+	require.True(t, model.IsErrNotFound(err))
+}
+
+func testDeleteTimeEntry(t *testing.T, store store.Store) {
+	entry := createTestTimeEntry(t, store, utils.NewID(utils.IDTypeBoard), utils.NewID(utils.IDTypeCard), 1000)
+
+	require.NoError(t, store.DeleteTimeEntry(entry.ID))
+
+	_, err := store.GetTimeEntry(entry.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	err = store.DeleteTimeEntry(entry.ID)
+	require.True(t, model.IsErrNotFound(err))
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const lookupTimeout = 5 * time.Second
+
+// ErrInternalAddress is returned when a webhook URL points to a loopback,
+// private or link-local address that is not allowed by the configuration.
+var ErrInternalAddress = errors.New("webhook host resolves to an internal address")
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, which is not
+// reported as private by net.IP.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+func isInternalIP(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsUnspecified() ||
+		sharedAddressSpace.Contains(ip)
+}
+
+// allowedInternalHosts parses the hosts allowed to resolve to internal
+// addresses, a list of hostnames, IP addresses and CIDR ranges separated by
+// spaces or commas, as in the AllowedUntrustedInternalConnections setting of
+// the Mattermost server.
+func (wh *Client) allowedInternalHosts() ([]string, []*net.IPNet) {
+	var hostnames []string
+	var networks []*net.IPNet
+
+	fields := strings.FieldsFunc(wh.config.AllowedInternalHosts, func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+	for _, field := range fields {
+		if _, network, err := net.ParseCIDR(field); err == nil {
+			networks = append(networks, network)
+			continue
+		}
+		if ip := net.ParseIP(field); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		hostnames = append(hostnames, strings.ToLower(field))
+	}
+	return hostnames, networks
+}
+
+// checkAddresses returns ErrInternalAddress if one of the addresses of a host
+// is internal, unless the host or the address is allowed by the
+// configuration.
+func (wh *Client) checkAddresses(host string, ips []net.IP) error {
+	hostnames, networks := wh.allowedInternalHosts()
+	for _, hostname := range hostnames {
+		if strings.EqualFold(host, hostname) {
+			return nil
+		}
+	}
+
+	for _, ip := range ips {
+		if !isInternalIP(ip) {
+			continue
+		}
+		allowed := false
+		for _, network := range networks {
+			if network.Contains(ip) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("%w: %s", ErrInternalAddress, ip)
+		}
+	}
+	return nil
+}
+
+func lookupIPs(ctx context.Context, host string) ([]net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IP{ip}, nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	ips := make([]net.IP, 0, len(addrs))
+	for _, addr := range addrs {
+		ips = append(ips, addr.IP)
+	}
+	return ips, nil
+}
+
+// CheckURL returns ErrInternalAddress if the host of a webhook URL resolves to
+// an internal address. A host that cannot be resolved yet is accepted, as the
+// addresses are checked again each time a delivery is sent.
+func (wh *Client) CheckURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), lookupTimeout)
+	defer cancel()
+
+	ips, _ := lookupIPs(ctx, u.Hostname())
+	return wh.checkAddresses(u.Hostname(), ips)
+}
+
+// dialContext resolves the host of a delivery and dials the address that was
+// checked, so that the host cannot resolve to another address in between.
+func (wh *Client) dialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	ips, err := lookupIPs(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if err = wh.checkAddresses(host, ips); err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{Timeout: requestTimeout}
+	for _, ip := range ips {
+		var conn net.Conn
+		conn, err = dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+	}
+	if err == nil {
+		err = fmt.Errorf("no address found for host %s", host)
+	}
+	return nil, err
+}
+
+// newDeliveryClient returns the HTTP client of the board webhook deliveries,
+// which only connects to the addresses allowed by checkAddresses, does not
+// use a proxy and does not follow redirects.
+func (wh *Client) newDeliveryClient() *http.Client {
+	return &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			DialContext:         wh.dialContext,
+			TLSHandshakeTimeout: requestTimeout,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -5,9 +5,15 @@ package webhook
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/mattermost/mattermost-plugin-boards/server/model"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/config"
@@ -15,6 +21,21 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
 
+const (
+	HeaderEvent     = "X-Boards-Event"
+	HeaderDelivery  = "X-Boards-Delivery"
+	HeaderSignature = "X-Boards-Signature"
+
+	signaturePrefix = "sha256="
+	requestTimeout  = 10 * time.Second
+
+	// maxResponseSize is the amount of a webhook response that is read
+	// before the connection is closed.
+	maxResponseSize = 64 * 1024
+)
+
+var ErrUnexpectedStatus = errors.New("unexpected response status")
+
 // NotifyUpdate calls webhooks.
 func (wh *Client) NotifyUpdate(block *model.Block) {
 	if len(wh.config.WebhookUpdate) < 1 {
@@ -23,27 +44,79 @@ func (wh *Client) NotifyUpdate(block *model.Block) {
 
 	json, err := json.Marshal(block)
 	if err != nil {
-		wh.logger.Fatal("NotifyUpdate: json.Marshal", mlog.Err(err))
+		wh.logger.Error("NotifyUpdate: json.Marshal", mlog.Err(err))
+		return
 	}
 	for _, url := range wh.config.WebhookUpdate {
-		resp, _ := http.Post(url, "application/json", bytes.NewBuffer(json)) //nolint:gosec
-		_, _ = io.ReadAll(resp.Body)
+		resp, err := wh.httpClient.Post(url, "application/json", bytes.NewBuffer(json))
+		if err != nil {
+			wh.logger.Warn("webhook.NotifyUpdate", mlog.String("url", url), mlog.Err(err))
+			continue
+		}
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize))
 		resp.Body.Close()
 
-		wh.logger.Debug("webhook.NotifyUpdate", mlog.String("url", url))
+		wh.logger.Debug("webhook.NotifyUpdate", mlog.String("url", url), mlog.Int("status", resp.StatusCode))
 	}
 }
 
+// Deliver posts the payload of a webhook delivery, signed with the secret of
+// the webhook, and returns the status code of the response.
+func (wh *Client) Deliver(webhook *model.BoardWebhook, delivery *model.WebhookDelivery) (int, error) {
+	payload := []byte(delivery.Payload)
+
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, payload))
+
+	resp, err := wh.deliveryClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize))
+
+	wh.logger.Debug("webhook.Deliver",
+		mlog.String("webhook_id", webhook.ID),
+		mlog.String("delivery_id", delivery.ID),
+		mlog.Int("status", resp.StatusCode),
+	)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("%w: %d", ErrUnexpectedStatus, resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature of a payload, sent in the X-Boards-Signature
+// header. It is the hex encoded HMAC-SHA256 of the payload keyed with the
+// secret of the webhook, prefixed with "sha256=".
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
 // Client is a webhook client.
 type Client struct {
-	config *config.Configuration
-	logger mlog.LoggerIFace
+	config         *config.Configuration
+	logger         mlog.LoggerIFace
+	httpClient     *http.Client
+	deliveryClient *http.Client
 }
 
 // NewClient creates a new Client.
 func NewClient(config *config.Configuration, logger mlog.LoggerIFace) *Client {
-	return &Client{
-		config: config,
-		logger: logger,
+	client := &Client{
+		config:     config,
+		logger:     logger,
+		httpClient: &http.Client{Timeout: requestTimeout},
 	}
+	client.deliveryClient = client.newDeliveryClient()
+	return client
 }
@@ -4,6 +4,10 @@
 package webhook
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -11,6 +15,7 @@ import (
 	"github.com/mattermost/mattermost-plugin-boards/server/model"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/config"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
@@ -40,3 +45,148 @@ func TestClientUpdateNotify(t *testing.T) {
 		t.Error("webhook url not be notified")
 	}
 }
+
+func TestClientDeliver(t *testing.T) {
+	logger, _ := mlog.NewLogger()
+	defer func() {
+		err := logger.Shutdown()
+		assert.NoError(t, err)
+	}()
+
+	client := NewClient(&config.Configuration{AllowedInternalHosts: "127.0.0.0/8"}, logger)
+
+	webhook := &model.BoardWebhook{ID: "webhook-id", Secret: "secret"}
+	delivery := &model.WebhookDelivery{
+		ID:      "delivery-id",
+		Event:   model.WebhookEventCardCreated,
+		Payload: `{"event":"card.created"}`,
+	}
+
+	t.Run("signed delivery", func(t *testing.T) {
+		var received *http.Request
+		var body []byte
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer ts.Close()
+		webhook.URL = ts.URL
+
+		status, err := client.Deliver(webhook, delivery)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, status)
+
+		require.Equal(t, delivery.Payload, string(body))
+		require.Equal(t, "application/json", received.Header.Get("Content-Type"))
+		require.Equal(t, model.WebhookEventCardCreated, received.Header.Get(HeaderEvent))
+		require.Equal(t, "delivery-id", received.Header.Get(HeaderDelivery))
+
+		mac := hmac.New(sha256.New, []byte("secret"))
+		_, _ = mac.Write(body)
+		require.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), received.Header.Get(HeaderSignature))
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer ts.Close()
+		webhook.URL = ts.URL
+
+		status, err := client.Deliver(webhook, delivery)
+		require.ErrorIs(t, err, ErrUnexpectedStatus)
+		require.Equal(t, http.StatusInternalServerError, status)
+	})
+
+	t.Run("redirects are not followed", func(t *testing.T) {
+		var redirected bool
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			redirected = true
+		}))
+		defer target.Close()
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+		}))
+		defer ts.Close()
+		webhook.URL = ts.URL
+
+		status, err := client.Deliver(webhook, delivery)
+		require.ErrorIs(t, err, ErrUnexpectedStatus)
+		require.Equal(t, http.StatusTemporaryRedirect, status)
+		require.False(t, redirected)
+	})
+
+	t.Run("unreachable receiver", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		webhook.URL = ts.URL
+		ts.Close()
+
+		status, err := client.Deliver(webhook, delivery)
+		require.Error(t, err)
+		require.Equal(t, 0, status)
+	})
+}
+
+func TestClientUpdateNotifyUnreachable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.Close()
+
+	logger, _ := mlog.NewLogger()
+	defer func() {
+		err := logger.Shutdown()
+		assert.NoError(t, err)
+	}()
+
+	client := NewClient(&config.Configuration{WebhookUpdate: []string{ts.URL}}, logger)
+
+	require.NotPanics(t, func() {
+		client.NotifyUpdate(&model.Block{})
+	})
+}
+
+func TestClientInternalAddresses(t *testing.T) {
+	logger, _ := mlog.NewLogger()
+	defer func() {
+		err := logger.Shutdown()
+		assert.NoError(t, err)
+	}()
+
+	cfg := &config.Configuration{}
+	client := NewClient(cfg, logger)
+
+	t.Run("check url", func(t *testing.T) {
+		for _, url := range []string{
+			"http://127.0.0.1/hook",
+			"http://localhost:8065/hook",
+			"http://10.0.0.1/hook",
+			"http://169.254.169.254/latest/meta-data",
+			"http://[::1]/hook",
+		} {
+			require.ErrorIs(t, client.CheckURL(url), ErrInternalAddress, url)
+		}
+		require.NoError(t, client.CheckURL("https://8.8.8.8/hook"))
+	})
+
+	t.Run("delivery to an internal address", func(t *testing.T) {
+		var isDelivered bool
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			isDelivered = true
+		}))
+		defer ts.Close()
+
+		webhook := &model.BoardWebhook{ID: "webhook-id", URL: ts.URL, Secret: "secret"}
+		_, err := client.Deliver(webhook, &model.WebhookDelivery{ID: "delivery-id", Payload: "{}"})
+		require.ErrorIs(t, err, ErrInternalAddress)
+		require.False(t, isDelivered)
+	})
+
+	t.Run("allowed internal hosts", func(t *testing.T) {
+		cfg.AllowedInternalHosts = "localhost, 10.0.0.0/8"
+		defer func() { cfg.AllowedInternalHosts = "" }()
+
+		require.NoError(t, client.CheckURL("http://localhost:8065/hook"))
+		require.NoError(t, client.CheckURL("http://10.1.2.3/hook"))
+		require.ErrorIs(t, client.CheckURL("http://192.168.1.1/hook"), ErrInternalAddress)
+	})
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnName(t *testing.T) {
+	require.Equal(t, "A", ColumnName(0))
+	require.Equal(t, "Z", ColumnName(25))
+	require.Equal(t, "AA", ColumnName(26))
+	require.Equal(t, "AZ", ColumnName(51))
+	require.Equal(t, "BA", ColumnName(52))
+	require.Equal(t, "ZZ", ColumnName(701))
+	require.Equal(t, "AAA", ColumnName(702))
+}
+
+func TestSheetName(t *testing.T) {
+	require.Equal(t, "Roadmap 2024", sheetName(" Roadmap [2024] "))
+	require.Equal(t, defaultSheetName, sheetName("???"))
+	require.Len(t, []rune(sheetName("a very long board title that does not fit")), maxSheetNameLength)
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, Sheet{
+		Name: "Tasks & bugs",
+		Rows: [][]string{
+			{"Title", "Estimate"},
+			{"Fix <login>", "3.5"},
+			{"Write docs", "unknown"},
+		},
+		NumericColumns: map[int]bool{1: true},
+	})
+	require.NoError(t, err)
+
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	files := map[string]string{}
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(content)
+	}
+
+	require.Contains(t, files, "[Content_Types].xml")
+	require.Contains(t, files, "_rels/.rels")
+	require.Contains(t, files, "xl/_rels/workbook.xml.rels")
+	require.Contains(t, files["xl/workbook.xml"], `name="Tasks &amp; bugs"`)
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	require.Contains(t, sheet, `<c r="B1" t="inlineStr"><is><t xml:space="preserve">Estimate</t></is></c>`)
+	require.Contains(t, sheet, `<c r="A2" t="inlineStr"><is><t xml:space="preserve">Fix &lt;login&gt;</t></is></c>`)
+	require.Contains(t, sheet, `<c r="B2"><v>3.5</v></c>`)
+	require.Contains(t, sheet, `<c r="B3" t="inlineStr"><is><t xml:space="preserve">unknown</t></is></c>`)
+}